@@ -3,12 +3,14 @@ package agentkapp
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ash2k/stager"
@@ -20,13 +22,18 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
 	"github.com/pluralsh/kubernetes-agent/pkg/api"
 	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/local"
 	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/rpc"
+	agent_control_agent "github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/agent"
 	agent_registrar_agent "github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/agent"
+	gitops_agent "github.com/pluralsh/kubernetes-agent/pkg/module/gitops/agent"
 	kubernetes_api_agent "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/agent"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
 	observability_agent "github.com/pluralsh/kubernetes-agent/pkg/module/observability/agent"
 	reverse_tunnel_agent "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/agent"
+	service_proxy_agent "github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/agent"
+	tcp_forward_agent "github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/agent"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
 	grpctool2 "github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
@@ -34,6 +41,7 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/mathz"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/metric"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/tlstool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/wstunnel"
 
@@ -85,6 +93,8 @@ const (
 	getConfigurationResetDuration = 10 * time.Minute
 	getConfigurationBackoffFactor = 2.0
 	getConfigurationJitter        = 1.0
+
+	tokenFileCheckPeriod = 10 * time.Second
 )
 
 type App struct {
@@ -106,8 +116,14 @@ type App struct {
 	ObservabilityCertFile      string
 	ObservabilityKeyFile       string
 	TokenFile                  string
-	AgentToken                 api.AgentToken
-	K8sClientGetter            genericclioptions.RESTClientGetter
+	// AgentConfigName is the name of the AgentConfig object in the agent's namespace. Not read if empty.
+	AgentConfigName string
+	// GitopsAuth holds the credentials for the repositories of GitOps manifest projects.
+	GitopsAuth      gitops_agent.GitAuth
+	AgentToken      api.AgentToken
+	K8sClientGetter genericclioptions.RESTClientGetter
+	// rotatedToken is the token that has been switched to after startup. nil if it hasn't been rotated.
+	rotatedToken atomic.Pointer[api.AgentToken]
 }
 
 func (a *App) Run(ctx context.Context) (retErr error) {
@@ -179,12 +195,18 @@ func (a *App) Run(ctx context.Context) (retErr error) {
 		eventRecorder:      eventRecorder,
 	})
 
+	// Dispatched to replace reverse tunnels, e.g. once the token has been rotated.
+	reconnect := &syncz.Subscriptions[struct{}]{}
+
 	// Construct agent modules
-	beforeServersModules, afterServersModules, err := a.constructModules(internalSrv.server, kasConn, internalSrv.conn, k8sFactory, lr, reg, podId)
+	beforeServersModules, afterServersModules, err := a.constructModules(internalSrv.server, kasConn, internalSrv.conn, k8sFactory, eventRecorder, lr, reg, podId, reconnect)
+	if err != nil {
+		return err
+	}
+	runner, err := a.newModuleRunner(kasConn, k8sFactory, eventRecorder)
 	if err != nil {
 		return err
 	}
-	runner := a.newModuleRunner(kasConn)
 	beforeServersModulesRun := runner.RegisterModules(beforeServersModules)
 	afterServersModulesRun := runner.RegisterModules(afterServersModules)
 
@@ -202,6 +224,13 @@ func (a *App) Run(ctx context.Context) (retErr error) {
 				lr.Run(ctx)
 				return nil
 			})
+			if a.TokenFile != "" {
+				stage.Go(func(ctx context.Context) error {
+					// Start token file watcher.
+					a.newTokenFileWatcher(reconnect).Run(ctx)
+					return nil
+				})
+			}
 		},
 		func(stage stager.Stage) {
 			// Start modules.
@@ -222,7 +251,21 @@ func (a *App) Run(ctx context.Context) (retErr error) {
 	)
 }
 
-func (a *App) newModuleRunner(kasConn *grpc.ClientConn) *moduleRunner {
+func (a *App) newModuleRunner(kasConn *grpc.ClientConn, k8sFactory util.Factory, eventRecorder record.EventRecorder) (*moduleRunner, error) {
+	var localConfigWatcher local.WatcherInterface
+	if a.AgentConfigName != "" {
+		dynamicClient, err := k8sFactory.DynamicClient()
+		if err != nil {
+			return nil, err
+		}
+		localConfigWatcher = &local.Watcher{
+			Log:           a.Log,
+			Client:        dynamicClient,
+			EventRecorder: eventRecorder,
+			Namespace:     a.AgentMeta.PodNamespace,
+			Name:          a.AgentConfigName,
+		}
+	}
 	return &moduleRunner{
 		log: a.Log,
 		configurationWatcher: &rpc2.ConfigurationWatcher{
@@ -248,11 +291,13 @@ func (a *App) newModuleRunner(kasConn *grpc.ClientConn) *moduleRunner {
 				return a.GitLabExternalUrl.set(*u)
 			},
 		},
-	}
+		localConfigWatcher: localConfigWatcher,
+	}, nil
 }
 
 func (a *App) constructModules(internalServer *grpc.Server, kasConn, internalServerConn grpc.ClientConnInterface,
-	k8sFactory util.Factory, lr *leaderRunner, reg *prometheus.Registry, podId int64) ([]modagent.Module, []modagent.Module, error) {
+	k8sFactory util.Factory, eventRecorder record.EventRecorder, lr *leaderRunner, reg *prometheus.Registry, podId int64,
+	reconnect *syncz.Subscriptions[struct{}]) ([]modagent.Module, []modagent.Module, error) {
 	factories := []modagent.Factory{
 		&observability_agent.Factory{
 			LogLevel:            a.LogLevel,
@@ -267,15 +312,31 @@ func (a *App) constructModules(internalServer *grpc.Server, kasConn, internalSer
 		},
 		&reverse_tunnel_agent.Factory{
 			InternalServerConn: internalServerConn,
+			Reconnect:          reconnect,
 		},
 		&kubernetes_api_agent.Factory{},
+		&service_proxy_agent.Factory{},
+		&tcp_forward_agent.Factory{},
 		&agent_registrar_agent.Factory{
 			PodId: podId,
 		},
+		&agent_control_agent.Factory{
+			LogLevel:     a.LogLevel,
+			GrpcLogLevel: a.GrpcLogLevel,
+			Reconnect:    reconnect,
+			RotateToken:  a.rotateToken,
+		},
+		&gitops_agent.Factory{
+			GitAuth: a.GitopsAuth,
+		},
+		&gitops_agent.PreviewFactory{
+			GitAuth: a.GitopsAuth,
+		},
 	}
 	var beforeServersModules, afterServersModules []modagent.Module
 	for _, f := range factories {
 		moduleName := f.Name()
+		a.AgentMeta.Features = append(a.AgentMeta.Features, moduleName)
 		module, err := f.New(&modagent.Config{
 			Log:       a.Log.With(logz2.ModuleName(moduleName)),
 			AgentMeta: a.AgentMeta,
@@ -285,6 +346,7 @@ func (a *App) constructModules(internalServer *grpc.Server, kasConn, internalSer
 				gitLabExternalUrl: a.GitLabExternalUrl,
 			},
 			K8sUtilFactory:     k8sFactory,
+			EventRecorder:      eventRecorder,
 			KasConn:            kasConn,
 			Server:             internalServer,
 			AgentName:          agentName,
@@ -416,7 +478,7 @@ func (a *App) constructKasConnection(ctx context.Context, tp trace.TracerProvide
 	if !secure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
-	opts = append(opts, grpc.WithPerRPCCredentials(grpctool2.NewTokenCredentials(a.AgentToken, !secure)))
+	opts = append(opts, grpc.WithPerRPCCredentials(grpctool2.NewTokenSourceCredentials(a.currentToken, !secure)))
 	conn, err := grpc.NewClient(addressToDial, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("gRPC.dial: %w", err)
@@ -424,6 +486,58 @@ func (a *App) constructKasConnection(ctx context.Context, tp trace.TracerProvide
 	return conn, nil
 }
 
+// currentToken returns the token to authenticate to kas with.
+func (a *App) currentToken() api.AgentToken {
+	if t := a.rotatedToken.Load(); t != nil {
+		return *t
+	}
+	return a.AgentToken
+}
+
+// rotateToken switches to the given token or, if it's empty, reads the token from the token file again.
+// Subsequent requests to kas use the new token.
+func (a *App) rotateToken(token api.AgentToken) error {
+	if token == "" {
+		if a.TokenFile == "" {
+			return errors.New("token file is not used, the new token must be provided")
+		}
+		var err error
+		token, err = readTokenFile(a.TokenFile)
+		if err != nil {
+			return err
+		}
+	}
+	a.rotatedToken.Store(&token)
+	a.Log.Info("Agent token has been rotated")
+	return nil
+}
+
+func (a *App) newTokenFileWatcher(reconnect *syncz.Subscriptions[struct{}]) *tokenFileWatcher {
+	return &tokenFileWatcher{
+		log:    a.Log,
+		file:   a.TokenFile,
+		period: tokenFileCheckPeriod,
+		token:  a.AgentToken,
+		onChange: func(ctx context.Context, token api.AgentToken) {
+			_ = a.rotateToken(token) // cannot fail with a non-empty token
+			// Existing tunnels were authenticated with the old token, replace them.
+			reconnect.Dispatch(ctx, struct{}{})
+		},
+	}
+}
+
+func readTokenFile(file string) (api.AgentToken, error) {
+	tokenData, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("token file: %w", err)
+	}
+	tokenData = bytes.TrimSuffix(tokenData, []byte{'\n'})
+	if len(tokenData) == 0 {
+		return "", errors.New("token file: file is empty")
+	}
+	return api.AgentToken(tokenData), nil
+}
+
 func NewCommand() *cobra.Command {
 	kubeConfigFlags := genericclioptions.NewConfigFlags(true)
 	a := App{
@@ -456,12 +570,11 @@ func NewCommand() *cobra.Command {
 			case a.TokenFile != "" && ok:
 				return fmt.Errorf("unable to use both token file and %s environment variable to set the agent token", envVarAgentkToken)
 			case a.TokenFile != "":
-				tokenData, err := os.ReadFile(a.TokenFile)
+				token, err := readTokenFile(a.TokenFile)
 				if err != nil {
-					return fmt.Errorf("token file: %w", err)
+					return err
 				}
-				tokenData = bytes.TrimSuffix(tokenData, []byte{'\n'})
-				a.AgentToken = api.AgentToken(tokenData)
+				a.AgentToken = token
 			case ok:
 				a.AgentToken = api.AgentToken(tokenFromEnv)
 				err := os.Unsetenv(envVarAgentkToken)
@@ -505,6 +618,7 @@ func NewCommand() *cobra.Command {
 	f := c.Flags()
 	f.StringVar(&a.KasAddress, "kas-address", "", "GitLab Kubernetes Agent Server address")
 	f.StringVar(&a.TokenFile, "token-file", "", "File with access token")
+	f.StringVar(&a.AgentConfigName, "agent-config", "", "Name of the AgentConfig object in the agent's namespace to read configuration from. Disabled if empty")
 
 	f.StringVar(&a.KasCACertFile, "ca-cert-file", "", "File with X.509 certificate authority certificate in PEM format. Used for verifying cert of agent server")
 	f.StringArrayVar(&a.KasHeaders, "kas-header", []string{}, "HTTP headers to set when connecting to the agent server")
@@ -516,6 +630,11 @@ func NewCommand() *cobra.Command {
 	f.StringVar(&a.ObservabilityCertFile, "observability-cert-file", "", "File with X.509 certificate in PEM format for observability endpoint TLS")
 	f.StringVar(&a.ObservabilityKeyFile, "observability-key-file", "", "File with X.509 key in PEM format for observability endpoint TLS")
 
+	f.StringVar(&a.GitopsAuth.SshKeyFile, "gitops-ssh-key-file", "", "File with SSH private key to access GitOps repositories with")
+	f.StringVar(&a.GitopsAuth.SshKnownHostsFile, "gitops-ssh-known-hosts-file", "", "File with SSH known hosts to verify GitOps repository hosts with. The system known_hosts files are used if it is not provided")
+	f.StringVar(&a.GitopsAuth.HttpUsername, "gitops-http-username", "", "Username to access HTTP(S) GitOps repositories with")
+	f.StringVar(&a.GitopsAuth.HttpPasswordFile, "gitops-http-password-file", "", "File with password or token to access HTTP(S) GitOps repositories with")
+
 	kubeConfigFlags.AddFlags(f)
 	cobra.CheckErr(c.MarkFlagRequired("kas-address"))
 	return c
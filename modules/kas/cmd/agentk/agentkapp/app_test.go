@@ -2,10 +2,15 @@ package agentkapp
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
 )
 
 func TestParseHeaders(t *testing.T) {
@@ -24,3 +29,35 @@ func TestParseHeaders(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, h)
 }
+
+func TestRotateToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	a := App{
+		Log:        zaptest.NewLogger(t),
+		TokenFile:  tokenFile,
+		AgentToken: "old",
+	}
+	assert.Equal(t, api.AgentToken("old"), a.currentToken())
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("new\n"), 0o600))
+	require.NoError(t, a.rotateToken(""))
+	assert.Equal(t, api.AgentToken("new"), a.currentToken())
+
+	// An empty file is likely a Secret that is being updated, keep using the current token.
+	require.NoError(t, os.WriteFile(tokenFile, nil, 0o600))
+	require.EqualError(t, a.rotateToken(""), "token file: file is empty")
+	assert.Equal(t, api.AgentToken("new"), a.currentToken())
+
+	require.NoError(t, a.rotateToken("provided"))
+	assert.Equal(t, api.AgentToken("provided"), a.currentToken())
+}
+
+func TestRotateToken_WithoutTokenFile(t *testing.T) {
+	a := App{
+		Log:        zaptest.NewLogger(t),
+		AgentToken: "old",
+	}
+	require.EqualError(t, a.rotateToken(""), "token file is not used, the new token must be provided")
+	require.NoError(t, a.rotateToken("new"))
+	assert.Equal(t, api.AgentToken("new"), a.currentToken())
+}
@@ -3,10 +3,12 @@ package agentkapp
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/ash2k/stager"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/local"
 	agent_configuration_rpc "github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
@@ -52,7 +54,19 @@ func (h moduleHolder) runPipe(ctx context.Context) error {
 type moduleRunner struct {
 	log                  *zap.Logger
 	configurationWatcher agent_configuration_rpc.ConfigurationWatcherInterface
-	holders              []moduleHolder
+	// localConfigWatcher is nil if configuration is only received from kas.
+	localConfigWatcher local.WatcherInterface
+	holders            []moduleHolder
+
+	mu sync.Mutex // protects the fields below
+	// serverData is the last configuration received from kas. nil until received.
+	serverData *agent_configuration_rpc.ConfigurationData
+	// localConfig is the configuration from the AgentConfig object. nil if there is no object.
+	localConfig *local.Configuration
+	// localSynced is true once the state of the AgentConfig object is known.
+	localSynced bool
+	// reportedGeneration is the generation of the AgentConfig object the result has been reported for. 0 if none.
+	reportedGeneration int64
 }
 
 // RegisterModules registers modules with the runner. It returns a function to run modules.
@@ -84,18 +98,67 @@ func (r *moduleRunner) RegisterModules(modules []modagent.Module) func(context.C
 }
 
 func (r *moduleRunner) RunConfigurationRefresh(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	if r.localConfigWatcher != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.localConfigWatcher.Watch(ctx, func(ctx context.Context, config *local.Configuration) {
+				r.mu.Lock()
+				r.localConfig = config
+				r.localSynced = true
+				if config == nil {
+					r.reportedGeneration = 0 // a new object starts from the first generation
+				}
+				report := r.applyCurrentConfiguration()
+				r.mu.Unlock()
+				report(ctx)
+			})
+		}()
+	}
 	r.configurationWatcher.Watch(ctx, func(ctx context.Context, data agent_configuration_rpc.ConfigurationData) {
-		err := r.applyConfiguration(data.CommitId, data.Config)
-		if err != nil {
-			if !errz.ContextDone(err) {
-				r.log.Error("Failed to apply configuration", logz2.CommitId(data.CommitId), logz2.Error(err))
-			}
-			return
-		}
+		r.mu.Lock()
+		r.serverData = &data
+		report := r.applyCurrentConfiguration()
+		r.mu.Unlock()
+		report(ctx)
 	})
 	return nil
 }
 
+// applyCurrentConfiguration applies the configuration from kas, merged with the configuration from the AgentConfig
+// object if there is one. Nothing is applied until both sources are known.
+// Must be called with mu held. Returns a function that reports the result to the AgentConfig object. It makes API
+// calls so it must be called after mu has been released.
+// The result is only reported once per generation of the object.
+func (r *moduleRunner) applyCurrentConfiguration() func(context.Context) {
+	if r.serverData == nil || (r.localConfigWatcher != nil && !r.localSynced) {
+		return noReport
+	}
+	config := r.serverData.Config
+	if r.localConfig != nil {
+		config = local.Merge(config, r.localConfig.File)
+	}
+	err := r.applyConfiguration(r.serverData.CommitId, config)
+	if err != nil {
+		if errz.ContextDone(err) {
+			return noReport
+		}
+		r.log.Error("Failed to apply configuration", logz2.CommitId(r.serverData.CommitId), logz2.Error(err))
+	}
+	if r.localConfig == nil || r.localConfig.Generation == r.reportedGeneration {
+		return noReport
+	}
+	r.reportedGeneration = r.localConfig.Generation
+	localConfig := r.localConfig
+	return func(ctx context.Context) {
+		r.localConfigWatcher.ReportApplied(ctx, localConfig, err)
+	}
+}
+
+func noReport(context.Context) {}
+
 func (r *moduleRunner) applyConfiguration(commitId string, config *agentcfg.AgentConfiguration) error {
 	r.log.Debug("Applying configuration", logz2.CommitId(commitId), logz2.ProtoJsonValue(logz2.AgentConfig, config))
 	// Default and validate before setting for use.
@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/local"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modagent"
@@ -152,3 +153,231 @@ func TestConfigurationIsSquashed(t *testing.T) {
 	err := g.Wait()
 	require.NoError(t, err)
 }
+
+func TestConfigurationIsMergedWithLocalConfiguration(t *testing.T) {
+	serverCfg := &agentcfg.AgentConfiguration{
+		AgentId: 123,
+		Observability: &agentcfg.ObservabilityCF{
+			Logging: &agentcfg.LoggingCF{Level: agentcfg.LogLevelEnum_debug},
+		},
+	}
+	localCfg := &local.Configuration{
+		File: &agentcfg.ConfigurationFile{
+			Gitops: &agentcfg.GitopsCF{
+				ManifestProjects: []*agentcfg.ManifestProjectCF{
+					{
+						Id: &projectId,
+					},
+				},
+			},
+		},
+		Generation: 2,
+	}
+	expected := &agentcfg.AgentConfiguration{
+		AgentId:       serverCfg.AgentId,
+		Observability: serverCfg.Observability,
+		Gitops:        localCfg.File.Gitops,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	watcher := mock_rpc.NewMockConfigurationWatcherInterface(ctrl)
+	m := mock_modagent.NewMockModule(ctrl)
+	localSynced := make(chan struct{})
+	localWatcher := &fakeLocalConfigWatcher{
+		watch: func(ctx context.Context, cb local.Callback) {
+			cb(ctx, localCfg)
+			close(localSynced)
+			<-ctx.Done()
+		},
+	}
+	m.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
+			c := <-cfg
+			cancel()
+			assert.Empty(t, cmp.Diff(expected, c, protocmp.Transform()))
+			<-ctx.Done()
+			return nil
+		})
+	m.EXPECT().
+		DefaultAndValidateConfiguration(gomock.Any())
+	watcher.EXPECT().
+		Watch(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, callback rpc.ConfigurationCallback) {
+			<-localSynced
+			callback(ctx, rpc.ConfigurationData{CommitId: revision1, Config: serverCfg})
+			<-ctx.Done()
+		})
+	a := moduleRunner{
+		log:                  zaptest.NewLogger(t),
+		configurationWatcher: watcher,
+		localConfigWatcher:   localWatcher,
+	}
+	run := a.RegisterModules([]modagent.Module{m})
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return run(ctx)
+	})
+	g.Go(func() error {
+		return a.RunConfigurationRefresh(ctx)
+	})
+	err := g.Wait()
+	require.NoError(t, err)
+	require.Len(t, localWatcher.reported, 1)
+	assert.Same(t, localCfg, localWatcher.reported[0].config)
+	assert.NoError(t, localWatcher.reported[0].err)
+}
+
+func TestConfigurationIsNotAppliedUntilLocalConfigurationIsKnown(t *testing.T) {
+	serverCfg := &agentcfg.AgentConfiguration{
+		AgentId: 123,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	watcher := mock_rpc.NewMockConfigurationWatcherInterface(ctrl)
+	m := mock_modagent.NewMockModule(ctrl)
+	serverReceived := make(chan struct{})
+	localWatcher := &fakeLocalConfigWatcher{
+		watch: func(ctx context.Context, cb local.Callback) {
+			<-serverReceived
+			cb(ctx, nil) // no AgentConfig object
+			<-ctx.Done()
+		},
+	}
+	m.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
+			c := <-cfg
+			cancel()
+			assert.Empty(t, cmp.Diff(serverCfg, c, protocmp.Transform()))
+			<-ctx.Done()
+			return nil
+		})
+	m.EXPECT().
+		DefaultAndValidateConfiguration(serverCfg)
+	watcher.EXPECT().
+		Watch(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, callback rpc.ConfigurationCallback) {
+			callback(ctx, rpc.ConfigurationData{CommitId: revision1, Config: serverCfg})
+			close(serverReceived)
+			<-ctx.Done()
+		})
+	a := moduleRunner{
+		log:                  zaptest.NewLogger(t),
+		configurationWatcher: watcher,
+		localConfigWatcher:   localWatcher,
+	}
+	run := a.RegisterModules([]modagent.Module{m})
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return run(ctx)
+	})
+	g.Go(func() error {
+		return a.RunConfigurationRefresh(ctx)
+	})
+	err := g.Wait()
+	require.NoError(t, err)
+	assert.Empty(t, localWatcher.reported)
+}
+
+func TestLocalConfigurationResultIsReportedOncePerGeneration(t *testing.T) {
+	serverCfg1 := &agentcfg.AgentConfiguration{
+		AgentId: 123,
+	}
+	serverCfg2 := &agentcfg.AgentConfiguration{
+		AgentId: 123,
+		Observability: &agentcfg.ObservabilityCF{
+			Logging: &agentcfg.LoggingCF{Level: agentcfg.LogLevelEnum_debug},
+		},
+	}
+	localCfg := &local.Configuration{
+		File:       &agentcfg.ConfigurationFile{},
+		Generation: 2,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	watcher := mock_rpc.NewMockConfigurationWatcherInterface(ctrl)
+	m := mock_modagent.NewMockModule(ctrl)
+	localSynced := make(chan struct{})
+	firstReceived := make(chan struct{})
+	var a *moduleRunner
+	localWatcher := &fakeLocalConfigWatcher{
+		watch: func(ctx context.Context, cb local.Callback) {
+			cb(ctx, localCfg)
+			close(localSynced)
+			<-ctx.Done()
+		},
+		onReport: func() {
+			// The result is reported without holding the lock.
+			if assert.True(t, a.mu.TryLock()) {
+				a.mu.Unlock()
+			}
+		},
+	}
+	m.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
+			<-cfg
+			close(firstReceived)
+			<-cfg
+			cancel()
+			<-ctx.Done()
+			return nil
+		})
+	m.EXPECT().
+		DefaultAndValidateConfiguration(gomock.Any()).
+		Times(2)
+	watcher.EXPECT().
+		Watch(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, callback rpc.ConfigurationCallback) {
+			<-localSynced
+			callback(ctx, rpc.ConfigurationData{CommitId: revision1, Config: serverCfg1})
+			<-firstReceived
+			callback(ctx, rpc.ConfigurationData{CommitId: revision2, Config: serverCfg2})
+			<-ctx.Done()
+		})
+	a = &moduleRunner{
+		log:                  zaptest.NewLogger(t),
+		configurationWatcher: watcher,
+		localConfigWatcher:   localWatcher,
+	}
+	run := a.RegisterModules([]modagent.Module{m})
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return run(ctx)
+	})
+	g.Go(func() error {
+		return a.RunConfigurationRefresh(ctx)
+	})
+	err := g.Wait()
+	require.NoError(t, err)
+	require.Len(t, localWatcher.reported, 1)
+	assert.Same(t, localCfg, localWatcher.reported[0].config)
+	assert.NoError(t, localWatcher.reported[0].err)
+}
+
+type reportedLocalConfig struct {
+	config *local.Configuration
+	err    error
+}
+
+type fakeLocalConfigWatcher struct {
+	watch func(context.Context, local.Callback)
+	// onReport is called on every report if set.
+	onReport func()
+	reported []reportedLocalConfig
+}
+
+func (w *fakeLocalConfigWatcher) Watch(ctx context.Context, cb local.Callback) {
+	w.watch(ctx, cb)
+}
+
+func (w *fakeLocalConfigWatcher) ReportApplied(ctx context.Context, config *local.Configuration, err error) {
+	if w.onReport != nil {
+		w.onReport()
+	}
+	w.reported = append(w.reported, reportedLocalConfig{config: config, err: err})
+}
@@ -0,0 +1,52 @@
+package agentkapp
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+// tokenFileWatcher rotates the agent token when the content of the token file changes,
+// e.g. when the Secret it is projected from is updated.
+// The file is polled because Kubernetes updates Secret volumes by swapping a symlink,
+// which file system notifications for the file itself don't report.
+type tokenFileWatcher struct {
+	log    *zap.Logger
+	file   string
+	period time.Duration
+	// token is the token that has been read from the file last.
+	token    api.AgentToken
+	onChange func(ctx context.Context, token api.AgentToken)
+}
+
+func (w *tokenFileWatcher) Run(ctx context.Context) {
+	t := time.NewTicker(w.period)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			w.check(ctx)
+		}
+	}
+}
+
+func (w *tokenFileWatcher) check(ctx context.Context) {
+	token, err := readTokenFile(w.file)
+	if err != nil {
+		// Keep using the current token, the file might be in the middle of an update.
+		w.log.Warn("Failed to read token file", logz.Error(err))
+		return
+	}
+	if token == w.token {
+		return
+	}
+	w.token = token
+	w.log.Info("Token file has changed, rotating agent token")
+	w.onChange(ctx, token)
+}
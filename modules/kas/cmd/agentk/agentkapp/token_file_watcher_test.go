@@ -0,0 +1,44 @@
+package agentkapp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+)
+
+func TestTokenFileWatcher_RotatesOnChange(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	var rotations []api.AgentToken
+	w := &tokenFileWatcher{
+		log:   zaptest.NewLogger(t),
+		file:  tokenFile,
+		token: "old",
+		onChange: func(ctx context.Context, token api.AgentToken) {
+			rotations = append(rotations, token)
+		},
+	}
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("old\n"), 0o600))
+	w.check(context.Background())
+	assert.Empty(t, rotations)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("new\n"), 0o600))
+	w.check(context.Background())
+	w.check(context.Background())
+	assert.Equal(t, []api.AgentToken{"new"}, rotations)
+
+	// The file is being updated, the token stays the same.
+	require.NoError(t, os.WriteFile(tokenFile, nil, 0o600))
+	w.check(context.Background())
+	require.NoError(t, os.Remove(tokenFile))
+	w.check(context.Background())
+	assert.Equal(t, []api.AgentToken{"new"}, rotations)
+	assert.Equal(t, api.AgentToken("new"), w.token)
+}
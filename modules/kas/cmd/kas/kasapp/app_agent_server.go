@@ -4,14 +4,18 @@ import (
 	"context"
 	"crypto/tls"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/ash2k/stager"
 	grpc_validator "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar"
+	agent_registrar_rpc "github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpc"
 	modserver2 "github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/observability"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/rpc"
 	tunnel2 "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel"
 	grpctool2 "github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
@@ -23,7 +27,6 @@ import (
 
 	"github.com/coder/websocket"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/redis/rueidis"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	otelmetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
@@ -38,6 +41,7 @@ import (
 const (
 	defaultMaxMessageSize                 = 10 * 1024 * 1024
 	agentConnectionRateExceededMetricName = "agent_server_rate_exceeded_total"
+	agentVersionSkewRefusedMetricName     = "agent_server_version_skew_refused_total"
 )
 
 type agentServer struct {
@@ -48,13 +52,14 @@ type agentServer struct {
 	tunnelRegistry *tunnel2.Registry
 	auxCancel      context.CancelFunc
 	ready          func()
+	drainOnce      sync.Once
 }
 
 func newAgentServer(log *zap.Logger, cfg *kascfg.ConfigurationFile, srvApi modserver2.Api, dt trace.Tracer, dm otelmetric.Meter,
-	tp trace.TracerProvider, mp otelmetric.MeterProvider, redisClient rueidis.Client, ssh stats.Handler, factory modserver2.AgentRpcApiFactory,
+	tp trace.TracerProvider, mp otelmetric.MeterProvider, storage redistool.Backend, ssh stats.Handler, factory modserver2.AgentRpcApiFactory,
 	ownPrivateApiUrl string, probeRegistry *observability.ProbeRegistry, reg *prometheus.Registry,
 	streamProm grpc.StreamServerInterceptor, unaryProm grpc.UnaryServerInterceptor,
-	grpcServerErrorReporter grpctool2.ServerErrorReporter) (*agentServer, error) {
+	grpcServerErrorReporter grpctool2.ServerErrorReporter, versionSkewPolicy *agent_registrar.VersionSkewPolicy) (*agentServer, error) {
 	listenCfg := cfg.Agent.Listen
 	tlsConfig, err := tlstool.MaybeDefaultServerTLSConfig(listenCfg.CertificateFile, listenCfg.KeyFile)
 	if err != nil {
@@ -64,10 +69,21 @@ func newAgentServer(log *zap.Logger, cfg *kascfg.ConfigurationFile, srvApi modse
 		Name: agentConnectionRateExceededMetricName,
 		Help: "The total number of times configured rate limit of new agent connections was exceeded",
 	})
-	err = reg.Register(rateExceededCounter)
+	versionSkewRefusedCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: agentVersionSkewRefusedMetricName,
+		Help: "The total number of agent requests refused because of an unsupported agent version",
+	})
+	err = metric.Register(reg, rateExceededCounter, versionSkewRefusedCounter)
 	if err != nil {
 		return nil, err
 	}
+	// Tunnels are restricted with the "restrict" version skew policy. Agents can always (un)register,
+	// so that kas knows their version and can tell them about the skew.
+	versionSkewRestricted := []string{rpc.ReverseTunnel_Connect_FullMethodName}
+	versionSkewExempt := []string{
+		agent_registrar_rpc.AgentRegistrar_Register_FullMethodName,
+		agent_registrar_rpc.AgentRegistrar_Unregister_FullMethodName,
+	}
 	// Tunnel registry
 	tunnelRegistry, err := tunnel2.NewRegistry(
 		log,
@@ -75,14 +91,16 @@ func newAgentServer(log *zap.Logger, cfg *kascfg.ConfigurationFile, srvApi modse
 		dt,
 		cfg.Agent.RedisConnInfoRefresh.AsDuration(),
 		cfg.Agent.RedisConnInfoTtl.AsDuration(),
-		tunnel2.NewRedisTracker(redisClient, cfg.Redis.KeyPrefix+":tunnel_tracker2", ownPrivateApiUrl),
+		tunnel2.NewStorageTracker(storage, cfg.Redis.KeyPrefix+":tunnel_tracker2", ownPrivateApiUrl),
+		rpc.Compression(rpc.Compression_value[cfg.Agent.ReverseTunnel.Compression]),
+		cfg.Agent.ReverseTunnel.Multiplexing,
 	)
 	if err != nil {
 		return nil, err
 	}
 	var agentConnectionLimiter grpctool2.ServerLimiter
-	agentConnectionLimiter = redistool.NewTokenLimiter(
-		redisClient,
+	agentConnectionLimiter = redistool.NewLimiter(
+		storage,
 		cfg.Redis.KeyPrefix+":agent_limit",
 		uint64(listenCfg.ConnectionsPerTokenPerMinute),
 		rateExceededCounter,
@@ -120,6 +138,7 @@ func newAgentServer(log *zap.Logger, cfg *kascfg.ConfigurationFile, srvApi modse
 			streamProm, // 1. measure all invocations
 			modserver2.StreamAgentRpcApiInterceptor(factory), // 2. inject RPC API
 			grpc_validator.StreamServerInterceptor(),         // x. wrap with validator
+			agent_registrar.StreamServerVersionSkewInterceptor(versionSkewPolicy, versionSkewRestricted, versionSkewExempt, versionSkewRefusedCounter),
 			grpctool2.StreamServerLimitingInterceptor(agentConnectionLimiter),
 			grpctool2.StreamServerErrorReporterInterceptor(grpcServerErrorReporter),
 		),
@@ -127,6 +146,7 @@ func newAgentServer(log *zap.Logger, cfg *kascfg.ConfigurationFile, srvApi modse
 			unaryProm, // 1. measure all invocations
 			modserver2.UnaryAgentRpcApiInterceptor(factory), // 2. inject RPC API
 			grpc_validator.UnaryServerInterceptor(),         // x. wrap with validator
+			agent_registrar.UnaryServerVersionSkewInterceptor(versionSkewPolicy, versionSkewRestricted, versionSkewExempt, versionSkewRefusedCounter),
 			grpctool2.UnaryServerLimitingInterceptor(agentConnectionLimiter),
 			grpctool2.UnaryServerErrorReporterInterceptor(grpcServerErrorReporter),
 		),
@@ -203,8 +223,41 @@ func (s *agentServer) Start(stage stager.Stage) {
 
 		return lis, nil
 	}, func() {
-		time.Sleep(s.listenCfg.ListenGracePeriod.AsDuration())
-		s.auxCancel()
+		s.Drain()
 		registryCancel()
 	})
 }
+
+// Drain hands agents over to other kas replicas. It is called as soon as shutdown starts, before other servers
+// and modules stop, so that rolling kas upgrades don't interrupt requests to agents:
+//   - other kas replicas stop routing requests to this one and new tunnels are rejected.
+//   - the server stops accepting connections so agents connect to other replicas.
+//   - idle tunnels are closed and agents are asked to replace multiplexed tunnels.
+//   - in-flight requests get up to drain_grace_period to finish, then remaining connections are closed.
+//
+// Safe to call more than once, subsequent calls wait for the first one to return.
+func (s *agentServer) Drain() {
+	s.drainOnce.Do(s.drain)
+}
+
+func (s *agentServer) drain() {
+	time.Sleep(s.listenCfg.ListenGracePeriod.AsDuration())
+	s.log.Info("Draining agent connections")
+	s.tunnelRegistry.Drain(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		s.server.GracefulStop()
+	}()
+	s.auxCancel()
+	t := time.NewTimer(s.listenCfg.DrainGracePeriod.AsDuration())
+	defer t.Stop()
+	select {
+	case <-stopped:
+		s.log.Info("Drained agent connections")
+	case <-t.C:
+		s.log.Warn("Drain grace period has elapsed, closing agent connections with in-flight requests")
+		s.server.Stop()
+		<-stopped
+	}
+}
@@ -22,9 +22,13 @@ import (
 	"github.com/pluralsh/kubernetes-agent/cmd/kas/kasapp/plural"
 	"github.com/pluralsh/kubernetes-agent/pkg/api"
 	gapi "github.com/pluralsh/kubernetes-agent/pkg/gitlab/api"
+	agent_configuration_server "github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/server"
+	agent_control_server "github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/server"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar"
 	agent_registrar_server "github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/server"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	agent_tracker_server "github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker/server"
+	gitops_server "github.com/pluralsh/kubernetes-agent/pkg/module/gitops/server"
 	kubernetes_api_server "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/server"
 	modserver2 "github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
@@ -32,6 +36,8 @@ import (
 	observability_server "github.com/pluralsh/kubernetes-agent/pkg/module/observability/server"
 	reverse_tunnel_server "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/server"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tunnel_introspection"
+	tunnel_introspection_server "github.com/pluralsh/kubernetes-agent/pkg/module/tunnel_introspection/server"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/usage_metrics"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
@@ -63,6 +69,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	_ "google.golang.org/grpc/encoding/gzip" // Install the gzip compressor
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/pluralsh/kubernetes-agent/cmd"
 	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
@@ -147,21 +155,20 @@ func (a *ConfiguredApp) Run(ctx context.Context) (retErr error) {
 		return fmt.Errorf("error tracker: %w", err)
 	}
 
-	// Redis
-	redisClient, err := a.constructRedisClient(tp, mp)
+	// Storage
+	storage, storageClose, err := a.constructStorage(tp, mp, probeRegistry)
 	if err != nil {
 		return err
 	}
-	defer redisClient.Close()
-	probeRegistry.RegisterReadinessProbe("redis", constructRedisReadinessProbe(redisClient))
+	defer storageClose()
 
-	srvApi := newServerApi(a.Log, sentryHub, redisClient)
+	srvApi := newServerApi(a.Log, sentryHub)
 	errRep := modshared.ApiToErrReporter(srvApi)
 	grpcServerErrorReporter := &serverErrorReporter{log: a.Log, errReporter: errRep}
 
 	// RPC API factory
 	// Plural: Use fake factory
-	rpcApiFactory, agentRpcApiFactory := a.constructPluralRpcApiFactory(errRep, sentryHub, redisClient, dt)
+	rpcApiFactory, agentRpcApiFactory := a.constructPluralRpcApiFactory(errRep, sentryHub, storage, dt)
 
 	// Server for handling API requests from other kas instances
 	privateApiSrv, err := newPrivateApiServer(a.Log, errRep, a.Configuration, tp, mp, p, csh, ssh, rpcApiFactory, // nolint: contextcheck
@@ -171,8 +178,9 @@ func (a *ConfiguredApp) Run(ctx context.Context) (retErr error) {
 	}
 
 	// Server for handling agentk requests
-	agentSrv, err := newAgentServer(a.Log, a.Configuration, srvApi, dt, dm, tp, mp, redisClient, ssh, agentRpcApiFactory, // nolint: contextcheck
-		privateApiSrv.ownUrl, probeRegistry, reg, streamProm, unaryProm, grpcServerErrorReporter)
+	versionSkewPolicy := a.constructVersionSkewPolicy()
+	agentSrv, err := newAgentServer(a.Log, a.Configuration, srvApi, dt, dm, tp, mp, storage, ssh, agentRpcApiFactory, // nolint: contextcheck
+		privateApiSrv.ownUrl, probeRegistry, reg, streamProm, unaryProm, grpcServerErrorReporter, versionSkewPolicy)
 	if err != nil {
 		return fmt.Errorf("agent server: %w", err)
 	}
@@ -215,29 +223,52 @@ func (a *ConfiguredApp) Run(ctx context.Context) (retErr error) {
 	}
 
 	// Agent tracker
-	agentTracker := a.constructAgentTracker(errRep, redisClient)
+	agentTracker := a.constructAgentTracker(errRep, storage)
 
 	// Usage tracker
 	usageTracker := usage_metrics.NewUsageTracker()
 
+	// JSON view of tunnel introspection for the observability endpoint
+	tunnelsHandler := tunnel_introspection_server.NewHttpHandler(
+		a.Log.With(logz.ModuleName(tunnel_introspection.ModuleName)),
+		srvApi,
+		agentSrv.tunnelRegistry,
+		privateApiSrv.ownUrl,
+	)
+
 	// Module factories
 	factories := []modserver2.Factory{
 		&observability_server.Factory{
 			Gatherer: reg,
+			Handlers: map[string]http.Handler{
+				tunnel_introspection_server.HttpUrlPath: tunnelsHandler,
+			},
 		},
 		&usage_metrics_server.Factory{
 			UsageTracker: usageTracker,
 		},
-		&agent_registrar_server.Factory{
+		&agent_configuration_server.Factory{
 			AgentRegisterer: agentTracker,
 		},
+		&agent_registrar_server.Factory{
+			AgentRegisterer:   agentTracker,
+			VersionSkewPolicy: versionSkewPolicy,
+		},
 		&agent_tracker_server.Factory{
 			AgentQuerier: agentTracker,
 		},
 		&reverse_tunnel_server.Factory{
 			TunnelHandler: agentSrv.tunnelRegistry,
 		},
-		&kubernetes_api_server.Factory{},
+		&tunnel_introspection_server.Factory{
+			Introspector: agentSrv.tunnelRegistry,
+			OwnUrl:       privateApiSrv.ownUrl,
+		},
+		&agent_control_server.Factory{},
+		&gitops_server.Factory{},
+		&kubernetes_api_server.Factory{
+			AgentQuerier: agentTracker,
+		},
 	}
 
 	var beforeServersModules, afterServersModules []modserver2.Module
@@ -258,7 +289,7 @@ func (a *ConfiguredApp) Run(ctx context.Context) (retErr error) {
 			TraceProvider:    tp,
 			TracePropagator:  p,
 			MeterProvider:    mp,
-			RedisClient:      redisClient,
+			Storage:          storage,
 			KasName:          kasName,
 			Version:          cmd.Version,
 			CommitId:         cmd.Commit,
@@ -282,6 +313,18 @@ func (a *ConfiguredApp) Run(ctx context.Context) (retErr error) {
 	return stager.RunStages(ctx,
 		// Start things that modules use.
 		func(stage stager.Stage) {
+			if storage.Store != nil {
+				stage.Go(func(ctx context.Context) error {
+					storage.Store.Run(ctx)
+					return nil
+				})
+			}
+			if storage.Kubernetes != nil {
+				stage.Go(func(ctx context.Context) error {
+					storage.Kubernetes.Run(ctx, a.Configuration.Storage.Kubernetes.GcPeriod.AsDuration())
+					return nil
+				})
+			}
 			stage.Go(agentTracker.Run)
 			stage.Go(tunnelQuerier.Run)
 		},
@@ -309,11 +352,18 @@ func (a *ConfiguredApp) Run(ctx context.Context) (retErr error) {
 		// Start modules.
 		func(stage stager.Stage) {
 			startModules(stage, afterServersModules)
+			// This stage is shut down first. Start draining agent connections right away rather than after
+			// the modules have stopped, as they may take a long time to finish their in-flight requests.
+			stage.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				agentSrv.Drain()
+				return nil
+			})
 		},
 	)
 }
 
-func (a *ConfiguredApp) constructPluralRpcApiFactory(errRep errz.ErrReporter, sentryHub *sentry.Hub, redisClient rueidis.Client, dt trace.Tracer) (modserver2.RpcApiFactory, modserver2.AgentRpcApiFactory) {
+func (a *ConfiguredApp) constructPluralRpcApiFactory(errRep errz.ErrReporter, sentryHub *sentry.Hub, storage redistool2.Backend, dt trace.Tracer) (modserver2.RpcApiFactory, modserver2.AgentRpcApiFactory) {
 	aCfg := a.Configuration.Agent
 	f := serverRpcApiFactory{
 		log:       a.Log,
@@ -324,33 +374,55 @@ func (a *ConfiguredApp) constructPluralRpcApiFactory(errRep errz.ErrReporter, se
 		AgentInfoCache: cache.NewWithError[api.AgentToken, *api.AgentInfo](
 			aCfg.InfoCacheTtl.AsDuration(),
 			aCfg.InfoCacheErrorTtl.AsDuration(),
-			&redistool2.ErrCacher[api.AgentToken]{
-				Log:          a.Log,
-				ErrRep:       errRep,
-				Client:       redisClient,
-				ErrMarshaler: prototool.ProtoErrMarshaler{},
-				KeyToRedisKey: func(key api.AgentToken) string {
+			redistool2.NewErrCacher(
+				storage,
+				a.Log,
+				errRep,
+				prototool.ProtoErrMarshaler{},
+				func(key api.AgentToken) string {
 					return a.Configuration.Redis.KeyPrefix + ":agent_info_errs:" + string(api.AgentToken2key(key))
 				},
-			},
+			),
 			dt,
 			gapi.IsCacheableError,
 		),
+		TokenOverlap: &plural.TokenOverlap{
+			Window: aCfg.TokenOverlapWindow.AsDuration(),
+			Cacher: redistool2.NewValueCacher(
+				storage,
+				a.Log,
+				errRep,
+				func(tokenHash string) string {
+					return a.Configuration.Redis.KeyPrefix + ":agent_token_overlap:" + tokenHash
+				},
+			),
+		},
 		PluralURL: a.Configuration.PluralUrl,
 	}
 	return f.New, fAgent.New
 }
 
-func (a *ConfiguredApp) constructAgentTracker(errRep errz.ErrReporter, redisClient rueidis.Client) agent_tracker.Tracker {
+func (a *ConfiguredApp) constructVersionSkewPolicy() *agent_registrar.VersionSkewPolicy {
+	cfg := a.Configuration.Agent.VersionSkew
+	return &agent_registrar.VersionSkewPolicy{
+		KasVersion:             cmd.Version,
+		SupportedMinorVersions: cfg.SupportedMinorVersions,
+		AllowNewerAgents:       cfg.AllowNewerAgents,
+		Action:                 cfg.Policy,
+	}
+}
+
+func (a *ConfiguredApp) constructAgentTracker(errRep errz.ErrReporter, storage redistool2.Backend) agent_tracker.Tracker {
 	cfg := a.Configuration
-	return agent_tracker.NewRedisTracker(
+	return agent_tracker.NewStorageTracker(
 		a.Log,
 		errRep,
-		redisClient,
+		storage,
 		cfg.Redis.KeyPrefix+":agent_tracker2",
 		cfg.Agent.RedisConnInfoTtl.AsDuration(),
 		cfg.Agent.RedisConnInfoRefresh.AsDuration(),
 		cfg.Agent.RedisConnInfoGc.AsDuration(),
+		cfg.Agent.ConnectionHistoryTtl.AsDuration(),
 	)
 }
 
@@ -387,6 +459,53 @@ func (a *ConfiguredApp) constructSentryHub(tp trace.TracerProvider, mp otelmetri
 	return sentry.NewHub(sentryClient, sentry.NewScope()), nil
 }
 
+// constructStorage returns the configured storage backend and a function to close it.
+func (a *ConfiguredApp) constructStorage(tp trace.TracerProvider, mp otelmetric.MeterProvider,
+	probeRegistry *observability.ProbeRegistry) (redistool2.Backend, func(), error) {
+	switch a.Configuration.Storage.Backend {
+	case kascfg.StorageBackendMemory:
+		a.Log.Warn("Using in-memory storage. Data is not shared between kas replicas, only run a single replica")
+		return redistool2.Backend{Store: redistool2.NewMemoryStore()}, func() {}, nil
+	case kascfg.StorageBackendKubernetes:
+		kubeStore, err := a.constructKubernetesStore()
+		if err != nil {
+			return redistool2.Backend{}, nil, err
+		}
+		probeRegistry.RegisterReadinessProbe("kubernetes_storage", constructKubernetesStorageReadinessProbe(kubeStore))
+		return redistool2.Backend{Store: redistool2.NewMemoryStore(), Kubernetes: kubeStore}, func() {}, nil
+	default: // kascfg.StorageBackendRedis
+		redisClient, err := a.constructRedisClient(tp, mp)
+		if err != nil {
+			return redistool2.Backend{}, nil, err
+		}
+		probeRegistry.RegisterReadinessProbe("redis", constructRedisReadinessProbe(redisClient))
+		return redistool2.Backend{Client: redisClient}, redisClient.Close, nil
+	}
+}
+
+func (a *ConfiguredApp) constructKubernetesStore() (*redistool2.KubernetesStore, error) {
+	cfg := a.Configuration.Storage.Kubernetes
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = cfg.KubeconfigFile
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes storage: %w", err)
+	}
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes storage: namespace: %w", err)
+		}
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes storage: %w", err)
+	}
+	return redistool2.NewKubernetesStore(a.Log, client, namespace), nil
+}
+
 func (a *ConfiguredApp) constructRedisClient(tp trace.TracerProvider, mp otelmetric.MeterProvider) (rueidis.Client, error) {
 	cfg := a.Configuration.Redis
 	dialTimeout := cfg.DialTimeout.AsDuration()
@@ -576,6 +695,16 @@ func startModules(stage stager.Stage, modules []modserver2.Module) {
 	}
 }
 
+func constructKubernetesStorageReadinessProbe(store *redistool2.KubernetesStore) observability.Probe {
+	return func(ctx context.Context) error {
+		err := store.Check(ctx)
+		if err != nil {
+			return fmt.Errorf("kubernetes storage: %w", err)
+		}
+		return nil
+	}
+}
+
 func constructRedisReadinessProbe(redisClient rueidis.Client) observability.Probe {
 	return func(ctx context.Context) error {
 		pingCmd := redisClient.B().Ping().Build()
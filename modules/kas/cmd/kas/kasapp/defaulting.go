@@ -20,17 +20,28 @@ const (
 	defaultAgentRedisConnInfoTTL     = 5 * time.Minute
 	defaultAgentRedisConnInfoRefresh = 4 * time.Minute
 	defaultAgentRedisConnInfoGC      = 10 * time.Minute
+	defaultAgentConnectionHistoryTTL = 7 * 24 * time.Hour
+
+	defaultAgentVersionSkewPolicy                 = kascfg.VersionSkewPolicyWarn
+	defaultAgentVersionSkewSupportedMinorVersions = 3
 
 	defaultAgentListenNetwork                      = "tcp"
 	defaultAgentListenAddress                      = "127.0.0.1:8150"
 	defaultAgentListenConnectionsPerTokenPerMinute = 40000
 	defaultAgentListenMaxConnectionAge             = 2 * time.Hour
+	defaultAgentListenDrainGracePeriod             = 1 * time.Hour
+
+	defaultAgentReverseTunnelCompression = "none"
 
 	defaultRedisDialTimeout  = 5 * time.Second
 	defaultRedisWriteTimeout = 3 * time.Second
 	defaultRedisKeyPrefix    = "gitlab-kas"
 	defaultRedisNetwork      = "tcp"
 
+	defaultStorageBackend            = kascfg.StorageBackendRedis
+	defaultStorageKubernetesGCPeriod = 10 * time.Minute
+	defaultStorageKubernetesReplicas = 1
+
 	defaultApiListenNetwork          = "tcp"
 	defaultApiListenAddress          = "127.0.0.1:8153"
 	defaultApiListenMaxConnectionAge = 2 * time.Hour
@@ -57,6 +68,9 @@ func ApplyDefaultsToKasConfigurationFile(cfg *kascfg.ConfigurationFile) {
 	prototool.NotNil(&cfg.Redis)
 	defaultRedis(cfg.Redis)
 
+	prototool.NotNil(&cfg.Storage)
+	defaultStorage(cfg.Storage)
+
 	prototool.NotNil(&cfg.Api)
 	defaultApi(cfg.Api)
 
@@ -91,12 +105,32 @@ func defaultAgent(a *kascfg.AgentCF) {
 	prototool.Uint32(&a.Listen.ConnectionsPerTokenPerMinute, defaultAgentListenConnectionsPerTokenPerMinute)
 	prototool.Duration(&a.Listen.MaxConnectionAge, defaultAgentListenMaxConnectionAge)
 	prototool.Duration(&a.Listen.ListenGracePeriod, defaultListenGracePeriod)
+	prototool.Duration(&a.Listen.DrainGracePeriod, defaultAgentListenDrainGracePeriod)
 
 	prototool.Duration(&a.InfoCacheTtl, defaultAgentInfoCacheTTL)
 	prototool.Duration(&a.InfoCacheErrorTtl, defaultAgentInfoCacheErrorTTL)
 	prototool.Duration(&a.RedisConnInfoTtl, defaultAgentRedisConnInfoTTL)
 	prototool.Duration(&a.RedisConnInfoRefresh, defaultAgentRedisConnInfoRefresh)
 	prototool.Duration(&a.RedisConnInfoGc, defaultAgentRedisConnInfoGC)
+	prototool.Duration(&a.ConnectionHistoryTtl, defaultAgentConnectionHistoryTTL)
+
+	prototool.NotNil(&a.ReverseTunnel)
+	prototool.String(&a.ReverseTunnel.Compression, defaultAgentReverseTunnelCompression)
+
+	prototool.NotNil(&a.VersionSkew)
+	prototool.String(&a.VersionSkew.Policy, defaultAgentVersionSkewPolicy)
+	prototool.Uint32(&a.VersionSkew.SupportedMinorVersions, defaultAgentVersionSkewSupportedMinorVersions)
+}
+
+func defaultStorage(s *kascfg.StorageCF) {
+	prototool.String(&s.Backend, defaultStorageBackend)
+	if s.Backend == kascfg.StorageBackendKubernetes {
+		prototool.NotNil(&s.Kubernetes)
+	}
+	if s.Kubernetes != nil {
+		prototool.Duration(&s.Kubernetes.GcPeriod, defaultStorageKubernetesGCPeriod)
+		prototool.Uint32(&s.Kubernetes.Replicas, defaultStorageKubernetesReplicas)
+	}
 }
 
 func defaultRedis(r *kascfg.RedisCF) {
@@ -7,6 +7,7 @@ import (
 	modserver2 "github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
 	"github.com/pluralsh/kubernetes-agent/pkg/plural"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
 
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	"go.uber.org/zap"
@@ -16,6 +17,7 @@ type ServerAgentRpcApi struct {
 	modserver2.RpcApi
 	Token          api.AgentToken
 	AgentInfoCache *cache.CacheWithErr[api.AgentToken, *api.AgentInfo]
+	TokenOverlap   *TokenOverlap
 	PluralURL      string
 }
 
@@ -24,18 +26,34 @@ func (a *ServerAgentRpcApi) AgentToken() api.AgentToken {
 }
 
 func (a *ServerAgentRpcApi) AgentInfo(ctx context.Context, log *zap.Logger) (*api.AgentInfo, error) {
-	return a.getAgentInfoCached(ctx)
+	return a.getAgentInfoCached(ctx, log)
 }
 
-func (a *ServerAgentRpcApi) getAgentInfoCached(ctx context.Context) (*api.AgentInfo, error) {
+func (a *ServerAgentRpcApi) getAgentInfoCached(ctx context.Context, log *zap.Logger) (*api.AgentInfo, error) {
 	return a.AgentInfoCache.GetItem(ctx, a.Token, func() (*api.AgentInfo, error) {
-		return plural.GetAgentInfo(ctx, a.Token, a.PluralURL)
+		return a.getAgentInfo(ctx, log)
 	})
 }
 
+func (a *ServerAgentRpcApi) getAgentInfo(ctx context.Context, log *zap.Logger) (*api.AgentInfo, error) {
+	info, err := plural.GetAgentInfo(ctx, a.Token, a.PluralURL)
+	if err != nil {
+		// The token might have just been replaced, give agentk time to switch to the new one.
+		if overlapInfo := a.TokenOverlap.lookup(ctx, a.Token, err); overlapInfo != nil {
+			log.Info("Agent token has been replaced, accepting it within the token overlap window",
+				logz.AgentId(overlapInfo.Id), logz.Error(err))
+			return overlapInfo, nil
+		}
+		return nil, err
+	}
+	a.TokenOverlap.remember(ctx, a.Token, info)
+	return info, nil
+}
+
 type ServerAgentRpcApiFactory struct {
 	RPCApiFactory  modserver2.RpcApiFactory
 	AgentInfoCache *cache.CacheWithErr[api.AgentToken, *api.AgentInfo]
+	TokenOverlap   *TokenOverlap
 	PluralURL      string
 }
 
@@ -48,6 +66,7 @@ func (f *ServerAgentRpcApiFactory) New(ctx context.Context, fullMethodName strin
 		RpcApi:         f.RPCApiFactory(ctx, fullMethodName),
 		Token:          api.AgentToken(token),
 		AgentInfoCache: f.AgentInfoCache,
+		TokenOverlap:   f.TokenOverlap,
 		PluralURL:      f.PluralURL,
 	}, nil
 }
@@ -0,0 +1,171 @@
+package plural
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
+)
+
+const (
+	testClusterId = "7b9b7d5e-6c2a-4d0e-9b1a-2f4c7e3d8a10"
+)
+
+var (
+	_ modserver.AgentRpcApi        = (*ServerAgentRpcApi)(nil)
+	_ modserver.AgentRpcApiFactory = (*ServerAgentRpcApiFactory)(nil).New
+)
+
+func TestGetAgentInfo_AcceptsReplacedTokenWithinOverlapWindow(t *testing.T) {
+	console := newFakeConsole(t)
+	overlap := newTokenOverlap(time.Minute)
+	oldApi := setupApi(console, overlap, "old")
+	newApi := setupApi(console, overlap, "new")
+
+	console.accept("old")
+	info, err := oldApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, testClusterId, info.ClusterId)
+
+	// The token has been replaced and the new one has been used.
+	console.accept("new")
+	_, err = newApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	overlapInfo, err := oldApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, info, overlapInfo)
+}
+
+func TestGetAgentInfo_RejectsReplacedTokenUntilRotationIsSeen(t *testing.T) {
+	console := newFakeConsole(t)
+	a := setupApi(console, newTokenOverlap(time.Minute), "old")
+
+	console.accept("old")
+	_, err := a.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	// The new token has not been used yet.
+	console.accept("new")
+	_, err = a.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	assert.Error(t, err)
+}
+
+func TestGetAgentInfo_RejectsRevokedToken(t *testing.T) {
+	console := newFakeConsole(t)
+	overlap := newTokenOverlap(time.Minute)
+	oldApi := setupApi(console, overlap, "old")
+	newApi := setupApi(console, overlap, "new")
+
+	console.accept("old")
+	_, err := oldApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	console.accept("new")
+	_, err = newApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	console.revoke("old")
+	_, err = oldApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	assert.Error(t, err)
+}
+
+func TestGetAgentInfo_RejectsUnknownToken(t *testing.T) {
+	console := newFakeConsole(t)
+	a := setupApi(console, newTokenOverlap(time.Minute), "old")
+
+	console.accept("new")
+	_, err := a.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	assert.Error(t, err)
+}
+
+func TestGetAgentInfo_RejectsReplacedTokenWhenOverlapIsDisabled(t *testing.T) {
+	console := newFakeConsole(t)
+	overlap := newTokenOverlap(0)
+	oldApi := setupApi(console, overlap, "old")
+	newApi := setupApi(console, overlap, "new")
+
+	console.accept("old")
+	_, err := oldApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	console.accept("new")
+	_, err = newApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	_, err = oldApi.getAgentInfo(context.Background(), zaptest.NewLogger(t))
+	assert.Error(t, err)
+}
+
+func newTokenOverlap(window time.Duration) *TokenOverlap {
+	return &TokenOverlap{
+		Window: window,
+		Cacher: &redistool.MemoryValueCacher[string]{
+			Store: redistool.NewMemoryStore(),
+			KeyToRedisKey: func(key string) string {
+				return "overlap:" + key
+			},
+		},
+	}
+}
+
+func setupApi(console *fakeConsole, overlap *TokenOverlap, token api.AgentToken) *ServerAgentRpcApi {
+	return &ServerAgentRpcApi{
+		Token:        token,
+		TokenOverlap: overlap,
+		PluralURL:    console.url,
+	}
+}
+
+// fakeConsole is a Plural Console that accepts a single agent token.
+// Other tokens are rejected with a GraphQL error, revoked tokens with 401 Unauthorized.
+type fakeConsole struct {
+	url        string
+	mu         sync.Mutex
+	validToken api.AgentToken
+	revoked    map[api.AgentToken]bool
+}
+
+func newFakeConsole(t *testing.T) *fakeConsole {
+	c := &fakeConsole{
+		revoked: map[api.AgentToken]bool{},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		token := api.AgentToken(strings.TrimPrefix(r.Header.Get("Authorization"), "Token "))
+		if c.revoked[token] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if token != c.validToken {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"could not find resource"}],"data":{"myCluster":null}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"myCluster":{"id":"` + testClusterId + `","name":"test"}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	c.url = srv.URL
+	return c
+}
+
+func (c *fakeConsole) accept(token api.AgentToken) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validToken = token
+}
+
+func (c *fakeConsole) revoke(token api.AgentToken) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.revoked[token] = true
+}
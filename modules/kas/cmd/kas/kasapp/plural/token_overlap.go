@@ -0,0 +1,89 @@
+package plural
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
+)
+
+// TokenOverlap keeps agent tokens working for Window after Plural Console stopped accepting them because
+// they have been replaced. It gives agentk time to switch to a new token without losing connectivity.
+type TokenOverlap struct {
+	// Window is how long a token is accepted after it was last accepted by Plural Console. Zero disables overlap.
+	Window time.Duration
+	// Cacher keeps agent info by token hash and the hash of the last accepted token by agent id.
+	// The token itself is never stored.
+	Cacher redistool.ValueCacher[string]
+}
+
+// remember records that the token has been accepted.
+func (o *TokenOverlap) remember(ctx context.Context, token api.AgentToken, info *api.AgentInfo) {
+	if o.Window == 0 {
+		return
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return // cannot happen
+	}
+	hash := tokenHash(token)
+	o.Cacher.CacheValue(ctx, tokenKey(hash), data, o.Window)
+	o.Cacher.CacheValue(ctx, agentKey(info.Id), []byte(hash), o.Window)
+}
+
+// lookup returns agent info if the token has been accepted within Window and Plural Console has accepted another
+// token for the same agent since then. Returns nil otherwise.
+// err is the error Plural Console returned for the token. Tokens Plural Console has revoked are never accepted.
+func (o *TokenOverlap) lookup(ctx context.Context, token api.AgentToken, err error) *api.AgentInfo {
+	if o.Window == 0 || isUnauthorized(err) {
+		return nil
+	}
+	hash := tokenHash(token)
+	data := o.Cacher.GetValue(ctx, tokenKey(hash))
+	if data == nil {
+		return nil
+	}
+	info := &api.AgentInfo{}
+	err = json.Unmarshal(data, info)
+	if err != nil {
+		return nil
+	}
+	lastHash := o.Cacher.GetValue(ctx, agentKey(info.Id))
+	if lastHash == nil || bytes.Equal(lastHash, []byte(hash)) {
+		return nil // token has not been replaced
+	}
+	return info
+}
+
+// isUnauthorized checks if Plural Console has rejected the token with 401 Unauthorized i.e. it has been revoked.
+func isUnauthorized(err error) bool {
+	var e *clientv2.ErrorResponse
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.NetworkError != nil && e.NetworkError.Code == http.StatusUnauthorized
+}
+
+func tokenKey(hash string) string {
+	return "token:" + hash
+}
+
+func agentKey(agentId int64) string {
+	return "agent:" + strconv.FormatInt(agentId, 10)
+}
+
+// tokenHash hashes the whole token, unlike api.AgentToken2key, since the key is enough to be authenticated.
+func tokenHash(token api.AgentToken) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
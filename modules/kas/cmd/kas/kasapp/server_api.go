@@ -21,7 +21,6 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
 
-	"github.com/redis/rueidis"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -54,16 +53,14 @@ type SentryHub interface {
 type serverApi struct {
 	log             *zap.Logger
 	Hub             SentryHub
-	redisClient     rueidis.Client
 	gitPushEvent    syncz.Subscriptions[*event.GitPushEvent]
 	redisPollConfig retry.PollConfigFactory
 }
 
-func newServerApi(log *zap.Logger, hub SentryHub, redisClient rueidis.Client) *serverApi {
+func newServerApi(log *zap.Logger, hub SentryHub) *serverApi {
 	return &serverApi{
-		log:         log,
-		Hub:         hub,
-		redisClient: redisClient,
+		log: log,
+		Hub: hub,
 		redisPollConfig: retry.NewPollConfigFactory(redisAttemptInterval, retry.NewExponentialBackoffFactory(
 			redisInitBackoff,
 			redisMaxBackoff,
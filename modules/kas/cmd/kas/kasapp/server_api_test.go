@@ -71,7 +71,7 @@ func setupApi(t *testing.T) (context.Context, *zap.Logger, *MockSentryHub, *serv
 	ctrl := gomock.NewController(t)
 	hub := NewMockSentryHub(ctrl)
 	ctx, traceId := testhelpers.CtxWithSpanContext(t)
-	apiObj := newServerApi(log, hub, nil)
+	apiObj := newServerApi(log, hub)
 	return ctx, log, hub, apiObj, traceId
 }
 
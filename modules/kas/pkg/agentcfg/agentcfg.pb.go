@@ -169,7 +169,10 @@ type ManifestProjectCF struct {
 	// https://github.com/kubernetes-sigs/cli-utils/blob/d6968048dcd80b1c7b55d9e4f31fc25f71c9b490/pkg/inventory/policy.go#L12-L66
 	InventoryPolicy string `protobuf:"bytes,11,opt,name=inventory_policy,proto3" json:"inventory_policy,omitempty"`
 	// Ref in the GitOps repository to fetch manifests from.
-	Ref           *GitRefCF `protobuf:"bytes,12,opt,name=ref,proto3" json:"ref,omitempty"`
+	Ref *GitRefCF `protobuf:"bytes,12,opt,name=ref,proto3" json:"ref,omitempty"`
+	// Prune empty defines whether all objects of the project may be pruned when the manifests render to no objects.
+	// An empty render is usually a mistake, e.g. paths that don't match any files, so such a sync fails by default.
+	PruneEmpty    bool `protobuf:"varint,13,opt,name=prune_empty,proto3" json:"prune_empty,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -283,6 +286,13 @@ func (x *ManifestProjectCF) GetRef() *GitRefCF {
 	return nil
 }
 
+func (x *ManifestProjectCF) GetPruneEmpty() bool {
+	if x != nil {
+		return x.PruneEmpty
+	}
+	return false
+}
+
 type isManifestProjectCF_PruneOneof interface {
 	isManifestProjectCF_PruneOneof()
 }
@@ -303,6 +313,7 @@ type GitRefCF struct {
 	//	*GitRefCF_Tag
 	//	*GitRefCF_Branch
 	//	*GitRefCF_Commit
+	//	*GitRefCF_Name
 	Ref           isGitRefCF_Ref `protobuf_oneof:"ref"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -372,6 +383,15 @@ func (x *GitRefCF) GetCommit() string {
 	return ""
 }
 
+func (x *GitRefCF) GetName() string {
+	if x != nil {
+		if x, ok := x.Ref.(*GitRefCF_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
 type isGitRefCF_Ref interface {
 	isGitRefCF_Ref()
 }
@@ -391,12 +411,19 @@ type GitRefCF_Commit struct {
 	Commit string `protobuf:"bytes,3,opt,name=commit,proto3,oneof"`
 }
 
+type GitRefCF_Name struct {
+	// A full Git reference name, e.g. `refs/pull/123/head` or `refs/merge-requests/123/head`
+	Name string `protobuf:"bytes,4,opt,name=name,proto3,oneof"`
+}
+
 func (*GitRefCF_Tag) isGitRefCF_Ref() {}
 
 func (*GitRefCF_Branch) isGitRefCF_Ref() {}
 
 func (*GitRefCF_Commit) isGitRefCF_Ref() {}
 
+func (*GitRefCF_Name) isGitRefCF_Ref() {}
+
 type GitopsCF struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	ManifestProjects []*ManifestProjectCF   `protobuf:"bytes,1,rep,name=manifest_projects,proto3" json:"manifest_projects,omitempty"`
@@ -1692,6 +1719,9 @@ type ConfigurationFile struct {
 	UserAccess        *UserAccessCF        `protobuf:"bytes,6,opt,name=user_access,proto3" json:"user_access,omitempty"`
 	RemoteDevelopment *RemoteDevelopmentCF `protobuf:"bytes,7,opt,name=remote_development,proto3" json:"remote_development,omitempty"`
 	Flux              *FluxCF              `protobuf:"bytes,8,opt,name=flux,proto3" json:"flux,omitempty"`
+	ServiceProxy      *ServiceProxyCF      `protobuf:"bytes,9,opt,name=service_proxy,proto3" json:"service_proxy,omitempty"`
+	TcpForward        *TcpForwardCF        `protobuf:"bytes,10,opt,name=tcp_forward,proto3" json:"tcp_forward,omitempty"`
+	ReverseTunnel     *ReverseTunnelCF     `protobuf:"bytes,11,opt,name=reverse_tunnel,proto3" json:"reverse_tunnel,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -1775,6 +1805,27 @@ func (x *ConfigurationFile) GetFlux() *FluxCF {
 	return nil
 }
 
+func (x *ConfigurationFile) GetServiceProxy() *ServiceProxyCF {
+	if x != nil {
+		return x.ServiceProxy
+	}
+	return nil
+}
+
+func (x *ConfigurationFile) GetTcpForward() *TcpForwardCF {
+	if x != nil {
+		return x.TcpForward
+	}
+	return nil
+}
+
+func (x *ConfigurationFile) GetReverseTunnel() *ReverseTunnelCF {
+	if x != nil {
+		return x.ReverseTunnel
+	}
+	return nil
+}
+
 // AgentConfiguration represents configuration for agentk.
 // Note that agentk configuration is not exactly the whole file as the file
 // may contain bits that are not relevant for the agent. For example, some
@@ -1794,6 +1845,9 @@ type AgentConfiguration struct {
 	RemoteDevelopment *RemoteDevelopmentCF `protobuf:"bytes,9,opt,name=remote_development,json=remoteDevelopment,proto3" json:"remote_development,omitempty"`
 	Flux              *FluxCF              `protobuf:"bytes,10,opt,name=flux,proto3" json:"flux,omitempty"`
 	GitlabExternalUrl string               `protobuf:"bytes,11,opt,name=gitlab_external_url,json=gitlabExternalUrl,proto3" json:"gitlab_external_url,omitempty"`
+	ServiceProxy      *ServiceProxyCF      `protobuf:"bytes,12,opt,name=service_proxy,json=serviceProxy,proto3" json:"service_proxy,omitempty"`
+	TcpForward        *TcpForwardCF        `protobuf:"bytes,13,opt,name=tcp_forward,json=tcpForward,proto3" json:"tcp_forward,omitempty"`
+	ReverseTunnel     *ReverseTunnelCF     `protobuf:"bytes,14,opt,name=reverse_tunnel,json=reverseTunnel,proto3" json:"reverse_tunnel,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -1898,6 +1952,27 @@ func (x *AgentConfiguration) GetGitlabExternalUrl() string {
 	return ""
 }
 
+func (x *AgentConfiguration) GetServiceProxy() *ServiceProxyCF {
+	if x != nil {
+		return x.ServiceProxy
+	}
+	return nil
+}
+
+func (x *AgentConfiguration) GetTcpForward() *TcpForwardCF {
+	if x != nil {
+		return x.TcpForward
+	}
+	return nil
+}
+
+func (x *AgentConfiguration) GetReverseTunnel() *ReverseTunnelCF {
+	if x != nil {
+		return x.ReverseTunnel
+	}
+	return nil
+}
+
 // GitLabWorkspacesProxy represents the gitlab workspaces proxy configuration for the remote development module
 type GitLabWorkspacesProxy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2116,13 +2191,469 @@ func (x *FluxCF) GetWebhookReceiverUrl() string {
 	return ""
 }
 
+// ServiceProxyCF configures access to in-cluster HTTP services through kas.
+type ServiceProxyCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Services that can be accessed. Requests to other services are rejected.
+	Services      []*ServiceProxyServiceCF `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceProxyCF) Reset() {
+	*x = ServiceProxyCF{}
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceProxyCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceProxyCF) ProtoMessage() {}
+
+func (x *ServiceProxyCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceProxyCF.ProtoReflect.Descriptor instead.
+func (*ServiceProxyCF) Descriptor() ([]byte, []int) {
+	return file_pkg_agentcfg_agentcfg_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ServiceProxyCF) GetServices() []*ServiceProxyServiceCF {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type ServiceProxyServiceCF struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Ports of the service that can be accessed. All ports can be accessed if empty.
+	Ports []uint32 `protobuf:"varint,3,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	// Don't verify the certificate of the service when it's accessed using https.
+	InsecureSkipTlsVerify bool `protobuf:"varint,4,opt,name=insecure_skip_tls_verify,proto3" json:"insecure_skip_tls_verify,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ServiceProxyServiceCF) Reset() {
+	*x = ServiceProxyServiceCF{}
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceProxyServiceCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceProxyServiceCF) ProtoMessage() {}
+
+func (x *ServiceProxyServiceCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceProxyServiceCF.ProtoReflect.Descriptor instead.
+func (*ServiceProxyServiceCF) Descriptor() ([]byte, []int) {
+	return file_pkg_agentcfg_agentcfg_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ServiceProxyServiceCF) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ServiceProxyServiceCF) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceProxyServiceCF) GetPorts() []uint32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *ServiceProxyServiceCF) GetInsecureSkipTlsVerify() bool {
+	if x != nil {
+		return x.InsecureSkipTlsVerify
+	}
+	return false
+}
+
+// TcpForwardCF configures TCP connections to in-cluster targets through kas.
+type TcpForwardCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Targets that can be connected to. Connections to other targets are rejected.
+	Targets       []*TcpForwardTargetCF `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TcpForwardCF) Reset() {
+	*x = TcpForwardCF{}
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TcpForwardCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TcpForwardCF) ProtoMessage() {}
+
+func (x *TcpForwardCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TcpForwardCF.ProtoReflect.Descriptor instead.
+func (*TcpForwardCF) Descriptor() ([]byte, []int) {
+	return file_pkg_agentcfg_agentcfg_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TcpForwardCF) GetTargets() []*TcpForwardTargetCF {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type TcpForwardTargetCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*TcpForwardTargetCF_Service
+	//	*TcpForwardTargetCF_Host
+	Target isTcpForwardTargetCF_Target `protobuf_oneof:"target"`
+	// Ports of the target that can be connected to.
+	Ports         []uint32 `protobuf:"varint,3,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TcpForwardTargetCF) Reset() {
+	*x = TcpForwardTargetCF{}
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TcpForwardTargetCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TcpForwardTargetCF) ProtoMessage() {}
+
+func (x *TcpForwardTargetCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TcpForwardTargetCF.ProtoReflect.Descriptor instead.
+func (*TcpForwardTargetCF) Descriptor() ([]byte, []int) {
+	return file_pkg_agentcfg_agentcfg_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TcpForwardTargetCF) GetTarget() isTcpForwardTargetCF_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *TcpForwardTargetCF) GetService() *TcpForwardServiceCF {
+	if x != nil {
+		if x, ok := x.Target.(*TcpForwardTargetCF_Service); ok {
+			return x.Service
+		}
+	}
+	return nil
+}
+
+func (x *TcpForwardTargetCF) GetHost() string {
+	if x != nil {
+		if x, ok := x.Target.(*TcpForwardTargetCF_Host); ok {
+			return x.Host
+		}
+	}
+	return ""
+}
+
+func (x *TcpForwardTargetCF) GetPorts() []uint32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type isTcpForwardTargetCF_Target interface {
+	isTcpForwardTargetCF_Target()
+}
+
+type TcpForwardTargetCF_Service struct {
+	Service *TcpForwardServiceCF `protobuf:"bytes,1,opt,name=service,proto3,oneof"`
+}
+
+type TcpForwardTargetCF_Host struct {
+	// DNS name or IP address.
+	Host string `protobuf:"bytes,2,opt,name=host,proto3,oneof"`
+}
+
+func (*TcpForwardTargetCF_Service) isTcpForwardTargetCF_Target() {}
+
+func (*TcpForwardTargetCF_Host) isTcpForwardTargetCF_Target() {}
+
+type TcpForwardServiceCF struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TcpForwardServiceCF) Reset() {
+	*x = TcpForwardServiceCF{}
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TcpForwardServiceCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TcpForwardServiceCF) ProtoMessage() {}
+
+func (x *TcpForwardServiceCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TcpForwardServiceCF.ProtoReflect.Descriptor instead.
+func (*TcpForwardServiceCF) Descriptor() ([]byte, []int) {
+	return file_pkg_agentcfg_agentcfg_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TcpForwardServiceCF) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TcpForwardServiceCF) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ReverseTunnelCF configures the pool of reverse tunnels that agentk keeps open to kas.
+// A tunnel is idle when it's ready to carry a request and active while it's carrying one.
+// Changes are applied without restarting agentk. Excess idle tunnels are closed once they have been idle for max_idle_time.
+type ReverseTunnelCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Minimum number of idle tunnels. With adaptive sizing it's the starting value.
+	MinIdleConnections uint32 `protobuf:"varint,1,opt,name=min_idle_connections,proto3" json:"min_idle_connections,omitempty"`
+	// Maximum number of tunnels, idle and active.
+	MaxConnections uint32 `protobuf:"varint,2,opt,name=max_connections,proto3" json:"max_connections,omitempty"`
+	// Number of tunnels to open at once when there are fewer idle tunnels than the minimum.
+	ScaleUpStep uint32 `protobuf:"varint,3,opt,name=scale_up_step,proto3" json:"scale_up_step,omitempty"`
+	// How long a tunnel can stay idle before it's closed, if there are more idle tunnels than the minimum.
+	MaxIdleTime *durationpb.Duration `protobuf:"bytes,4,opt,name=max_idle_time,proto3" json:"max_idle_time,omitempty"`
+	// Size the minimum number of idle tunnels from the observed request rate and tunnel setup time.
+	// Not enabled if not set.
+	Adaptive      *ReverseTunnelAdaptiveCF `protobuf:"bytes,5,opt,name=adaptive,proto3" json:"adaptive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseTunnelCF) Reset() {
+	*x = ReverseTunnelCF{}
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTunnelCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTunnelCF) ProtoMessage() {}
+
+func (x *ReverseTunnelCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTunnelCF.ProtoReflect.Descriptor instead.
+func (*ReverseTunnelCF) Descriptor() ([]byte, []int) {
+	return file_pkg_agentcfg_agentcfg_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReverseTunnelCF) GetMinIdleConnections() uint32 {
+	if x != nil {
+		return x.MinIdleConnections
+	}
+	return 0
+}
+
+func (x *ReverseTunnelCF) GetMaxConnections() uint32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *ReverseTunnelCF) GetScaleUpStep() uint32 {
+	if x != nil {
+		return x.ScaleUpStep
+	}
+	return 0
+}
+
+func (x *ReverseTunnelCF) GetMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxIdleTime
+	}
+	return nil
+}
+
+func (x *ReverseTunnelCF) GetAdaptive() *ReverseTunnelAdaptiveCF {
+	if x != nil {
+		return x.Adaptive
+	}
+	return nil
+}
+
+// ReverseTunnelAdaptiveCF keeps enough idle tunnels to serve the peak request rate of the window
+// for as long as it takes to set up a new tunnel, with 2x headroom.
+type ReverseTunnelAdaptiveCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Period over which the request rate and tunnel setup time are observed.
+	Window *durationpb.Duration `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	// Lower bound of the minimum number of idle tunnels.
+	MinIdleConnections uint32 `protobuf:"varint,2,opt,name=min_idle_connections,proto3" json:"min_idle_connections,omitempty"`
+	// Upper bound of the minimum number of idle tunnels.
+	MaxIdleConnections uint32 `protobuf:"varint,3,opt,name=max_idle_connections,proto3" json:"max_idle_connections,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReverseTunnelAdaptiveCF) Reset() {
+	*x = ReverseTunnelAdaptiveCF{}
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTunnelAdaptiveCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTunnelAdaptiveCF) ProtoMessage() {}
+
+func (x *ReverseTunnelAdaptiveCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_agentcfg_agentcfg_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTunnelAdaptiveCF.ProtoReflect.Descriptor instead.
+func (*ReverseTunnelAdaptiveCF) Descriptor() ([]byte, []int) {
+	return file_pkg_agentcfg_agentcfg_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReverseTunnelAdaptiveCF) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *ReverseTunnelAdaptiveCF) GetMinIdleConnections() uint32 {
+	if x != nil {
+		return x.MinIdleConnections
+	}
+	return 0
+}
+
+func (x *ReverseTunnelAdaptiveCF) GetMaxIdleConnections() uint32 {
+	if x != nil {
+		return x.MaxIdleConnections
+	}
+	return 0
+}
+
 var File_pkg_agentcfg_agentcfg_proto protoreflect.FileDescriptor
 
 const file_pkg_agentcfg_agentcfg_proto_rawDesc = "" +
 	"\n" +
 	"\x1bpkg/agentcfg/agentcfg.proto\x12\x15plural.agent.agentcfg\x1a\x17validate/validate.proto\x1a\x1egoogle/protobuf/duration.proto\"%\n" +
 	"\x06PathCF\x12\x1b\n" +
-	"\x04glob\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04glob\"\x9e\x05\n" +
+	"\x04glob\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04glob\"\xc0\x05\n" +
 	"\x11ManifestProjectCF\x12\x1c\n" +
 	"\x02id\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01H\x01R\x02id\x88\x01\x01\x12,\n" +
 	"\x11default_namespace\x18\x04 \x01(\tR\x11default_namespace\x123\n" +
@@ -2137,13 +2668,15 @@ const file_pkg_agentcfg_agentcfg_proto_rawDesc = "" +
 	"foregroundR\x18prune_propagation_policy\x12a\n" +
 	"\x10inventory_policy\x18\v \x01(\tB5\xfaB2r0R\x00R\n" +
 	"must_matchR\x15adopt_if_no_inventoryR\tadopt_allR\x10inventory_policy\x121\n" +
-	"\x03ref\x18\f \x01(\v2\x1f.plural.agent.agentcfg.GitRefCFR\x03refB\r\n" +
+	"\x03ref\x18\f \x01(\v2\x1f.plural.agent.agentcfg.GitRefCFR\x03ref\x12 \n" +
+	"\vprune_empty\x18\r \x01(\bR\vprune_emptyB\r\n" +
 	"\vprune_oneofB\x05\n" +
-	"\x03_idJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"y\n" +
+	"\x03_idJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"\x9d\x01\n" +
 	"\bGitRefCF\x12\x1b\n" +
 	"\x03tag\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\x03tag\x12!\n" +
 	"\x06branch\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\x06branch\x12!\n" +
-	"\x06commit\x18\x03 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\x06commitB\n" +
+	"\x06commit\x18\x03 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\x06commit\x12\"\n" +
+	"\x04name\x18\x04 \x01(\tB\f\xfaB\tr\a:\x05refs/H\x00R\x04nameB\n" +
 	"\n" +
 	"\x03ref\x12\x03\xf8B\x01\"p\n" +
 	"\bGitopsCF\x12V\n" +
@@ -2230,7 +2763,7 @@ const file_pkg_agentcfg_agentcfg_proto_rawDesc = "" +
 	"\brequests\x18\x02 \x01(\v2\x1f.plural.agent.agentcfg.ResourceR\brequests\"4\n" +
 	"\bResource\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\tR\x03cpu\x12\x16\n" +
-	"\x06memory\x18\x02 \x01(\tR\x06memory\"\x93\x04\n" +
+	"\x06memory\x18\x02 \x01(\tR\x06memory\"\xf7\x05\n" +
 	"\x11ConfigurationFile\x127\n" +
 	"\x06gitops\x18\x01 \x01(\v2\x1f.plural.agent.agentcfg.GitopsCFR\x06gitops\x12L\n" +
 	"\robservability\x18\x02 \x01(\v2&.plural.agent.agentcfg.ObservabilityCFR\robservability\x12?\n" +
@@ -2238,7 +2771,11 @@ const file_pkg_agentcfg_agentcfg_proto_rawDesc = "" +
 	"\x12container_scanning\x18\x05 \x01(\v2*.plural.agent.agentcfg.ContainerScanningCFR\x12container_scanning\x12E\n" +
 	"\vuser_access\x18\x06 \x01(\v2#.plural.agent.agentcfg.UserAccessCFR\vuser_access\x12Z\n" +
 	"\x12remote_development\x18\a \x01(\v2*.plural.agent.agentcfg.RemoteDevelopmentCFR\x12remote_development\x121\n" +
-	"\x04flux\x18\b \x01(\v2\x1d.plural.agent.agentcfg.FluxCFR\x04fluxJ\x04\b\x03\x10\x04\"\xd7\x04\n" +
+	"\x04flux\x18\b \x01(\v2\x1d.plural.agent.agentcfg.FluxCFR\x04flux\x12K\n" +
+	"\rservice_proxy\x18\t \x01(\v2%.plural.agent.agentcfg.ServiceProxyCFR\rservice_proxy\x12E\n" +
+	"\vtcp_forward\x18\n" +
+	" \x01(\v2#.plural.agent.agentcfg.TcpForwardCFR\vtcp_forward\x12N\n" +
+	"\x0ereverse_tunnel\x18\v \x01(\v2&.plural.agent.agentcfg.ReverseTunnelCFR\x0ereverse_tunnelJ\x04\b\x03\x10\x04\"\xb8\x06\n" +
 	"\x12AgentConfiguration\x127\n" +
 	"\x06gitops\x18\x01 \x01(\v2\x1f.plural.agent.agentcfg.GitopsCFR\x06gitops\x12L\n" +
 	"\robservability\x18\x02 \x01(\v2&.plural.agent.agentcfg.ObservabilityCFR\robservability\x12\x19\n" +
@@ -2251,7 +2788,11 @@ const file_pkg_agentcfg_agentcfg_proto_rawDesc = "" +
 	"\x12remote_development\x18\t \x01(\v2*.plural.agent.agentcfg.RemoteDevelopmentCFR\x11remoteDevelopment\x121\n" +
 	"\x04flux\x18\n" +
 	" \x01(\v2\x1d.plural.agent.agentcfg.FluxCFR\x04flux\x12.\n" +
-	"\x13gitlab_external_url\x18\v \x01(\tR\x11gitlabExternalUrlJ\x04\b\x03\x10\x04\"5\n" +
+	"\x13gitlab_external_url\x18\v \x01(\tR\x11gitlabExternalUrl\x12J\n" +
+	"\rservice_proxy\x18\f \x01(\v2%.plural.agent.agentcfg.ServiceProxyCFR\fserviceProxy\x12D\n" +
+	"\vtcp_forward\x18\r \x01(\v2#.plural.agent.agentcfg.TcpForwardCFR\n" +
+	"tcpForward\x12M\n" +
+	"\x0ereverse_tunnel\x18\x0e \x01(\v2&.plural.agent.agentcfg.ReverseTunnelCFR\rreverseTunnelJ\x04\b\x03\x10\x04\"5\n" +
 	"\x15GitLabWorkspacesProxy\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"C\n" +
 	"\x16WorkspaceNetworkPolicy\x12\x1d\n" +
@@ -2266,7 +2807,35 @@ const file_pkg_agentcfg_agentcfg_proto_rawDesc = "" +
 	"\x17gitlab_workspaces_proxy\x18\x05 \x01(\v2,.plural.agent.agentcfg.GitLabWorkspacesProxyR\x17gitlab_workspaces_proxy\x12U\n" +
 	"\x0enetwork_policy\x18\x06 \x01(\v2-.plural.agent.agentcfg.WorkspaceNetworkPolicyR\x0enetwork_policy\"<\n" +
 	"\x06FluxCF\x122\n" +
-	"\x14webhook_receiver_url\x18\x01 \x01(\tR\x14webhook_receiver_url*:\n" +
+	"\x14webhook_receiver_url\x18\x01 \x01(\tR\x14webhook_receiver_url\"Z\n" +
+	"\x0eServiceProxyCF\x12H\n" +
+	"\bservices\x18\x01 \x03(\v2,.plural.agent.agentcfg.ServiceProxyServiceCFR\bservices\"\xbf\x01\n" +
+	"\x15ServiceProxyServiceCF\x12%\n" +
+	"\tnamespace\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\tnamespace\x12\x1b\n" +
+	"\x04name\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04name\x12&\n" +
+	"\x05ports\x18\x03 \x03(\rB\x10\xfaB\r\x92\x01\n" +
+	"\"\b*\x06\x18\xff\xff\x03 \x00R\x05ports\x12:\n" +
+	"\x18insecure_skip_tls_verify\x18\x04 \x01(\bR\x18insecure_skip_tls_verify\"S\n" +
+	"\fTcpForwardCF\x12C\n" +
+	"\atargets\x18\x01 \x03(\v2).plural.agent.agentcfg.TcpForwardTargetCFR\atargets\"\xbe\x01\n" +
+	"\x12TcpForwardTargetCF\x12P\n" +
+	"\aservice\x18\x01 \x01(\v2*.plural.agent.agentcfg.TcpForwardServiceCFB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\aservice\x12\x1d\n" +
+	"\x04host\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\x04host\x12(\n" +
+	"\x05ports\x18\x03 \x03(\rB\x12\xfaB\x0f\x92\x01\f\b\x01\"\b*\x06\x18\xff\xff\x03 \x00R\x05portsB\r\n" +
+	"\x06target\x12\x03\xf8B\x01\"Y\n" +
+	"\x13TcpForwardServiceCF\x12%\n" +
+	"\tnamespace\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\tnamespace\x12\x1b\n" +
+	"\x04name\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04name\"\xac\x02\n" +
+	"\x0fReverseTunnelCF\x122\n" +
+	"\x14min_idle_connections\x18\x01 \x01(\rR\x14min_idle_connections\x12(\n" +
+	"\x0fmax_connections\x18\x02 \x01(\rR\x0fmax_connections\x12$\n" +
+	"\rscale_up_step\x18\x03 \x01(\rR\rscale_up_step\x12I\n" +
+	"\rmax_idle_time\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\rmax_idle_time\x12J\n" +
+	"\badaptive\x18\x05 \x01(\v2..plural.agent.agentcfg.ReverseTunnelAdaptiveCFR\badaptive\"\xbe\x01\n" +
+	"\x17ReverseTunnelAdaptiveCF\x12;\n" +
+	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x06window\x122\n" +
+	"\x14min_idle_connections\x18\x02 \x01(\rR\x14min_idle_connections\x122\n" +
+	"\x14max_idle_connections\x18\x03 \x01(\rR\x14max_idle_connections*:\n" +
 	"\x0elog_level_enum\x12\b\n" +
 	"\x04info\x10\x00\x12\t\n" +
 	"\x05debug\x10\x01\x12\b\n" +
@@ -2286,7 +2855,7 @@ func file_pkg_agentcfg_agentcfg_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_agentcfg_agentcfg_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_agentcfg_agentcfg_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_pkg_agentcfg_agentcfg_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_pkg_agentcfg_agentcfg_proto_goTypes = []any{
 	(LogLevelEnum)(0),               // 0: plural.agent.agentcfg.log_level_enum
 	(*PathCF)(nil),                  // 1: plural.agent.agentcfg.PathCF
@@ -2321,12 +2890,19 @@ var file_pkg_agentcfg_agentcfg_proto_goTypes = []any{
 	(*WorkspaceNetworkPolicy)(nil),  // 30: plural.agent.agentcfg.WorkspaceNetworkPolicy
 	(*RemoteDevelopmentCF)(nil),     // 31: plural.agent.agentcfg.RemoteDevelopmentCF
 	(*FluxCF)(nil),                  // 32: plural.agent.agentcfg.FluxCF
-	(*durationpb.Duration)(nil),     // 33: google.protobuf.Duration
+	(*ServiceProxyCF)(nil),          // 33: plural.agent.agentcfg.ServiceProxyCF
+	(*ServiceProxyServiceCF)(nil),   // 34: plural.agent.agentcfg.ServiceProxyServiceCF
+	(*TcpForwardCF)(nil),            // 35: plural.agent.agentcfg.TcpForwardCF
+	(*TcpForwardTargetCF)(nil),      // 36: plural.agent.agentcfg.TcpForwardTargetCF
+	(*TcpForwardServiceCF)(nil),     // 37: plural.agent.agentcfg.TcpForwardServiceCF
+	(*ReverseTunnelCF)(nil),         // 38: plural.agent.agentcfg.ReverseTunnelCF
+	(*ReverseTunnelAdaptiveCF)(nil), // 39: plural.agent.agentcfg.ReverseTunnelAdaptiveCF
+	(*durationpb.Duration)(nil),     // 40: google.protobuf.Duration
 }
 var file_pkg_agentcfg_agentcfg_proto_depIdxs = []int32{
 	1,  // 0: plural.agent.agentcfg.ManifestProjectCF.paths:type_name -> plural.agent.agentcfg.PathCF
-	33, // 1: plural.agent.agentcfg.ManifestProjectCF.reconcile_timeout:type_name -> google.protobuf.Duration
-	33, // 2: plural.agent.agentcfg.ManifestProjectCF.prune_timeout:type_name -> google.protobuf.Duration
+	40, // 1: plural.agent.agentcfg.ManifestProjectCF.reconcile_timeout:type_name -> google.protobuf.Duration
+	40, // 2: plural.agent.agentcfg.ManifestProjectCF.prune_timeout:type_name -> google.protobuf.Duration
 	3,  // 3: plural.agent.agentcfg.ManifestProjectCF.ref:type_name -> plural.agent.agentcfg.GitRefCF
 	2,  // 4: plural.agent.agentcfg.GitopsCF.manifest_projects:type_name -> plural.agent.agentcfg.ManifestProjectCF
 	6,  // 5: plural.agent.agentcfg.ObservabilityCF.logging:type_name -> plural.agent.agentcfg.LoggingCF
@@ -2358,21 +2934,33 @@ var file_pkg_agentcfg_agentcfg_proto_depIdxs = []int32{
 	16, // 31: plural.agent.agentcfg.ConfigurationFile.user_access:type_name -> plural.agent.agentcfg.UserAccessCF
 	31, // 32: plural.agent.agentcfg.ConfigurationFile.remote_development:type_name -> plural.agent.agentcfg.RemoteDevelopmentCF
 	32, // 33: plural.agent.agentcfg.ConfigurationFile.flux:type_name -> plural.agent.agentcfg.FluxCF
-	4,  // 34: plural.agent.agentcfg.AgentConfiguration.gitops:type_name -> plural.agent.agentcfg.GitopsCF
-	5,  // 35: plural.agent.agentcfg.AgentConfiguration.observability:type_name -> plural.agent.agentcfg.ObservabilityCF
-	8,  // 36: plural.agent.agentcfg.AgentConfiguration.ci_access:type_name -> plural.agent.agentcfg.CiAccessCF
-	22, // 37: plural.agent.agentcfg.AgentConfiguration.container_scanning:type_name -> plural.agent.agentcfg.ContainerScanningCF
-	31, // 38: plural.agent.agentcfg.AgentConfiguration.remote_development:type_name -> plural.agent.agentcfg.RemoteDevelopmentCF
-	32, // 39: plural.agent.agentcfg.AgentConfiguration.flux:type_name -> plural.agent.agentcfg.FluxCF
-	33, // 40: plural.agent.agentcfg.RemoteDevelopmentCF.partial_sync_interval:type_name -> google.protobuf.Duration
-	33, // 41: plural.agent.agentcfg.RemoteDevelopmentCF.full_sync_interval:type_name -> google.protobuf.Duration
-	29, // 42: plural.agent.agentcfg.RemoteDevelopmentCF.gitlab_workspaces_proxy:type_name -> plural.agent.agentcfg.GitLabWorkspacesProxy
-	30, // 43: plural.agent.agentcfg.RemoteDevelopmentCF.network_policy:type_name -> plural.agent.agentcfg.WorkspaceNetworkPolicy
-	44, // [44:44] is the sub-list for method output_type
-	44, // [44:44] is the sub-list for method input_type
-	44, // [44:44] is the sub-list for extension type_name
-	44, // [44:44] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	33, // 34: plural.agent.agentcfg.ConfigurationFile.service_proxy:type_name -> plural.agent.agentcfg.ServiceProxyCF
+	35, // 35: plural.agent.agentcfg.ConfigurationFile.tcp_forward:type_name -> plural.agent.agentcfg.TcpForwardCF
+	38, // 36: plural.agent.agentcfg.ConfigurationFile.reverse_tunnel:type_name -> plural.agent.agentcfg.ReverseTunnelCF
+	4,  // 37: plural.agent.agentcfg.AgentConfiguration.gitops:type_name -> plural.agent.agentcfg.GitopsCF
+	5,  // 38: plural.agent.agentcfg.AgentConfiguration.observability:type_name -> plural.agent.agentcfg.ObservabilityCF
+	8,  // 39: plural.agent.agentcfg.AgentConfiguration.ci_access:type_name -> plural.agent.agentcfg.CiAccessCF
+	22, // 40: plural.agent.agentcfg.AgentConfiguration.container_scanning:type_name -> plural.agent.agentcfg.ContainerScanningCF
+	31, // 41: plural.agent.agentcfg.AgentConfiguration.remote_development:type_name -> plural.agent.agentcfg.RemoteDevelopmentCF
+	32, // 42: plural.agent.agentcfg.AgentConfiguration.flux:type_name -> plural.agent.agentcfg.FluxCF
+	33, // 43: plural.agent.agentcfg.AgentConfiguration.service_proxy:type_name -> plural.agent.agentcfg.ServiceProxyCF
+	35, // 44: plural.agent.agentcfg.AgentConfiguration.tcp_forward:type_name -> plural.agent.agentcfg.TcpForwardCF
+	38, // 45: plural.agent.agentcfg.AgentConfiguration.reverse_tunnel:type_name -> plural.agent.agentcfg.ReverseTunnelCF
+	40, // 46: plural.agent.agentcfg.RemoteDevelopmentCF.partial_sync_interval:type_name -> google.protobuf.Duration
+	40, // 47: plural.agent.agentcfg.RemoteDevelopmentCF.full_sync_interval:type_name -> google.protobuf.Duration
+	29, // 48: plural.agent.agentcfg.RemoteDevelopmentCF.gitlab_workspaces_proxy:type_name -> plural.agent.agentcfg.GitLabWorkspacesProxy
+	30, // 49: plural.agent.agentcfg.RemoteDevelopmentCF.network_policy:type_name -> plural.agent.agentcfg.WorkspaceNetworkPolicy
+	34, // 50: plural.agent.agentcfg.ServiceProxyCF.services:type_name -> plural.agent.agentcfg.ServiceProxyServiceCF
+	36, // 51: plural.agent.agentcfg.TcpForwardCF.targets:type_name -> plural.agent.agentcfg.TcpForwardTargetCF
+	37, // 52: plural.agent.agentcfg.TcpForwardTargetCF.service:type_name -> plural.agent.agentcfg.TcpForwardServiceCF
+	40, // 53: plural.agent.agentcfg.ReverseTunnelCF.max_idle_time:type_name -> google.protobuf.Duration
+	39, // 54: plural.agent.agentcfg.ReverseTunnelCF.adaptive:type_name -> plural.agent.agentcfg.ReverseTunnelAdaptiveCF
+	40, // 55: plural.agent.agentcfg.ReverseTunnelAdaptiveCF.window:type_name -> google.protobuf.Duration
+	56, // [56:56] is the sub-list for method output_type
+	56, // [56:56] is the sub-list for method input_type
+	56, // [56:56] is the sub-list for extension type_name
+	56, // [56:56] is the sub-list for extension extendee
+	0,  // [0:56] is the sub-list for field type_name
 }
 
 func init() { file_pkg_agentcfg_agentcfg_proto_init() }
@@ -2387,6 +2975,7 @@ func file_pkg_agentcfg_agentcfg_proto_init() {
 		(*GitRefCF_Tag)(nil),
 		(*GitRefCF_Branch)(nil),
 		(*GitRefCF_Commit)(nil),
+		(*GitRefCF_Name)(nil),
 	}
 	file_pkg_agentcfg_agentcfg_proto_msgTypes[5].OneofWrappers = []any{}
 	file_pkg_agentcfg_agentcfg_proto_msgTypes[10].OneofWrappers = []any{
@@ -2399,13 +2988,17 @@ func file_pkg_agentcfg_agentcfg_proto_init() {
 		(*UserAccessAsCF_User)(nil),
 	}
 	file_pkg_agentcfg_agentcfg_proto_msgTypes[29].OneofWrappers = []any{}
+	file_pkg_agentcfg_agentcfg_proto_msgTypes[35].OneofWrappers = []any{
+		(*TcpForwardTargetCF_Service)(nil),
+		(*TcpForwardTargetCF_Host)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_agentcfg_agentcfg_proto_rawDesc), len(file_pkg_agentcfg_agentcfg_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   32,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -322,6 +322,8 @@ func (m *ManifestProjectCF) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for PruneEmpty
+
 	switch v := m.PruneOneof.(type) {
 	case *ManifestProjectCF_Prune:
 		if v == nil {
@@ -551,6 +553,30 @@ func (m *GitRefCF) validate(all bool) error {
 			errors = append(errors, err)
 		}
 
+	case *GitRefCF_Name:
+		if v == nil {
+			err := GitRefCFValidationError{
+				field:  "Ref",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofRefPresent = true
+
+		if !strings.HasPrefix(m.GetName(), "refs/") {
+			err := GitRefCFValidationError{
+				field:  "Name",
+				reason: "value does not have prefix \"refs/\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
@@ -4157,6 +4183,93 @@ func (m *ConfigurationFile) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetServiceProxy()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "ServiceProxy",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "ServiceProxy",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetServiceProxy()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigurationFileValidationError{
+				field:  "ServiceProxy",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTcpForward()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "TcpForward",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "TcpForward",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTcpForward()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigurationFileValidationError{
+				field:  "TcpForward",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetReverseTunnel()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "ReverseTunnel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "ReverseTunnel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReverseTunnel()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigurationFileValidationError{
+				field:  "ReverseTunnel",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return ConfigurationFileMultiError(errors)
 	}
@@ -4441,6 +4554,93 @@ func (m *AgentConfiguration) validate(all bool) error {
 
 	// no validation rules for GitlabExternalUrl
 
+	if all {
+		switch v := interface{}(m.GetServiceProxy()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentConfigurationValidationError{
+					field:  "ServiceProxy",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentConfigurationValidationError{
+					field:  "ServiceProxy",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetServiceProxy()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentConfigurationValidationError{
+				field:  "ServiceProxy",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTcpForward()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentConfigurationValidationError{
+					field:  "TcpForward",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentConfigurationValidationError{
+					field:  "TcpForward",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTcpForward()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentConfigurationValidationError{
+				field:  "TcpForward",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetReverseTunnel()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentConfigurationValidationError{
+					field:  "ReverseTunnel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentConfigurationValidationError{
+					field:  "ReverseTunnel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReverseTunnel()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentConfigurationValidationError{
+				field:  "ReverseTunnel",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return AgentConfigurationMultiError(errors)
 	}
@@ -5054,3 +5254,1059 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = FluxCFValidationError{}
+
+// Validate checks the field values on ServiceProxyCF with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ServiceProxyCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServiceProxyCF with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ServiceProxyCFMultiError,
+// or nil if none found.
+func (m *ServiceProxyCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServiceProxyCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetServices() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ServiceProxyCFValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ServiceProxyCFValidationError{
+						field:  fmt.Sprintf("Services[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ServiceProxyCFValidationError{
+					field:  fmt.Sprintf("Services[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ServiceProxyCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceProxyCFMultiError is an error wrapping multiple validation errors
+// returned by ServiceProxyCF.ValidateAll() if the designated constraints
+// aren't met.
+type ServiceProxyCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceProxyCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceProxyCFMultiError) AllErrors() []error { return m }
+
+// ServiceProxyCFValidationError is the validation error returned by
+// ServiceProxyCF.Validate if the designated constraints aren't met.
+type ServiceProxyCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceProxyCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceProxyCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceProxyCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceProxyCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceProxyCFValidationError) ErrorName() string { return "ServiceProxyCFValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ServiceProxyCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServiceProxyCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceProxyCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceProxyCFValidationError{}
+
+// Validate checks the field values on ServiceProxyServiceCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ServiceProxyServiceCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ServiceProxyServiceCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ServiceProxyServiceCFMultiError, or nil if none found.
+func (m *ServiceProxyServiceCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ServiceProxyServiceCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetNamespace()) < 1 {
+		err := ServiceProxyServiceCFValidationError{
+			field:  "Namespace",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetName()) < 1 {
+		err := ServiceProxyServiceCFValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetPorts() {
+		_, _ = idx, item
+
+		if val := item; val <= 0 || val > 65535 {
+			err := ServiceProxyServiceCFValidationError{
+				field:  fmt.Sprintf("Ports[%v]", idx),
+				reason: "value must be inside range (0, 65535]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for InsecureSkipTlsVerify
+
+	if len(errors) > 0 {
+		return ServiceProxyServiceCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceProxyServiceCFMultiError is an error wrapping multiple validation
+// errors returned by ServiceProxyServiceCF.ValidateAll() if the designated
+// constraints aren't met.
+type ServiceProxyServiceCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceProxyServiceCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceProxyServiceCFMultiError) AllErrors() []error { return m }
+
+// ServiceProxyServiceCFValidationError is the validation error returned by
+// ServiceProxyServiceCF.Validate if the designated constraints aren't met.
+type ServiceProxyServiceCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceProxyServiceCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceProxyServiceCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceProxyServiceCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceProxyServiceCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceProxyServiceCFValidationError) ErrorName() string {
+	return "ServiceProxyServiceCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ServiceProxyServiceCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServiceProxyServiceCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceProxyServiceCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceProxyServiceCFValidationError{}
+
+// Validate checks the field values on TcpForwardCF with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TcpForwardCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TcpForwardCF with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TcpForwardCFMultiError, or
+// nil if none found.
+func (m *TcpForwardCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TcpForwardCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetTargets() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TcpForwardCFValidationError{
+						field:  fmt.Sprintf("Targets[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TcpForwardCFValidationError{
+						field:  fmt.Sprintf("Targets[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TcpForwardCFValidationError{
+					field:  fmt.Sprintf("Targets[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return TcpForwardCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// TcpForwardCFMultiError is an error wrapping multiple validation errors
+// returned by TcpForwardCF.ValidateAll() if the designated constraints aren't met.
+type TcpForwardCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TcpForwardCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TcpForwardCFMultiError) AllErrors() []error { return m }
+
+// TcpForwardCFValidationError is the validation error returned by
+// TcpForwardCF.Validate if the designated constraints aren't met.
+type TcpForwardCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TcpForwardCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TcpForwardCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TcpForwardCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TcpForwardCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TcpForwardCFValidationError) ErrorName() string { return "TcpForwardCFValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TcpForwardCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTcpForwardCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TcpForwardCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TcpForwardCFValidationError{}
+
+// Validate checks the field values on TcpForwardTargetCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TcpForwardTargetCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TcpForwardTargetCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TcpForwardTargetCFMultiError, or nil if none found.
+func (m *TcpForwardTargetCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TcpForwardTargetCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetPorts()) < 1 {
+		err := TcpForwardTargetCFValidationError{
+			field:  "Ports",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetPorts() {
+		_, _ = idx, item
+
+		if val := item; val <= 0 || val > 65535 {
+			err := TcpForwardTargetCFValidationError{
+				field:  fmt.Sprintf("Ports[%v]", idx),
+				reason: "value must be inside range (0, 65535]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	oneofTargetPresent := false
+	switch v := m.Target.(type) {
+	case *TcpForwardTargetCF_Service:
+		if v == nil {
+			err := TcpForwardTargetCFValidationError{
+				field:  "Target",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofTargetPresent = true
+
+		if m.GetService() == nil {
+			err := TcpForwardTargetCFValidationError{
+				field:  "Service",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetService()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TcpForwardTargetCFValidationError{
+						field:  "Service",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TcpForwardTargetCFValidationError{
+						field:  "Service",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetService()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TcpForwardTargetCFValidationError{
+					field:  "Service",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *TcpForwardTargetCF_Host:
+		if v == nil {
+			err := TcpForwardTargetCFValidationError{
+				field:  "Target",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofTargetPresent = true
+
+		if len(m.GetHost()) < 1 {
+			err := TcpForwardTargetCFValidationError{
+				field:  "Host",
+				reason: "value length must be at least 1 bytes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofTargetPresent {
+		err := TcpForwardTargetCFValidationError{
+			field:  "Target",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return TcpForwardTargetCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// TcpForwardTargetCFMultiError is an error wrapping multiple validation errors
+// returned by TcpForwardTargetCF.ValidateAll() if the designated constraints
+// aren't met.
+type TcpForwardTargetCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TcpForwardTargetCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TcpForwardTargetCFMultiError) AllErrors() []error { return m }
+
+// TcpForwardTargetCFValidationError is the validation error returned by
+// TcpForwardTargetCF.Validate if the designated constraints aren't met.
+type TcpForwardTargetCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TcpForwardTargetCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TcpForwardTargetCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TcpForwardTargetCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TcpForwardTargetCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TcpForwardTargetCFValidationError) ErrorName() string {
+	return "TcpForwardTargetCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TcpForwardTargetCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTcpForwardTargetCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TcpForwardTargetCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TcpForwardTargetCFValidationError{}
+
+// Validate checks the field values on TcpForwardServiceCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TcpForwardServiceCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TcpForwardServiceCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TcpForwardServiceCFMultiError, or nil if none found.
+func (m *TcpForwardServiceCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TcpForwardServiceCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetNamespace()) < 1 {
+		err := TcpForwardServiceCFValidationError{
+			field:  "Namespace",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetName()) < 1 {
+		err := TcpForwardServiceCFValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return TcpForwardServiceCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// TcpForwardServiceCFMultiError is an error wrapping multiple validation
+// errors returned by TcpForwardServiceCF.ValidateAll() if the designated
+// constraints aren't met.
+type TcpForwardServiceCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TcpForwardServiceCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TcpForwardServiceCFMultiError) AllErrors() []error { return m }
+
+// TcpForwardServiceCFValidationError is the validation error returned by
+// TcpForwardServiceCF.Validate if the designated constraints aren't met.
+type TcpForwardServiceCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TcpForwardServiceCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TcpForwardServiceCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TcpForwardServiceCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TcpForwardServiceCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TcpForwardServiceCFValidationError) ErrorName() string {
+	return "TcpForwardServiceCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TcpForwardServiceCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTcpForwardServiceCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TcpForwardServiceCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TcpForwardServiceCFValidationError{}
+
+// Validate checks the field values on ReverseTunnelCF with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReverseTunnelCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReverseTunnelCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReverseTunnelCFMultiError, or nil if none found.
+func (m *ReverseTunnelCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReverseTunnelCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for MinIdleConnections
+
+	// no validation rules for MaxConnections
+
+	// no validation rules for ScaleUpStep
+
+	if d := m.GetMaxIdleTime(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ReverseTunnelCFValidationError{
+				field:  "MaxIdleTime",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := ReverseTunnelCFValidationError{
+					field:  "MaxIdleTime",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAdaptive()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ReverseTunnelCFValidationError{
+					field:  "Adaptive",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ReverseTunnelCFValidationError{
+					field:  "Adaptive",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAdaptive()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReverseTunnelCFValidationError{
+				field:  "Adaptive",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ReverseTunnelCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReverseTunnelCFMultiError is an error wrapping multiple validation errors
+// returned by ReverseTunnelCF.ValidateAll() if the designated constraints
+// aren't met.
+type ReverseTunnelCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReverseTunnelCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReverseTunnelCFMultiError) AllErrors() []error { return m }
+
+// ReverseTunnelCFValidationError is the validation error returned by
+// ReverseTunnelCF.Validate if the designated constraints aren't met.
+type ReverseTunnelCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReverseTunnelCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReverseTunnelCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReverseTunnelCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReverseTunnelCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReverseTunnelCFValidationError) ErrorName() string { return "ReverseTunnelCFValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReverseTunnelCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReverseTunnelCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReverseTunnelCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReverseTunnelCFValidationError{}
+
+// Validate checks the field values on ReverseTunnelAdaptiveCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReverseTunnelAdaptiveCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReverseTunnelAdaptiveCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReverseTunnelAdaptiveCFMultiError, or nil if none found.
+func (m *ReverseTunnelAdaptiveCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReverseTunnelAdaptiveCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if d := m.GetWindow(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ReverseTunnelAdaptiveCFValidationError{
+				field:  "Window",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := ReverseTunnelAdaptiveCFValidationError{
+					field:  "Window",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	// no validation rules for MinIdleConnections
+
+	// no validation rules for MaxIdleConnections
+
+	if len(errors) > 0 {
+		return ReverseTunnelAdaptiveCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReverseTunnelAdaptiveCFMultiError is an error wrapping multiple validation
+// errors returned by ReverseTunnelAdaptiveCF.ValidateAll() if the designated
+// constraints aren't met.
+type ReverseTunnelAdaptiveCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReverseTunnelAdaptiveCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReverseTunnelAdaptiveCFMultiError) AllErrors() []error { return m }
+
+// ReverseTunnelAdaptiveCFValidationError is the validation error returned by
+// ReverseTunnelAdaptiveCF.Validate if the designated constraints aren't met.
+type ReverseTunnelAdaptiveCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReverseTunnelAdaptiveCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReverseTunnelAdaptiveCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReverseTunnelAdaptiveCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReverseTunnelAdaptiveCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReverseTunnelAdaptiveCFValidationError) ErrorName() string {
+	return "ReverseTunnelAdaptiveCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReverseTunnelAdaptiveCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReverseTunnelAdaptiveCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReverseTunnelAdaptiveCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReverseTunnelAdaptiveCFValidationError{}
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	console "github.com/pluralsh/console/go/client"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/plural"
+)
+
+// ConsoleSink sends events to Plural Console as cluster audit logs.
+// Console attributes audit logs to the user of the token, so only events with a PluralToken are sent.
+type ConsoleSink struct {
+	client *plural.Client
+}
+
+func NewConsoleSink(pluralUrl string) *ConsoleSink {
+	return &ConsoleSink{
+		client: plural.NewUnauthorized(pluralUrl),
+	}
+}
+
+func (s *ConsoleSink) Name() string {
+	return "console"
+}
+
+func (s *ConsoleSink) Write(ctx context.Context, events []*Event) (int, error) {
+	for i, e := range events {
+		if e.PluralToken == "" {
+			continue
+		}
+		responseCode := int64(e.ResponseStatus)
+		_, err := s.client.Console.AddClusterAuditLog(ctx, console.ClusterAuditAttributes{
+			ClusterID:    e.ClusterId,
+			Method:       e.Method,
+			Path:         e.Path,
+			ResponseCode: &responseCode,
+		}, plural.WithToken(e.PluralToken))
+		if err != nil {
+			if isPermanentConsoleError(err) {
+				return i, &RejectedError{Err: err}
+			}
+			return i, err
+		}
+	}
+	return len(events), nil
+}
+
+// isPermanentConsoleError returns true if Console has processed and refused the request.
+// Retrying such a request would fail again.
+func isPermanentConsoleError(err error) bool {
+	var e *clientv2.ErrorResponse
+	if !errors.As(err, &e) {
+		return false // transport error
+	}
+	if e.NetworkError == nil {
+		return true // GraphQL errors
+	}
+	switch code := e.NetworkError.Code; code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	default:
+		return code >= 400 && code < 500
+	}
+}
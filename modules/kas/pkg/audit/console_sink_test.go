@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestIsPermanentConsoleError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{
+			name: "transport error",
+			err:  errors.New("request failed: connection refused"),
+		},
+		{
+			name:      "GraphQL error",
+			err:       &clientv2.ErrorResponse{GqlErrors: &gqlerror.List{gqlerror.Errorf("forbidden")}},
+			permanent: true,
+		},
+		{
+			name:      "wrapped client error",
+			err:       fmt.Errorf("wrapped: %w", &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: http.StatusUnauthorized}}),
+			permanent: true,
+		},
+		{
+			name: "too many requests",
+			err:  &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: http.StatusTooManyRequests}},
+		},
+		{
+			name: "server error",
+			err:  &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: http.StatusBadGateway}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.permanent, isPermanentConsoleError(tc.err))
+		})
+	}
+}
+
+func TestConsoleSink_AuthenticatesEachEventWithItsToken(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		tokens []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"addClusterAuditLog":true}}`))
+	}))
+	defer srv.Close()
+
+	s := NewConsoleSink(srv.URL)
+	n, err := s.Write(context.Background(), []*Event{
+		{
+			PluralToken: "token1",
+		},
+		{}, // not sent
+		{
+			PluralToken: "token2",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"Token token1", "Token token2"}, tokens)
+}
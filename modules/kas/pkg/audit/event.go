@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Event is an audit record of a request made through kas.
+type Event struct {
+	Time time.Time `json:"time"`
+	// ClusterId is the Plural cluster id the request was made to.
+	ClusterId string `json:"cluster_id"`
+	// AuthnType is how the request was authenticated.
+	AuthnType string `json:"authn_type,omitempty"`
+	// User is the impersonated user. Empty if the request was made using agent's own identity.
+	User   string   `json:"user,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+	Method string   `json:"method"`
+	Path   string   `json:"path"`
+	// Kubernetes API request attributes. Empty if the request could not be parsed.
+	Verb        string `json:"verb,omitempty"`
+	ApiGroup    string `json:"api_group,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	// ResponseStatus is the HTTP status code of the response. Zero if no response has been sent.
+	ResponseStatus int           `json:"response_status"`
+	Latency        time.Duration `json:"latency_ns"`
+	TraceId        string        `json:"trace_id,omitempty"`
+	// PluralToken is the Plural Console token the request was authenticated with, if any.
+	// It is used to attribute the event to the user in Plural Console and is never serialized.
+	PluralToken string `json:"-"`
+}
+
+// Sink persists audit events.
+type Sink interface {
+	// Name returns sink's name. It is used in logs and metrics.
+	Name() string
+	// Write persists events in order. It returns the number of events that have been processed.
+	// Events after that are retried later if an error is returned.
+	// A *RejectedError means the event at the returned index can never be written and should be skipped.
+	Write(ctx context.Context, events []*Event) (int, error)
+}
+
+// RejectedError is returned by a Sink when an event was permanently rejected.
+type RejectedError struct {
+	Err error
+}
+
+func (e *RejectedError) Error() string {
+	return "event rejected: " + e.Err.Error()
+}
+
+func (e *RejectedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+)
+
+// FileSink writes events to a file as JSON lines.
+type FileSink struct {
+	w io.WriteCloser
+	// partial is the event that the last Write has written only partially, rest is the remainder of its line.
+	// The remainder is written first when the event is retried so that the file has no duplicate or torn lines.
+	partial *Event
+	rest    []byte
+}
+
+// NewFileSink opens the file for appending, creating it if needed.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{
+		w: f,
+	}, nil
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Write(ctx context.Context, events []*Event) (int, error) {
+	var buf bytes.Buffer
+	// ends[i] is the offset in buf right after the line of events[i].
+	ends := make([]int, 0, len(events))
+	if len(events) > 0 && events[0] == s.partial {
+		buf.Write(s.rest)
+		ends = append(ends, buf.Len())
+	}
+	s.partial = nil
+	s.rest = nil
+	enc := json.NewEncoder(&buf)
+	for i := len(ends); i < len(events); i++ {
+		if err := enc.Encode(events[i]); err != nil {
+			// Write what has been encoded so far and skip the event that cannot be encoded.
+			if n, werr := s.write(events, buf.Bytes(), ends); werr != nil {
+				return n, werr
+			}
+			return i, &RejectedError{Err: err}
+		}
+		ends = append(ends, buf.Len())
+	}
+	// A single write per batch so that events are not interleaved with other writers.
+	return s.write(events, buf.Bytes(), ends)
+}
+
+// write writes data and returns the number of events that have been written completely.
+// The remainder of a partially written event is kept for the next Write.
+func (s *FileSink) write(events []*Event, data []byte, ends []int) (int, error) {
+	n, err := s.w.Write(data)
+	if err == nil {
+		return len(ends), nil
+	}
+	written := sort.SearchInts(ends, n+1) // number of lines that end at or before n
+	start := 0
+	if written > 0 {
+		start = ends[written-1]
+	}
+	if written < len(ends) && n > start {
+		s.partial = events[written]
+		s.rest = bytes.Clone(data[n:ends[written]])
+	}
+	return written, err
+}
+
+func (s *FileSink) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink_AppendsJsonLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o600))
+
+	s, err := NewFileSink(path)
+	require.NoError(t, err)
+	events := []*Event{
+		{
+			Time:           time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			ClusterId:      "c1",
+			User:           "user1",
+			Groups:         []string{"g1"},
+			Method:         "GET",
+			Path:           "/api/v1/namespaces/ns1/pods",
+			Verb:           "list",
+			Resource:       "pods",
+			Namespace:      "ns1",
+			ResponseStatus: 200,
+			Latency:        time.Millisecond,
+			PluralToken:    "secret",
+		},
+		{
+			ClusterId: "c2",
+			Method:    "POST",
+		},
+	}
+	n, err := s.Write(context.Background(), events)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "{}", lines[0])
+	assert.NotContains(t, lines[1], "secret")
+	var e Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	events[0].PluralToken = ""
+	assert.Equal(t, events[0], &e)
+	assert.Contains(t, lines[2], `"cluster_id":"c2"`)
+}
+
+func TestFileSink_CompletesPartiallyWrittenEvent(t *testing.T) {
+	w := &shortWriter{
+		limit: 10, // in the middle of the first line
+	}
+	s := &FileSink{
+		w: w,
+	}
+	events := []*Event{
+		{
+			ClusterId: "c1",
+		},
+		{
+			ClusterId: "c2",
+		},
+	}
+	n, err := s.Write(context.Background(), events)
+	require.Error(t, err)
+	assert.Zero(t, n)
+
+	// The line of the first event is now longer than the limit. Allow the rest of it and a part of the next one.
+	w.limit = len(encodeEvents(t, events[:1])) + 5
+	n, err = s.Write(context.Background(), events)
+	require.Error(t, err)
+	assert.Equal(t, 1, n)
+
+	w.limit = -1
+	n, err = s.Write(context.Background(), events[1:])
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	assert.Equal(t, encodeEvents(t, events), w.buf.Bytes())
+}
+
+func encodeEvents(t *testing.T, events []*Event) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range events {
+		require.NoError(t, enc.Encode(e))
+	}
+	return buf.Bytes()
+}
+
+// shortWriter fails writes once limit bytes have been written in total. A negative limit means no limit.
+type shortWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if w.limit < 0 || w.buf.Len()+len(p) <= w.limit {
+		return w.buf.Write(p)
+	}
+	n, _ := w.buf.Write(p[:w.limit-w.buf.Len()])
+	return n, errors.New("disk full")
+}
+
+func (w *shortWriter) Close() error {
+	return nil
+}
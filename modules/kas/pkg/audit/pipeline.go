@@ -0,0 +1,244 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+)
+
+const (
+	droppedEventsMetricName = "audit_events_dropped_total"
+	writeErrorsMetricName   = "audit_write_errors_total"
+
+	dropReasonQueueFull = "queue_full"
+	dropReasonRejected  = "rejected"
+	dropReasonShutdown  = "shutdown"
+
+	initBackoff   = 1 * time.Second
+	resetDuration = 10 * time.Minute
+	backoffFactor = 2.0
+	jitter        = 1.0
+
+	// drainTimeout is how long to try to write buffered events on shutdown.
+	drainTimeout = 10 * time.Second
+)
+
+type PipelineConfig struct {
+	// QueueSize is the maximum number of events buffered per sink.
+	QueueSize int
+	// BatchSize is the maximum number of events written to a sink at once.
+	BatchSize int
+	// FlushInterval is how often to write buffered events even if a batch is not full.
+	FlushInterval time.Duration
+	// MaxRetryBackoff is the maximum delay between attempts to write a batch.
+	MaxRetryBackoff time.Duration
+}
+
+// Pipeline buffers audit events and writes them to sinks in batches.
+// Each sink has its own queue so that a slow or unavailable sink does not affect the others.
+// Events are dropped, counted and logged when a queue is full, e.g. while a sink is unavailable.
+type Pipeline struct {
+	queues        []*sinkQueue
+	droppedEvents *prometheus.CounterVec
+	writeErrors   *prometheus.CounterVec
+}
+
+func NewPipeline(log *zap.Logger, cfg PipelineConfig, sinks ...Sink) *Pipeline {
+	p := &Pipeline{
+		droppedEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: droppedEventsMetricName,
+			Help: "The total number of audit events that were not written to a sink",
+		}, []string{"sink", "reason"}),
+		writeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: writeErrorsMetricName,
+			Help: "The total number of failed attempts to write audit events to a sink",
+		}, []string{"sink"}),
+	}
+	pollConfig := retry.NewPollConfigFactory(0, retry.NewExponentialBackoffFactory(
+		initBackoff,
+		cfg.MaxRetryBackoff,
+		resetDuration,
+		backoffFactor,
+		jitter,
+	))
+	for _, sink := range sinks {
+		name := sink.Name()
+		p.queues = append(p.queues, &sinkQueue{
+			log:           log.With(logz.AuditSink(name)),
+			sink:          sink,
+			events:        make(chan *Event, cfg.QueueSize),
+			batchSize:     cfg.BatchSize,
+			flushInterval: cfg.FlushInterval,
+			pollConfig:    pollConfig,
+			queueFull:     p.droppedEvents.WithLabelValues(name, dropReasonQueueFull),
+			rejected:      p.droppedEvents.WithLabelValues(name, dropReasonRejected),
+			shutdown:      p.droppedEvents.WithLabelValues(name, dropReasonShutdown),
+			writeErrors:   p.writeErrors.WithLabelValues(name),
+		})
+	}
+	return p
+}
+
+// Collectors returns metrics of the pipeline that should be registered.
+func (p *Pipeline) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{p.droppedEvents, p.writeErrors}
+}
+
+// Record enqueues an event for all sinks. It never blocks.
+// The event must not be mutated after it has been recorded.
+func (p *Pipeline) Record(e *Event) {
+	for _, q := range p.queues {
+		select {
+		case q.events <- e:
+		default:
+			q.queueFull.Inc()
+			q.dropped.Add(1)
+		}
+	}
+}
+
+// Run writes recorded events to sinks until the context is done.
+// Then it makes a final attempt to write buffered events and closes sinks that implement io.Closer.
+func (p *Pipeline) Run(ctx context.Context) {
+	var wg wait.Group
+	defer wg.Wait()
+	for _, q := range p.queues {
+		wg.Start(func() {
+			q.run(ctx)
+		})
+	}
+}
+
+type sinkQueue struct {
+	log           *zap.Logger
+	sink          Sink
+	events        chan *Event
+	batchSize     int
+	flushInterval time.Duration
+	pollConfig    retry.PollConfigFactory
+	queueFull     prometheus.Counter
+	rejected      prometheus.Counter
+	shutdown      prometheus.Counter
+	writeErrors   prometheus.Counter
+	// dropped is the number of events dropped because the queue was full since they were last logged.
+	dropped atomic.Uint64
+}
+
+func (q *sinkQueue) run(ctx context.Context) {
+	defer q.close()
+	batch := make([]*Event, 0, q.batchSize)
+	t := time.NewTicker(q.flushInterval)
+	defer t.Stop()
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			q.drain(batch)
+			return
+		case e := <-q.events:
+			batch = append(batch, e)
+			if len(batch) < q.batchSize {
+				continue
+			}
+		case <-t.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		batch = q.flush(ctx, batch)
+		q.logDropped()
+	}
+}
+
+// flush writes the batch, retrying with backoff until it succeeds or the context is done.
+// It returns events that have not been written.
+func (q *sinkQueue) flush(ctx context.Context, batch []*Event) []*Event {
+	_ = retry.PollWithBackoff(ctx, q.pollConfig(), func(ctx context.Context) (error, retry.AttemptResult) {
+		var err error
+		batch, err = q.write(ctx, batch)
+		switch {
+		case err == nil, len(batch) == 0:
+			return nil, retry.Done
+		case isRejected(err):
+			return nil, retry.ContinueImmediately
+		default:
+			q.writeErrors.Inc()
+			q.log.Warn("Failed to write audit events, will retry", logz.Error(err))
+			// Events are not taken from the queue while backing off so it may fill up.
+			q.logDropped()
+			return nil, retry.Backoff
+		}
+	})
+	return batch
+}
+
+// logDropped logs the number of events that have been dropped since the last call, if any.
+func (q *sinkQueue) logDropped() {
+	if n := q.dropped.Swap(0); n > 0 {
+		q.log.Warn("Audit event queue is full, events have been dropped", logz.U64Count(n))
+	}
+}
+
+// drain makes a single attempt to write all buffered events.
+func (q *sinkQueue) drain(batch []*Event) {
+loop:
+	for {
+		select {
+		case e := <-q.events:
+			batch = append(batch, e)
+		default:
+			break loop
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	defer q.logDropped()
+	for len(batch) > 0 {
+		var err error
+		batch, err = q.write(ctx, batch)
+		if err == nil || isRejected(err) {
+			continue
+		}
+		q.writeErrors.Inc()
+		q.shutdown.Add(float64(len(batch)))
+		q.log.Error("Failed to write audit events on shutdown", logz.Error(err))
+		return
+	}
+}
+
+// write writes the batch to the sink and returns events that have not been processed.
+// A rejected event is logged, counted and removed from the batch.
+func (q *sinkQueue) write(ctx context.Context, batch []*Event) ([]*Event, error) {
+	n, err := q.sink.Write(ctx, batch)
+	if isRejected(err) {
+		q.rejected.Inc()
+		q.log.Error("Audit event rejected", logz.Error(err))
+		n++
+	}
+	return slices.Delete(batch, 0, n), err
+}
+
+func (q *sinkQueue) close() {
+	c, ok := q.sink.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := c.Close(); err != nil {
+		q.log.Error("Failed to close audit sink", logz.Error(err))
+	}
+}
+
+func isRejected(err error) bool {
+	var rejected *RejectedError
+	return errors.As(err, &rejected)
+}
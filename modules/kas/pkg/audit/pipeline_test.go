@@ -0,0 +1,252 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var (
+	_ Sink = (*FileSink)(nil)
+	_ Sink = (*ConsoleSink)(nil)
+)
+
+func TestPipeline_WritesInBatches(t *testing.T) {
+	sink := &fakeSink{}
+	p := newTestPipeline(t, 2, sink)
+	events := recordEvents(p, 5)
+
+	runPipeline(t, p, func() bool {
+		return sink.len() == 4 // two full batches
+	})
+
+	assert.Equal(t, events, sink.written())
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4}}, sink.batchPaths())
+}
+
+func TestPipeline_RetriesFailedWrites(t *testing.T) {
+	sink := &fakeSink{
+		errs: []error{errors.New("unavailable"), errors.New("unavailable")},
+	}
+	p := newTestPipeline(t, 3, sink)
+	events := recordEvents(p, 3)
+
+	runPipeline(t, p, func() bool {
+		return sink.len() == 3
+	})
+
+	assert.Equal(t, events, sink.written())
+	assert.EqualValues(t, 2, testutil.ToFloat64(p.writeErrors.WithLabelValues("fake")))
+}
+
+func TestPipeline_SkipsRejectedEvents(t *testing.T) {
+	sink := &fakeSink{
+		errs: []error{&RejectedError{Err: errors.New("bad event")}},
+	}
+	p := newTestPipeline(t, 3, sink)
+	events := recordEvents(p, 3)
+
+	runPipeline(t, p, func() bool {
+		return sink.len() == 2
+	})
+
+	assert.Equal(t, events[1:], sink.written())
+	assert.EqualValues(t, 1, testutil.ToFloat64(p.droppedEvents.WithLabelValues("fake", dropReasonRejected)))
+	assert.Zero(t, testutil.ToFloat64(p.writeErrors.WithLabelValues("fake")))
+}
+
+func TestPipeline_DropsWhenQueueIsFull(t *testing.T) {
+	sink := &fakeSink{}
+	p := NewPipeline(zaptest.NewLogger(t), PipelineConfig{
+		QueueSize:       2,
+		BatchSize:       10,
+		FlushInterval:   time.Hour,
+		MaxRetryBackoff: time.Second,
+	}, sink)
+	events := recordEvents(p, 3)
+
+	assert.EqualValues(t, 1, testutil.ToFloat64(p.droppedEvents.WithLabelValues("fake", dropReasonQueueFull)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // drain on shutdown
+	p.Run(ctx)
+
+	assert.Equal(t, events[:2], sink.written())
+	assert.True(t, sink.closed)
+}
+
+func TestPipeline_DropsAndLogsWhileBackingOff(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	sink := &unavailableSink{}
+	p := NewPipeline(zap.New(core), PipelineConfig{
+		QueueSize:       2,
+		BatchSize:       1,
+		FlushInterval:   time.Hour,
+		MaxRetryBackoff: 10 * time.Millisecond,
+	}, sink)
+	q := p.queues[0]
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	events := recordEvents(p, 1)
+	require.Eventually(t, func() bool {
+		return len(q.events) == 0 && testutil.ToFloat64(p.writeErrors.WithLabelValues("fake")) > 0
+	}, 10*time.Second, time.Millisecond)
+
+	// The first event is being retried. Two more fit into the queue and the rest is dropped.
+	for i := 1; i < 5; i++ {
+		e := &Event{
+			Path: string(rune('0' + i)),
+		}
+		events = append(events, e)
+		p.Record(e)
+	}
+	assert.EqualValues(t, 2, testutil.ToFloat64(p.droppedEvents.WithLabelValues("fake", dropReasonQueueFull)))
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Audit event queue is full, events have been dropped").
+			FilterField(zap.Uint64("count", 2)).Len() == 1
+	}, 10*time.Second, time.Millisecond)
+
+	sink.available.Store(true)
+	require.Eventually(t, func() bool {
+		return sink.len() == 3
+	}, 10*time.Second, time.Millisecond)
+	assert.Equal(t, events[:3], sink.written())
+}
+
+func TestPipeline_CountsEventsLostOnShutdown(t *testing.T) {
+	sink := &fakeSink{
+		errs: []error{errors.New("unavailable")},
+	}
+	p := newTestPipeline(t, 10, sink)
+	recordEvents(p, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.Run(ctx)
+
+	assert.Empty(t, sink.written())
+	assert.EqualValues(t, 3, testutil.ToFloat64(p.droppedEvents.WithLabelValues("fake", dropReasonShutdown)))
+}
+
+func newTestPipeline(t *testing.T, batchSize int, sink Sink) *Pipeline {
+	return NewPipeline(zaptest.NewLogger(t), PipelineConfig{
+		QueueSize:       10,
+		BatchSize:       batchSize,
+		FlushInterval:   time.Hour, // only full batches are written before shutdown
+		MaxRetryBackoff: 10 * time.Millisecond,
+	}, sink)
+}
+
+func recordEvents(p *Pipeline, n int) []*Event {
+	events := make([]*Event, 0, n)
+	for i := range n {
+		e := &Event{
+			Path: string(rune('0' + i)),
+		}
+		events = append(events, e)
+		p.Record(e)
+	}
+	return events
+}
+
+// runPipeline runs the pipeline until cond is true and then shuts it down.
+func runPipeline(t *testing.T, p *Pipeline, cond func() bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.Run(ctx)
+	}()
+	require.Eventually(t, cond, 10*time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+type fakeSink struct {
+	mu      sync.Mutex
+	batches [][]*Event
+	// errs are returned by consecutive failing Write calls. A RejectedError rejects the first event of the batch.
+	errs   []error
+	closed bool
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Write(ctx context.Context, events []*Event) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return 0, err
+	}
+	s.batches = append(s.batches, append([]*Event(nil), events...))
+	return len(events), nil
+}
+
+// unavailableSink fails all writes until it becomes available.
+type unavailableSink struct {
+	fakeSink
+	available atomic.Bool
+}
+
+func (s *unavailableSink) Write(ctx context.Context, events []*Event) (int, error) {
+	if !s.available.Load() {
+		return 0, errors.New("unavailable")
+	}
+	return s.fakeSink.Write(ctx, events)
+}
+
+func (s *fakeSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSink) written() []*Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []*Event
+	for _, b := range s.batches {
+		events = append(events, b...)
+	}
+	return events
+}
+
+func (s *fakeSink) len() int {
+	return len(s.written())
+}
+
+// batchPaths returns indexes of written events, as recorded by recordEvents, grouped by batch.
+func (s *fakeSink) batchPaths() [][]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res [][]int
+	for _, b := range s.batches {
+		var batch []int
+		for _, e := range b {
+			batch = append(batch, int(e.Path[0]-'0'))
+		}
+		res = append(res, batch)
+	}
+	return res
+}
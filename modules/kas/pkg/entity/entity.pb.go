@@ -23,6 +23,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// VersionSkew is how the version of agentk relates to the versions kas supports.
+type VersionSkew int32
+
+const (
+	// Versions could not be compared, e.g. because agentk or kas is a development build.
+	VersionSkew_version_skew_unknown VersionSkew = 0
+	// agentk version is supported.
+	VersionSkew_supported VersionSkew = 1
+	// agentk is older than the oldest version kas supports.
+	VersionSkew_agent_too_old VersionSkew = 2
+	// agentk is newer than kas.
+	VersionSkew_agent_too_new VersionSkew = 3
+)
+
+// Enum value maps for VersionSkew.
+var (
+	VersionSkew_name = map[int32]string{
+		0: "version_skew_unknown",
+		1: "supported",
+		2: "agent_too_old",
+		3: "agent_too_new",
+	}
+	VersionSkew_value = map[string]int32{
+		"version_skew_unknown": 0,
+		"supported":            1,
+		"agent_too_old":        2,
+		"agent_too_new":        3,
+	}
+)
+
+func (x VersionSkew) Enum() *VersionSkew {
+	p := new(VersionSkew)
+	*p = x
+	return p
+}
+
+func (x VersionSkew) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VersionSkew) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_entity_entity_proto_enumTypes[0].Descriptor()
+}
+
+func (VersionSkew) Type() protoreflect.EnumType {
+	return &file_pkg_entity_entity_proto_enumTypes[0]
+}
+
+func (x VersionSkew) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VersionSkew.Descriptor instead.
+func (VersionSkew) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_entity_entity_proto_rawDescGZIP(), []int{0}
+}
+
 // AgentMeta contains information about agentk.
 type AgentMeta struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -36,8 +93,10 @@ type AgentMeta struct {
 	PodName string `protobuf:"bytes,4,opt,name=pod_name,proto3" json:"pod_name,omitempty"`
 	// Version of the Kubernetes cluster.
 	KubernetesVersion *KubernetesVersion `protobuf:"bytes,5,opt,name=kubernetes_version,proto3" json:"kubernetes_version,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Features enabled in the binary i.e. names of the modules it runs.
+	Features      []string `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AgentMeta) Reset() {
@@ -105,6 +164,13 @@ func (x *AgentMeta) GetKubernetesVersion() *KubernetesVersion {
 	return nil
 }
 
+func (x *AgentMeta) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
 // Version information of the Kubernetes cluster.
 type KubernetesVersion struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -178,22 +244,187 @@ func (x *KubernetesVersion) GetPlatform() string {
 	return ""
 }
 
+// ClusterMeta contains information about the Kubernetes cluster agentk runs in.
+// Fields are empty if agentk could not determine them.
+type ClusterMeta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of nodes in the cluster.
+	NodeCount int64 `protobuf:"varint,1,opt,name=node_count,proto3" json:"node_count,omitempty"`
+	// Cloud provider the cluster runs on, e.g. "aws", "gce", "azure".
+	CloudProvider string `protobuf:"bytes,2,opt,name=cloud_provider,proto3" json:"cloud_provider,omitempty"`
+	// Container network interface plugin of the cluster, e.g. "calico", "cilium".
+	Cni string `protobuf:"bytes,3,opt,name=cni,proto3" json:"cni,omitempty"`
+	// Region of the cloud provider the cluster runs in.
+	Region        string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterMeta) Reset() {
+	*x = ClusterMeta{}
+	mi := &file_pkg_entity_entity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterMeta) ProtoMessage() {}
+
+func (x *ClusterMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_entity_entity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterMeta.ProtoReflect.Descriptor instead.
+func (*ClusterMeta) Descriptor() ([]byte, []int) {
+	return file_pkg_entity_entity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ClusterMeta) GetNodeCount() int64 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+func (x *ClusterMeta) GetCloudProvider() string {
+	if x != nil {
+		return x.CloudProvider
+	}
+	return ""
+}
+
+func (x *ClusterMeta) GetCni() string {
+	if x != nil {
+		return x.Cni
+	}
+	return ""
+}
+
+func (x *ClusterMeta) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+// VersionSkewVerdict is what kas decided about the version of agentk.
+type VersionSkewVerdict struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Skew  VersionSkew            `protobuf:"varint,1,opt,name=skew,proto3,enum=plural.agent.entity.VersionSkew" json:"skew,omitempty"`
+	// Version of kas.
+	KasVersion string `protobuf:"bytes,2,opt,name=kas_version,proto3" json:"kas_version,omitempty"`
+	// What kas does with requests from agentk: "warn", "restrict" or "reject".
+	// Only set if the agentk version is not supported.
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Human-readable explanation of the verdict.
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionSkewVerdict) Reset() {
+	*x = VersionSkewVerdict{}
+	mi := &file_pkg_entity_entity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionSkewVerdict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionSkewVerdict) ProtoMessage() {}
+
+func (x *VersionSkewVerdict) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_entity_entity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionSkewVerdict.ProtoReflect.Descriptor instead.
+func (*VersionSkewVerdict) Descriptor() ([]byte, []int) {
+	return file_pkg_entity_entity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VersionSkewVerdict) GetSkew() VersionSkew {
+	if x != nil {
+		return x.Skew
+	}
+	return VersionSkew_version_skew_unknown
+}
+
+func (x *VersionSkewVerdict) GetKasVersion() string {
+	if x != nil {
+		return x.KasVersion
+	}
+	return ""
+}
+
+func (x *VersionSkewVerdict) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *VersionSkewVerdict) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_pkg_entity_entity_proto protoreflect.FileDescriptor
 
 const file_pkg_entity_entity_proto_rawDesc = "" +
 	"\n" +
-	"\x17pkg/entity/entity.proto\x12\x13plural.agent.entity\"\xdd\x01\n" +
+	"\x17pkg/entity/entity.proto\x12\x13plural.agent.entity\"\xf9\x01\n" +
 	"\tAgentMeta\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1c\n" +
 	"\tcommit_id\x18\x02 \x01(\tR\tcommit_id\x12$\n" +
 	"\rpod_namespace\x18\x03 \x01(\tR\rpod_namespace\x12\x1a\n" +
 	"\bpod_name\x18\x04 \x01(\tR\bpod_name\x12V\n" +
-	"\x12kubernetes_version\x18\x05 \x01(\v2&.plural.agent.entity.KubernetesVersionR\x12kubernetes_version\"}\n" +
+	"\x12kubernetes_version\x18\x05 \x01(\v2&.plural.agent.entity.KubernetesVersionR\x12kubernetes_version\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\tR\bfeatures\"}\n" +
 	"\x11KubernetesVersion\x12\x14\n" +
 	"\x05major\x18\x01 \x01(\tR\x05major\x12\x14\n" +
 	"\x05minor\x18\x02 \x01(\tR\x05minor\x12 \n" +
 	"\vgit_version\x18\x03 \x01(\tR\vgit_version\x12\x1a\n" +
-	"\bplatform\x18\x04 \x01(\tR\bplatformB1Z/github.com/pluralsh/kubernetes-agent/pkg/entityb\x06proto3"
+	"\bplatform\x18\x04 \x01(\tR\bplatform\"\x7f\n" +
+	"\vClusterMeta\x12\x1e\n" +
+	"\n" +
+	"node_count\x18\x01 \x01(\x03R\n" +
+	"node_count\x12&\n" +
+	"\x0ecloud_provider\x18\x02 \x01(\tR\x0ecloud_provider\x12\x10\n" +
+	"\x03cni\x18\x03 \x01(\tR\x03cni\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\"\x9e\x01\n" +
+	"\x12VersionSkewVerdict\x124\n" +
+	"\x04skew\x18\x01 \x01(\x0e2 .plural.agent.entity.VersionSkewR\x04skew\x12 \n" +
+	"\vkas_version\x18\x02 \x01(\tR\vkas_version\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage*\\\n" +
+	"\vVersionSkew\x12\x18\n" +
+	"\x14version_skew_unknown\x10\x00\x12\r\n" +
+	"\tsupported\x10\x01\x12\x11\n" +
+	"\ragent_too_old\x10\x02\x12\x11\n" +
+	"\ragent_too_new\x10\x03B1Z/github.com/pluralsh/kubernetes-agent/pkg/entityb\x06proto3"
 
 var (
 	file_pkg_entity_entity_proto_rawDescOnce sync.Once
@@ -207,18 +438,23 @@ func file_pkg_entity_entity_proto_rawDescGZIP() []byte {
 	return file_pkg_entity_entity_proto_rawDescData
 }
 
-var file_pkg_entity_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_entity_entity_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_entity_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_pkg_entity_entity_proto_goTypes = []any{
-	(*AgentMeta)(nil),         // 0: plural.agent.entity.AgentMeta
-	(*KubernetesVersion)(nil), // 1: plural.agent.entity.KubernetesVersion
+	(VersionSkew)(0),           // 0: plural.agent.entity.VersionSkew
+	(*AgentMeta)(nil),          // 1: plural.agent.entity.AgentMeta
+	(*KubernetesVersion)(nil),  // 2: plural.agent.entity.KubernetesVersion
+	(*ClusterMeta)(nil),        // 3: plural.agent.entity.ClusterMeta
+	(*VersionSkewVerdict)(nil), // 4: plural.agent.entity.VersionSkewVerdict
 }
 var file_pkg_entity_entity_proto_depIdxs = []int32{
-	1, // 0: plural.agent.entity.AgentMeta.kubernetes_version:type_name -> plural.agent.entity.KubernetesVersion
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: plural.agent.entity.AgentMeta.kubernetes_version:type_name -> plural.agent.entity.KubernetesVersion
+	0, // 1: plural.agent.entity.VersionSkewVerdict.skew:type_name -> plural.agent.entity.VersionSkew
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_pkg_entity_entity_proto_init() }
@@ -231,13 +467,14 @@ func file_pkg_entity_entity_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_entity_entity_proto_rawDesc), len(file_pkg_entity_entity_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_pkg_entity_entity_proto_goTypes,
 		DependencyIndexes: file_pkg_entity_entity_proto_depIdxs,
+		EnumInfos:         file_pkg_entity_entity_proto_enumTypes,
 		MessageInfos:      file_pkg_entity_entity_proto_msgTypes,
 	}.Build()
 	File_pkg_entity_entity_proto = out.File
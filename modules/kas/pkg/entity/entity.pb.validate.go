@@ -280,3 +280,220 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = KubernetesVersionValidationError{}
+
+// Validate checks the field values on ClusterMeta with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ClusterMeta) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ClusterMeta with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ClusterMetaMultiError, or
+// nil if none found.
+func (m *ClusterMeta) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ClusterMeta) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for NodeCount
+
+	// no validation rules for CloudProvider
+
+	// no validation rules for Cni
+
+	// no validation rules for Region
+
+	if len(errors) > 0 {
+		return ClusterMetaMultiError(errors)
+	}
+
+	return nil
+}
+
+// ClusterMetaMultiError is an error wrapping multiple validation errors
+// returned by ClusterMeta.ValidateAll() if the designated constraints aren't met.
+type ClusterMetaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ClusterMetaMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ClusterMetaMultiError) AllErrors() []error { return m }
+
+// ClusterMetaValidationError is the validation error returned by
+// ClusterMeta.Validate if the designated constraints aren't met.
+type ClusterMetaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ClusterMetaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ClusterMetaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ClusterMetaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ClusterMetaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ClusterMetaValidationError) ErrorName() string { return "ClusterMetaValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ClusterMetaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sClusterMeta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ClusterMetaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ClusterMetaValidationError{}
+
+// Validate checks the field values on VersionSkewVerdict with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VersionSkewVerdict) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VersionSkewVerdict with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VersionSkewVerdictMultiError, or nil if none found.
+func (m *VersionSkewVerdict) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VersionSkewVerdict) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Skew
+
+	// no validation rules for KasVersion
+
+	// no validation rules for Action
+
+	// no validation rules for Message
+
+	if len(errors) > 0 {
+		return VersionSkewVerdictMultiError(errors)
+	}
+
+	return nil
+}
+
+// VersionSkewVerdictMultiError is an error wrapping multiple validation errors
+// returned by VersionSkewVerdict.ValidateAll() if the designated constraints
+// aren't met.
+type VersionSkewVerdictMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VersionSkewVerdictMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VersionSkewVerdictMultiError) AllErrors() []error { return m }
+
+// VersionSkewVerdictValidationError is the validation error returned by
+// VersionSkewVerdict.Validate if the designated constraints aren't met.
+type VersionSkewVerdictValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VersionSkewVerdictValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VersionSkewVerdictValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VersionSkewVerdictValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VersionSkewVerdictValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VersionSkewVerdictValidationError) ErrorName() string {
+	return "VersionSkewVerdictValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VersionSkewVerdictValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVersionSkewVerdict.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VersionSkewVerdictValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VersionSkewVerdictValidationError{}
@@ -100,8 +100,11 @@ type ListenAgentCF struct {
 	MaxConnectionAge *durationpb.Duration `protobuf:"bytes,7,opt,name=max_connection_age,proto3" json:"max_connection_age,omitempty"`
 	// How much time to wait before stopping accepting new connections on shutdown.
 	ListenGracePeriod *durationpb.Duration `protobuf:"bytes,8,opt,name=listen_grace_period,proto3" json:"listen_grace_period,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// How much time to wait for in-flight requests to agents to finish on shutdown.
+	// Once the time is up, remaining agent connections are closed.
+	DrainGracePeriod *durationpb.Duration `protobuf:"bytes,9,opt,name=drain_grace_period,proto3" json:"drain_grace_period,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ListenAgentCF) Reset() {
@@ -190,6 +193,13 @@ func (x *ListenAgentCF) GetListenGracePeriod() *durationpb.Duration {
 	return nil
 }
 
+func (x *ListenAgentCF) GetDrainGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.DrainGracePeriod
+	}
+	return nil
+}
+
 type PrometheusCF struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Expected URL path for requests.
@@ -587,8 +597,27 @@ type KubernetesApiCF struct {
 	// TTL for failed allowed agent lookups.
 	// /api/v4/job/allowed_agents
 	AllowedAgentCacheErrorTtl *durationpb.Duration `protobuf:"bytes,4,opt,name=allowed_agent_cache_error_ttl,proto3" json:"allowed_agent_cache_error_ttl,omitempty"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+	// Additional ways to authenticate users of the proxy.
+	// Plural Console tokens (`Bearer plrl:<cluster id>:<token>`) are always accepted.
+	Authentication *KubernetesApiAuthenticationCF `protobuf:"bytes,5,opt,name=authentication,proto3" json:"authentication,omitempty"`
+	// Policies to evaluate, in order, for each authenticated request before it is proxied to the agent.
+	// The first matching policy decides if the request is allowed or denied.
+	// Requests that don't match any policy are allowed.
+	Policies []*KubernetesApiPolicyCF `protobuf:"bytes,6,rep,name=policies,proto3" json:"policies,omitempty"`
+	// Audit log of proxied requests.
+	Audit *KubernetesApiAuditCF `protobuf:"bytes,7,opt,name=audit,proto3" json:"audit,omitempty"`
+	// Limits for proxied requests.
+	Limits *KubernetesApiLimitsCF `protobuf:"bytes,8,opt,name=limits,proto3" json:"limits,omitempty"`
+	// Kubeconfig endpoint, served at `<url_path_prefix>-/kubeconfig`.
+	// Returns a kubeconfig with all connected clusters that the caller can access.
+	Kubeconfig *KubernetesApiKubeconfigCF `protobuf:"bytes,9,opt,name=kubeconfig,proto3" json:"kubeconfig,omitempty"`
+	// Cache of discovery and OpenAPI responses, per cluster and impersonated identity.
+	// Not enabled if not set.
+	DiscoveryCache *KubernetesApiDiscoveryCacheCF `protobuf:"bytes,10,opt,name=discovery_cache,proto3" json:"discovery_cache,omitempty"`
+	// Recording of exec and attach sessions in asciinema v2 format. Not enabled if not set.
+	SessionRecording *KubernetesApiSessionRecordingCF `protobuf:"bytes,11,opt,name=session_recording,proto3" json:"session_recording,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *KubernetesApiCF) Reset() {
@@ -649,47 +678,1174 @@ func (x *KubernetesApiCF) GetAllowedAgentCacheErrorTtl() *durationpb.Duration {
 	return nil
 }
 
-type AgentCF struct {
+func (x *KubernetesApiCF) GetAuthentication() *KubernetesApiAuthenticationCF {
+	if x != nil {
+		return x.Authentication
+	}
+	return nil
+}
+
+func (x *KubernetesApiCF) GetPolicies() []*KubernetesApiPolicyCF {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *KubernetesApiCF) GetAudit() *KubernetesApiAuditCF {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *KubernetesApiCF) GetLimits() *KubernetesApiLimitsCF {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *KubernetesApiCF) GetKubeconfig() *KubernetesApiKubeconfigCF {
+	if x != nil {
+		return x.Kubeconfig
+	}
+	return nil
+}
+
+func (x *KubernetesApiCF) GetDiscoveryCache() *KubernetesApiDiscoveryCacheCF {
+	if x != nil {
+		return x.DiscoveryCache
+	}
+	return nil
+}
+
+func (x *KubernetesApiCF) GetSessionRecording() *KubernetesApiSessionRecordingCF {
+	if x != nil {
+		return x.SessionRecording
+	}
+	return nil
+}
+
+// KubernetesApiPolicyCF matches requests by the conditions below. All non-empty conditions must match.
+// An empty condition or a condition that contains "*" matches anything.
+type KubernetesApiPolicyCF struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// RPC listener configuration for agentk connections.
-	Listen *ListenAgentCF `protobuf:"bytes,1,opt,name=listen,proto3" json:"listen,omitempty"`
-	// Configuration for agent's configuration repository.
-	Configuration *AgentConfigurationCF `protobuf:"bytes,2,opt,name=configuration,proto3" json:"configuration,omitempty"`
-	// TTL for successful agent info lookups.
-	// /api/v4/internal/kubernetes/agent_info
-	// Set to zero to disable.
-	InfoCacheTtl *durationpb.Duration `protobuf:"bytes,4,opt,name=info_cache_ttl,proto3" json:"info_cache_ttl,omitempty"`
-	// TTL for failed agent info lookups.
-	// /api/v4/internal/kubernetes/agent_info
-	InfoCacheErrorTtl *durationpb.Duration `protobuf:"bytes,5,opt,name=info_cache_error_ttl,proto3" json:"info_cache_error_ttl,omitempty"`
-	// TTL for information about connected agents, stored in Redis.
-	RedisConnInfoTtl *durationpb.Duration `protobuf:"bytes,7,opt,name=redis_conn_info_ttl,proto3" json:"redis_conn_info_ttl,omitempty"`
-	// Refresh period for information about connected agents, stored in Redis.
-	RedisConnInfoRefresh *durationpb.Duration `protobuf:"bytes,8,opt,name=redis_conn_info_refresh,proto3" json:"redis_conn_info_refresh,omitempty"`
-	// Garbage collection period for information about connected agents, stored in Redis.
-	// If gitlab-kas crashes, another gitlab-kas instance will clean up stale data. This is how often this cleanup runs.
-	RedisConnInfoGc *durationpb.Duration `protobuf:"bytes,9,opt,name=redis_conn_info_gc,proto3" json:"redis_conn_info_gc,omitempty"`
-	// Configuration for exposing Kubernetes API.
-	KubernetesApi *KubernetesApiCF `protobuf:"bytes,10,opt,name=kubernetes_api,proto3" json:"kubernetes_api,omitempty"`
+	// Name of the policy. Included in the response when a request is denied.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// What to do with matching requests. Supported values: allow, deny.
+	Effect string `protobuf:"bytes,2,opt,name=effect,proto3" json:"effect,omitempty"`
+	// Plural cluster ids.
+	ClusterIds []string `protobuf:"bytes,3,rep,name=cluster_ids,proto3" json:"cluster_ids,omitempty"`
+	// Usernames of the impersonated users.
+	Users []string `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
+	// Groups of the impersonated users. Plural bound roles can be matched as "plural:role:<role name>" groups.
+	Groups []string `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Kubernetes API verbs, e.g. get, list, watch, create, update, patch, delete, deletecollection.
+	Verbs []string `protobuf:"bytes,6,rep,name=verbs,proto3" json:"verbs,omitempty"`
+	// API groups. Use "" for the core API group.
+	ApiGroups []string `protobuf:"bytes,7,rep,name=api_groups,proto3" json:"api_groups,omitempty"`
+	// Resources, e.g. pods, secrets.
+	Resources []string `protobuf:"bytes,8,rep,name=resources,proto3" json:"resources,omitempty"`
+	// Subresources, e.g. exec, attach, log. Use "" to only match requests without a subresource.
+	Subresources []string `protobuf:"bytes,9,rep,name=subresources,proto3" json:"subresources,omitempty"`
+	// Namespaces. Use "" to only match cluster-scoped requests.
+	Namespaces    []string `protobuf:"bytes,10,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentCF) Reset() {
-	*x = AgentCF{}
+func (x *KubernetesApiPolicyCF) Reset() {
+	*x = KubernetesApiPolicyCF{}
 	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentCF) String() string {
+func (x *KubernetesApiPolicyCF) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentCF) ProtoMessage() {}
+func (*KubernetesApiPolicyCF) ProtoMessage() {}
+
+func (x *KubernetesApiPolicyCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiPolicyCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiPolicyCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KubernetesApiPolicyCF) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KubernetesApiPolicyCF) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *KubernetesApiPolicyCF) GetClusterIds() []string {
+	if x != nil {
+		return x.ClusterIds
+	}
+	return nil
+}
+
+func (x *KubernetesApiPolicyCF) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *KubernetesApiPolicyCF) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *KubernetesApiPolicyCF) GetVerbs() []string {
+	if x != nil {
+		return x.Verbs
+	}
+	return nil
+}
+
+func (x *KubernetesApiPolicyCF) GetApiGroups() []string {
+	if x != nil {
+		return x.ApiGroups
+	}
+	return nil
+}
+
+func (x *KubernetesApiPolicyCF) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *KubernetesApiPolicyCF) GetSubresources() []string {
+	if x != nil {
+		return x.Subresources
+	}
+	return nil
+}
+
+func (x *KubernetesApiPolicyCF) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+// KubernetesApiAuthenticationCF configures additional authentication methods. Usernames and groups of
+// authenticated users are prefixed with the method, e.g. "oidc:", "static:" or "x509:", so that they cannot
+// clash with Kubernetes users and groups, such as system:masters.
+type KubernetesApiAuthenticationCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// OIDC ID tokens (`Bearer oidc:<cluster id>:<id token>`) verified against a local JWKS file.
+	Oidc *KubernetesApiOidcAuthCF `protobuf:"bytes,1,opt,name=oidc,proto3" json:"oidc,omitempty"`
+	// Static bearer tokens (`Bearer static:<cluster id>:<token>`) loaded from a file.
+	StaticToken *KubernetesApiStaticTokenAuthCF `protobuf:"bytes,2,opt,name=static_token,proto3" json:"static_token,omitempty"`
+	// X.509 client certificates. The cluster id is taken from the Gitlab-Agent-Id header.
+	// Requires TLS to be enabled on the listener.
+	ClientCertificate *KubernetesApiClientCertificateAuthCF `protobuf:"bytes,3,opt,name=client_certificate,proto3" json:"client_certificate,omitempty"`
+	// CI job tokens (`Bearer ci:<cluster id>:<job token>`) verified by Plural Console.
+	// Requires a Plural Console that serves the `allowedAgentsForJob` query. Not enabled if not set.
+	CiJob         *KubernetesApiCiJobAuthCF `protobuf:"bytes,4,opt,name=ci_job,proto3" json:"ci_job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiAuthenticationCF) Reset() {
+	*x = KubernetesApiAuthenticationCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiAuthenticationCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiAuthenticationCF) ProtoMessage() {}
+
+func (x *KubernetesApiAuthenticationCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiAuthenticationCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiAuthenticationCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *KubernetesApiAuthenticationCF) GetOidc() *KubernetesApiOidcAuthCF {
+	if x != nil {
+		return x.Oidc
+	}
+	return nil
+}
+
+func (x *KubernetesApiAuthenticationCF) GetStaticToken() *KubernetesApiStaticTokenAuthCF {
+	if x != nil {
+		return x.StaticToken
+	}
+	return nil
+}
+
+func (x *KubernetesApiAuthenticationCF) GetClientCertificate() *KubernetesApiClientCertificateAuthCF {
+	if x != nil {
+		return x.ClientCertificate
+	}
+	return nil
+}
+
+func (x *KubernetesApiAuthenticationCF) GetCiJob() *KubernetesApiCiJobAuthCF {
+	if x != nil {
+		return x.CiJob
+	}
+	return nil
+}
+
+type KubernetesApiOidcAuthCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Expected value of the iss claim.
+	Issuer string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// Expected value of the aud claim.
+	Audience string `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`
+	// JSON Web Key Set file with public keys to verify ID token signatures.
+	// See https://datatracker.ietf.org/doc/html/rfc7517#section-5.
+	JwksFile string `protobuf:"bytes,3,opt,name=jwks_file,proto3" json:"jwks_file,omitempty"`
+	// Claim to use as the username.
+	UsernameClaim string `protobuf:"bytes,4,opt,name=username_claim,proto3" json:"username_claim,omitempty"`
+	// Claim to use as the list of groups.
+	GroupsClaim string `protobuf:"bytes,5,opt,name=groups_claim,proto3" json:"groups_claim,omitempty"`
+	// Plural cluster ids that ID token holders can access. Use "*" to allow all clusters.
+	ClusterIds    []string `protobuf:"bytes,6,rep,name=cluster_ids,proto3" json:"cluster_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiOidcAuthCF) Reset() {
+	*x = KubernetesApiOidcAuthCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiOidcAuthCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiOidcAuthCF) ProtoMessage() {}
+
+func (x *KubernetesApiOidcAuthCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiOidcAuthCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiOidcAuthCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *KubernetesApiOidcAuthCF) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *KubernetesApiOidcAuthCF) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *KubernetesApiOidcAuthCF) GetJwksFile() string {
+	if x != nil {
+		return x.JwksFile
+	}
+	return ""
+}
+
+func (x *KubernetesApiOidcAuthCF) GetUsernameClaim() string {
+	if x != nil {
+		return x.UsernameClaim
+	}
+	return ""
+}
+
+func (x *KubernetesApiOidcAuthCF) GetGroupsClaim() string {
+	if x != nil {
+		return x.GroupsClaim
+	}
+	return ""
+}
+
+func (x *KubernetesApiOidcAuthCF) GetClusterIds() []string {
+	if x != nil {
+		return x.ClusterIds
+	}
+	return nil
+}
+
+type KubernetesApiStaticTokenAuthCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CSV file with one `token,username,uid,"group1,group2"` record per line. Groups are optional.
+	// Same format as the Kubernetes API server's --token-auth-file.
+	TokenFile string `protobuf:"bytes,1,opt,name=token_file,proto3" json:"token_file,omitempty"`
+	// Plural cluster ids that static token holders can access. Use "*" to allow all clusters.
+	ClusterIds    []string `protobuf:"bytes,2,rep,name=cluster_ids,proto3" json:"cluster_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiStaticTokenAuthCF) Reset() {
+	*x = KubernetesApiStaticTokenAuthCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiStaticTokenAuthCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiStaticTokenAuthCF) ProtoMessage() {}
+
+func (x *KubernetesApiStaticTokenAuthCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiStaticTokenAuthCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiStaticTokenAuthCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *KubernetesApiStaticTokenAuthCF) GetTokenFile() string {
+	if x != nil {
+		return x.TokenFile
+	}
+	return ""
+}
+
+func (x *KubernetesApiStaticTokenAuthCF) GetClusterIds() []string {
+	if x != nil {
+		return x.ClusterIds
+	}
+	return nil
+}
+
+// KubernetesApiCiJobAuthCF enables CI job tokens. Plural Console returns the agents a job can access, along with the
+// ci_access configuration of each of them. Lookups are cached for allowed_agent_cache_ttl.
+type KubernetesApiCiJobAuthCF struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiCiJobAuthCF) Reset() {
+	*x = KubernetesApiCiJobAuthCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiCiJobAuthCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiCiJobAuthCF) ProtoMessage() {}
+
+func (x *KubernetesApiCiJobAuthCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiCiJobAuthCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiCiJobAuthCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{12}
+}
+
+type KubernetesApiClientCertificateAuthCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// X.509 CA certificate in PEM format to verify client certificates.
+	// Certificate's common name is used as the username and organizations as groups.
+	CaCertificateFile string `protobuf:"bytes,1,opt,name=ca_certificate_file,proto3" json:"ca_certificate_file,omitempty"`
+	// Plural cluster ids that client certificate holders can access. Use "*" to allow all clusters.
+	ClusterIds    []string `protobuf:"bytes,2,rep,name=cluster_ids,proto3" json:"cluster_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiClientCertificateAuthCF) Reset() {
+	*x = KubernetesApiClientCertificateAuthCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiClientCertificateAuthCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiClientCertificateAuthCF) ProtoMessage() {}
+
+func (x *KubernetesApiClientCertificateAuthCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiClientCertificateAuthCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiClientCertificateAuthCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *KubernetesApiClientCertificateAuthCF) GetCaCertificateFile() string {
+	if x != nil {
+		return x.CaCertificateFile
+	}
+	return ""
+}
+
+func (x *KubernetesApiClientCertificateAuthCF) GetClusterIds() []string {
+	if x != nil {
+		return x.ClusterIds
+	}
+	return nil
+}
+
+type KubernetesApiLimitsCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of requests a user can make per minute, across all clusters.
+	// Requests made using agent's own identity are not limited by this.
+	// Zero means no limit.
+	RequestsPerUserPerMinute uint32 `protobuf:"varint,1,opt,name=requests_per_user_per_minute,proto3" json:"requests_per_user_per_minute,omitempty"`
+	// Maximum number of requests that can be made to a single cluster per minute.
+	// Zero means no limit.
+	RequestsPerClusterPerMinute uint32 `protobuf:"varint,2,opt,name=requests_per_cluster_per_minute,proto3" json:"requests_per_cluster_per_minute,omitempty"`
+	// Maximum size of a request body in bytes.
+	// Zero means no limit.
+	MaxRequestBodySize uint64 `protobuf:"varint,3,opt,name=max_request_body_size,proto3" json:"max_request_body_size,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *KubernetesApiLimitsCF) Reset() {
+	*x = KubernetesApiLimitsCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiLimitsCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiLimitsCF) ProtoMessage() {}
+
+func (x *KubernetesApiLimitsCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiLimitsCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiLimitsCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *KubernetesApiLimitsCF) GetRequestsPerUserPerMinute() uint32 {
+	if x != nil {
+		return x.RequestsPerUserPerMinute
+	}
+	return 0
+}
+
+func (x *KubernetesApiLimitsCF) GetRequestsPerClusterPerMinute() uint32 {
+	if x != nil {
+		return x.RequestsPerClusterPerMinute
+	}
+	return 0
+}
+
+func (x *KubernetesApiLimitsCF) GetMaxRequestBodySize() uint64 {
+	if x != nil {
+		return x.MaxRequestBodySize
+	}
+	return 0
+}
+
+type KubernetesApiAuditCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of events buffered per sink.
+	// Events are dropped and counted in the audit_events_dropped_total metric when the buffer is full.
+	QueueSize uint32 `protobuf:"varint,1,opt,name=queue_size,proto3" json:"queue_size,omitempty"`
+	// Maximum number of events written to a sink at once.
+	BatchSize uint32 `protobuf:"varint,2,opt,name=batch_size,proto3" json:"batch_size,omitempty"`
+	// How often to write buffered events even if a batch is not full.
+	FlushInterval *durationpb.Duration `protobuf:"bytes,3,opt,name=flush_interval,proto3" json:"flush_interval,omitempty"`
+	// Maximum delay between attempts to write a batch that a sink failed to accept.
+	// Writes are retried with exponential backoff until they succeed or kas shuts down.
+	MaxRetryBackoff *durationpb.Duration `protobuf:"bytes,4,opt,name=max_retry_backoff,proto3" json:"max_retry_backoff,omitempty"`
+	// Write events to a file, one JSON object per line.
+	// Events of requests made with Plural Console tokens are always sent to Plural Console.
+	File          *KubernetesApiAuditFileSinkCF `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiAuditCF) Reset() {
+	*x = KubernetesApiAuditCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiAuditCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiAuditCF) ProtoMessage() {}
+
+func (x *KubernetesApiAuditCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiAuditCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiAuditCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *KubernetesApiAuditCF) GetQueueSize() uint32 {
+	if x != nil {
+		return x.QueueSize
+	}
+	return 0
+}
+
+func (x *KubernetesApiAuditCF) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *KubernetesApiAuditCF) GetFlushInterval() *durationpb.Duration {
+	if x != nil {
+		return x.FlushInterval
+	}
+	return nil
+}
+
+func (x *KubernetesApiAuditCF) GetMaxRetryBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxRetryBackoff
+	}
+	return nil
+}
+
+func (x *KubernetesApiAuditCF) GetFile() *KubernetesApiAuditFileSinkCF {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type KubernetesApiAuditFileSinkCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path to the file. Events are appended to it.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiAuditFileSinkCF) Reset() {
+	*x = KubernetesApiAuditFileSinkCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiAuditFileSinkCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiAuditFileSinkCF) ProtoMessage() {}
+
+func (x *KubernetesApiAuditFileSinkCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiAuditFileSinkCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiAuditFileSinkCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *KubernetesApiAuditFileSinkCF) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type KubernetesApiKubeconfigCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// URL of the proxy, including url_path_prefix, to use in generated kubeconfigs.
+	// If not set, it is derived from the scheme and host of the request.
+	ServerUrl string `protobuf:"bytes,1,opt,name=server_url,proto3" json:"server_url,omitempty"`
+	// Credential plugin to use in kubeconfigs requested with `?exec=true`.
+	// If not set, such requests are rejected.
+	Exec          *KubernetesApiKubeconfigExecCF `protobuf:"bytes,2,opt,name=exec,proto3" json:"exec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiKubeconfigCF) Reset() {
+	*x = KubernetesApiKubeconfigCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiKubeconfigCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiKubeconfigCF) ProtoMessage() {}
+
+func (x *KubernetesApiKubeconfigCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiKubeconfigCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiKubeconfigCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *KubernetesApiKubeconfigCF) GetServerUrl() string {
+	if x != nil {
+		return x.ServerUrl
+	}
+	return ""
+}
+
+func (x *KubernetesApiKubeconfigCF) GetExec() *KubernetesApiKubeconfigExecCF {
+	if x != nil {
+		return x.Exec
+	}
+	return nil
+}
+
+type KubernetesApiDiscoveryCacheCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long to cache a response for.
+	// Cached responses of a cluster are also dropped when the agent reports a different Kubernetes version.
+	Ttl *durationpb.Duration `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Maximum total size of cached response bodies, in bytes. Each kas instance has its own cache.
+	MaxSize       uint64 `protobuf:"varint,2,opt,name=max_size,proto3" json:"max_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiDiscoveryCacheCF) Reset() {
+	*x = KubernetesApiDiscoveryCacheCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiDiscoveryCacheCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiDiscoveryCacheCF) ProtoMessage() {}
+
+func (x *KubernetesApiDiscoveryCacheCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiDiscoveryCacheCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiDiscoveryCacheCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *KubernetesApiDiscoveryCacheCF) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *KubernetesApiDiscoveryCacheCF) GetMaxSize() uint64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+// KubernetesApiSessionRecordingCF selects sessions to record. A session is recorded if it is in one of the clusters
+// or if the user is in one of the groups. All sessions are recorded if both lists are empty.
+type KubernetesApiSessionRecordingCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Plural cluster ids.
+	ClusterIds []string `protobuf:"bytes,1,rep,name=cluster_ids,proto3" json:"cluster_ids,omitempty"`
+	// Groups of the impersonated users. Plural bound roles can be matched as "plural:role:<role name>" groups.
+	Groups []string `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Write recordings to a local directory, one file per session.
+	File          *KubernetesApiSessionRecordingFileSinkCF `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiSessionRecordingCF) Reset() {
+	*x = KubernetesApiSessionRecordingCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiSessionRecordingCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiSessionRecordingCF) ProtoMessage() {}
+
+func (x *KubernetesApiSessionRecordingCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiSessionRecordingCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiSessionRecordingCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *KubernetesApiSessionRecordingCF) GetClusterIds() []string {
+	if x != nil {
+		return x.ClusterIds
+	}
+	return nil
+}
+
+func (x *KubernetesApiSessionRecordingCF) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *KubernetesApiSessionRecordingCF) GetFile() *KubernetesApiSessionRecordingFileSinkCF {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type KubernetesApiSessionRecordingFileSinkCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path to the directory. It is created if it doesn't exist.
+	Dir           string `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiSessionRecordingFileSinkCF) Reset() {
+	*x = KubernetesApiSessionRecordingFileSinkCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiSessionRecordingFileSinkCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiSessionRecordingFileSinkCF) ProtoMessage() {}
+
+func (x *KubernetesApiSessionRecordingFileSinkCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiSessionRecordingFileSinkCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiSessionRecordingFileSinkCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *KubernetesApiSessionRecordingFileSinkCF) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+type KubernetesApiKubeconfigExecCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Command that prints a client.authentication.k8s.io/v1 ExecCredential.
+	// The token in it must use the `<token type>:<cluster id>:<token>` format.
+	// The cluster id is passed in the PLURAL_CLUSTER_ID environment variable.
+	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// Arguments to pass to the command.
+	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// Message to show to the user if the command is not installed.
+	InstallHint string `protobuf:"bytes,3,opt,name=install_hint,proto3" json:"install_hint,omitempty"`
+	// Token types the command can get a token for. The type of the token the kubeconfig was requested with is passed
+	// in the PLURAL_TOKEN_TYPE environment variable. Requests with other token types are rejected.
+	// Defaults to Plural Console tokens only.
+	TokenTypes    []string `protobuf:"bytes,4,rep,name=token_types,proto3" json:"token_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesApiKubeconfigExecCF) Reset() {
+	*x = KubernetesApiKubeconfigExecCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesApiKubeconfigExecCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesApiKubeconfigExecCF) ProtoMessage() {}
+
+func (x *KubernetesApiKubeconfigExecCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesApiKubeconfigExecCF.ProtoReflect.Descriptor instead.
+func (*KubernetesApiKubeconfigExecCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *KubernetesApiKubeconfigExecCF) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *KubernetesApiKubeconfigExecCF) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *KubernetesApiKubeconfigExecCF) GetInstallHint() string {
+	if x != nil {
+		return x.InstallHint
+	}
+	return ""
+}
+
+func (x *KubernetesApiKubeconfigExecCF) GetTokenTypes() []string {
+	if x != nil {
+		return x.TokenTypes
+	}
+	return nil
+}
+
+type AgentCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RPC listener configuration for agentk connections.
+	Listen *ListenAgentCF `protobuf:"bytes,1,opt,name=listen,proto3" json:"listen,omitempty"`
+	// Configuration for agent's configuration repository.
+	Configuration *AgentConfigurationCF `protobuf:"bytes,2,opt,name=configuration,proto3" json:"configuration,omitempty"`
+	// TTL for successful agent info lookups.
+	// /api/v4/internal/kubernetes/agent_info
+	// Set to zero to disable.
+	InfoCacheTtl *durationpb.Duration `protobuf:"bytes,4,opt,name=info_cache_ttl,proto3" json:"info_cache_ttl,omitempty"`
+	// TTL for failed agent info lookups.
+	// /api/v4/internal/kubernetes/agent_info
+	InfoCacheErrorTtl *durationpb.Duration `protobuf:"bytes,5,opt,name=info_cache_error_ttl,proto3" json:"info_cache_error_ttl,omitempty"`
+	// TTL for information about connected agents, stored in Redis.
+	RedisConnInfoTtl *durationpb.Duration `protobuf:"bytes,7,opt,name=redis_conn_info_ttl,proto3" json:"redis_conn_info_ttl,omitempty"`
+	// Refresh period for information about connected agents, stored in Redis.
+	RedisConnInfoRefresh *durationpb.Duration `protobuf:"bytes,8,opt,name=redis_conn_info_refresh,proto3" json:"redis_conn_info_refresh,omitempty"`
+	// Garbage collection period for information about connected agents, stored in Redis.
+	// If gitlab-kas crashes, another gitlab-kas instance will clean up stale data. This is how often this cleanup runs.
+	RedisConnInfoGc *durationpb.Duration `protobuf:"bytes,9,opt,name=redis_conn_info_gc,proto3" json:"redis_conn_info_gc,omitempty"`
+	// Configuration for exposing Kubernetes API.
+	KubernetesApi *KubernetesApiCF `protobuf:"bytes,10,opt,name=kubernetes_api,proto3" json:"kubernetes_api,omitempty"`
+	// Configuration for reverse tunnels from agentk.
+	ReverseTunnel *AgentReverseTunnelCF `protobuf:"bytes,11,opt,name=reverse_tunnel,proto3" json:"reverse_tunnel,omitempty"`
+	// How long to keep the history of agent connections, i.e. when they were last seen and why they ended.
+	ConnectionHistoryTtl *durationpb.Duration `protobuf:"bytes,12,opt,name=connection_history_ttl,proto3" json:"connection_history_ttl,omitempty"`
+	// Which agentk versions are supported and what to do with the others.
+	VersionSkew *VersionSkewCF `protobuf:"bytes,13,opt,name=version_skew,proto3" json:"version_skew,omitempty"`
+	// How long an agent token keeps being accepted after it has been replaced with a new one.
+	// Gives agentk time to switch to the new token without losing connectivity.
+	// Only applies once Plural Console has accepted another token for the same agent. Revoked tokens are
+	// never accepted. Disabled by default.
+	TokenOverlapWindow *durationpb.Duration `protobuf:"bytes,14,opt,name=token_overlap_window,proto3" json:"token_overlap_window,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AgentCF) Reset() {
+	*x = AgentCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCF) ProtoMessage() {}
+
+func (x *AgentCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCF.ProtoReflect.Descriptor instead.
+func (*AgentCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AgentCF) GetListen() *ListenAgentCF {
+	if x != nil {
+		return x.Listen
+	}
+	return nil
+}
+
+func (x *AgentCF) GetConfiguration() *AgentConfigurationCF {
+	if x != nil {
+		return x.Configuration
+	}
+	return nil
+}
+
+func (x *AgentCF) GetInfoCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.InfoCacheTtl
+	}
+	return nil
+}
+
+func (x *AgentCF) GetInfoCacheErrorTtl() *durationpb.Duration {
+	if x != nil {
+		return x.InfoCacheErrorTtl
+	}
+	return nil
+}
+
+func (x *AgentCF) GetRedisConnInfoTtl() *durationpb.Duration {
+	if x != nil {
+		return x.RedisConnInfoTtl
+	}
+	return nil
+}
+
+func (x *AgentCF) GetRedisConnInfoRefresh() *durationpb.Duration {
+	if x != nil {
+		return x.RedisConnInfoRefresh
+	}
+	return nil
+}
+
+func (x *AgentCF) GetRedisConnInfoGc() *durationpb.Duration {
+	if x != nil {
+		return x.RedisConnInfoGc
+	}
+	return nil
+}
+
+func (x *AgentCF) GetKubernetesApi() *KubernetesApiCF {
+	if x != nil {
+		return x.KubernetesApi
+	}
+	return nil
+}
+
+func (x *AgentCF) GetReverseTunnel() *AgentReverseTunnelCF {
+	if x != nil {
+		return x.ReverseTunnel
+	}
+	return nil
+}
+
+func (x *AgentCF) GetConnectionHistoryTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ConnectionHistoryTtl
+	}
+	return nil
+}
+
+func (x *AgentCF) GetVersionSkew() *VersionSkewCF {
+	if x != nil {
+		return x.VersionSkew
+	}
+	return nil
+}
+
+func (x *AgentCF) GetTokenOverlapWindow() *durationpb.Duration {
+	if x != nil {
+		return x.TokenOverlapWindow
+	}
+	return nil
+}
+
+type VersionSkewCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// What to do with agents whose version is not supported:
+	// - "warn" only reports the skew in metrics, logs, the agent tracker API and as an event in the agent's cluster.
+	// - "restrict" also refuses reverse tunnels from the agent.
+	// - "reject" refuses all requests from the agent, except registration.
+	Policy string `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Number of minor versions of agentk that are supported, counting the one of kas.
+	// E.g. 3 with kas v1.5 supports agentk v1.3 to v1.5.
+	SupportedMinorVersions uint32 `protobuf:"varint,2,opt,name=supported_minor_versions,proto3" json:"supported_minor_versions,omitempty"`
+	// Support agentk versions that are newer than kas.
+	AllowNewerAgents bool `protobuf:"varint,3,opt,name=allow_newer_agents,proto3" json:"allow_newer_agents,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *VersionSkewCF) Reset() {
+	*x = VersionSkewCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionSkewCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionSkewCF) ProtoMessage() {}
 
-func (x *AgentCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[8]
+func (x *VersionSkewCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,65 +1856,86 @@ func (x *AgentCF) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentCF.ProtoReflect.Descriptor instead.
-func (*AgentCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use VersionSkewCF.ProtoReflect.Descriptor instead.
+func (*VersionSkewCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *AgentCF) GetListen() *ListenAgentCF {
+func (x *VersionSkewCF) GetPolicy() string {
 	if x != nil {
-		return x.Listen
+		return x.Policy
 	}
-	return nil
+	return ""
 }
 
-func (x *AgentCF) GetConfiguration() *AgentConfigurationCF {
+func (x *VersionSkewCF) GetSupportedMinorVersions() uint32 {
 	if x != nil {
-		return x.Configuration
+		return x.SupportedMinorVersions
 	}
-	return nil
+	return 0
 }
 
-func (x *AgentCF) GetInfoCacheTtl() *durationpb.Duration {
+func (x *VersionSkewCF) GetAllowNewerAgents() bool {
 	if x != nil {
-		return x.InfoCacheTtl
+		return x.AllowNewerAgents
 	}
-	return nil
+	return false
 }
 
-func (x *AgentCF) GetInfoCacheErrorTtl() *durationpb.Duration {
-	if x != nil {
-		return x.InfoCacheErrorTtl
-	}
-	return nil
+type AgentReverseTunnelCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Compression of data sent over reverse tunnels. One of "none", "gzip", "zstd".
+	// Only used with agentk versions that support the selected algorithm, data is sent uncompressed otherwise.
+	Compression string `protobuf:"bytes,1,opt,name=compression,proto3" json:"compression,omitempty"`
+	// Multiplex several requests over one reverse tunnel.
+	// Only used with agentk versions that support multiplexing.
+	Multiplexing  bool `protobuf:"varint,2,opt,name=multiplexing,proto3" json:"multiplexing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentCF) GetRedisConnInfoTtl() *durationpb.Duration {
-	if x != nil {
-		return x.RedisConnInfoTtl
-	}
-	return nil
+func (x *AgentReverseTunnelCF) Reset() {
+	*x = AgentReverseTunnelCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentCF) GetRedisConnInfoRefresh() *durationpb.Duration {
+func (x *AgentReverseTunnelCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentReverseTunnelCF) ProtoMessage() {}
+
+func (x *AgentReverseTunnelCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[24]
 	if x != nil {
-		return x.RedisConnInfoRefresh
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AgentCF) GetRedisConnInfoGc() *durationpb.Duration {
+// Deprecated: Use AgentReverseTunnelCF.ProtoReflect.Descriptor instead.
+func (*AgentReverseTunnelCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AgentReverseTunnelCF) GetCompression() string {
 	if x != nil {
-		return x.RedisConnInfoGc
+		return x.Compression
 	}
-	return nil
+	return ""
 }
 
-func (x *AgentCF) GetKubernetesApi() *KubernetesApiCF {
+func (x *AgentReverseTunnelCF) GetMultiplexing() bool {
 	if x != nil {
-		return x.KubernetesApi
+		return x.Multiplexing
 	}
-	return nil
+	return false
 }
 
 type AgentConfigurationCF struct {
@@ -767,13 +1944,16 @@ type AgentConfigurationCF struct {
 	PollPeriod *durationpb.Duration `protobuf:"bytes,1,opt,name=poll_period,proto3" json:"poll_period,omitempty"`
 	// Maximum file size of the agent configuration file.
 	MaxConfigurationFileSize uint32 `protobuf:"varint,2,opt,name=max_configuration_file_size,proto3" json:"max_configuration_file_size,omitempty"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+	// Read agent configuration from the local file system instead of Plural Console.
+	// Meant for self-hosted and test setups.
+	Local         *AgentConfigurationLocalCF `protobuf:"bytes,3,opt,name=local,proto3" json:"local,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AgentConfigurationCF) Reset() {
 	*x = AgentConfigurationCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[9]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -785,7 +1965,7 @@ func (x *AgentConfigurationCF) String() string {
 func (*AgentConfigurationCF) ProtoMessage() {}
 
 func (x *AgentConfigurationCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[9]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,7 +1978,7 @@ func (x *AgentConfigurationCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentConfigurationCF.ProtoReflect.Descriptor instead.
 func (*AgentConfigurationCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{9}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *AgentConfigurationCF) GetPollPeriod() *durationpb.Duration {
@@ -815,6 +1995,98 @@ func (x *AgentConfigurationCF) GetMaxConfigurationFileSize() uint32 {
 	return 0
 }
 
+func (x *AgentConfigurationCF) GetLocal() *AgentConfigurationLocalCF {
+	if x != nil {
+		return x.Local
+	}
+	return nil
+}
+
+type AgentConfigurationLocalCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*AgentConfigurationLocalCF_File
+	//	*AgentConfigurationLocalCF_Directory
+	Source        isAgentConfigurationLocalCF_Source `protobuf_oneof:"source"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentConfigurationLocalCF) Reset() {
+	*x = AgentConfigurationLocalCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConfigurationLocalCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfigurationLocalCF) ProtoMessage() {}
+
+func (x *AgentConfigurationLocalCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConfigurationLocalCF.ProtoReflect.Descriptor instead.
+func (*AgentConfigurationLocalCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AgentConfigurationLocalCF) GetSource() isAgentConfigurationLocalCF_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *AgentConfigurationLocalCF) GetFile() string {
+	if x != nil {
+		if x, ok := x.Source.(*AgentConfigurationLocalCF_File); ok {
+			return x.File
+		}
+	}
+	return ""
+}
+
+func (x *AgentConfigurationLocalCF) GetDirectory() string {
+	if x != nil {
+		if x, ok := x.Source.(*AgentConfigurationLocalCF_Directory); ok {
+			return x.Directory
+		}
+	}
+	return ""
+}
+
+type isAgentConfigurationLocalCF_Source interface {
+	isAgentConfigurationLocalCF_Source()
+}
+
+type AgentConfigurationLocalCF_File struct {
+	// YAML file with the configuration of all agents.
+	File string `protobuf:"bytes,1,opt,name=file,proto3,oneof"`
+}
+
+type AgentConfigurationLocalCF_Directory struct {
+	// Directory with a <agent name>/config.yaml file per agent.
+	// Agents without a file get an empty configuration.
+	Directory string `protobuf:"bytes,2,opt,name=directory,proto3,oneof"`
+}
+
+func (*AgentConfigurationLocalCF_File) isAgentConfigurationLocalCF_Source() {}
+
+func (*AgentConfigurationLocalCF_Directory) isAgentConfigurationLocalCF_Source() {}
+
 type GoogleProfilerCF struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Enabled         bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
@@ -827,7 +2099,7 @@ type GoogleProfilerCF struct {
 
 func (x *GoogleProfilerCF) Reset() {
 	*x = GoogleProfilerCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[10]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -839,7 +2111,7 @@ func (x *GoogleProfilerCF) String() string {
 func (*GoogleProfilerCF) ProtoMessage() {}
 
 func (x *GoogleProfilerCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[10]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -852,7 +2124,7 @@ func (x *GoogleProfilerCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoogleProfilerCF.ProtoReflect.Descriptor instead.
 func (*GoogleProfilerCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{10}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GoogleProfilerCF) GetEnabled() bool {
@@ -893,7 +2165,7 @@ type LivenessProbeCF struct {
 
 func (x *LivenessProbeCF) Reset() {
 	*x = LivenessProbeCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[11]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -905,7 +2177,7 @@ func (x *LivenessProbeCF) String() string {
 func (*LivenessProbeCF) ProtoMessage() {}
 
 func (x *LivenessProbeCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[11]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -918,7 +2190,7 @@ func (x *LivenessProbeCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LivenessProbeCF.ProtoReflect.Descriptor instead.
 func (*LivenessProbeCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{11}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *LivenessProbeCF) GetUrlPath() string {
@@ -938,7 +2210,7 @@ type ReadinessProbeCF struct {
 
 func (x *ReadinessProbeCF) Reset() {
 	*x = ReadinessProbeCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[12]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -950,7 +2222,7 @@ func (x *ReadinessProbeCF) String() string {
 func (*ReadinessProbeCF) ProtoMessage() {}
 
 func (x *ReadinessProbeCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[12]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +2235,7 @@ func (x *ReadinessProbeCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadinessProbeCF.ProtoReflect.Descriptor instead.
 func (*ReadinessProbeCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{12}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ReadinessProbeCF) GetUrlPath() string {
@@ -997,7 +2269,7 @@ type ObservabilityCF struct {
 
 func (x *ObservabilityCF) Reset() {
 	*x = ObservabilityCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[13]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1009,7 +2281,7 @@ func (x *ObservabilityCF) String() string {
 func (*ObservabilityCF) ProtoMessage() {}
 
 func (x *ObservabilityCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[13]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1022,7 +2294,7 @@ func (x *ObservabilityCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObservabilityCF.ProtoReflect.Descriptor instead.
 func (*ObservabilityCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{13}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ObservabilityCF) GetUsageReportingPeriod() *durationpb.Duration {
@@ -1103,7 +2375,7 @@ type TokenBucketRateLimitCF struct {
 
 func (x *TokenBucketRateLimitCF) Reset() {
 	*x = TokenBucketRateLimitCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[14]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1115,7 +2387,7 @@ func (x *TokenBucketRateLimitCF) String() string {
 func (*TokenBucketRateLimitCF) ProtoMessage() {}
 
 func (x *TokenBucketRateLimitCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[14]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1128,7 +2400,7 @@ func (x *TokenBucketRateLimitCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenBucketRateLimitCF.ProtoReflect.Descriptor instead.
 func (*TokenBucketRateLimitCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{14}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *TokenBucketRateLimitCF) GetRefillRatePerSecond() float64 {
@@ -1147,6 +2419,8 @@ func (x *TokenBucketRateLimitCF) GetBucketSize() uint32 {
 
 type RedisCF struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required if the storage backend is "redis".
+	//
 	// Types that are valid to be assigned to RedisConfig:
 	//
 	//	*RedisCF_Server
@@ -1188,7 +2462,7 @@ type RedisCF struct {
 
 func (x *RedisCF) Reset() {
 	*x = RedisCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[15]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1200,7 +2474,7 @@ func (x *RedisCF) String() string {
 func (*RedisCF) ProtoMessage() {}
 
 func (x *RedisCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[15]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1213,7 +2487,7 @@ func (x *RedisCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisCF.ProtoReflect.Descriptor instead.
 func (*RedisCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{15}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *RedisCF) GetRedisConfig() isRedisCF_RedisConfig {
@@ -1354,7 +2628,7 @@ type RedisTLSCF struct {
 
 func (x *RedisTLSCF) Reset() {
 	*x = RedisTLSCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[16]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1366,7 +2640,7 @@ func (x *RedisTLSCF) String() string {
 func (*RedisTLSCF) ProtoMessage() {}
 
 func (x *RedisTLSCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[16]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1379,7 +2653,7 @@ func (x *RedisTLSCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisTLSCF.ProtoReflect.Descriptor instead.
 func (*RedisTLSCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{16}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *RedisTLSCF) GetEnabled() bool {
@@ -1420,7 +2694,7 @@ type RedisServerCF struct {
 
 func (x *RedisServerCF) Reset() {
 	*x = RedisServerCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[17]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1432,7 +2706,7 @@ func (x *RedisServerCF) String() string {
 func (*RedisServerCF) ProtoMessage() {}
 
 func (x *RedisServerCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[17]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1445,7 +2719,7 @@ func (x *RedisServerCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisServerCF.ProtoReflect.Descriptor instead.
 func (*RedisServerCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{17}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *RedisServerCF) GetAddress() string {
@@ -1469,7 +2743,7 @@ type RedisSentinelCF struct {
 
 func (x *RedisSentinelCF) Reset() {
 	*x = RedisSentinelCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[18]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1481,7 +2755,7 @@ func (x *RedisSentinelCF) String() string {
 func (*RedisSentinelCF) ProtoMessage() {}
 
 func (x *RedisSentinelCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[18]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1494,7 +2768,7 @@ func (x *RedisSentinelCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisSentinelCF.ProtoReflect.Descriptor instead.
 func (*RedisSentinelCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{18}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *RedisSentinelCF) GetMasterName() string {
@@ -1543,7 +2817,7 @@ type ListenApiCF struct {
 
 func (x *ListenApiCF) Reset() {
 	*x = ListenApiCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[19]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1555,7 +2829,7 @@ func (x *ListenApiCF) String() string {
 func (*ListenApiCF) ProtoMessage() {}
 
 func (x *ListenApiCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[19]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1568,7 +2842,7 @@ func (x *ListenApiCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListenApiCF.ProtoReflect.Descriptor instead.
 func (*ListenApiCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{19}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ListenApiCF) GetNetwork() string {
@@ -1648,7 +2922,7 @@ type ListenPrivateApiCF struct {
 
 func (x *ListenPrivateApiCF) Reset() {
 	*x = ListenPrivateApiCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[20]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1660,7 +2934,7 @@ func (x *ListenPrivateApiCF) String() string {
 func (*ListenPrivateApiCF) ProtoMessage() {}
 
 func (x *ListenPrivateApiCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[20]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1673,7 +2947,7 @@ func (x *ListenPrivateApiCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListenPrivateApiCF.ProtoReflect.Descriptor instead.
 func (*ListenPrivateApiCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{20}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ListenPrivateApiCF) GetNetwork() string {
@@ -1742,7 +3016,7 @@ type ApiCF struct {
 
 func (x *ApiCF) Reset() {
 	*x = ApiCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[21]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1754,7 +3028,7 @@ func (x *ApiCF) String() string {
 func (*ApiCF) ProtoMessage() {}
 
 func (x *ApiCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[21]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1767,7 +3041,7 @@ func (x *ApiCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiCF.ProtoReflect.Descriptor instead.
 func (*ApiCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{21}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ApiCF) GetListen() *ListenApiCF {
@@ -1787,7 +3061,7 @@ type PrivateApiCF struct {
 
 func (x *PrivateApiCF) Reset() {
 	*x = PrivateApiCF{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[22]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1799,7 +3073,7 @@ func (x *PrivateApiCF) String() string {
 func (*PrivateApiCF) ProtoMessage() {}
 
 func (x *PrivateApiCF) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[22]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1812,7 +3086,7 @@ func (x *PrivateApiCF) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrivateApiCF.ProtoReflect.Descriptor instead.
 func (*PrivateApiCF) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{22}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *PrivateApiCF) GetListen() *ListenPrivateApiCF {
@@ -1832,20 +3106,23 @@ type ConfigurationFile struct {
 	// monitoring, logging, usage metrics, profiling.
 	Observability *ObservabilityCF `protobuf:"bytes,2,opt,name=observability,proto3" json:"observability,omitempty"`
 	// Redis configurations available to kas.
+	// Required if the storage backend is "redis".
 	Redis *RedisCF `protobuf:"bytes,3,opt,name=redis,proto3" json:"redis,omitempty"`
 	// Public API.
 	Api *ApiCF `protobuf:"bytes,4,opt,name=api,proto3" json:"api,omitempty"`
 	// Private API for kas->kas communication.
 	PrivateApi *PrivateApiCF `protobuf:"bytes,5,opt,name=private_api,proto3" json:"private_api,omitempty"`
 	// Plural URL address
-	PluralUrl     string `protobuf:"bytes,6,opt,name=plural_url,proto3" json:"plural_url,omitempty"`
+	PluralUrl string `protobuf:"bytes,6,opt,name=plural_url,proto3" json:"plural_url,omitempty"`
+	// Storage of the data that kas replicas share.
+	Storage       *StorageCF `protobuf:"bytes,7,opt,name=storage,proto3" json:"storage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConfigurationFile) Reset() {
 	*x = ConfigurationFile{}
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[23]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1857,7 +3134,7 @@ func (x *ConfigurationFile) String() string {
 func (*ConfigurationFile) ProtoMessage() {}
 
 func (x *ConfigurationFile) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[23]
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1870,7 +3147,7 @@ func (x *ConfigurationFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigurationFile.ProtoReflect.Descriptor instead.
 func (*ConfigurationFile) Descriptor() ([]byte, []int) {
-	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{23}
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ConfigurationFile) GetAgent() *AgentCF {
@@ -1915,11 +3192,156 @@ func (x *ConfigurationFile) GetPluralUrl() string {
 	return ""
 }
 
+func (x *ConfigurationFile) GetStorage() *StorageCF {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+type StorageCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Storage backend. One of "redis", "memory", "kubernetes".
+	// "memory" keeps the data in the kas process. Only a single kas replica can be run with it.
+	// It is meant for development environments, single-cluster installations and integration tests.
+	// "kubernetes" keeps agent connections and tunnels in Lease objects in a Kubernetes cluster.
+	// Rate limits and cached errors are kept in the memory of each kas replica. kas refuses to start with more than one
+	// replica if the Kubernetes API proxy has rate limits.
+	// Default is "redis".
+	Backend string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	// Configuration of the "kubernetes" backend.
+	Kubernetes    *KubernetesStorageCF `protobuf:"bytes,2,opt,name=kubernetes,proto3" json:"kubernetes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageCF) Reset() {
+	*x = StorageCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageCF) ProtoMessage() {}
+
+func (x *StorageCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageCF.ProtoReflect.Descriptor instead.
+func (*StorageCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StorageCF) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *StorageCF) GetKubernetes() *KubernetesStorageCF {
+	if x != nil {
+		return x.Kubernetes
+	}
+	return nil
+}
+
+type KubernetesStorageCF struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Namespace to keep Lease objects in.
+	// Defaults to the namespace of the kubeconfig context or, when running in a Pod, to the namespace of the Pod.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Path to the kubeconfig file to use. The KUBECONFIG environment variable is used if not set.
+	// If neither is set, the in-cluster configuration is used.
+	KubeconfigFile string `protobuf:"bytes,2,opt,name=kubeconfig_file,proto3" json:"kubeconfig_file,omitempty"`
+	// How often to delete Lease objects that have expired.
+	// These are left behind by kas replicas that have not shut down cleanly.
+	GcPeriod *durationpb.Duration `protobuf:"bytes,3,opt,name=gc_period,proto3" json:"gc_period,omitempty"`
+	// Number of kas replicas that share the Lease objects.
+	// Rate limits are not shared, so agent.kubernetes_api.limits.requests_per_user_per_minute and
+	// requests_per_cluster_per_minute cannot be set with more than one replica.
+	// Default is 1.
+	Replicas      uint32 `protobuf:"varint,4,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesStorageCF) Reset() {
+	*x = KubernetesStorageCF{}
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesStorageCF) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesStorageCF) ProtoMessage() {}
+
+func (x *KubernetesStorageCF) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_kascfg_kascfg_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesStorageCF.ProtoReflect.Descriptor instead.
+func (*KubernetesStorageCF) Descriptor() ([]byte, []int) {
+	return file_pkg_kascfg_kascfg_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *KubernetesStorageCF) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *KubernetesStorageCF) GetKubeconfigFile() string {
+	if x != nil {
+		return x.KubeconfigFile
+	}
+	return ""
+}
+
+func (x *KubernetesStorageCF) GetGcPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GcPeriod
+	}
+	return nil
+}
+
+func (x *KubernetesStorageCF) GetReplicas() uint32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
 var File_pkg_kascfg_kascfg_proto protoreflect.FileDescriptor
 
 const file_pkg_kascfg_kascfg_proto_rawDesc = "" +
 	"\n" +
-	"\x17pkg/kascfg/kascfg.proto\x12\x13plural.agent.kascfg\x1a\x1egoogle/protobuf/duration.proto\x1a\x17validate/validate.proto\"\xd0\x03\n" +
+	"\x17pkg/kascfg/kascfg.proto\x12\x13plural.agent.kascfg\x1a\x1egoogle/protobuf/duration.proto\x1a\x17validate/validate.proto\"\xa5\x04\n" +
 	"\rListenAgentCF\x12;\n" +
 	"\anetwork\x18\x01 \x01(\tB\x1c\xfaB\x19r\x17R\x03tcpR\x04tcp4R\x04tcp6R\x04unixH\x00R\anetwork\x88\x01\x01\x12\x18\n" +
 	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1c\n" +
@@ -1928,7 +3350,8 @@ const file_pkg_kascfg_kascfg_proto_rawDesc = "" +
 	"\bkey_file\x18\x05 \x01(\tR\bkey_file\x12J\n" +
 	" connections_per_token_per_minute\x18\x06 \x01(\rR connections_per_token_per_minute\x12S\n" +
 	"\x12max_connection_age\x18\a \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x12max_connection_age\x12U\n" +
-	"\x13listen_grace_period\x18\b \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x13listen_grace_periodB\n" +
+	"\x13listen_grace_period\x18\b \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x13listen_grace_period\x12S\n" +
+	"\x12drain_grace_period\x18\t \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x12drain_grace_periodB\n" +
 	"\n" +
 	"\b_network\"*\n" +
 	"\fPrometheusCF\x12\x1a\n" +
@@ -1965,12 +3388,94 @@ const file_pkg_kascfg_kascfg_proto_rawDesc = "" +
 	"\x13listen_grace_period\x18\x05 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x13listen_grace_period\x12Y\n" +
 	"\x15shutdown_grace_period\x18\x06 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x15shutdown_grace_periodB\n" +
 	"\n" +
-	"\b_network\"\xc9\x02\n" +
+	"\b_network\"\x84\a\n" +
 	"\x0fKubernetesApiCF\x12B\n" +
 	"\x06listen\x18\x01 \x01(\v2*.plural.agent.kascfg.ListenKubernetesApiCFR\x06listen\x12(\n" +
 	"\x0furl_path_prefix\x18\x02 \x01(\tR\x0furl_path_prefix\x12]\n" +
 	"\x17allowed_agent_cache_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x022\x00R\x17allowed_agent_cache_ttl\x12i\n" +
-	"\x1dallowed_agent_cache_error_ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x1dallowed_agent_cache_error_ttl\"\xf7\x04\n" +
+	"\x1dallowed_agent_cache_error_ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x1dallowed_agent_cache_error_ttl\x12Z\n" +
+	"\x0eauthentication\x18\x05 \x01(\v22.plural.agent.kascfg.KubernetesApiAuthenticationCFR\x0eauthentication\x12F\n" +
+	"\bpolicies\x18\x06 \x03(\v2*.plural.agent.kascfg.KubernetesApiPolicyCFR\bpolicies\x12?\n" +
+	"\x05audit\x18\a \x01(\v2).plural.agent.kascfg.KubernetesApiAuditCFR\x05audit\x12B\n" +
+	"\x06limits\x18\b \x01(\v2*.plural.agent.kascfg.KubernetesApiLimitsCFR\x06limits\x12N\n" +
+	"\n" +
+	"kubeconfig\x18\t \x01(\v2..plural.agent.kascfg.KubernetesApiKubeconfigCFR\n" +
+	"kubeconfig\x12\\\n" +
+	"\x0fdiscovery_cache\x18\n" +
+	" \x01(\v22.plural.agent.kascfg.KubernetesApiDiscoveryCacheCFR\x0fdiscovery_cache\x12b\n" +
+	"\x11session_recording\x18\v \x01(\v24.plural.agent.kascfg.KubernetesApiSessionRecordingCFR\x11session_recording\"\xc8\x02\n" +
+	"\x15KubernetesApiPolicyCF\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04name\x12*\n" +
+	"\x06effect\x18\x02 \x01(\tB\x12\xfaB\x0fr\rR\x05allowR\x04denyR\x06effect\x12 \n" +
+	"\vcluster_ids\x18\x03 \x03(\tR\vcluster_ids\x12\x14\n" +
+	"\x05users\x18\x04 \x03(\tR\x05users\x12\x16\n" +
+	"\x06groups\x18\x05 \x03(\tR\x06groups\x12\x14\n" +
+	"\x05verbs\x18\x06 \x03(\tR\x05verbs\x12\x1e\n" +
+	"\n" +
+	"api_groups\x18\a \x03(\tR\n" +
+	"api_groups\x12\x1c\n" +
+	"\tresources\x18\b \x03(\tR\tresources\x12\"\n" +
+	"\fsubresources\x18\t \x03(\tR\fsubresources\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\n" +
+	" \x03(\tR\n" +
+	"namespaces\"\xec\x02\n" +
+	"\x1dKubernetesApiAuthenticationCF\x12@\n" +
+	"\x04oidc\x18\x01 \x01(\v2,.plural.agent.kascfg.KubernetesApiOidcAuthCFR\x04oidc\x12W\n" +
+	"\fstatic_token\x18\x02 \x01(\v23.plural.agent.kascfg.KubernetesApiStaticTokenAuthCFR\fstatic_token\x12i\n" +
+	"\x12client_certificate\x18\x03 \x01(\v29.plural.agent.kascfg.KubernetesApiClientCertificateAuthCFR\x12client_certificate\x12E\n" +
+	"\x06ci_job\x18\x04 \x01(\v2-.plural.agent.kascfg.KubernetesApiCiJobAuthCFR\x06ci_job\"\xfe\x01\n" +
+	"\x17KubernetesApiOidcAuthCF\x12\x1f\n" +
+	"\x06issuer\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\x06issuer\x12#\n" +
+	"\baudience\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01R\baudience\x12%\n" +
+	"\tjwks_file\x18\x03 \x01(\tB\a\xfaB\x04r\x02 \x01R\tjwks_file\x12&\n" +
+	"\x0eusername_claim\x18\x04 \x01(\tR\x0eusername_claim\x12\"\n" +
+	"\fgroups_claim\x18\x05 \x01(\tR\fgroups_claim\x12*\n" +
+	"\vcluster_ids\x18\x06 \x03(\tB\b\xfaB\x05\x92\x01\x02\b\x01R\vcluster_ids\"u\n" +
+	"\x1eKubernetesApiStaticTokenAuthCF\x12'\n" +
+	"\n" +
+	"token_file\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\n" +
+	"token_file\x12*\n" +
+	"\vcluster_ids\x18\x02 \x03(\tB\b\xfaB\x05\x92\x01\x02\b\x01R\vcluster_ids\"\x1a\n" +
+	"\x18KubernetesApiCiJobAuthCF\"\x8d\x01\n" +
+	"$KubernetesApiClientCertificateAuthCF\x129\n" +
+	"\x13ca_certificate_file\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\x13ca_certificate_file\x12*\n" +
+	"\vcluster_ids\x18\x02 \x03(\tB\b\xfaB\x05\x92\x01\x02\b\x01R\vcluster_ids\"\xdb\x01\n" +
+	"\x15KubernetesApiLimitsCF\x12B\n" +
+	"\x1crequests_per_user_per_minute\x18\x01 \x01(\rR\x1crequests_per_user_per_minute\x12H\n" +
+	"\x1frequests_per_cluster_per_minute\x18\x02 \x01(\rR\x1frequests_per_cluster_per_minute\x124\n" +
+	"\x15max_request_body_size\x18\x03 \x01(\x04R\x15max_request_body_size\"\xbd\x02\n" +
+	"\x14KubernetesApiAuditCF\x12\x1e\n" +
+	"\n" +
+	"queue_size\x18\x01 \x01(\rR\n" +
+	"queue_size\x12\x1e\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\rR\n" +
+	"batch_size\x12K\n" +
+	"\x0eflush_interval\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x0eflush_interval\x12Q\n" +
+	"\x11max_retry_backoff\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x11max_retry_backoff\x12E\n" +
+	"\x04file\x18\x05 \x01(\v21.plural.agent.kascfg.KubernetesApiAuditFileSinkCFR\x04file\";\n" +
+	"\x1cKubernetesApiAuditFileSinkCF\x12\x1b\n" +
+	"\x04path\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04path\"\x90\x01\n" +
+	"\x19KubernetesApiKubeconfigCF\x12+\n" +
+	"\n" +
+	"server_url\x18\x01 \x01(\tB\v\xfaB\br\x06\xd0\x01\x01\x88\x01\x01R\n" +
+	"server_url\x12F\n" +
+	"\x04exec\x18\x02 \x01(\v22.plural.agent.kascfg.KubernetesApiKubeconfigExecCFR\x04exec\"r\n" +
+	"\x1dKubernetesApiDiscoveryCacheCF\x125\n" +
+	"\x03ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x03ttl\x12\x1a\n" +
+	"\bmax_size\x18\x02 \x01(\x04R\bmax_size\"\xb7\x01\n" +
+	"\x1fKubernetesApiSessionRecordingCF\x12 \n" +
+	"\vcluster_ids\x18\x01 \x03(\tR\vcluster_ids\x12\x16\n" +
+	"\x06groups\x18\x02 \x03(\tR\x06groups\x12Z\n" +
+	"\x04file\x18\x03 \x01(\v2<.plural.agent.kascfg.KubernetesApiSessionRecordingFileSinkCFB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04file\"D\n" +
+	"'KubernetesApiSessionRecordingFileSinkCF\x12\x19\n" +
+	"\x03dir\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\x03dir\"\xc0\x01\n" +
+	"\x1dKubernetesApiKubeconfigExecCF\x12!\n" +
+	"\acommand\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\acommand\x12\x12\n" +
+	"\x04args\x18\x02 \x03(\tR\x04args\x12\"\n" +
+	"\finstall_hint\x18\x03 \x01(\tR\finstall_hint\x12D\n" +
+	"\vtoken_types\x18\x04 \x03(\tB\"\xfaB\x1f\x92\x01\x1c\"\x1ar\x18R\x04plrlR\x02ciR\x04oidcR\x06staticR\vtoken_types\"\xc8\a\n" +
 	"\aAgentCF\x12:\n" +
 	"\x06listen\x18\x01 \x01(\v2\".plural.agent.kascfg.ListenAgentCFR\x06listen\x12O\n" +
 	"\rconfiguration\x18\x02 \x01(\v2).plural.agent.kascfg.AgentConfigurationCFR\rconfiguration\x12K\n" +
@@ -1980,10 +3485,26 @@ const file_pkg_kascfg_kascfg_proto_rawDesc = "" +
 	"\x17redis_conn_info_refresh\x18\b \x01(\v2\x19.google.protobuf.DurationR\x17redis_conn_info_refresh\x12I\n" +
 	"\x12redis_conn_info_gc\x18\t \x01(\v2\x19.google.protobuf.DurationR\x12redis_conn_info_gc\x12L\n" +
 	"\x0ekubernetes_api\x18\n" +
-	" \x01(\v2$.plural.agent.kascfg.KubernetesApiCFR\x0ekubernetes_api\"\x9f\x01\n" +
+	" \x01(\v2$.plural.agent.kascfg.KubernetesApiCFR\x0ekubernetes_api\x12Q\n" +
+	"\x0ereverse_tunnel\x18\v \x01(\v2).plural.agent.kascfg.AgentReverseTunnelCFR\x0ereverse_tunnel\x12[\n" +
+	"\x16connection_history_ttl\x18\f \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x16connection_history_ttl\x12F\n" +
+	"\fversion_skew\x18\r \x01(\v2\".plural.agent.kascfg.VersionSkewCFR\fversion_skew\x12W\n" +
+	"\x14token_overlap_window\x18\x0e \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x022\x00R\x14token_overlap_window\"\xb2\x01\n" +
+	"\rVersionSkewCF\x125\n" +
+	"\x06policy\x18\x01 \x01(\tB\x1d\xfaB\x1ar\x18R\x04warnR\brestrictR\x06rejectR\x06policy\x12:\n" +
+	"\x18supported_minor_versions\x18\x02 \x01(\rR\x18supported_minor_versions\x12.\n" +
+	"\x12allow_newer_agents\x18\x03 \x01(\bR\x12allow_newer_agents\"u\n" +
+	"\x14AgentReverseTunnelCF\x129\n" +
+	"\vcompression\x18\x01 \x01(\tB\x17\xfaB\x14r\x12R\x04noneR\x04gzipR\x04zstdR\vcompression\x12\"\n" +
+	"\fmultiplexing\x18\x02 \x01(\bR\fmultiplexing\"\xe5\x01\n" +
 	"\x14AgentConfigurationCF\x12E\n" +
 	"\vpoll_period\x18\x01 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\vpoll_period\x12@\n" +
-	"\x1bmax_configuration_file_size\x18\x02 \x01(\rR\x1bmax_configuration_file_size\"\x9e\x01\n" +
+	"\x1bmax_configuration_file_size\x18\x02 \x01(\rR\x1bmax_configuration_file_size\x12D\n" +
+	"\x05local\x18\x03 \x01(\v2..plural.agent.kascfg.AgentConfigurationLocalCFR\x05local\"r\n" +
+	"\x19AgentConfigurationLocalCF\x12\x1d\n" +
+	"\x04file\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\x04file\x12'\n" +
+	"\tdirectory\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\tdirectoryB\r\n" +
+	"\x06source\x12\x03\xf8B\x01\"\x9e\x01\n" +
 	"\x10GoogleProfilerCF\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1e\n" +
 	"\n" +
@@ -2009,7 +3530,7 @@ const file_pkg_kascfg_kascfg_proto_rawDesc = "" +
 	"\x0freadiness_probe\x18\t \x01(\v2%.plural.agent.kascfg.ReadinessProbeCFR\x0freadiness_probe\"\x82\x01\n" +
 	"\x16TokenBucketRateLimitCF\x12F\n" +
 	"\x16refill_rate_per_second\x18\x01 \x01(\x01B\x0e\xfaB\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00R\x16refill_rate_per_second\x12 \n" +
-	"\vbucket_size\x18\x02 \x01(\rR\vbucket_size\"\xec\x05\n" +
+	"\vbucket_size\x18\x02 \x01(\rR\vbucket_size\"\xe7\x05\n" +
 	"\aRedisCF\x12F\n" +
 	"\x06server\x18\x01 \x01(\v2\".plural.agent.kascfg.RedisServerCFB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x06server\x12L\n" +
 	"\bsentinel\x18\x02 \x01(\v2$.plural.agent.kascfg.RedisSentinelCFB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\bsentinel\x12\x1c\n" +
@@ -2026,8 +3547,8 @@ const file_pkg_kascfg_kascfg_proto_rawDesc = "" +
 	"\rpassword_file\x18\v \x01(\tR\rpassword_file\x12,\n" +
 	"\anetwork\x18\f \x01(\tB\x12\xfaB\x0fr\rR\x00R\x03tcpR\x04unixR\anetwork\x121\n" +
 	"\x03tls\x18\r \x01(\v2\x1f.plural.agent.kascfg.RedisTLSCFR\x03tls\x12/\n" +
-	"\x0edatabase_index\x18\x0e \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\x0edatabase_indexB\x13\n" +
-	"\fredis_config\x12\x03\xf8B\x01\"\xa0\x01\n" +
+	"\x0edatabase_index\x18\x0e \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\x0edatabase_indexB\x0e\n" +
+	"\fredis_config\"\xa0\x01\n" +
 	"\n" +
 	"RedisTLSCF\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12*\n" +
@@ -2064,16 +3585,28 @@ const file_pkg_kascfg_kascfg_proto_rawDesc = "" +
 	"\x05ApiCF\x12B\n" +
 	"\x06listen\x18\x01 \x01(\v2 .plural.agent.kascfg.ListenApiCFB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x06listen\"Y\n" +
 	"\fPrivateApiCF\x12I\n" +
-	"\x06listen\x18\x01 \x01(\v2'.plural.agent.kascfg.ListenPrivateApiCFB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x06listen\"\xf8\x02\n" +
+	"\x06listen\x18\x01 \x01(\v2'.plural.agent.kascfg.ListenPrivateApiCFB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x06listen\"\xa8\x03\n" +
 	"\x11ConfigurationFile\x122\n" +
 	"\x05agent\x18\x01 \x01(\v2\x1c.plural.agent.kascfg.AgentCFR\x05agent\x12J\n" +
-	"\robservability\x18\x02 \x01(\v2$.plural.agent.kascfg.ObservabilityCFR\robservability\x12<\n" +
-	"\x05redis\x18\x03 \x01(\v2\x1c.plural.agent.kascfg.RedisCFB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x05redis\x126\n" +
+	"\robservability\x18\x02 \x01(\v2$.plural.agent.kascfg.ObservabilityCFR\robservability\x122\n" +
+	"\x05redis\x18\x03 \x01(\v2\x1c.plural.agent.kascfg.RedisCFR\x05redis\x126\n" +
 	"\x03api\x18\x04 \x01(\v2\x1a.plural.agent.kascfg.ApiCFB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x03api\x12M\n" +
 	"\vprivate_api\x18\x05 \x01(\v2!.plural.agent.kascfg.PrivateApiCFB\b\xfaB\x05\x8a\x01\x02\x10\x01R\vprivate_api\x12\x1e\n" +
 	"\n" +
 	"plural_url\x18\x06 \x01(\tR\n" +
-	"plural_url*:\n" +
+	"plural_url\x128\n" +
+	"\astorage\x18\a \x01(\v2\x1e.plural.agent.kascfg.StorageCFR\astorage\"\x91\x01\n" +
+	"\tStorageCF\x12:\n" +
+	"\abackend\x18\x01 \x01(\tB \xfaB\x1dr\x1bR\x05redisR\x06memoryR\n" +
+	"kubernetesR\abackend\x12H\n" +
+	"\n" +
+	"kubernetes\x18\x02 \x01(\v2(.plural.agent.kascfg.KubernetesStorageCFR\n" +
+	"kubernetes\"\xbc\x01\n" +
+	"\x13KubernetesStorageCF\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12(\n" +
+	"\x0fkubeconfig_file\x18\x02 \x01(\tR\x0fkubeconfig_file\x12A\n" +
+	"\tgc_period\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\tgc_period\x12\x1a\n" +
+	"\breplicas\x18\x04 \x01(\rR\breplicas*:\n" +
 	"\x0elog_level_enum\x12\b\n" +
 	"\x04info\x10\x00\x12\t\n" +
 	"\x05debug\x10\x01\x12\b\n" +
@@ -2093,86 +3626,131 @@ func file_pkg_kascfg_kascfg_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_kascfg_kascfg_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_kascfg_kascfg_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_pkg_kascfg_kascfg_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_pkg_kascfg_kascfg_proto_goTypes = []any{
-	(LogLevelEnum)(0),              // 0: plural.agent.kascfg.log_level_enum
-	(*ListenAgentCF)(nil),          // 1: plural.agent.kascfg.ListenAgentCF
-	(*PrometheusCF)(nil),           // 2: plural.agent.kascfg.PrometheusCF
-	(*ObservabilityListenCF)(nil),  // 3: plural.agent.kascfg.ObservabilityListenCF
-	(*TracingCF)(nil),              // 4: plural.agent.kascfg.TracingCF
-	(*LoggingCF)(nil),              // 5: plural.agent.kascfg.LoggingCF
-	(*SentryCF)(nil),               // 6: plural.agent.kascfg.SentryCF
-	(*ListenKubernetesApiCF)(nil),  // 7: plural.agent.kascfg.ListenKubernetesApiCF
-	(*KubernetesApiCF)(nil),        // 8: plural.agent.kascfg.KubernetesApiCF
-	(*AgentCF)(nil),                // 9: plural.agent.kascfg.AgentCF
-	(*AgentConfigurationCF)(nil),   // 10: plural.agent.kascfg.AgentConfigurationCF
-	(*GoogleProfilerCF)(nil),       // 11: plural.agent.kascfg.GoogleProfilerCF
-	(*LivenessProbeCF)(nil),        // 12: plural.agent.kascfg.LivenessProbeCF
-	(*ReadinessProbeCF)(nil),       // 13: plural.agent.kascfg.ReadinessProbeCF
-	(*ObservabilityCF)(nil),        // 14: plural.agent.kascfg.ObservabilityCF
-	(*TokenBucketRateLimitCF)(nil), // 15: plural.agent.kascfg.TokenBucketRateLimitCF
-	(*RedisCF)(nil),                // 16: plural.agent.kascfg.RedisCF
-	(*RedisTLSCF)(nil),             // 17: plural.agent.kascfg.RedisTLSCF
-	(*RedisServerCF)(nil),          // 18: plural.agent.kascfg.RedisServerCF
-	(*RedisSentinelCF)(nil),        // 19: plural.agent.kascfg.RedisSentinelCF
-	(*ListenApiCF)(nil),            // 20: plural.agent.kascfg.ListenApiCF
-	(*ListenPrivateApiCF)(nil),     // 21: plural.agent.kascfg.ListenPrivateApiCF
-	(*ApiCF)(nil),                  // 22: plural.agent.kascfg.ApiCF
-	(*PrivateApiCF)(nil),           // 23: plural.agent.kascfg.PrivateApiCF
-	(*ConfigurationFile)(nil),      // 24: plural.agent.kascfg.ConfigurationFile
-	(*durationpb.Duration)(nil),    // 25: google.protobuf.Duration
+	(LogLevelEnum)(0),                               // 0: plural.agent.kascfg.log_level_enum
+	(*ListenAgentCF)(nil),                           // 1: plural.agent.kascfg.ListenAgentCF
+	(*PrometheusCF)(nil),                            // 2: plural.agent.kascfg.PrometheusCF
+	(*ObservabilityListenCF)(nil),                   // 3: plural.agent.kascfg.ObservabilityListenCF
+	(*TracingCF)(nil),                               // 4: plural.agent.kascfg.TracingCF
+	(*LoggingCF)(nil),                               // 5: plural.agent.kascfg.LoggingCF
+	(*SentryCF)(nil),                                // 6: plural.agent.kascfg.SentryCF
+	(*ListenKubernetesApiCF)(nil),                   // 7: plural.agent.kascfg.ListenKubernetesApiCF
+	(*KubernetesApiCF)(nil),                         // 8: plural.agent.kascfg.KubernetesApiCF
+	(*KubernetesApiPolicyCF)(nil),                   // 9: plural.agent.kascfg.KubernetesApiPolicyCF
+	(*KubernetesApiAuthenticationCF)(nil),           // 10: plural.agent.kascfg.KubernetesApiAuthenticationCF
+	(*KubernetesApiOidcAuthCF)(nil),                 // 11: plural.agent.kascfg.KubernetesApiOidcAuthCF
+	(*KubernetesApiStaticTokenAuthCF)(nil),          // 12: plural.agent.kascfg.KubernetesApiStaticTokenAuthCF
+	(*KubernetesApiCiJobAuthCF)(nil),                // 13: plural.agent.kascfg.KubernetesApiCiJobAuthCF
+	(*KubernetesApiClientCertificateAuthCF)(nil),    // 14: plural.agent.kascfg.KubernetesApiClientCertificateAuthCF
+	(*KubernetesApiLimitsCF)(nil),                   // 15: plural.agent.kascfg.KubernetesApiLimitsCF
+	(*KubernetesApiAuditCF)(nil),                    // 16: plural.agent.kascfg.KubernetesApiAuditCF
+	(*KubernetesApiAuditFileSinkCF)(nil),            // 17: plural.agent.kascfg.KubernetesApiAuditFileSinkCF
+	(*KubernetesApiKubeconfigCF)(nil),               // 18: plural.agent.kascfg.KubernetesApiKubeconfigCF
+	(*KubernetesApiDiscoveryCacheCF)(nil),           // 19: plural.agent.kascfg.KubernetesApiDiscoveryCacheCF
+	(*KubernetesApiSessionRecordingCF)(nil),         // 20: plural.agent.kascfg.KubernetesApiSessionRecordingCF
+	(*KubernetesApiSessionRecordingFileSinkCF)(nil), // 21: plural.agent.kascfg.KubernetesApiSessionRecordingFileSinkCF
+	(*KubernetesApiKubeconfigExecCF)(nil),           // 22: plural.agent.kascfg.KubernetesApiKubeconfigExecCF
+	(*AgentCF)(nil),                                 // 23: plural.agent.kascfg.AgentCF
+	(*VersionSkewCF)(nil),                           // 24: plural.agent.kascfg.VersionSkewCF
+	(*AgentReverseTunnelCF)(nil),                    // 25: plural.agent.kascfg.AgentReverseTunnelCF
+	(*AgentConfigurationCF)(nil),                    // 26: plural.agent.kascfg.AgentConfigurationCF
+	(*AgentConfigurationLocalCF)(nil),               // 27: plural.agent.kascfg.AgentConfigurationLocalCF
+	(*GoogleProfilerCF)(nil),                        // 28: plural.agent.kascfg.GoogleProfilerCF
+	(*LivenessProbeCF)(nil),                         // 29: plural.agent.kascfg.LivenessProbeCF
+	(*ReadinessProbeCF)(nil),                        // 30: plural.agent.kascfg.ReadinessProbeCF
+	(*ObservabilityCF)(nil),                         // 31: plural.agent.kascfg.ObservabilityCF
+	(*TokenBucketRateLimitCF)(nil),                  // 32: plural.agent.kascfg.TokenBucketRateLimitCF
+	(*RedisCF)(nil),                                 // 33: plural.agent.kascfg.RedisCF
+	(*RedisTLSCF)(nil),                              // 34: plural.agent.kascfg.RedisTLSCF
+	(*RedisServerCF)(nil),                           // 35: plural.agent.kascfg.RedisServerCF
+	(*RedisSentinelCF)(nil),                         // 36: plural.agent.kascfg.RedisSentinelCF
+	(*ListenApiCF)(nil),                             // 37: plural.agent.kascfg.ListenApiCF
+	(*ListenPrivateApiCF)(nil),                      // 38: plural.agent.kascfg.ListenPrivateApiCF
+	(*ApiCF)(nil),                                   // 39: plural.agent.kascfg.ApiCF
+	(*PrivateApiCF)(nil),                            // 40: plural.agent.kascfg.PrivateApiCF
+	(*ConfigurationFile)(nil),                       // 41: plural.agent.kascfg.ConfigurationFile
+	(*StorageCF)(nil),                               // 42: plural.agent.kascfg.StorageCF
+	(*KubernetesStorageCF)(nil),                     // 43: plural.agent.kascfg.KubernetesStorageCF
+	(*durationpb.Duration)(nil),                     // 44: google.protobuf.Duration
 }
 var file_pkg_kascfg_kascfg_proto_depIdxs = []int32{
-	25, // 0: plural.agent.kascfg.ListenAgentCF.max_connection_age:type_name -> google.protobuf.Duration
-	25, // 1: plural.agent.kascfg.ListenAgentCF.listen_grace_period:type_name -> google.protobuf.Duration
-	0,  // 2: plural.agent.kascfg.LoggingCF.level:type_name -> plural.agent.kascfg.log_level_enum
-	0,  // 3: plural.agent.kascfg.LoggingCF.grpc_level:type_name -> plural.agent.kascfg.log_level_enum
-	25, // 4: plural.agent.kascfg.ListenKubernetesApiCF.listen_grace_period:type_name -> google.protobuf.Duration
-	25, // 5: plural.agent.kascfg.ListenKubernetesApiCF.shutdown_grace_period:type_name -> google.protobuf.Duration
-	7,  // 6: plural.agent.kascfg.KubernetesApiCF.listen:type_name -> plural.agent.kascfg.ListenKubernetesApiCF
-	25, // 7: plural.agent.kascfg.KubernetesApiCF.allowed_agent_cache_ttl:type_name -> google.protobuf.Duration
-	25, // 8: plural.agent.kascfg.KubernetesApiCF.allowed_agent_cache_error_ttl:type_name -> google.protobuf.Duration
-	1,  // 9: plural.agent.kascfg.AgentCF.listen:type_name -> plural.agent.kascfg.ListenAgentCF
-	10, // 10: plural.agent.kascfg.AgentCF.configuration:type_name -> plural.agent.kascfg.AgentConfigurationCF
-	25, // 11: plural.agent.kascfg.AgentCF.info_cache_ttl:type_name -> google.protobuf.Duration
-	25, // 12: plural.agent.kascfg.AgentCF.info_cache_error_ttl:type_name -> google.protobuf.Duration
-	25, // 13: plural.agent.kascfg.AgentCF.redis_conn_info_ttl:type_name -> google.protobuf.Duration
-	25, // 14: plural.agent.kascfg.AgentCF.redis_conn_info_refresh:type_name -> google.protobuf.Duration
-	25, // 15: plural.agent.kascfg.AgentCF.redis_conn_info_gc:type_name -> google.protobuf.Duration
-	8,  // 16: plural.agent.kascfg.AgentCF.kubernetes_api:type_name -> plural.agent.kascfg.KubernetesApiCF
-	25, // 17: plural.agent.kascfg.AgentConfigurationCF.poll_period:type_name -> google.protobuf.Duration
-	25, // 18: plural.agent.kascfg.ObservabilityCF.usage_reporting_period:type_name -> google.protobuf.Duration
-	3,  // 19: plural.agent.kascfg.ObservabilityCF.listen:type_name -> plural.agent.kascfg.ObservabilityListenCF
-	2,  // 20: plural.agent.kascfg.ObservabilityCF.prometheus:type_name -> plural.agent.kascfg.PrometheusCF
-	4,  // 21: plural.agent.kascfg.ObservabilityCF.tracing:type_name -> plural.agent.kascfg.TracingCF
-	6,  // 22: plural.agent.kascfg.ObservabilityCF.sentry:type_name -> plural.agent.kascfg.SentryCF
-	5,  // 23: plural.agent.kascfg.ObservabilityCF.logging:type_name -> plural.agent.kascfg.LoggingCF
-	11, // 24: plural.agent.kascfg.ObservabilityCF.google_profiler:type_name -> plural.agent.kascfg.GoogleProfilerCF
-	12, // 25: plural.agent.kascfg.ObservabilityCF.liveness_probe:type_name -> plural.agent.kascfg.LivenessProbeCF
-	13, // 26: plural.agent.kascfg.ObservabilityCF.readiness_probe:type_name -> plural.agent.kascfg.ReadinessProbeCF
-	18, // 27: plural.agent.kascfg.RedisCF.server:type_name -> plural.agent.kascfg.RedisServerCF
-	19, // 28: plural.agent.kascfg.RedisCF.sentinel:type_name -> plural.agent.kascfg.RedisSentinelCF
-	25, // 29: plural.agent.kascfg.RedisCF.dial_timeout:type_name -> google.protobuf.Duration
-	25, // 30: plural.agent.kascfg.RedisCF.read_timeout:type_name -> google.protobuf.Duration
-	25, // 31: plural.agent.kascfg.RedisCF.write_timeout:type_name -> google.protobuf.Duration
-	25, // 32: plural.agent.kascfg.RedisCF.idle_timeout:type_name -> google.protobuf.Duration
-	17, // 33: plural.agent.kascfg.RedisCF.tls:type_name -> plural.agent.kascfg.RedisTLSCF
-	25, // 34: plural.agent.kascfg.ListenApiCF.max_connection_age:type_name -> google.protobuf.Duration
-	25, // 35: plural.agent.kascfg.ListenApiCF.listen_grace_period:type_name -> google.protobuf.Duration
-	25, // 36: plural.agent.kascfg.ListenPrivateApiCF.max_connection_age:type_name -> google.protobuf.Duration
-	25, // 37: plural.agent.kascfg.ListenPrivateApiCF.listen_grace_period:type_name -> google.protobuf.Duration
-	20, // 38: plural.agent.kascfg.ApiCF.listen:type_name -> plural.agent.kascfg.ListenApiCF
-	21, // 39: plural.agent.kascfg.PrivateApiCF.listen:type_name -> plural.agent.kascfg.ListenPrivateApiCF
-	9,  // 40: plural.agent.kascfg.ConfigurationFile.agent:type_name -> plural.agent.kascfg.AgentCF
-	14, // 41: plural.agent.kascfg.ConfigurationFile.observability:type_name -> plural.agent.kascfg.ObservabilityCF
-	16, // 42: plural.agent.kascfg.ConfigurationFile.redis:type_name -> plural.agent.kascfg.RedisCF
-	22, // 43: plural.agent.kascfg.ConfigurationFile.api:type_name -> plural.agent.kascfg.ApiCF
-	23, // 44: plural.agent.kascfg.ConfigurationFile.private_api:type_name -> plural.agent.kascfg.PrivateApiCF
-	45, // [45:45] is the sub-list for method output_type
-	45, // [45:45] is the sub-list for method input_type
-	45, // [45:45] is the sub-list for extension type_name
-	45, // [45:45] is the sub-list for extension extendee
-	0,  // [0:45] is the sub-list for field type_name
+	44, // 0: plural.agent.kascfg.ListenAgentCF.max_connection_age:type_name -> google.protobuf.Duration
+	44, // 1: plural.agent.kascfg.ListenAgentCF.listen_grace_period:type_name -> google.protobuf.Duration
+	44, // 2: plural.agent.kascfg.ListenAgentCF.drain_grace_period:type_name -> google.protobuf.Duration
+	0,  // 3: plural.agent.kascfg.LoggingCF.level:type_name -> plural.agent.kascfg.log_level_enum
+	0,  // 4: plural.agent.kascfg.LoggingCF.grpc_level:type_name -> plural.agent.kascfg.log_level_enum
+	44, // 5: plural.agent.kascfg.ListenKubernetesApiCF.listen_grace_period:type_name -> google.protobuf.Duration
+	44, // 6: plural.agent.kascfg.ListenKubernetesApiCF.shutdown_grace_period:type_name -> google.protobuf.Duration
+	7,  // 7: plural.agent.kascfg.KubernetesApiCF.listen:type_name -> plural.agent.kascfg.ListenKubernetesApiCF
+	44, // 8: plural.agent.kascfg.KubernetesApiCF.allowed_agent_cache_ttl:type_name -> google.protobuf.Duration
+	44, // 9: plural.agent.kascfg.KubernetesApiCF.allowed_agent_cache_error_ttl:type_name -> google.protobuf.Duration
+	10, // 10: plural.agent.kascfg.KubernetesApiCF.authentication:type_name -> plural.agent.kascfg.KubernetesApiAuthenticationCF
+	9,  // 11: plural.agent.kascfg.KubernetesApiCF.policies:type_name -> plural.agent.kascfg.KubernetesApiPolicyCF
+	16, // 12: plural.agent.kascfg.KubernetesApiCF.audit:type_name -> plural.agent.kascfg.KubernetesApiAuditCF
+	15, // 13: plural.agent.kascfg.KubernetesApiCF.limits:type_name -> plural.agent.kascfg.KubernetesApiLimitsCF
+	18, // 14: plural.agent.kascfg.KubernetesApiCF.kubeconfig:type_name -> plural.agent.kascfg.KubernetesApiKubeconfigCF
+	19, // 15: plural.agent.kascfg.KubernetesApiCF.discovery_cache:type_name -> plural.agent.kascfg.KubernetesApiDiscoveryCacheCF
+	20, // 16: plural.agent.kascfg.KubernetesApiCF.session_recording:type_name -> plural.agent.kascfg.KubernetesApiSessionRecordingCF
+	11, // 17: plural.agent.kascfg.KubernetesApiAuthenticationCF.oidc:type_name -> plural.agent.kascfg.KubernetesApiOidcAuthCF
+	12, // 18: plural.agent.kascfg.KubernetesApiAuthenticationCF.static_token:type_name -> plural.agent.kascfg.KubernetesApiStaticTokenAuthCF
+	14, // 19: plural.agent.kascfg.KubernetesApiAuthenticationCF.client_certificate:type_name -> plural.agent.kascfg.KubernetesApiClientCertificateAuthCF
+	13, // 20: plural.agent.kascfg.KubernetesApiAuthenticationCF.ci_job:type_name -> plural.agent.kascfg.KubernetesApiCiJobAuthCF
+	44, // 21: plural.agent.kascfg.KubernetesApiAuditCF.flush_interval:type_name -> google.protobuf.Duration
+	44, // 22: plural.agent.kascfg.KubernetesApiAuditCF.max_retry_backoff:type_name -> google.protobuf.Duration
+	17, // 23: plural.agent.kascfg.KubernetesApiAuditCF.file:type_name -> plural.agent.kascfg.KubernetesApiAuditFileSinkCF
+	22, // 24: plural.agent.kascfg.KubernetesApiKubeconfigCF.exec:type_name -> plural.agent.kascfg.KubernetesApiKubeconfigExecCF
+	44, // 25: plural.agent.kascfg.KubernetesApiDiscoveryCacheCF.ttl:type_name -> google.protobuf.Duration
+	21, // 26: plural.agent.kascfg.KubernetesApiSessionRecordingCF.file:type_name -> plural.agent.kascfg.KubernetesApiSessionRecordingFileSinkCF
+	1,  // 27: plural.agent.kascfg.AgentCF.listen:type_name -> plural.agent.kascfg.ListenAgentCF
+	26, // 28: plural.agent.kascfg.AgentCF.configuration:type_name -> plural.agent.kascfg.AgentConfigurationCF
+	44, // 29: plural.agent.kascfg.AgentCF.info_cache_ttl:type_name -> google.protobuf.Duration
+	44, // 30: plural.agent.kascfg.AgentCF.info_cache_error_ttl:type_name -> google.protobuf.Duration
+	44, // 31: plural.agent.kascfg.AgentCF.redis_conn_info_ttl:type_name -> google.protobuf.Duration
+	44, // 32: plural.agent.kascfg.AgentCF.redis_conn_info_refresh:type_name -> google.protobuf.Duration
+	44, // 33: plural.agent.kascfg.AgentCF.redis_conn_info_gc:type_name -> google.protobuf.Duration
+	8,  // 34: plural.agent.kascfg.AgentCF.kubernetes_api:type_name -> plural.agent.kascfg.KubernetesApiCF
+	25, // 35: plural.agent.kascfg.AgentCF.reverse_tunnel:type_name -> plural.agent.kascfg.AgentReverseTunnelCF
+	44, // 36: plural.agent.kascfg.AgentCF.connection_history_ttl:type_name -> google.protobuf.Duration
+	24, // 37: plural.agent.kascfg.AgentCF.version_skew:type_name -> plural.agent.kascfg.VersionSkewCF
+	44, // 38: plural.agent.kascfg.AgentCF.token_overlap_window:type_name -> google.protobuf.Duration
+	44, // 39: plural.agent.kascfg.AgentConfigurationCF.poll_period:type_name -> google.protobuf.Duration
+	27, // 40: plural.agent.kascfg.AgentConfigurationCF.local:type_name -> plural.agent.kascfg.AgentConfigurationLocalCF
+	44, // 41: plural.agent.kascfg.ObservabilityCF.usage_reporting_period:type_name -> google.protobuf.Duration
+	3,  // 42: plural.agent.kascfg.ObservabilityCF.listen:type_name -> plural.agent.kascfg.ObservabilityListenCF
+	2,  // 43: plural.agent.kascfg.ObservabilityCF.prometheus:type_name -> plural.agent.kascfg.PrometheusCF
+	4,  // 44: plural.agent.kascfg.ObservabilityCF.tracing:type_name -> plural.agent.kascfg.TracingCF
+	6,  // 45: plural.agent.kascfg.ObservabilityCF.sentry:type_name -> plural.agent.kascfg.SentryCF
+	5,  // 46: plural.agent.kascfg.ObservabilityCF.logging:type_name -> plural.agent.kascfg.LoggingCF
+	28, // 47: plural.agent.kascfg.ObservabilityCF.google_profiler:type_name -> plural.agent.kascfg.GoogleProfilerCF
+	29, // 48: plural.agent.kascfg.ObservabilityCF.liveness_probe:type_name -> plural.agent.kascfg.LivenessProbeCF
+	30, // 49: plural.agent.kascfg.ObservabilityCF.readiness_probe:type_name -> plural.agent.kascfg.ReadinessProbeCF
+	35, // 50: plural.agent.kascfg.RedisCF.server:type_name -> plural.agent.kascfg.RedisServerCF
+	36, // 51: plural.agent.kascfg.RedisCF.sentinel:type_name -> plural.agent.kascfg.RedisSentinelCF
+	44, // 52: plural.agent.kascfg.RedisCF.dial_timeout:type_name -> google.protobuf.Duration
+	44, // 53: plural.agent.kascfg.RedisCF.read_timeout:type_name -> google.protobuf.Duration
+	44, // 54: plural.agent.kascfg.RedisCF.write_timeout:type_name -> google.protobuf.Duration
+	44, // 55: plural.agent.kascfg.RedisCF.idle_timeout:type_name -> google.protobuf.Duration
+	34, // 56: plural.agent.kascfg.RedisCF.tls:type_name -> plural.agent.kascfg.RedisTLSCF
+	44, // 57: plural.agent.kascfg.ListenApiCF.max_connection_age:type_name -> google.protobuf.Duration
+	44, // 58: plural.agent.kascfg.ListenApiCF.listen_grace_period:type_name -> google.protobuf.Duration
+	44, // 59: plural.agent.kascfg.ListenPrivateApiCF.max_connection_age:type_name -> google.protobuf.Duration
+	44, // 60: plural.agent.kascfg.ListenPrivateApiCF.listen_grace_period:type_name -> google.protobuf.Duration
+	37, // 61: plural.agent.kascfg.ApiCF.listen:type_name -> plural.agent.kascfg.ListenApiCF
+	38, // 62: plural.agent.kascfg.PrivateApiCF.listen:type_name -> plural.agent.kascfg.ListenPrivateApiCF
+	23, // 63: plural.agent.kascfg.ConfigurationFile.agent:type_name -> plural.agent.kascfg.AgentCF
+	31, // 64: plural.agent.kascfg.ConfigurationFile.observability:type_name -> plural.agent.kascfg.ObservabilityCF
+	33, // 65: plural.agent.kascfg.ConfigurationFile.redis:type_name -> plural.agent.kascfg.RedisCF
+	39, // 66: plural.agent.kascfg.ConfigurationFile.api:type_name -> plural.agent.kascfg.ApiCF
+	40, // 67: plural.agent.kascfg.ConfigurationFile.private_api:type_name -> plural.agent.kascfg.PrivateApiCF
+	42, // 68: plural.agent.kascfg.ConfigurationFile.storage:type_name -> plural.agent.kascfg.StorageCF
+	43, // 69: plural.agent.kascfg.StorageCF.kubernetes:type_name -> plural.agent.kascfg.KubernetesStorageCF
+	44, // 70: plural.agent.kascfg.KubernetesStorageCF.gc_period:type_name -> google.protobuf.Duration
+	71, // [71:71] is the sub-list for method output_type
+	71, // [71:71] is the sub-list for method input_type
+	71, // [71:71] is the sub-list for extension type_name
+	71, // [71:71] is the sub-list for extension extendee
+	0,  // [0:71] is the sub-list for field type_name
 }
 
 func init() { file_pkg_kascfg_kascfg_proto_init() }
@@ -2185,19 +3763,23 @@ func file_pkg_kascfg_kascfg_proto_init() {
 	file_pkg_kascfg_kascfg_proto_msgTypes[3].OneofWrappers = []any{}
 	file_pkg_kascfg_kascfg_proto_msgTypes[4].OneofWrappers = []any{}
 	file_pkg_kascfg_kascfg_proto_msgTypes[6].OneofWrappers = []any{}
-	file_pkg_kascfg_kascfg_proto_msgTypes[15].OneofWrappers = []any{
+	file_pkg_kascfg_kascfg_proto_msgTypes[26].OneofWrappers = []any{
+		(*AgentConfigurationLocalCF_File)(nil),
+		(*AgentConfigurationLocalCF_Directory)(nil),
+	}
+	file_pkg_kascfg_kascfg_proto_msgTypes[32].OneofWrappers = []any{
 		(*RedisCF_Server)(nil),
 		(*RedisCF_Sentinel)(nil),
 	}
-	file_pkg_kascfg_kascfg_proto_msgTypes[19].OneofWrappers = []any{}
-	file_pkg_kascfg_kascfg_proto_msgTypes[20].OneofWrappers = []any{}
+	file_pkg_kascfg_kascfg_proto_msgTypes[36].OneofWrappers = []any{}
+	file_pkg_kascfg_kascfg_proto_msgTypes[37].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_kascfg_kascfg_proto_rawDesc), len(file_pkg_kascfg_kascfg_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   24,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -127,6 +127,36 @@ func (m *ListenAgentCF) validate(all bool) error {
 		}
 	}
 
+	if d := m.GetDrainGracePeriod(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ListenAgentCFValidationError{
+				field:  "DrainGracePeriod",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := ListenAgentCFValidationError{
+					field:  "DrainGracePeriod",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
 	if m.Network != nil {
 
 		if _, ok := _ListenAgentCF_Network_InLookup[m.GetNetwork()]; !ok {
@@ -1135,6 +1165,214 @@ func (m *KubernetesApiCF) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetAuthentication()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Authentication",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Authentication",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAuthentication()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiCFValidationError{
+				field:  "Authentication",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetPolicies() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, KubernetesApiCFValidationError{
+						field:  fmt.Sprintf("Policies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, KubernetesApiCFValidationError{
+						field:  fmt.Sprintf("Policies[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return KubernetesApiCFValidationError{
+					field:  fmt.Sprintf("Policies[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetAudit()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Audit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Audit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAudit()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiCFValidationError{
+				field:  "Audit",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLimits()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Limits",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Limits",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLimits()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiCFValidationError{
+				field:  "Limits",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetKubeconfig()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Kubeconfig",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "Kubeconfig",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetKubeconfig()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiCFValidationError{
+				field:  "Kubeconfig",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetDiscoveryCache()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "DiscoveryCache",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "DiscoveryCache",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDiscoveryCache()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiCFValidationError{
+				field:  "DiscoveryCache",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSessionRecording()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "SessionRecording",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiCFValidationError{
+					field:  "SessionRecording",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSessionRecording()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiCFValidationError{
+				field:  "SessionRecording",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return KubernetesApiCFMultiError(errors)
 	}
@@ -1213,168 +1451,180 @@ var _ interface {
 	ErrorName() string
 } = KubernetesApiCFValidationError{}
 
-// Validate checks the field values on AgentCF with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *AgentCF) Validate() error {
+// Validate checks the field values on KubernetesApiPolicyCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiPolicyCF) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on AgentCF with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in AgentCFMultiError, or nil if none found.
-func (m *AgentCF) ValidateAll() error {
+// ValidateAll checks the field values on KubernetesApiPolicyCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesApiPolicyCFMultiError, or nil if none found.
+func (m *KubernetesApiPolicyCF) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *AgentCF) validate(all bool) error {
+func (m *KubernetesApiPolicyCF) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetListen()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "Listen",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "Listen",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
+	if len(m.GetName()) < 1 {
+		err := KubernetesApiPolicyCFValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 bytes",
 		}
-	} else if v, ok := interface{}(m.GetListen()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return AgentCFValidationError{
-				field:  "Listen",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
-	if all {
-		switch v := interface{}(m.GetConfiguration()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "Configuration",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "Configuration",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
+	if _, ok := _KubernetesApiPolicyCF_Effect_InLookup[m.GetEffect()]; !ok {
+		err := KubernetesApiPolicyCFValidationError{
+			field:  "Effect",
+			reason: "value must be in list [allow deny]",
 		}
-	} else if v, ok := interface{}(m.GetConfiguration()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return AgentCFValidationError{
-				field:  "Configuration",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
-	if d := m.GetInfoCacheTtl(); d != nil {
-		dur, err := d.AsDuration(), d.CheckValid()
-		if err != nil {
-			err = AgentCFValidationError{
-				field:  "InfoCacheTtl",
-				reason: "value is not a valid duration",
-				cause:  err,
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		} else {
+	if len(errors) > 0 {
+		return KubernetesApiPolicyCFMultiError(errors)
+	}
 
-			gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+	return nil
+}
 
-			if dur < gte {
-				err := AgentCFValidationError{
-					field:  "InfoCacheTtl",
-					reason: "value must be greater than or equal to 0s",
-				}
-				if !all {
-					return err
-				}
-				errors = append(errors, err)
-			}
+// KubernetesApiPolicyCFMultiError is an error wrapping multiple validation
+// errors returned by KubernetesApiPolicyCF.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesApiPolicyCFMultiError []error
 
-		}
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiPolicyCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
 	}
+	return strings.Join(msgs, "; ")
+}
 
-	if d := m.GetInfoCacheErrorTtl(); d != nil {
-		dur, err := d.AsDuration(), d.CheckValid()
-		if err != nil {
-			err = AgentCFValidationError{
-				field:  "InfoCacheErrorTtl",
-				reason: "value is not a valid duration",
-				cause:  err,
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		} else {
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiPolicyCFMultiError) AllErrors() []error { return m }
 
-			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+// KubernetesApiPolicyCFValidationError is the validation error returned by
+// KubernetesApiPolicyCF.Validate if the designated constraints aren't met.
+type KubernetesApiPolicyCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
 
-			if dur <= gt {
-				err := AgentCFValidationError{
-					field:  "InfoCacheErrorTtl",
-					reason: "value must be greater than 0s",
-				}
-				if !all {
-					return err
-				}
-				errors = append(errors, err)
-			}
+// Field function returns field value.
+func (e KubernetesApiPolicyCFValidationError) Field() string { return e.field }
 
-		}
+// Reason function returns reason value.
+func (e KubernetesApiPolicyCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiPolicyCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiPolicyCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiPolicyCFValidationError) ErrorName() string {
+	return "KubernetesApiPolicyCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiPolicyCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
 	}
 
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiPolicyCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiPolicyCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiPolicyCFValidationError{}
+
+var _KubernetesApiPolicyCF_Effect_InLookup = map[string]struct{}{
+	"allow": {},
+	"deny":  {},
+}
+
+// Validate checks the field values on KubernetesApiAuthenticationCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiAuthenticationCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiAuthenticationCF with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// KubernetesApiAuthenticationCFMultiError, or nil if none found.
+func (m *KubernetesApiAuthenticationCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiAuthenticationCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
 	if all {
-		switch v := interface{}(m.GetRedisConnInfoTtl()).(type) {
+		switch v := interface{}(m.GetOidc()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "RedisConnInfoTtl",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "Oidc",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "RedisConnInfoTtl",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "Oidc",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetRedisConnInfoTtl()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetOidc()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AgentCFValidationError{
-				field:  "RedisConnInfoTtl",
+			return KubernetesApiAuthenticationCFValidationError{
+				field:  "Oidc",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -1382,28 +1632,28 @@ func (m *AgentCF) validate(all bool) error {
 	}
 
 	if all {
-		switch v := interface{}(m.GetRedisConnInfoRefresh()).(type) {
+		switch v := interface{}(m.GetStaticToken()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "RedisConnInfoRefresh",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "StaticToken",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "RedisConnInfoRefresh",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "StaticToken",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetRedisConnInfoRefresh()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetStaticToken()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AgentCFValidationError{
-				field:  "RedisConnInfoRefresh",
+			return KubernetesApiAuthenticationCFValidationError{
+				field:  "StaticToken",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -1411,28 +1661,28 @@ func (m *AgentCF) validate(all bool) error {
 	}
 
 	if all {
-		switch v := interface{}(m.GetRedisConnInfoGc()).(type) {
+		switch v := interface{}(m.GetClientCertificate()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "RedisConnInfoGc",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "ClientCertificate",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "RedisConnInfoGc",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "ClientCertificate",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetRedisConnInfoGc()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetClientCertificate()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AgentCFValidationError{
-				field:  "RedisConnInfoGc",
+			return KubernetesApiAuthenticationCFValidationError{
+				field:  "ClientCertificate",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -1440,28 +1690,28 @@ func (m *AgentCF) validate(all bool) error {
 	}
 
 	if all {
-		switch v := interface{}(m.GetKubernetesApi()).(type) {
+		switch v := interface{}(m.GetCiJob()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "KubernetesApi",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "CiJob",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, AgentCFValidationError{
-					field:  "KubernetesApi",
+				errors = append(errors, KubernetesApiAuthenticationCFValidationError{
+					field:  "CiJob",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetKubernetesApi()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetCiJob()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AgentCFValidationError{
-				field:  "KubernetesApi",
+			return KubernetesApiAuthenticationCFValidationError{
+				field:  "CiJob",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -1469,18 +1719,19 @@ func (m *AgentCF) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return AgentCFMultiError(errors)
+		return KubernetesApiAuthenticationCFMultiError(errors)
 	}
 
 	return nil
 }
 
-// AgentCFMultiError is an error wrapping multiple validation errors returned
-// by AgentCF.ValidateAll() if the designated constraints aren't met.
-type AgentCFMultiError []error
+// KubernetesApiAuthenticationCFMultiError is an error wrapping multiple
+// validation errors returned by KubernetesApiAuthenticationCF.ValidateAll()
+// if the designated constraints aren't met.
+type KubernetesApiAuthenticationCFMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AgentCFMultiError) Error() string {
+func (m KubernetesApiAuthenticationCFMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1489,11 +1740,12 @@ func (m AgentCFMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AgentCFMultiError) AllErrors() []error { return m }
+func (m KubernetesApiAuthenticationCFMultiError) AllErrors() []error { return m }
 
-// AgentCFValidationError is the validation error returned by AgentCF.Validate
-// if the designated constraints aren't met.
-type AgentCFValidationError struct {
+// KubernetesApiAuthenticationCFValidationError is the validation error
+// returned by KubernetesApiAuthenticationCF.Validate if the designated
+// constraints aren't met.
+type KubernetesApiAuthenticationCFValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1501,22 +1753,24 @@ type AgentCFValidationError struct {
 }
 
 // Field function returns field value.
-func (e AgentCFValidationError) Field() string { return e.field }
+func (e KubernetesApiAuthenticationCFValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AgentCFValidationError) Reason() string { return e.reason }
+func (e KubernetesApiAuthenticationCFValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AgentCFValidationError) Cause() error { return e.cause }
+func (e KubernetesApiAuthenticationCFValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AgentCFValidationError) Key() bool { return e.key }
+func (e KubernetesApiAuthenticationCFValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AgentCFValidationError) ErrorName() string { return "AgentCFValidationError" }
+func (e KubernetesApiAuthenticationCFValidationError) ErrorName() string {
+	return "KubernetesApiAuthenticationCFValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e AgentCFValidationError) Error() string {
+func (e KubernetesApiAuthenticationCFValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1528,14 +1782,14 @@ func (e AgentCFValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAgentCF.%s: %s%s",
+		"invalid %sKubernetesApiAuthenticationCF.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AgentCFValidationError{}
+var _ error = KubernetesApiAuthenticationCFValidationError{}
 
 var _ interface {
 	Field() string
@@ -1543,76 +1797,92 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AgentCFValidationError{}
+} = KubernetesApiAuthenticationCFValidationError{}
 
-// Validate checks the field values on AgentConfigurationCF with the rules
+// Validate checks the field values on KubernetesApiOidcAuthCF with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *AgentConfigurationCF) Validate() error {
+func (m *KubernetesApiOidcAuthCF) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on AgentConfigurationCF with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on KubernetesApiOidcAuthCF with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// AgentConfigurationCFMultiError, or nil if none found.
-func (m *AgentConfigurationCF) ValidateAll() error {
+// KubernetesApiOidcAuthCFMultiError, or nil if none found.
+func (m *KubernetesApiOidcAuthCF) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *AgentConfigurationCF) validate(all bool) error {
+func (m *KubernetesApiOidcAuthCF) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if d := m.GetPollPeriod(); d != nil {
-		dur, err := d.AsDuration(), d.CheckValid()
-		if err != nil {
-			err = AgentConfigurationCFValidationError{
-				field:  "PollPeriod",
-				reason: "value is not a valid duration",
-				cause:  err,
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		} else {
-
-			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+	if len(m.GetIssuer()) < 1 {
+		err := KubernetesApiOidcAuthCFValidationError{
+			field:  "Issuer",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-			if dur <= gt {
-				err := AgentConfigurationCFValidationError{
-					field:  "PollPeriod",
-					reason: "value must be greater than 0s",
-				}
-				if !all {
-					return err
-				}
-				errors = append(errors, err)
-			}
+	if len(m.GetAudience()) < 1 {
+		err := KubernetesApiOidcAuthCFValidationError{
+			field:  "Audience",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
+	if len(m.GetJwksFile()) < 1 {
+		err := KubernetesApiOidcAuthCFValidationError{
+			field:  "JwksFile",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
-	// no validation rules for MaxConfigurationFileSize
+	// no validation rules for UsernameClaim
+
+	// no validation rules for GroupsClaim
+
+	if len(m.GetClusterIds()) < 1 {
+		err := KubernetesApiOidcAuthCFValidationError{
+			field:  "ClusterIds",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return AgentConfigurationCFMultiError(errors)
+		return KubernetesApiOidcAuthCFMultiError(errors)
 	}
 
 	return nil
 }
 
-// AgentConfigurationCFMultiError is an error wrapping multiple validation
-// errors returned by AgentConfigurationCF.ValidateAll() if the designated
+// KubernetesApiOidcAuthCFMultiError is an error wrapping multiple validation
+// errors returned by KubernetesApiOidcAuthCF.ValidateAll() if the designated
 // constraints aren't met.
-type AgentConfigurationCFMultiError []error
+type KubernetesApiOidcAuthCFMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AgentConfigurationCFMultiError) Error() string {
+func (m KubernetesApiOidcAuthCFMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1621,11 +1891,11 @@ func (m AgentConfigurationCFMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AgentConfigurationCFMultiError) AllErrors() []error { return m }
+func (m KubernetesApiOidcAuthCFMultiError) AllErrors() []error { return m }
 
-// AgentConfigurationCFValidationError is the validation error returned by
-// AgentConfigurationCF.Validate if the designated constraints aren't met.
-type AgentConfigurationCFValidationError struct {
+// KubernetesApiOidcAuthCFValidationError is the validation error returned by
+// KubernetesApiOidcAuthCF.Validate if the designated constraints aren't met.
+type KubernetesApiOidcAuthCFValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1633,24 +1903,2506 @@ type AgentConfigurationCFValidationError struct {
 }
 
 // Field function returns field value.
-func (e AgentConfigurationCFValidationError) Field() string { return e.field }
+func (e KubernetesApiOidcAuthCFValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AgentConfigurationCFValidationError) Reason() string { return e.reason }
+func (e KubernetesApiOidcAuthCFValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AgentConfigurationCFValidationError) Cause() error { return e.cause }
+func (e KubernetesApiOidcAuthCFValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AgentConfigurationCFValidationError) Key() bool { return e.key }
+func (e KubernetesApiOidcAuthCFValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AgentConfigurationCFValidationError) ErrorName() string {
-	return "AgentConfigurationCFValidationError"
+func (e KubernetesApiOidcAuthCFValidationError) ErrorName() string {
+	return "KubernetesApiOidcAuthCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiOidcAuthCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiOidcAuthCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiOidcAuthCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiOidcAuthCFValidationError{}
+
+// Validate checks the field values on KubernetesApiStaticTokenAuthCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiStaticTokenAuthCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiStaticTokenAuthCF with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// KubernetesApiStaticTokenAuthCFMultiError, or nil if none found.
+func (m *KubernetesApiStaticTokenAuthCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiStaticTokenAuthCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTokenFile()) < 1 {
+		err := KubernetesApiStaticTokenAuthCFValidationError{
+			field:  "TokenFile",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetClusterIds()) < 1 {
+		err := KubernetesApiStaticTokenAuthCFValidationError{
+			field:  "ClusterIds",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiStaticTokenAuthCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiStaticTokenAuthCFMultiError is an error wrapping multiple
+// validation errors returned by KubernetesApiStaticTokenAuthCF.ValidateAll()
+// if the designated constraints aren't met.
+type KubernetesApiStaticTokenAuthCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiStaticTokenAuthCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiStaticTokenAuthCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiStaticTokenAuthCFValidationError is the validation error
+// returned by KubernetesApiStaticTokenAuthCF.Validate if the designated
+// constraints aren't met.
+type KubernetesApiStaticTokenAuthCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiStaticTokenAuthCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiStaticTokenAuthCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiStaticTokenAuthCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiStaticTokenAuthCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiStaticTokenAuthCFValidationError) ErrorName() string {
+	return "KubernetesApiStaticTokenAuthCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiStaticTokenAuthCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiStaticTokenAuthCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiStaticTokenAuthCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiStaticTokenAuthCFValidationError{}
+
+// Validate checks the field values on KubernetesApiCiJobAuthCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiCiJobAuthCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiCiJobAuthCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesApiCiJobAuthCFMultiError, or nil if none found.
+func (m *KubernetesApiCiJobAuthCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiCiJobAuthCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return KubernetesApiCiJobAuthCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiCiJobAuthCFMultiError is an error wrapping multiple validation
+// errors returned by KubernetesApiCiJobAuthCF.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesApiCiJobAuthCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiCiJobAuthCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiCiJobAuthCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiCiJobAuthCFValidationError is the validation error returned by
+// KubernetesApiCiJobAuthCF.Validate if the designated constraints aren't met.
+type KubernetesApiCiJobAuthCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiCiJobAuthCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiCiJobAuthCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiCiJobAuthCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiCiJobAuthCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiCiJobAuthCFValidationError) ErrorName() string {
+	return "KubernetesApiCiJobAuthCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiCiJobAuthCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiCiJobAuthCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiCiJobAuthCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiCiJobAuthCFValidationError{}
+
+// Validate checks the field values on KubernetesApiClientCertificateAuthCF
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *KubernetesApiClientCertificateAuthCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiClientCertificateAuthCF
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// KubernetesApiClientCertificateAuthCFMultiError, or nil if none found.
+func (m *KubernetesApiClientCertificateAuthCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiClientCertificateAuthCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetCaCertificateFile()) < 1 {
+		err := KubernetesApiClientCertificateAuthCFValidationError{
+			field:  "CaCertificateFile",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetClusterIds()) < 1 {
+		err := KubernetesApiClientCertificateAuthCFValidationError{
+			field:  "ClusterIds",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiClientCertificateAuthCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiClientCertificateAuthCFMultiError is an error wrapping multiple
+// validation errors returned by
+// KubernetesApiClientCertificateAuthCF.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesApiClientCertificateAuthCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiClientCertificateAuthCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiClientCertificateAuthCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiClientCertificateAuthCFValidationError is the validation error
+// returned by KubernetesApiClientCertificateAuthCF.Validate if the designated
+// constraints aren't met.
+type KubernetesApiClientCertificateAuthCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiClientCertificateAuthCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiClientCertificateAuthCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiClientCertificateAuthCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiClientCertificateAuthCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiClientCertificateAuthCFValidationError) ErrorName() string {
+	return "KubernetesApiClientCertificateAuthCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiClientCertificateAuthCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiClientCertificateAuthCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiClientCertificateAuthCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiClientCertificateAuthCFValidationError{}
+
+// Validate checks the field values on KubernetesApiLimitsCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiLimitsCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiLimitsCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesApiLimitsCFMultiError, or nil if none found.
+func (m *KubernetesApiLimitsCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiLimitsCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RequestsPerUserPerMinute
+
+	// no validation rules for RequestsPerClusterPerMinute
+
+	// no validation rules for MaxRequestBodySize
+
+	if len(errors) > 0 {
+		return KubernetesApiLimitsCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiLimitsCFMultiError is an error wrapping multiple validation
+// errors returned by KubernetesApiLimitsCF.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesApiLimitsCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiLimitsCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiLimitsCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiLimitsCFValidationError is the validation error returned by
+// KubernetesApiLimitsCF.Validate if the designated constraints aren't met.
+type KubernetesApiLimitsCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiLimitsCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiLimitsCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiLimitsCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiLimitsCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiLimitsCFValidationError) ErrorName() string {
+	return "KubernetesApiLimitsCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiLimitsCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiLimitsCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiLimitsCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiLimitsCFValidationError{}
+
+// Validate checks the field values on KubernetesApiAuditCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiAuditCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiAuditCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesApiAuditCFMultiError, or nil if none found.
+func (m *KubernetesApiAuditCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiAuditCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for QueueSize
+
+	// no validation rules for BatchSize
+
+	if d := m.GetFlushInterval(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = KubernetesApiAuditCFValidationError{
+				field:  "FlushInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := KubernetesApiAuditCFValidationError{
+					field:  "FlushInterval",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetMaxRetryBackoff(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = KubernetesApiAuditCFValidationError{
+				field:  "MaxRetryBackoff",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := KubernetesApiAuditCFValidationError{
+					field:  "MaxRetryBackoff",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiAuditCFValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiAuditCFValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiAuditCFValidationError{
+				field:  "File",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiAuditCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiAuditCFMultiError is an error wrapping multiple validation
+// errors returned by KubernetesApiAuditCF.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesApiAuditCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiAuditCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiAuditCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiAuditCFValidationError is the validation error returned by
+// KubernetesApiAuditCF.Validate if the designated constraints aren't met.
+type KubernetesApiAuditCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiAuditCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiAuditCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiAuditCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiAuditCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiAuditCFValidationError) ErrorName() string {
+	return "KubernetesApiAuditCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiAuditCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiAuditCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiAuditCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiAuditCFValidationError{}
+
+// Validate checks the field values on KubernetesApiAuditFileSinkCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiAuditFileSinkCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiAuditFileSinkCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesApiAuditFileSinkCFMultiError, or nil if none found.
+func (m *KubernetesApiAuditFileSinkCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiAuditFileSinkCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetPath()) < 1 {
+		err := KubernetesApiAuditFileSinkCFValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiAuditFileSinkCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiAuditFileSinkCFMultiError is an error wrapping multiple
+// validation errors returned by KubernetesApiAuditFileSinkCF.ValidateAll() if
+// the designated constraints aren't met.
+type KubernetesApiAuditFileSinkCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiAuditFileSinkCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiAuditFileSinkCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiAuditFileSinkCFValidationError is the validation error returned
+// by KubernetesApiAuditFileSinkCF.Validate if the designated constraints
+// aren't met.
+type KubernetesApiAuditFileSinkCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiAuditFileSinkCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiAuditFileSinkCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiAuditFileSinkCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiAuditFileSinkCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiAuditFileSinkCFValidationError) ErrorName() string {
+	return "KubernetesApiAuditFileSinkCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiAuditFileSinkCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiAuditFileSinkCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiAuditFileSinkCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiAuditFileSinkCFValidationError{}
+
+// Validate checks the field values on KubernetesApiKubeconfigCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiKubeconfigCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiKubeconfigCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesApiKubeconfigCFMultiError, or nil if none found.
+func (m *KubernetesApiKubeconfigCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiKubeconfigCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetServerUrl() != "" {
+
+		if uri, err := url.Parse(m.GetServerUrl()); err != nil {
+			err = KubernetesApiKubeconfigCFValidationError{
+				field:  "ServerUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := KubernetesApiKubeconfigCFValidationError{
+				field:  "ServerUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetExec()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiKubeconfigCFValidationError{
+					field:  "Exec",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiKubeconfigCFValidationError{
+					field:  "Exec",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExec()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiKubeconfigCFValidationError{
+				field:  "Exec",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiKubeconfigCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiKubeconfigCFMultiError is an error wrapping multiple validation
+// errors returned by KubernetesApiKubeconfigCF.ValidateAll() if the
+// designated constraints aren't met.
+type KubernetesApiKubeconfigCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiKubeconfigCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiKubeconfigCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiKubeconfigCFValidationError is the validation error returned by
+// KubernetesApiKubeconfigCF.Validate if the designated constraints aren't met.
+type KubernetesApiKubeconfigCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiKubeconfigCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiKubeconfigCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiKubeconfigCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiKubeconfigCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiKubeconfigCFValidationError) ErrorName() string {
+	return "KubernetesApiKubeconfigCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiKubeconfigCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiKubeconfigCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiKubeconfigCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiKubeconfigCFValidationError{}
+
+// Validate checks the field values on KubernetesApiDiscoveryCacheCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiDiscoveryCacheCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiDiscoveryCacheCF with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// KubernetesApiDiscoveryCacheCFMultiError, or nil if none found.
+func (m *KubernetesApiDiscoveryCacheCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiDiscoveryCacheCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if d := m.GetTtl(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = KubernetesApiDiscoveryCacheCFValidationError{
+				field:  "Ttl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := KubernetesApiDiscoveryCacheCFValidationError{
+					field:  "Ttl",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	// no validation rules for MaxSize
+
+	if len(errors) > 0 {
+		return KubernetesApiDiscoveryCacheCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiDiscoveryCacheCFMultiError is an error wrapping multiple
+// validation errors returned by KubernetesApiDiscoveryCacheCF.ValidateAll()
+// if the designated constraints aren't met.
+type KubernetesApiDiscoveryCacheCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiDiscoveryCacheCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiDiscoveryCacheCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiDiscoveryCacheCFValidationError is the validation error
+// returned by KubernetesApiDiscoveryCacheCF.Validate if the designated
+// constraints aren't met.
+type KubernetesApiDiscoveryCacheCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiDiscoveryCacheCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiDiscoveryCacheCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiDiscoveryCacheCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiDiscoveryCacheCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiDiscoveryCacheCFValidationError) ErrorName() string {
+	return "KubernetesApiDiscoveryCacheCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiDiscoveryCacheCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiDiscoveryCacheCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiDiscoveryCacheCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiDiscoveryCacheCFValidationError{}
+
+// Validate checks the field values on KubernetesApiSessionRecordingCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiSessionRecordingCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiSessionRecordingCF with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// KubernetesApiSessionRecordingCFMultiError, or nil if none found.
+func (m *KubernetesApiSessionRecordingCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiSessionRecordingCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetFile() == nil {
+		err := KubernetesApiSessionRecordingCFValidationError{
+			field:  "File",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetFile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesApiSessionRecordingCFValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesApiSessionRecordingCFValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesApiSessionRecordingCFValidationError{
+				field:  "File",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiSessionRecordingCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiSessionRecordingCFMultiError is an error wrapping multiple
+// validation errors returned by KubernetesApiSessionRecordingCF.ValidateAll()
+// if the designated constraints aren't met.
+type KubernetesApiSessionRecordingCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiSessionRecordingCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiSessionRecordingCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiSessionRecordingCFValidationError is the validation error
+// returned by KubernetesApiSessionRecordingCF.Validate if the designated
+// constraints aren't met.
+type KubernetesApiSessionRecordingCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiSessionRecordingCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiSessionRecordingCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiSessionRecordingCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiSessionRecordingCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiSessionRecordingCFValidationError) ErrorName() string {
+	return "KubernetesApiSessionRecordingCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiSessionRecordingCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiSessionRecordingCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiSessionRecordingCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiSessionRecordingCFValidationError{}
+
+// Validate checks the field values on KubernetesApiSessionRecordingFileSinkCF
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *KubernetesApiSessionRecordingFileSinkCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on
+// KubernetesApiSessionRecordingFileSinkCF with the rules defined in the proto
+// definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in
+// KubernetesApiSessionRecordingFileSinkCFMultiError, or nil if none found.
+func (m *KubernetesApiSessionRecordingFileSinkCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiSessionRecordingFileSinkCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetDir()) < 1 {
+		err := KubernetesApiSessionRecordingFileSinkCFValidationError{
+			field:  "Dir",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiSessionRecordingFileSinkCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiSessionRecordingFileSinkCFMultiError is an error wrapping
+// multiple validation errors returned by
+// KubernetesApiSessionRecordingFileSinkCF.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesApiSessionRecordingFileSinkCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiSessionRecordingFileSinkCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiSessionRecordingFileSinkCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiSessionRecordingFileSinkCFValidationError is the validation
+// error returned by KubernetesApiSessionRecordingFileSinkCF.Validate if the
+// designated constraints aren't met.
+type KubernetesApiSessionRecordingFileSinkCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiSessionRecordingFileSinkCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiSessionRecordingFileSinkCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiSessionRecordingFileSinkCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiSessionRecordingFileSinkCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiSessionRecordingFileSinkCFValidationError) ErrorName() string {
+	return "KubernetesApiSessionRecordingFileSinkCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiSessionRecordingFileSinkCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiSessionRecordingFileSinkCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiSessionRecordingFileSinkCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiSessionRecordingFileSinkCFValidationError{}
+
+// Validate checks the field values on KubernetesApiKubeconfigExecCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesApiKubeconfigExecCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesApiKubeconfigExecCF with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// KubernetesApiKubeconfigExecCFMultiError, or nil if none found.
+func (m *KubernetesApiKubeconfigExecCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesApiKubeconfigExecCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetCommand()) < 1 {
+		err := KubernetesApiKubeconfigExecCFValidationError{
+			field:  "Command",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for InstallHint
+
+	for idx, item := range m.GetTokenTypes() {
+		_, _ = idx, item
+
+		if _, ok := _KubernetesApiKubeconfigExecCF_TokenTypes_InLookup[item]; !ok {
+			err := KubernetesApiKubeconfigExecCFValidationError{
+				field:  fmt.Sprintf("TokenTypes[%v]", idx),
+				reason: "value must be in list [plrl ci oidc static]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return KubernetesApiKubeconfigExecCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesApiKubeconfigExecCFMultiError is an error wrapping multiple
+// validation errors returned by KubernetesApiKubeconfigExecCF.ValidateAll()
+// if the designated constraints aren't met.
+type KubernetesApiKubeconfigExecCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesApiKubeconfigExecCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesApiKubeconfigExecCFMultiError) AllErrors() []error { return m }
+
+// KubernetesApiKubeconfigExecCFValidationError is the validation error
+// returned by KubernetesApiKubeconfigExecCF.Validate if the designated
+// constraints aren't met.
+type KubernetesApiKubeconfigExecCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesApiKubeconfigExecCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesApiKubeconfigExecCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesApiKubeconfigExecCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesApiKubeconfigExecCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesApiKubeconfigExecCFValidationError) ErrorName() string {
+	return "KubernetesApiKubeconfigExecCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesApiKubeconfigExecCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesApiKubeconfigExecCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesApiKubeconfigExecCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesApiKubeconfigExecCFValidationError{}
+
+var _KubernetesApiKubeconfigExecCF_TokenTypes_InLookup = map[string]struct{}{
+	"plrl":   {},
+	"ci":     {},
+	"oidc":   {},
+	"static": {},
+}
+
+// Validate checks the field values on AgentCF with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AgentCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AgentCF with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in AgentCFMultiError, or nil if none found.
+func (m *AgentCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AgentCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetListen()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "Listen",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "Listen",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetListen()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "Listen",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetConfiguration()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "Configuration",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "Configuration",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConfiguration()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "Configuration",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetInfoCacheTtl(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = AgentCFValidationError{
+				field:  "InfoCacheTtl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur < gte {
+				err := AgentCFValidationError{
+					field:  "InfoCacheTtl",
+					reason: "value must be greater than or equal to 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetInfoCacheErrorTtl(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = AgentCFValidationError{
+				field:  "InfoCacheErrorTtl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := AgentCFValidationError{
+					field:  "InfoCacheErrorTtl",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRedisConnInfoTtl()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "RedisConnInfoTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "RedisConnInfoTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRedisConnInfoTtl()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "RedisConnInfoTtl",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRedisConnInfoRefresh()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "RedisConnInfoRefresh",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "RedisConnInfoRefresh",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRedisConnInfoRefresh()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "RedisConnInfoRefresh",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRedisConnInfoGc()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "RedisConnInfoGc",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "RedisConnInfoGc",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRedisConnInfoGc()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "RedisConnInfoGc",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetKubernetesApi()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "KubernetesApi",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "KubernetesApi",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetKubernetesApi()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "KubernetesApi",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetReverseTunnel()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "ReverseTunnel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "ReverseTunnel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReverseTunnel()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "ReverseTunnel",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetConnectionHistoryTtl(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = AgentCFValidationError{
+				field:  "ConnectionHistoryTtl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := AgentCFValidationError{
+					field:  "ConnectionHistoryTtl",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetVersionSkew()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "VersionSkew",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentCFValidationError{
+					field:  "VersionSkew",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetVersionSkew()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentCFValidationError{
+				field:  "VersionSkew",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetTokenOverlapWindow(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = AgentCFValidationError{
+				field:  "TokenOverlapWindow",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur < gte {
+				err := AgentCFValidationError{
+					field:  "TokenOverlapWindow",
+					reason: "value must be greater than or equal to 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return AgentCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentCFMultiError is an error wrapping multiple validation errors returned
+// by AgentCF.ValidateAll() if the designated constraints aren't met.
+type AgentCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentCFMultiError) AllErrors() []error { return m }
+
+// AgentCFValidationError is the validation error returned by AgentCF.Validate
+// if the designated constraints aren't met.
+type AgentCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentCFValidationError) ErrorName() string { return "AgentCFValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AgentCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAgentCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AgentCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AgentCFValidationError{}
+
+// Validate checks the field values on VersionSkewCF with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *VersionSkewCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VersionSkewCF with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in VersionSkewCFMultiError, or
+// nil if none found.
+func (m *VersionSkewCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VersionSkewCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _VersionSkewCF_Policy_InLookup[m.GetPolicy()]; !ok {
+		err := VersionSkewCFValidationError{
+			field:  "Policy",
+			reason: "value must be in list [warn restrict reject]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for SupportedMinorVersions
+
+	// no validation rules for AllowNewerAgents
+
+	if len(errors) > 0 {
+		return VersionSkewCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// VersionSkewCFMultiError is an error wrapping multiple validation errors
+// returned by VersionSkewCF.ValidateAll() if the designated constraints
+// aren't met.
+type VersionSkewCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VersionSkewCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VersionSkewCFMultiError) AllErrors() []error { return m }
+
+// VersionSkewCFValidationError is the validation error returned by
+// VersionSkewCF.Validate if the designated constraints aren't met.
+type VersionSkewCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VersionSkewCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VersionSkewCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VersionSkewCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VersionSkewCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VersionSkewCFValidationError) ErrorName() string { return "VersionSkewCFValidationError" }
+
+// Error satisfies the builtin error interface
+func (e VersionSkewCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVersionSkewCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VersionSkewCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VersionSkewCFValidationError{}
+
+var _VersionSkewCF_Policy_InLookup = map[string]struct{}{
+	"warn":     {},
+	"restrict": {},
+	"reject":   {},
+}
+
+// Validate checks the field values on AgentReverseTunnelCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AgentReverseTunnelCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AgentReverseTunnelCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AgentReverseTunnelCFMultiError, or nil if none found.
+func (m *AgentReverseTunnelCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AgentReverseTunnelCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _AgentReverseTunnelCF_Compression_InLookup[m.GetCompression()]; !ok {
+		err := AgentReverseTunnelCFValidationError{
+			field:  "Compression",
+			reason: "value must be in list [none gzip zstd]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Multiplexing
+
+	if len(errors) > 0 {
+		return AgentReverseTunnelCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentReverseTunnelCFMultiError is an error wrapping multiple validation
+// errors returned by AgentReverseTunnelCF.ValidateAll() if the designated
+// constraints aren't met.
+type AgentReverseTunnelCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentReverseTunnelCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentReverseTunnelCFMultiError) AllErrors() []error { return m }
+
+// AgentReverseTunnelCFValidationError is the validation error returned by
+// AgentReverseTunnelCF.Validate if the designated constraints aren't met.
+type AgentReverseTunnelCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentReverseTunnelCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentReverseTunnelCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentReverseTunnelCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentReverseTunnelCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentReverseTunnelCFValidationError) ErrorName() string {
+	return "AgentReverseTunnelCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AgentReverseTunnelCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAgentReverseTunnelCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AgentReverseTunnelCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AgentReverseTunnelCFValidationError{}
+
+var _AgentReverseTunnelCF_Compression_InLookup = map[string]struct{}{
+	"none": {},
+	"gzip": {},
+	"zstd": {},
+}
+
+// Validate checks the field values on AgentConfigurationCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AgentConfigurationCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AgentConfigurationCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AgentConfigurationCFMultiError, or nil if none found.
+func (m *AgentConfigurationCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AgentConfigurationCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if d := m.GetPollPeriod(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = AgentConfigurationCFValidationError{
+				field:  "PollPeriod",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := AgentConfigurationCFValidationError{
+					field:  "PollPeriod",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	// no validation rules for MaxConfigurationFileSize
+
+	if all {
+		switch v := interface{}(m.GetLocal()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentConfigurationCFValidationError{
+					field:  "Local",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentConfigurationCFValidationError{
+					field:  "Local",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLocal()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentConfigurationCFValidationError{
+				field:  "Local",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AgentConfigurationCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentConfigurationCFMultiError is an error wrapping multiple validation
+// errors returned by AgentConfigurationCF.ValidateAll() if the designated
+// constraints aren't met.
+type AgentConfigurationCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentConfigurationCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentConfigurationCFMultiError) AllErrors() []error { return m }
+
+// AgentConfigurationCFValidationError is the validation error returned by
+// AgentConfigurationCF.Validate if the designated constraints aren't met.
+type AgentConfigurationCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentConfigurationCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentConfigurationCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentConfigurationCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentConfigurationCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentConfigurationCFValidationError) ErrorName() string {
+	return "AgentConfigurationCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AgentConfigurationCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAgentConfigurationCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AgentConfigurationCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AgentConfigurationCFValidationError{}
+
+// Validate checks the field values on AgentConfigurationLocalCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AgentConfigurationLocalCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AgentConfigurationLocalCF with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AgentConfigurationLocalCFMultiError, or nil if none found.
+func (m *AgentConfigurationLocalCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AgentConfigurationLocalCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofSourcePresent := false
+	switch v := m.Source.(type) {
+	case *AgentConfigurationLocalCF_File:
+		if v == nil {
+			err := AgentConfigurationLocalCFValidationError{
+				field:  "Source",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofSourcePresent = true
+
+		if len(m.GetFile()) < 1 {
+			err := AgentConfigurationLocalCFValidationError{
+				field:  "File",
+				reason: "value length must be at least 1 bytes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *AgentConfigurationLocalCF_Directory:
+		if v == nil {
+			err := AgentConfigurationLocalCFValidationError{
+				field:  "Source",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofSourcePresent = true
+
+		if len(m.GetDirectory()) < 1 {
+			err := AgentConfigurationLocalCFValidationError{
+				field:  "Directory",
+				reason: "value length must be at least 1 bytes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofSourcePresent {
+		err := AgentConfigurationLocalCFValidationError{
+			field:  "Source",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return AgentConfigurationLocalCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentConfigurationLocalCFMultiError is an error wrapping multiple validation
+// errors returned by AgentConfigurationLocalCF.ValidateAll() if the
+// designated constraints aren't met.
+type AgentConfigurationLocalCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentConfigurationLocalCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentConfigurationLocalCFMultiError) AllErrors() []error { return m }
+
+// AgentConfigurationLocalCFValidationError is the validation error returned by
+// AgentConfigurationLocalCF.Validate if the designated constraints aren't met.
+type AgentConfigurationLocalCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentConfigurationLocalCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentConfigurationLocalCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentConfigurationLocalCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentConfigurationLocalCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentConfigurationLocalCFValidationError) ErrorName() string {
+	return "AgentConfigurationLocalCFValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e AgentConfigurationCFValidationError) Error() string {
+func (e AgentConfigurationLocalCFValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1662,14 +4414,14 @@ func (e AgentConfigurationCFValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAgentConfigurationCF.%s: %s%s",
+		"invalid %sAgentConfigurationLocalCF.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AgentConfigurationCFValidationError{}
+var _ error = AgentConfigurationLocalCFValidationError{}
 
 var _ interface {
 	Field() string
@@ -1677,7 +4429,7 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AgentConfigurationCFValidationError{}
+} = AgentConfigurationLocalCFValidationError{}
 
 // Validate checks the field values on GoogleProfilerCF with the rules defined
 // in the proto definition for this message. If any rules are violated, the
@@ -2668,7 +5420,6 @@ func (m *RedisCF) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	oneofRedisConfigPresent := false
 	switch v := m.RedisConfig.(type) {
 	case *RedisCF_Server:
 		if v == nil {
@@ -2681,7 +5432,6 @@ func (m *RedisCF) validate(all bool) error {
 			}
 			errors = append(errors, err)
 		}
-		oneofRedisConfigPresent = true
 
 		if m.GetServer() == nil {
 			err := RedisCFValidationError{
@@ -2734,7 +5484,6 @@ func (m *RedisCF) validate(all bool) error {
 			}
 			errors = append(errors, err)
 		}
-		oneofRedisConfigPresent = true
 
 		if m.GetSentinel() == nil {
 			err := RedisCFValidationError{
@@ -2779,16 +5528,6 @@ func (m *RedisCF) validate(all bool) error {
 	default:
 		_ = v // ensures v is used
 	}
-	if !oneofRedisConfigPresent {
-		err := RedisCFValidationError{
-			field:  "RedisConfig",
-			reason: "value is required",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
 
 	if len(errors) > 0 {
 		return RedisCFMultiError(errors)
@@ -3989,17 +6728,6 @@ func (m *ConfigurationFile) validate(all bool) error {
 		}
 	}
 
-	if m.GetRedis() == nil {
-		err := ConfigurationFileValidationError{
-			field:  "Redis",
-			reason: "value is required",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
 	if all {
 		switch v := interface{}(m.GetRedis()).(type) {
 		case interface{ ValidateAll() error }:
@@ -4111,6 +6839,35 @@ func (m *ConfigurationFile) validate(all bool) error {
 
 	// no validation rules for PluralUrl
 
+	if all {
+		switch v := interface{}(m.GetStorage()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "Storage",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigurationFileValidationError{
+					field:  "Storage",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStorage()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigurationFileValidationError{
+				field:  "Storage",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return ConfigurationFileMultiError(errors)
 	}
@@ -4190,3 +6947,286 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ConfigurationFileValidationError{}
+
+// Validate checks the field values on StorageCF with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StorageCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StorageCF with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StorageCFMultiError, or nil
+// if none found.
+func (m *StorageCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StorageCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _StorageCF_Backend_InLookup[m.GetBackend()]; !ok {
+		err := StorageCFValidationError{
+			field:  "Backend",
+			reason: "value must be in list [redis memory kubernetes]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetKubernetes()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StorageCFValidationError{
+					field:  "Kubernetes",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StorageCFValidationError{
+					field:  "Kubernetes",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetKubernetes()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StorageCFValidationError{
+				field:  "Kubernetes",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return StorageCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// StorageCFMultiError is an error wrapping multiple validation errors returned
+// by StorageCF.ValidateAll() if the designated constraints aren't met.
+type StorageCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StorageCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StorageCFMultiError) AllErrors() []error { return m }
+
+// StorageCFValidationError is the validation error returned by
+// StorageCF.Validate if the designated constraints aren't met.
+type StorageCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StorageCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StorageCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StorageCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StorageCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StorageCFValidationError) ErrorName() string { return "StorageCFValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StorageCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStorageCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StorageCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StorageCFValidationError{}
+
+var _StorageCF_Backend_InLookup = map[string]struct{}{
+	"redis":      {},
+	"memory":     {},
+	"kubernetes": {},
+}
+
+// Validate checks the field values on KubernetesStorageCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesStorageCF) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesStorageCF with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesStorageCFMultiError, or nil if none found.
+func (m *KubernetesStorageCF) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesStorageCF) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Namespace
+
+	// no validation rules for KubeconfigFile
+
+	if d := m.GetGcPeriod(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = KubernetesStorageCFValidationError{
+				field:  "GcPeriod",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := KubernetesStorageCFValidationError{
+					field:  "GcPeriod",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	// no validation rules for Replicas
+
+	if len(errors) > 0 {
+		return KubernetesStorageCFMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesStorageCFMultiError is an error wrapping multiple validation
+// errors returned by KubernetesStorageCF.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesStorageCFMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesStorageCFMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesStorageCFMultiError) AllErrors() []error { return m }
+
+// KubernetesStorageCFValidationError is the validation error returned by
+// KubernetesStorageCF.Validate if the designated constraints aren't met.
+type KubernetesStorageCFValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesStorageCFValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesStorageCFValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesStorageCFValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesStorageCFValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesStorageCFValidationError) ErrorName() string {
+	return "KubernetesStorageCFValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesStorageCFValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesStorageCF.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesStorageCFValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesStorageCFValidationError{}
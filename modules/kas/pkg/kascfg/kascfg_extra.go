@@ -1,5 +1,15 @@
 package kascfg
 
+const (
+	StorageBackendRedis      = "redis"
+	StorageBackendMemory     = "memory"
+	StorageBackendKubernetes = "kubernetes"
+
+	VersionSkewPolicyWarn     = "warn"
+	VersionSkewPolicyRestrict = "restrict"
+	VersionSkewPolicyReject   = "reject"
+)
+
 // ValidateExtra performs extra validation checks.
 // Should be run after defaults have been applied.
 func (x *ConfigurationFile) ValidateExtra() error {
@@ -9,5 +19,21 @@ func (x *ConfigurationFile) ValidateExtra() error {
 			reason: "must be smaller than RedisConnInfoTtl",
 		}
 	}
+	if x.GetStorage().GetBackend() == StorageBackendRedis && x.GetRedis().GetRedisConfig() == nil {
+		return RedisCFValidationError{
+			field:  "RedisConfig",
+			reason: "value is required when storage backend is redis",
+		}
+	}
+	if x.GetStorage().GetBackend() == StorageBackendKubernetes && x.GetStorage().GetKubernetes().GetReplicas() > 1 {
+		limits := x.GetAgent().GetKubernetesApi().GetLimits()
+		if limits.GetRequestsPerUserPerMinute() > 0 || limits.GetRequestsPerClusterPerMinute() > 0 {
+			// Each replica would allow the configured number of requests.
+			return KubernetesStorageCFValidationError{
+				field:  "Replicas",
+				reason: "must be 1 when Kubernetes API rate limits are set, rate limits are not shared between replicas with the kubernetes storage backend",
+			}
+		}
+	}
 	return nil
 }
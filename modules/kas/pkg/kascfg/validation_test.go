@@ -2,7 +2,9 @@ package kascfg
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/testhelpers"
@@ -33,10 +35,37 @@ func TestValidation_Valid(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "minimal memory storage",
+			Valid: &ConfigurationFile{
+				Api: &ApiCF{
+					Listen: &ListenApiCF{
+						AuthenticationSecretFile: "/some/file",
+					},
+				},
+				PrivateApi: &PrivateApiCF{
+					Listen: &ListenPrivateApiCF{
+						AuthenticationSecretFile: "/some/file",
+					},
+				},
+				Storage: &StorageCF{
+					Backend: StorageBackendMemory,
+				},
+			},
+		},
 		{
 			Name: "AgentCF",
 			Valid: &AgentCF{
-				InfoCacheTtl: durationpb.New(0), // zero means "disabled"
+				InfoCacheTtl:       durationpb.New(0), // zero means "disabled"
+				TokenOverlapWindow: durationpb.New(0), // zero means "disabled"
+			},
+		},
+		{
+			Name: "AgentConfigurationLocalCF",
+			Valid: &AgentConfigurationLocalCF{
+				Source: &AgentConfigurationLocalCF_Directory{
+					Directory: "/etc/kas/agents",
+				},
 			},
 		},
 		{
@@ -124,12 +153,50 @@ func TestValidation_Invalid(t *testing.T) {
 				InfoCacheErrorTtl: durationpb.New(-1),
 			},
 		},
+		{
+			ErrString: "invalid AgentCF.TokenOverlapWindow: value must be greater than or equal to 0s",
+			Invalid: &AgentCF{
+				TokenOverlapWindow: durationpb.New(-1),
+			},
+		},
+		{
+			ErrString: "invalid AgentCF.ConnectionHistoryTtl: value must be greater than 0s",
+			Invalid: &AgentCF{
+				ConnectionHistoryTtl: durationpb.New(0),
+			},
+		},
+		{
+			ErrString: `invalid VersionSkewCF.Policy: value must be in list [warn restrict reject]`,
+			Invalid: &VersionSkewCF{
+				Policy: "block",
+			},
+		},
+		{
+			ErrString: "invalid AgentConfigurationLocalCF.Source: value is required",
+			Invalid:   &AgentConfigurationLocalCF{},
+		},
+		{
+			ErrString: "invalid AgentConfigurationLocalCF.Directory: value length must be at least 1 bytes",
+			Invalid: &AgentConfigurationLocalCF{
+				Source: &AgentConfigurationLocalCF_Directory{},
+			},
+		},
 		{
 			ErrString: "invalid AgentConfigurationCF.PollPeriod: value must be greater than 0s",
 			Invalid: &AgentConfigurationCF{
 				PollPeriod: durationpb.New(0),
 			},
 		},
+		{
+			ErrString: "invalid AgentConfigurationLocalCF.Source: value is required",
+			Invalid:   &AgentConfigurationLocalCF{},
+		},
+		{
+			ErrString: "invalid AgentConfigurationLocalCF.Directory: value length must be at least 1 bytes",
+			Invalid: &AgentConfigurationLocalCF{
+				Source: &AgentConfigurationLocalCF_Directory{},
+			},
+		},
 		{
 			ErrString: "invalid AgentConfigurationCF.PollPeriod: value must be greater than 0s",
 			Invalid: &AgentConfigurationCF{
@@ -236,10 +303,6 @@ func TestValidation_Invalid(t *testing.T) {
 				IdleTimeout: durationpb.New(-1),
 			},
 		},
-		{
-			ErrString: "invalid RedisCF.RedisConfig: value is required",
-			Invalid:   &RedisCF{},
-		},
 		{
 			ErrString: "invalid RedisCF.Server: value is required",
 			Invalid: &RedisCF{
@@ -287,6 +350,12 @@ func TestValidation_Invalid(t *testing.T) {
 				MaxConnectionAge: durationpb.New(-1),
 			},
 		},
+		{
+			ErrString: "invalid ListenAgentCF.DrainGracePeriod: value must be greater than 0s",
+			Invalid: &ListenAgentCF{
+				DrainGracePeriod: durationpb.New(0),
+			},
+		},
 		{
 			ErrString: "invalid ListenApiCF.AuthenticationSecretFile: value length must be at least 1 bytes",
 			Invalid:   &ListenApiCF{},
@@ -313,6 +382,131 @@ func TestValidation_Invalid(t *testing.T) {
 			ErrString: "invalid PrivateApiCF.Listen: value is required",
 			Invalid:   &PrivateApiCF{},
 		},
+		{
+			ErrString: "invalid KubernetesStorageCF.GcPeriod: value must be greater than 0s",
+			Invalid: &KubernetesStorageCF{
+				GcPeriod: durationpb.New(0),
+			},
+		},
+		{
+			ErrString: `invalid StorageCF.Backend: value must be in list [redis memory kubernetes]`,
+			Invalid: &StorageCF{
+				Backend: "etcd",
+			},
+		},
 	}
 	testhelpers.AssertInvalid(t, tests)
 }
+
+func TestValidateExtra(t *testing.T) {
+	agent := &AgentCF{
+		RedisConnInfoTtl:     durationpb.New(5 * time.Minute),
+		RedisConnInfoRefresh: durationpb.New(4 * time.Minute),
+	}
+	tests := []struct {
+		name      string
+		cfg       *ConfigurationFile
+		errString string
+	}{
+		{
+			name: "redis backend without redis config",
+			cfg: &ConfigurationFile{
+				Agent: agent,
+				Redis: &RedisCF{},
+				Storage: &StorageCF{
+					Backend: StorageBackendRedis,
+				},
+			},
+			errString: "invalid RedisCF.RedisConfig: value is required when storage backend is redis",
+		},
+		{
+			name: "memory backend without redis config",
+			cfg: &ConfigurationFile{
+				Agent: agent,
+				Redis: &RedisCF{},
+				Storage: &StorageCF{
+					Backend: StorageBackendMemory,
+				},
+			},
+		},
+		{
+			name: "redis backend with redis config",
+			cfg: &ConfigurationFile{
+				Agent: agent,
+				Redis: &RedisCF{
+					RedisConfig: &RedisCF_Server{
+						Server: &RedisServerCF{
+							Address: "address:6380",
+						},
+					},
+				},
+				Storage: &StorageCF{
+					Backend: StorageBackendRedis,
+				},
+			},
+		},
+		{
+			name: "kubernetes backend with replicas and rate limits",
+			cfg: &ConfigurationFile{
+				Agent: &AgentCF{
+					RedisConnInfoTtl:     agent.RedisConnInfoTtl,
+					RedisConnInfoRefresh: agent.RedisConnInfoRefresh,
+					KubernetesApi: &KubernetesApiCF{
+						Limits: &KubernetesApiLimitsCF{
+							RequestsPerUserPerMinute: 600,
+						},
+					},
+				},
+				Storage: &StorageCF{
+					Backend: StorageBackendKubernetes,
+					Kubernetes: &KubernetesStorageCF{
+						Replicas: 2,
+					},
+				},
+			},
+			errString: "invalid KubernetesStorageCF.Replicas: must be 1 when Kubernetes API rate limits are set, rate limits are not shared between replicas with the kubernetes storage backend",
+		},
+		{
+			name: "kubernetes backend with one replica and rate limits",
+			cfg: &ConfigurationFile{
+				Agent: &AgentCF{
+					RedisConnInfoTtl:     agent.RedisConnInfoTtl,
+					RedisConnInfoRefresh: agent.RedisConnInfoRefresh,
+					KubernetesApi: &KubernetesApiCF{
+						Limits: &KubernetesApiLimitsCF{
+							RequestsPerClusterPerMinute: 6000,
+						},
+					},
+				},
+				Storage: &StorageCF{
+					Backend: StorageBackendKubernetes,
+					Kubernetes: &KubernetesStorageCF{
+						Replicas: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "kubernetes backend with replicas and without rate limits",
+			cfg: &ConfigurationFile{
+				Agent: agent,
+				Storage: &StorageCF{
+					Backend: StorageBackendKubernetes,
+					Kubernetes: &KubernetesStorageCF{
+						Replicas: 3,
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateExtra()
+			if tc.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.errString)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package local
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+const (
+	// ConditionApplied tells whether agentk has applied the configuration from the object.
+	ConditionApplied = "Applied"
+
+	reasonApplied              = "Applied"
+	reasonInvalidConfiguration = "InvalidConfiguration"
+	reasonRejected             = "Rejected"
+)
+
+// AgentConfigGVR identifies AgentConfig objects. The spec of an AgentConfig is an agentk configuration file.
+var AgentConfigGVR = schema.GroupVersionResource{
+	Group:    "agent.plural.sh",
+	Version:  "v1alpha1",
+	Resource: "agentconfigs",
+}
+
+// Configuration is the configuration file from an AgentConfig object.
+type Configuration struct {
+	File *agentcfg.ConfigurationFile
+	// Generation of the object the file is from.
+	Generation int64
+}
+
+// Callback is called with the configuration from the object or with nil if there is no object.
+type Callback func(context.Context, *Configuration)
+
+// WatcherInterface abstracts Watcher.
+type WatcherInterface interface {
+	// Watch calls the callback once the current state is known and then on every change until ctx is done.
+	// Objects with an invalid configuration are reported and skipped.
+	Watch(ctx context.Context, cb Callback)
+	// ReportApplied records whether agentk has applied the configuration.
+	ReportApplied(ctx context.Context, config *Configuration, err error)
+}
@@ -0,0 +1,42 @@
+package local
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+// Merge returns the configuration from kas with the sections that are set in the in-cluster configuration file.
+// A section from the file replaces the whole section from kas, sections are never merged field by field.
+// Fields that identify the agent always come from kas. So does ci_access: kas authorizes CI jobs with the
+// configuration it gets from Plural Console, the section from the file would have no effect.
+func Merge(server *agentcfg.AgentConfiguration, file *agentcfg.ConfigurationFile) *agentcfg.AgentConfiguration {
+	config := proto.Clone(server).(*agentcfg.AgentConfiguration)
+	// Modules default the configuration they are given, don't let them modify the file.
+	file = proto.Clone(file).(*agentcfg.ConfigurationFile)
+	if file.Gitops != nil {
+		config.Gitops = file.Gitops
+	}
+	if file.Observability != nil {
+		config.Observability = file.Observability
+	}
+	if file.ContainerScanning != nil {
+		config.ContainerScanning = file.ContainerScanning
+	}
+	if file.RemoteDevelopment != nil {
+		config.RemoteDevelopment = file.RemoteDevelopment
+	}
+	if file.Flux != nil {
+		config.Flux = file.Flux
+	}
+	if file.ServiceProxy != nil {
+		config.ServiceProxy = file.ServiceProxy
+	}
+	if file.TcpForward != nil {
+		config.TcpForward = file.TcpForward
+	}
+	if file.ReverseTunnel != nil {
+		config.ReverseTunnel = file.ReverseTunnel
+	}
+	return config
+}
@@ -0,0 +1,67 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+func TestMerge(t *testing.T) {
+	server := &agentcfg.AgentConfiguration{
+		AgentId:           123,
+		GitlabExternalUrl: "https://console.example.com",
+		Observability: &agentcfg.ObservabilityCF{
+			Logging: &agentcfg.LoggingCF{Level: agentcfg.LogLevelEnum_debug},
+		},
+		Gitops: &agentcfg.GitopsCF{
+			ManifestProjects: []*agentcfg.ManifestProjectCF{
+				{DefaultNamespace: "from-kas"},
+			},
+		},
+	}
+	file := &agentcfg.ConfigurationFile{
+		Gitops: &agentcfg.GitopsCF{
+			ManifestProjects: []*agentcfg.ManifestProjectCF{
+				{DefaultNamespace: "from-cluster"},
+			},
+		},
+		CiAccess: &agentcfg.CiAccessCF{ // ignored
+			Projects: []*agentcfg.CiAccessProjectCF{
+				{Id: "group/project"},
+			},
+		},
+	}
+	expected := &agentcfg.AgentConfiguration{
+		AgentId:           123,
+		GitlabExternalUrl: "https://console.example.com",
+		Observability:     server.Observability,
+		Gitops:            file.Gitops,
+	}
+	serverBefore := proto.Clone(server)
+	fileBefore := proto.Clone(file)
+
+	merged := Merge(server, file)
+
+	assert.Empty(t, cmp.Diff(expected, merged, protocmp.Transform()))
+	// Inputs are not modified and not shared.
+	merged.Gitops.ManifestProjects[0].DefaultNamespace = "changed"
+	merged.Observability.Logging.Level = agentcfg.LogLevelEnum_error
+	assert.Empty(t, cmp.Diff(serverBefore, server, protocmp.Transform()))
+	assert.Empty(t, cmp.Diff(fileBefore, file, protocmp.Transform()))
+}
+
+func TestMerge_EmptyFile(t *testing.T) {
+	server := &agentcfg.AgentConfiguration{
+		AgentId: 123,
+		Observability: &agentcfg.ObservabilityCF{
+			Logging: &agentcfg.LoggingCF{Level: agentcfg.LogLevelEnum_debug},
+		},
+	}
+	merged := Merge(server, &agentcfg.ConfigurationFile{})
+	assert.Empty(t, cmp.Diff(server, merged, protocmp.Transform()))
+}
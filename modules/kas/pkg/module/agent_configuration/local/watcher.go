@@ -0,0 +1,221 @@
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+const (
+	// defaultSyncTimeout is how long to wait for the object to be listed before agentk goes on without it.
+	// Listing fails forever if the CRD is not installed or agentk is not allowed to list the objects.
+	defaultSyncTimeout = 30 * time.Second
+)
+
+// Watcher watches a single AgentConfig object.
+type Watcher struct {
+	Log           *zap.Logger
+	Client        dynamic.Interface
+	EventRecorder record.EventRecorder
+	Namespace     string
+	Name          string
+	// SyncTimeout overrides defaultSyncTimeout if set.
+	SyncTimeout time.Duration
+}
+
+func (w *Watcher) Watch(ctx context.Context, cb Callback) {
+	var (
+		mu sync.Mutex // serializes callback invocations
+		// delivered is true once the callback has been called.
+		delivered bool
+	)
+	deliver := func(config *Configuration) {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = true
+		cb(ctx, config)
+	}
+	deliverNilIfNothingDelivered := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if !delivered {
+			delivered = true
+			cb(ctx, nil)
+		}
+	}
+	inf := dynamicinformer.NewFilteredDynamicInformer(w.Client, AgentConfigGVR, w.Namespace, 0, cache.Indexers{},
+		func(opts *meta_v1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", w.Name).String()
+		}).Informer()
+	reg, err := inf.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			w.handle(ctx, deliver, obj)
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			// Status updates don't change the generation, skip them.
+			if oldObj.(*unstructured.Unstructured).GetGeneration() == newObj.(*unstructured.Unstructured).GetGeneration() {
+				return
+			}
+			w.handle(ctx, deliver, newObj)
+		},
+		DeleteFunc: func(obj any) {
+			w.Log.Info("AgentConfig has been deleted, using configuration from kas only")
+			deliver(nil)
+		},
+	})
+	if err != nil {
+		// Cannot happen, the informer has not been started yet.
+		w.Log.Error("Failed to add AgentConfig event handler", logz.Error(err))
+		cb(ctx, nil)
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		inf.Run(ctx.Done())
+	}()
+	defer func() {
+		<-done
+	}()
+
+	syncTimeout := w.SyncTimeout
+	if syncTimeout == 0 {
+		syncTimeout = defaultSyncTimeout
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), reg.HasSynced) {
+		if ctx.Err() != nil {
+			return
+		}
+		w.Log.Warn("Failed to list AgentConfig objects, using configuration from kas only. Is the CRD installed and is agentk allowed to read the object?",
+			logz.NamespacedName(w.Namespace+"/"+w.Name))
+		// The informer keeps trying, the event handler passes the object to the callback once it's listed.
+	}
+	// Nothing has been delivered if there is no object, its configuration is invalid or it could not be listed.
+	deliverNilIfNothingDelivered()
+}
+
+func (w *Watcher) handle(ctx context.Context, deliver func(*Configuration), obj any) {
+	u := obj.(*unstructured.Unstructured)
+	file, err := parseConfigurationFile(u)
+	if err != nil {
+		w.Log.Warn("Invalid configuration in AgentConfig", logz.Error(err))
+		w.EventRecorder.Event(u, core_v1.EventTypeWarning, reasonInvalidConfiguration, err.Error())
+		w.setAppliedCondition(ctx, meta_v1.Condition{
+			Type:               ConditionApplied,
+			Status:             meta_v1.ConditionFalse,
+			ObservedGeneration: u.GetGeneration(),
+			Reason:             reasonInvalidConfiguration,
+			Message:            err.Error(),
+		})
+		return
+	}
+	deliver(&Configuration{
+		File:       file,
+		Generation: u.GetGeneration(),
+	})
+}
+
+func (w *Watcher) ReportApplied(ctx context.Context, config *Configuration, err error) {
+	cond := meta_v1.Condition{
+		Type:               ConditionApplied,
+		Status:             meta_v1.ConditionTrue,
+		ObservedGeneration: config.Generation,
+		Reason:             reasonApplied,
+		Message:            "Configuration has been applied",
+	}
+	if err != nil {
+		cond.Status = meta_v1.ConditionFalse
+		cond.Reason = reasonRejected
+		cond.Message = err.Error()
+	}
+	obj := w.setAppliedCondition(ctx, cond)
+	if err != nil && obj != nil {
+		w.EventRecorder.Event(obj, core_v1.EventTypeWarning, reasonRejected, err.Error())
+	}
+}
+
+// setAppliedCondition sets the condition in the status of the object.
+// Returns the object if the condition has changed, nil otherwise.
+func (w *Watcher) setAppliedCondition(ctx context.Context, cond meta_v1.Condition) *unstructured.Unstructured {
+	client := w.Client.Resource(AgentConfigGVR).Namespace(w.Namespace)
+	obj, err := client.Get(ctx, w.Name, meta_v1.GetOptions{})
+	if err != nil {
+		w.Log.Warn("Failed to get AgentConfig to update its status", logz.Error(err))
+		return nil
+	}
+	if obj.GetGeneration() != cond.ObservedGeneration {
+		return nil // the object has changed already, the new generation will be reported
+	}
+	var status agentConfigStatus
+	statusMap, _, _ := unstructured.NestedMap(obj.Object, "status")
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, &status)
+	if err != nil {
+		w.Log.Warn("Failed to read AgentConfig status", logz.Error(err))
+		return nil
+	}
+	if !meta.SetStatusCondition(&status.Conditions, cond) {
+		return nil
+	}
+	statusMap, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		w.Log.Warn("Failed to convert AgentConfig status", logz.Error(err))
+		return nil
+	}
+	err = unstructured.SetNestedMap(obj.Object, statusMap, "status")
+	if err != nil {
+		w.Log.Warn("Failed to set AgentConfig status", logz.Error(err))
+		return nil
+	}
+	_, err = client.UpdateStatus(ctx, obj, meta_v1.UpdateOptions{})
+	if err != nil {
+		// Another agentk replica might have updated the status concurrently, it reports the same result.
+		w.Log.Debug("Failed to update AgentConfig status", logz.Error(err))
+		return nil
+	}
+	return obj
+}
+
+type agentConfigStatus struct {
+	Conditions []meta_v1.Condition `json:"conditions,omitempty"`
+}
+
+func parseConfigurationFile(obj *unstructured.Unstructured) (*agentcfg.ConfigurationFile, error) {
+	file := &agentcfg.ConfigurationFile{}
+	spec, ok := obj.Object["spec"]
+	if !ok || spec == nil {
+		return file, nil
+	}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+	err = protojson.Unmarshal(specJSON, file)
+	if err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+	err = file.ValidateAll()
+	if err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+	return file, nil
+}
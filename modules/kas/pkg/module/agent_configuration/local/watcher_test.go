@@ -0,0 +1,161 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+const (
+	testNamespace = "agentk"
+	testName      = "agentk-config"
+)
+
+var (
+	_ WatcherInterface = (*Watcher)(nil)
+)
+
+func TestWatch_ValidObject(t *testing.T) {
+	w, _ := setupWatcher(t, agentConfig(map[string]any{
+		"observability": map[string]any{
+			"logging": map[string]any{
+				"level": "debug",
+			},
+		},
+	}))
+	config := watchFirst(t, w)
+	require.NotNil(t, config)
+	assert.EqualValues(t, 1, config.Generation)
+	assert.Equal(t, agentcfg.LogLevelEnum_debug, config.File.Observability.Logging.Level)
+}
+
+func TestWatch_NoObject(t *testing.T) {
+	w, _ := setupWatcher(t)
+	assert.Nil(t, watchFirst(t, w))
+}
+
+func TestWatch_InvalidObject(t *testing.T) {
+	w, recorder := setupWatcher(t, agentConfig(map[string]any{
+		"observability": map[string]any{
+			"logging": map[string]any{
+				"level": "verbose",
+			},
+		},
+	}))
+	assert.Nil(t, watchFirst(t, w))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning InvalidConfiguration spec:")
+	cond := getAppliedCondition(t, w)
+	require.NotNil(t, cond)
+	assert.Equal(t, meta_v1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonInvalidConfiguration, cond.Reason)
+}
+
+func TestReportApplied(t *testing.T) {
+	w, recorder := setupWatcher(t, agentConfig(map[string]any{}))
+	config := &Configuration{File: &agentcfg.ConfigurationFile{}, Generation: 1}
+
+	w.ReportApplied(context.Background(), config, nil)
+	cond := getAppliedCondition(t, w)
+	require.NotNil(t, cond)
+	assert.Equal(t, meta_v1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonApplied, cond.Reason)
+	assert.EqualValues(t, 1, cond.ObservedGeneration)
+	assert.Empty(t, recorder.Events)
+
+	w.ReportApplied(context.Background(), config, errors.New("gitops: boom"))
+	cond = getAppliedCondition(t, w)
+	require.NotNil(t, cond)
+	assert.Equal(t, meta_v1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonRejected, cond.Reason)
+	assert.Equal(t, "gitops: boom", cond.Message)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning Rejected gitops: boom", <-recorder.Events)
+
+	// Same result again, no new event.
+	w.ReportApplied(context.Background(), config, errors.New("gitops: boom"))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestReportApplied_SkipsOldGeneration(t *testing.T) {
+	w, _ := setupWatcher(t, agentConfig(map[string]any{}))
+	w.ReportApplied(context.Background(), &Configuration{File: &agentcfg.ConfigurationFile{}, Generation: 0}, nil)
+	assert.Nil(t, getAppliedCondition(t, w))
+}
+
+func setupWatcher(t *testing.T, objs ...runtime.Object) (*Watcher, *record.FakeRecorder) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		AgentConfigGVR: "AgentConfigList",
+	}, objs...)
+	recorder := record.NewFakeRecorder(10)
+	return &Watcher{
+		Log:           zaptest.NewLogger(t),
+		Client:        client,
+		EventRecorder: recorder,
+		Namespace:     testNamespace,
+		Name:          testName,
+		SyncTimeout:   10 * time.Second,
+	}, recorder
+}
+
+// watchFirst returns the configuration that the first callback invocation has been made with.
+func watchFirst(t *testing.T, w *Watcher) *Configuration {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var (
+		config *Configuration
+		called bool
+	)
+	w.Watch(ctx, func(ctx context.Context, c *Configuration) {
+		if called {
+			return
+		}
+		called = true
+		config = c
+		cancel()
+	})
+	require.True(t, called)
+	return config
+}
+
+func getAppliedCondition(t *testing.T, w *Watcher) *meta_v1.Condition {
+	obj, err := w.Client.Resource(AgentConfigGVR).Namespace(testNamespace).Get(context.Background(), testName, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	statusMap, _, _ := unstructured.NestedMap(obj.Object, "status")
+	var status agentConfigStatus
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, &status))
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == ConditionApplied {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func agentConfig(spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": AgentConfigGVR.GroupVersion().String(),
+			"kind":       "AgentConfig",
+			"metadata": map[string]any{
+				"namespace":  testNamespace,
+				"name":       testName,
+				"generation": int64(1),
+			},
+			"spec": spec,
+		},
+	}
+}
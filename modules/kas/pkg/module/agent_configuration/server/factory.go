@@ -0,0 +1,68 @@
+package server
+
+import (
+	"time"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+)
+
+const (
+	getConfigurationInitBackoff   = 10 * time.Second
+	getConfigurationMaxBackoff    = 5 * time.Minute
+	getConfigurationResetDuration = 10 * time.Minute
+	getConfigurationBackoffFactor = 2.0
+	getConfigurationJitter        = 1.0
+)
+
+type Factory struct {
+	AgentRegisterer agent_tracker.Registerer
+	// Source overrides where configuration comes from. If nil, it's constructed from the kas configuration.
+	Source ConfigurationSource
+}
+
+func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
+	agentCfg := config.Config.Agent.Configuration
+	source := f.Source
+	if source == nil {
+		source = newSource(agentCfg, config.Config.PluralUrl)
+	}
+	rpc.RegisterAgentConfigurationServer(config.AgentServer, &server{
+		source:                   source,
+		agentRegisterer:          f.AgentRegisterer,
+		maxConfigurationFileSize: int64(agentCfg.MaxConfigurationFileSize),
+		getConfigurationPollConfig: retry.NewPollConfigFactory(agentCfg.PollPeriod.AsDuration(), retry.NewExponentialBackoffFactory(
+			getConfigurationInitBackoff,
+			getConfigurationMaxBackoff,
+			getConfigurationResetDuration,
+			getConfigurationBackoffFactor,
+			getConfigurationJitter,
+		)),
+		pluralUrl: config.Config.PluralUrl,
+	})
+	return &module{}, nil
+}
+
+func (f *Factory) Name() string {
+	return agent_configuration.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	return modshared.ModuleStartBeforeServers
+}
+
+func newSource(cfg *kascfg.AgentConfigurationCF, pluralUrl string) ConfigurationSource {
+	switch s := cfg.GetLocal().GetSource().(type) {
+	case *kascfg.AgentConfigurationLocalCF_File:
+		return &FileSource{File: s.File}
+	case *kascfg.AgentConfigurationLocalCF_Directory:
+		return &DirectorySource{Directory: s.Directory}
+	default:
+		return &ConsoleSource{PluralUrl: pluralUrl}
+	}
+}
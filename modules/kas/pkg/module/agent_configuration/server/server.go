@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/mathz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+)
+
+type server struct {
+	rpc.UnimplementedAgentConfigurationServer
+	source                     ConfigurationSource
+	agentRegisterer            agent_tracker.Registerer
+	maxConfigurationFileSize   int64
+	getConfigurationPollConfig retry.PollConfigFactory
+	pluralUrl                  string
+}
+
+func (s *server) GetConfiguration(req *rpc.ConfigurationRequest, server rpc.AgentConfiguration_GetConfigurationServer) error {
+	connectedAgentInfo := &agent_tracker.ConnectedAgentInfo{
+		AgentMeta:    req.AgentMeta,
+		ConnectedAt:  timestamppb.Now(),
+		ConnectionId: mathz.Int63(),
+	}
+	ctx := server.Context()
+	rpcApi := modserver.AgentRpcApiFromContext(ctx)
+	log := rpcApi.Log()
+	defer s.maybeUnregisterAgent(log, rpcApi, connectedAgentInfo, req.SkipRegister)
+
+	lastProcessedCommitId := req.CommitId
+	return rpcApi.PollWithBackoff(s.getConfigurationPollConfig(), func() (error, retry.AttemptResult) {
+		// This call is made on each poll because it checks that the agent's token is still valid.
+		agentInfo, err := rpcApi.AgentInfo(ctx, log)
+		if err != nil {
+			if status.Code(err) == codes.Unavailable {
+				return nil, retry.Backoff
+			}
+			return err, retry.Done
+		}
+
+		// re-define log to avoid accidentally using the old one
+		log := log.With(logz.AgentId(agentInfo.Id)) // nolint:govet
+		s.maybeRegisterAgent(ctx, log, rpcApi, connectedAgentInfo, agentInfo, req.SkipRegister)
+
+		config, err := s.fetchConfiguration(ctx, rpcApi.AgentToken(), agentInfo)
+		if err != nil {
+			var ue errz.UserError
+			if errors.As(err, &ue) {
+				// Return the error to agentk, the user has to fix the configuration.
+				log.Debug("Invalid agent configuration", logz.Error(err))
+				return status.Errorf(codes.FailedPrecondition, "Config: %v", err), retry.Done
+			}
+			if errz.ContextDone(err) {
+				return nil, retry.Done
+			}
+			rpcApi.HandleProcessingError(log, agentInfo.Id, "Config: failed to fetch", err)
+			return nil, retry.Backoff
+		}
+		commitId, err := configurationCommitId(config)
+		if err != nil {
+			return err, retry.Done // cannot happen
+		}
+		if commitId == lastProcessedCommitId {
+			log.Debug("Agent configuration has not changed")
+			return nil, retry.Continue
+		}
+		log.Info("Sending agent configuration", logz.CommitId(commitId))
+		err = server.Send(&rpc.ConfigurationResponse{
+			Configuration: config,
+			CommitId:      commitId,
+		})
+		if err != nil {
+			return rpcApi.HandleIoError(log, "Config: failed to send config", err), retry.Done
+		}
+		lastProcessedCommitId = commitId
+		return nil, retry.Continue
+	})
+}
+
+// fetchConfiguration fetches agent's configuration file from the source and turns it into the agent's configuration.
+// An agent without a configuration file gets an empty configuration.
+func (s *server) fetchConfiguration(ctx context.Context, agentToken api.AgentToken, agentInfo *api.AgentInfo) (*agentcfg.AgentConfiguration, error) {
+	data, err := s.source.FetchConfiguration(ctx, agentToken, agentInfo)
+	if err != nil {
+		return nil, err
+	}
+	configFile, err := s.parseConfigurationFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return &agentcfg.AgentConfiguration{
+		Gitops:            configFile.Gitops,
+		Observability:     configFile.Observability,
+		AgentId:           agentInfo.Id,
+		CiAccess:          configFile.CiAccess,
+		ContainerScanning: configFile.ContainerScanning,
+		RemoteDevelopment: configFile.RemoteDevelopment,
+		Flux:              configFile.Flux,
+		GitlabExternalUrl: s.pluralUrl,
+		ServiceProxy:      configFile.ServiceProxy,
+		TcpForward:        configFile.TcpForward,
+		ReverseTunnel:     configFile.ReverseTunnel,
+	}, nil
+}
+
+func (s *server) parseConfigurationFile(data []byte) (*agentcfg.ConfigurationFile, error) {
+	configFile := &agentcfg.ConfigurationFile{}
+	if len(data) == 0 {
+		return configFile, nil
+	}
+	if int64(len(data)) > s.maxConfigurationFileSize {
+		return nil, errz.NewUserErrorf("configuration file is too big: %d bytes, maximum is %d bytes", len(data), s.maxConfigurationFileSize)
+	}
+	configJSON, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errz.NewUserErrorWithCause(err, "failed to parse configuration file as YAML")
+	}
+	// A file with only comments is an empty configuration.
+	if string(configJSON) == "null" {
+		return configFile, nil
+	}
+	err = protojson.Unmarshal(configJSON, configFile)
+	if err != nil {
+		return nil, errz.NewUserErrorWithCause(err, "failed to parse configuration file")
+	}
+	err = configFile.ValidateAll()
+	if err != nil {
+		return nil, errz.NewUserErrorWithCause(err, "invalid configuration file")
+	}
+	return configFile, nil
+}
+
+// configurationCommitId identifies the configuration by its content.
+// agentk sends it back when it reconnects so that the same configuration is not sent again.
+func configurationCommitId(config *agentcfg.AgentConfiguration) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("proto.Marshal: %w", err)
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:]), nil
+}
+
+func (s *server) maybeRegisterAgent(ctx context.Context, log *zap.Logger, rpcApi modserver.AgentRpcApi,
+	connectedAgentInfo *agent_tracker.ConnectedAgentInfo, agentInfo *api.AgentInfo, skipRegister bool) {
+	// Skip registering agent if skipRegister is true. The agent will call "Register" gRPC method instead.
+	if skipRegister {
+		return
+	}
+
+	if connectedAgentInfo.AgentId != 0 {
+		return
+	}
+	connectedAgentInfo.AgentId = agentInfo.Id
+	connectedAgentInfo.ClusterId = agentInfo.ClusterId
+	err := s.agentRegisterer.RegisterConnection(ctx, connectedAgentInfo)
+	if err != nil {
+		rpcApi.HandleProcessingError(log, agentInfo.Id, "Failed to register agent", err)
+	}
+}
+
+func (s *server) maybeUnregisterAgent(log *zap.Logger, rpcApi modserver.AgentRpcApi,
+	connectedAgentInfo *agent_tracker.ConnectedAgentInfo, skipRegister bool) {
+	// Skip unregistering agent if skipRegister is true. GC will clean up the agent from the storage.
+	if skipRegister {
+		return
+	}
+
+	if connectedAgentInfo.AgentId == 0 {
+		return
+	}
+	err := s.agentRegisterer.UnregisterConnection(context.Background(), connectedAgentInfo, agent_tracker.DisconnectReason_unknown)
+	if err != nil {
+		rpcApi.HandleProcessingError(log, connectedAgentInfo.AgentId, "Failed to unregister agent", err)
+	}
+}
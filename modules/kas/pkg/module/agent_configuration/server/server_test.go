@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/matcher"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/testhelpers"
+)
+
+const (
+	testPluralUrl = "https://console.example.com"
+	testConfig    = `
+observability:
+  logging:
+    level: debug
+`
+)
+
+var (
+	_ modserver.Module             = (*module)(nil)
+	_ modserver.Factory            = (*Factory)(nil)
+	_ rpc.AgentConfigurationServer = (*server)(nil)
+	_ ConfigurationSource          = (*ConsoleSource)(nil)
+	_ ConfigurationSource          = (*FileSource)(nil)
+	_ ConfigurationSource          = (*DirectorySource)(nil)
+)
+
+func TestGetConfiguration_SendsConfiguration(t *testing.T) {
+	s, stream, _ := setupServer(t, 1, staticSource(testConfig))
+	expected := &rpc.ConfigurationResponse{
+		Configuration: &agentcfg.AgentConfiguration{
+			Observability: &agentcfg.ObservabilityCF{
+				Logging: &agentcfg.LoggingCF{
+					Level: agentcfg.LogLevelEnum_debug,
+				},
+			},
+			AgentId:           testhelpers.AgentId,
+			GitlabExternalUrl: testPluralUrl,
+		},
+		CommitId: commitIdOf(t, testConfig),
+	}
+	stream.EXPECT().
+		Send(matcher.ProtoEq(t, expected))
+	err := s.GetConfiguration(&rpc.ConfigurationRequest{SkipRegister: true}, stream)
+	require.NoError(t, err)
+}
+
+func TestGetConfiguration_SendsEmptyConfigurationWithoutFile(t *testing.T) {
+	s, stream, _ := setupServer(t, 1, staticSource(""))
+	stream.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(resp *rpc.ConfigurationResponse) error {
+			assert.Equal(t, testhelpers.AgentId, resp.Configuration.AgentId)
+			assert.Nil(t, resp.Configuration.Observability)
+			assert.NotEmpty(t, resp.CommitId)
+			return nil
+		})
+	err := s.GetConfiguration(&rpc.ConfigurationRequest{SkipRegister: true}, stream)
+	require.NoError(t, err)
+}
+
+func TestGetConfiguration_SkipsConfigurationAgentHasAlready(t *testing.T) {
+	s, stream, _ := setupServer(t, 2, staticSource(testConfig))
+	// No Send() calls expected.
+	err := s.GetConfiguration(&rpc.ConfigurationRequest{
+		CommitId:     commitIdOf(t, testConfig),
+		SkipRegister: true,
+	}, stream)
+	require.NoError(t, err)
+}
+
+func TestGetConfiguration_SendsChangedConfiguration(t *testing.T) {
+	configs := []string{testConfig, testConfig, "observability: {}"}
+	s, stream, _ := setupServer(t, len(configs), func() string {
+		c := configs[0]
+		configs = configs[1:]
+		return c
+	})
+	var commitIds []string
+	stream.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(resp *rpc.ConfigurationResponse) error {
+			commitIds = append(commitIds, resp.CommitId)
+			return nil
+		}).
+		Times(2)
+	err := s.GetConfiguration(&rpc.ConfigurationRequest{SkipRegister: true}, stream)
+	require.NoError(t, err)
+	require.Len(t, commitIds, 2)
+	assert.NotEqual(t, commitIds[0], commitIds[1])
+}
+
+func TestGetConfiguration_RejectsInvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		errMsg string
+	}{
+		{
+			name:   "not YAML",
+			config: "a: b: c",
+			errMsg: "rpc error: code = FailedPrecondition desc = Config: failed to parse configuration file as YAML",
+		},
+		{
+			name:   "unknown field",
+			config: "unknown: true",
+			errMsg: "rpc error: code = FailedPrecondition desc = Config: failed to parse configuration file",
+		},
+		{
+			name:   "validation",
+			config: "gitops:\n  manifest_projects:\n  - dry_run_strategy: maybe",
+			errMsg: "rpc error: code = FailedPrecondition desc = Config: invalid configuration file",
+		},
+		{
+			name:   "too big",
+			config: "# " + string(make([]byte, 1024)),
+			errMsg: "rpc error: code = FailedPrecondition desc = Config: configuration file is too big: 1026 bytes, maximum is 1024 bytes",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, stream, _ := setupServer(t, 1, staticSource(tc.config))
+			err := s.GetConfiguration(&rpc.ConfigurationRequest{SkipRegister: true}, stream)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestGetConfiguration_RejectsInvalidToken(t *testing.T) {
+	s, stream, rpcApi := setupServerWithoutAgentInfo(t, 1, staticSource(testConfig))
+	rpcApi.EXPECT().
+		AgentInfo(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.Unauthenticated, "unauthenticated"))
+	err := s.GetConfiguration(&rpc.ConfigurationRequest{SkipRegister: true}, stream)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func commitIdOf(t *testing.T, config string) string {
+	s := &server{
+		source:                   staticSource(config),
+		maxConfigurationFileSize: 1024,
+		pluralUrl:                testPluralUrl,
+	}
+	agentConfig, err := s.fetchConfiguration(context.Background(), testhelpers.AgentkToken, testhelpers.AgentInfoObj())
+	require.NoError(t, err)
+	commitId, err := configurationCommitId(agentConfig)
+	require.NoError(t, err)
+	return commitId
+}
+
+func setupServer(t *testing.T, pollTimes int, source sourceFunc) (*server, *mock_rpc.MockAgentConfiguration_GetConfigurationServer[rpc.ConfigurationResponse], *mock_modserver.MockAgentRpcApi) {
+	s, stream, rpcApi := setupServerWithoutAgentInfo(t, pollTimes, source)
+	rpcApi.EXPECT().
+		AgentInfo(gomock.Any(), gomock.Any()).
+		Return(testhelpers.AgentInfoObj(), nil).
+		AnyTimes()
+	return s, stream, rpcApi
+}
+
+func setupServerWithoutAgentInfo(t *testing.T, pollTimes int, source sourceFunc) (*server, *mock_rpc.MockAgentConfiguration_GetConfigurationServer[rpc.ConfigurationResponse], *mock_modserver.MockAgentRpcApi) {
+	ctrl := gomock.NewController(t)
+	rpcApi := mock_modserver.NewMockAgentRpcApiWithMockPoller(ctrl, pollTimes)
+	rpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t)).
+		AnyTimes()
+	rpcApi.EXPECT().
+		AgentToken().
+		Return(testhelpers.AgentkToken).
+		AnyTimes()
+	ctx := grpctool.AddMaxConnectionAgeContext(context.Background(), context.Background())
+	ctx = modserver.InjectAgentRpcApi(ctx, rpcApi)
+	stream := mock_rpc.NewMockAgentConfiguration_GetConfigurationServer[rpc.ConfigurationResponse](ctrl)
+	stream.EXPECT().
+		Context().
+		Return(ctx).
+		MinTimes(1)
+	s := &server{
+		source:                     source,
+		maxConfigurationFileSize:   1024,
+		getConfigurationPollConfig: testhelpers.NewPollConfig(time.Minute),
+		pluralUrl:                  testPluralUrl,
+	}
+	return s, stream, rpcApi
+}
+
+// sourceFunc is a ConfigurationSource that returns what the function returns.
+type sourceFunc func() string
+
+func (f sourceFunc) FetchConfiguration(ctx context.Context, agentToken api.AgentToken, agentInfo *api.AgentInfo) ([]byte, error) {
+	return []byte(f()), nil
+}
+
+func staticSource(config string) sourceFunc {
+	return func() string {
+		return config
+	}
+}
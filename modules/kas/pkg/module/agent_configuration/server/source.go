@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_configuration"
+	"github.com/pluralsh/kubernetes-agent/pkg/plural"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+)
+
+// ConfigurationSource provides agent configuration files.
+type ConfigurationSource interface {
+	// FetchConfiguration returns the agent's configuration file as YAML or JSON.
+	// Returns nil if the agent has no configuration. Errors the user can fix are errz.UserError.
+	FetchConfiguration(ctx context.Context, agentToken api.AgentToken, agentInfo *api.AgentInfo) ([]byte, error)
+}
+
+// ConsoleSource gets configuration from the metadata of the agent's cluster in Plural Console.
+type ConsoleSource struct {
+	PluralUrl string
+}
+
+func (s *ConsoleSource) FetchConfiguration(ctx context.Context, agentToken api.AgentToken, agentInfo *api.AgentInfo) ([]byte, error) {
+	return plural.GetAgentConfiguration(ctx, agentToken, s.PluralUrl)
+}
+
+// FileSource reads the same configuration for all agents from a file.
+type FileSource struct {
+	File string
+}
+
+func (s *FileSource) FetchConfiguration(ctx context.Context, agentToken api.AgentToken, agentInfo *api.AgentInfo) ([]byte, error) {
+	return readConfigurationFile(s.File)
+}
+
+// DirectorySource reads configuration from the <agent name>/config.yaml file in a directory.
+type DirectorySource struct {
+	Directory string
+}
+
+func (s *DirectorySource) FetchConfiguration(ctx context.Context, agentToken api.AgentToken, agentInfo *api.AgentInfo) ([]byte, error) {
+	if !filepath.IsLocal(agentInfo.Name) {
+		return nil, errz.NewUserErrorf("agent name %q cannot be used as a directory name", agentInfo.Name)
+	}
+	return readConfigurationFile(filepath.Join(s.Directory, agentInfo.Name, agent_configuration.FileName))
+}
+
+func readConfigurationFile(file string) ([]byte, error) {
+	data, err := os.ReadFile(file) // nolint: gosec
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
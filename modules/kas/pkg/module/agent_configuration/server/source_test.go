@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/testhelpers"
+)
+
+func TestFileSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	s := &FileSource{File: file}
+
+	data, err := s.FetchConfiguration(context.Background(), testhelpers.AgentkToken, testhelpers.AgentInfoObj())
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, os.WriteFile(file, []byte(testConfig), 0o600))
+	data, err = s.FetchConfiguration(context.Background(), testhelpers.AgentkToken, testhelpers.AgentInfoObj())
+	require.NoError(t, err)
+	assert.Equal(t, testConfig, string(data))
+}
+
+func TestDirectorySource(t *testing.T) {
+	dir := t.TempDir()
+	s := &DirectorySource{Directory: dir}
+	agentInfo := testhelpers.AgentInfoObj()
+
+	data, err := s.FetchConfiguration(context.Background(), testhelpers.AgentkToken, agentInfo)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, agentInfo.Name), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, agentInfo.Name, "config.yaml"), []byte(testConfig), 0o600))
+	data, err = s.FetchConfiguration(context.Background(), testhelpers.AgentkToken, agentInfo)
+	require.NoError(t, err)
+	assert.Equal(t, testConfig, string(data))
+}
+
+func TestDirectorySource_RejectsNameOutsideOfDirectory(t *testing.T) {
+	s := &DirectorySource{Directory: t.TempDir()}
+	_, err := s.FetchConfiguration(context.Background(), testhelpers.AgentkToken, &api.AgentInfo{Name: "../agent"})
+	assert.ErrorAs(t, err, &errz.UserError{})
+}
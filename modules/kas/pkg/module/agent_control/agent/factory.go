@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
+)
+
+type Factory struct {
+	LogLevel     zap.AtomicLevel
+	GrpcLogLevel zap.AtomicLevel
+	// Reconnect is dispatched to to make the reverse_tunnel module replace its tunnels.
+	Reconnect *syncz.Subscriptions[struct{}]
+	// RotateToken switches to the given token or, if it's empty, reads the token from the token file again.
+	RotateToken func(token api.AgentToken) error
+}
+
+func (f *Factory) IsProducingLeaderModules() bool {
+	return false
+}
+
+func (f *Factory) New(config *modagent.Config) (modagent.Module, error) {
+	rpc.RegisterAgentControlServer(config.Server, &server{
+		agentMeta:    config.AgentMeta,
+		startedAt:    time.Now(),
+		logLevel:     f.LogLevel,
+		grpcLogLevel: f.GrpcLogLevel,
+		reconnect:    f.Reconnect,
+		rotateToken:  f.RotateToken,
+	})
+	return &module{}, nil
+}
+
+func (f *Factory) Name() string {
+	return agent_control.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	// This module exposes an API endpoint on the internal server, but it does not make requests to it.
+	return modshared.ModuleStartBeforeServers
+}
@@ -0,0 +1,33 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control"
+)
+
+type module struct {
+}
+
+func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			return nil
+		case _, ok := <-cfg:
+			if !ok {
+				return nil
+			}
+		}
+	}
+}
+
+func (m *module) DefaultAndValidateConfiguration(config *agentcfg.AgentConfiguration) error {
+	return nil
+}
+
+func (m *module) Name() string {
+	return agent_control.ModuleName
+}
@@ -0,0 +1,194 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
+)
+
+const (
+	cpuProfileName            = "cpu"
+	defaultCpuProfileDuration = 30 * time.Second
+	// outputChunkSize is the maximum size of a chunk of data in a CommandOutput message.
+	outputChunkSize = 32 * 1024
+)
+
+type server struct {
+	rpc.UnimplementedAgentControlServer
+	agentMeta    *entity.AgentMeta
+	startedAt    time.Time
+	logLevel     zap.AtomicLevel
+	grpcLogLevel zap.AtomicLevel
+	reconnect    *syncz.Subscriptions[struct{}]
+	rotateToken  func(token api.AgentToken) error
+}
+
+func (s *server) Execute(cmd *rpc.Command, stream rpc.AgentControl_ExecuteServer) error {
+	log := modagent.RpcApiFromContext(stream.Context()).Log()
+	log.Info("Executing command from kas", zap.String("command", cmd.Name()))
+	switch c := cmd.Command.(type) {
+	case *rpc.Command_SetLogLevel:
+		return s.setLogLevel(c.SetLogLevel, stream)
+	case *rpc.Command_Profile:
+		return s.profile(c.Profile, stream)
+	case *rpc.Command_Reconnect:
+		s.reconnect.Dispatch(stream.Context(), struct{}{})
+		return sendMessage(stream, "replacing reverse tunnels")
+	case *rpc.Command_RotateToken:
+		return s.doRotateToken(c.RotateToken, stream)
+	case *rpc.Command_GetDiagnostics:
+		return stream.Send(&rpc.CommandOutput{
+			Output: &rpc.CommandOutput_Diagnostics{
+				Diagnostics: s.diagnostics(),
+			},
+		})
+	default:
+		// Should never happen, the request is validated.
+		return status.Errorf(codes.InvalidArgument, "unknown command: %T", c)
+	}
+}
+
+func (s *server) setLogLevel(cmd *rpc.SetLogLevel, stream rpc.AgentControl_ExecuteServer) error {
+	err := setLevel(s.logLevel, cmd.Level)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("log level set to %s", cmd.Level)
+	if cmd.GrpcLevel != "" {
+		err = setLevel(s.grpcLogLevel, cmd.GrpcLevel)
+		if err != nil {
+			return err
+		}
+		msg += fmt.Sprintf(", gRPC log level set to %s", cmd.GrpcLevel)
+	}
+	return sendMessage(stream, msg)
+}
+
+func (s *server) profile(cmd *rpc.Profile, stream rpc.AgentControl_ExecuteServer) error {
+	w := bufio.NewWriterSize(&outputWriter{stream: stream}, outputChunkSize)
+	if cmd.Name == cpuProfileName {
+		err := s.cpuProfile(stream.Context(), cmd, w)
+		if err != nil {
+			return err
+		}
+	} else {
+		p := pprof.Lookup(cmd.Name)
+		if p == nil {
+			return status.Errorf(codes.InvalidArgument, "unknown profile: %s", cmd.Name)
+		}
+		err := p.WriteTo(w, int(cmd.Debug))
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (s *server) cpuProfile(ctx context.Context, cmd *rpc.Profile, w *bufio.Writer) error {
+	duration := cmd.Duration.AsDuration()
+	if duration == 0 {
+		duration = defaultCpuProfileDuration
+	}
+	err := pprof.StartCPUProfile(w)
+	if err != nil {
+		// A CPU profile is being collected already.
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	t := time.NewTimer(duration)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		pprof.StopCPUProfile()
+		return ctx.Err()
+	case <-t.C:
+		pprof.StopCPUProfile()
+		return nil
+	}
+}
+
+func (s *server) doRotateToken(cmd *rpc.RotateToken, stream rpc.AgentControl_ExecuteServer) error {
+	if s.rotateToken == nil {
+		return status.Error(codes.Unimplemented, "token rotation is not supported")
+	}
+	err := s.rotateToken(api.AgentToken(cmd.Token))
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "token rotation: %v", err)
+	}
+	// Existing tunnels were authenticated with the old token, replace them.
+	s.reconnect.Dispatch(stream.Context(), struct{}{})
+	return sendMessage(stream, "token has been rotated, replacing reverse tunnels")
+}
+
+func (s *server) diagnostics() *rpc.Diagnostics {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return &rpc.Diagnostics{
+		Version:        s.agentMeta.Version,
+		CommitId:       s.agentMeta.CommitId,
+		PodNamespace:   s.agentMeta.PodNamespace,
+		PodName:        s.agentMeta.PodName,
+		StartedAt:      timestamppb.New(s.startedAt),
+		LogLevel:       s.logLevel.String(),
+		GrpcLogLevel:   s.grpcLogLevel.String(),
+		Features:       s.agentMeta.Features,
+		Goroutines:     int64(runtime.NumGoroutine()),
+		HeapAllocBytes: mem.HeapAlloc,
+		SysBytes:       mem.Sys,
+		NumGc:          mem.NumGC,
+	}
+}
+
+func setLevel(logLevel zap.AtomicLevel, val string) error {
+	level, err := logz.LevelFromString(val)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	logLevel.SetLevel(level)
+	return nil
+}
+
+func sendMessage(stream rpc.AgentControl_ExecuteServer, msg string) error {
+	return stream.Send(&rpc.CommandOutput{
+		Output: &rpc.CommandOutput_Message{
+			Message: msg,
+		},
+	})
+}
+
+// outputWriter sends written data as CommandOutput messages of at most outputChunkSize bytes.
+type outputWriter struct {
+	stream rpc.AgentControl_ExecuteServer
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), outputChunkSize)
+		err := w.stream.Send(&rpc.CommandOutput{
+			Output: &rpc.CommandOutput_Data{
+				Data: p[:n],
+			},
+		})
+		if err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
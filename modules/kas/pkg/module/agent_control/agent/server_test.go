@@ -0,0 +1,226 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_agent_control"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modshared"
+)
+
+var (
+	_ modagent.Module                = (*module)(nil)
+	_ modagent.Factory               = (*Factory)(nil)
+	_ rpc.AgentControlServer         = (*server)(nil)
+	_ rpc.AgentControlClient         = (*mock_agent_control.MockAgentControlClient)(nil)
+	_ rpc.AgentControl_ExecuteServer = (*mock_agent_control.MockAgentControl_ExecuteServer[rpc.CommandOutput])(nil)
+)
+
+func TestExecute_SetLogLevel(t *testing.T) {
+	s, stream, out := setupServer(t)
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_SetLogLevel{
+			SetLogLevel: &rpc.SetLogLevel{
+				Level:     "debug",
+				GrpcLevel: "error",
+			},
+		},
+	}, stream)
+	require.NoError(t, err)
+	assert.Equal(t, zap.DebugLevel, s.logLevel.Level())
+	assert.Equal(t, zap.ErrorLevel, s.grpcLogLevel.Level())
+	require.Len(t, *out, 1)
+	assert.Equal(t, "log level set to debug, gRPC log level set to error", (*out)[0].GetMessage())
+}
+
+func TestExecute_SetLogLevel_KeepsGrpcLevel(t *testing.T) {
+	s, stream, _ := setupServer(t)
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_SetLogLevel{
+			SetLogLevel: &rpc.SetLogLevel{
+				Level: "warn",
+			},
+		},
+	}, stream)
+	require.NoError(t, err)
+	assert.Equal(t, zap.WarnLevel, s.logLevel.Level())
+	assert.Equal(t, zap.InfoLevel, s.grpcLogLevel.Level())
+}
+
+func TestExecute_Profile(t *testing.T) {
+	s, stream, out := setupServer(t)
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_Profile{
+			Profile: &rpc.Profile{
+				Name:  "goroutine",
+				Debug: 2,
+			},
+		},
+	}, stream)
+	require.NoError(t, err)
+	var profile bytes.Buffer
+	for _, o := range *out {
+		assert.LessOrEqual(t, len(o.GetData()), outputChunkSize)
+		profile.Write(o.GetData())
+	}
+	assert.Contains(t, profile.String(), "goroutine")
+	assert.Contains(t, profile.String(), "TestExecute_Profile")
+}
+
+func TestExecute_Reconnect(t *testing.T) {
+	s, stream, out := setupServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reconnected := make(chan struct{})
+	go s.reconnect.On(ctx, func(ctx context.Context, _ struct{}) {
+		close(reconnected)
+	})
+	// The event is only delivered once the subscription has been added, so retry until then.
+	require.Eventually(t, func() bool {
+		*out = nil
+		require.NoError(t, s.Execute(&rpc.Command{
+			Command: &rpc.Command_Reconnect{
+				Reconnect: &rpc.Reconnect{},
+			},
+		}, stream))
+		select {
+		case <-reconnected:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Minute, time.Millisecond)
+	require.Len(t, *out, 1)
+	assert.Equal(t, "replacing reverse tunnels", (*out)[0].GetMessage())
+}
+
+func TestExecute_RotateToken(t *testing.T) {
+	s, stream, out := setupServer(t)
+	var rotatedTo *api.AgentToken
+	s.rotateToken = func(token api.AgentToken) error {
+		rotatedTo = &token
+		return nil
+	}
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_RotateToken{
+			RotateToken: &rpc.RotateToken{},
+		},
+	}, stream)
+	require.NoError(t, err)
+	require.NotNil(t, rotatedTo)
+	assert.Empty(t, *rotatedTo)
+	require.Len(t, *out, 1)
+	assert.Equal(t, "token has been rotated, replacing reverse tunnels", (*out)[0].GetMessage())
+}
+
+func TestExecute_RotateToken_NewToken(t *testing.T) {
+	s, stream, _ := setupServer(t)
+	var rotatedTo api.AgentToken
+	s.rotateToken = func(token api.AgentToken) error {
+		rotatedTo = token
+		return nil
+	}
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_RotateToken{
+			RotateToken: &rpc.RotateToken{
+				Token: "new",
+			},
+		},
+	}, stream)
+	require.NoError(t, err)
+	assert.Equal(t, api.AgentToken("new"), rotatedTo)
+}
+
+func TestExecute_RotateToken_Error(t *testing.T) {
+	s, stream, _ := setupServer(t)
+	s.rotateToken = func(token api.AgentToken) error {
+		return errors.New("token file: file is empty")
+	}
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_RotateToken{
+			RotateToken: &rpc.RotateToken{},
+		},
+	}, stream)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = token rotation: token file: file is empty")
+}
+
+func TestExecute_RotateToken_NotSupported(t *testing.T) {
+	s, stream, _ := setupServer(t)
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_RotateToken{
+			RotateToken: &rpc.RotateToken{},
+		},
+	}, stream)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestExecute_GetDiagnostics(t *testing.T) {
+	s, stream, out := setupServer(t)
+	err := s.Execute(&rpc.Command{
+		Command: &rpc.Command_GetDiagnostics{
+			GetDiagnostics: &rpc.GetDiagnostics{},
+		},
+	}, stream)
+	require.NoError(t, err)
+	require.Len(t, *out, 1)
+	d := (*out)[0].GetDiagnostics()
+	assert.Equal(t, "v1.2.3", d.Version)
+	assert.Equal(t, "agentk-123", d.PodName)
+	assert.Equal(t, "info", d.LogLevel)
+	assert.Equal(t, []string{"agent_control"}, d.Features)
+	assert.Positive(t, d.Goroutines)
+	assert.Positive(t, d.HeapAllocBytes)
+}
+
+func setupServer(t *testing.T) (*server, *mock_agent_control.MockAgentControl_ExecuteServer[rpc.CommandOutput], *[]*rpc.CommandOutput) {
+	ctrl := gomock.NewController(t)
+	rpcApi := mock_modshared.NewMockRpcApi(ctrl)
+	rpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t)).
+		AnyTimes()
+	ctx := modagent.InjectRpcApi(context.Background(), rpcApi)
+	var out []*rpc.CommandOutput
+	stream := mock_agent_control.NewMockAgentControl_ExecuteServer[rpc.CommandOutput](ctrl)
+	stream.EXPECT().
+		Context().
+		Return(ctx).
+		AnyTimes()
+	stream.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(o *rpc.CommandOutput) error {
+			out = append(out, o)
+			return nil
+		}).
+		AnyTimes()
+	s := &server{
+		agentMeta: &entity.AgentMeta{
+			Version:      "v1.2.3",
+			PodNamespace: "agentk",
+			PodName:      "agentk-123",
+			Features:     []string{"agent_control"},
+		},
+		startedAt:    time.Now(),
+		logLevel:     zap.NewAtomicLevelAt(zap.InfoLevel),
+		grpcLogLevel: zap.NewAtomicLevelAt(zap.InfoLevel),
+		reconnect:    &syncz.Subscriptions[struct{}]{},
+	}
+	return s, stream, &out
+}
@@ -0,0 +1,5 @@
+package agent_control
+
+const (
+	ModuleName = "agent_control"
+)
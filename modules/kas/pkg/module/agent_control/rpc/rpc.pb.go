@@ -0,0 +1,828 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: pkg/module/agent_control/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SetLogLevel changes the log levels of agentk until the next configuration update.
+type SetLogLevel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Level string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// Log level of gRPC. Not changed if empty.
+	GrpcLevel     string `protobuf:"bytes,2,opt,name=grpc_level,proto3" json:"grpc_level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevel) Reset() {
+	*x = SetLogLevel{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevel) ProtoMessage() {}
+
+func (x *SetLogLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevel.ProtoReflect.Descriptor instead.
+func (*SetLogLevel) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetLogLevel) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevel) GetGrpcLevel() string {
+	if x != nil {
+		return x.GrpcLevel
+	}
+	return ""
+}
+
+// Profile collects a runtime profile of agentk in the format of the pprof tool.
+type Profile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the profile. "cpu" is a CPU profile, the rest are the profiles of the runtime/pprof package.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Format of the profile, see runtime/pprof.Profile.WriteTo(). 0 is the binary pprof format,
+	// 1 and 2 are human-readable text. Ignored for the "cpu" profile.
+	Debug uint32 `protobuf:"varint,2,opt,name=debug,proto3" json:"debug,omitempty"`
+	// For how long to collect the "cpu" profile. Ignored for other profiles.
+	Duration      *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Profile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Profile) GetDebug() uint32 {
+	if x != nil {
+		return x.Debug
+	}
+	return 0
+}
+
+func (x *Profile) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// Reconnect replaces all reverse tunnels of agentk with new ones.
+// Tunnels that are in use are replaced once they are done with the request, including the one that carries
+// this command.
+type Reconnect struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reconnect) Reset() {
+	*x = Reconnect{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reconnect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reconnect) ProtoMessage() {}
+
+func (x *Reconnect) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reconnect.ProtoReflect.Descriptor instead.
+func (*Reconnect) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+// RotateToken makes agentk switch to a new token and replace its reverse tunnels.
+// Subsequent requests to kas use the new token.
+type RotateToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The new token. If empty, agentk reads its token from the token file again.
+	// A token set this way is lost when agentk restarts, the token file or environment variable must be updated too.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateToken) Reset() {
+	*x = RotateToken{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateToken) ProtoMessage() {}
+
+func (x *RotateToken) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateToken.ProtoReflect.Descriptor instead.
+func (*RotateToken) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RotateToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// GetDiagnostics reports the state of agentk.
+type GetDiagnostics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiagnostics) Reset() {
+	*x = GetDiagnostics{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiagnostics) ProtoMessage() {}
+
+func (x *GetDiagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiagnostics.ProtoReflect.Descriptor instead.
+func (*GetDiagnostics) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{4}
+}
+
+type Command struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Command:
+	//
+	//	*Command_SetLogLevel
+	//	*Command_Profile
+	//	*Command_Reconnect
+	//	*Command_RotateToken
+	//	*Command_GetDiagnostics
+	Command       isCommand_Command `protobuf_oneof:"command"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Command) GetCommand() isCommand_Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Command) GetSetLogLevel() *SetLogLevel {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetLogLevel); ok {
+			return x.SetLogLevel
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetProfile() *Profile {
+	if x != nil {
+		if x, ok := x.Command.(*Command_Profile); ok {
+			return x.Profile
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetReconnect() *Reconnect {
+	if x != nil {
+		if x, ok := x.Command.(*Command_Reconnect); ok {
+			return x.Reconnect
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetRotateToken() *RotateToken {
+	if x != nil {
+		if x, ok := x.Command.(*Command_RotateToken); ok {
+			return x.RotateToken
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetGetDiagnostics() *GetDiagnostics {
+	if x != nil {
+		if x, ok := x.Command.(*Command_GetDiagnostics); ok {
+			return x.GetDiagnostics
+		}
+	}
+	return nil
+}
+
+type isCommand_Command interface {
+	isCommand_Command()
+}
+
+type Command_SetLogLevel struct {
+	SetLogLevel *SetLogLevel `protobuf:"bytes,1,opt,name=set_log_level,proto3,oneof"`
+}
+
+type Command_Profile struct {
+	Profile *Profile `protobuf:"bytes,2,opt,name=profile,proto3,oneof"`
+}
+
+type Command_Reconnect struct {
+	Reconnect *Reconnect `protobuf:"bytes,3,opt,name=reconnect,proto3,oneof"`
+}
+
+type Command_RotateToken struct {
+	RotateToken *RotateToken `protobuf:"bytes,4,opt,name=rotate_token,proto3,oneof"`
+}
+
+type Command_GetDiagnostics struct {
+	GetDiagnostics *GetDiagnostics `protobuf:"bytes,5,opt,name=get_diagnostics,proto3,oneof"`
+}
+
+func (*Command_SetLogLevel) isCommand_Command() {}
+
+func (*Command_Profile) isCommand_Command() {}
+
+func (*Command_Reconnect) isCommand_Command() {}
+
+func (*Command_RotateToken) isCommand_Command() {}
+
+func (*Command_GetDiagnostics) isCommand_Command() {}
+
+type Diagnostics struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Version  string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	CommitId string                 `protobuf:"bytes,2,opt,name=commit_id,proto3" json:"commit_id,omitempty"`
+	// Namespace and name of the agentk pod that executed the command.
+	PodNamespace string                 `protobuf:"bytes,3,opt,name=pod_namespace,proto3" json:"pod_namespace,omitempty"`
+	PodName      string                 `protobuf:"bytes,4,opt,name=pod_name,proto3" json:"pod_name,omitempty"`
+	StartedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,proto3" json:"started_at,omitempty"`
+	LogLevel     string                 `protobuf:"bytes,6,opt,name=log_level,proto3" json:"log_level,omitempty"`
+	GrpcLogLevel string                 `protobuf:"bytes,7,opt,name=grpc_log_level,proto3" json:"grpc_log_level,omitempty"`
+	// Names of the modules agentk runs.
+	Features   []string `protobuf:"bytes,8,rep,name=features,proto3" json:"features,omitempty"`
+	Goroutines int64    `protobuf:"varint,9,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	// Bytes of allocated heap objects.
+	HeapAllocBytes uint64 `protobuf:"varint,10,opt,name=heap_alloc_bytes,proto3" json:"heap_alloc_bytes,omitempty"`
+	// Bytes of memory obtained from the OS.
+	SysBytes      uint64 `protobuf:"varint,11,opt,name=sys_bytes,proto3" json:"sys_bytes,omitempty"`
+	NumGc         uint32 `protobuf:"varint,12,opt,name=num_gc,proto3" json:"num_gc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Diagnostics) Reset() {
+	*x = Diagnostics{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostics) ProtoMessage() {}
+
+func (x *Diagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diagnostics.ProtoReflect.Descriptor instead.
+func (*Diagnostics) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Diagnostics) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Diagnostics) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *Diagnostics) GetPodNamespace() string {
+	if x != nil {
+		return x.PodNamespace
+	}
+	return ""
+}
+
+func (x *Diagnostics) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *Diagnostics) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Diagnostics) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+func (x *Diagnostics) GetGrpcLogLevel() string {
+	if x != nil {
+		return x.GrpcLogLevel
+	}
+	return ""
+}
+
+func (x *Diagnostics) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *Diagnostics) GetGoroutines() int64 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *Diagnostics) GetHeapAllocBytes() uint64 {
+	if x != nil {
+		return x.HeapAllocBytes
+	}
+	return 0
+}
+
+func (x *Diagnostics) GetSysBytes() uint64 {
+	if x != nil {
+		return x.SysBytes
+	}
+	return 0
+}
+
+func (x *Diagnostics) GetNumGc() uint32 {
+	if x != nil {
+		return x.NumGc
+	}
+	return 0
+}
+
+type CommandOutput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Output:
+	//
+	//	*CommandOutput_Data
+	//	*CommandOutput_Diagnostics
+	//	*CommandOutput_Message
+	Output        isCommandOutput_Output `protobuf_oneof:"output"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandOutput) Reset() {
+	*x = CommandOutput{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandOutput) ProtoMessage() {}
+
+func (x *CommandOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandOutput.ProtoReflect.Descriptor instead.
+func (*CommandOutput) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CommandOutput) GetOutput() isCommandOutput_Output {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *CommandOutput) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Output.(*CommandOutput_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *CommandOutput) GetDiagnostics() *Diagnostics {
+	if x != nil {
+		if x, ok := x.Output.(*CommandOutput_Diagnostics); ok {
+			return x.Diagnostics
+		}
+	}
+	return nil
+}
+
+func (x *CommandOutput) GetMessage() string {
+	if x != nil {
+		if x, ok := x.Output.(*CommandOutput_Message); ok {
+			return x.Message
+		}
+	}
+	return ""
+}
+
+type isCommandOutput_Output interface {
+	isCommandOutput_Output()
+}
+
+type CommandOutput_Data struct {
+	// A chunk of a profile.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type CommandOutput_Diagnostics struct {
+	Diagnostics *Diagnostics `protobuf:"bytes,2,opt,name=diagnostics,proto3,oneof"`
+}
+
+type CommandOutput_Message struct {
+	// Outcome of a command that has no other output.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3,oneof"`
+}
+
+func (*CommandOutput_Data) isCommandOutput_Output() {}
+
+func (*CommandOutput_Diagnostics) isCommandOutput_Output() {}
+
+func (*CommandOutput_Message) isCommandOutput_Output() {}
+
+type ExecuteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       int64                  `protobuf:"varint,1,opt,name=agent_id,proto3" json:"agent_id,omitempty"`
+	Command       *Command               `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteRequest) Reset() {
+	*x = ExecuteRequest{}
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteRequest) ProtoMessage() {}
+
+func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExecuteRequest) GetAgentId() int64 {
+	if x != nil {
+		return x.AgentId
+	}
+	return 0
+}
+
+func (x *ExecuteRequest) GetCommand() *Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+var File_pkg_module_agent_control_rpc_rpc_proto protoreflect.FileDescriptor
+
+const file_pkg_module_agent_control_rpc_rpc_proto_rawDesc = "" +
+	"\n" +
+	"&pkg/module/agent_control/rpc/rpc.proto\x12\x1eplural.agent.agent_control.rpc\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\x87\x01\n" +
+	"\vSetLogLevel\x125\n" +
+	"\x05level\x18\x01 \x01(\tB\x1f\xfaB\x1cr\x1aR\x05debugR\x04infoR\x04warnR\x05errorR\x05level\x12A\n" +
+	"\n" +
+	"grpc_level\x18\x02 \x01(\tB!\xfaB\x1er\x1cR\x00R\x05debugR\x04infoR\x04warnR\x05errorR\n" +
+	"grpc_level\"\xc3\x01\n" +
+	"\aProfile\x12S\n" +
+	"\x04name\x18\x01 \x01(\tB?\xfaB<r:R\x03cpuR\tgoroutineR\x04heapR\x06allocsR\fthreadcreateR\x05blockR\x05mutexR\x04name\x12\x1d\n" +
+	"\x05debug\x18\x02 \x01(\rB\a\xfaB\x04*\x02\x18\x02R\x05debug\x12D\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\r\xfaB\n" +
+	"\xaa\x01\a\"\x03\b\xac\x022\x00R\bduration\"\v\n" +
+	"\tReconnect\"-\n" +
+	"\vRotateToken\x12\x1e\n" +
+	"\x05token\x18\x01 \x01(\tB\b\xfaB\x05r\x03(\x80\bR\x05token\"\x10\n" +
+	"\x0eGetDiagnostics\"\xad\x03\n" +
+	"\aCommand\x12S\n" +
+	"\rset_log_level\x18\x01 \x01(\v2+.plural.agent.agent_control.rpc.SetLogLevelH\x00R\rset_log_level\x12C\n" +
+	"\aprofile\x18\x02 \x01(\v2'.plural.agent.agent_control.rpc.ProfileH\x00R\aprofile\x12I\n" +
+	"\treconnect\x18\x03 \x01(\v2).plural.agent.agent_control.rpc.ReconnectH\x00R\treconnect\x12Q\n" +
+	"\frotate_token\x18\x04 \x01(\v2+.plural.agent.agent_control.rpc.RotateTokenH\x00R\frotate_token\x12Z\n" +
+	"\x0fget_diagnostics\x18\x05 \x01(\v2..plural.agent.agent_control.rpc.GetDiagnosticsH\x00R\x0fget_diagnosticsB\x0e\n" +
+	"\acommand\x12\x03\xf8B\x01\"\xa7\x03\n" +
+	"\vDiagnostics\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1c\n" +
+	"\tcommit_id\x18\x02 \x01(\tR\tcommit_id\x12$\n" +
+	"\rpod_namespace\x18\x03 \x01(\tR\rpod_namespace\x12\x1a\n" +
+	"\bpod_name\x18\x04 \x01(\tR\bpod_name\x12:\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"started_at\x12\x1c\n" +
+	"\tlog_level\x18\x06 \x01(\tR\tlog_level\x12&\n" +
+	"\x0egrpc_log_level\x18\a \x01(\tR\x0egrpc_log_level\x12\x1a\n" +
+	"\bfeatures\x18\b \x03(\tR\bfeatures\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\t \x01(\x03R\n" +
+	"goroutines\x12*\n" +
+	"\x10heap_alloc_bytes\x18\n" +
+	" \x01(\x04R\x10heap_alloc_bytes\x12\x1c\n" +
+	"\tsys_bytes\x18\v \x01(\x04R\tsys_bytes\x12\x16\n" +
+	"\x06num_gc\x18\f \x01(\rR\x06num_gc\"\xa1\x01\n" +
+	"\rCommandOutput\x12\x14\n" +
+	"\x04data\x18\x01 \x01(\fH\x00R\x04data\x12O\n" +
+	"\vdiagnostics\x18\x02 \x01(\v2+.plural.agent.agent_control.rpc.DiagnosticsH\x00R\vdiagnostics\x12\x1a\n" +
+	"\amessage\x18\x03 \x01(\tH\x00R\amessageB\r\n" +
+	"\x06output\x12\x03\xf8B\x01\"\x82\x01\n" +
+	"\x0eExecuteRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\bagent_id\x12K\n" +
+	"\acommand\x18\x02 \x01(\v2'.plural.agent.agent_control.rpc.CommandB\b\xfaB\x05\x8a\x01\x02\x10\x01R\acommand2u\n" +
+	"\fAgentControl\x12e\n" +
+	"\aExecute\x12'.plural.agent.agent_control.rpc.Command\x1a-.plural.agent.agent_control.rpc.CommandOutput\"\x000\x012\x7f\n" +
+	"\x0fAgentControlApi\x12l\n" +
+	"\aExecute\x12..plural.agent.agent_control.rpc.ExecuteRequest\x1a-.plural.agent.agent_control.rpc.CommandOutput\"\x000\x01BCZAgithub.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpcb\x06proto3"
+
+var (
+	file_pkg_module_agent_control_rpc_rpc_proto_rawDescOnce sync.Once
+	file_pkg_module_agent_control_rpc_rpc_proto_rawDescData []byte
+)
+
+func file_pkg_module_agent_control_rpc_rpc_proto_rawDescGZIP() []byte {
+	file_pkg_module_agent_control_rpc_rpc_proto_rawDescOnce.Do(func() {
+		file_pkg_module_agent_control_rpc_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_module_agent_control_rpc_rpc_proto_rawDesc), len(file_pkg_module_agent_control_rpc_rpc_proto_rawDesc)))
+	})
+	return file_pkg_module_agent_control_rpc_rpc_proto_rawDescData
+}
+
+var file_pkg_module_agent_control_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pkg_module_agent_control_rpc_rpc_proto_goTypes = []any{
+	(*SetLogLevel)(nil),           // 0: plural.agent.agent_control.rpc.SetLogLevel
+	(*Profile)(nil),               // 1: plural.agent.agent_control.rpc.Profile
+	(*Reconnect)(nil),             // 2: plural.agent.agent_control.rpc.Reconnect
+	(*RotateToken)(nil),           // 3: plural.agent.agent_control.rpc.RotateToken
+	(*GetDiagnostics)(nil),        // 4: plural.agent.agent_control.rpc.GetDiagnostics
+	(*Command)(nil),               // 5: plural.agent.agent_control.rpc.Command
+	(*Diagnostics)(nil),           // 6: plural.agent.agent_control.rpc.Diagnostics
+	(*CommandOutput)(nil),         // 7: plural.agent.agent_control.rpc.CommandOutput
+	(*ExecuteRequest)(nil),        // 8: plural.agent.agent_control.rpc.ExecuteRequest
+	(*durationpb.Duration)(nil),   // 9: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_pkg_module_agent_control_rpc_rpc_proto_depIdxs = []int32{
+	9,  // 0: plural.agent.agent_control.rpc.Profile.duration:type_name -> google.protobuf.Duration
+	0,  // 1: plural.agent.agent_control.rpc.Command.set_log_level:type_name -> plural.agent.agent_control.rpc.SetLogLevel
+	1,  // 2: plural.agent.agent_control.rpc.Command.profile:type_name -> plural.agent.agent_control.rpc.Profile
+	2,  // 3: plural.agent.agent_control.rpc.Command.reconnect:type_name -> plural.agent.agent_control.rpc.Reconnect
+	3,  // 4: plural.agent.agent_control.rpc.Command.rotate_token:type_name -> plural.agent.agent_control.rpc.RotateToken
+	4,  // 5: plural.agent.agent_control.rpc.Command.get_diagnostics:type_name -> plural.agent.agent_control.rpc.GetDiagnostics
+	10, // 6: plural.agent.agent_control.rpc.Diagnostics.started_at:type_name -> google.protobuf.Timestamp
+	6,  // 7: plural.agent.agent_control.rpc.CommandOutput.diagnostics:type_name -> plural.agent.agent_control.rpc.Diagnostics
+	5,  // 8: plural.agent.agent_control.rpc.ExecuteRequest.command:type_name -> plural.agent.agent_control.rpc.Command
+	5,  // 9: plural.agent.agent_control.rpc.AgentControl.Execute:input_type -> plural.agent.agent_control.rpc.Command
+	8,  // 10: plural.agent.agent_control.rpc.AgentControlApi.Execute:input_type -> plural.agent.agent_control.rpc.ExecuteRequest
+	7,  // 11: plural.agent.agent_control.rpc.AgentControl.Execute:output_type -> plural.agent.agent_control.rpc.CommandOutput
+	7,  // 12: plural.agent.agent_control.rpc.AgentControlApi.Execute:output_type -> plural.agent.agent_control.rpc.CommandOutput
+	11, // [11:13] is the sub-list for method output_type
+	9,  // [9:11] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_pkg_module_agent_control_rpc_rpc_proto_init() }
+func file_pkg_module_agent_control_rpc_rpc_proto_init() {
+	if File_pkg_module_agent_control_rpc_rpc_proto != nil {
+		return
+	}
+	file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[5].OneofWrappers = []any{
+		(*Command_SetLogLevel)(nil),
+		(*Command_Profile)(nil),
+		(*Command_Reconnect)(nil),
+		(*Command_RotateToken)(nil),
+		(*Command_GetDiagnostics)(nil),
+	}
+	file_pkg_module_agent_control_rpc_rpc_proto_msgTypes[7].OneofWrappers = []any{
+		(*CommandOutput_Data)(nil),
+		(*CommandOutput_Diagnostics)(nil),
+		(*CommandOutput_Message)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_agent_control_rpc_rpc_proto_rawDesc), len(file_pkg_module_agent_control_rpc_rpc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_pkg_module_agent_control_rpc_rpc_proto_goTypes,
+		DependencyIndexes: file_pkg_module_agent_control_rpc_rpc_proto_depIdxs,
+		MessageInfos:      file_pkg_module_agent_control_rpc_rpc_proto_msgTypes,
+	}.Build()
+	File_pkg_module_agent_control_rpc_rpc_proto = out.File
+	file_pkg_module_agent_control_rpc_rpc_proto_goTypes = nil
+	file_pkg_module_agent_control_rpc_rpc_proto_depIdxs = nil
+}
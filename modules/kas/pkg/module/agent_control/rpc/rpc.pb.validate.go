@@ -0,0 +1,1449 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: pkg/module/agent_control/rpc/rpc.proto
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on SetLogLevel with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SetLogLevel) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetLogLevel with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SetLogLevelMultiError, or
+// nil if none found.
+func (m *SetLogLevel) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetLogLevel) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _SetLogLevel_Level_InLookup[m.GetLevel()]; !ok {
+		err := SetLogLevelValidationError{
+			field:  "Level",
+			reason: "value must be in list [debug info warn error]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _SetLogLevel_GrpcLevel_InLookup[m.GetGrpcLevel()]; !ok {
+		err := SetLogLevelValidationError{
+			field:  "GrpcLevel",
+			reason: "value must be in list [ debug info warn error]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SetLogLevelMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetLogLevelMultiError is an error wrapping multiple validation errors
+// returned by SetLogLevel.ValidateAll() if the designated constraints aren't met.
+type SetLogLevelMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetLogLevelMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetLogLevelMultiError) AllErrors() []error { return m }
+
+// SetLogLevelValidationError is the validation error returned by
+// SetLogLevel.Validate if the designated constraints aren't met.
+type SetLogLevelValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetLogLevelValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetLogLevelValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetLogLevelValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetLogLevelValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetLogLevelValidationError) ErrorName() string { return "SetLogLevelValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SetLogLevelValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetLogLevel.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetLogLevelValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetLogLevelValidationError{}
+
+var _SetLogLevel_Level_InLookup = map[string]struct{}{
+	"debug": {},
+	"info":  {},
+	"warn":  {},
+	"error": {},
+}
+
+var _SetLogLevel_GrpcLevel_InLookup = map[string]struct{}{
+	"":      {},
+	"debug": {},
+	"info":  {},
+	"warn":  {},
+	"error": {},
+}
+
+// Validate checks the field values on Profile with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Profile) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Profile with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in ProfileMultiError, or nil if none found.
+func (m *Profile) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Profile) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _Profile_Name_InLookup[m.GetName()]; !ok {
+		err := ProfileValidationError{
+			field:  "Name",
+			reason: "value must be in list [cpu goroutine heap allocs threadcreate block mutex]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetDebug() > 2 {
+		err := ProfileValidationError{
+			field:  "Debug",
+			reason: "value must be less than or equal to 2",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetDuration(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ProfileValidationError{
+				field:  "Duration",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			lte := time.Duration(300*time.Second + 0*time.Nanosecond)
+			gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur < gte || dur > lte {
+				err := ProfileValidationError{
+					field:  "Duration",
+					reason: "value must be inside range [0s, 5m0s]",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return ProfileMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProfileMultiError is an error wrapping multiple validation errors returned
+// by Profile.ValidateAll() if the designated constraints aren't met.
+type ProfileMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProfileMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProfileMultiError) AllErrors() []error { return m }
+
+// ProfileValidationError is the validation error returned by Profile.Validate
+// if the designated constraints aren't met.
+type ProfileValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProfileValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProfileValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProfileValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProfileValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProfileValidationError) ErrorName() string { return "ProfileValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProfileValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProfile.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProfileValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProfileValidationError{}
+
+var _Profile_Name_InLookup = map[string]struct{}{
+	"cpu":          {},
+	"goroutine":    {},
+	"heap":         {},
+	"allocs":       {},
+	"threadcreate": {},
+	"block":        {},
+	"mutex":        {},
+}
+
+// Validate checks the field values on Reconnect with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Reconnect) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Reconnect with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ReconnectMultiError, or nil
+// if none found.
+func (m *Reconnect) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Reconnect) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ReconnectMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReconnectMultiError is an error wrapping multiple validation errors returned
+// by Reconnect.ValidateAll() if the designated constraints aren't met.
+type ReconnectMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReconnectMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReconnectMultiError) AllErrors() []error { return m }
+
+// ReconnectValidationError is the validation error returned by
+// Reconnect.Validate if the designated constraints aren't met.
+type ReconnectValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReconnectValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReconnectValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReconnectValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReconnectValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReconnectValidationError) ErrorName() string { return "ReconnectValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReconnectValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReconnect.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReconnectValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReconnectValidationError{}
+
+// Validate checks the field values on RotateToken with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RotateToken) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RotateToken with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RotateTokenMultiError, or
+// nil if none found.
+func (m *RotateToken) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RotateToken) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetToken()) > 1024 {
+		err := RotateTokenValidationError{
+			field:  "Token",
+			reason: "value length must be at most 1024 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RotateTokenMultiError(errors)
+	}
+
+	return nil
+}
+
+// RotateTokenMultiError is an error wrapping multiple validation errors
+// returned by RotateToken.ValidateAll() if the designated constraints aren't met.
+type RotateTokenMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RotateTokenMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RotateTokenMultiError) AllErrors() []error { return m }
+
+// RotateTokenValidationError is the validation error returned by
+// RotateToken.Validate if the designated constraints aren't met.
+type RotateTokenValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RotateTokenValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RotateTokenValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RotateTokenValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RotateTokenValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RotateTokenValidationError) ErrorName() string { return "RotateTokenValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RotateTokenValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRotateToken.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RotateTokenValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RotateTokenValidationError{}
+
+// Validate checks the field values on GetDiagnostics with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetDiagnostics) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetDiagnostics with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetDiagnosticsMultiError,
+// or nil if none found.
+func (m *GetDiagnostics) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetDiagnostics) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetDiagnosticsMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetDiagnosticsMultiError is an error wrapping multiple validation errors
+// returned by GetDiagnostics.ValidateAll() if the designated constraints
+// aren't met.
+type GetDiagnosticsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetDiagnosticsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetDiagnosticsMultiError) AllErrors() []error { return m }
+
+// GetDiagnosticsValidationError is the validation error returned by
+// GetDiagnostics.Validate if the designated constraints aren't met.
+type GetDiagnosticsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetDiagnosticsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetDiagnosticsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetDiagnosticsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetDiagnosticsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetDiagnosticsValidationError) ErrorName() string { return "GetDiagnosticsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetDiagnosticsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetDiagnostics.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetDiagnosticsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetDiagnosticsValidationError{}
+
+// Validate checks the field values on Command with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Command) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Command with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in CommandMultiError, or nil if none found.
+func (m *Command) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Command) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofCommandPresent := false
+	switch v := m.Command.(type) {
+	case *Command_SetLogLevel:
+		if v == nil {
+			err := CommandValidationError{
+				field:  "Command",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofCommandPresent = true
+
+		if all {
+			switch v := interface{}(m.GetSetLogLevel()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "SetLogLevel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "SetLogLevel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSetLogLevel()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CommandValidationError{
+					field:  "SetLogLevel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Command_Profile:
+		if v == nil {
+			err := CommandValidationError{
+				field:  "Command",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofCommandPresent = true
+
+		if all {
+			switch v := interface{}(m.GetProfile()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "Profile",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "Profile",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetProfile()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CommandValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Command_Reconnect:
+		if v == nil {
+			err := CommandValidationError{
+				field:  "Command",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofCommandPresent = true
+
+		if all {
+			switch v := interface{}(m.GetReconnect()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "Reconnect",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "Reconnect",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetReconnect()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CommandValidationError{
+					field:  "Reconnect",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Command_RotateToken:
+		if v == nil {
+			err := CommandValidationError{
+				field:  "Command",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofCommandPresent = true
+
+		if all {
+			switch v := interface{}(m.GetRotateToken()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "RotateToken",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "RotateToken",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRotateToken()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CommandValidationError{
+					field:  "RotateToken",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Command_GetDiagnostics:
+		if v == nil {
+			err := CommandValidationError{
+				field:  "Command",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofCommandPresent = true
+
+		if all {
+			switch v := interface{}(m.GetGetDiagnostics()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "GetDiagnostics",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CommandValidationError{
+						field:  "GetDiagnostics",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetGetDiagnostics()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CommandValidationError{
+					field:  "GetDiagnostics",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofCommandPresent {
+		err := CommandValidationError{
+			field:  "Command",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CommandMultiError(errors)
+	}
+
+	return nil
+}
+
+// CommandMultiError is an error wrapping multiple validation errors returned
+// by Command.ValidateAll() if the designated constraints aren't met.
+type CommandMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CommandMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CommandMultiError) AllErrors() []error { return m }
+
+// CommandValidationError is the validation error returned by Command.Validate
+// if the designated constraints aren't met.
+type CommandValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CommandValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CommandValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CommandValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CommandValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CommandValidationError) ErrorName() string { return "CommandValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CommandValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCommand.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CommandValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CommandValidationError{}
+
+// Validate checks the field values on Diagnostics with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Diagnostics) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Diagnostics with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DiagnosticsMultiError, or
+// nil if none found.
+func (m *Diagnostics) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Diagnostics) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Version
+
+	// no validation rules for CommitId
+
+	// no validation rules for PodNamespace
+
+	// no validation rules for PodName
+
+	if all {
+		switch v := interface{}(m.GetStartedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DiagnosticsValidationError{
+					field:  "StartedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DiagnosticsValidationError{
+					field:  "StartedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DiagnosticsValidationError{
+				field:  "StartedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for LogLevel
+
+	// no validation rules for GrpcLogLevel
+
+	// no validation rules for Goroutines
+
+	// no validation rules for HeapAllocBytes
+
+	// no validation rules for SysBytes
+
+	// no validation rules for NumGc
+
+	if len(errors) > 0 {
+		return DiagnosticsMultiError(errors)
+	}
+
+	return nil
+}
+
+// DiagnosticsMultiError is an error wrapping multiple validation errors
+// returned by Diagnostics.ValidateAll() if the designated constraints aren't met.
+type DiagnosticsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DiagnosticsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DiagnosticsMultiError) AllErrors() []error { return m }
+
+// DiagnosticsValidationError is the validation error returned by
+// Diagnostics.Validate if the designated constraints aren't met.
+type DiagnosticsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DiagnosticsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DiagnosticsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DiagnosticsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DiagnosticsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DiagnosticsValidationError) ErrorName() string { return "DiagnosticsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DiagnosticsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDiagnostics.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DiagnosticsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DiagnosticsValidationError{}
+
+// Validate checks the field values on CommandOutput with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CommandOutput) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CommandOutput with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CommandOutputMultiError, or
+// nil if none found.
+func (m *CommandOutput) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CommandOutput) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofOutputPresent := false
+	switch v := m.Output.(type) {
+	case *CommandOutput_Data:
+		if v == nil {
+			err := CommandOutputValidationError{
+				field:  "Output",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofOutputPresent = true
+		// no validation rules for Data
+	case *CommandOutput_Diagnostics:
+		if v == nil {
+			err := CommandOutputValidationError{
+				field:  "Output",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofOutputPresent = true
+
+		if all {
+			switch v := interface{}(m.GetDiagnostics()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CommandOutputValidationError{
+						field:  "Diagnostics",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CommandOutputValidationError{
+						field:  "Diagnostics",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetDiagnostics()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CommandOutputValidationError{
+					field:  "Diagnostics",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *CommandOutput_Message:
+		if v == nil {
+			err := CommandOutputValidationError{
+				field:  "Output",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofOutputPresent = true
+		// no validation rules for Message
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofOutputPresent {
+		err := CommandOutputValidationError{
+			field:  "Output",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CommandOutputMultiError(errors)
+	}
+
+	return nil
+}
+
+// CommandOutputMultiError is an error wrapping multiple validation errors
+// returned by CommandOutput.ValidateAll() if the designated constraints
+// aren't met.
+type CommandOutputMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CommandOutputMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CommandOutputMultiError) AllErrors() []error { return m }
+
+// CommandOutputValidationError is the validation error returned by
+// CommandOutput.Validate if the designated constraints aren't met.
+type CommandOutputValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CommandOutputValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CommandOutputValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CommandOutputValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CommandOutputValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CommandOutputValidationError) ErrorName() string { return "CommandOutputValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CommandOutputValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCommandOutput.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CommandOutputValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CommandOutputValidationError{}
+
+// Validate checks the field values on ExecuteRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExecuteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExecuteRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExecuteRequestMultiError,
+// or nil if none found.
+func (m *ExecuteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExecuteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAgentId() <= 0 {
+		err := ExecuteRequestValidationError{
+			field:  "AgentId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetCommand() == nil {
+		err := ExecuteRequestValidationError{
+			field:  "Command",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetCommand()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ExecuteRequestValidationError{
+					field:  "Command",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ExecuteRequestValidationError{
+					field:  "Command",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCommand()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ExecuteRequestValidationError{
+				field:  "Command",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ExecuteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExecuteRequestMultiError is an error wrapping multiple validation errors
+// returned by ExecuteRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ExecuteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExecuteRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExecuteRequestMultiError) AllErrors() []error { return m }
+
+// ExecuteRequestValidationError is the validation error returned by
+// ExecuteRequest.Validate if the designated constraints aren't met.
+type ExecuteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExecuteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExecuteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExecuteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExecuteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExecuteRequestValidationError) ErrorName() string { return "ExecuteRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExecuteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExecuteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExecuteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExecuteRequestValidationError{}
@@ -0,0 +1,11 @@
+package rpc
+
+// Name returns the name of the command e.g. "set_log_level".
+func (x *Command) Name() string {
+	m := x.ProtoReflect()
+	f := m.WhichOneof(m.Descriptor().Oneofs().ByName("command"))
+	if f == nil {
+		return ""
+	}
+	return string(f.Name())
+}
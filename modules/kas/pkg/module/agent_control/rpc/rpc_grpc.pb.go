@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.31.1
+// source: pkg/module/agent_control/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AgentControl_Execute_FullMethodName = "/plural.agent.agent_control.rpc.AgentControl/Execute"
+)
+
+// AgentControlClient is the client API for AgentControl service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AgentControl is implemented by agentk. kas sends commands to it over the reverse tunnel.
+type AgentControlClient interface {
+	// Execute executes a command and streams its output back.
+	Execute(ctx context.Context, in *Command, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandOutput], error)
+}
+
+type agentControlClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAgentControlClient(cc grpc.ClientConnInterface) AgentControlClient {
+	return &agentControlClient{cc}
+}
+
+func (c *agentControlClient) Execute(ctx context.Context, in *Command, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandOutput], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentControl_ServiceDesc.Streams[0], AgentControl_Execute_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Command, CommandOutput]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentControl_ExecuteClient = grpc.ServerStreamingClient[CommandOutput]
+
+// AgentControlServer is the server API for AgentControl service.
+// All implementations must embed UnimplementedAgentControlServer
+// for forward compatibility.
+//
+// AgentControl is implemented by agentk. kas sends commands to it over the reverse tunnel.
+type AgentControlServer interface {
+	// Execute executes a command and streams its output back.
+	Execute(*Command, grpc.ServerStreamingServer[CommandOutput]) error
+	mustEmbedUnimplementedAgentControlServer()
+}
+
+// UnimplementedAgentControlServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAgentControlServer struct{}
+
+func (UnimplementedAgentControlServer) Execute(*Command, grpc.ServerStreamingServer[CommandOutput]) error {
+	return status.Error(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedAgentControlServer) mustEmbedUnimplementedAgentControlServer() {}
+func (UnimplementedAgentControlServer) testEmbeddedByValue()                      {}
+
+// UnsafeAgentControlServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AgentControlServer will
+// result in compilation errors.
+type UnsafeAgentControlServer interface {
+	mustEmbedUnimplementedAgentControlServer()
+}
+
+func RegisterAgentControlServer(s grpc.ServiceRegistrar, srv AgentControlServer) {
+	// If the following call panics, it indicates UnimplementedAgentControlServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AgentControl_ServiceDesc, srv)
+}
+
+func _AgentControl_Execute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Command)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentControlServer).Execute(m, &grpc.GenericServerStream[Command, CommandOutput]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentControl_ExecuteServer = grpc.ServerStreamingServer[CommandOutput]
+
+// AgentControl_ServiceDesc is the grpc.ServiceDesc for AgentControl service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AgentControl_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.agent_control.rpc.AgentControl",
+	HandlerType: (*AgentControlServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Execute",
+			Handler:       _AgentControl_Execute_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/module/agent_control/rpc/rpc.proto",
+}
+
+const (
+	AgentControlApi_Execute_FullMethodName = "/plural.agent.agent_control.rpc.AgentControlApi/Execute"
+)
+
+// AgentControlApiClient is the client API for AgentControlApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AgentControlApi is implemented by kas. It sends commands to one of the connected agentk instances of an agent.
+type AgentControlApiClient interface {
+	// Execute executes a command in agentk and streams its output back.
+	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandOutput], error)
+}
+
+type agentControlApiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAgentControlApiClient(cc grpc.ClientConnInterface) AgentControlApiClient {
+	return &agentControlApiClient{cc}
+}
+
+func (c *agentControlApiClient) Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandOutput], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentControlApi_ServiceDesc.Streams[0], AgentControlApi_Execute_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecuteRequest, CommandOutput]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentControlApi_ExecuteClient = grpc.ServerStreamingClient[CommandOutput]
+
+// AgentControlApiServer is the server API for AgentControlApi service.
+// All implementations must embed UnimplementedAgentControlApiServer
+// for forward compatibility.
+//
+// AgentControlApi is implemented by kas. It sends commands to one of the connected agentk instances of an agent.
+type AgentControlApiServer interface {
+	// Execute executes a command in agentk and streams its output back.
+	Execute(*ExecuteRequest, grpc.ServerStreamingServer[CommandOutput]) error
+	mustEmbedUnimplementedAgentControlApiServer()
+}
+
+// UnimplementedAgentControlApiServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAgentControlApiServer struct{}
+
+func (UnimplementedAgentControlApiServer) Execute(*ExecuteRequest, grpc.ServerStreamingServer[CommandOutput]) error {
+	return status.Error(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedAgentControlApiServer) mustEmbedUnimplementedAgentControlApiServer() {}
+func (UnimplementedAgentControlApiServer) testEmbeddedByValue()                         {}
+
+// UnsafeAgentControlApiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AgentControlApiServer will
+// result in compilation errors.
+type UnsafeAgentControlApiServer interface {
+	mustEmbedUnimplementedAgentControlApiServer()
+}
+
+func RegisterAgentControlApiServer(s grpc.ServiceRegistrar, srv AgentControlApiServer) {
+	// If the following call panics, it indicates UnimplementedAgentControlApiServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AgentControlApi_ServiceDesc, srv)
+}
+
+func _AgentControlApi_Execute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecuteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentControlApiServer).Execute(m, &grpc.GenericServerStream[ExecuteRequest, CommandOutput]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentControlApi_ExecuteServer = grpc.ServerStreamingServer[CommandOutput]
+
+// AgentControlApi_ServiceDesc is the grpc.ServiceDesc for AgentControlApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AgentControlApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.agent_control.rpc.AgentControlApi",
+	HandlerType: (*AgentControlApiServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Execute",
+			Handler:       _AgentControlApi_Execute_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/module/agent_control/rpc/rpc.proto",
+}
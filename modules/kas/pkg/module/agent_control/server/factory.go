@@ -0,0 +1,47 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/metric"
+)
+
+const (
+	commandsMetricName = "agent_control_commands_total"
+	commandLabel       = "command"
+	codeLabel          = "code"
+)
+
+type Factory struct {
+}
+
+func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
+	commands := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: commandsMetricName,
+		Help: "The total number of commands sent to agents by command and gRPC status code",
+	}, []string{commandLabel, codeLabel})
+	err := metric.Register(config.Registerer, commands)
+	if err != nil {
+		return nil, err
+	}
+	rpc.RegisterAgentControlApiServer(config.ApiServer, &server{
+		agentControlClient: rpc.NewAgentControlClient(config.AgentConn),
+		commands:           commands,
+	})
+	config.RegisterAgentApi(&rpc.AgentControl_ServiceDesc)
+	return &module{}, nil
+}
+
+func (f *Factory) Name() string {
+	return agent_control.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	// Start after servers because the module uses agent connection (config.AgentConn), which works by accessing
+	// in-memory private API server.
+	return modshared.ModuleStartAfterServers
+}
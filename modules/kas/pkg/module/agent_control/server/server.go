@@ -0,0 +1,58 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+type server struct {
+	rpc.UnimplementedAgentControlApiServer
+	agentControlClient rpc.AgentControlClient
+	commands           *prometheus.CounterVec
+}
+
+func (s *server) Execute(req *rpc.ExecuteRequest, stream rpc.AgentControlApi_ExecuteServer) error {
+	ctx := stream.Context()
+	rpcApi := modserver.RpcApiFromContext(ctx)
+	log := rpcApi.Log().With(logz.AgentId(req.AgentId), zap.String("command", req.Command.Name()))
+	// Commands change the state of agentk, log them for auditing.
+	log.Info("Sending command to agent")
+	err := s.execute(req, stream, log)
+	s.commands.WithLabelValues(req.Command.Name(), status.Code(err).String()).Inc()
+	return err
+}
+
+func (s *server) execute(req *rpc.ExecuteRequest, stream rpc.AgentControlApi_ExecuteServer, log *zap.Logger) error {
+	ctx := stream.Context()
+	rpcApi := modserver.RpcApiFromContext(ctx)
+	md := metadata.Pairs(modserver.RoutingAgentIdMetadataKey, strconv.FormatInt(req.AgentId, 10))
+	client, err := s.agentControlClient.Execute(metadata.NewOutgoingContext(ctx, md), req.Command)
+	if err != nil {
+		return err
+	}
+	for {
+		out, err := client.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			// Errors from agentk and from routing are gRPC status errors, pass them through.
+			log.Debug("Command failed", logz.Error(err))
+			return err
+		}
+		err = stream.Send(out)
+		if err != nil {
+			return rpcApi.HandleIoError(log, "Send() failed", err)
+		}
+	}
+}
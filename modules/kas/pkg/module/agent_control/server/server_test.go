@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/matcher"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_agent_control"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modserver"
+)
+
+var (
+	_ modserver.Module          = (*module)(nil)
+	_ modserver.Factory         = (*Factory)(nil)
+	_ rpc.AgentControlApiServer = (*server)(nil)
+)
+
+func TestExecute_RelaysOutput(t *testing.T) {
+	s, client, stream, ctrl := setupServer(t)
+	req := &rpc.ExecuteRequest{
+		AgentId: 123,
+		Command: &rpc.Command{
+			Command: &rpc.Command_Profile{
+				Profile: &rpc.Profile{Name: "heap"},
+			},
+		},
+	}
+	agentStream := mock_agent_control.NewMockAgentControl_ExecuteClient[rpc.CommandOutput](ctrl)
+	out1 := &rpc.CommandOutput{Output: &rpc.CommandOutput_Data{Data: []byte("1")}}
+	out2 := &rpc.CommandOutput{Output: &rpc.CommandOutput_Data{Data: []byte("2")}}
+	client.EXPECT().
+		Execute(gomock.Any(), matcher.ProtoEq(t, req.Command)).
+		DoAndReturn(func(ctx context.Context, cmd *rpc.Command, opts ...grpc.CallOption) (grpc.ServerStreamingClient[rpc.CommandOutput], error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Equal(t, []string{"123"}, md.Get(modserver.RoutingAgentIdMetadataKey))
+			return agentStream, nil
+		})
+	gomock.InOrder(
+		agentStream.EXPECT().Recv().Return(out1, nil),
+		stream.EXPECT().Send(out1),
+		agentStream.EXPECT().Recv().Return(out2, nil),
+		stream.EXPECT().Send(out2),
+		agentStream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+	err := s.Execute(req, stream)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, testutil.ToFloat64(s.commands.WithLabelValues("profile", "OK")))
+}
+
+func TestExecute_PassesThroughAgentError(t *testing.T) {
+	s, client, stream, ctrl := setupServer(t)
+	req := &rpc.ExecuteRequest{
+		AgentId: 123,
+		Command: &rpc.Command{
+			Command: &rpc.Command_RotateToken{
+				RotateToken: &rpc.RotateToken{},
+			},
+		},
+	}
+	agentStream := mock_agent_control.NewMockAgentControl_ExecuteClient[rpc.CommandOutput](ctrl)
+	client.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		Return(agentStream, nil)
+	agentStream.EXPECT().
+		Recv().
+		Return(nil, status.Error(codes.Unimplemented, "token rotation is not supported"))
+	err := s.Execute(req, stream)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.EqualValues(t, 1, testutil.ToFloat64(s.commands.WithLabelValues("rotate_token", "Unimplemented")))
+}
+
+func setupServer(t *testing.T) (*server, *mock_agent_control.MockAgentControlClient,
+	*mock_agent_control.MockAgentControlApi_ExecuteServer[rpc.CommandOutput], *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	rpcApi := mock_modserver.NewMockRpcApi(ctrl)
+	rpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t)).
+		AnyTimes()
+	client := mock_agent_control.NewMockAgentControlClient(ctrl)
+	stream := mock_agent_control.NewMockAgentControlApi_ExecuteServer[rpc.CommandOutput](ctrl)
+	stream.EXPECT().
+		Context().
+		Return(modserver.InjectRpcApi(context.Background(), rpcApi)).
+		AnyTimes()
+	s := &server{
+		agentControlClient: client,
+		commands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: commandsMetricName,
+		}, []string{commandLabel, codeLabel}),
+	}
+	return s, client, stream, ctrl
+}
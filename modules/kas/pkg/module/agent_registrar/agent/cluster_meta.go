@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+)
+
+const (
+	cniNamespace = metav1.NamespaceSystem
+)
+
+// cniDaemonSets maps name prefixes of DaemonSets that CNI plugins run to plugin names.
+var cniDaemonSets = []struct {
+	prefix string
+	cni    string
+}{
+	{prefix: "calico-node", cni: "calico"},
+	{prefix: "cilium", cni: "cilium"},
+	{prefix: "kube-flannel", cni: "flannel"},
+	{prefix: "aws-node", cni: "aws-vpc-cni"},
+	{prefix: "azure-cns", cni: "azure-cni"},
+	{prefix: "weave-net", cni: "weave"},
+	{prefix: "antrea-agent", cni: "antrea"},
+	{prefix: "kube-router", cni: "kube-router"},
+}
+
+// clusterMeta collects information about the cluster.
+// It returns what it managed to collect and the errors it encountered, if any.
+func clusterMeta(ctx context.Context, client kubernetes.Interface) (*entity.ClusterMeta, error) {
+	meta := &entity.ClusterMeta{}
+	var errs []error
+	// Zero resource version allows the API server to serve the list from its cache.
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+	if err == nil {
+		meta.NodeCount = int64(len(nodes.Items))
+		if len(nodes.Items) > 0 {
+			node := &nodes.Items[0]
+			meta.CloudProvider = cloudProvider(node)
+			meta.Region = node.Labels[corev1.LabelTopologyRegion]
+		}
+	} else {
+		errs = append(errs, err)
+	}
+	daemonSets, err := client.AppsV1().DaemonSets(cniNamespace).List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+	if err == nil {
+		meta.Cni = cni(daemonSets.Items)
+	} else {
+		errs = append(errs, err)
+	}
+	return meta, errors.Join(errs...)
+}
+
+// cni returns the name of the CNI plugin that runs one of the DaemonSets.
+func cni(daemonSets []appsv1.DaemonSet) string {
+	for _, ds := range daemonSets {
+		for _, c := range cniDaemonSets {
+			if strings.HasPrefix(ds.Name, c.prefix) {
+				return c.cni
+			}
+		}
+	}
+	return ""
+}
+
+// cloudProvider returns the scheme of the node's provider id e.g. "aws" for "aws:///us-east-1a/i-0123".
+func cloudProvider(node *corev1.Node) string {
+	provider, _, found := strings.Cut(node.Spec.ProviderID, "://")
+	if !found {
+		return ""
+	}
+	return provider
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+)
+
+func TestClusterMeta(t *testing.T) {
+	node := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					corev1.LabelTopologyRegion: "europe-west1",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				ProviderID: "gce://project/europe-west1-b/" + name,
+			},
+		}
+	}
+	client := fake.NewClientset(
+		node("n1"),
+		node("n2"),
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kube-proxy",
+				Namespace: metav1.NamespaceSystem,
+			},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cilium",
+				Namespace: metav1.NamespaceSystem,
+			},
+		},
+	)
+	meta, err := clusterMeta(context.Background(), client)
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(&entity.ClusterMeta{
+		NodeCount:     2,
+		CloudProvider: "gce",
+		Cni:           "cilium",
+		Region:        "europe-west1",
+	}, meta, protocmp.Transform()))
+}
+
+func TestClusterMeta_Empty(t *testing.T) {
+	meta, err := clusterMeta(context.Background(), fake.NewClientset())
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(&entity.ClusterMeta{}, meta, protocmp.Transform()))
+}
@@ -18,6 +18,9 @@ const (
 	registerResetDuration   = 10 * time.Minute
 	registerBackoffFactor   = 2.0
 	registerJitter          = 1.0
+	unregisterTimeout       = 5 * time.Second
+
+	versionSkewEventReason = "VersionSkew"
 )
 
 type Factory struct {
@@ -51,6 +54,8 @@ func (f *Factory) New(config *modagent.Config) (modagent.Module, error) {
 		)),
 		Client:      rpc.NewAgentRegistrarClient(config.KasConn),
 		KubeVersion: kubeClientset.Discovery(),
+		KubeClient:  kubeClientset,
+		Recorder:    config.EventRecorder,
 	}
 	return m, nil
 }
@@ -7,13 +7,17 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/entity"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar"
 	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
 
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 type module struct {
@@ -23,11 +27,14 @@ type module struct {
 	PollConfig  retry.PollConfigFactory
 	Client      rpc2.AgentRegistrarClient
 	KubeVersion discovery.ServerVersionInterface
+	KubeClient  kubernetes.Interface
+	Recorder    record.EventRecorder
 }
 
 func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
 	// Create a deep copy of agentMeta to prevent unexpected mutations
 	agentMeta := proto.Clone(m.AgentMeta).(*entity.AgentMeta)
+	lastSkew := entity.VersionSkew_version_skew_unknown
 
 	_ = retry.PollWithBackoff(ctx, m.PollConfig(), func(ctx context.Context) (error, retry.AttemptResult) { // nolint:staticcheck
 		// Retrieve and set the Kubernetes version
@@ -41,9 +48,15 @@ func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguratio
 			m.Log.Warn("Failed to fetch Kubernetes version", logz.Error(err))
 		}
 
-		_, err = m.Client.Register(ctx, &rpc2.RegisterRequest{
-			AgentMeta: agentMeta,
-			PodId:     m.PodId,
+		clusterMeta, err := clusterMeta(ctx, m.KubeClient)
+		if err != nil {
+			m.Log.Warn("Failed to collect cluster information", logz.Error(err))
+		}
+
+		resp, err := m.Client.Register(ctx, &rpc2.RegisterRequest{
+			AgentMeta:   agentMeta,
+			PodId:       m.PodId,
+			ClusterMeta: clusterMeta,
 		})
 		if err != nil {
 			if !grpctool.RequestCanceledOrTimedOut(err) {
@@ -51,12 +64,47 @@ func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguratio
 			}
 			return nil, retry.Backoff
 		}
+		verdict := resp.GetVersionSkew()
+		if verdict.GetSkew() != lastSkew {
+			lastSkew = verdict.GetSkew()
+			m.reportVersionSkew(verdict)
+		}
 
 		return nil, retry.Continue
 	})
+	m.unregister()
 	return nil
 }
 
+// reportVersionSkew surfaces an unsupported agentk version as a Kubernetes event on the agentk Pod.
+func (m *module) reportVersionSkew(verdict *entity.VersionSkewVerdict) {
+	switch verdict.GetSkew() {
+	case entity.VersionSkew_agent_too_old, entity.VersionSkew_agent_too_new:
+	default:
+		return
+	}
+	m.Log.Warn("Agent version is not supported by the server", zap.String("reason", verdict.Message))
+	pod := &corev1.ObjectReference{
+		Kind:      "Pod",
+		Namespace: m.AgentMeta.PodNamespace,
+		Name:      m.AgentMeta.PodName,
+	}
+	m.Recorder.Event(pod, corev1.EventTypeWarning, versionSkewEventReason, verdict.Message)
+}
+
+// unregister tells kas that this agentk pod is shutting down so that it is recorded in the connection history.
+func (m *module) unregister() {
+	ctx, cancel := context.WithTimeout(context.Background(), unregisterTimeout)
+	defer cancel()
+	_, err := m.Client.Unregister(ctx, &rpc2.UnregisterRequest{
+		PodId:  m.PodId,
+		Reason: agent_tracker.DisconnectReason_agent_shutdown,
+	})
+	if err != nil {
+		m.Log.Debug("Failed to unregister agent pod", logz.Error(err))
+	}
+}
+
 func (m *module) DefaultAndValidateConfiguration(config *agentcfg.AgentConfiguration) error {
 	return nil
 }
@@ -6,36 +6,110 @@ import (
 
 	"github.com/pluralsh/kubernetes-agent/pkg/entity"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/mathz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/matcher"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_agent_registrar"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/testhelpers"
 
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 func TestModule_Run(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	podId := mathz.Int63()
 	ctrl := gomock.NewController(t)
 	client := mock_agent_registrar.NewMockAgentRegistrarClient(ctrl)
 	client.EXPECT().
 		Register(gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(ctx context.Context, request *rpc.RegisterRequest, opts ...grpc.CallOption) (*rpc.RegisterResponse, error) {
+			assert.EqualValues(t, 1, request.ClusterMeta.NodeCount)
+			assert.Equal(t, "aws", request.ClusterMeta.CloudProvider)
 			cancel()
 			return &rpc.RegisterResponse{}, nil
 		})
+	client.EXPECT().
+		Unregister(gomock.Any(), matcher.ProtoEq(t, &rpc.UnregisterRequest{
+			PodId:  podId,
+			Reason: agent_tracker.DisconnectReason_agent_shutdown,
+		}), gomock.Any())
+
+	kubeClient := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: "aws:///us-east-1a/i-0123",
+		},
+	})
 
 	m := &module{
 		Log:         zaptest.NewLogger(t),
 		AgentMeta:   &entity.AgentMeta{KubernetesVersion: &entity.KubernetesVersion{}},
-		PodId:       mathz.Int63(),
+		PodId:       podId,
+		PollConfig:  testhelpers.NewPollConfig(0),
+		Client:      client,
+		KubeVersion: kubeClient.Discovery(),
+		KubeClient:  kubeClient,
+		Recorder:    record.NewFakeRecorder(1),
+	}
+	_ = m.Run(ctx, nil)
+}
+
+func TestModule_Run_VersionSkewEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tooOld := &entity.VersionSkewVerdict{
+		Skew:    entity.VersionSkew_agent_too_old,
+		Message: "agentk v1.0.0 is too old",
+	}
+	ctrl := gomock.NewController(t)
+	client := mock_agent_registrar.NewMockAgentRegistrarClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().
+			Register(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&rpc.RegisterResponse{VersionSkew: tooOld}, nil),
+		client.EXPECT().
+			Register(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, request *rpc.RegisterRequest, opts ...grpc.CallOption) (*rpc.RegisterResponse, error) {
+				cancel()
+				// Same verdict is not reported again
+				return &rpc.RegisterResponse{VersionSkew: tooOld}, nil
+			}),
+	)
+	client.EXPECT().
+		Unregister(gomock.Any(), gomock.Any(), gomock.Any())
+
+	kubeClient := fake.NewClientset()
+	recorder := record.NewFakeRecorder(2)
+	m := &module{
+		Log: zaptest.NewLogger(t),
+		AgentMeta: &entity.AgentMeta{
+			PodNamespace:      "ns",
+			PodName:           "agentk",
+			KubernetesVersion: &entity.KubernetesVersion{},
+		},
 		PollConfig:  testhelpers.NewPollConfig(0),
 		Client:      client,
-		KubeVersion: fake.NewSimpleClientset().Discovery(),
+		KubeVersion: kubeClient.Discovery(),
+		KubeClient:  kubeClient,
+		Recorder:    recorder,
 	}
 	_ = m.Run(ctx, nil)
+	close(recorder.Events)
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	assert.Equal(t, []string{"Warning VersionSkew agentk v1.0.0 is too old"}, events)
 }
@@ -9,7 +9,9 @@
 package rpc
 
 import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	entity "github.com/pluralsh/kubernetes-agent/pkg/entity"
+	agent_tracker "github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -29,7 +31,9 @@ type RegisterRequest struct {
 	AgentMeta *entity.AgentMeta      `protobuf:"bytes,1,opt,name=agent_meta,json=agentMeta,proto3" json:"agent_meta,omitempty"`
 	// Uniquely identifies a particular agentk pods.
 	// Randomly generated when an agentk pod starts working.
-	PodId         int64 `protobuf:"varint,2,opt,name=pod_id,json=podId,proto3" json:"pod_id,omitempty"`
+	PodId int64 `protobuf:"varint,2,opt,name=pod_id,json=podId,proto3" json:"pod_id,omitempty"`
+	// Information about the cluster agentk runs in.
+	ClusterMeta   *entity.ClusterMeta `protobuf:"bytes,3,opt,name=cluster_meta,json=clusterMeta,proto3" json:"cluster_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -78,8 +82,17 @@ func (x *RegisterRequest) GetPodId() int64 {
 	return 0
 }
 
+func (x *RegisterRequest) GetClusterMeta() *entity.ClusterMeta {
+	if x != nil {
+		return x.ClusterMeta
+	}
+	return nil
+}
+
 type RegisterResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// What kas decided about the version of agentk. Not set by older kas versions.
+	VersionSkew   *entity.VersionSkewVerdict `protobuf:"bytes,1,opt,name=version_skew,json=versionSkew,proto3" json:"version_skew,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -114,18 +127,123 @@ func (*RegisterResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_module_agent_registrar_rpc_rpc_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *RegisterResponse) GetVersionSkew() *entity.VersionSkewVerdict {
+	if x != nil {
+		return x.VersionSkew
+	}
+	return nil
+}
+
+type UnregisterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same as RegisterRequest.pod_id.
+	PodId int64 `protobuf:"varint,1,opt,name=pod_id,json=podId,proto3" json:"pod_id,omitempty"`
+	// Why agentk is disconnecting.
+	Reason        agent_tracker.DisconnectReason `protobuf:"varint,2,opt,name=reason,proto3,enum=plural.agent.agent_tracker.DisconnectReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterRequest) Reset() {
+	*x = UnregisterRequest{}
+	mi := &file_pkg_module_agent_registrar_rpc_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterRequest) ProtoMessage() {}
+
+func (x *UnregisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_registrar_rpc_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_registrar_rpc_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UnregisterRequest) GetPodId() int64 {
+	if x != nil {
+		return x.PodId
+	}
+	return 0
+}
+
+func (x *UnregisterRequest) GetReason() agent_tracker.DisconnectReason {
+	if x != nil {
+		return x.Reason
+	}
+	return agent_tracker.DisconnectReason(0)
+}
+
+type UnregisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterResponse) Reset() {
+	*x = UnregisterResponse{}
+	mi := &file_pkg_module_agent_registrar_rpc_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterResponse) ProtoMessage() {}
+
+func (x *UnregisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_registrar_rpc_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterResponse.ProtoReflect.Descriptor instead.
+func (*UnregisterResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_registrar_rpc_rpc_proto_rawDescGZIP(), []int{3}
+}
+
 var File_pkg_module_agent_registrar_rpc_rpc_proto protoreflect.FileDescriptor
 
 const file_pkg_module_agent_registrar_rpc_rpc_proto_rawDesc = "" +
 	"\n" +
-	"(pkg/module/agent_registrar/rpc/rpc.proto\x12 plural.agent.agent_registrar.rpc\x1a\x17pkg/entity/entity.proto\"g\n" +
+	"(pkg/module/agent_registrar/rpc/rpc.proto\x12 plural.agent.agent_registrar.rpc\x1a\x17pkg/entity/entity.proto\x1a,pkg/module/agent_tracker/agent_tracker.proto\x1a\x17validate/validate.proto\"\xac\x01\n" +
 	"\x0fRegisterRequest\x12=\n" +
 	"\n" +
 	"agent_meta\x18\x01 \x01(\v2\x1e.plural.agent.entity.AgentMetaR\tagentMeta\x12\x15\n" +
-	"\x06pod_id\x18\x02 \x01(\x03R\x05podId\"\x12\n" +
-	"\x10RegisterResponse2\x85\x01\n" +
+	"\x06pod_id\x18\x02 \x01(\x03R\x05podId\x12C\n" +
+	"\fcluster_meta\x18\x03 \x01(\v2 .plural.agent.entity.ClusterMetaR\vclusterMeta\"^\n" +
+	"\x10RegisterResponse\x12J\n" +
+	"\fversion_skew\x18\x01 \x01(\v2'.plural.agent.entity.VersionSkewVerdictR\vversionSkew\"z\n" +
+	"\x11UnregisterRequest\x12\x15\n" +
+	"\x06pod_id\x18\x01 \x01(\x03R\x05podId\x12N\n" +
+	"\x06reason\x18\x02 \x01(\x0e2,.plural.agent.agent_tracker.DisconnectReasonB\b\xfaB\x05\x82\x01\x02\x10\x01R\x06reason\"\x14\n" +
+	"\x12UnregisterResponse2\x80\x02\n" +
 	"\x0eAgentRegistrar\x12s\n" +
-	"\bRegister\x121.plural.agent.agent_registrar.rpc.RegisterRequest\x1a2.plural.agent.agent_registrar.rpc.RegisterResponse\"\x00BEZCgithub.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpcb\x06proto3"
+	"\bRegister\x121.plural.agent.agent_registrar.rpc.RegisterRequest\x1a2.plural.agent.agent_registrar.rpc.RegisterResponse\"\x00\x12y\n" +
+	"\n" +
+	"Unregister\x123.plural.agent.agent_registrar.rpc.UnregisterRequest\x1a4.plural.agent.agent_registrar.rpc.UnregisterResponse\"\x00BEZCgithub.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpcb\x06proto3"
 
 var (
 	file_pkg_module_agent_registrar_rpc_rpc_proto_rawDescOnce sync.Once
@@ -139,21 +257,31 @@ func file_pkg_module_agent_registrar_rpc_rpc_proto_rawDescGZIP() []byte {
 	return file_pkg_module_agent_registrar_rpc_rpc_proto_rawDescData
 }
 
-var file_pkg_module_agent_registrar_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_module_agent_registrar_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_pkg_module_agent_registrar_rpc_rpc_proto_goTypes = []any{
-	(*RegisterRequest)(nil),  // 0: plural.agent.agent_registrar.rpc.RegisterRequest
-	(*RegisterResponse)(nil), // 1: plural.agent.agent_registrar.rpc.RegisterResponse
-	(*entity.AgentMeta)(nil), // 2: plural.agent.entity.AgentMeta
+	(*RegisterRequest)(nil),             // 0: plural.agent.agent_registrar.rpc.RegisterRequest
+	(*RegisterResponse)(nil),            // 1: plural.agent.agent_registrar.rpc.RegisterResponse
+	(*UnregisterRequest)(nil),           // 2: plural.agent.agent_registrar.rpc.UnregisterRequest
+	(*UnregisterResponse)(nil),          // 3: plural.agent.agent_registrar.rpc.UnregisterResponse
+	(*entity.AgentMeta)(nil),            // 4: plural.agent.entity.AgentMeta
+	(*entity.ClusterMeta)(nil),          // 5: plural.agent.entity.ClusterMeta
+	(*entity.VersionSkewVerdict)(nil),   // 6: plural.agent.entity.VersionSkewVerdict
+	(agent_tracker.DisconnectReason)(0), // 7: plural.agent.agent_tracker.DisconnectReason
 }
 var file_pkg_module_agent_registrar_rpc_rpc_proto_depIdxs = []int32{
-	2, // 0: plural.agent.agent_registrar.rpc.RegisterRequest.agent_meta:type_name -> plural.agent.entity.AgentMeta
-	0, // 1: plural.agent.agent_registrar.rpc.AgentRegistrar.Register:input_type -> plural.agent.agent_registrar.rpc.RegisterRequest
-	1, // 2: plural.agent.agent_registrar.rpc.AgentRegistrar.Register:output_type -> plural.agent.agent_registrar.rpc.RegisterResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 0: plural.agent.agent_registrar.rpc.RegisterRequest.agent_meta:type_name -> plural.agent.entity.AgentMeta
+	5, // 1: plural.agent.agent_registrar.rpc.RegisterRequest.cluster_meta:type_name -> plural.agent.entity.ClusterMeta
+	6, // 2: plural.agent.agent_registrar.rpc.RegisterResponse.version_skew:type_name -> plural.agent.entity.VersionSkewVerdict
+	7, // 3: plural.agent.agent_registrar.rpc.UnregisterRequest.reason:type_name -> plural.agent.agent_tracker.DisconnectReason
+	0, // 4: plural.agent.agent_registrar.rpc.AgentRegistrar.Register:input_type -> plural.agent.agent_registrar.rpc.RegisterRequest
+	2, // 5: plural.agent.agent_registrar.rpc.AgentRegistrar.Unregister:input_type -> plural.agent.agent_registrar.rpc.UnregisterRequest
+	1, // 6: plural.agent.agent_registrar.rpc.AgentRegistrar.Register:output_type -> plural.agent.agent_registrar.rpc.RegisterResponse
+	3, // 7: plural.agent.agent_registrar.rpc.AgentRegistrar.Unregister:output_type -> plural.agent.agent_registrar.rpc.UnregisterResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_pkg_module_agent_registrar_rpc_rpc_proto_init() }
@@ -167,7 +295,7 @@ func file_pkg_module_agent_registrar_rpc_rpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_agent_registrar_rpc_rpc_proto_rawDesc), len(file_pkg_module_agent_registrar_rpc_rpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -17,6 +17,8 @@ import (
 	"unicode/utf8"
 
 	"google.golang.org/protobuf/types/known/anypb"
+
+	agent_tracker "github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 )
 
 // ensure the imports are used
@@ -33,6 +35,8 @@ var (
 	_ = (*mail.Address)(nil)
 	_ = anypb.Any{}
 	_ = sort.Sort
+
+	_ = agent_tracker.DisconnectReason(0)
 )
 
 // Validate checks the field values on RegisterRequest with the rules defined
@@ -88,6 +92,35 @@ func (m *RegisterRequest) validate(all bool) error {
 
 	// no validation rules for PodId
 
+	if all {
+		switch v := interface{}(m.GetClusterMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RegisterRequestValidationError{
+					field:  "ClusterMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RegisterRequestValidationError{
+					field:  "ClusterMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClusterMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RegisterRequestValidationError{
+				field:  "ClusterMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return RegisterRequestMultiError(errors)
 	}
@@ -188,6 +221,35 @@ func (m *RegisterResponse) validate(all bool) error {
 
 	var errors []error
 
+	if all {
+		switch v := interface{}(m.GetVersionSkew()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RegisterResponseValidationError{
+					field:  "VersionSkew",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RegisterResponseValidationError{
+					field:  "VersionSkew",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetVersionSkew()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RegisterResponseValidationError{
+				field:  "VersionSkew",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return RegisterResponseMultiError(errors)
 	}
@@ -265,3 +327,220 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = RegisterResponseValidationError{}
+
+// Validate checks the field values on UnregisterRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UnregisterRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnregisterRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnregisterRequestMultiError, or nil if none found.
+func (m *UnregisterRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnregisterRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for PodId
+
+	if _, ok := agent_tracker.DisconnectReason_name[int32(m.GetReason())]; !ok {
+		err := UnregisterRequestValidationError{
+			field:  "Reason",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UnregisterRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UnregisterRequestMultiError is an error wrapping multiple validation errors
+// returned by UnregisterRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UnregisterRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnregisterRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnregisterRequestMultiError) AllErrors() []error { return m }
+
+// UnregisterRequestValidationError is the validation error returned by
+// UnregisterRequest.Validate if the designated constraints aren't met.
+type UnregisterRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnregisterRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnregisterRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnregisterRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnregisterRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnregisterRequestValidationError) ErrorName() string {
+	return "UnregisterRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnregisterRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnregisterRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnregisterRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnregisterRequestValidationError{}
+
+// Validate checks the field values on UnregisterResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UnregisterResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnregisterResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnregisterResponseMultiError, or nil if none found.
+func (m *UnregisterResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnregisterResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return UnregisterResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UnregisterResponseMultiError is an error wrapping multiple validation errors
+// returned by UnregisterResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UnregisterResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnregisterResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnregisterResponseMultiError) AllErrors() []error { return m }
+
+// UnregisterResponseValidationError is the validation error returned by
+// UnregisterResponse.Validate if the designated constraints aren't met.
+type UnregisterResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnregisterResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnregisterResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnregisterResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnregisterResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnregisterResponseValidationError) ErrorName() string {
+	return "UnregisterResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnregisterResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnregisterResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnregisterResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnregisterResponseValidationError{}
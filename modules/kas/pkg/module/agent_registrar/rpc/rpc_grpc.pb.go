@@ -21,7 +21,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AgentRegistrar_Register_FullMethodName = "/plural.agent.agent_registrar.rpc.AgentRegistrar/Register"
+	AgentRegistrar_Register_FullMethodName   = "/plural.agent.agent_registrar.rpc.AgentRegistrar/Register"
+	AgentRegistrar_Unregister_FullMethodName = "/plural.agent.agent_registrar.rpc.AgentRegistrar/Unregister"
 )
 
 // AgentRegistrarClient is the client API for AgentRegistrar service.
@@ -30,6 +31,8 @@ const (
 type AgentRegistrarClient interface {
 	// Register a new agent.
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	// Unregister an agent that is disconnecting.
+	Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*UnregisterResponse, error)
 }
 
 type agentRegistrarClient struct {
@@ -50,12 +53,24 @@ func (c *agentRegistrarClient) Register(ctx context.Context, in *RegisterRequest
 	return out, nil
 }
 
+func (c *agentRegistrarClient) Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*UnregisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnregisterResponse)
+	err := c.cc.Invoke(ctx, AgentRegistrar_Unregister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentRegistrarServer is the server API for AgentRegistrar service.
 // All implementations must embed UnimplementedAgentRegistrarServer
 // for forward compatibility.
 type AgentRegistrarServer interface {
 	// Register a new agent.
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// Unregister an agent that is disconnecting.
+	Unregister(context.Context, *UnregisterRequest) (*UnregisterResponse, error)
 	mustEmbedUnimplementedAgentRegistrarServer()
 }
 
@@ -69,6 +84,9 @@ type UnimplementedAgentRegistrarServer struct{}
 func (UnimplementedAgentRegistrarServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedAgentRegistrarServer) Unregister(context.Context, *UnregisterRequest) (*UnregisterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Unregister not implemented")
+}
 func (UnimplementedAgentRegistrarServer) mustEmbedUnimplementedAgentRegistrarServer() {}
 func (UnimplementedAgentRegistrarServer) testEmbeddedByValue()                        {}
 
@@ -108,6 +126,24 @@ func _AgentRegistrar_Register_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentRegistrar_Unregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentRegistrarServer).Unregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentRegistrar_Unregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentRegistrarServer).Unregister(ctx, req.(*UnregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentRegistrar_ServiceDesc is the grpc.ServiceDesc for AgentRegistrar service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -119,6 +155,10 @@ var AgentRegistrar_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _AgentRegistrar_Register_Handler,
 		},
+		{
+			MethodName: "Unregister",
+			Handler:    _AgentRegistrar_Unregister_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/module/agent_registrar/rpc/rpc.proto",
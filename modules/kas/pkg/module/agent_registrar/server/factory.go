@@ -1,20 +1,40 @@
 package server
 
 import (
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/metric"
+)
+
+const (
+	registrationsMetricName = "agent_registrations_total"
+	versionSkewLabel        = "version_skew"
 )
 
 type Factory struct {
-	AgentRegisterer agent_tracker.Registerer
+	AgentRegisterer   agent_tracker.Registerer
+	VersionSkewPolicy *agent_registrar.VersionSkewPolicy
 }
 
 func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
+	registrations := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: registrationsMetricName,
+		Help: "The total number of agent registrations by how the agent version relates to the versions kas supports",
+	}, []string{versionSkewLabel})
+	err := metric.Register(config.Registerer, registrations)
+	if err != nil {
+		return nil, err
+	}
+
 	rpc.RegisterAgentRegistrarServer(config.AgentServer, &server{
-		agentRegisterer: f.AgentRegisterer,
+		agentRegisterer:   f.AgentRegisterer,
+		versionSkewPolicy: f.VersionSkewPolicy,
+		registrations:     registrations,
 	})
 
 	return &module{}, nil
@@ -3,11 +3,14 @@ package server
 import (
 	"context"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar"
 	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpc"
 	agent_tracker2 "github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
@@ -15,7 +18,9 @@ import (
 
 type server struct {
 	rpc2.UnimplementedAgentRegistrarServer
-	agentRegisterer agent_tracker2.Registerer
+	agentRegisterer   agent_tracker2.Registerer
+	versionSkewPolicy *agent_registrar.VersionSkewPolicy
+	registrations     *prometheus.CounterVec
 }
 
 func (s *server) Register(ctx context.Context, req *rpc2.RegisterRequest) (*rpc2.RegisterResponse, error) {
@@ -28,12 +33,17 @@ func (s *server) Register(ctx context.Context, req *rpc2.RegisterRequest) (*rpc2
 		return nil, err
 	}
 
+	verdict := s.versionSkewPolicy.Verdict(req.AgentMeta.GetVersion())
+	s.registrations.WithLabelValues(verdict.Skew.String()).Inc()
+
 	connectedAgentInfo := &agent_tracker2.ConnectedAgentInfo{
 		AgentMeta:    req.AgentMeta,
 		ConnectedAt:  timestamppb.Now(),
 		ConnectionId: req.PodId,
 		AgentId:      agentInfo.Id,
 		ClusterId:    agentInfo.ClusterId,
+		ClusterMeta:  req.ClusterMeta,
+		VersionSkew:  verdict.Skew,
 	}
 
 	// Register agent
@@ -44,5 +54,38 @@ func (s *server) Register(ctx context.Context, req *rpc2.RegisterRequest) (*rpc2
 	}
 
 	log.Info("Successfully registered agent", zap.String("name", agentInfo.Name), zap.Int64("id", agentInfo.Id))
-	return &rpc2.RegisterResponse{}, nil
+	if verdict.Skew == entity.VersionSkew_agent_too_old || verdict.Skew == entity.VersionSkew_agent_too_new {
+		log.Warn("Agent version is not supported", zap.Int64("id", agentInfo.Id), zap.String("reason", verdict.Message))
+	}
+	return &rpc2.RegisterResponse{
+		VersionSkew: verdict,
+	}, nil
+}
+
+func (s *server) Unregister(ctx context.Context, req *rpc2.UnregisterRequest) (*rpc2.UnregisterResponse, error) {
+	rpcApi := modserver.AgentRpcApiFromContext(ctx)
+	log := rpcApi.Log()
+
+	// Get agent info
+	agentInfo, err := rpcApi.AgentInfo(ctx, log)
+	if err != nil {
+		return nil, err
+	}
+
+	connectedAgentInfo := &agent_tracker2.ConnectedAgentInfo{
+		ConnectionId: req.PodId,
+		AgentId:      agentInfo.Id,
+		ClusterId:    agentInfo.ClusterId,
+	}
+
+	// Unregister agent
+	err = s.agentRegisterer.UnregisterConnection(ctx, connectedAgentInfo, req.Reason)
+	if err != nil {
+		rpcApi.HandleProcessingError(log, agentInfo.Id, "Failed to unregister agent", err)
+		return nil, status.Error(codes.Unavailable, "Failed to unregister agent")
+	}
+
+	log.Info("Successfully unregistered agent", zap.String("name", agentInfo.Name), zap.Int64("id", agentInfo.Id),
+		zap.Stringer("reason", req.Reason))
+	return &rpc2.UnregisterResponse{}, nil
 }
@@ -5,13 +5,19 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_registrar/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
@@ -34,12 +40,39 @@ func TestRegister(t *testing.T) {
 			assert.EqualValues(t, 123, connectedAgentInfo.AgentId)
 			assert.EqualValues(t, "456", connectedAgentInfo.ClusterId)
 			assert.EqualValues(t, 123456789, connectedAgentInfo.ConnectionId)
+			assert.Equal(t, entity.VersionSkew_supported, connectedAgentInfo.VersionSkew)
 			return nil
 		})
 
 	resp, err := s.Register(ctx, req)
-	assert.NotNil(t, resp)
-	assert.NoError(t, err)
+	require.NoError(t, err)
+	assert.Equal(t, entity.VersionSkew_supported, resp.VersionSkew.Skew)
+	assert.EqualValues(t, 1, testutil.ToFloat64(s.registrations.WithLabelValues("supported")))
+}
+
+func TestRegister_UnsupportedVersion(t *testing.T) {
+	mockRpcApi, mockAgentTracker, s, req, ctx := setupServer(t)
+	req.AgentMeta.Version = "v1.0.0"
+
+	mockRpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t))
+	mockRpcApi.EXPECT().
+		AgentInfo(gomock.Any(), gomock.Any()).
+		Return(&api.AgentInfo{Id: 123, ClusterId: "456"}, nil)
+	mockAgentTracker.EXPECT().
+		RegisterConnection(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, connectedAgentInfo *agent_tracker.ConnectedAgentInfo) error {
+			assert.Equal(t, entity.VersionSkew_agent_too_old, connectedAgentInfo.VersionSkew)
+			return nil
+		})
+
+	resp, err := s.Register(ctx, req)
+	require.NoError(t, err) // registration is never refused
+	assert.Equal(t, entity.VersionSkew_agent_too_old, resp.VersionSkew.Skew)
+	assert.Equal(t, kascfg.VersionSkewPolicyWarn, resp.VersionSkew.Action)
+	assert.Equal(t, "v1.3.0", resp.VersionSkew.KasVersion)
+	assert.EqualValues(t, 1, testutil.ToFloat64(s.registrations.WithLabelValues("agent_too_old")))
 }
 
 func TestRegister_AgentInfo_Error(t *testing.T) {
@@ -79,6 +112,54 @@ func TestRegister_registerAgent_Error(t *testing.T) {
 	assert.Equal(t, codes.Unavailable, status.Code(err))
 }
 
+func TestUnregister(t *testing.T) {
+	mockRpcApi, mockAgentTracker, s, _, ctx := setupServer(t)
+
+	mockRpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t))
+	mockRpcApi.EXPECT().
+		AgentInfo(gomock.Any(), gomock.Any()).
+		Return(&api.AgentInfo{Id: 123, ClusterId: "456"}, nil)
+	mockAgentTracker.EXPECT().
+		UnregisterConnection(gomock.Any(), gomock.Any(), agent_tracker.DisconnectReason_agent_shutdown).
+		Do(func(ctx context.Context, connectedAgentInfo *agent_tracker.ConnectedAgentInfo, reason agent_tracker.DisconnectReason) error {
+			assert.EqualValues(t, 123, connectedAgentInfo.AgentId)
+			assert.EqualValues(t, "456", connectedAgentInfo.ClusterId)
+			assert.EqualValues(t, 123456789, connectedAgentInfo.ConnectionId)
+			return nil
+		})
+
+	resp, err := s.Unregister(ctx, &rpc.UnregisterRequest{
+		PodId:  123456789,
+		Reason: agent_tracker.DisconnectReason_agent_shutdown,
+	})
+	assert.NotNil(t, resp)
+	assert.NoError(t, err)
+}
+
+func TestUnregister_Error(t *testing.T) {
+	mockRpcApi, mockAgentTracker, s, _, ctx := setupServer(t)
+
+	expectedErr := errors.New("expected error")
+
+	mockRpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t))
+	mockRpcApi.EXPECT().
+		AgentInfo(gomock.Any(), gomock.Any()).
+		Return(&api.AgentInfo{Id: 1, ClusterId: "1"}, nil)
+	mockAgentTracker.EXPECT().
+		UnregisterConnection(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(expectedErr)
+	mockRpcApi.EXPECT().
+		HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), expectedErr)
+
+	resp, err := s.Unregister(ctx, &rpc.UnregisterRequest{PodId: 1})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
 func setupServer(t *testing.T) (*mock_modserver2.MockAgentRpcApi,
 	*mock_agent_tracker.MockTracker, *server, *rpc.RegisterRequest, context.Context) {
 	ctrl := gomock.NewController(t)
@@ -88,6 +169,14 @@ func setupServer(t *testing.T) (*mock_modserver2.MockAgentRpcApi,
 
 	s := &server{
 		agentRegisterer: mockAgentTracker,
+		versionSkewPolicy: &agent_registrar.VersionSkewPolicy{
+			KasVersion:             "v1.3.0",
+			SupportedMinorVersions: 3,
+			Action:                 kascfg.VersionSkewPolicyWarn,
+		},
+		registrations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: registrationsMetricName,
+		}, []string{versionSkewLabel}),
 	}
 
 	req := &rpc.RegisterRequest{
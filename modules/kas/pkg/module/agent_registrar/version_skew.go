@@ -0,0 +1,181 @@
+package agent_registrar
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/mod/semver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+)
+
+const (
+	// developmentVersion is the version of binaries that were built without a version.
+	developmentVersion = "v0.0.0"
+	userAgentHeader    = "user-agent"
+)
+
+// VersionSkewPolicy decides whether kas supports a version of agentk and what to do if it doesn't.
+type VersionSkewPolicy struct {
+	KasVersion string
+	// SupportedMinorVersions is the number of supported minor versions of agentk, counting the one of kas.
+	SupportedMinorVersions uint32
+	AllowNewerAgents       bool
+	// Action is one of kascfg.VersionSkewPolicy* constants.
+	Action string
+}
+
+// Check returns how agentVersion relates to the versions kas supports.
+func (p *VersionSkewPolicy) Check(agentVersion string) entity.VersionSkew {
+	agentMajor, agentMinor, ok := majorMinor(agentVersion)
+	if !ok {
+		return entity.VersionSkew_version_skew_unknown
+	}
+	kasMajor, kasMinor, ok := majorMinor(p.KasVersion)
+	if !ok {
+		return entity.VersionSkew_version_skew_unknown
+	}
+	switch {
+	case agentMajor < kasMajor:
+		return entity.VersionSkew_agent_too_old
+	case agentMajor > kasMajor:
+		if p.AllowNewerAgents {
+			return entity.VersionSkew_supported
+		}
+		return entity.VersionSkew_agent_too_new
+	case agentMinor > kasMinor:
+		if p.AllowNewerAgents {
+			return entity.VersionSkew_supported
+		}
+		return entity.VersionSkew_agent_too_new
+	case kasMinor-agentMinor >= int(p.SupportedMinorVersions):
+		return entity.VersionSkew_agent_too_old
+	default:
+		return entity.VersionSkew_supported
+	}
+}
+
+// Verdict returns the verdict for agentVersion to send to agentk.
+func (p *VersionSkewPolicy) Verdict(agentVersion string) *entity.VersionSkewVerdict {
+	skew := p.Check(agentVersion)
+	v := &entity.VersionSkewVerdict{
+		Skew:       skew,
+		KasVersion: p.KasVersion,
+	}
+	switch skew {
+	case entity.VersionSkew_agent_too_old:
+		v.Action = p.Action
+		v.Message = fmt.Sprintf("agentk %s is too old for kas %s, %s. Please upgrade agentk.", agentVersion, p.KasVersion, p.actionDescription())
+	case entity.VersionSkew_agent_too_new:
+		v.Action = p.Action
+		v.Message = fmt.Sprintf("agentk %s is newer than kas %s, %s. Please upgrade kas or downgrade agentk.", agentVersion, p.KasVersion, p.actionDescription())
+	case entity.VersionSkew_supported:
+		v.Message = fmt.Sprintf("agentk %s is supported by kas %s", agentVersion, p.KasVersion)
+	default:
+		v.Message = fmt.Sprintf("unable to compare agentk version %q with kas version %q", agentVersion, p.KasVersion)
+	}
+	return v
+}
+
+func (p *VersionSkewPolicy) actionDescription() string {
+	switch p.Action {
+	case kascfg.VersionSkewPolicyRestrict:
+		return "reverse tunnels are refused"
+	case kascfg.VersionSkewPolicyReject:
+		return "requests are refused"
+	default:
+		return "it may not work correctly"
+	}
+}
+
+// refuses returns an error if the request to fullMethod from an agent with the version skew must be refused.
+func (p *VersionSkewPolicy) refuses(ctx context.Context, fullMethod string, restrictedMethods, exemptMethods []string) error {
+	if slices.Contains(exemptMethods, fullMethod) {
+		return nil
+	}
+	switch p.Action {
+	case kascfg.VersionSkewPolicyRestrict:
+		if !slices.Contains(restrictedMethods, fullMethod) {
+			return nil
+		}
+	case kascfg.VersionSkewPolicyReject:
+	default:
+		return nil
+	}
+	agentVersion := agentVersionFromContext(ctx)
+	v := p.Verdict(agentVersion)
+	switch v.Skew {
+	case entity.VersionSkew_agent_too_old, entity.VersionSkew_agent_too_new:
+		return status.Error(codes.FailedPrecondition, v.Message)
+	default:
+		return nil
+	}
+}
+
+// UnaryServerVersionSkewInterceptor returns a new unary server interceptor that refuses requests from agents with
+// unsupported versions, according to the policy. Requests to restrictedMethods are refused with the "restrict" action,
+// all requests but those to exemptMethods are refused with the "reject" action.
+func UnaryServerVersionSkewInterceptor(policy *VersionSkewPolicy, restrictedMethods, exemptMethods []string, refused prometheus.Counter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := policy.refuses(ctx, info.FullMethod, restrictedMethods, exemptMethods); err != nil {
+			refused.Inc()
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerVersionSkewInterceptor is the streaming version of UnaryServerVersionSkewInterceptor.
+func StreamServerVersionSkewInterceptor(policy *VersionSkewPolicy, restrictedMethods, exemptMethods []string, refused prometheus.Counter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := policy.refuses(ss.Context(), info.FullMethod, restrictedMethods, exemptMethods); err != nil {
+			refused.Inc()
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// agentVersionFromContext returns the agentk version from the user agent of the request.
+// agentk sets it to "<name>/<version>/<commit>", gRPC appends its own user agent to it.
+func agentVersionFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ua := md.Get(userAgentHeader)
+	if len(ua) == 0 {
+		return ""
+	}
+	product, _, _ := strings.Cut(ua[0], " ")
+	parts := strings.Split(product, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func majorMinor(version string) (int, int, bool) {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) || semver.Canonical(version) == developmentVersion {
+		return 0, 0, false
+	}
+	major, minor, _ := strings.Cut(strings.TrimPrefix(semver.MajorMinor(version), "v"), ".")
+	ma, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, 0, false
+	}
+	mi, err := strconv.Atoi(minor)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ma, mi, true
+}
@@ -0,0 +1,162 @@
+package agent_registrar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+)
+
+const (
+	restrictedMethod = "/test.Service/Restricted"
+	exemptMethod     = "/test.Service/Exempt"
+	otherMethod      = "/test.Service/Other"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		agentVersion     string
+		allowNewerAgents bool
+		expected         entity.VersionSkew
+	}{
+		{agentVersion: "v1.5.0", expected: entity.VersionSkew_supported},
+		{agentVersion: "v1.5.3", expected: entity.VersionSkew_supported},
+		{agentVersion: "1.3.0", expected: entity.VersionSkew_supported},
+		{agentVersion: "v1.3.0-rc1", expected: entity.VersionSkew_supported},
+		{agentVersion: "v1.2.9", expected: entity.VersionSkew_agent_too_old},
+		{agentVersion: "v0.9.0", expected: entity.VersionSkew_agent_too_old},
+		{agentVersion: "v1.6.0", expected: entity.VersionSkew_agent_too_new},
+		{agentVersion: "v2.0.0", expected: entity.VersionSkew_agent_too_new},
+		{agentVersion: "v1.6.0", allowNewerAgents: true, expected: entity.VersionSkew_supported},
+		{agentVersion: "v2.0.0", allowNewerAgents: true, expected: entity.VersionSkew_supported},
+		{agentVersion: "v0.0.0", expected: entity.VersionSkew_version_skew_unknown},
+		{agentVersion: "dev", expected: entity.VersionSkew_version_skew_unknown},
+		{agentVersion: "", expected: entity.VersionSkew_version_skew_unknown},
+	}
+	for _, tc := range tests {
+		t.Run(tc.agentVersion, func(t *testing.T) {
+			p := &VersionSkewPolicy{
+				KasVersion:             "v1.5.1",
+				SupportedMinorVersions: 3,
+				AllowNewerAgents:       tc.allowNewerAgents,
+			}
+			assert.Equal(t, tc.expected, p.Check(tc.agentVersion))
+		})
+	}
+}
+
+func TestCheck_UnknownKasVersion(t *testing.T) {
+	p := &VersionSkewPolicy{
+		KasVersion:             "v0.0.0",
+		SupportedMinorVersions: 3,
+	}
+	assert.Equal(t, entity.VersionSkew_version_skew_unknown, p.Check("v1.0.0"))
+}
+
+func TestVerdict(t *testing.T) {
+	p := &VersionSkewPolicy{
+		KasVersion:             "v1.5.0",
+		SupportedMinorVersions: 3,
+		Action:                 kascfg.VersionSkewPolicyRestrict,
+	}
+	v := p.Verdict("v1.1.0")
+	assert.Equal(t, entity.VersionSkew_agent_too_old, v.Skew)
+	assert.Equal(t, "v1.5.0", v.KasVersion)
+	assert.Equal(t, kascfg.VersionSkewPolicyRestrict, v.Action)
+	assert.Equal(t, "agentk v1.1.0 is too old for kas v1.5.0, reverse tunnels are refused. Please upgrade agentk.", v.Message)
+
+	v = p.Verdict("v1.4.0")
+	assert.Equal(t, entity.VersionSkew_supported, v.Skew)
+	assert.Empty(t, v.Action)
+}
+
+func TestAgentVersionFromContext(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent []string
+		expected  string
+	}{
+		{
+			name:      "agentk",
+			userAgent: []string{"gitlab-agent/v1.2.3/abcdef grpc-go/1.60.0"},
+			expected:  "v1.2.3",
+		},
+		{
+			name:      "other client",
+			userAgent: []string{"grpc-go/1.60.0"},
+			expected:  "1.60.0",
+		},
+		{
+			name:      "no version",
+			userAgent: []string{"curl"},
+			expected:  "",
+		},
+		{
+			name:     "no user agent",
+			expected: "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tc.userAgent != nil {
+				md.Set(userAgentHeader, tc.userAgent...)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			assert.Equal(t, tc.expected, agentVersionFromContext(ctx))
+		})
+	}
+}
+
+func TestUnaryServerVersionSkewInterceptor(t *testing.T) {
+	tests := []struct {
+		action       string
+		agentVersion string
+		method       string
+		refused      bool
+	}{
+		{action: kascfg.VersionSkewPolicyWarn, agentVersion: "v1.0.0", method: restrictedMethod},
+		{action: kascfg.VersionSkewPolicyRestrict, agentVersion: "v1.0.0", method: restrictedMethod, refused: true},
+		{action: kascfg.VersionSkewPolicyRestrict, agentVersion: "v1.0.0", method: otherMethod},
+		{action: kascfg.VersionSkewPolicyRestrict, agentVersion: "v1.5.0", method: restrictedMethod},
+		{action: kascfg.VersionSkewPolicyReject, agentVersion: "v1.0.0", method: otherMethod, refused: true},
+		{action: kascfg.VersionSkewPolicyReject, agentVersion: "v2.0.0", method: restrictedMethod, refused: true},
+		{action: kascfg.VersionSkewPolicyReject, agentVersion: "v1.0.0", method: exemptMethod},
+		{action: kascfg.VersionSkewPolicyReject, agentVersion: "v0.0.0", method: otherMethod},
+	}
+	for _, tc := range tests {
+		t.Run(tc.action+" "+tc.agentVersion+" "+tc.method, func(t *testing.T) {
+			p := &VersionSkewPolicy{
+				KasVersion:             "v1.5.0",
+				SupportedMinorVersions: 3,
+				Action:                 tc.action,
+			}
+			refused := prometheus.NewCounter(prometheus.CounterOpts{Name: "refused"})
+			interceptor := UnaryServerVersionSkewInterceptor(p, []string{restrictedMethod}, []string{exemptMethod}, refused)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(userAgentHeader, "gitlab-agent/"+tc.agentVersion+"/abc"))
+			handlerCalled := false
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCalled = true
+				return nil, nil
+			})
+			if tc.refused {
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+				assert.False(t, handlerCalled)
+				assert.EqualValues(t, 1, testutil.ToFloat64(refused))
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, handlerCalled)
+				assert.EqualValues(t, 0, testutil.ToFloat64(refused))
+			}
+		})
+	}
+}
@@ -25,6 +25,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DisconnectReason is why an agentk->kas connection ended.
+type DisconnectReason int32
+
+const (
+	DisconnectReason_unknown DisconnectReason = 0
+	// agentk told kas that it is shutting down.
+	DisconnectReason_agent_shutdown DisconnectReason = 1
+	// agentk stopped registering the connection and it expired.
+	// This happens if the agentk pod is killed or cannot reach kas.
+	DisconnectReason_expired DisconnectReason = 2
+)
+
+// Enum value maps for DisconnectReason.
+var (
+	DisconnectReason_name = map[int32]string{
+		0: "unknown",
+		1: "agent_shutdown",
+		2: "expired",
+	}
+	DisconnectReason_value = map[string]int32{
+		"unknown":        0,
+		"agent_shutdown": 1,
+		"expired":        2,
+	}
+)
+
+func (x DisconnectReason) Enum() *DisconnectReason {
+	p := new(DisconnectReason)
+	*p = x
+	return p
+}
+
+func (x DisconnectReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisconnectReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_module_agent_tracker_agent_tracker_proto_enumTypes[0].Descriptor()
+}
+
+func (DisconnectReason) Type() protoreflect.EnumType {
+	return &file_pkg_module_agent_tracker_agent_tracker_proto_enumTypes[0]
+}
+
+func (x DisconnectReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisconnectReason.Descriptor instead.
+func (DisconnectReason) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_module_agent_tracker_agent_tracker_proto_rawDescGZIP(), []int{0}
+}
+
 // ConnectedAgentInfo contains information about a connected agentk.
 type ConnectedAgentInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -38,7 +91,11 @@ type ConnectedAgentInfo struct {
 	// Unique id of the agent.
 	AgentId int64 `protobuf:"varint,4,opt,name=agent_id,proto3" json:"agent_id,omitempty"`
 	// Id of the parent cluster.
-	ClusterId     string `protobuf:"bytes,5,opt,name=cluster_id,proto3" json:"cluster_id,omitempty"`
+	ClusterId string `protobuf:"bytes,5,opt,name=cluster_id,proto3" json:"cluster_id,omitempty"`
+	// Information about the cluster sent by the agent.
+	ClusterMeta *entity.ClusterMeta `protobuf:"bytes,6,opt,name=cluster_meta,proto3" json:"cluster_meta,omitempty"`
+	// How the agent version relates to the versions kas supports.
+	VersionSkew   entity.VersionSkew `protobuf:"varint,7,opt,name=version_skew,proto3,enum=plural.agent.entity.VersionSkew" json:"version_skew,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -108,11 +165,98 @@ func (x *ConnectedAgentInfo) GetClusterId() string {
 	return ""
 }
 
+func (x *ConnectedAgentInfo) GetClusterMeta() *entity.ClusterMeta {
+	if x != nil {
+		return x.ClusterMeta
+	}
+	return nil
+}
+
+func (x *ConnectedAgentInfo) GetVersionSkew() entity.VersionSkew {
+	if x != nil {
+		return x.VersionSkew
+	}
+	return entity.VersionSkew(0)
+}
+
+// ConnectionHistoryEntry contains information about an agentk->kas connection, current or past.
+type ConnectionHistoryEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Last known information about the connection.
+	Info *ConnectedAgentInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// When the connection was last registered by the agent.
+	LastSeenAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_seen_at,proto3" json:"last_seen_at,omitempty"`
+	// When agentk unregistered the connection. Not set for active and expired connections.
+	DisconnectedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=disconnected_at,proto3" json:"disconnected_at,omitempty"`
+	// Why the connection ended. Not set if the connection is active.
+	DisconnectReason DisconnectReason `protobuf:"varint,4,opt,name=disconnect_reason,proto3,enum=plural.agent.agent_tracker.DisconnectReason" json:"disconnect_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConnectionHistoryEntry) Reset() {
+	*x = ConnectionHistoryEntry{}
+	mi := &file_pkg_module_agent_tracker_agent_tracker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionHistoryEntry) ProtoMessage() {}
+
+func (x *ConnectionHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_tracker_agent_tracker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionHistoryEntry.ProtoReflect.Descriptor instead.
+func (*ConnectionHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_tracker_agent_tracker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConnectionHistoryEntry) GetInfo() *ConnectedAgentInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *ConnectionHistoryEntry) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *ConnectionHistoryEntry) GetDisconnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisconnectedAt
+	}
+	return nil
+}
+
+func (x *ConnectionHistoryEntry) GetDisconnectReason() DisconnectReason {
+	if x != nil {
+		return x.DisconnectReason
+	}
+	return DisconnectReason_unknown
+}
+
 var File_pkg_module_agent_tracker_agent_tracker_proto protoreflect.FileDescriptor
 
 const file_pkg_module_agent_tracker_agent_tracker_proto_rawDesc = "" +
 	"\n" +
-	",pkg/module/agent_tracker/agent_tracker.proto\x12\x1aplural.agent.agent_tracker\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17pkg/entity/entity.proto\"\xf6\x01\n" +
+	",pkg/module/agent_tracker/agent_tracker.proto\x12\x1aplural.agent.agent_tracker\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17pkg/entity/entity.proto\"\x82\x03\n" +
 	"\x12ConnectedAgentInfo\x12>\n" +
 	"\n" +
 	"agent_meta\x18\x01 \x01(\v2\x1e.plural.agent.entity.AgentMetaR\n" +
@@ -122,7 +266,18 @@ const file_pkg_module_agent_tracker_agent_tracker_proto_rawDesc = "" +
 	"\bagent_id\x18\x04 \x01(\x03R\bagent_id\x12\x1e\n" +
 	"\n" +
 	"cluster_id\x18\x05 \x01(\tR\n" +
-	"cluster_idB?Z=github.com/pluralsh/kubernetes-agent/pkg/module/agent_trackerb\x06proto3"
+	"cluster_id\x12D\n" +
+	"\fcluster_meta\x18\x06 \x01(\v2 .plural.agent.entity.ClusterMetaR\fcluster_meta\x12D\n" +
+	"\fversion_skew\x18\a \x01(\x0e2 .plural.agent.entity.VersionSkewR\fversion_skew\"\xbe\x02\n" +
+	"\x16ConnectionHistoryEntry\x12B\n" +
+	"\x04info\x18\x01 \x01(\v2..plural.agent.agent_tracker.ConnectedAgentInfoR\x04info\x12>\n" +
+	"\flast_seen_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\flast_seen_at\x12D\n" +
+	"\x0fdisconnected_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0fdisconnected_at\x12Z\n" +
+	"\x11disconnect_reason\x18\x04 \x01(\x0e2,.plural.agent.agent_tracker.DisconnectReasonR\x11disconnect_reason*@\n" +
+	"\x10DisconnectReason\x12\v\n" +
+	"\aunknown\x10\x00\x12\x12\n" +
+	"\x0eagent_shutdown\x10\x01\x12\v\n" +
+	"\aexpired\x10\x02B?Z=github.com/pluralsh/kubernetes-agent/pkg/module/agent_trackerb\x06proto3"
 
 var (
 	file_pkg_module_agent_tracker_agent_tracker_proto_rawDescOnce sync.Once
@@ -136,20 +291,31 @@ func file_pkg_module_agent_tracker_agent_tracker_proto_rawDescGZIP() []byte {
 	return file_pkg_module_agent_tracker_agent_tracker_proto_rawDescData
 }
 
-var file_pkg_module_agent_tracker_agent_tracker_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pkg_module_agent_tracker_agent_tracker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_module_agent_tracker_agent_tracker_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_pkg_module_agent_tracker_agent_tracker_proto_goTypes = []any{
-	(*ConnectedAgentInfo)(nil),    // 0: plural.agent.agent_tracker.ConnectedAgentInfo
-	(*entity.AgentMeta)(nil),      // 1: plural.agent.entity.AgentMeta
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(DisconnectReason)(0),          // 0: plural.agent.agent_tracker.DisconnectReason
+	(*ConnectedAgentInfo)(nil),     // 1: plural.agent.agent_tracker.ConnectedAgentInfo
+	(*ConnectionHistoryEntry)(nil), // 2: plural.agent.agent_tracker.ConnectionHistoryEntry
+	(*entity.AgentMeta)(nil),       // 3: plural.agent.entity.AgentMeta
+	(*timestamppb.Timestamp)(nil),  // 4: google.protobuf.Timestamp
+	(*entity.ClusterMeta)(nil),     // 5: plural.agent.entity.ClusterMeta
+	(entity.VersionSkew)(0),        // 6: plural.agent.entity.VersionSkew
 }
 var file_pkg_module_agent_tracker_agent_tracker_proto_depIdxs = []int32{
-	1, // 0: plural.agent.agent_tracker.ConnectedAgentInfo.agent_meta:type_name -> plural.agent.entity.AgentMeta
-	2, // 1: plural.agent.agent_tracker.ConnectedAgentInfo.connected_at:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: plural.agent.agent_tracker.ConnectedAgentInfo.agent_meta:type_name -> plural.agent.entity.AgentMeta
+	4, // 1: plural.agent.agent_tracker.ConnectedAgentInfo.connected_at:type_name -> google.protobuf.Timestamp
+	5, // 2: plural.agent.agent_tracker.ConnectedAgentInfo.cluster_meta:type_name -> plural.agent.entity.ClusterMeta
+	6, // 3: plural.agent.agent_tracker.ConnectedAgentInfo.version_skew:type_name -> plural.agent.entity.VersionSkew
+	1, // 4: plural.agent.agent_tracker.ConnectionHistoryEntry.info:type_name -> plural.agent.agent_tracker.ConnectedAgentInfo
+	4, // 5: plural.agent.agent_tracker.ConnectionHistoryEntry.last_seen_at:type_name -> google.protobuf.Timestamp
+	4, // 6: plural.agent.agent_tracker.ConnectionHistoryEntry.disconnected_at:type_name -> google.protobuf.Timestamp
+	0, // 7: plural.agent.agent_tracker.ConnectionHistoryEntry.disconnect_reason:type_name -> plural.agent.agent_tracker.DisconnectReason
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_pkg_module_agent_tracker_agent_tracker_proto_init() }
@@ -162,13 +328,14 @@ func file_pkg_module_agent_tracker_agent_tracker_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_agent_tracker_agent_tracker_proto_rawDesc), len(file_pkg_module_agent_tracker_agent_tracker_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_pkg_module_agent_tracker_agent_tracker_proto_goTypes,
 		DependencyIndexes: file_pkg_module_agent_tracker_agent_tracker_proto_depIdxs,
+		EnumInfos:         file_pkg_module_agent_tracker_agent_tracker_proto_enumTypes,
 		MessageInfos:      file_pkg_module_agent_tracker_agent_tracker_proto_msgTypes,
 	}.Build()
 	File_pkg_module_agent_tracker_agent_tracker_proto = out.File
@@ -17,6 +17,8 @@ import (
 	"unicode/utf8"
 
 	"google.golang.org/protobuf/types/known/anypb"
+
+	entity "github.com/pluralsh/kubernetes-agent/pkg/entity"
 )
 
 // ensure the imports are used
@@ -33,6 +35,8 @@ var (
 	_ = (*mail.Address)(nil)
 	_ = anypb.Any{}
 	_ = sort.Sort
+
+	_ = entity.VersionSkew(0)
 )
 
 // Validate checks the field values on ConnectedAgentInfo with the rules
@@ -121,6 +125,37 @@ func (m *ConnectedAgentInfo) validate(all bool) error {
 
 	// no validation rules for ClusterId
 
+	if all {
+		switch v := interface{}(m.GetClusterMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConnectedAgentInfoValidationError{
+					field:  "ClusterMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConnectedAgentInfoValidationError{
+					field:  "ClusterMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClusterMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConnectedAgentInfoValidationError{
+				field:  "ClusterMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for VersionSkew
+
 	if len(errors) > 0 {
 		return ConnectedAgentInfoMultiError(errors)
 	}
@@ -200,3 +235,194 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ConnectedAgentInfoValidationError{}
+
+// Validate checks the field values on ConnectionHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConnectionHistoryEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConnectionHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConnectionHistoryEntryMultiError, or nil if none found.
+func (m *ConnectionHistoryEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConnectionHistoryEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetInfo()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConnectionHistoryEntryValidationError{
+					field:  "Info",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConnectionHistoryEntryValidationError{
+					field:  "Info",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInfo()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConnectionHistoryEntryValidationError{
+				field:  "Info",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastSeenAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConnectionHistoryEntryValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConnectionHistoryEntryValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastSeenAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConnectionHistoryEntryValidationError{
+				field:  "LastSeenAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetDisconnectedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConnectionHistoryEntryValidationError{
+					field:  "DisconnectedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConnectionHistoryEntryValidationError{
+					field:  "DisconnectedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDisconnectedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConnectionHistoryEntryValidationError{
+				field:  "DisconnectedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for DisconnectReason
+
+	if len(errors) > 0 {
+		return ConnectionHistoryEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConnectionHistoryEntryMultiError is an error wrapping multiple validation
+// errors returned by ConnectionHistoryEntry.ValidateAll() if the designated
+// constraints aren't met.
+type ConnectionHistoryEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConnectionHistoryEntryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConnectionHistoryEntryMultiError) AllErrors() []error { return m }
+
+// ConnectionHistoryEntryValidationError is the validation error returned by
+// ConnectionHistoryEntry.Validate if the designated constraints aren't met.
+type ConnectionHistoryEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConnectionHistoryEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConnectionHistoryEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConnectionHistoryEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConnectionHistoryEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConnectionHistoryEntryValidationError) ErrorName() string {
+	return "ConnectionHistoryEntryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConnectionHistoryEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConnectionHistoryEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConnectionHistoryEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConnectionHistoryEntryValidationError{}
@@ -29,7 +29,6 @@ type GetConnectedAgentsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Request:
 	//
-	//	*GetConnectedAgentsRequest_ProjectId
 	//	*GetConnectedAgentsRequest_AgentId
 	Request       isGetConnectedAgentsRequest_Request `protobuf_oneof:"request"`
 	unknownFields protoimpl.UnknownFields
@@ -73,15 +72,6 @@ func (x *GetConnectedAgentsRequest) GetRequest() isGetConnectedAgentsRequest_Req
 	return nil
 }
 
-func (x *GetConnectedAgentsRequest) GetProjectId() int64 {
-	if x != nil {
-		if x, ok := x.Request.(*GetConnectedAgentsRequest_ProjectId); ok {
-			return x.ProjectId
-		}
-	}
-	return 0
-}
-
 func (x *GetConnectedAgentsRequest) GetAgentId() int64 {
 	if x != nil {
 		if x, ok := x.Request.(*GetConnectedAgentsRequest_AgentId); ok {
@@ -95,16 +85,10 @@ type isGetConnectedAgentsRequest_Request interface {
 	isGetConnectedAgentsRequest_Request()
 }
 
-type GetConnectedAgentsRequest_ProjectId struct {
-	ProjectId int64 `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3,oneof"`
-}
-
 type GetConnectedAgentsRequest_AgentId struct {
 	AgentId int64 `protobuf:"varint,2,opt,name=agent_id,json=agentId,proto3,oneof"`
 }
 
-func (*GetConnectedAgentsRequest_ProjectId) isGetConnectedAgentsRequest_Request() {}
-
 func (*GetConnectedAgentsRequest_AgentId) isGetConnectedAgentsRequest_Request() {}
 
 type GetConnectedAgentsResponse struct {
@@ -152,20 +136,361 @@ func (x *GetConnectedAgentsResponse) GetAgents() []*agent_tracker.ConnectedAgent
 	return nil
 }
 
+// AgentFilter selects connected agents. All set fields must match.
+type AgentFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Id of the cluster the agent belongs to.
+	ClusterId string `protobuf:"bytes,1,opt,name=cluster_id,proto3" json:"cluster_id,omitempty"`
+	// Only agents with agentk version lower than this semantic version, e.g. "v0.5.0".
+	AgentVersionBelow string `protobuf:"bytes,2,opt,name=agent_version_below,proto3" json:"agent_version_below,omitempty"`
+	// Kubernetes version of the cluster in "major.minor" form, e.g. "1.27".
+	KubernetesVersion string `protobuf:"bytes,3,opt,name=kubernetes_version,proto3" json:"kubernetes_version,omitempty"`
+	// Cloud provider of the cluster.
+	CloudProvider string `protobuf:"bytes,4,opt,name=cloud_provider,proto3" json:"cloud_provider,omitempty"`
+	// Container network interface plugin of the cluster.
+	Cni string `protobuf:"bytes,5,opt,name=cni,proto3" json:"cni,omitempty"`
+	// Cloud provider region of the cluster.
+	Region string `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	// A feature that must be enabled in agentk.
+	Feature string `protobuf:"bytes,7,opt,name=feature,proto3" json:"feature,omitempty"`
+	// Only agents with a version that kas does not support.
+	UnsupportedVersion bool `protobuf:"varint,8,opt,name=unsupported_version,proto3" json:"unsupported_version,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AgentFilter) Reset() {
+	*x = AgentFilter{}
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentFilter) ProtoMessage() {}
+
+func (x *AgentFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentFilter.ProtoReflect.Descriptor instead.
+func (*AgentFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AgentFilter) GetClusterId() string {
+	if x != nil {
+		return x.ClusterId
+	}
+	return ""
+}
+
+func (x *AgentFilter) GetAgentVersionBelow() string {
+	if x != nil {
+		return x.AgentVersionBelow
+	}
+	return ""
+}
+
+func (x *AgentFilter) GetKubernetesVersion() string {
+	if x != nil {
+		return x.KubernetesVersion
+	}
+	return ""
+}
+
+func (x *AgentFilter) GetCloudProvider() string {
+	if x != nil {
+		return x.CloudProvider
+	}
+	return ""
+}
+
+func (x *AgentFilter) GetCni() string {
+	if x != nil {
+		return x.Cni
+	}
+	return ""
+}
+
+func (x *AgentFilter) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *AgentFilter) GetFeature() string {
+	if x != nil {
+		return x.Feature
+	}
+	return ""
+}
+
+func (x *AgentFilter) GetUnsupportedVersion() bool {
+	if x != nil {
+		return x.UnsupportedVersion
+	}
+	return false
+}
+
+type ListAgentsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *AgentFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Maximum number of agents to return. Zero means the default of 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,proto3" json:"page_size,omitempty"`
+	// ListAgentsResponse.next_page_token from the previous call to get the next page.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAgentsRequest) GetFilter() *AgentFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListAgentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAgentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAgentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Connections of agents that match the filter, ordered by agent id and connection id.
+	Agents []*agent_tracker.ConnectedAgentInfo `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// Token to get the next page. Empty if there are no more agents.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*agent_tracker.ConnectedAgentInfo {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetAgentHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       int64                  `protobuf:"varint,1,opt,name=agent_id,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentHistoryRequest) Reset() {
+	*x = GetAgentHistoryRequest{}
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentHistoryRequest) ProtoMessage() {}
+
+func (x *GetAgentHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAgentHistoryRequest) GetAgentId() int64 {
+	if x != nil {
+		return x.AgentId
+	}
+	return 0
+}
+
+type GetAgentHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Current and past connections of the agent, most recently seen first.
+	Entries       []*agent_tracker.ConnectionHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentHistoryResponse) Reset() {
+	*x = GetAgentHistoryResponse{}
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentHistoryResponse) ProtoMessage() {}
+
+func (x *GetAgentHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAgentHistoryResponse) GetEntries() []*agent_tracker.ConnectionHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
 var File_pkg_module_agent_tracker_rpc_rpc_proto protoreflect.FileDescriptor
 
 const file_pkg_module_agent_tracker_rpc_rpc_proto_rawDesc = "" +
 	"\n" +
-	"&pkg/module/agent_tracker/rpc/rpc.proto\x12\x1eplural.agent.agent_tracker.rpc\x1a,pkg/module/agent_tracker/agent_tracker.proto\x1a\x17validate/validate.proto\"i\n" +
-	"\x19GetConnectedAgentsRequest\x12\x1f\n" +
-	"\n" +
-	"project_id\x18\x01 \x01(\x03H\x00R\tprojectId\x12\x1b\n" +
+	"&pkg/module/agent_tracker/rpc/rpc.proto\x12\x1eplural.agent.agent_tracker.rpc\x1a,pkg/module/agent_tracker/agent_tracker.proto\x1a\x17validate/validate.proto\"Z\n" +
+	"\x19GetConnectedAgentsRequest\x12\x1b\n" +
 	"\bagent_id\x18\x02 \x01(\x03H\x00R\aagentIdB\x0e\n" +
-	"\arequest\x12\x03\xf8B\x01\"d\n" +
+	"\arequest\x12\x03\xf8B\x01J\x04\b\x01\x10\x02R\n" +
+	"project_id\"d\n" +
 	"\x1aGetConnectedAgentsResponse\x12F\n" +
-	"\x06agents\x18\x01 \x03(\v2..plural.agent.agent_tracker.ConnectedAgentInfoR\x06agents2\x9e\x01\n" +
+	"\x06agents\x18\x01 \x03(\v2..plural.agent.agent_tracker.ConnectedAgentInfoR\x06agents\"\xee\x02\n" +
+	"\vAgentFilter\x12\x1e\n" +
+	"\n" +
+	"cluster_id\x18\x01 \x01(\tR\n" +
+	"cluster_id\x12U\n" +
+	"\x13agent_version_below\x18\x02 \x01(\tB#\xfaB r\x1e2\x1c^(v?[0-9]+(\\.[0-9]+){0,2})?$R\x13agent_version_below\x12J\n" +
+	"\x12kubernetes_version\x18\x03 \x01(\tB\x1a\xfaB\x17r\x152\x13^([0-9]+\\.[0-9]+)?$R\x12kubernetes_version\x12&\n" +
+	"\x0ecloud_provider\x18\x04 \x01(\tR\x0ecloud_provider\x12\x10\n" +
+	"\x03cni\x18\x05 \x01(\tR\x03cni\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12\x18\n" +
+	"\afeature\x18\a \x01(\tR\afeature\x120\n" +
+	"\x13unsupported_version\x18\b \x01(\bR\x13unsupported_version\"\xa2\x01\n" +
+	"\x11ListAgentsRequest\x12C\n" +
+	"\x06filter\x18\x01 \x01(\v2+.plural.agent.agent_tracker.rpc.AgentFilterR\x06filter\x12(\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\n" +
+	"\xfaB\a\x1a\x05\x18\xe8\a(\x00R\tpage_size\x12\x1e\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\n" +
+	"page_token\"\x86\x01\n" +
+	"\x12ListAgentsResponse\x12F\n" +
+	"\x06agents\x18\x01 \x03(\v2..plural.agent.agent_tracker.ConnectedAgentInfoR\x06agents\x12(\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\x0fnext_page_token\"=\n" +
+	"\x16GetAgentHistoryRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\bagent_id\"g\n" +
+	"\x17GetAgentHistoryResponse\x12L\n" +
+	"\aentries\x18\x01 \x03(\v22.plural.agent.agent_tracker.ConnectionHistoryEntryR\aentries2\x9c\x03\n" +
 	"\fAgentTracker\x12\x8d\x01\n" +
-	"\x12GetConnectedAgents\x129.plural.agent.agent_tracker.rpc.GetConnectedAgentsRequest\x1a:.plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse\"\x00BCZAgithub.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker/rpcb\x06proto3"
+	"\x12GetConnectedAgents\x129.plural.agent.agent_tracker.rpc.GetConnectedAgentsRequest\x1a:.plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse\"\x00\x12u\n" +
+	"\n" +
+	"ListAgents\x121.plural.agent.agent_tracker.rpc.ListAgentsRequest\x1a2.plural.agent.agent_tracker.rpc.ListAgentsResponse\"\x00\x12\x84\x01\n" +
+	"\x0fGetAgentHistory\x126.plural.agent.agent_tracker.rpc.GetAgentHistoryRequest\x1a7.plural.agent.agent_tracker.rpc.GetAgentHistoryResponse\"\x00BCZAgithub.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker/rpcb\x06proto3"
 
 var (
 	file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescOnce sync.Once
@@ -179,21 +504,34 @@ func file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescGZIP() []byte {
 	return file_pkg_module_agent_tracker_rpc_rpc_proto_rawDescData
 }
 
-var file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_pkg_module_agent_tracker_rpc_rpc_proto_goTypes = []any{
-	(*GetConnectedAgentsRequest)(nil),        // 0: plural.agent.agent_tracker.rpc.GetConnectedAgentsRequest
-	(*GetConnectedAgentsResponse)(nil),       // 1: plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse
-	(*agent_tracker.ConnectedAgentInfo)(nil), // 2: plural.agent.agent_tracker.ConnectedAgentInfo
+	(*GetConnectedAgentsRequest)(nil),            // 0: plural.agent.agent_tracker.rpc.GetConnectedAgentsRequest
+	(*GetConnectedAgentsResponse)(nil),           // 1: plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse
+	(*AgentFilter)(nil),                          // 2: plural.agent.agent_tracker.rpc.AgentFilter
+	(*ListAgentsRequest)(nil),                    // 3: plural.agent.agent_tracker.rpc.ListAgentsRequest
+	(*ListAgentsResponse)(nil),                   // 4: plural.agent.agent_tracker.rpc.ListAgentsResponse
+	(*GetAgentHistoryRequest)(nil),               // 5: plural.agent.agent_tracker.rpc.GetAgentHistoryRequest
+	(*GetAgentHistoryResponse)(nil),              // 6: plural.agent.agent_tracker.rpc.GetAgentHistoryResponse
+	(*agent_tracker.ConnectedAgentInfo)(nil),     // 7: plural.agent.agent_tracker.ConnectedAgentInfo
+	(*agent_tracker.ConnectionHistoryEntry)(nil), // 8: plural.agent.agent_tracker.ConnectionHistoryEntry
 }
 var file_pkg_module_agent_tracker_rpc_rpc_proto_depIdxs = []int32{
-	2, // 0: plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse.agents:type_name -> plural.agent.agent_tracker.ConnectedAgentInfo
-	0, // 1: plural.agent.agent_tracker.rpc.AgentTracker.GetConnectedAgents:input_type -> plural.agent.agent_tracker.rpc.GetConnectedAgentsRequest
-	1, // 2: plural.agent.agent_tracker.rpc.AgentTracker.GetConnectedAgents:output_type -> plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	7, // 0: plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse.agents:type_name -> plural.agent.agent_tracker.ConnectedAgentInfo
+	2, // 1: plural.agent.agent_tracker.rpc.ListAgentsRequest.filter:type_name -> plural.agent.agent_tracker.rpc.AgentFilter
+	7, // 2: plural.agent.agent_tracker.rpc.ListAgentsResponse.agents:type_name -> plural.agent.agent_tracker.ConnectedAgentInfo
+	8, // 3: plural.agent.agent_tracker.rpc.GetAgentHistoryResponse.entries:type_name -> plural.agent.agent_tracker.ConnectionHistoryEntry
+	0, // 4: plural.agent.agent_tracker.rpc.AgentTracker.GetConnectedAgents:input_type -> plural.agent.agent_tracker.rpc.GetConnectedAgentsRequest
+	3, // 5: plural.agent.agent_tracker.rpc.AgentTracker.ListAgents:input_type -> plural.agent.agent_tracker.rpc.ListAgentsRequest
+	5, // 6: plural.agent.agent_tracker.rpc.AgentTracker.GetAgentHistory:input_type -> plural.agent.agent_tracker.rpc.GetAgentHistoryRequest
+	1, // 7: plural.agent.agent_tracker.rpc.AgentTracker.GetConnectedAgents:output_type -> plural.agent.agent_tracker.rpc.GetConnectedAgentsResponse
+	4, // 8: plural.agent.agent_tracker.rpc.AgentTracker.ListAgents:output_type -> plural.agent.agent_tracker.rpc.ListAgentsResponse
+	6, // 9: plural.agent.agent_tracker.rpc.AgentTracker.GetAgentHistory:output_type -> plural.agent.agent_tracker.rpc.GetAgentHistoryResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_pkg_module_agent_tracker_rpc_rpc_proto_init() }
@@ -202,7 +540,6 @@ func file_pkg_module_agent_tracker_rpc_rpc_proto_init() {
 		return
 	}
 	file_pkg_module_agent_tracker_rpc_rpc_proto_msgTypes[0].OneofWrappers = []any{
-		(*GetConnectedAgentsRequest_ProjectId)(nil),
 		(*GetConnectedAgentsRequest_AgentId)(nil),
 	}
 	type x struct{}
@@ -211,7 +548,7 @@ func file_pkg_module_agent_tracker_rpc_rpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_agent_tracker_rpc_rpc_proto_rawDesc), len(file_pkg_module_agent_tracker_rpc_rpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -59,19 +59,6 @@ func (m *GetConnectedAgentsRequest) validate(all bool) error {
 
 	oneofRequestPresent := false
 	switch v := m.Request.(type) {
-	case *GetConnectedAgentsRequest_ProjectId:
-		if v == nil {
-			err := GetConnectedAgentsRequestValidationError{
-				field:  "Request",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
-		oneofRequestPresent = true
-		// no validation rules for ProjectId
 	case *GetConnectedAgentsRequest_AgentId:
 		if v == nil {
 			err := GetConnectedAgentsRequestValidationError{
@@ -314,3 +301,671 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = GetConnectedAgentsResponseValidationError{}
+
+// Validate checks the field values on AgentFilter with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AgentFilter) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AgentFilter with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AgentFilterMultiError, or
+// nil if none found.
+func (m *AgentFilter) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AgentFilter) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ClusterId
+
+	if !_AgentFilter_AgentVersionBelow_Pattern.MatchString(m.GetAgentVersionBelow()) {
+		err := AgentFilterValidationError{
+			field:  "AgentVersionBelow",
+			reason: "value does not match regex pattern \"^(v?[0-9]+(\\\\.[0-9]+){0,2})?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_AgentFilter_KubernetesVersion_Pattern.MatchString(m.GetKubernetesVersion()) {
+		err := AgentFilterValidationError{
+			field:  "KubernetesVersion",
+			reason: "value does not match regex pattern \"^([0-9]+\\\\.[0-9]+)?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for CloudProvider
+
+	// no validation rules for Cni
+
+	// no validation rules for Region
+
+	// no validation rules for Feature
+
+	// no validation rules for UnsupportedVersion
+
+	if len(errors) > 0 {
+		return AgentFilterMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentFilterMultiError is an error wrapping multiple validation errors
+// returned by AgentFilter.ValidateAll() if the designated constraints aren't met.
+type AgentFilterMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentFilterMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentFilterMultiError) AllErrors() []error { return m }
+
+// AgentFilterValidationError is the validation error returned by
+// AgentFilter.Validate if the designated constraints aren't met.
+type AgentFilterValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentFilterValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentFilterValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentFilterValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentFilterValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentFilterValidationError) ErrorName() string { return "AgentFilterValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AgentFilterValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAgentFilter.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AgentFilterValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AgentFilterValidationError{}
+
+var _AgentFilter_AgentVersionBelow_Pattern = regexp.MustCompile("^(v?[0-9]+(\\.[0-9]+){0,2})?$")
+
+var _AgentFilter_KubernetesVersion_Pattern = regexp.MustCompile("^([0-9]+\\.[0-9]+)?$")
+
+// Validate checks the field values on ListAgentsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListAgentsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAgentsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAgentsRequestMultiError, or nil if none found.
+func (m *ListAgentsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAgentsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetFilter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListAgentsRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListAgentsRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListAgentsRequestValidationError{
+				field:  "Filter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if val := m.GetPageSize(); val < 0 || val > 1000 {
+		err := ListAgentsRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PageToken
+
+	if len(errors) > 0 {
+		return ListAgentsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAgentsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListAgentsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListAgentsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAgentsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAgentsRequestMultiError) AllErrors() []error { return m }
+
+// ListAgentsRequestValidationError is the validation error returned by
+// ListAgentsRequest.Validate if the designated constraints aren't met.
+type ListAgentsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAgentsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAgentsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAgentsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAgentsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAgentsRequestValidationError) ErrorName() string {
+	return "ListAgentsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAgentsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAgentsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAgentsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAgentsRequestValidationError{}
+
+// Validate checks the field values on ListAgentsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAgentsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAgentsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAgentsResponseMultiError, or nil if none found.
+func (m *ListAgentsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAgentsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetAgents() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAgentsResponseValidationError{
+						field:  fmt.Sprintf("Agents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAgentsResponseValidationError{
+						field:  fmt.Sprintf("Agents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAgentsResponseValidationError{
+					field:  fmt.Sprintf("Agents[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	if len(errors) > 0 {
+		return ListAgentsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAgentsResponseMultiError is an error wrapping multiple validation errors
+// returned by ListAgentsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListAgentsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAgentsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAgentsResponseMultiError) AllErrors() []error { return m }
+
+// ListAgentsResponseValidationError is the validation error returned by
+// ListAgentsResponse.Validate if the designated constraints aren't met.
+type ListAgentsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAgentsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAgentsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAgentsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAgentsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAgentsResponseValidationError) ErrorName() string {
+	return "ListAgentsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAgentsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAgentsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAgentsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAgentsResponseValidationError{}
+
+// Validate checks the field values on GetAgentHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetAgentHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetAgentHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAgentHistoryRequestMultiError, or nil if none found.
+func (m *GetAgentHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetAgentHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAgentId() <= 0 {
+		err := GetAgentHistoryRequestValidationError{
+			field:  "AgentId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetAgentHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetAgentHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by GetAgentHistoryRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetAgentHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetAgentHistoryRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetAgentHistoryRequestMultiError) AllErrors() []error { return m }
+
+// GetAgentHistoryRequestValidationError is the validation error returned by
+// GetAgentHistoryRequest.Validate if the designated constraints aren't met.
+type GetAgentHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetAgentHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetAgentHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetAgentHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetAgentHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetAgentHistoryRequestValidationError) ErrorName() string {
+	return "GetAgentHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetAgentHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetAgentHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetAgentHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetAgentHistoryRequestValidationError{}
+
+// Validate checks the field values on GetAgentHistoryResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetAgentHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetAgentHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAgentHistoryResponseMultiError, or nil if none found.
+func (m *GetAgentHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetAgentHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetEntries() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetAgentHistoryResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetAgentHistoryResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetAgentHistoryResponseValidationError{
+					field:  fmt.Sprintf("Entries[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetAgentHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetAgentHistoryResponseMultiError is an error wrapping multiple validation
+// errors returned by GetAgentHistoryResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetAgentHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetAgentHistoryResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetAgentHistoryResponseMultiError) AllErrors() []error { return m }
+
+// GetAgentHistoryResponseValidationError is the validation error returned by
+// GetAgentHistoryResponse.Validate if the designated constraints aren't met.
+type GetAgentHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetAgentHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetAgentHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetAgentHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetAgentHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetAgentHistoryResponseValidationError) ErrorName() string {
+	return "GetAgentHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetAgentHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetAgentHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetAgentHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetAgentHistoryResponseValidationError{}
@@ -22,14 +22,20 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	AgentTracker_GetConnectedAgents_FullMethodName = "/plural.agent.agent_tracker.rpc.AgentTracker/GetConnectedAgents"
+	AgentTracker_ListAgents_FullMethodName         = "/plural.agent.agent_tracker.rpc.AgentTracker/ListAgents"
+	AgentTracker_GetAgentHistory_FullMethodName    = "/plural.agent.agent_tracker.rpc.AgentTracker/GetAgentHistory"
 )
 
 // AgentTrackerClient is the client API for AgentTracker service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AgentTrackerClient interface {
-	// Get connected agents for a configuration project or an agent id.
+	// Get connected agents for an agent id.
 	GetConnectedAgents(ctx context.Context, in *GetConnectedAgentsRequest, opts ...grpc.CallOption) (*GetConnectedAgentsResponse, error)
+	// List connected agents across all clusters.
+	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// Get the connection history of an agent, including when and why past connections ended.
+	GetAgentHistory(ctx context.Context, in *GetAgentHistoryRequest, opts ...grpc.CallOption) (*GetAgentHistoryResponse, error)
 }
 
 type agentTrackerClient struct {
@@ -50,12 +56,36 @@ func (c *agentTrackerClient) GetConnectedAgents(ctx context.Context, in *GetConn
 	return out, nil
 }
 
+func (c *agentTrackerClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, AgentTracker_ListAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentTrackerClient) GetAgentHistory(ctx context.Context, in *GetAgentHistoryRequest, opts ...grpc.CallOption) (*GetAgentHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentHistoryResponse)
+	err := c.cc.Invoke(ctx, AgentTracker_GetAgentHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentTrackerServer is the server API for AgentTracker service.
 // All implementations must embed UnimplementedAgentTrackerServer
 // for forward compatibility.
 type AgentTrackerServer interface {
-	// Get connected agents for a configuration project or an agent id.
+	// Get connected agents for an agent id.
 	GetConnectedAgents(context.Context, *GetConnectedAgentsRequest) (*GetConnectedAgentsResponse, error)
+	// List connected agents across all clusters.
+	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// Get the connection history of an agent, including when and why past connections ended.
+	GetAgentHistory(context.Context, *GetAgentHistoryRequest) (*GetAgentHistoryResponse, error)
 	mustEmbedUnimplementedAgentTrackerServer()
 }
 
@@ -69,6 +99,12 @@ type UnimplementedAgentTrackerServer struct{}
 func (UnimplementedAgentTrackerServer) GetConnectedAgents(context.Context, *GetConnectedAgentsRequest) (*GetConnectedAgentsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetConnectedAgents not implemented")
 }
+func (UnimplementedAgentTrackerServer) ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedAgentTrackerServer) GetAgentHistory(context.Context, *GetAgentHistoryRequest) (*GetAgentHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAgentHistory not implemented")
+}
 func (UnimplementedAgentTrackerServer) mustEmbedUnimplementedAgentTrackerServer() {}
 func (UnimplementedAgentTrackerServer) testEmbeddedByValue()                      {}
 
@@ -108,6 +144,42 @@ func _AgentTracker_GetConnectedAgents_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentTracker_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentTrackerServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentTracker_ListAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentTrackerServer).ListAgents(ctx, req.(*ListAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentTracker_GetAgentHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentTrackerServer).GetAgentHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentTracker_GetAgentHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentTrackerServer).GetAgentHistory(ctx, req.(*GetAgentHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentTracker_ServiceDesc is the grpc.ServiceDesc for AgentTracker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -119,6 +191,14 @@ var AgentTracker_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConnectedAgents",
 			Handler:    _AgentTracker_GetConnectedAgents_Handler,
 		},
+		{
+			MethodName: "ListAgents",
+			Handler:    _AgentTracker_ListAgents_Handler,
+		},
+		{
+			MethodName: "GetAgentHistory",
+			Handler:    _AgentTracker_GetAgentHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/module/agent_tracker/rpc/rpc.proto",
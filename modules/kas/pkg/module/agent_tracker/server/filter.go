@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
+	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker/rpc"
+)
+
+// agentFilter matches connected agents against rpc2.AgentFilter.
+type agentFilter struct {
+	*rpc2.AgentFilter
+	// versionBelow is AgentFilter.AgentVersionBelow in the canonical form of the semver package.
+	versionBelow string
+	major, minor string
+}
+
+func newAgentFilter(f *rpc2.AgentFilter) (*agentFilter, error) {
+	af := &agentFilter{
+		AgentFilter: f,
+	}
+	if v := f.GetAgentVersionBelow(); v != "" {
+		af.versionBelow = semver.Canonical(withVPrefix(v))
+		if af.versionBelow == "" {
+			return nil, fmt.Errorf("invalid agent version: %q", v)
+		}
+	}
+	if v := f.GetKubernetesVersion(); v != "" {
+		af.major, af.minor, _ = strings.Cut(v, ".")
+	}
+	return af, nil
+}
+
+func (f *agentFilter) matchesClusterId(clusterId string) bool {
+	return f.GetClusterId() == "" || f.GetClusterId() == clusterId
+}
+
+func (f *agentFilter) matches(info *agent_tracker.ConnectedAgentInfo) bool {
+	meta := info.AgentMeta
+	cluster := info.ClusterMeta
+	if !f.matchesClusterId(info.ClusterId) {
+		return false
+	}
+	if f.versionBelow != "" {
+		v := withVPrefix(meta.GetVersion())
+		// Versions that are not semantic versions are not comparable, so they don't match.
+		if !semver.IsValid(v) || semver.Compare(v, f.versionBelow) >= 0 {
+			return false
+		}
+	}
+	if f.major != "" {
+		kv := meta.GetKubernetesVersion()
+		// Some providers report minor versions like "27+".
+		if kv.GetMajor() != f.major || strings.TrimRight(kv.GetMinor(), "+") != f.minor {
+			return false
+		}
+	}
+	if f.GetCloudProvider() != "" && cluster.GetCloudProvider() != f.GetCloudProvider() {
+		return false
+	}
+	if f.GetCni() != "" && cluster.GetCni() != f.GetCni() {
+		return false
+	}
+	if f.GetRegion() != "" && cluster.GetRegion() != f.GetRegion() {
+		return false
+	}
+	if f.GetFeature() != "" && !slices.Contains(meta.GetFeatures(), f.GetFeature()) {
+		return false
+	}
+	if f.GetUnsupportedVersion() {
+		switch info.VersionSkew {
+		case entity.VersionSkew_agent_too_old, entity.VersionSkew_agent_too_new:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func withVPrefix(v string) string {
+	if strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}
+
+// pageToken is the position of the last returned connection.
+// Agents are ordered by agent id and then by connection id.
+type pageToken struct {
+	agentId      int64
+	connectionId int64
+}
+
+func decodePageToken(token string) (pageToken, error) {
+	if token == "" {
+		return pageToken{}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageToken{}, err
+	}
+	var t pageToken
+	_, err = fmt.Sscanf(string(data), "%d:%d", &t.agentId, &t.connectionId)
+	if err != nil {
+		return pageToken{}, err
+	}
+	return t, nil
+}
+
+func (t pageToken) encode() string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", t.agentId, t.connectionId))
+}
+
+// isAfter returns true if the connection comes after the position in the token.
+// A zero token is before all connections.
+func (t pageToken) isAfter(info *agent_tracker.ConnectedAgentInfo) bool {
+	if t == (pageToken{}) {
+		return true
+	}
+	if info.AgentId != t.agentId {
+		return info.AgentId > t.agentId
+	}
+	return info.ConnectionId > t.connectionId
+}
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"cmp"
 	"context"
+	"slices"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -12,6 +14,10 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
 )
 
+const (
+	defaultPageSize = 100
+)
+
 type server struct {
 	rpc2.UnimplementedAgentTrackerServer
 	agentQuerier agent_tracker.Querier
@@ -36,3 +42,65 @@ func (s *server) GetConnectedAgents(ctx context.Context, req *rpc2.GetConnectedA
 		return nil, status.Errorf(codes.InvalidArgument, "Unexpected field type: %T", req.Request)
 	}
 }
+
+func (s *server) ListAgents(ctx context.Context, req *rpc2.ListAgentsRequest) (*rpc2.ListAgentsResponse, error) {
+	rpcApi := modserver.RpcApiFromContext(ctx)
+	log := rpcApi.Log()
+	filter, err := newAgentFilter(req.Filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	// Connections of all agents are fetched with a single query. They are filtered and sorted in memory.
+	var infos []*agent_tracker.ConnectedAgentInfo
+	err = s.agentQuerier.GetConnections(ctx, func(info *agent_tracker.ConnectedAgentInfo) (bool, error) {
+		if after.isAfter(info) && filter.matches(info) {
+			infos = append(infos, info)
+		}
+		return false, nil
+	})
+	if err != nil {
+		rpcApi.HandleProcessingError(log, modshared.NoAgentId, "GetConnections() failed", err)
+		return nil, status.Error(codes.Unavailable, "GetConnections() failed")
+	}
+	slices.SortFunc(infos, func(a, b *agent_tracker.ConnectedAgentInfo) int {
+		return cmp.Or(cmp.Compare(a.AgentId, b.AgentId), cmp.Compare(a.ConnectionId, b.ConnectionId))
+	})
+
+	resp := &rpc2.ListAgentsResponse{}
+	if len(infos) > pageSize {
+		infos = infos[:pageSize]
+		last := infos[pageSize-1]
+		resp.NextPageToken = pageToken{agentId: last.AgentId, connectionId: last.ConnectionId}.encode()
+	}
+	resp.Agents = infos
+	return resp, nil
+}
+
+func (s *server) GetAgentHistory(ctx context.Context, req *rpc2.GetAgentHistoryRequest) (*rpc2.GetAgentHistoryResponse, error) {
+	rpcApi := modserver.RpcApiFromContext(ctx)
+	log := rpcApi.Log()
+	var entries []*agent_tracker.ConnectionHistoryEntry
+	err := s.agentQuerier.GetConnectionHistory(ctx, req.AgentId, func(entry *agent_tracker.ConnectionHistoryEntry) (bool, error) {
+		entries = append(entries, entry)
+		return false, nil
+	})
+	if err != nil {
+		rpcApi.HandleProcessingError(log, req.AgentId, "GetConnectionHistory() failed", err)
+		return nil, status.Error(codes.Unavailable, "GetConnectionHistory() failed")
+	}
+	slices.SortFunc(entries, func(a, b *agent_tracker.ConnectionHistoryEntry) int {
+		return b.LastSeenAt.AsTime().Compare(a.LastSeenAt.AsTime())
+	})
+	return &rpc2.GetAgentHistoryResponse{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_agent_tracker"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modserver"
+)
+
+var (
+	_ rpc.AgentTrackerServer = &server{}
+)
+
+func TestListAgents_FiltersAndPaginates(t *testing.T) {
+	s, tracker, ctx := setupServer(t)
+	agents := map[int64][]*agent_tracker.ConnectedAgentInfo{
+		1: {
+			connInfo(1, 11, "v0.4.0", "27+", "aws"),
+			connInfo(1, 10, "v0.4.1", "27", "aws"),
+		},
+		2: {
+			connInfo(2, 20, "v0.5.0", "27", "aws"), // too new
+			connInfo(2, 21, "dev", "27", "aws"),    // not a semantic version
+		},
+		3: {
+			connInfo(3, 30, "0.3.0", "28", "aws"), // other Kubernetes version
+			connInfo(3, 31, "0.3.0", "27", "gce"), // other cloud provider
+			connInfo(3, 32, "0.3.0", "27", "aws"),
+		},
+	}
+	tracker.EXPECT().
+		GetConnections(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, cb agent_tracker.ConnectedAgentInfoCallback) error {
+			for _, agentId := range []int64{3, 1, 2} {
+				for _, info := range agents[agentId] {
+					_, err := cb(info)
+					require.NoError(t, err)
+				}
+			}
+			return nil
+		}).
+		Times(2)
+
+	req := &rpc.ListAgentsRequest{
+		Filter: &rpc.AgentFilter{
+			AgentVersionBelow: "v0.5",
+			KubernetesVersion: "1.27",
+			CloudProvider:     "aws",
+		},
+		PageSize: 2,
+	}
+	resp, err := s.ListAgents(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int64{{1, 10}, {1, 11}}, ids(resp.Agents))
+	require.NotEmpty(t, resp.NextPageToken)
+
+	req.PageToken = resp.NextPageToken
+	resp, err = s.ListAgents(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int64{{3, 32}}, ids(resp.Agents))
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestListAgents_UnsupportedVersion(t *testing.T) {
+	s, tracker, ctx := setupServer(t)
+	tooOld := connInfo(1, 10, "v0.1.0", "27", "aws")
+	tooOld.VersionSkew = entity.VersionSkew_agent_too_old
+	supported := connInfo(1, 11, "v0.4.0", "27", "aws")
+	supported.VersionSkew = entity.VersionSkew_supported
+	tooNew := connInfo(2, 20, "v0.9.0", "27", "aws")
+	tooNew.VersionSkew = entity.VersionSkew_agent_too_new
+	unknown := connInfo(2, 21, "dev", "27", "aws")
+	agents := map[int64][]*agent_tracker.ConnectedAgentInfo{
+		1: {tooOld, supported},
+		2: {tooNew, unknown},
+	}
+	tracker.EXPECT().
+		GetConnections(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, cb agent_tracker.ConnectedAgentInfoCallback) error {
+			for _, infos := range agents {
+				for _, info := range infos {
+					_, err := cb(info)
+					require.NoError(t, err)
+				}
+			}
+			return nil
+		})
+
+	resp, err := s.ListAgents(ctx, &rpc.ListAgentsRequest{
+		Filter: &rpc.AgentFilter{
+			UnsupportedVersion: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int64{{1, 10}, {2, 20}}, ids(resp.Agents))
+}
+
+func TestListAgents_InvalidPageToken(t *testing.T) {
+	s, _, ctx := setupServer(t)
+	_, err := s.ListAgents(ctx, &rpc.ListAgentsRequest{
+		PageToken: "!",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetAgentHistory_MostRecentFirst(t *testing.T) {
+	s, tracker, ctx := setupServer(t)
+	now := time.Now()
+	tracker.EXPECT().
+		GetConnectionHistory(gomock.Any(), int64(1), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, agentId int64, cb agent_tracker.ConnectionHistoryCallback) error {
+			for _, connectionId := range []int64{10, 12, 11} {
+				_, err := cb(&agent_tracker.ConnectionHistoryEntry{
+					Info:       connInfo(agentId, connectionId, "v1.0.0", "27", "aws"),
+					LastSeenAt: timestamppb.New(now.Add(time.Duration(connectionId) * time.Minute)),
+				})
+				require.NoError(t, err)
+			}
+			return nil
+		})
+	resp, err := s.GetAgentHistory(ctx, &rpc.GetAgentHistoryRequest{AgentId: 1})
+	require.NoError(t, err)
+	var connectionIds []int64
+	for _, e := range resp.Entries {
+		connectionIds = append(connectionIds, e.Info.ConnectionId)
+	}
+	assert.Equal(t, []int64{12, 11, 10}, connectionIds)
+}
+
+func TestDecodePageToken_RoundTrip(t *testing.T) {
+	token := pageToken{agentId: 123, connectionId: 456}
+	decoded, err := decodePageToken(token.encode())
+	require.NoError(t, err)
+	assert.Equal(t, token, decoded)
+}
+
+func setupServer(t *testing.T) (*server, *mock_agent_tracker.MockTracker, context.Context) {
+	ctrl := gomock.NewController(t)
+	rpcApi := mock_modserver.NewMockRpcApi(ctrl)
+	rpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t)).
+		AnyTimes()
+	tracker := mock_agent_tracker.NewMockTracker(ctrl)
+	s := &server{
+		agentQuerier: tracker,
+	}
+	return s, tracker, modserver.InjectRpcApi(context.Background(), rpcApi)
+}
+
+func connInfo(agentId, connectionId int64, version, kubernetesMinor, cloudProvider string) *agent_tracker.ConnectedAgentInfo {
+	return &agent_tracker.ConnectedAgentInfo{
+		AgentMeta: &entity.AgentMeta{
+			Version: version,
+			KubernetesVersion: &entity.KubernetesVersion{
+				Major: "1",
+				Minor: kubernetesMinor,
+			},
+		},
+		ConnectionId: connectionId,
+		AgentId:      agentId,
+		ClusterId:    "cluster",
+		ClusterMeta: &entity.ClusterMeta{
+			CloudProvider: cloudProvider,
+		},
+	}
+}
+
+func ids(infos []*agent_tracker.ConnectedAgentInfo) [][2]int64 {
+	var res [][2]int64
+	for _, info := range infos {
+		res = append(res, [2]int64{info.AgentId, info.ConnectionId})
+	}
+	return res
+}
@@ -7,10 +7,10 @@ import (
 	"sync"
 	"time"
 
-	"github.com/redis/rueidis"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
@@ -19,22 +19,35 @@ import (
 )
 
 const (
-	refreshOverlap           = 5 * time.Second
-	connectedAgentsKey int64 = 0
+	refreshOverlap                = 5 * time.Second
+	connectedAgentsKey      int64 = 0
+	connectionsKey          int64 = 0
+	unregisteredConnections int64 = 0
 )
 
 type ConnectedAgentInfoCallback func(*ConnectedAgentInfo) (done bool, err error)
 
+type ConnectedAgentCallback func(agentId int64, clusterId string) (done bool, err error)
+
+type ConnectionHistoryCallback func(*ConnectionHistoryEntry) (done bool, err error)
+
 type Registerer interface {
 	// RegisterConnection registers connection with the tracker.
 	RegisterConnection(ctx context.Context, info *ConnectedAgentInfo) error
 	// UnregisterConnection unregisters connection with the tracker.
-	UnregisterConnection(ctx context.Context, info *ConnectedAgentInfo) error
+	// reason is recorded in the connection history.
+	UnregisterConnection(ctx context.Context, info *ConnectedAgentInfo, reason DisconnectReason) error
 }
 
 type Querier interface {
 	GetConnectionsByAgentId(ctx context.Context, agentId int64, cb ConnectedAgentInfoCallback) error
 	GetConnectedAgentsCount(ctx context.Context) (int64, error)
+	// GetConnectedAgents calls cb for each connected agent.
+	GetConnectedAgents(ctx context.Context, cb ConnectedAgentCallback) error
+	// GetConnections calls cb for each connection of all agents.
+	GetConnections(ctx context.Context, cb ConnectedAgentInfoCallback) error
+	// GetConnectionHistory calls cb for each current and past connection of the agent that is still in the history.
+	GetConnectionHistory(ctx context.Context, agentId int64, cb ConnectionHistoryCallback) error
 }
 
 type Tracker interface {
@@ -43,7 +56,8 @@ type Tracker interface {
 	Run(ctx context.Context) error
 }
 
-type RedisTracker struct {
+// StorageTracker keeps track of agent connections in the storage backend that kas replicas share.
+type StorageTracker struct {
 	log           *zap.Logger
 	errRep        errz.ErrReporter
 	refreshPeriod time.Duration
@@ -52,21 +66,36 @@ type RedisTracker struct {
 	// mu protects fields below
 	mu                   sync.Mutex
 	connectionsByAgentId redistool.ExpiringHash[int64, int64] // agentId -> connectionId -> info
-	connectedAgents      redistool.ExpiringHash[int64, int64] // hash name -> agentId -> ""
+	connectedAgents      redistool.ExpiringHash[int64, int64] // hash name -> agentId -> clusterId
+	// connections has connections of all agents so that they can be listed with a single query.
+	connections redistool.ExpiringHash[int64, int64] // hash name -> connectionId -> info
+	// connectionHistory is only written to, entries are not refreshed and expire after historyTtl.
+	connectionHistory redistool.ExpiringHash[int64, int64] // agentId -> connectionId -> ConnectionHistoryEntry
+	// unregistered has connections that have been unregistered recently. A connection may be registered through
+	// several kas replicas and unregistered through another one. The replicas stop refreshing such connections.
+	// Entries are not refreshed and expire after ttl, which is longer than refreshPeriod.
+	unregistered redistool.ExpiringHash[int64, int64] // hash name -> connectionId -> ConnectionHistoryEntry
+	// registered has connections that have been registered through this kas replica, and when.
+	registered map[int64]map[int64]time.Time // agentId -> connectionId -> registration time
 }
 
-func NewRedisTracker(log *zap.Logger, errRep errz.ErrReporter, client rueidis.Client, agentKeyPrefix string, ttl, refreshPeriod, gcPeriod time.Duration) *RedisTracker {
-	return &RedisTracker{
+func NewStorageTracker(log *zap.Logger, errRep errz.ErrReporter, backend redistool.Backend, agentKeyPrefix string,
+	ttl, refreshPeriod, gcPeriod, historyTtl time.Duration) *StorageTracker {
+	return &StorageTracker{
 		log:                  log,
 		errRep:               errRep,
 		refreshPeriod:        refreshPeriod,
 		gcPeriod:             gcPeriod,
-		connectionsByAgentId: redistool.NewRedisExpiringHash(client, connectionsByAgentIdHashKey(agentKeyPrefix), int64ToStr, ttl),
-		connectedAgents:      redistool.NewRedisExpiringHash(client, connectedAgentsHashKey(agentKeyPrefix), int64ToStr, ttl),
+		connectionsByAgentId: redistool.NewExpiringHash(backend, connectionsByAgentIdHashKey(agentKeyPrefix), int64ToStr, ttl),
+		connectedAgents:      redistool.NewExpiringHash(backend, connectedAgentsHashKey(agentKeyPrefix), int64ToStr, ttl),
+		connections:          redistool.NewExpiringHash(backend, connectionsHashKey(agentKeyPrefix), int64ToStr, ttl),
+		connectionHistory:    redistool.NewExpiringHash(backend, connectionHistoryHashKey(agentKeyPrefix), int64ToStr, historyTtl),
+		unregistered:         redistool.NewExpiringHash(backend, unregisteredConnectionsHashKey(agentKeyPrefix), int64ToStr, ttl),
+		registered:           make(map[int64]map[int64]time.Time),
 	}
 }
 
-func (t *RedisTracker) Run(ctx context.Context) error {
+func (t *StorageTracker) Run(ctx context.Context) error {
 	refreshTicker := time.NewTicker(t.refreshPeriod)
 	defer refreshTicker.Stop()
 	gcTicker := time.NewTicker(t.gcPeriod)
@@ -87,14 +116,28 @@ func (t *RedisTracker) Run(ctx context.Context) error {
 	}
 }
 
-func (t *RedisTracker) RegisterConnection(ctx context.Context, info *ConnectedAgentInfo) error {
+func (t *StorageTracker) RegisterConnection(ctx context.Context, info *ConnectedAgentInfo) error {
 	infoBytes, err := proto.Marshal(info)
 	if err != nil {
 		// This should never happen
 		return fmt.Errorf("failed to marshal object: %w", err)
 	}
+	historyBytes, err := proto.Marshal(&ConnectionHistoryEntry{
+		Info:       info,
+		LastSeenAt: timestamppb.Now(),
+	})
+	if err != nil {
+		// This should never happen
+		return fmt.Errorf("failed to marshal object: %w", err)
+	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	conns := t.registered[info.AgentId]
+	if conns == nil {
+		conns = make(map[int64]time.Time, 1)
+		t.registered[info.AgentId] = conns
+	}
+	conns[info.ConnectionId] = time.Now()
 	var wg errgroup.Group
 	// wg.Go(func() error {
 	//	return t.connectionsByClusterId.Set(ctx, info.ProjectId, info.ConnectionId, infoBytes)
@@ -103,14 +146,64 @@ func (t *RedisTracker) RegisterConnection(ctx context.Context, info *ConnectedAg
 		return t.connectionsByAgentId.Set(ctx, info.AgentId, info.ConnectionId, infoBytes)
 	})
 	wg.Go(func() error {
-		return t.connectedAgents.Set(ctx, connectedAgentsKey, info.AgentId, nil)
+		return t.connectedAgents.Set(ctx, connectedAgentsKey, info.AgentId, []byte(info.ClusterId))
+	})
+	wg.Go(func() error {
+		return t.connections.Set(ctx, connectionsKey, info.ConnectionId, infoBytes)
+	})
+	wg.Go(func() error {
+		return t.setHistoryEntry(ctx, info.AgentId, info.ConnectionId, historyBytes)
 	})
 	return wg.Wait()
 }
 
-func (t *RedisTracker) UnregisterConnection(ctx context.Context, info *ConnectedAgentInfo) error {
+func (t *StorageTracker) UnregisterConnection(ctx context.Context, info *ConnectedAgentInfo, reason DisconnectReason) error {
+	// Keep what we know about the connection and only record how it ended.
+	entry := &ConnectionHistoryEntry{
+		Info: info,
+	}
+	err := t.getConnectionHistory(ctx, info.AgentId, func(e *ConnectionHistoryEntry) (bool, error) {
+		if e.Info.GetConnectionId() != info.ConnectionId {
+			return false, nil
+		}
+		entry = e
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	entry.DisconnectedAt = timestamppb.Now()
+	entry.DisconnectReason = reason
+	historyBytes, err := proto.Marshal(entry)
+	if err != nil {
+		// This should never happen
+		return fmt.Errorf("failed to marshal object: %w", err)
+	}
+	err = t.unregisterConnection(ctx, info, historyBytes)
+	if err != nil {
+		return err
+	}
+	// Delete the agent from connected agents if it has no connections left, through any kas replica.
+	hasConnections := false
+	err = t.GetConnectionsByAgentId(ctx, info.AgentId, func(*ConnectedAgentInfo) (bool, error) {
+		hasConnections = true
+		return true, nil
+	})
+	if err != nil || hasConnections {
+		return err
+	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	if len(t.registered[info.AgentId]) > 0 {
+		return nil // registered concurrently
+	}
+	return t.connectedAgents.Unset(ctx, connectedAgentsKey, info.AgentId)
+}
+
+func (t *StorageTracker) unregisterConnection(ctx context.Context, info *ConnectedAgentInfo, historyBytes []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.untrackLocked(info.AgentId, info.ConnectionId)
 	var wg errgroup.Group
 	// wg.Go(func() error {
 	//	return t.connectionsByClusterId.Unset(ctx, info.ProjectId, info.ConnectionId)
@@ -118,38 +211,169 @@ func (t *RedisTracker) UnregisterConnection(ctx context.Context, info *Connected
 	wg.Go(func() error {
 		return t.connectionsByAgentId.Unset(ctx, info.AgentId, info.ConnectionId)
 	})
-	t.connectedAgents.Forget(connectedAgentsKey, info.AgentId)
+	wg.Go(func() error {
+		return t.connections.Unset(ctx, connectionsKey, info.ConnectionId)
+	})
+	wg.Go(func() error {
+		return t.setHistoryEntry(ctx, info.AgentId, info.ConnectionId, historyBytes)
+	})
+	wg.Go(func() error {
+		err := t.unregistered.Set(ctx, unregisteredConnections, info.ConnectionId, historyBytes)
+		t.unregistered.Forget(unregisteredConnections, info.ConnectionId)
+		return err
+	})
 	return wg.Wait()
 }
 
-func (t *RedisTracker) GetConnectionsByAgentId(ctx context.Context, agentId int64, cb ConnectedAgentInfoCallback) error {
+// untrackLocked removes the connection from the registered ones.
+// The agent is not refreshed anymore if it has no connections left.
+func (t *StorageTracker) untrackLocked(agentId, connectionId int64) {
+	conns := t.registered[agentId]
+	delete(conns, connectionId)
+	if len(conns) == 0 {
+		delete(t.registered, agentId)
+		t.connectedAgents.Forget(connectedAgentsKey, agentId)
+	}
+}
+
+// setHistoryEntry sets the entry and forgets it right away so that it is not refreshed and expires after the history TTL.
+// Must be called with mu held.
+func (t *StorageTracker) setHistoryEntry(ctx context.Context, agentId, connectionId int64, entryBytes []byte) error {
+	err := t.connectionHistory.Set(ctx, agentId, connectionId, entryBytes)
+	t.connectionHistory.Forget(agentId, connectionId)
+	return err
+}
+
+func (t *StorageTracker) GetConnectionsByAgentId(ctx context.Context, agentId int64, cb ConnectedAgentInfoCallback) error {
 	return t.getConnectionsByKey(ctx, t.connectionsByAgentId, agentId, cb)
 }
 
-func (t *RedisTracker) GetConnectedAgentsCount(ctx context.Context) (int64, error) {
+func (t *StorageTracker) GetConnections(ctx context.Context, cb ConnectedAgentInfoCallback) error {
+	return t.getConnectionsByKey(ctx, t.connections, connectionsKey, cb)
+}
+
+func (t *StorageTracker) GetConnectedAgentsCount(ctx context.Context) (int64, error) {
 	return t.connectedAgents.Len(ctx, connectedAgentsKey)
 }
 
-func (t *RedisTracker) refreshRegistrations(ctx context.Context, nextRefresh time.Time) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	// Run refreshes concurrently to release mu ASAP.
+func (t *StorageTracker) GetConnectedAgents(ctx context.Context, cb ConnectedAgentCallback) error {
+	_, err := t.connectedAgents.Scan(ctx, connectedAgentsKey, func(rawHashKey string, value []byte, err error) (bool, error) {
+		if err != nil {
+			t.errRep.HandleProcessingError(ctx, t.log, "Redis hash scan", err)
+			return false, nil
+		}
+		agentId, err := strconv.ParseInt(rawHashKey, 10, 64)
+		if err != nil {
+			t.errRep.HandleProcessingError(ctx, t.log, "Redis hash key parse", err)
+			return false, nil
+		}
+		if len(value) == 0 {
+			// Registered by an older kas that doesn't store the cluster id.
+			return false, nil
+		}
+		return cb(agentId, string(value))
+	})
+	return err
+}
+
+// GetConnectionHistory calls cb for each connection of the agent in the history.
+// Connections that were not unregistered but are not tracked anymore are reported as expired.
+func (t *StorageTracker) GetConnectionHistory(ctx context.Context, agentId int64, cb ConnectionHistoryCallback) error {
+	active := map[int64]struct{}{}
+	err := t.GetConnectionsByAgentId(ctx, agentId, func(info *ConnectedAgentInfo) (bool, error) {
+		active[info.ConnectionId] = struct{}{}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	return t.getConnectionHistory(ctx, agentId, func(entry *ConnectionHistoryEntry) (bool, error) {
+		if entry.DisconnectReason == DisconnectReason_unknown {
+			if _, ok := active[entry.Info.GetConnectionId()]; !ok {
+				entry.DisconnectReason = DisconnectReason_expired
+			}
+		}
+		return cb(entry)
+	})
+}
+
+func (t *StorageTracker) getConnectionHistory(ctx context.Context, agentId int64, cb ConnectionHistoryCallback) error {
+	_, err := t.connectionHistory.Scan(ctx, agentId, func(rawHashKey string, value []byte, err error) (bool, error) {
+		if err != nil {
+			t.errRep.HandleProcessingError(ctx, t.log, "Redis hash scan", err)
+			return false, nil
+		}
+		var entry ConnectionHistoryEntry
+		err = proto.Unmarshal(value, &entry)
+		if err != nil {
+			t.errRep.HandleProcessingError(ctx, t.log, "Redis proto.Unmarshal(ConnectionHistoryEntry)", err)
+			return false, nil
+		}
+		return cb(&entry)
+	})
+	return err
+}
+
+func (t *StorageTracker) refreshRegistrations(ctx context.Context, nextRefresh time.Time) {
+	unregistered, err := t.getUnregistered(ctx)
+	if err != nil {
+		t.errRep.HandleProcessingError(ctx, t.log, "Failed to get unregistered connections from Redis", err)
+		// continue anyway
+	}
+	var refreshFuncs []func(context.Context) error
+	func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		// Don't bring back connections that have been unregistered through another kas replica.
+		for _, entry := range unregistered {
+			agentId := entry.Info.GetAgentId()
+			registeredAt, ok := t.registered[agentId][entry.Info.GetConnectionId()]
+			if ok && registeredAt.Before(entry.DisconnectedAt.AsTime()) {
+				t.connectionsByAgentId.Forget(agentId, entry.Info.GetConnectionId())
+				t.connections.Forget(connectionsKey, entry.Info.GetConnectionId())
+				t.untrackLocked(agentId, entry.Info.GetConnectionId())
+			}
+		}
+		refreshFuncs = []func(context.Context) error{
+			t.connectionsByAgentId.Refresh(nextRefresh),
+			t.connectedAgents.Refresh(nextRefresh),
+			t.connections.Refresh(nextRefresh),
+		}
+	}()
+	// Run refreshes concurrently, without holding mu so that registrations are not blocked by slow IO.
 	var wg wait.Group
-	t.refreshHash(ctx, &wg, t.connectionsByAgentId, nextRefresh)
-	t.refreshHash(ctx, &wg, t.connectedAgents, nextRefresh)
+	for _, refresh := range refreshFuncs {
+		wg.Start(func() {
+			err := refresh(ctx)
+			if err != nil {
+				t.errRep.HandleProcessingError(ctx, t.log, "Failed to refresh hash data in Redis", err)
+			}
+		})
+	}
 	wg.Wait()
 }
 
-func (t *RedisTracker) refreshHash(ctx context.Context, wg *wait.Group, h redistool.ExpiringHash[int64, int64], nextRefresh time.Time) {
-	wg.Start(func() {
-		err := h.Refresh(ctx, nextRefresh)
+// getUnregistered returns connections that have been unregistered recently.
+func (t *StorageTracker) getUnregistered(ctx context.Context) ([]*ConnectionHistoryEntry, error) {
+	var entries []*ConnectionHistoryEntry
+	_, err := t.unregistered.Scan(ctx, unregisteredConnections, func(rawHashKey string, value []byte, err error) (bool, error) {
 		if err != nil {
-			t.errRep.HandleProcessingError(ctx, t.log, "Failed to refresh hash data in Redis", err)
+			t.errRep.HandleProcessingError(ctx, t.log, "Redis hash scan", err)
+			return false, nil
 		}
+		var entry ConnectionHistoryEntry
+		err = proto.Unmarshal(value, &entry)
+		if err != nil {
+			t.errRep.HandleProcessingError(ctx, t.log, "Redis proto.Unmarshal(ConnectionHistoryEntry)", err)
+			return false, nil
+		}
+		entries = append(entries, &entry)
+		return false, nil
 	})
+	return entries, err
 }
 
-func (t *RedisTracker) runGC(ctx context.Context) int {
+func (t *StorageTracker) runGC(ctx context.Context) int {
 	var gcFuncs []func(context.Context) (int, error)
 	func() {
 		t.mu.Lock()
@@ -157,6 +381,7 @@ func (t *RedisTracker) runGC(ctx context.Context) int {
 		gcFuncs = []func(context.Context) (int, error){
 			t.connectionsByAgentId.GC(),
 			t.connectedAgents.GC(),
+			t.connections.GC(),
 		}
 	}()
 	keysDeleted := 0
@@ -177,7 +402,7 @@ func (t *RedisTracker) runGC(ctx context.Context) int {
 	return keysDeleted
 }
 
-func (t *RedisTracker) getConnectionsByKey(ctx context.Context, hash redistool.ExpiringHash[int64, int64], key int64, cb ConnectedAgentInfoCallback) error {
+func (t *StorageTracker) getConnectionsByKey(ctx context.Context, hash redistool.ExpiringHash[int64, int64], key int64, cb ConnectedAgentInfoCallback) error {
 	_, err := hash.Scan(ctx, key, func(rawHashKey string, value []byte, err error) (bool, error) {
 		if err != nil {
 			t.errRep.HandleProcessingError(ctx, t.log, "Redis hash scan", err)
@@ -202,6 +427,30 @@ func connectionsByAgentIdHashKey(agentKeyPrefix string) redistool.KeyToRedisKey[
 	}
 }
 
+// connectionHistoryHashKey returns a key for agentId -> (connectionId -> marshaled ConnectionHistoryEntry).
+func connectionHistoryHashKey(agentKeyPrefix string) redistool.KeyToRedisKey[int64] {
+	prefix := agentKeyPrefix + ":conn_history_by_agent_id:"
+	return func(agentId int64) string {
+		return redistool.PrefixedInt64Key(prefix, agentId)
+	}
+}
+
+// connectionsHashKey returns the key for the hash of connections of all agents.
+func connectionsHashKey(agentKeyPrefix string) redistool.KeyToRedisKey[int64] {
+	prefix := agentKeyPrefix + ":connections"
+	return func(_ int64) string {
+		return prefix
+	}
+}
+
+// unregisteredConnectionsHashKey returns the key for the hash of recently unregistered connections.
+func unregisteredConnectionsHashKey(agentKeyPrefix string) redistool.KeyToRedisKey[int64] {
+	prefix := agentKeyPrefix + ":unregistered_connections"
+	return func(_ int64) string {
+		return prefix
+	}
+}
+
 // connectedAgentsHashKey returns the key for the hash of connected agents.
 func connectedAgentsHashKey(agentKeyPrefix string) redistool.KeyToRedisKey[int64] {
 	prefix := agentKeyPrefix + ":connected_agents"
@@ -3,6 +3,8 @@ package agent_tracker
 import (
 	"context"
 	"errors"
+	"maps"
+	"slices"
 	"testing"
 	"time"
 
@@ -23,21 +25,27 @@ import (
 )
 
 var (
-	_ Registerer                 = &RedisTracker{}
-	_ Querier                    = &RedisTracker{}
-	_ Tracker                    = &RedisTracker{}
+	_ Registerer                 = &StorageTracker{}
+	_ Querier                    = &StorageTracker{}
+	_ Tracker                    = &StorageTracker{}
 	_ ConnectedAgentInfoCallback = (&ConnectedAgentInfoCollector{}).Collect
 )
 
 func TestRegisterConnection_HappyPath(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	r, connectedAgents, byAgentId, _, info := setupTracker(t)
+	r, connectedAgents, byAgentId, history, _, info := setupTracker(t)
 
 	byAgentId.EXPECT().
 		Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any())
 	connectedAgents.EXPECT().
-		Set(gomock.Any(), connectedAgentsKey, info.AgentId, gomock.Any())
+		Set(gomock.Any(), connectedAgentsKey, info.AgentId, []byte(info.ClusterId))
+	gomock.InOrder(
+		history.EXPECT().
+			Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any()),
+		history.EXPECT().
+			Forget(info.AgentId, info.ConnectionId),
+	)
 
 	go func() {
 		assert.NoError(t, r.RegisterConnection(context.Background(), info))
@@ -50,7 +58,7 @@ func TestRegisterConnection_HappyPath(t *testing.T) {
 func TestRegisterConnection_AllCalledOnError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	r, connectedAgents, byAgentId, _, info := setupTracker(t)
+	r, connectedAgents, byAgentId, history, _, info := setupTracker(t)
 
 	err1 := errors.New("err1")
 	err2 := errors.New("err2")
@@ -62,6 +70,13 @@ func TestRegisterConnection_AllCalledOnError(t *testing.T) {
 	connectedAgents.EXPECT().
 		Set(gomock.Any(), connectedAgentsKey, info.AgentId, gomock.Any()).
 		Return(err3)
+	gomock.InOrder(
+		history.EXPECT().
+			Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any()).
+			Return(err1),
+		history.EXPECT().
+			Forget(info.AgentId, info.ConnectionId),
+	)
 
 	go func() {
 		err := r.RegisterConnection(context.Background(), info)
@@ -76,23 +91,57 @@ func TestRegisterConnection_AllCalledOnError(t *testing.T) {
 func TestUnregisterConnection_HappyPath(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	r, connectedAgents, byAgentId, _, info := setupTracker(t)
+	r, connectedAgents, byAgentId, history, _, info := setupTracker(t)
 
 	gomock.InOrder(
 		byAgentId.EXPECT().
 			Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any()),
 		byAgentId.EXPECT().
 			Unset(gomock.Any(), info.AgentId, info.ConnectionId),
+		byAgentId.EXPECT().
+			Scan(gomock.Any(), info.AgentId, gomock.Any()), // no connections left
 	)
 	gomock.InOrder(
 		connectedAgents.EXPECT().
 			Set(gomock.Any(), connectedAgentsKey, info.AgentId, gomock.Any()),
 		connectedAgents.EXPECT().
 			Forget(connectedAgentsKey, info.AgentId),
+		connectedAgents.EXPECT().
+			Unset(gomock.Any(), connectedAgentsKey, info.AgentId),
+	)
+	var registered []byte
+	gomock.InOrder(
+		history.EXPECT().
+			Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key int64, hashKey int64, value []byte) error {
+				registered = value
+				return nil
+			}),
+		history.EXPECT().
+			Forget(info.AgentId, info.ConnectionId),
+		history.EXPECT().
+			Scan(gomock.Any(), info.AgentId, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key int64, cb redistool.ScanCallback) (int, error) {
+				_, err := cb("123", registered, nil)
+				return 0, err
+			}),
+		history.EXPECT().
+			Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key int64, hashKey int64, value []byte) error {
+				var entry ConnectionHistoryEntry
+				require.NoError(t, proto.Unmarshal(value, &entry))
+				assert.Empty(t, cmp.Diff(info, entry.Info, protocmp.Transform()))
+				assert.NotNil(t, entry.LastSeenAt)
+				assert.NotNil(t, entry.DisconnectedAt)
+				assert.Equal(t, DisconnectReason_agent_shutdown, entry.DisconnectReason)
+				return nil
+			}),
+		history.EXPECT().
+			Forget(info.AgentId, info.ConnectionId),
 	)
 	go func() {
 		assert.NoError(t, r.RegisterConnection(context.Background(), info))
-		assert.NoError(t, r.UnregisterConnection(context.Background(), info))
+		assert.NoError(t, r.UnregisterConnection(context.Background(), info, DisconnectReason_agent_shutdown))
 		cancel()
 	}()
 
@@ -102,7 +151,7 @@ func TestUnregisterConnection_HappyPath(t *testing.T) {
 func TestUnregisterConnection_AllCalledOnError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	r, connectedAgents, byAgentId, _, info := setupTracker(t)
+	r, connectedAgents, byAgentId, history, _, info := setupTracker(t)
 
 	err1 := errors.New("err1")
 	err2 := errors.New("err2")
@@ -120,10 +169,23 @@ func TestUnregisterConnection_AllCalledOnError(t *testing.T) {
 		connectedAgents.EXPECT().
 			Forget(connectedAgentsKey, info.AgentId),
 	)
+	gomock.InOrder(
+		history.EXPECT().
+			Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any()),
+		history.EXPECT().
+			Forget(info.AgentId, info.ConnectionId),
+		history.EXPECT().
+			Scan(gomock.Any(), info.AgentId, gomock.Any()),
+		history.EXPECT().
+			Set(gomock.Any(), info.AgentId, info.ConnectionId, gomock.Any()).
+			Return(err1),
+		history.EXPECT().
+			Forget(info.AgentId, info.ConnectionId),
+	)
 
 	go func() {
 		assert.NoError(t, r.RegisterConnection(context.Background(), info))
-		err := r.UnregisterConnection(context.Background(), info)
+		err := r.UnregisterConnection(context.Background(), info, DisconnectReason_agent_shutdown)
 		assert.True(t, errors.Is(err, err1) || errors.Is(err, err2), err)
 		cancel()
 	}()
@@ -131,8 +193,55 @@ func TestUnregisterConnection_AllCalledOnError(t *testing.T) {
 	require.NoError(t, r.Run(ctx))
 }
 
+func TestUnregisterConnection_OtherReplicaStopsRefreshing(t *testing.T) {
+	a, b := setupReplicas(t)
+	info := connInfo()
+	other := connInfo()
+	other.ConnectionId++
+	other.AgentId++
+	require.NoError(t, a.RegisterConnection(context.Background(), info))
+	require.NoError(t, a.RegisterConnection(context.Background(), other))
+	// The agent unregisters through another kas replica.
+	require.NoError(t, b.UnregisterConnection(context.Background(), info, DisconnectReason_agent_shutdown))
+	a.refreshRegistrations(context.Background(), time.Now().Add(time.Hour)) // refresh everything
+
+	var infos ConnectedAgentInfoCollector
+	require.NoError(t, a.GetConnectionsByAgentId(context.Background(), info.AgentId, infos.Collect))
+	assert.Empty(t, infos)
+	require.NoError(t, a.GetConnections(context.Background(), infos.Collect))
+	require.Len(t, infos, 1)
+	assert.Equal(t, other.ConnectionId, infos[0].ConnectionId)
+	agentIds := map[int64]string{}
+	require.NoError(t, a.GetConnectedAgents(context.Background(), func(agentId int64, clusterId string) (bool, error) {
+		agentIds[agentId] = clusterId
+		return false, nil
+	}))
+	assert.Equal(t, map[int64]string{other.AgentId: other.ClusterId}, agentIds)
+	a.mu.Lock()
+	assert.Equal(t, []int64{other.AgentId}, slices.Collect(maps.Keys(a.registered)))
+	a.mu.Unlock()
+}
+
+func TestUnregisterConnection_KeepsAgentWithOtherConnections(t *testing.T) {
+	a, b := setupReplicas(t)
+	info := connInfo()
+	other := connInfo()
+	other.ConnectionId++
+	require.NoError(t, a.RegisterConnection(context.Background(), info))
+	require.NoError(t, b.RegisterConnection(context.Background(), other))
+	require.NoError(t, b.UnregisterConnection(context.Background(), info, DisconnectReason_agent_shutdown))
+
+	size, err := a.GetConnectedAgentsCount(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, size)
+	var infos ConnectedAgentInfoCollector
+	require.NoError(t, a.GetConnections(context.Background(), infos.Collect))
+	require.Len(t, infos, 1)
+	assert.Equal(t, other.ConnectionId, infos[0].ConnectionId)
+}
+
 func TestGC_HappyPath(t *testing.T) {
-	r, connectedAgents, byAgentId, _, _ := setupTracker(t)
+	r, connectedAgents, byAgentId, _, _, _ := setupTracker(t)
 
 	wasCalled1 := false
 	wasCalled2 := false
@@ -157,7 +266,7 @@ func TestGC_HappyPath(t *testing.T) {
 }
 
 func TestGC_AllCalledOnError(t *testing.T) {
-	r, connectedAgents, byAgentId, rep, _ := setupTracker(t)
+	r, connectedAgents, byAgentId, _, rep, _ := setupTracker(t)
 
 	wasCalled2 := false
 	wasCalled3 := false
@@ -190,29 +299,31 @@ func TestGC_AllCalledOnError(t *testing.T) {
 }
 
 func TestRefresh_HappyPath(t *testing.T) {
-	r, connectedAgents, byAgentId, _, _ := setupTracker(t)
+	r, connectedAgents, byAgentId, _, _, _ := setupTracker(t)
 
 	connectedAgents.EXPECT().
-		Refresh(gomock.Any(), gomock.Any())
+		Refresh(gomock.Any()).
+		Return(func(context.Context) error { return nil })
 	byAgentId.EXPECT().
-		Refresh(gomock.Any(), gomock.Any())
+		Refresh(gomock.Any()).
+		Return(func(context.Context) error { return nil })
 	r.refreshRegistrations(context.Background(), time.Now())
 }
 
 func TestRefresh_AllCalledOnError(t *testing.T) {
-	r, connectedAgents, byAgentId, rep, _ := setupTracker(t)
+	r, connectedAgents, byAgentId, _, rep, _ := setupTracker(t)
 
 	gomock.InOrder(
 		connectedAgents.EXPECT().
-			Refresh(gomock.Any(), gomock.Any()).
-			Return(errors.New("err3")),
+			Refresh(gomock.Any()).
+			Return(func(context.Context) error { return errors.New("err3") }),
 		rep.EXPECT().
 			HandleProcessingError(gomock.Any(), gomock.Any(), "Failed to refresh hash data in Redis", matcher.ErrorEq("err3")),
 	)
 	gomock.InOrder(
 		byAgentId.EXPECT().
-			Refresh(gomock.Any(), gomock.Any()).
-			Return(errors.New("err1")),
+			Refresh(gomock.Any()).
+			Return(func(context.Context) error { return errors.New("err1") }),
 		rep.EXPECT().
 			HandleProcessingError(gomock.Any(), gomock.Any(), "Failed to refresh hash data in Redis", matcher.ErrorEq("err1")),
 	)
@@ -220,7 +331,7 @@ func TestRefresh_AllCalledOnError(t *testing.T) {
 }
 
 func TestGetConnectionsByAgentId_HappyPath(t *testing.T) {
-	r, _, byAgentId, _, info := setupTracker(t)
+	r, _, byAgentId, _, _, info := setupTracker(t)
 	infoBytes, err := proto.Marshal(info)
 	require.NoError(t, err)
 	byAgentId.EXPECT().
@@ -244,7 +355,7 @@ func TestGetConnectionsByAgentId_HappyPath(t *testing.T) {
 }
 
 func TestGetConnectionsByAgentId_ScanError(t *testing.T) {
-	r, _, byAgentId, rep, info := setupTracker(t)
+	r, _, byAgentId, _, rep, info := setupTracker(t)
 	gomock.InOrder(
 		byAgentId.EXPECT().
 			Scan(gomock.Any(), info.AgentId, gomock.Any()).
@@ -265,7 +376,7 @@ func TestGetConnectionsByAgentId_ScanError(t *testing.T) {
 }
 
 func TestGetConnectionsByAgentId_UnmarshalError(t *testing.T) {
-	r, _, byAgentId, rep, info := setupTracker(t)
+	r, _, byAgentId, _, rep, info := setupTracker(t)
 	byAgentId.EXPECT().
 		Scan(gomock.Any(), info.AgentId, gomock.Any()).
 		Do(func(ctx context.Context, key int64, cb redistool.ScanCallback) (int, error) {
@@ -284,7 +395,7 @@ func TestGetConnectionsByAgentId_UnmarshalError(t *testing.T) {
 }
 
 func TestGetConnectedAgentsCount_HappyPath(t *testing.T) {
-	r, connectedAgents, _, _, _ := setupTracker(t)
+	r, connectedAgents, _, _, _, _ := setupTracker(t)
 	connectedAgents.EXPECT().
 		Len(gomock.Any(), connectedAgentsKey).
 		Return(int64(1), nil)
@@ -294,7 +405,7 @@ func TestGetConnectedAgentsCount_HappyPath(t *testing.T) {
 }
 
 func TestGetConnectedAgentsCount_LenError(t *testing.T) {
-	r, connectedAgents, _, _, _ := setupTracker(t)
+	r, connectedAgents, _, _, _, _ := setupTracker(t)
 	connectedAgents.EXPECT().
 		Len(gomock.Any(), connectedAgentsKey).
 		Return(int64(0), errors.New("intended error"))
@@ -303,20 +414,130 @@ func TestGetConnectedAgentsCount_LenError(t *testing.T) {
 	assert.Zero(t, size)
 }
 
-func setupTracker(t *testing.T) (*RedisTracker, *mock_redis.MockExpiringHash[int64, int64], *mock_redis.MockExpiringHash[int64, int64], *mock_tool.MockErrReporter, *ConnectedAgentInfo) {
+func TestGetConnectedAgents_HappyPath(t *testing.T) {
+	r, connectedAgents, _, _, _, info := setupTracker(t)
+	connectedAgents.EXPECT().
+		Scan(gomock.Any(), connectedAgentsKey, gomock.Any()).
+		Do(func(ctx context.Context, key int64, cb redistool.ScanCallback) (int, error) {
+			done, err := cb("345", []byte(info.ClusterId), nil)
+			require.NoError(t, err)
+			assert.False(t, done)
+			done, err = cb("346", nil, nil) // no cluster id
+			require.NoError(t, err)
+			assert.False(t, done)
+			return 0, nil
+		})
+	var cbCalled int
+	err := r.GetConnectedAgents(context.Background(), func(agentId int64, clusterId string) (done bool, err error) {
+		cbCalled++
+		assert.EqualValues(t, 345, agentId)
+		assert.Equal(t, info.ClusterId, clusterId)
+		return false, nil
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, cbCalled)
+}
+
+func TestGetConnectedAgents_InvalidKey(t *testing.T) {
+	r, connectedAgents, _, _, rep, _ := setupTracker(t)
+	gomock.InOrder(
+		connectedAgents.EXPECT().
+			Scan(gomock.Any(), connectedAgentsKey, gomock.Any()).
+			Do(func(ctx context.Context, key int64, cb redistool.ScanCallback) (int, error) {
+				done, err := cb("abc", []byte("456"), nil)
+				require.NoError(t, err) // ignores error to keep going
+				assert.False(t, done)
+				return 0, nil
+			}),
+		rep.EXPECT().
+			HandleProcessingError(gomock.Any(), gomock.Any(), "Redis hash key parse", gomock.Any()),
+	)
+	err := r.GetConnectedAgents(context.Background(), func(agentId int64, clusterId string) (done bool, err error) {
+		require.FailNow(t, "unexpected call")
+		return false, nil
+	})
+	require.NoError(t, err)
+}
+
+func TestGetConnectionHistory_ReportsExpiredConnections(t *testing.T) {
+	r, _, byAgentId, history, _, info := setupTracker(t)
+	infoBytes, err := proto.Marshal(info)
+	require.NoError(t, err)
+	entry := func(connectionId int64, reason DisconnectReason) []byte {
+		i := proto.Clone(info).(*ConnectedAgentInfo)
+		i.ConnectionId = connectionId
+		data, marshalErr := proto.Marshal(&ConnectionHistoryEntry{
+			Info:             i,
+			LastSeenAt:       timestamppb.Now(),
+			DisconnectReason: reason,
+		})
+		require.NoError(t, marshalErr)
+		return data
+	}
+	byAgentId.EXPECT().
+		Scan(gomock.Any(), info.AgentId, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key int64, cb redistool.ScanCallback) (int, error) {
+			_, cbErr := cb("123", infoBytes, nil)
+			return 0, cbErr
+		})
+	history.EXPECT().
+		Scan(gomock.Any(), info.AgentId, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key int64, cb redistool.ScanCallback) (int, error) {
+			for _, e := range [][]byte{
+				entry(info.ConnectionId, DisconnectReason_unknown), // active
+				entry(124, DisconnectReason_unknown),               // not active anymore
+				entry(125, DisconnectReason_agent_shutdown),
+			} {
+				_, cbErr := cb("", e, nil)
+				require.NoError(t, cbErr)
+			}
+			return 0, nil
+		})
+	reasons := map[int64]DisconnectReason{}
+	err = r.GetConnectionHistory(context.Background(), info.AgentId, func(e *ConnectionHistoryEntry) (bool, error) {
+		reasons[e.Info.ConnectionId] = e.DisconnectReason
+		return false, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]DisconnectReason{
+		info.ConnectionId: DisconnectReason_unknown,
+		124:               DisconnectReason_expired,
+		125:               DisconnectReason_agent_shutdown,
+	}, reasons)
+}
+
+func setupTracker(t *testing.T) (*StorageTracker, *mock_redis.MockExpiringHash[int64, int64], *mock_redis.MockExpiringHash[int64, int64],
+	*mock_redis.MockExpiringHash[int64, int64], *mock_tool.MockErrReporter, *ConnectedAgentInfo) {
 	ctrl := gomock.NewController(t)
 	rep := mock_tool.NewMockErrReporter(ctrl)
 	connectedAgents := mock_redis.NewMockExpiringHash[int64, int64](ctrl)
 	byAgentId := mock_redis.NewMockExpiringHash[int64, int64](ctrl)
-	tr := &RedisTracker{
+	history := mock_redis.NewMockExpiringHash[int64, int64](ctrl)
+	store := redistool.NewMemoryStore()
+	tr := &StorageTracker{
 		log:                  zaptest.NewLogger(t),
 		errRep:               rep,
 		refreshPeriod:        time.Minute,
 		gcPeriod:             time.Minute,
 		connectionsByAgentId: byAgentId,
 		connectedAgents:      connectedAgents,
+		connections:          redistool.NewStoreExpiringHash[int64, int64](store, connectionsHashKey("p"), int64ToStr, time.Minute),
+		connectionHistory:    history,
+		unregistered:         redistool.NewStoreExpiringHash[int64, int64](store, unregisteredConnectionsHashKey("p"), int64ToStr, time.Minute),
+		registered:           make(map[int64]map[int64]time.Time),
+	}
+	return tr, connectedAgents, byAgentId, history, rep, connInfo()
+}
+
+// setupReplicas returns trackers of two kas replicas that share storage.
+func setupReplicas(t *testing.T) (*StorageTracker, *StorageTracker) {
+	ctrl := gomock.NewController(t)
+	backend := redistool.Backend{Store: redistool.NewMemoryStore()}
+	newTracker := func() *StorageTracker {
+		return NewStorageTracker(zaptest.NewLogger(t), mock_tool.NewMockErrReporter(ctrl), backend, "p",
+			time.Minute, time.Minute, time.Minute, time.Hour)
 	}
-	return tr, connectedAgents, byAgentId, rep, connInfo()
+	return newTracker(), newTracker()
 }
 
 func connInfo() *ConnectedAgentInfo {
@@ -0,0 +1,428 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+const (
+	dryRunStrategyNone   = "none"
+	dryRunStrategyClient = "client"
+	dryRunStrategyServer = "server"
+
+	inventoryPolicyMustMatch          = "must_match"
+	inventoryPolicyAdoptIfNoInventory = "adopt_if_no_inventory"
+	inventoryPolicyAdoptAll           = "adopt_all"
+)
+
+var (
+	errPruneAll = errors.New("manifests have no objects, refusing to prune all objects of the inventory, set prune_empty to allow it")
+)
+
+type syncResult struct {
+	applied int
+	pruned  int
+}
+
+// applier applies the objects of a manifest project with server-side apply and prunes the objects that have been
+// removed from it.
+type applier struct {
+	log       *zap.Logger
+	client    dynamic.Interface
+	mapper    meta.RESTMapper
+	inventory *inventory
+	// defaultNamespace is used for namespaced objects without a namespace.
+	defaultNamespace string
+	dryRunStrategy   string
+	prune            bool
+	// pruneEmpty allows pruning all objects of the inventory when there are no objects to apply.
+	pruneEmpty             bool
+	prunePropagationPolicy meta_v1.DeletionPropagation
+	inventoryPolicy        string
+	reconcileTimeout       time.Duration
+	pruneTimeout           time.Duration
+	// pollInterval is how often objects are checked while waiting for them to be reconciled or deleted.
+	pollInterval time.Duration
+}
+
+type appliedObject struct {
+	key objectKey
+	gvr schema.GroupVersionResource
+	obj *unstructured.Unstructured
+}
+
+// sync applies the objects and then, if enabled and all objects have been applied, prunes the objects that are
+// in the inventory but not among the given ones.
+func (a *applier) sync(ctx context.Context, objs []*unstructured.Unstructured) (syncResult, error) {
+	if a.dryRunStrategy == dryRunStrategyClient {
+		return a.syncClientDryRun(objs)
+	}
+	dryRun := a.dryRunStrategy == dryRunStrategyServer
+	var result syncResult
+	oldKeys, err := a.inventory.load(ctx)
+	if err != nil {
+		return result, err
+	}
+	err = a.checkPruneAll(objs, oldKeys)
+	if err != nil {
+		return result, err
+	}
+	var (
+		errs           []error
+		applied        []appliedObject
+		newKeys        = make(map[objectKey]struct{}, len(objs))
+		mapperWasReset bool
+	)
+	for _, obj := range sortForApply(objs) {
+		o, err := a.prepare(obj, &mapperWasReset)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, ok := newKeys[o.key]; ok {
+			errs = append(errs, fmt.Errorf("%s: duplicate object", o.key))
+			continue
+		}
+		newKeys[o.key] = struct{}{}
+		o.obj, err = a.apply(ctx, o, dryRun)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", o.key, err))
+			continue
+		}
+		applied = append(applied, o)
+	}
+	result.applied = len(applied)
+	if len(errs) > 0 {
+		// Keep track of what has been applied but don't prune anything, the objects might only have failed to apply.
+		if !dryRun {
+			for _, o := range applied {
+				oldKeys[o.key] = struct{}{}
+			}
+			err = a.inventory.store(ctx, oldKeys)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return result, errors.Join(errs...)
+	}
+	keep := make(map[objectKey]struct{}, len(applied))
+	for _, o := range applied {
+		keep[o.key] = struct{}{}
+	}
+	if a.prune {
+		var toPrune []objectKey
+		for _, key := range sortedKeys(oldKeys) {
+			if _, ok := keep[key]; !ok {
+				toPrune = append(toPrune, key)
+			}
+		}
+		var pruneErrs []error
+		result.pruned, pruneErrs = a.pruneObjects(ctx, toPrune, keep, dryRun)
+		errs = append(errs, pruneErrs...)
+	}
+	if dryRun {
+		return result, errors.Join(errs...)
+	}
+	err = a.inventory.store(ctx, keep)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return result, errors.Join(errs...)
+	}
+	return result, a.waitForReconcile(ctx, applied)
+}
+
+// checkPruneAll returns errPruneAll if there are no objects and all objects of the inventory would be pruned,
+// unless pruneEmpty is set.
+func (a *applier) checkPruneAll(objs []*unstructured.Unstructured, oldKeys map[objectKey]struct{}) error {
+	if !a.prune || a.pruneEmpty || len(objs) > 0 || len(oldKeys) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w (%d objects)", errPruneAll, len(oldKeys))
+}
+
+// syncClientDryRun only checks that the objects can be mapped to resources, nothing is sent to the API server.
+func (a *applier) syncClientDryRun(objs []*unstructured.Unstructured) (syncResult, error) {
+	var (
+		errs           []error
+		mapperWasReset bool
+	)
+	keys := make(map[objectKey]struct{}, len(objs))
+	for _, obj := range objs {
+		o, err := a.prepare(obj, &mapperWasReset)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, ok := keys[o.key]; ok {
+			errs = append(errs, fmt.Errorf("%s: duplicate object", o.key))
+			continue
+		}
+		keys[o.key] = struct{}{}
+	}
+	return syncResult{applied: len(keys)}, errors.Join(errs...)
+}
+
+// prepare returns a copy of the object that is ready to be applied.
+func (a *applier) prepare(obj *unstructured.Unstructured, mapperWasReset *bool) (appliedObject, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) && !*mapperWasReset {
+		// The kind might be from a CRD that has just been applied.
+		if r, ok := a.mapper.(meta.ResettableRESTMapper); ok {
+			r.Reset()
+			*mapperWasReset = true
+			mapping, err = a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if err != nil {
+		return appliedObject{}, fmt.Errorf("%s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+	obj = obj.DeepCopy()
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(a.defaultNamespace)
+		}
+	} else {
+		obj.SetNamespace("")
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[owningInventoryAnnotation] = a.inventory.name
+	obj.SetAnnotations(annotations)
+	return appliedObject{
+		key: objectKey{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Group:     gvk.Group,
+			Kind:      gvk.Kind,
+		},
+		gvr: mapping.Resource,
+		obj: obj,
+	}, nil
+}
+
+func (a *applier) apply(ctx context.Context, o appliedObject, dryRun bool) (*unstructured.Unstructured, error) {
+	client := a.client.Resource(o.gvr).Namespace(o.key.Namespace)
+	live, err := client.Get(ctx, o.key.Name, meta_v1.GetOptions{})
+	switch {
+	case err == nil:
+		err = a.checkInventoryPolicy(live)
+		if err != nil {
+			return nil, err
+		}
+	case apierrors.IsNotFound(err):
+	default:
+		return nil, err
+	}
+	opts := meta_v1.ApplyOptions{
+		FieldManager: modagent.FieldManager,
+		// The manifests are the source of truth, take over fields that others have set.
+		Force: true,
+	}
+	if dryRun {
+		opts.DryRun = []string{meta_v1.DryRunAll}
+	}
+	return client.Apply(ctx, o.key.Name, o.obj, opts)
+}
+
+// checkInventoryPolicy returns an error if the live object must not be taken over.
+func (a *applier) checkInventoryPolicy(live *unstructured.Unstructured) error {
+	owner := live.GetAnnotations()[owningInventoryAnnotation]
+	if owner == a.inventory.name {
+		return nil
+	}
+	switch a.inventoryPolicy {
+	case inventoryPolicyAdoptAll:
+		return nil
+	case inventoryPolicyAdoptIfNoInventory:
+		if owner == "" {
+			return nil
+		}
+	}
+	if owner == "" {
+		return errors.New("object exists and is not managed by GitOps, set inventory_policy to adopt_if_no_inventory to take it over")
+	}
+	return fmt.Errorf("object is managed by inventory %s, set inventory_policy to adopt_all to take it over", owner)
+}
+
+// pruneObjects deletes the objects. Objects that could not be deleted are added to keep.
+func (a *applier) pruneObjects(ctx context.Context, keys []objectKey, keep map[objectKey]struct{}, dryRun bool) (int, []error) {
+	var (
+		errs    []error
+		deleted []appliedObject
+	)
+	for _, key := range sortForPrune(keys) {
+		mapping, err := a.mapper.RESTMapping(key.GroupKind())
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				continue // the kind does not exist anymore, neither does the object
+			}
+			errs = append(errs, fmt.Errorf("prune %s: %w", key, err))
+			keep[key] = struct{}{}
+			continue
+		}
+		client := a.client.Resource(mapping.Resource).Namespace(key.Namespace)
+		live, err := client.Get(ctx, key.Name, meta_v1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("prune %s: %w", key, err))
+			keep[key] = struct{}{}
+			continue
+		}
+		if live.GetAnnotations()[owningInventoryAnnotation] != a.inventory.name {
+			// Another inventory has taken the object over or it has been released manually.
+			a.log.Info("Not pruning object that is not managed by this inventory anymore", logz.ObjectKey(key.String()))
+			continue
+		}
+		uid := live.GetUID()
+		opts := meta_v1.DeleteOptions{
+			PropagationPolicy: &a.prunePropagationPolicy,
+			// Don't delete a new object with the same name.
+			Preconditions: &meta_v1.Preconditions{
+				UID: &uid,
+			},
+		}
+		if dryRun {
+			opts.DryRun = []string{meta_v1.DryRunAll}
+		}
+		err = client.Delete(ctx, key.Name, opts)
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("prune %s: %w", key, err))
+			keep[key] = struct{}{}
+			continue
+		}
+		deleted = append(deleted, appliedObject{key: key, gvr: mapping.Resource, obj: live})
+	}
+	if !dryRun {
+		err := a.waitForDeletion(ctx, deleted)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(deleted), errs
+}
+
+// waitForReconcile waits until the controllers of the objects have observed their current generation.
+// Objects without status.observedGeneration are considered reconciled.
+func (a *applier) waitForReconcile(ctx context.Context, objs []appliedObject) error {
+	var pending []appliedObject
+	for _, o := range objs {
+		if !isReconciled(o.obj) {
+			pending = append(pending, o)
+		}
+	}
+	return a.waitFor(ctx, pending, a.reconcileTimeout, "reconciled", func(o appliedObject, live *unstructured.Unstructured, err error) (bool, error) {
+		if err != nil {
+			return false, err
+		}
+		return isReconciled(live), nil
+	})
+}
+
+// waitForDeletion waits until the objects are gone.
+func (a *applier) waitForDeletion(ctx context.Context, objs []appliedObject) error {
+	return a.waitFor(ctx, objs, a.pruneTimeout, "deleted", func(o appliedObject, live *unstructured.Unstructured, err error) (bool, error) {
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return live.GetUID() != o.obj.GetUID(), nil // a new object with the same name is not the one that was deleted
+	})
+}
+
+// waitFor polls the objects until isDone returns true for all of them or timeout elapses. It does not wait if
+// timeout is zero.
+func (a *applier) waitFor(ctx context.Context, objs []appliedObject, timeout time.Duration, what string,
+	isDone func(o appliedObject, live *unstructured.Unstructured, err error) (bool, error)) error {
+	if timeout == 0 || len(objs) == 0 {
+		return nil
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	pending := objs
+	err := wait.PollUntilContextCancel(waitCtx, a.pollInterval, false, func(ctx context.Context) (bool, error) {
+		var stillPending []appliedObject
+		for _, o := range pending {
+			live, err := a.client.Resource(o.gvr).Namespace(o.key.Namespace).Get(ctx, o.key.Name, meta_v1.GetOptions{})
+			done, err := isDone(o, live, err)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", o.key, err)
+			}
+			if !done {
+				stillPending = append(stillPending, o)
+			}
+		}
+		pending = stillPending
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		if ctx.Err() == nil && waitCtx.Err() != nil {
+			return fmt.Errorf("%d object(s) have not been %s within %s, e.g. %s", len(pending), what, timeout, pending[0].key)
+		}
+		return err
+	}
+	return nil
+}
+
+func isReconciled(obj *unstructured.Unstructured) bool {
+	observedGeneration, found, err := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if err != nil || !found {
+		return true
+	}
+	return observedGeneration >= obj.GetGeneration()
+}
+
+// sortForApply returns the objects with namespaces and CRDs first so that the objects that depend on them can be
+// applied in the same sync. The order is stable otherwise.
+func sortForApply(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	result := make([]*unstructured.Unstructured, len(objs))
+	copy(result, objs)
+	sort.SliceStable(result, func(i, j int) bool {
+		return applyPriority(result[i].GroupVersionKind().GroupKind()) < applyPriority(result[j].GroupVersionKind().GroupKind())
+	})
+	return result
+}
+
+// sortForPrune returns the keys with namespaces and CRDs last, the reverse of the apply order.
+func sortForPrune(keys []objectKey) []objectKey {
+	result := make([]objectKey, len(keys))
+	copy(result, keys)
+	sort.SliceStable(result, func(i, j int) bool {
+		return applyPriority(result[i].GroupKind()) > applyPriority(result[j].GroupKind())
+	})
+	return result
+}
+
+func applyPriority(gk schema.GroupKind) int {
+	switch gk {
+	case schema.GroupKind{Kind: "Namespace"}:
+		return 0
+	case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,344 @@
+package agent
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const (
+	testNamespace     = "ns"
+	testInventoryName = "gitops-123-0123456789abcdef"
+)
+
+var (
+	namespaceGVR  = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	deploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+func TestSync_AppliesAndStoresInventory(t *testing.T) {
+	a, client := setupApplier(t)
+	result, err := a.sync(context.Background(), []*unstructured.Unstructured{
+		configMap("cm1", ""),
+		namespace("ns1"),
+		configMap("cm2", "ns1"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, syncResult{applied: 3}, result)
+
+	cm1 := getObject(t, client, configMapGVR, testNamespace, "cm1")
+	assert.Equal(t, testInventoryName, cm1.GetAnnotations()[owningInventoryAnnotation])
+	getObject(t, client, configMapGVR, "ns1", "cm2")
+	getObject(t, client, namespaceGVR, "", "ns1")
+	assert.Equal(t, []string{
+		"_ns1__Namespace",
+		"ns1_cm2__ConfigMap",
+		"ns_cm1__ConfigMap",
+	}, inventoryKeys(t, client))
+}
+
+func TestSync_Prunes(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", ""), configMap("cm2", "")})
+	require.NoError(t, err)
+
+	result, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+	assert.Equal(t, syncResult{applied: 1, pruned: 1}, result)
+	assertNotFound(t, client, configMapGVR, testNamespace, "cm2")
+	assert.Equal(t, []string{"ns_cm1__ConfigMap"}, inventoryKeys(t, client))
+}
+
+func TestSync_PruneDisabled(t *testing.T) {
+	a, client := setupApplier(t)
+	a.prune = false
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", ""), configMap("cm2", "")})
+	require.NoError(t, err)
+
+	result, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+	assert.Equal(t, syncResult{applied: 1}, result)
+	getObject(t, client, configMapGVR, testNamespace, "cm2")
+	// The object is not tracked anymore.
+	assert.Equal(t, []string{"ns_cm1__ConfigMap"}, inventoryKeys(t, client))
+}
+
+func TestSync_DoesNotPruneAllWithoutObjects(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+
+	_, err = a.sync(context.Background(), nil)
+	assert.ErrorIs(t, err, errPruneAll)
+	getObject(t, client, configMapGVR, testNamespace, "cm1")
+	assert.Equal(t, []string{"ns_cm1__ConfigMap"}, inventoryKeys(t, client))
+}
+
+func TestSync_PruneEmpty(t *testing.T) {
+	a, client := setupApplier(t)
+	a.pruneEmpty = true
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+
+	result, err := a.sync(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, syncResult{pruned: 1}, result)
+	assertNotFound(t, client, configMapGVR, testNamespace, "cm1")
+	assert.Empty(t, inventoryKeys(t, client))
+}
+
+func TestSync_DoesNotPruneOnError(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", ""), configMap("cm2", "")})
+	require.NoError(t, err)
+
+	unknown := configMap("x", "")
+	unknown.SetAPIVersion("example.com/v1")
+	unknown.SetKind("Unknown")
+	result, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", ""), unknown})
+	assert.ErrorContains(t, err, "Unknown x: ")
+	assert.Equal(t, syncResult{applied: 1}, result)
+	getObject(t, client, configMapGVR, testNamespace, "cm2")
+	assert.Equal(t, []string{"ns_cm1__ConfigMap", "ns_cm2__ConfigMap"}, inventoryKeys(t, client))
+}
+
+func TestSync_DoesNotPruneObjectsOfOtherInventories(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", ""), configMap("cm2", "")})
+	require.NoError(t, err)
+	cm2 := getObject(t, client, configMapGVR, testNamespace, "cm2")
+	cm2.SetAnnotations(map[string]string{owningInventoryAnnotation: "other"})
+	require.NoError(t, client.Tracker().Update(configMapGVR, cm2, testNamespace))
+
+	result, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+	assert.Equal(t, syncResult{applied: 1}, result)
+	getObject(t, client, configMapGVR, testNamespace, "cm2")
+}
+
+func TestSync_DuplicateObject(t *testing.T) {
+	a, _ := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", ""), configMap("cm1", testNamespace)})
+	assert.EqualError(t, err, "ns_cm1__ConfigMap: duplicate object")
+}
+
+func TestSync_InventoryPolicy(t *testing.T) {
+	tcs := []struct {
+		name    string
+		policy  string
+		owner   string
+		wantErr string
+	}{
+		{
+			name:    "must_match, not managed",
+			policy:  inventoryPolicyMustMatch,
+			wantErr: "ns_cm1__ConfigMap: object exists and is not managed by GitOps, set inventory_policy to adopt_if_no_inventory to take it over",
+		},
+		{
+			name:   "adopt_if_no_inventory, not managed",
+			policy: inventoryPolicyAdoptIfNoInventory,
+		},
+		{
+			name:    "adopt_if_no_inventory, other inventory",
+			policy:  inventoryPolicyAdoptIfNoInventory,
+			owner:   "other",
+			wantErr: "ns_cm1__ConfigMap: object is managed by inventory other, set inventory_policy to adopt_all to take it over",
+		},
+		{
+			name:   "adopt_all, other inventory",
+			policy: inventoryPolicyAdoptAll,
+			owner:  "other",
+		},
+		{
+			name:   "must_match, same inventory",
+			policy: inventoryPolicyMustMatch,
+			owner:  testInventoryName,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			live := configMap("cm1", testNamespace)
+			if tc.owner != "" {
+				live.SetAnnotations(map[string]string{owningInventoryAnnotation: tc.owner})
+			}
+			a, client := setupApplier(t, live)
+			a.inventoryPolicy = tc.policy
+			_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			cm1 := getObject(t, client, configMapGVR, testNamespace, "cm1")
+			assert.Equal(t, testInventoryName, cm1.GetAnnotations()[owningInventoryAnnotation])
+		})
+	}
+}
+
+func TestSync_ClientDryRun(t *testing.T) {
+	a, client := setupApplier(t)
+	a.dryRunStrategy = dryRunStrategyClient
+	result, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+	assert.Equal(t, syncResult{applied: 1}, result)
+	assert.Empty(t, client.Actions())
+}
+
+func TestSync_ReconcileTimeout(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"namespace":  testNamespace,
+			"name":       "d",
+			"generation": int64(1),
+		},
+		"status": map[string]any{
+			"observedGeneration": int64(1),
+		},
+	}}
+	a, _ := setupApplier(t, live)
+	a.inventoryPolicy = inventoryPolicyAdoptAll
+	a.reconcileTimeout = 50 * time.Millisecond
+	d := live.DeepCopy()
+	unstructured.RemoveNestedField(d.Object, "status")
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{d})
+	assert.EqualError(t, err, "1 object(s) have not been reconciled within 50ms, e.g. ns_d_apps_Deployment")
+}
+
+func TestSortForApply(t *testing.T) {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName("crd")
+	objs := sortForApply([]*unstructured.Unstructured{configMap("cm1", ""), crd, configMap("cm2", ""), namespace("ns1")})
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{"ns1", "crd", "cm1", "cm2"}, names)
+}
+
+func setupApplier(t *testing.T, objs ...runtime.Object) (*applier, *fake.FakeDynamicClient) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		configMapGVR:  "ConfigMapList",
+		namespaceGVR:  "NamespaceList",
+		deploymentGVR: "DeploymentList",
+	}, objs...)
+	client.PrependReactor("patch", "*", applyReactor(client.Tracker()))
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}, {Group: "apps", Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	a := &applier{
+		log:    zaptest.NewLogger(t),
+		client: client,
+		mapper: mapper,
+		inventory: &inventory{
+			client:    client,
+			namespace: testNamespace,
+			name:      testInventoryName,
+			projectId: "https://example.com/manifests.git",
+		},
+		defaultNamespace:       testNamespace,
+		dryRunStrategy:         dryRunStrategyNone,
+		prune:                  true,
+		prunePropagationPolicy: meta_v1.DeletePropagationForeground,
+		inventoryPolicy:        inventoryPolicyMustMatch,
+		reconcileTimeout:       time.Second,
+		pruneTimeout:           time.Second,
+		pollInterval:           10 * time.Millisecond,
+	}
+	return a, client
+}
+
+// applyReactor implements server-side apply by replacing the object. The object tracker of the fake client only
+// supports apply for existing objects.
+func applyReactor(tracker k8stesting.ObjectTracker) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		obj := &unstructured.Unstructured{}
+		err := obj.UnmarshalJSON(patch.GetPatch())
+		if err != nil {
+			return true, nil, err
+		}
+		gvr, ns := patch.GetResource(), patch.GetNamespace()
+		live, err := tracker.Get(gvr, ns, patch.GetName())
+		switch {
+		case err == nil:
+			liveObj := live.(*unstructured.Unstructured)
+			obj.SetUID(liveObj.GetUID())
+			obj.SetGeneration(liveObj.GetGeneration() + 1)
+			if status, ok := liveObj.Object["status"]; ok {
+				obj.Object["status"] = status
+			}
+			err = tracker.Update(gvr, obj, ns)
+		case apierrors.IsNotFound(err):
+			obj.SetUID(types.UID(ns + "/" + patch.GetName()))
+			obj.SetGeneration(1)
+			err = tracker.Create(gvr, obj, ns)
+		}
+		if err != nil {
+			return true, nil, err
+		}
+		return true, obj, nil
+	}
+}
+
+func getObject(t *testing.T, client *fake.FakeDynamicClient, gvr schema.GroupVersionResource, ns, name string) *unstructured.Unstructured {
+	obj, err := client.Resource(gvr).Namespace(ns).Get(context.Background(), name, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	return obj
+}
+
+func assertNotFound(t *testing.T, client *fake.FakeDynamicClient, gvr schema.GroupVersionResource, ns, name string) {
+	_, err := client.Resource(gvr).Namespace(ns).Get(context.Background(), name, meta_v1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), err)
+}
+
+func inventoryKeys(t *testing.T, client *fake.FakeDynamicClient) []string {
+	cm := getObject(t, client, configMapGVR, testNamespace, testInventoryName)
+	assert.Equal(t, testInventoryName, cm.GetLabels()[inventoryIdLabel])
+	data, _, err := unstructured.NestedStringMap(cm.Object, "data")
+	require.NoError(t, err)
+	var keys []string
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func configMap(name, ns string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace(ns)
+	obj.SetName(name)
+	return obj
+}
+
+func namespace(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Namespace")
+	obj.SetName(name)
+	return obj
+}
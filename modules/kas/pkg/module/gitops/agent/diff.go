@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+)
+
+// ignoredFields are set by the API server and are not part of what is applied.
+var ignoredFields = [][]string{
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"status"},
+}
+
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// diffObjects returns the fields that differ between the live and the desired object. If onlyDesired is true, fields
+// that are only in the live object are not compared i.e. the desired object is a manifest and not a full object.
+func diffObjects(live, desired *unstructured.Unstructured, onlyDesired bool) []*rpc.FieldChange {
+	l := live.DeepCopy().Object
+	d := desired.DeepCopy().Object
+	for _, field := range ignoredFields {
+		unstructured.RemoveNestedField(l, field...)
+		unstructured.RemoveNestedField(d, field...)
+	}
+	return diffMaps("", l, d, onlyDesired, nil)
+}
+
+func diffMaps(path string, live, desired map[string]any, onlyDesired bool, changes []*rpc.FieldChange) []*rpc.FieldChange {
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	if !onlyDesired {
+		for k := range live {
+			if _, ok := desired[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := fieldPath(path, k)
+		l, lOk := live[k]
+		d, dOk := desired[k]
+		switch {
+		case !lOk:
+			changes = append(changes, &rpc.FieldChange{Path: p, NewValue: toJson(d)})
+		case !dOk:
+			changes = append(changes, &rpc.FieldChange{Path: p, OldValue: toJson(l)})
+		default:
+			changes = diffValues(p, l, d, onlyDesired, changes)
+		}
+	}
+	return changes
+}
+
+func diffValues(path string, live, desired any, onlyDesired bool, changes []*rpc.FieldChange) []*rpc.FieldChange {
+	switch d := desired.(type) {
+	case map[string]any:
+		if l, ok := live.(map[string]any); ok {
+			return diffMaps(path, l, d, onlyDesired, changes)
+		}
+	case []any:
+		// Items are only compared one by one if the list has the same length, otherwise the whole list has changed.
+		if l, ok := live.([]any); ok && len(l) == len(d) {
+			for i := range d {
+				changes = diffValues(path+"["+strconv.Itoa(i)+"]", l[i], d[i], onlyDesired, changes)
+			}
+			return changes
+		}
+	}
+	// Compare JSON rather than the values, numbers from manifests are float64 and from the API server int64.
+	l, d := toJson(live), toJson(desired)
+	if l == d {
+		return changes
+	}
+	return append(changes, &rpc.FieldChange{Path: path, OldValue: l, NewValue: d})
+}
+
+// fieldPath returns the path of a field in the format kubectl uses for JSONPath e.g. .metadata.labels["app.kubernetes.io/name"].
+func fieldPath(parent, field string) string {
+	if identifierRegexp.MatchString(field) {
+		return parent + "." + field
+	}
+	return parent + "[" + strconv.Quote(field) + "]"
+}
+
+func toJson(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(v)
+	if err != nil {
+		// Values come from JSON or YAML documents, they can always be encoded.
+		return err.Error()
+	}
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+)
+
+func TestDiffObjects(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":            "d",
+			"resourceVersion": "123",
+			"labels": map[string]any{
+				"app.kubernetes.io/name": "a",
+				"removed":                "x",
+			},
+		},
+		"spec": map[string]any{
+			"replicas": int64(1),
+			"containers": []any{
+				map[string]any{"name": "c", "image": "nginx:1"},
+			},
+			"args": []any{"a"},
+		},
+		"status": map[string]any{"replicas": int64(1)},
+	}}
+	desired := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":            "d",
+			"resourceVersion": "124",
+			"labels": map[string]any{
+				"app.kubernetes.io/name": "b",
+			},
+		},
+		"spec": map[string]any{
+			"replicas": float64(1), // same number
+			"containers": []any{
+				map[string]any{"name": "c", "image": "nginx:2"},
+			},
+			"args":  []any{"a", "b"},
+			"added": true,
+		},
+	}}
+	changes := diffObjects(live, desired, false)
+	assert.Empty(t, cmp.Diff([]*rpc.FieldChange{
+		{Path: `.metadata.labels["app.kubernetes.io/name"]`, OldValue: `"a"`, NewValue: `"b"`},
+		{Path: ".metadata.labels.removed", OldValue: `"x"`},
+		{Path: ".spec.added", NewValue: "true"},
+		{Path: ".spec.args", OldValue: `["a"]`, NewValue: `["a","b"]`},
+		{Path: ".spec.containers[0].image", OldValue: `"nginx:1"`, NewValue: `"nginx:2"`},
+	}, changes, protocmp.Transform()))
+
+	// Fields that are only in the live object are not compared.
+	changes = diffObjects(live, desired, true)
+	assert.Empty(t, cmp.Diff([]*rpc.FieldChange{
+		{Path: `.metadata.labels["app.kubernetes.io/name"]`, OldValue: `"a"`, NewValue: `"b"`},
+		{Path: ".spec.added", NewValue: "true"},
+		{Path: ".spec.args", OldValue: `["a"]`, NewValue: `["a","b"]`},
+		{Path: ".spec.containers[0].image", OldValue: `"nginx:1"`, NewValue: `"nginx:2"`},
+	}, changes, protocmp.Transform()))
+}
+
+func TestDiffObjects_Unchanged(t *testing.T) {
+	obj := configMap("cm1", testNamespace)
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "<b>", "data", "html"))
+	assert.Empty(t, diffObjects(obj, obj.DeepCopy(), false))
+}
@@ -0,0 +1,129 @@
+package agent
+
+import (
+	"time"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+)
+
+const (
+	// pollInterval is how often the ref of a manifest project is checked for new commits.
+	pollInterval = 20 * time.Second
+	// resyncPeriod is how often manifests are applied even if the ref has not changed.
+	resyncPeriod = 5 * time.Minute
+	// objectPollInterval is how often objects are checked while waiting for them to be reconciled or deleted.
+	objectPollInterval = 2 * time.Second
+	// statusRefreshPeriod is how often the sync status is sent to kas if it has not changed. Must be less than
+	// the time kas keeps the status for.
+	statusRefreshPeriod = 10 * time.Minute
+	// previewTimeout is how long a preview may take, including the fetch.
+	previewTimeout = 2 * time.Minute
+	// previewMaxFetchSize is the maximum total size of the objects a preview may fetch. Objects are kept in memory.
+	previewMaxFetchSize = 128 << 20
+
+	initBackoff   = 10 * time.Second
+	maxBackoff    = 5 * time.Minute
+	resetDuration = 10 * time.Minute
+	backoffFactor = 2.0
+	jitter        = 1.0
+)
+
+type Factory struct {
+	// GitAuth holds the credentials to access the repositories of manifest projects with.
+	GitAuth GitAuth
+}
+
+func (f *Factory) IsProducingLeaderModules() bool {
+	// Only one replica must apply the manifests.
+	return true
+}
+
+func (f *Factory) New(config *modagent.Config) (modagent.Module, error) {
+	client, err := config.K8sUtilFactory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := config.K8sUtilFactory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	backoff := retry.NewExponentialBackoffFactory(initBackoff, maxBackoff, resetDuration, backoffFactor, jitter)
+	reporter := newSyncStatusReporter(
+		config.Log,
+		rpc.NewGitopsClient(config.KasConn),
+		retry.NewPollConfigFactory(statusRefreshPeriod, backoff)(),
+	)
+	return &module{
+		log: config.Log,
+		workerManager: modagent.NewWorkerManager[*agentcfg.ManifestProjectCF](config.Log, &workerFactory{
+			log:                config.Log,
+			client:             client,
+			mapper:             mapper,
+			gitAuth:            &f.GitAuth,
+			reporter:           reporter,
+			inventoryNs:        config.AgentMeta.PodNamespace,
+			pollConfig:         retry.NewPollConfigFactory(pollInterval, backoff),
+			resyncPeriod:       resyncPeriod,
+			objectPollInterval: objectPollInterval,
+		}),
+		reporter: reporter,
+	}, nil
+}
+
+func (f *Factory) Name() string {
+	return gitops.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	return modshared.ModuleStartBeforeServers
+}
+
+// PreviewFactory creates the module that previews manifest projects. It shares the GitOps configuration with the
+// module that Factory creates.
+type PreviewFactory struct {
+	// GitAuth holds the credentials to access the repositories of manifest projects with.
+	GitAuth GitAuth
+}
+
+func (f *PreviewFactory) IsProducingLeaderModules() bool {
+	return false
+}
+
+func (f *PreviewFactory) New(config *modagent.Config) (modagent.Module, error) {
+	client, err := config.K8sUtilFactory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := config.K8sUtilFactory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	projects := &projectsHolder{}
+	rpc.RegisterGitopsPreviewServer(config.Server, &previewServer{
+		log:          config.Log,
+		client:       client,
+		mapper:       mapper,
+		gitAuth:      &f.GitAuth,
+		projects:     projects,
+		inventoryNs:  config.AgentMeta.PodNamespace,
+		timeout:      previewTimeout,
+		maxFetchSize: previewMaxFetchSize,
+	})
+	return &previewModule{
+		projects: projects,
+	}, nil
+}
+
+func (f *PreviewFactory) Name() string {
+	return gitops.PreviewModuleName
+}
+
+func (f *PreviewFactory) StartStopPhase() modshared.ModuleStartStopPhase {
+	// This module exposes an API endpoint on the internal server, but it does not make requests to it.
+	return modshared.ModuleStartBeforeServers
+}
@@ -0,0 +1,239 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+const (
+	// fetchedCommitRef is where a commit that is fetched by SHA is stored.
+	fetchedCommitRef = "refs/fetched"
+)
+
+var (
+	errTooLarge = errors.New("fetched objects are too large")
+)
+
+// GitAuth holds the credentials to access Git repositories with.
+// Files are read on each access so that rotated credentials are picked up.
+type GitAuth struct {
+	// SshKeyFile is a private key for SSH repositories.
+	SshKeyFile string
+	// SshKnownHostsFile is used to verify SSH hosts. The known_hosts files of the system are used if empty.
+	SshKnownHostsFile string
+	// HttpUsername is the username for HTTP(S) repositories.
+	HttpUsername string
+	// HttpPasswordFile holds the password or token for HTTP(S) repositories.
+	HttpPasswordFile string
+}
+
+func (a *GitAuth) authMethod(ep *transport.Endpoint) (transport.AuthMethod, error) {
+	switch ep.Protocol {
+	case "ssh":
+		if a.SshKeyFile == "" {
+			return nil, nil // go-git falls back to the SSH agent
+		}
+		user := ep.User
+		if user == "" {
+			user = "git"
+		}
+		keys, err := gitssh.NewPublicKeysFromFile(user, a.SshKeyFile, "")
+		if err != nil {
+			return nil, fmt.Errorf("SSH key: %w", err)
+		}
+		if a.SshKnownHostsFile != "" {
+			keys.HostKeyCallback, err = gitssh.NewKnownHostsCallback(a.SshKnownHostsFile)
+			if err != nil {
+				return nil, fmt.Errorf("SSH known hosts: %w", err)
+			}
+		}
+		return keys, nil
+	case "http", "https":
+		if a.HttpPasswordFile == "" {
+			return nil, nil
+		}
+		password, err := os.ReadFile(a.HttpPasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP password: %w", err)
+		}
+		return &githttp.BasicAuth{
+			Username: a.HttpUsername,
+			Password: string(bytes.TrimSuffix(password, []byte{'\n'})),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// gitRepository reads manifests from a Git repository.
+// Nothing is kept between calls, every fetch gets only the objects of a single commit.
+type gitRepository struct {
+	url  string
+	auth *GitAuth
+	// maxSize is the maximum total size of the objects a fetch may get. Zero means no limit.
+	maxSize int64
+}
+
+func newGitRepository(url string, auth *GitAuth) (*gitRepository, error) {
+	_, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, err
+	}
+	return &gitRepository{
+		url:  url,
+		auth: auth,
+	}, nil
+}
+
+// resolve returns the name of the reference ref points at and the hash it has in the remote repository.
+// A nil ref is the default branch. The name is empty for a commit.
+func (r *gitRepository) resolve(ctx context.Context, ref *agentcfg.GitRefCF) (plumbing.ReferenceName, plumbing.Hash, error) {
+	var name plumbing.ReferenceName
+	switch x := ref.GetRef().(type) {
+	case *agentcfg.GitRefCF_Commit:
+		if !plumbing.IsHash(x.Commit) {
+			return "", plumbing.ZeroHash, fmt.Errorf("commit %q is not a full SHA-1", x.Commit)
+		}
+		return "", plumbing.NewHash(x.Commit), nil
+	case *agentcfg.GitRefCF_Tag:
+		name = plumbing.NewTagReferenceName(x.Tag)
+	case *agentcfg.GitRefCF_Branch:
+		name = plumbing.NewBranchReferenceName(x.Branch)
+	case *agentcfg.GitRefCF_Name:
+		name = plumbing.ReferenceName(x.Name)
+	case nil:
+		name = plumbing.HEAD
+	default:
+		return "", plumbing.ZeroHash, fmt.Errorf("unknown ref type: %T", x)
+	}
+	auth, err := r.authMethod()
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{r.url},
+	})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{
+		Auth: auth,
+	})
+	if err != nil {
+		return "", plumbing.ZeroHash, fmt.Errorf("list references: %w", err)
+	}
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, ref := range refs {
+		byName[ref.Name()] = ref
+	}
+	found := byName[name]
+	if found != nil && found.Type() == plumbing.SymbolicReference { // HEAD
+		name = found.Target()
+		found = byName[name]
+	}
+	if found == nil {
+		return "", plumbing.ZeroHash, fmt.Errorf("reference %s not found", name)
+	}
+	return name, found.Hash(), nil
+}
+
+// fetch returns the commit the reference points at. If name is empty, it returns the commit with the given hash.
+func (r *gitRepository) fetch(ctx context.Context, name plumbing.ReferenceName, hash plumbing.Hash) (*object.Commit, error) {
+	auth, err := r.authMethod()
+	if err != nil {
+		return nil, err
+	}
+	var s storage.Storer = memory.NewStorage()
+	if r.maxSize > 0 {
+		s = &limitedStorage{
+			Storage: memory.NewStorage(),
+			maxSize: r.maxSize,
+		}
+	}
+	repo, err := git.Init(s, nil)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{r.url},
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts := &git.FetchOptions{
+		Auth:  auth,
+		Tags:  git.NoTags,
+		Depth: 1,
+	}
+	if name == "" {
+		// Needs a server that allows fetching commits by SHA. Most do, e.g. GitHub and GitLab.
+		opts.RefSpecs = []config.RefSpec{config.RefSpec(hash.String() + ":" + fetchedCommitRef)}
+	} else {
+		opts.RefSpecs = []config.RefSpec{config.RefSpec("+" + name + ":" + name)}
+	}
+	err = remote.FetchContext(ctx, opts)
+	switch {
+	case err == nil, errors.Is(err, git.NoErrAlreadyUpToDate):
+	case errors.Is(err, git.ErrExactSHA1NotSupported):
+		return nil, fmt.Errorf("commit %s: %w, use a branch, a tag or a reference name instead", hash, err)
+	default:
+		return nil, fmt.Errorf("fetch: %w", err)
+	}
+	if name != "" {
+		// The reference might have moved since it has been resolved, use what has been fetched.
+		ref, err := repo.Reference(name, false) // nolint: govet
+		if err != nil {
+			return nil, fmt.Errorf("reference %s: %w", name, err)
+		}
+		hash = ref.Hash()
+	}
+	obj, err := repo.Object(plumbing.AnyObject, hash)
+	if err != nil {
+		return nil, fmt.Errorf("object %s: %w", hash, err)
+	}
+	switch o := obj.(type) {
+	case *object.Commit:
+		return o, nil
+	case *object.Tag: // annotated tag
+		return o.Commit()
+	default:
+		return nil, fmt.Errorf("object %s is a %s, not a commit", hash, obj.Type())
+	}
+}
+
+// limitedStorage is an in-memory storage that fails once the objects in it exceed maxSize bytes.
+type limitedStorage struct {
+	*memory.Storage
+	maxSize int64
+	size    int64
+}
+
+func (s *limitedStorage) SetEncodedObject(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	s.size += obj.Size()
+	if s.size > s.maxSize {
+		return plumbing.ZeroHash, fmt.Errorf("%w: more than %d bytes", errTooLarge, s.maxSize)
+	}
+	return s.Storage.SetEncodedObject(obj)
+}
+
+func (r *gitRepository) authMethod() (transport.AuthMethod, error) {
+	ep, err := transport.NewEndpoint(r.url)
+	if err != nil {
+		return nil, err
+	}
+	return r.auth.authMethod(ep)
+}
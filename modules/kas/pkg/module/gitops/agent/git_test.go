@@ -0,0 +1,249 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+func TestGitRepository_Branch(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"a.yaml": "1"})
+	repo := r.gitRepository(t)
+
+	name, hash, err := repo.resolve(context.Background(), &agentcfg.GitRefCF{
+		Ref: &agentcfg.GitRefCF_Branch{Branch: "main"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("main"), name)
+	assert.Equal(t, c1, hash)
+
+	c2 := r.commit(t, map[string]string{"a.yaml": "2"})
+	commit, err := repo.fetch(context.Background(), name, hash)
+	require.NoError(t, err)
+	// The branch has moved, the fetched commit is returned.
+	assert.Equal(t, c2, commit.Hash)
+	assert.Equal(t, "2", fileContents(t, commit, "a.yaml"))
+}
+
+func TestGitRepository_DefaultBranch(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"a.yaml": "1"})
+	repo := r.gitRepository(t)
+
+	name, hash, err := repo.resolve(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("main"), name)
+	assert.Equal(t, c1, hash)
+}
+
+func TestGitRepository_AnnotatedTag(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"a.yaml": "1"})
+	_, err := r.repo.CreateTag("v1", c1, &git.CreateTagOptions{
+		Tagger:  testSignature(),
+		Message: "v1",
+	})
+	require.NoError(t, err)
+	r.commit(t, map[string]string{"a.yaml": "2"})
+	repo := r.gitRepository(t)
+
+	name, hash, err := repo.resolve(context.Background(), &agentcfg.GitRefCF{
+		Ref: &agentcfg.GitRefCF_Tag{Tag: "v1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewTagReferenceName("v1"), name)
+	assert.NotEqual(t, c1, hash) // hash of the tag object
+
+	commit, err := repo.fetch(context.Background(), name, hash)
+	require.NoError(t, err)
+	assert.Equal(t, c1, commit.Hash)
+	assert.Equal(t, "1", fileContents(t, commit, "a.yaml"))
+}
+
+func TestGitRepository_Commit(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit(t, map[string]string{"a.yaml": "1"})
+	c2 := r.commit(t, map[string]string{"a.yaml": "2"})
+	r.commit(t, map[string]string{"a.yaml": "3"})
+	repo := r.gitRepository(t)
+
+	name, hash, err := repo.resolve(context.Background(), &agentcfg.GitRefCF{
+		Ref: &agentcfg.GitRefCF_Commit{Commit: c2.String()},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, name)
+	assert.Equal(t, c2, hash)
+
+	commit, err := repo.fetch(context.Background(), name, hash)
+	require.NoError(t, err)
+	assert.Equal(t, c2, commit.Hash)
+	assert.Equal(t, "2", fileContents(t, commit, "a.yaml"))
+	// Only the commit has been fetched, not its history.
+	_, err = commit.Parent(0)
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
+
+func TestGitRepository_CommitNotAllowed(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"a.yaml": "1"})
+	cfg, err := r.repo.Config()
+	require.NoError(t, err)
+	cfg.Raw.RemoveSection("uploadpack")
+	require.NoError(t, r.repo.SetConfig(cfg))
+	repo := r.gitRepository(t)
+
+	_, err = repo.fetch(context.Background(), "", c1)
+	assert.ErrorIs(t, err, git.ErrExactSHA1NotSupported)
+}
+
+func TestGitRepository_Name(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"a.yaml": "1"})
+	pr := plumbing.ReferenceName("refs/pull/1/head")
+	require.NoError(t, r.repo.Storer.SetReference(plumbing.NewHashReference(pr, c1)))
+	r.commit(t, map[string]string{"a.yaml": "2"})
+	repo := r.gitRepository(t)
+
+	name, hash, err := repo.resolve(context.Background(), &agentcfg.GitRefCF{
+		Ref: &agentcfg.GitRefCF_Name{Name: pr.String()},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pr, name)
+	assert.Equal(t, c1, hash)
+
+	commit, err := repo.fetch(context.Background(), name, hash)
+	require.NoError(t, err)
+	assert.Equal(t, c1, commit.Hash)
+	assert.Equal(t, "1", fileContents(t, commit, "a.yaml"))
+}
+
+func TestGitRepository_MaxSize(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit(t, map[string]string{"a.yaml": "0123456789"})
+	repo := r.gitRepository(t)
+	repo.maxSize = 10
+
+	_, err := repo.fetch(context.Background(), plumbing.NewBranchReferenceName("main"), plumbing.ZeroHash)
+	assert.ErrorIs(t, err, errTooLarge)
+}
+
+func TestGitRepository_CommitMustBeFullSha(t *testing.T) {
+	repo := newTestRepo(t).gitRepository(t)
+	_, _, err := repo.resolve(context.Background(), &agentcfg.GitRefCF{
+		Ref: &agentcfg.GitRefCF_Commit{Commit: "abc123"},
+	})
+	assert.EqualError(t, err, `commit "abc123" is not a full SHA-1`)
+}
+
+func TestGitRepository_UnknownBranch(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit(t, map[string]string{"a.yaml": "1"})
+	repo := r.gitRepository(t)
+	_, _, err := repo.resolve(context.Background(), &agentcfg.GitRefCF{
+		Ref: &agentcfg.GitRefCF_Branch{Branch: "nope"},
+	})
+	assert.EqualError(t, err, "reference refs/heads/nope not found")
+}
+
+func TestGitAuth_Http(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("token\n"), 0o600))
+	a := GitAuth{
+		HttpUsername:     "user",
+		HttpPasswordFile: passwordFile,
+	}
+	ep, err := transport.NewEndpoint("https://example.com/repo.git")
+	require.NoError(t, err)
+	auth, err := a.authMethod(ep)
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: "user", Password: "token"}, auth)
+
+	// Credentials are only used for the protocol they are for.
+	ep, err = transport.NewEndpoint("git@example.com:repo.git")
+	require.NoError(t, err)
+	auth, err = a.authMethod(ep)
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+}
+
+type testRepo struct {
+	dir  string
+	repo *git.Repository
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	dir := t.TempDir()
+	repo, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{
+			DefaultBranch: plumbing.NewBranchReferenceName("main"),
+		},
+	})
+	require.NoError(t, err)
+	// Like GitHub and GitLab, allow fetching commits by SHA.
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	cfg.Raw.Section("uploadpack").SetOption("allowReachableSHA1InWant", "true")
+	require.NoError(t, repo.SetConfig(cfg))
+	return &testRepo{
+		dir:  dir,
+		repo: repo,
+	}
+}
+
+// commit replaces the contents of the repository with the files and commits them.
+func (r *testRepo) commit(t *testing.T, files map[string]string) plumbing.Hash {
+	wt, err := r.repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Remove(".")
+	if err != nil {
+		require.ErrorIs(t, err, plumbing.ErrReferenceNotFound) // nothing to remove before the first commit
+	}
+	for name, content := range files {
+		file := filepath.Join(r.dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o700))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+		_, err = wt.Add(name)
+		require.NoError(t, err)
+	}
+	hash, err := wt.Commit("commit", &git.CommitOptions{
+		Author:            testSignature(),
+		AllowEmptyCommits: true,
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+func (r *testRepo) gitRepository(t *testing.T) *gitRepository {
+	repo, err := newGitRepository(r.dir, &GitAuth{})
+	require.NoError(t, err)
+	return repo
+}
+
+func testSignature() *object.Signature {
+	return &object.Signature{
+		Name:  "test",
+		Email: "test@example.com",
+		When:  time.Unix(1700000000, 0),
+	}
+}
+
+func fileContents(t *testing.T, commit *object.Commit, name string) string {
+	f, err := commit.File(name)
+	require.NoError(t, err)
+	contents, err := f.Contents()
+	require.NoError(t, err)
+	return contents
+}
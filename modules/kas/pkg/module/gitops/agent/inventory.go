@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+)
+
+// The annotation and the label are the ones cli-utils uses so that the objects can be inspected with the same tools.
+const (
+	// owningInventoryAnnotation is set on the applied objects. Its value is the id of the inventory.
+	owningInventoryAnnotation = "config.k8s.io/owning-inventory"
+	// inventoryIdLabel is set on the inventory ConfigMap.
+	inventoryIdLabel = "cli-utils.sigs.k8s.io/inventory-id"
+	// projectIdAnnotation is set on the inventory ConfigMap to tell what manifest project it is for.
+	projectIdAnnotation = "agent.plural.sh/gitops-project-id"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// objectKey identifies an object in the inventory.
+type objectKey struct {
+	Namespace string
+	Name      string
+	Group     string
+	Kind      string
+}
+
+// String returns the key in the format cli-utils uses i.e. <namespace>_<name>_<group>_<kind>.
+// Colons, which RBAC object names may contain, are replaced by double underscores.
+func (k objectKey) String() string {
+	return k.Namespace + "_" + strings.ReplaceAll(k.Name, ":", "__") + "_" + k.Group + "_" + k.Kind
+}
+
+func (k objectKey) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: k.Group, Kind: k.Kind}
+}
+
+func parseObjectKey(s string) (objectKey, error) {
+	// The name may contain double underscores, split off the other parts from both ends.
+	namespace, rest, ok := strings.Cut(s, "_")
+	if !ok {
+		return objectKey{}, fmt.Errorf("invalid object key %q", s)
+	}
+	i := strings.LastIndexByte(rest, '_')
+	if i == -1 {
+		return objectKey{}, fmt.Errorf("invalid object key %q", s)
+	}
+	kind := rest[i+1:]
+	rest = rest[:i]
+	i = strings.LastIndexByte(rest, '_')
+	if i == -1 {
+		return objectKey{}, fmt.Errorf("invalid object key %q", s)
+	}
+	group := rest[i+1:]
+	name := rest[:i]
+	if name == "" || kind == "" {
+		return objectKey{}, fmt.Errorf("invalid object key %q", s)
+	}
+	return objectKey{
+		Namespace: namespace,
+		Name:      strings.ReplaceAll(name, "__", ":"),
+		Group:     group,
+		Kind:      kind,
+	}, nil
+}
+
+// inventoryName returns the name of the inventory ConfigMap of a manifest project. It is also the id of the inventory.
+// The agent id is part of it so that agents that sync the same project into a cluster don't share the objects.
+func inventoryName(agentId int64, projectId string) string {
+	h := sha256.Sum256([]byte(projectId))
+	return "gitops-" + strconv.FormatInt(agentId, 10) + "-" + hex.EncodeToString(h[:8])
+}
+
+// inventory keeps track of the objects of a manifest project in a ConfigMap.
+type inventory struct {
+	client    dynamic.Interface
+	namespace string
+	name      string
+	projectId string
+}
+
+// load returns the objects in the inventory. The inventory is empty if the ConfigMap does not exist.
+func (i *inventory) load(ctx context.Context) (map[objectKey]struct{}, error) {
+	cm, err := i.client.Resource(configMapGVR).Namespace(i.namespace).Get(ctx, i.name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return map[objectKey]struct{}{}, nil
+		}
+		return nil, fmt.Errorf("get inventory: %w", err)
+	}
+	data, _, err := unstructured.NestedStringMap(cm.Object, "data")
+	if err != nil {
+		return nil, fmt.Errorf("inventory: %w", err)
+	}
+	keys := make(map[objectKey]struct{}, len(data))
+	for k := range data {
+		key, err := parseObjectKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("inventory: %w", err)
+		}
+		keys[key] = struct{}{}
+	}
+	return keys, nil
+}
+
+// store replaces the objects in the inventory.
+func (i *inventory) store(ctx context.Context, keys map[objectKey]struct{}) error {
+	data := make(map[string]any, len(keys))
+	for key := range keys {
+		data[key.String()] = ""
+	}
+	cm := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"namespace": i.namespace,
+				"name":      i.name,
+				"labels": map[string]any{
+					inventoryIdLabel: i.name,
+				},
+				"annotations": map[string]any{
+					projectIdAnnotation: i.projectId,
+				},
+			},
+			"data": data,
+		},
+	}
+	_, err := i.client.Resource(configMapGVR).Namespace(i.namespace).Apply(ctx, i.name, cm, meta_v1.ApplyOptions{
+		FieldManager: modagent.FieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("store inventory: %w", err)
+	}
+	return nil
+}
+
+func sortedKeys(keys map[objectKey]struct{}) []objectKey {
+	result := make([]objectKey, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
@@ -0,0 +1,44 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectKey_RoundTrip(t *testing.T) {
+	keys := []objectKey{
+		{Namespace: "ns", Name: "cm", Kind: "ConfigMap"},
+		{Name: "ns", Kind: "Namespace"},
+		{Name: "system:controller:foo", Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+		{Namespace: "ns", Name: "my_app", Group: "apps", Kind: "Deployment"},
+	}
+	for _, key := range keys {
+		t.Run(key.String(), func(t *testing.T) {
+			parsed, err := parseObjectKey(key.String())
+			require.NoError(t, err)
+			assert.Equal(t, key, parsed)
+		})
+	}
+}
+
+func TestObjectKey_String(t *testing.T) {
+	key := objectKey{Name: "system:foo", Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}
+	assert.Equal(t, "_system__foo_rbac.authorization.k8s.io_ClusterRole", key.String())
+}
+
+func TestParseObjectKey_Invalid(t *testing.T) {
+	for _, s := range []string{"", "ns", "ns_name", "ns__group_Kind", "ns_name_group_"} {
+		_, err := parseObjectKey(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestInventoryName(t *testing.T) {
+	name := inventoryName(123, "https://example.com/manifests.git")
+	assert.Regexp(t, `^gitops-123-[0-9a-f]{16}$`, name)
+	assert.Equal(t, name, inventoryName(123, "https://example.com/manifests.git"))
+	assert.NotEqual(t, name, inventoryName(124, "https://example.com/manifests.git"))
+	assert.NotEqual(t, name, inventoryName(123, "https://example.com/other.git"))
+}
@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"go.uber.org/zap"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/prototool"
+)
+
+const (
+	defaultPathGlob               = "**/*.{yaml,yml,json}"
+	defaultReconcileTimeout       = time.Hour
+	defaultDryRunStrategy         = dryRunStrategyNone
+	defaultPrune                  = true
+	defaultPruneTimeout           = time.Hour
+	defaultPrunePropagationPolicy = "foreground"
+	defaultInventoryPolicy        = inventoryPolicyMustMatch
+)
+
+type module struct {
+	log           *zap.Logger
+	workerManager *modagent.WorkerManager[*agentcfg.ManifestProjectCF]
+	reporter      *syncStatusReporter
+}
+
+func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
+	reporterCtx, reporterCancel := context.WithCancel(context.Background())
+	reporterDone := make(chan struct{})
+	go func() {
+		defer close(reporterDone)
+		m.reporter.Run(reporterCtx)
+	}()
+	defer func() {
+		reporterCancel()
+		<-reporterDone
+	}()
+	defer m.workerManager.StopAllWorkers()
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			return nil
+		case config, ok := <-cfg:
+			if !ok {
+				return nil
+			}
+			err := m.workerManager.ApplyConfiguration(config.AgentId, config)
+			if err != nil {
+				m.log.Error("Failed to apply manifest projects configuration", logz.Error(err))
+				continue
+			}
+			projectIds := make(map[string]struct{}, len(config.Gitops.ManifestProjects))
+			for _, project := range config.Gitops.ManifestProjects {
+				projectIds[project.GetId()] = struct{}{}
+			}
+			m.reporter.retain(projectIds)
+		}
+	}
+}
+
+func (m *module) DefaultAndValidateConfiguration(config *agentcfg.AgentConfiguration) error {
+	prototool.NotNil(&config.Gitops)
+	ids := make(map[string]struct{}, len(config.Gitops.ManifestProjects))
+	for i, project := range config.Gitops.ManifestProjects {
+		id := project.GetId()
+		if _, ok := ids[id]; ok {
+			return fmt.Errorf("manifest project %d: duplicate id %q", i, id)
+		}
+		ids[id] = struct{}{}
+		err := defaultAndValidateProject(project)
+		if err != nil {
+			return fmt.Errorf("manifest project %q: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (m *module) Name() string {
+	return gitops.ModuleName
+}
+
+func defaultAndValidateProject(project *agentcfg.ManifestProjectCF) error {
+	_, err := newGitRepository(project.GetId(), &GitAuth{})
+	if err != nil {
+		return err
+	}
+	if len(project.Paths) == 0 {
+		project.Paths = []*agentcfg.PathCF{{Glob: defaultPathGlob}}
+	}
+	for _, p := range project.Paths {
+		if !doublestar.ValidatePattern(strings.TrimPrefix(p.Glob, "/")) {
+			return fmt.Errorf("invalid glob %q", p.Glob)
+		}
+	}
+	prototool.String(&project.DefaultNamespace, meta_v1.NamespaceDefault)
+	prototool.Duration(&project.ReconcileTimeout, defaultReconcileTimeout)
+	prototool.String(&project.DryRunStrategy, defaultDryRunStrategy)
+	if project.PruneOneof == nil {
+		project.PruneOneof = &agentcfg.ManifestProjectCF_Prune{Prune: defaultPrune}
+	}
+	prototool.Duration(&project.PruneTimeout, defaultPruneTimeout)
+	prototool.String(&project.PrunePropagationPolicy, defaultPrunePropagationPolicy)
+	prototool.String(&project.InventoryPolicy, defaultInventoryPolicy)
+	return nil
+}
@@ -0,0 +1,95 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+)
+
+var (
+	_ modagent.Module                                     = (*module)(nil)
+	_ modagent.Factory                                    = (*Factory)(nil)
+	_ modagent.WorkerFactory[*agentcfg.ManifestProjectCF] = (*workerFactory)(nil)
+	_ modagent.Worker                                     = (*worker)(nil)
+)
+
+func TestDefaultAndValidateConfiguration(t *testing.T) {
+	m := &module{}
+	cfg := &agentcfg.AgentConfiguration{
+		Gitops: &agentcfg.GitopsCF{
+			ManifestProjects: []*agentcfg.ManifestProjectCF{
+				{
+					Id: proto.String("https://example.com/manifests.git"),
+				},
+			},
+		},
+	}
+	require.NoError(t, m.DefaultAndValidateConfiguration(cfg))
+	assert.Empty(t, cmp.Diff(&agentcfg.ManifestProjectCF{
+		Id:                     proto.String("https://example.com/manifests.git"),
+		DefaultNamespace:       "default",
+		Paths:                  []*agentcfg.PathCF{{Glob: defaultPathGlob}},
+		ReconcileTimeout:       durationpb.New(defaultReconcileTimeout),
+		DryRunStrategy:         "none",
+		PruneOneof:             &agentcfg.ManifestProjectCF_Prune{Prune: true},
+		PruneTimeout:           durationpb.New(defaultPruneTimeout),
+		PrunePropagationPolicy: "foreground",
+		InventoryPolicy:        "must_match",
+	}, cfg.Gitops.ManifestProjects[0], protocmp.Transform()))
+}
+
+func TestDefaultAndValidateConfiguration_KeepsPruneDisabled(t *testing.T) {
+	m := &module{}
+	cfg := &agentcfg.AgentConfiguration{
+		Gitops: &agentcfg.GitopsCF{
+			ManifestProjects: []*agentcfg.ManifestProjectCF{
+				{
+					Id:         proto.String("git@example.com:manifests.git"),
+					PruneOneof: &agentcfg.ManifestProjectCF_Prune{Prune: false},
+				},
+			},
+		},
+	}
+	require.NoError(t, m.DefaultAndValidateConfiguration(cfg))
+	assert.False(t, cfg.Gitops.ManifestProjects[0].GetPrune())
+}
+
+func TestDefaultAndValidateConfiguration_NoGitops(t *testing.T) {
+	m := &module{}
+	cfg := &agentcfg.AgentConfiguration{}
+	require.NoError(t, m.DefaultAndValidateConfiguration(cfg))
+	assert.NotNil(t, cfg.Gitops)
+}
+
+func TestDefaultAndValidateConfiguration_Invalid(t *testing.T) {
+	m := &module{}
+	err := m.DefaultAndValidateConfiguration(&agentcfg.AgentConfiguration{
+		Gitops: &agentcfg.GitopsCF{
+			ManifestProjects: []*agentcfg.ManifestProjectCF{
+				{Id: proto.String("https://example.com/manifests.git")},
+				{Id: proto.String("https://example.com/manifests.git")},
+			},
+		},
+	})
+	assert.EqualError(t, err, `manifest project 1: duplicate id "https://example.com/manifests.git"`)
+
+	err = m.DefaultAndValidateConfiguration(&agentcfg.AgentConfiguration{
+		Gitops: &agentcfg.GitopsCF{
+			ManifestProjects: []*agentcfg.ManifestProjectCF{
+				{
+					Id:    proto.String("https://example.com/manifests.git"),
+					Paths: []*agentcfg.PathCF{{Glob: "[a"}},
+				},
+			},
+		},
+	})
+	assert.EqualError(t, err, `manifest project "https://example.com/manifests.git": invalid glob "[a"`)
+}
@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+)
+
+const (
+	previewStrategyServer = "server"
+	previewStrategyClient = "client"
+)
+
+// previewStrategy returns how objects are compared with the live ones. Only the client strategy avoids sending
+// the objects to the API server, a preview never changes anything so all other strategies use a server-side dry-run.
+func (a *applier) previewStrategy() string {
+	if a.dryRunStrategy == dryRunStrategyClient {
+		return previewStrategyClient
+	}
+	return previewStrategyServer
+}
+
+// preview returns what sync would do to the objects and to the objects in the inventory, without changing anything.
+// Objects that depend on objects that don't exist yet, such as objects in a new namespace or of a new CRD, fail
+// with the server strategy because nothing is created.
+func (a *applier) preview(ctx context.Context, objs []*unstructured.Unstructured) ([]*rpc.ObjectPreview, error) {
+	oldKeys, err := a.inventory.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = a.checkPruneAll(objs, oldKeys)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		result         []*rpc.ObjectPreview
+		newKeys        = make(map[objectKey]struct{}, len(objs))
+		mapperWasReset bool
+	)
+	for _, obj := range sortForApply(objs) {
+		gvk := obj.GroupVersionKind()
+		p := &rpc.ObjectPreview{
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}
+		result = append(result, p)
+		o, err := a.prepare(obj, &mapperWasReset)
+		if err != nil {
+			setPreviewError(p, err)
+			continue
+		}
+		p.Namespace = o.key.Namespace
+		if _, ok := newKeys[o.key]; ok {
+			setPreviewError(p, errors.New("duplicate object"))
+			continue
+		}
+		newKeys[o.key] = struct{}{}
+		err = a.previewApply(ctx, o, p)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			setPreviewError(p, err)
+		}
+	}
+	if !a.prune {
+		return result, nil
+	}
+	for _, key := range sortForPrune(sortedKeys(oldKeys)) {
+		if _, ok := newKeys[key]; ok {
+			continue
+		}
+		p := a.previewPrune(ctx, key)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if p != nil {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (a *applier) previewApply(ctx context.Context, o appliedObject, p *rpc.ObjectPreview) error {
+	client := a.client.Resource(o.gvr).Namespace(o.key.Namespace)
+	live, err := client.Get(ctx, o.key.Name, meta_v1.GetOptions{})
+	switch {
+	case err == nil:
+		err = a.checkInventoryPolicy(live)
+		if err != nil {
+			return err
+		}
+	case apierrors.IsNotFound(err):
+		live = nil
+	default:
+		return err
+	}
+	desired := o.obj
+	if a.previewStrategy() == previewStrategyServer {
+		desired, err = client.Apply(ctx, o.key.Name, o.obj, meta_v1.ApplyOptions{
+			FieldManager: modagent.FieldManager,
+			Force:        true,
+			DryRun:       []string{meta_v1.DryRunAll},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if live == nil {
+		p.Action = rpc.ObjectPreview_create
+		return nil
+	}
+	p.Changes = diffObjects(live, desired, a.previewStrategy() == previewStrategyClient)
+	if len(p.Changes) == 0 {
+		p.Action = rpc.ObjectPreview_unchanged
+	} else {
+		p.Action = rpc.ObjectPreview_update
+	}
+	return nil
+}
+
+// previewPrune returns what prune would do to the object. It returns nil if the object would be left alone.
+func (a *applier) previewPrune(ctx context.Context, key objectKey) *rpc.ObjectPreview {
+	p := &rpc.ObjectPreview{
+		Action:    rpc.ObjectPreview_prune,
+		Group:     key.Group,
+		Kind:      key.Kind,
+		Namespace: key.Namespace,
+		Name:      key.Name,
+	}
+	mapping, err := a.mapper.RESTMapping(key.GroupKind())
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil // the kind does not exist anymore, neither does the object
+		}
+		setPreviewError(p, err)
+		return p
+	}
+	p.Version = mapping.Resource.Version
+	client := a.client.Resource(mapping.Resource).Namespace(key.Namespace)
+	live, err := client.Get(ctx, key.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		setPreviewError(p, err)
+		return p
+	}
+	if live.GetAnnotations()[owningInventoryAnnotation] != a.inventory.name {
+		return nil // see pruneObjects()
+	}
+	if a.previewStrategy() == previewStrategyServer {
+		uid := live.GetUID()
+		err = client.Delete(ctx, key.Name, meta_v1.DeleteOptions{
+			PropagationPolicy: &a.prunePropagationPolicy,
+			Preconditions: &meta_v1.Preconditions{
+				UID: &uid,
+			},
+			DryRun: []string{meta_v1.DryRunAll},
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			setPreviewError(p, err)
+		}
+	}
+	return p
+}
+
+func setPreviewError(p *rpc.ObjectPreview, err error) {
+	p.Action = rpc.ObjectPreview_failed
+	p.Changes = nil
+	p.Error = err.Error()
+}
@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops"
+)
+
+// projectsHolder keeps the manifest projects of the current configuration for the preview server.
+type projectsHolder struct {
+	mu       sync.Mutex
+	agentId  int64
+	projects map[string]*agentcfg.ManifestProjectCF
+}
+
+func (h *projectsHolder) set(config *agentcfg.AgentConfiguration) {
+	projects := make(map[string]*agentcfg.ManifestProjectCF, len(config.Gitops.ManifestProjects))
+	for _, project := range config.Gitops.ManifestProjects {
+		projects[project.GetId()] = project
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.agentId = config.AgentId
+	h.projects = projects
+}
+
+// get returns the agent id and the project with the id. The project is nil if there is no such project.
+func (h *projectsHolder) get(id string) (int64, *agentcfg.ManifestProjectCF) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.agentId, h.projects[id]
+}
+
+type previewModule struct {
+	projects *projectsHolder
+}
+
+func (m *previewModule) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			return nil
+		case config, ok := <-cfg:
+			if !ok {
+				return nil
+			}
+			m.projects.set(config)
+		}
+	}
+}
+
+func (m *previewModule) DefaultAndValidateConfiguration(config *agentcfg.AgentConfiguration) error {
+	// The gitops module defaults and validates the manifest projects.
+	return nil
+}
+
+func (m *previewModule) Name() string {
+	return gitops.PreviewModuleName
+}
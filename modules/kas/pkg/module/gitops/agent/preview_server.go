@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+type previewServer struct {
+	rpc.UnimplementedGitopsPreviewServer
+	log         *zap.Logger
+	client      dynamic.Interface
+	mapper      meta.RESTMapper
+	gitAuth     *GitAuth
+	projects    *projectsHolder
+	inventoryNs string
+	// timeout bounds the time a preview takes.
+	timeout time.Duration
+	// maxFetchSize bounds the memory a preview uses for the fetched objects.
+	maxFetchSize int64
+}
+
+func (s *previewServer) Preview(ctx context.Context, req *rpc.PreviewManifestsRequest) (*rpc.PreviewResponse, error) {
+	agentId, project := s.projects.get(req.ProjectId)
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "manifest project %q is not in the agent configuration", req.ProjectId)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	log := s.log.With(logz.WorkerId(req.ProjectId))
+	repo, err := newGitRepository(project.GetId(), s.gitAuth)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	repo.maxSize = s.maxFetchSize
+	ref := req.Ref
+	if ref == nil {
+		ref = project.Ref
+	}
+	name, hash, err := repo.resolve(ctx, ref)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "resolve ref: %v", err)
+	}
+	commit, err := repo.fetch(ctx, name, hash)
+	if err != nil {
+		if errors.Is(err, errTooLarge) || errors.Is(err, git.ErrExactSHA1NotSupported) {
+			return nil, status.Errorf(codes.FailedPrecondition, "fetch: %v", err)
+		}
+		return nil, status.Errorf(codes.Unavailable, "fetch: %v", err)
+	}
+	objs, err := renderManifests(commit, project.Paths)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "render manifests: %v", err)
+	}
+	log.Info("Previewing manifest project", logz.CommitId(commit.Hash.String()))
+	a := newApplier(log, s.client, s.mapper, s.inventoryNs, agentId, project, 0) // preview does not wait for objects
+	objects, err := a.preview(ctx, objs)
+	if err != nil {
+		if errors.Is(err, errPruneAll) {
+			return nil, status.Errorf(codes.FailedPrecondition, "preview: %v", err)
+		}
+		return nil, status.Errorf(codes.Unavailable, "preview: %v", err)
+	}
+	return &rpc.PreviewResponse{
+		CommitId: commit.Hash.String(),
+		Strategy: a.previewStrategy(),
+		Objects:  objects,
+	}, nil
+}
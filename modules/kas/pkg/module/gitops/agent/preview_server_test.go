@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+)
+
+var (
+	_ modagent.Module         = (*previewModule)(nil)
+	_ modagent.Factory        = (*PreviewFactory)(nil)
+	_ rpc.GitopsPreviewServer = (*previewServer)(nil)
+)
+
+func TestPreviewServer_Ref(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"cm.yaml": configMapYAML})
+	require.NoError(t, r.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), c1)))
+	c2 := r.commit(t, map[string]string{"cm.yaml": configMapYAML, "cm2.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm2\n"})
+	s, projectId := setupPreviewServer(t, r, &agentcfg.GitRefCF{Ref: &agentcfg.GitRefCF_Branch{Branch: "feature"}})
+
+	// The ref of the project.
+	resp, err := s.Preview(context.Background(), &rpc.PreviewManifestsRequest{ProjectId: projectId})
+	require.NoError(t, err)
+	assert.Equal(t, "server", resp.Strategy)
+	assert.Equal(t, c1.String(), resp.CommitId)
+	require.Len(t, resp.Objects, 1)
+	assert.Equal(t, "cm1", resp.Objects[0].Name)
+	assert.Equal(t, rpc.ObjectPreview_create, resp.Objects[0].Action)
+
+	// The ref of the request.
+	resp, err = s.Preview(context.Background(), &rpc.PreviewManifestsRequest{
+		ProjectId: projectId,
+		Ref:       &agentcfg.GitRefCF{Ref: &agentcfg.GitRefCF_Commit{Commit: c2.String()}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, c2.String(), resp.CommitId)
+	assert.Len(t, resp.Objects, 2)
+}
+
+func TestPreviewServer_UnknownProject(t *testing.T) {
+	s, _ := setupPreviewServer(t, newTestRepo(t), nil)
+	_, err := s.Preview(context.Background(), &rpc.PreviewManifestsRequest{ProjectId: "https://example.com/other.git"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestPreviewServer_UnknownRef(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit(t, map[string]string{"cm.yaml": configMapYAML})
+	s, projectId := setupPreviewServer(t, r, nil)
+	_, err := s.Preview(context.Background(), &rpc.PreviewManifestsRequest{
+		ProjectId: projectId,
+		Ref:       &agentcfg.GitRefCF{Ref: &agentcfg.GitRefCF_Branch{Branch: "nope"}},
+	})
+	assert.Equal(t, status.Error(codes.FailedPrecondition, "resolve ref: reference refs/heads/nope not found"), err)
+}
+
+func TestPreviewServer_FetchTooLarge(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit(t, map[string]string{"cm.yaml": configMapYAML})
+	s, projectId := setupPreviewServer(t, r, nil)
+	s.maxFetchSize = 10
+	_, err := s.Preview(context.Background(), &rpc.PreviewManifestsRequest{ProjectId: projectId})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func setupPreviewServer(t *testing.T, r *testRepo, ref *agentcfg.GitRefCF) (*previewServer, string) {
+	a, client := setupApplier(t)
+	setupDryRun(client)
+	project := &agentcfg.ManifestProjectCF{
+		Id:  proto.String(r.dir),
+		Ref: ref,
+	}
+	cfg := &agentcfg.AgentConfiguration{
+		AgentId: 123,
+		Gitops: &agentcfg.GitopsCF{
+			ManifestProjects: []*agentcfg.ManifestProjectCF{project},
+		},
+	}
+	require.NoError(t, (&module{}).DefaultAndValidateConfiguration(cfg))
+	projects := &projectsHolder{}
+	projects.set(cfg)
+	return &previewServer{
+		log:         zaptest.NewLogger(t),
+		client:      client,
+		mapper:      a.mapper,
+		gitAuth:     &GitAuth{},
+		projects:    projects,
+		inventoryNs: testNamespace,
+		timeout:     time.Minute,
+	}, project.GetId()
+}
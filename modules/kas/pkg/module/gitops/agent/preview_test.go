@@ -0,0 +1,137 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+)
+
+func TestPreview_Server(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{
+		configMapWithData("unchanged", "a"),
+		configMapWithData("updated", "a"),
+		configMapWithData("pruned", "a"),
+	})
+	require.NoError(t, err)
+	unmanaged := configMap("unmanaged", testNamespace)
+	require.NoError(t, client.Tracker().Create(configMapGVR, unmanaged, testNamespace))
+	setupDryRun(client)
+	client.ClearActions()
+
+	objs, err := a.preview(context.Background(), []*unstructured.Unstructured{
+		configMapWithData("unchanged", "a"),
+		configMapWithData("updated", "b"),
+		configMapWithData("created", "a"),
+		configMap("unmanaged", ""),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff([]*rpc.ObjectPreview{
+		{Action: rpc.ObjectPreview_unchanged, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "unchanged"},
+		{Action: rpc.ObjectPreview_update, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "updated",
+			Changes: []*rpc.FieldChange{{Path: ".data.key", OldValue: `"a"`, NewValue: `"b"`}}},
+		{Action: rpc.ObjectPreview_create, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "created"},
+		{Action: rpc.ObjectPreview_failed, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "unmanaged",
+			Error: "object exists and is not managed by GitOps, set inventory_policy to adopt_if_no_inventory to take it over"},
+		{Action: rpc.ObjectPreview_prune, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "pruned"},
+	}, objs, protocmp.Transform()))
+	for _, action := range client.Actions() {
+		assert.Contains(t, []string{"get", "patch", "delete"}, action.GetVerb())
+	}
+	// Nothing has been changed.
+	getObject(t, client, configMapGVR, testNamespace, "pruned")
+	assertNotFound(t, client, configMapGVR, testNamespace, "created")
+}
+
+func TestPreview_Client(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{
+		configMapWithData("updated", "a"),
+		configMapWithData("pruned", "a"),
+	})
+	require.NoError(t, err)
+	a.dryRunStrategy = dryRunStrategyClient
+	client.ClearActions()
+
+	objs, err := a.preview(context.Background(), []*unstructured.Unstructured{
+		configMapWithData("updated", "b"),
+		configMapWithData("created", "a"),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff([]*rpc.ObjectPreview{
+		{Action: rpc.ObjectPreview_update, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "updated",
+			Changes: []*rpc.FieldChange{{Path: ".data.key", OldValue: `"a"`, NewValue: `"b"`}}},
+		{Action: rpc.ObjectPreview_create, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "created"},
+		{Action: rpc.ObjectPreview_prune, Version: "v1", Kind: "ConfigMap", Namespace: testNamespace, Name: "pruned"},
+	}, objs, protocmp.Transform()))
+	for _, action := range client.Actions() {
+		assert.Equal(t, "get", action.GetVerb())
+	}
+}
+
+func TestPreview_PruneDisabled(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", ""), configMap("cm2", "")})
+	require.NoError(t, err)
+	setupDryRun(client)
+	a.prune = false
+
+	objs, err := a.preview(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, rpc.ObjectPreview_unchanged, objs[0].Action)
+}
+
+func TestPreview_DoesNotPruneAllWithoutObjects(t *testing.T) {
+	a, client := setupApplier(t)
+	_, err := a.sync(context.Background(), []*unstructured.Unstructured{configMap("cm1", "")})
+	require.NoError(t, err)
+	setupDryRun(client)
+
+	_, err = a.preview(context.Background(), nil)
+	assert.ErrorIs(t, err, errPruneAll)
+}
+
+// setupDryRun makes the fake client handle writes like the API server handles a dry-run: apply returns the
+// resulting object and delete does nothing. The fake client does not pass the dry-run option to reactors.
+func setupDryRun(client *fake.FakeDynamicClient) {
+	tracker := client.Tracker()
+	client.PrependReactor("delete", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		obj := &unstructured.Unstructured{}
+		err := obj.UnmarshalJSON(patch.GetPatch())
+		if err != nil {
+			return true, nil, err
+		}
+		live, err := tracker.Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if err == nil {
+			liveObj := live.(*unstructured.Unstructured)
+			obj.SetUID(liveObj.GetUID())
+			obj.SetResourceVersion("next") // set by the API server, not a change
+		}
+		return true, obj, nil
+	})
+}
+
+func configMapWithData(name, value string) *unstructured.Unstructured {
+	obj := configMap(name, "")
+	obj.Object["data"] = map[string]any{"key": value}
+	return obj
+}
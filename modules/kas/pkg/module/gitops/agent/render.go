@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+const (
+	// maxManifestsSize limits the total size of the manifest files of a project.
+	maxManifestsSize = 20 * 1024 * 1024
+)
+
+// renderManifests returns the objects from the files of the commit that match the paths.
+// Objects are in the order of the files and of the documents in them.
+func renderManifests(commit *object.Commit, paths []*agentcfg.PathCF) ([]*unstructured.Unstructured, error) {
+	globs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		glob := strings.TrimPrefix(p.Glob, "/")
+		if !doublestar.ValidatePattern(glob) {
+			return nil, fmt.Errorf("invalid glob %q", p.Glob)
+		}
+		globs = append(globs, glob)
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		objs  []*unstructured.Unstructured
+		total int64
+	)
+	err = files.ForEach(func(f *object.File) error {
+		if !isManifestFile(f.Name) || !matchesAny(globs, f.Name) {
+			return nil
+		}
+		total += f.Size
+		if total > maxManifestsSize {
+			return fmt.Errorf("manifest files are larger than %d bytes in total", maxManifestsSize)
+		}
+		contents, err := f.Contents()
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+		fileObjs, err := decodeObjects([]byte(contents))
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+		objs = append(objs, fileObjs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+func isManifestFile(name string) bool {
+	for _, dir := range strings.Split(path.Dir(name), "/") {
+		if strings.HasPrefix(dir, ".") && dir != "." {
+			return false
+		}
+	}
+	switch path.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func matchesAny(globs []string, name string) bool {
+	for _, glob := range globs {
+		if doublestar.MatchUnvalidated(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeObjects decodes a YAML stream or a JSON document. Lists are expanded into their items.
+func decodeObjects(data []byte) ([]*unstructured.Unstructured, error) {
+	dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objs []*unstructured.Unstructured
+	for i := 0; ; i++ {
+		var m map[string]any
+		err := dec.Decode(&m)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if len(m) == 0 {
+			continue // empty document
+		}
+		u := &unstructured.Unstructured{Object: m}
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", i, err)
+			}
+			for j := range list.Items {
+				err = validateObject(&list.Items[j])
+				if err != nil {
+					return nil, fmt.Errorf("document %d, item %d: %w", i, j, err)
+				}
+				objs = append(objs, &list.Items[j])
+			}
+			continue
+		}
+		err = validateObject(u)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		objs = append(objs, u)
+	}
+}
+
+func validateObject(u *unstructured.Unstructured) error {
+	switch {
+	case u.GetAPIVersion() == "":
+		return errors.New("apiVersion is missing")
+	case u.GetKind() == "":
+		return errors.New("kind is missing")
+	case u.GetName() == "":
+		return errors.New("metadata.name is missing")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+)
+
+const (
+	configMapYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+`
+	twoDocumentsYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm2
+---
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: s1
+`
+	listJSON = `{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "v1", "kind": "ServiceAccount", "metadata": {"name": "sa1"}},
+  {"apiVersion": "v1", "kind": "ServiceAccount", "metadata": {"name": "sa2"}}
+]}`
+)
+
+func TestRenderManifests_Globs(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit(t, map[string]string{
+		"a.yaml":             configMapYAML,
+		"dir/b.yml":          twoDocumentsYAML,
+		"dir/list.json":      listJSON,
+		"dir/README.md":      "not a manifest",
+		".hidden/c.yaml":     configMapYAML,
+		"other/skipped.yaml": configMapYAML,
+	})
+	commit, err := r.repo.CommitObject(hash)
+	require.NoError(t, err)
+
+	objs, err := renderManifests(commit, []*agentcfg.PathCF{
+		{Glob: "/*.yaml"},
+		{Glob: "dir/**/*"},
+		{Glob: "**/c.yaml"}, // does not match files in dot-directories
+	})
+	require.NoError(t, err)
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+	}
+	assert.Equal(t, []string{
+		"ConfigMap/cm1",
+		"ConfigMap/cm2",
+		"Secret/s1",
+		"ServiceAccount/sa1",
+		"ServiceAccount/sa2",
+	}, names)
+}
+
+func TestRenderManifests_InvalidGlob(t *testing.T) {
+	r := newTestRepo(t)
+	commit, err := r.repo.CommitObject(r.commit(t, map[string]string{}))
+	require.NoError(t, err)
+	_, err = renderManifests(commit, []*agentcfg.PathCF{{Glob: "[a"}})
+	assert.EqualError(t, err, `invalid glob "[a"`)
+}
+
+func TestRenderManifests_InvalidManifest(t *testing.T) {
+	r := newTestRepo(t)
+	commit, err := r.repo.CommitObject(r.commit(t, map[string]string{
+		"a.yaml": configMapYAML + "---\napiVersion: v1\nkind: ConfigMap\n",
+	}))
+	require.NoError(t, err)
+	_, err = renderManifests(commit, []*agentcfg.PathCF{{Glob: "**"}})
+	assert.EqualError(t, err, "a.yaml: document 1: metadata.name is missing")
+}
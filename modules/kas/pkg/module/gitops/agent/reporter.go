@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+)
+
+// syncStatusReporter sends the sync status of all manifest projects to kas when it changes.
+// The status is also sent every pollConfig.Interval so that it doesn't expire in kas.
+type syncStatusReporter struct {
+	log        *zap.Logger
+	client     rpc.GitopsClient
+	pollConfig retry.PollConfig
+
+	mu       sync.Mutex // protects the fields below
+	statuses map[string]*rpc.ProjectSyncStatus
+	// version is incremented on every change.
+	version     uint64
+	sentVersion uint64
+	sentAt      time.Time
+}
+
+func newSyncStatusReporter(log *zap.Logger, client rpc.GitopsClient, pollConfig retry.PollConfig) *syncStatusReporter {
+	return &syncStatusReporter{
+		log:        log,
+		client:     client,
+		pollConfig: pollConfig,
+		statuses:   map[string]*rpc.ProjectSyncStatus{},
+	}
+}
+
+// set sets the status of a project.
+func (r *syncStatusReporter) set(status *rpc.ProjectSyncStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if proto.Equal(r.statuses[status.ProjectId], status) {
+		return
+	}
+	r.statuses[status.ProjectId] = status
+	r.version++
+	r.pollConfig.Poke()
+}
+
+// retain removes the status of the projects that are not in projectIds.
+func (r *syncStatusReporter) retain(projectIds map[string]struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changed := false
+	for id := range r.statuses {
+		if _, ok := projectIds[id]; !ok {
+			delete(r.statuses, id)
+			changed = true
+		}
+	}
+	if changed {
+		r.version++
+		r.pollConfig.Poke()
+	}
+}
+
+func (r *syncStatusReporter) Run(ctx context.Context) {
+	_ = retry.PollWithBackoff(ctx, r.pollConfig, func(ctx context.Context) (error, retry.AttemptResult) {
+		req, version := r.request()
+		if req == nil {
+			return nil, retry.Continue
+		}
+		_, err := r.client.ReportSyncStatus(ctx, req)
+		if err != nil {
+			if !errz.ContextDone(err) {
+				r.log.Warn("Failed to report sync status", logz.Error(err))
+			}
+			return nil, retry.Backoff
+		}
+		r.mu.Lock()
+		r.sentVersion = version
+		r.sentAt = time.Now()
+		r.mu.Unlock()
+		return nil, retry.Continue
+	})
+}
+
+// request returns the request to send and the version it is for. The request is nil if the status has not changed
+// since it was last sent and it's not time to refresh it yet.
+func (r *syncStatusReporter) request() (*rpc.ReportSyncStatusRequest, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changed := r.version != r.sentVersion
+	refresh := !r.sentAt.IsZero() && time.Since(r.sentAt) >= r.pollConfig.Interval
+	if !changed && !refresh {
+		return nil, 0
+	}
+	req := &rpc.ReportSyncStatusRequest{
+		Projects: make([]*rpc.ProjectSyncStatus, 0, len(r.statuses)),
+	}
+	for _, s := range r.statuses {
+		req.Projects = append(req.Projects, s)
+	}
+	sort.Slice(req.Projects, func(i, j int) bool {
+		return req.Projects[i].ProjectId < req.Projects[j].ProjectId
+	})
+	return req, r.version
+}
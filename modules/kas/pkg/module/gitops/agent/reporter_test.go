@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/matcher"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_gitops"
+)
+
+func TestReporter_SendsWhenChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_gitops.NewMockGitopsClient(ctrl)
+	r := newSyncStatusReporter(zaptest.NewLogger(t), client, testPollConfig(time.Hour))
+	s1 := &rpc.ProjectSyncStatus{ProjectId: "b", State: rpc.SyncState_synced}
+	s2 := &rpc.ProjectSyncStatus{ProjectId: "a", State: rpc.SyncState_failed, Message: "boom"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sent := make(chan struct{})
+	gomock.InOrder(
+		client.EXPECT().
+			ReportSyncStatus(gomock.Any(), matcher.ProtoEq(t, &rpc.ReportSyncStatusRequest{
+				Projects: []*rpc.ProjectSyncStatus{s2, s1}, // sorted by project id
+			})).
+			DoAndReturn(func(ctx context.Context, req *rpc.ReportSyncStatusRequest, opts ...grpc.CallOption) (*rpc.ReportSyncStatusResponse, error) {
+				sent <- struct{}{}
+				return &rpc.ReportSyncStatusResponse{}, nil
+			}),
+		client.EXPECT().
+			ReportSyncStatus(gomock.Any(), matcher.ProtoEq(t, &rpc.ReportSyncStatusRequest{
+				Projects: []*rpc.ProjectSyncStatus{s1},
+			})).
+			DoAndReturn(func(ctx context.Context, req *rpc.ReportSyncStatusRequest, opts ...grpc.CallOption) (*rpc.ReportSyncStatusResponse, error) {
+				cancel()
+				return &rpc.ReportSyncStatusResponse{}, nil
+			}),
+	)
+	r.set(s1)
+	r.set(s2)
+	go func() {
+		<-sent
+		r.set(s1) // no change
+		r.retain(map[string]struct{}{"b": {}})
+	}()
+	r.Run(ctx)
+}
+
+func TestReporter_NothingToSend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_gitops.NewMockGitopsClient(ctrl)
+	r := newSyncStatusReporter(zaptest.NewLogger(t), client, testPollConfig(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	r.Run(ctx) // no calls are expected
+}
+
+func testPollConfig(interval time.Duration) retry.PollConfig {
+	return retry.NewPollConfigFactory(interval, retry.NewExponentialBackoffFactory(time.Second, time.Second, time.Second, 2, 0))()
+}
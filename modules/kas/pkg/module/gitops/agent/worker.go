@@ -0,0 +1,108 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+)
+
+// worker syncs a manifest project. It polls the ref and syncs the manifests when the ref points to a different
+// commit. Manifests are also synced every resyncPeriod to undo changes made to the objects in the cluster.
+type worker struct {
+	log          *zap.Logger
+	project      *agentcfg.ManifestProjectCF
+	repo         *gitRepository
+	repoErr      error
+	applier      *applier
+	reporter     *syncStatusReporter
+	pollConfig   retry.PollConfigFactory
+	resyncPeriod time.Duration
+}
+
+func (w *worker) Run(ctx context.Context) {
+	if w.repoErr != nil {
+		w.reportFailure(plumbing.ZeroHash, w.repoErr)
+		return
+	}
+	var (
+		// resolvedHash is what the ref pointed to when the manifests were rendered.
+		resolvedHash plumbing.Hash
+		commitId     plumbing.Hash
+		objs         []*unstructured.Unstructured
+		// syncedAt is zero if the last sync has failed.
+		syncedAt time.Time
+	)
+	_ = retry.PollWithBackoff(ctx, w.pollConfig(), func(ctx context.Context) (error, retry.AttemptResult) {
+		name, hash, err := w.repo.resolve(ctx, w.project.Ref)
+		if err != nil {
+			return nil, w.handleError(ctx, plumbing.ZeroHash, fmt.Errorf("resolve ref: %w", err))
+		}
+		if hash != resolvedHash || objs == nil {
+			commit, err := w.repo.fetch(ctx, name, hash)
+			if err != nil {
+				return nil, w.handleError(ctx, plumbing.ZeroHash, fmt.Errorf("fetch: %w", err))
+			}
+			rendered, err := renderManifests(commit, w.project.Paths)
+			if err != nil {
+				return nil, w.handleError(ctx, commit.Hash, fmt.Errorf("render manifests: %w", err))
+			}
+			w.log.Info("Syncing new commit", logz.CommitId(commit.Hash.String()))
+			resolvedHash = hash
+			commitId = commit.Hash
+			objs = rendered
+			syncedAt = time.Time{}
+		} else if !syncedAt.IsZero() && time.Since(syncedAt) < w.resyncPeriod {
+			return nil, retry.Continue
+		}
+		result, err := w.applier.sync(ctx, objs)
+		if err != nil {
+			syncedAt = time.Time{}
+			return nil, w.handleError(ctx, commitId, fmt.Errorf("sync: %w", err))
+		}
+		syncedAt = time.Now()
+		w.reporter.set(&rpc.ProjectSyncStatus{
+			ProjectId:      w.project.GetId(),
+			CommitId:       commitId.String(),
+			State:          rpc.SyncState_synced,
+			AppliedObjects: int32(result.applied), // nolint:gosec
+			PrunedObjects:  int32(result.pruned),  // nolint:gosec
+			SyncedAt:       timestamppb.New(syncedAt),
+			DryRun:         w.applier.dryRunStrategy != dryRunStrategyNone,
+		})
+		return nil, retry.Continue
+	})
+}
+
+// handleError reports the failure unless the context is done i.e. the worker is being stopped.
+func (w *worker) handleError(ctx context.Context, commitId plumbing.Hash, err error) retry.AttemptResult {
+	if ctx.Err() != nil {
+		return retry.Done
+	}
+	w.log.Error("GitOps sync failed", logz.Error(err))
+	w.reportFailure(commitId, err)
+	return retry.Backoff
+}
+
+func (w *worker) reportFailure(commitId plumbing.Hash, err error) {
+	status := &rpc.ProjectSyncStatus{
+		ProjectId: w.project.GetId(),
+		State:     rpc.SyncState_failed,
+		Message:   err.Error(),
+		SyncedAt:  timestamppb.Now(),
+		DryRun:    w.applier.dryRunStrategy != dryRunStrategyNone,
+	}
+	if !commitId.IsZero() {
+		status.CommitId = commitId.String()
+	}
+	w.reporter.set(status)
+}
@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+)
+
+type workerFactory struct {
+	log                *zap.Logger
+	client             dynamic.Interface
+	mapper             meta.RESTMapper
+	gitAuth            *GitAuth
+	reporter           *syncStatusReporter
+	inventoryNs        string
+	pollConfig         retry.PollConfigFactory
+	resyncPeriod       time.Duration
+	objectPollInterval time.Duration
+}
+
+func (f *workerFactory) New(agentId int64, source modagent.WorkSource[*agentcfg.ManifestProjectCF]) modagent.Worker {
+	project := source.Configuration()
+	log := f.log.With(logz.WorkerId(project.GetId()))
+	w := &worker{
+		log:          log,
+		project:      project,
+		reporter:     f.reporter,
+		pollConfig:   f.pollConfig,
+		resyncPeriod: f.resyncPeriod,
+		applier:      newApplier(log, f.client, f.mapper, f.inventoryNs, agentId, project, f.objectPollInterval),
+	}
+	// The id has been validated in DefaultAndValidateConfiguration.
+	w.repo, w.repoErr = newGitRepository(project.GetId(), f.gitAuth)
+	return w
+}
+
+func (f *workerFactory) SourcesFromConfiguration(cfg *agentcfg.AgentConfiguration) []modagent.WorkSource[*agentcfg.ManifestProjectCF] {
+	projects := cfg.Gitops.ManifestProjects
+	sources := make([]modagent.WorkSource[*agentcfg.ManifestProjectCF], 0, len(projects))
+	for _, project := range projects {
+		sources = append(sources, manifestSource{project: project})
+	}
+	return sources
+}
+
+type manifestSource struct {
+	project *agentcfg.ManifestProjectCF
+}
+
+func (s manifestSource) ID() string {
+	return s.project.GetId()
+}
+
+func (s manifestSource) Configuration() *agentcfg.ManifestProjectCF {
+	return s.project
+}
+
+// newApplier returns an applier for the manifest project.
+func newApplier(log *zap.Logger, client dynamic.Interface, mapper meta.RESTMapper, inventoryNs string, agentId int64,
+	project *agentcfg.ManifestProjectCF, pollInterval time.Duration) *applier {
+	return &applier{
+		log:    log,
+		client: client,
+		mapper: mapper,
+		inventory: &inventory{
+			client:    client,
+			namespace: inventoryNs,
+			name:      inventoryName(agentId, project.GetId()),
+			projectId: project.GetId(),
+		},
+		defaultNamespace:       project.DefaultNamespace,
+		dryRunStrategy:         project.DryRunStrategy,
+		prune:                  project.GetPrune(),
+		pruneEmpty:             project.PruneEmpty,
+		prunePropagationPolicy: propagationPolicy(project.PrunePropagationPolicy),
+		inventoryPolicy:        project.InventoryPolicy,
+		reconcileTimeout:       project.ReconcileTimeout.AsDuration(),
+		pruneTimeout:           project.PruneTimeout.AsDuration(),
+		pollInterval:           pollInterval,
+	}
+}
+
+// propagationPolicy maps the configuration value to the API value.
+func propagationPolicy(policy string) meta_v1.DeletionPropagation {
+	switch policy {
+	case "orphan":
+		return meta_v1.DeletePropagationOrphan
+	case "background":
+		return meta_v1.DeletePropagationBackground
+	default:
+		return meta_v1.DeletePropagationForeground
+	}
+}
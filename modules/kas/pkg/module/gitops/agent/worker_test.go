@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_gitops"
+)
+
+func TestWorker_SyncsNewCommits(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"cm.yaml": configMapYAML, "dir/cm2.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm2\n"})
+	w, client := setupWorker(t, r)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.Run(ctx)
+	}()
+
+	status := waitForStatus(t, w, c1.String())
+	assert.Equal(t, rpc.SyncState_synced, status.State)
+	assert.EqualValues(t, 2, status.AppliedObjects)
+	assert.False(t, status.DryRun)
+	getObject(t, client, configMapGVR, testNamespace, "cm1")
+	getObject(t, client, configMapGVR, testNamespace, "cm2")
+
+	c2 := r.commit(t, map[string]string{"cm.yaml": configMapYAML})
+	status = waitForStatus(t, w, c2.String())
+	assert.Equal(t, rpc.SyncState_synced, status.State)
+	assert.EqualValues(t, 1, status.AppliedObjects)
+	assert.EqualValues(t, 1, status.PrunedObjects)
+	assertNotFound(t, client, configMapGVR, testNamespace, "cm2")
+}
+
+func TestWorker_ReportsFailure(t *testing.T) {
+	r := newTestRepo(t)
+	c1 := r.commit(t, map[string]string{"cm.yaml": "kind: ConfigMap\n"})
+	w, _ := setupWorker(t, r)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.Run(ctx)
+	}()
+
+	status := waitForStatus(t, w, c1.String())
+	assert.Equal(t, rpc.SyncState_failed, status.State)
+	assert.Equal(t, "render manifests: cm.yaml: document 0: apiVersion is missing", status.Message)
+}
+
+func setupWorker(t *testing.T, r *testRepo) (*worker, *fake.FakeDynamicClient) {
+	a, client := setupApplier(t)
+	repo := r.gitRepository(t)
+	ctrl := gomock.NewController(t)
+	project := &agentcfg.ManifestProjectCF{
+		Id:    proto.String(repo.url),
+		Paths: []*agentcfg.PathCF{{Glob: "**"}},
+	}
+	return &worker{
+		log:      zaptest.NewLogger(t),
+		project:  project,
+		repo:     repo,
+		applier:  a,
+		reporter: newSyncStatusReporter(zaptest.NewLogger(t), mock_gitops.NewMockGitopsClient(ctrl), testPollConfig(time.Hour)),
+		pollConfig: retry.NewPollConfigFactory(10*time.Millisecond, retry.NewExponentialBackoffFactory(
+			10*time.Millisecond, 10*time.Millisecond, time.Second, 2, 0)),
+		resyncPeriod: time.Hour,
+	}, client
+}
+
+// waitForStatus waits for the status of the commit to be reported.
+func waitForStatus(t *testing.T, w *worker, commitId string) *rpc.ProjectSyncStatus {
+	var status *rpc.ProjectSyncStatus
+	require.Eventually(t, func() bool {
+		w.reporter.mu.Lock()
+		defer w.reporter.mu.Unlock()
+		status = w.reporter.statuses[w.project.GetId()]
+		return status != nil && status.CommitId == commitId
+	}, 5*time.Second, 10*time.Millisecond)
+	return status
+}
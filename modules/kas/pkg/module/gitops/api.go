@@ -0,0 +1,8 @@
+package gitops
+
+const (
+	ModuleName = "gitops"
+	// PreviewModuleName is the name of the agentk module that previews manifest projects. Unlike the gitops module,
+	// it runs in every agentk pod because preview requests can be routed to any of them.
+	PreviewModuleName = "gitops_preview"
+)
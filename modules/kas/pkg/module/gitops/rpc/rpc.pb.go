@@ -0,0 +1,982 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: pkg/module/gitops/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	agentcfg "github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SyncState int32
+
+const (
+	// The project has not been synced yet.
+	SyncState_sync_state_unknown SyncState = 0
+	// Manifests at commit_id have been applied and, if enabled, pruned.
+	SyncState_synced SyncState = 1
+	// The last sync has failed, see message.
+	SyncState_failed SyncState = 2
+)
+
+// Enum value maps for SyncState.
+var (
+	SyncState_name = map[int32]string{
+		0: "sync_state_unknown",
+		1: "synced",
+		2: "failed",
+	}
+	SyncState_value = map[string]int32{
+		"sync_state_unknown": 0,
+		"synced":             1,
+		"failed":             2,
+	}
+)
+
+func (x SyncState) Enum() *SyncState {
+	p := new(SyncState)
+	*p = x
+	return p
+}
+
+func (x SyncState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SyncState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_module_gitops_rpc_rpc_proto_enumTypes[0].Descriptor()
+}
+
+func (SyncState) Type() protoreflect.EnumType {
+	return &file_pkg_module_gitops_rpc_rpc_proto_enumTypes[0]
+}
+
+func (x SyncState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SyncState.Descriptor instead.
+func (SyncState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+type ObjectPreview_Action int32
+
+const (
+	ObjectPreview_action_unknown ObjectPreview_Action = 0
+	// The object does not exist and would be created.
+	ObjectPreview_create ObjectPreview_Action = 1
+	// The object exists and would be changed, see changes.
+	ObjectPreview_update ObjectPreview_Action = 2
+	// The object exists and would not be changed.
+	ObjectPreview_unchanged ObjectPreview_Action = 3
+	// The object has been removed from the manifests and would be deleted.
+	ObjectPreview_prune ObjectPreview_Action = 4
+	// The object could not be applied or deleted, see error.
+	ObjectPreview_failed ObjectPreview_Action = 5
+)
+
+// Enum value maps for ObjectPreview_Action.
+var (
+	ObjectPreview_Action_name = map[int32]string{
+		0: "action_unknown",
+		1: "create",
+		2: "update",
+		3: "unchanged",
+		4: "prune",
+		5: "failed",
+	}
+	ObjectPreview_Action_value = map[string]int32{
+		"action_unknown": 0,
+		"create":         1,
+		"update":         2,
+		"unchanged":      3,
+		"prune":          4,
+		"failed":         5,
+	}
+)
+
+func (x ObjectPreview_Action) Enum() *ObjectPreview_Action {
+	p := new(ObjectPreview_Action)
+	*p = x
+	return p
+}
+
+func (x ObjectPreview_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ObjectPreview_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_module_gitops_rpc_rpc_proto_enumTypes[1].Descriptor()
+}
+
+func (ObjectPreview_Action) Type() protoreflect.EnumType {
+	return &file_pkg_module_gitops_rpc_rpc_proto_enumTypes[1]
+}
+
+func (x ObjectPreview_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ObjectPreview_Action.Descriptor instead.
+func (ObjectPreview_Action) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{9, 0}
+}
+
+// Sync status of a manifest project.
+type ProjectSyncStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Id of the manifest project i.e. the Git repository URL.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,proto3" json:"project_id,omitempty"`
+	// Commit the manifests are from. Empty if the ref could not be resolved.
+	CommitId string    `protobuf:"bytes,2,opt,name=commit_id,proto3" json:"commit_id,omitempty"`
+	State    SyncState `protobuf:"varint,3,opt,name=state,proto3,enum=plural.agent.gitops.rpc.SyncState" json:"state,omitempty"`
+	// Why the sync has failed.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Number of objects that have been applied.
+	AppliedObjects int32 `protobuf:"varint,5,opt,name=applied_objects,proto3" json:"applied_objects,omitempty"`
+	// Number of objects that have been pruned.
+	PrunedObjects int32 `protobuf:"varint,6,opt,name=pruned_objects,proto3" json:"pruned_objects,omitempty"`
+	// When the sync has finished.
+	SyncedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=synced_at,proto3" json:"synced_at,omitempty"`
+	// Objects have not been changed because of the dry run strategy of the project.
+	DryRun        bool `protobuf:"varint,8,opt,name=dry_run,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectSyncStatus) Reset() {
+	*x = ProjectSyncStatus{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectSyncStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectSyncStatus) ProtoMessage() {}
+
+func (x *ProjectSyncStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectSyncStatus.ProtoReflect.Descriptor instead.
+func (*ProjectSyncStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProjectSyncStatus) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ProjectSyncStatus) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *ProjectSyncStatus) GetState() SyncState {
+	if x != nil {
+		return x.State
+	}
+	return SyncState_sync_state_unknown
+}
+
+func (x *ProjectSyncStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProjectSyncStatus) GetAppliedObjects() int32 {
+	if x != nil {
+		return x.AppliedObjects
+	}
+	return 0
+}
+
+func (x *ProjectSyncStatus) GetPrunedObjects() int32 {
+	if x != nil {
+		return x.PrunedObjects
+	}
+	return 0
+}
+
+func (x *ProjectSyncStatus) GetSyncedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SyncedAt
+	}
+	return nil
+}
+
+func (x *ProjectSyncStatus) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ReportSyncStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of all manifest projects of the agent.
+	Projects      []*ProjectSyncStatus `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSyncStatusRequest) Reset() {
+	*x = ReportSyncStatusRequest{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSyncStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSyncStatusRequest) ProtoMessage() {}
+
+func (x *ReportSyncStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSyncStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReportSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReportSyncStatusRequest) GetProjects() []*ProjectSyncStatus {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type ReportSyncStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSyncStatusResponse) Reset() {
+	*x = ReportSyncStatusResponse{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSyncStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSyncStatusResponse) ProtoMessage() {}
+
+func (x *ReportSyncStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSyncStatusResponse.ProtoReflect.Descriptor instead.
+func (*ReportSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+// Sync status of an agent, as stored by kas.
+type AgentSyncStatus struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AgentId  int64                  `protobuf:"varint,1,opt,name=agent_id,proto3" json:"agent_id,omitempty"`
+	Projects []*ProjectSyncStatus   `protobuf:"bytes,2,rep,name=projects,proto3" json:"projects,omitempty"`
+	// When agentk has reported the status.
+	ReportedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=reported_at,proto3" json:"reported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentSyncStatus) Reset() {
+	*x = AgentSyncStatus{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentSyncStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentSyncStatus) ProtoMessage() {}
+
+func (x *AgentSyncStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentSyncStatus.ProtoReflect.Descriptor instead.
+func (*AgentSyncStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AgentSyncStatus) GetAgentId() int64 {
+	if x != nil {
+		return x.AgentId
+	}
+	return 0
+}
+
+func (x *AgentSyncStatus) GetProjects() []*ProjectSyncStatus {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *AgentSyncStatus) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+type GetSyncStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       int64                  `protobuf:"varint,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusRequest) Reset() {
+	*x = GetSyncStatusRequest{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusRequest) ProtoMessage() {}
+
+func (x *GetSyncStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSyncStatusRequest) GetAgentId() int64 {
+	if x != nil {
+		return x.AgentId
+	}
+	return 0
+}
+
+type GetSyncStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Not set if the agent has not reported a status recently.
+	Status        *AgentSyncStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusResponse) Reset() {
+	*x = GetSyncStatusResponse{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusResponse) ProtoMessage() {}
+
+func (x *GetSyncStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSyncStatusResponse) GetStatus() *AgentSyncStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type PreviewRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	AgentId       int64                    `protobuf:"varint,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Preview       *PreviewManifestsRequest `protobuf:"bytes,2,opt,name=preview,proto3" json:"preview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRequest) Reset() {
+	*x = PreviewRequest{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRequest) ProtoMessage() {}
+
+func (x *PreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PreviewRequest) GetAgentId() int64 {
+	if x != nil {
+		return x.AgentId
+	}
+	return 0
+}
+
+func (x *PreviewRequest) GetPreview() *PreviewManifestsRequest {
+	if x != nil {
+		return x.Preview
+	}
+	return nil
+}
+
+type PreviewManifestsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Id of a manifest project in the configuration of the agent.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,proto3" json:"project_id,omitempty"`
+	// Ref to preview, e.g. the branch of a merge request. The ref of the project is used if not set.
+	Ref           *agentcfg.GitRefCF `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewManifestsRequest) Reset() {
+	*x = PreviewManifestsRequest{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewManifestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewManifestsRequest) ProtoMessage() {}
+
+func (x *PreviewManifestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewManifestsRequest.ProtoReflect.Descriptor instead.
+func (*PreviewManifestsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PreviewManifestsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *PreviewManifestsRequest) GetRef() *agentcfg.GitRefCF {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+// A change of a field of an object.
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the field, e.g. .spec.template.spec.containers[0].image.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// JSON of the live value. Empty if the field is added.
+	OldValue string `protobuf:"bytes,2,opt,name=old_value,proto3" json:"old_value,omitempty"`
+	// JSON of the new value. Empty if the field is removed.
+	NewValue      string `protobuf:"bytes,3,opt,name=new_value,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FieldChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// What syncing would do to an object.
+type ObjectPreview struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Action  ObjectPreview_Action   `protobuf:"varint,1,opt,name=action,proto3,enum=plural.agent.gitops.rpc.ObjectPreview_Action" json:"action,omitempty"`
+	Group   string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Version string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Kind    string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Empty for cluster-scoped objects.
+	Namespace string `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// Changed fields, sorted by path. Only set for update.
+	Changes []*FieldChange `protobuf:"bytes,7,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Why the object could not be applied or deleted. Only set for failed.
+	Error         string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectPreview) Reset() {
+	*x = ObjectPreview{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectPreview) ProtoMessage() {}
+
+func (x *ObjectPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectPreview.ProtoReflect.Descriptor instead.
+func (*ObjectPreview) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ObjectPreview) GetAction() ObjectPreview_Action {
+	if x != nil {
+		return x.Action
+	}
+	return ObjectPreview_action_unknown
+}
+
+func (x *ObjectPreview) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ObjectPreview) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ObjectPreview) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ObjectPreview) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ObjectPreview) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ObjectPreview) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ObjectPreview) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PreviewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Commit the manifests are from.
+	CommitId string `protobuf:"bytes,1,opt,name=commit_id,proto3" json:"commit_id,omitempty"`
+	// How the objects have been compared with the live ones:
+	// - "server": the result of a server-side dry-run apply is compared. Fields the API server sets, such as defaults,
+	//   are included.
+	// - "client": only the fields set in the manifests are compared. Used if the dry_run_strategy of the project
+	//   is "client".
+	Strategy string `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// Objects in the order they would be applied, followed by the objects that would be pruned.
+	Objects       []*ObjectPreview `protobuf:"bytes,3,rep,name=objects,proto3" json:"objects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewResponse) Reset() {
+	*x = PreviewResponse{}
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewResponse) ProtoMessage() {}
+
+func (x *PreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_gitops_rpc_rpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewResponse.ProtoReflect.Descriptor instead.
+func (*PreviewResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PreviewResponse) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *PreviewResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *PreviewResponse) GetObjects() []*ObjectPreview {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+var File_pkg_module_gitops_rpc_rpc_proto protoreflect.FileDescriptor
+
+const file_pkg_module_gitops_rpc_rpc_proto_rawDesc = "" +
+	"\n" +
+	"\x1fpkg/module/gitops/rpc/rpc.proto\x12\x17plural.agent.gitops.rpc\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bpkg/agentcfg/agentcfg.proto\x1a\x17validate/validate.proto\"\xde\x02\n" +
+	"\x11ProjectSyncStatus\x12'\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\n" +
+	"project_id\x12\x1c\n" +
+	"\tcommit_id\x18\x02 \x01(\tR\tcommit_id\x12B\n" +
+	"\x05state\x18\x03 \x01(\x0e2\".plural.agent.gitops.rpc.SyncStateB\b\xfaB\x05\x82\x01\x02\x10\x01R\x05state\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12(\n" +
+	"\x0fapplied_objects\x18\x05 \x01(\x05R\x0fapplied_objects\x12&\n" +
+	"\x0epruned_objects\x18\x06 \x01(\x05R\x0epruned_objects\x128\n" +
+	"\tsynced_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tsynced_at\x12\x18\n" +
+	"\adry_run\x18\b \x01(\bR\adry_run\"l\n" +
+	"\x17ReportSyncStatusRequest\x12Q\n" +
+	"\bprojects\x18\x01 \x03(\v2*.plural.agent.gitops.rpc.ProjectSyncStatusB\t\xfaB\x06\x92\x01\x03\x10\xe8\aR\bprojects\"\x1a\n" +
+	"\x18ReportSyncStatusResponse\"\xb3\x01\n" +
+	"\x0fAgentSyncStatus\x12\x1a\n" +
+	"\bagent_id\x18\x01 \x01(\x03R\bagent_id\x12F\n" +
+	"\bprojects\x18\x02 \x03(\v2*.plural.agent.gitops.rpc.ProjectSyncStatusR\bprojects\x12<\n" +
+	"\vreported_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vreported_at\":\n" +
+	"\x14GetSyncStatusRequest\x12\"\n" +
+	"\bagent_id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\aagentId\"Y\n" +
+	"\x15GetSyncStatusResponse\x12@\n" +
+	"\x06status\x18\x01 \x01(\v2(.plural.agent.gitops.rpc.AgentSyncStatusR\x06status\"\x8a\x01\n" +
+	"\x0ePreviewRequest\x12\"\n" +
+	"\bagent_id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\aagentId\x12T\n" +
+	"\apreview\x18\x02 \x01(\v20.plural.agent.gitops.rpc.PreviewManifestsRequestB\b\xfaB\x05\x8a\x01\x02\x10\x01R\apreview\"u\n" +
+	"\x17PreviewManifestsRequest\x12'\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\n" +
+	"project_id\x121\n" +
+	"\x03ref\x18\x02 \x01(\v2\x1f.plural.agent.agentcfg.GitRefCFR\x03ref\"]\n" +
+	"\vFieldChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1c\n" +
+	"\told_value\x18\x02 \x01(\tR\told_value\x12\x1c\n" +
+	"\tnew_value\x18\x03 \x01(\tR\tnew_value\"\xfe\x02\n" +
+	"\rObjectPreview\x12E\n" +
+	"\x06action\x18\x01 \x01(\x0e2-.plural.agent.gitops.rpc.ObjectPreview.ActionR\x06action\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12>\n" +
+	"\achanges\x18\a \x03(\v2$.plural.agent.gitops.rpc.FieldChangeR\achanges\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"Z\n" +
+	"\x06Action\x12\x12\n" +
+	"\x0eaction_unknown\x10\x00\x12\n" +
+	"\n" +
+	"\x06create\x10\x01\x12\n" +
+	"\n" +
+	"\x06update\x10\x02\x12\r\n" +
+	"\tunchanged\x10\x03\x12\t\n" +
+	"\x05prune\x10\x04\x12\n" +
+	"\n" +
+	"\x06failed\x10\x05\"\x8d\x01\n" +
+	"\x0fPreviewResponse\x12\x1c\n" +
+	"\tcommit_id\x18\x01 \x01(\tR\tcommit_id\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12@\n" +
+	"\aobjects\x18\x03 \x03(\v2&.plural.agent.gitops.rpc.ObjectPreviewR\aobjects*;\n" +
+	"\tSyncState\x12\x16\n" +
+	"\x12sync_state_unknown\x10\x00\x12\n" +
+	"\n" +
+	"\x06synced\x10\x01\x12\n" +
+	"\n" +
+	"\x06failed\x10\x022\x83\x01\n" +
+	"\x06Gitops\x12y\n" +
+	"\x10ReportSyncStatus\x120.plural.agent.gitops.rpc.ReportSyncStatusRequest\x1a1.plural.agent.gitops.rpc.ReportSyncStatusResponse\"\x002\xdd\x01\n" +
+	"\tGitopsApi\x12p\n" +
+	"\rGetSyncStatus\x12-.plural.agent.gitops.rpc.GetSyncStatusRequest\x1a..plural.agent.gitops.rpc.GetSyncStatusResponse\"\x00\x12^\n" +
+	"\aPreview\x12'.plural.agent.gitops.rpc.PreviewRequest\x1a(.plural.agent.gitops.rpc.PreviewResponse\"\x002x\n" +
+	"\rGitopsPreview\x12g\n" +
+	"\aPreview\x120.plural.agent.gitops.rpc.PreviewManifestsRequest\x1a(.plural.agent.gitops.rpc.PreviewResponse\"\x00B<Z:github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpcb\x06proto3"
+
+var (
+	file_pkg_module_gitops_rpc_rpc_proto_rawDescOnce sync.Once
+	file_pkg_module_gitops_rpc_rpc_proto_rawDescData []byte
+)
+
+func file_pkg_module_gitops_rpc_rpc_proto_rawDescGZIP() []byte {
+	file_pkg_module_gitops_rpc_rpc_proto_rawDescOnce.Do(func() {
+		file_pkg_module_gitops_rpc_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_module_gitops_rpc_rpc_proto_rawDesc), len(file_pkg_module_gitops_rpc_rpc_proto_rawDesc)))
+	})
+	return file_pkg_module_gitops_rpc_rpc_proto_rawDescData
+}
+
+var file_pkg_module_gitops_rpc_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_pkg_module_gitops_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_pkg_module_gitops_rpc_rpc_proto_goTypes = []any{
+	(SyncState)(0),                   // 0: plural.agent.gitops.rpc.SyncState
+	(ObjectPreview_Action)(0),        // 1: plural.agent.gitops.rpc.ObjectPreview.Action
+	(*ProjectSyncStatus)(nil),        // 2: plural.agent.gitops.rpc.ProjectSyncStatus
+	(*ReportSyncStatusRequest)(nil),  // 3: plural.agent.gitops.rpc.ReportSyncStatusRequest
+	(*ReportSyncStatusResponse)(nil), // 4: plural.agent.gitops.rpc.ReportSyncStatusResponse
+	(*AgentSyncStatus)(nil),          // 5: plural.agent.gitops.rpc.AgentSyncStatus
+	(*GetSyncStatusRequest)(nil),     // 6: plural.agent.gitops.rpc.GetSyncStatusRequest
+	(*GetSyncStatusResponse)(nil),    // 7: plural.agent.gitops.rpc.GetSyncStatusResponse
+	(*PreviewRequest)(nil),           // 8: plural.agent.gitops.rpc.PreviewRequest
+	(*PreviewManifestsRequest)(nil),  // 9: plural.agent.gitops.rpc.PreviewManifestsRequest
+	(*FieldChange)(nil),              // 10: plural.agent.gitops.rpc.FieldChange
+	(*ObjectPreview)(nil),            // 11: plural.agent.gitops.rpc.ObjectPreview
+	(*PreviewResponse)(nil),          // 12: plural.agent.gitops.rpc.PreviewResponse
+	(*timestamppb.Timestamp)(nil),    // 13: google.protobuf.Timestamp
+	(*agentcfg.GitRefCF)(nil),        // 14: plural.agent.agentcfg.GitRefCF
+}
+var file_pkg_module_gitops_rpc_rpc_proto_depIdxs = []int32{
+	0,  // 0: plural.agent.gitops.rpc.ProjectSyncStatus.state:type_name -> plural.agent.gitops.rpc.SyncState
+	13, // 1: plural.agent.gitops.rpc.ProjectSyncStatus.synced_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: plural.agent.gitops.rpc.ReportSyncStatusRequest.projects:type_name -> plural.agent.gitops.rpc.ProjectSyncStatus
+	2,  // 3: plural.agent.gitops.rpc.AgentSyncStatus.projects:type_name -> plural.agent.gitops.rpc.ProjectSyncStatus
+	13, // 4: plural.agent.gitops.rpc.AgentSyncStatus.reported_at:type_name -> google.protobuf.Timestamp
+	5,  // 5: plural.agent.gitops.rpc.GetSyncStatusResponse.status:type_name -> plural.agent.gitops.rpc.AgentSyncStatus
+	9,  // 6: plural.agent.gitops.rpc.PreviewRequest.preview:type_name -> plural.agent.gitops.rpc.PreviewManifestsRequest
+	14, // 7: plural.agent.gitops.rpc.PreviewManifestsRequest.ref:type_name -> plural.agent.agentcfg.GitRefCF
+	1,  // 8: plural.agent.gitops.rpc.ObjectPreview.action:type_name -> plural.agent.gitops.rpc.ObjectPreview.Action
+	10, // 9: plural.agent.gitops.rpc.ObjectPreview.changes:type_name -> plural.agent.gitops.rpc.FieldChange
+	11, // 10: plural.agent.gitops.rpc.PreviewResponse.objects:type_name -> plural.agent.gitops.rpc.ObjectPreview
+	3,  // 11: plural.agent.gitops.rpc.Gitops.ReportSyncStatus:input_type -> plural.agent.gitops.rpc.ReportSyncStatusRequest
+	6,  // 12: plural.agent.gitops.rpc.GitopsApi.GetSyncStatus:input_type -> plural.agent.gitops.rpc.GetSyncStatusRequest
+	8,  // 13: plural.agent.gitops.rpc.GitopsApi.Preview:input_type -> plural.agent.gitops.rpc.PreviewRequest
+	9,  // 14: plural.agent.gitops.rpc.GitopsPreview.Preview:input_type -> plural.agent.gitops.rpc.PreviewManifestsRequest
+	4,  // 15: plural.agent.gitops.rpc.Gitops.ReportSyncStatus:output_type -> plural.agent.gitops.rpc.ReportSyncStatusResponse
+	7,  // 16: plural.agent.gitops.rpc.GitopsApi.GetSyncStatus:output_type -> plural.agent.gitops.rpc.GetSyncStatusResponse
+	12, // 17: plural.agent.gitops.rpc.GitopsApi.Preview:output_type -> plural.agent.gitops.rpc.PreviewResponse
+	12, // 18: plural.agent.gitops.rpc.GitopsPreview.Preview:output_type -> plural.agent.gitops.rpc.PreviewResponse
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_pkg_module_gitops_rpc_rpc_proto_init() }
+func file_pkg_module_gitops_rpc_rpc_proto_init() {
+	if File_pkg_module_gitops_rpc_rpc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_gitops_rpc_rpc_proto_rawDesc), len(file_pkg_module_gitops_rpc_rpc_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_pkg_module_gitops_rpc_rpc_proto_goTypes,
+		DependencyIndexes: file_pkg_module_gitops_rpc_rpc_proto_depIdxs,
+		EnumInfos:         file_pkg_module_gitops_rpc_rpc_proto_enumTypes,
+		MessageInfos:      file_pkg_module_gitops_rpc_rpc_proto_msgTypes,
+	}.Build()
+	File_pkg_module_gitops_rpc_rpc_proto = out.File
+	file_pkg_module_gitops_rpc_rpc_proto_goTypes = nil
+	file_pkg_module_gitops_rpc_rpc_proto_depIdxs = nil
+}
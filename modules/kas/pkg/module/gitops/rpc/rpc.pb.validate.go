@@ -0,0 +1,1541 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: pkg/module/gitops/rpc/rpc.proto
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on ProjectSyncStatus with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ProjectSyncStatus) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProjectSyncStatus with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ProjectSyncStatusMultiError, or nil if none found.
+func (m *ProjectSyncStatus) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProjectSyncStatus) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetProjectId()) < 1 {
+		err := ProjectSyncStatusValidationError{
+			field:  "ProjectId",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for CommitId
+
+	if _, ok := SyncState_name[int32(m.GetState())]; !ok {
+		err := ProjectSyncStatusValidationError{
+			field:  "State",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Message
+
+	// no validation rules for AppliedObjects
+
+	// no validation rules for PrunedObjects
+
+	if all {
+		switch v := interface{}(m.GetSyncedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ProjectSyncStatusValidationError{
+					field:  "SyncedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ProjectSyncStatusValidationError{
+					field:  "SyncedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSyncedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ProjectSyncStatusValidationError{
+				field:  "SyncedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for DryRun
+
+	if len(errors) > 0 {
+		return ProjectSyncStatusMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProjectSyncStatusMultiError is an error wrapping multiple validation errors
+// returned by ProjectSyncStatus.ValidateAll() if the designated constraints
+// aren't met.
+type ProjectSyncStatusMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProjectSyncStatusMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProjectSyncStatusMultiError) AllErrors() []error { return m }
+
+// ProjectSyncStatusValidationError is the validation error returned by
+// ProjectSyncStatus.Validate if the designated constraints aren't met.
+type ProjectSyncStatusValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProjectSyncStatusValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProjectSyncStatusValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProjectSyncStatusValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProjectSyncStatusValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProjectSyncStatusValidationError) ErrorName() string {
+	return "ProjectSyncStatusValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ProjectSyncStatusValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProjectSyncStatus.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProjectSyncStatusValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProjectSyncStatusValidationError{}
+
+// Validate checks the field values on ReportSyncStatusRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReportSyncStatusRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReportSyncStatusRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReportSyncStatusRequestMultiError, or nil if none found.
+func (m *ReportSyncStatusRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReportSyncStatusRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetProjects()) > 1000 {
+		err := ReportSyncStatusRequestValidationError{
+			field:  "Projects",
+			reason: "value must contain no more than 1000 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetProjects() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ReportSyncStatusRequestValidationError{
+						field:  fmt.Sprintf("Projects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ReportSyncStatusRequestValidationError{
+						field:  fmt.Sprintf("Projects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ReportSyncStatusRequestValidationError{
+					field:  fmt.Sprintf("Projects[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ReportSyncStatusRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReportSyncStatusRequestMultiError is an error wrapping multiple validation
+// errors returned by ReportSyncStatusRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ReportSyncStatusRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReportSyncStatusRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReportSyncStatusRequestMultiError) AllErrors() []error { return m }
+
+// ReportSyncStatusRequestValidationError is the validation error returned by
+// ReportSyncStatusRequest.Validate if the designated constraints aren't met.
+type ReportSyncStatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReportSyncStatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReportSyncStatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReportSyncStatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReportSyncStatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReportSyncStatusRequestValidationError) ErrorName() string {
+	return "ReportSyncStatusRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReportSyncStatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReportSyncStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReportSyncStatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReportSyncStatusRequestValidationError{}
+
+// Validate checks the field values on ReportSyncStatusResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReportSyncStatusResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReportSyncStatusResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReportSyncStatusResponseMultiError, or nil if none found.
+func (m *ReportSyncStatusResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReportSyncStatusResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ReportSyncStatusResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReportSyncStatusResponseMultiError is an error wrapping multiple validation
+// errors returned by ReportSyncStatusResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ReportSyncStatusResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReportSyncStatusResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReportSyncStatusResponseMultiError) AllErrors() []error { return m }
+
+// ReportSyncStatusResponseValidationError is the validation error returned by
+// ReportSyncStatusResponse.Validate if the designated constraints aren't met.
+type ReportSyncStatusResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReportSyncStatusResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReportSyncStatusResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReportSyncStatusResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReportSyncStatusResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReportSyncStatusResponseValidationError) ErrorName() string {
+	return "ReportSyncStatusResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReportSyncStatusResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReportSyncStatusResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReportSyncStatusResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReportSyncStatusResponseValidationError{}
+
+// Validate checks the field values on AgentSyncStatus with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AgentSyncStatus) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AgentSyncStatus with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AgentSyncStatusMultiError, or nil if none found.
+func (m *AgentSyncStatus) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AgentSyncStatus) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for AgentId
+
+	for idx, item := range m.GetProjects() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AgentSyncStatusValidationError{
+						field:  fmt.Sprintf("Projects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AgentSyncStatusValidationError{
+						field:  fmt.Sprintf("Projects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AgentSyncStatusValidationError{
+					field:  fmt.Sprintf("Projects[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetReportedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentSyncStatusValidationError{
+					field:  "ReportedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentSyncStatusValidationError{
+					field:  "ReportedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReportedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentSyncStatusValidationError{
+				field:  "ReportedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AgentSyncStatusMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentSyncStatusMultiError is an error wrapping multiple validation errors
+// returned by AgentSyncStatus.ValidateAll() if the designated constraints
+// aren't met.
+type AgentSyncStatusMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentSyncStatusMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentSyncStatusMultiError) AllErrors() []error { return m }
+
+// AgentSyncStatusValidationError is the validation error returned by
+// AgentSyncStatus.Validate if the designated constraints aren't met.
+type AgentSyncStatusValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentSyncStatusValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentSyncStatusValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentSyncStatusValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentSyncStatusValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentSyncStatusValidationError) ErrorName() string { return "AgentSyncStatusValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AgentSyncStatusValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAgentSyncStatus.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AgentSyncStatusValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AgentSyncStatusValidationError{}
+
+// Validate checks the field values on GetSyncStatusRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSyncStatusRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSyncStatusRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetSyncStatusRequestMultiError, or nil if none found.
+func (m *GetSyncStatusRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSyncStatusRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAgentId() <= 0 {
+		err := GetSyncStatusRequestValidationError{
+			field:  "AgentId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetSyncStatusRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSyncStatusRequestMultiError is an error wrapping multiple validation
+// errors returned by GetSyncStatusRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetSyncStatusRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSyncStatusRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSyncStatusRequestMultiError) AllErrors() []error { return m }
+
+// GetSyncStatusRequestValidationError is the validation error returned by
+// GetSyncStatusRequest.Validate if the designated constraints aren't met.
+type GetSyncStatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSyncStatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSyncStatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSyncStatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSyncStatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSyncStatusRequestValidationError) ErrorName() string {
+	return "GetSyncStatusRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSyncStatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSyncStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSyncStatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSyncStatusRequestValidationError{}
+
+// Validate checks the field values on GetSyncStatusResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSyncStatusResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSyncStatusResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetSyncStatusResponseMultiError, or nil if none found.
+func (m *GetSyncStatusResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSyncStatusResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetStatus()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetSyncStatusResponseValidationError{
+					field:  "Status",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetSyncStatusResponseValidationError{
+					field:  "Status",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStatus()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetSyncStatusResponseValidationError{
+				field:  "Status",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetSyncStatusResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSyncStatusResponseMultiError is an error wrapping multiple validation
+// errors returned by GetSyncStatusResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetSyncStatusResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSyncStatusResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSyncStatusResponseMultiError) AllErrors() []error { return m }
+
+// GetSyncStatusResponseValidationError is the validation error returned by
+// GetSyncStatusResponse.Validate if the designated constraints aren't met.
+type GetSyncStatusResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSyncStatusResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSyncStatusResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSyncStatusResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSyncStatusResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSyncStatusResponseValidationError) ErrorName() string {
+	return "GetSyncStatusResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSyncStatusResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSyncStatusResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSyncStatusResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSyncStatusResponseValidationError{}
+
+// Validate checks the field values on PreviewRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PreviewRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PreviewRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PreviewRequestMultiError,
+// or nil if none found.
+func (m *PreviewRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PreviewRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAgentId() <= 0 {
+		err := PreviewRequestValidationError{
+			field:  "AgentId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPreview() == nil {
+		err := PreviewRequestValidationError{
+			field:  "Preview",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetPreview()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PreviewRequestValidationError{
+					field:  "Preview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PreviewRequestValidationError{
+					field:  "Preview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPreview()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PreviewRequestValidationError{
+				field:  "Preview",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PreviewRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PreviewRequestMultiError is an error wrapping multiple validation errors
+// returned by PreviewRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PreviewRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PreviewRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PreviewRequestMultiError) AllErrors() []error { return m }
+
+// PreviewRequestValidationError is the validation error returned by
+// PreviewRequest.Validate if the designated constraints aren't met.
+type PreviewRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PreviewRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PreviewRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PreviewRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PreviewRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PreviewRequestValidationError) ErrorName() string { return "PreviewRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PreviewRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPreviewRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PreviewRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PreviewRequestValidationError{}
+
+// Validate checks the field values on PreviewManifestsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PreviewManifestsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PreviewManifestsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PreviewManifestsRequestMultiError, or nil if none found.
+func (m *PreviewManifestsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PreviewManifestsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetProjectId()) < 1 {
+		err := PreviewManifestsRequestValidationError{
+			field:  "ProjectId",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetRef()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PreviewManifestsRequestValidationError{
+					field:  "Ref",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PreviewManifestsRequestValidationError{
+					field:  "Ref",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRef()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PreviewManifestsRequestValidationError{
+				field:  "Ref",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PreviewManifestsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PreviewManifestsRequestMultiError is an error wrapping multiple validation
+// errors returned by PreviewManifestsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type PreviewManifestsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PreviewManifestsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PreviewManifestsRequestMultiError) AllErrors() []error { return m }
+
+// PreviewManifestsRequestValidationError is the validation error returned by
+// PreviewManifestsRequest.Validate if the designated constraints aren't met.
+type PreviewManifestsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PreviewManifestsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PreviewManifestsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PreviewManifestsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PreviewManifestsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PreviewManifestsRequestValidationError) ErrorName() string {
+	return "PreviewManifestsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PreviewManifestsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPreviewManifestsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PreviewManifestsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PreviewManifestsRequestValidationError{}
+
+// Validate checks the field values on FieldChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FieldChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FieldChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FieldChangeMultiError, or
+// nil if none found.
+func (m *FieldChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FieldChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Path
+
+	// no validation rules for OldValue
+
+	// no validation rules for NewValue
+
+	if len(errors) > 0 {
+		return FieldChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// FieldChangeMultiError is an error wrapping multiple validation errors
+// returned by FieldChange.ValidateAll() if the designated constraints aren't met.
+type FieldChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FieldChangeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FieldChangeMultiError) AllErrors() []error { return m }
+
+// FieldChangeValidationError is the validation error returned by
+// FieldChange.Validate if the designated constraints aren't met.
+type FieldChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FieldChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FieldChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FieldChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FieldChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FieldChangeValidationError) ErrorName() string { return "FieldChangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FieldChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFieldChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FieldChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FieldChangeValidationError{}
+
+// Validate checks the field values on ObjectPreview with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ObjectPreview) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ObjectPreview with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ObjectPreviewMultiError, or
+// nil if none found.
+func (m *ObjectPreview) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ObjectPreview) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Action
+
+	// no validation rules for Group
+
+	// no validation rules for Version
+
+	// no validation rules for Kind
+
+	// no validation rules for Namespace
+
+	// no validation rules for Name
+
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ObjectPreviewValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ObjectPreviewValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ObjectPreviewValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return ObjectPreviewMultiError(errors)
+	}
+
+	return nil
+}
+
+// ObjectPreviewMultiError is an error wrapping multiple validation errors
+// returned by ObjectPreview.ValidateAll() if the designated constraints
+// aren't met.
+type ObjectPreviewMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ObjectPreviewMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ObjectPreviewMultiError) AllErrors() []error { return m }
+
+// ObjectPreviewValidationError is the validation error returned by
+// ObjectPreview.Validate if the designated constraints aren't met.
+type ObjectPreviewValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ObjectPreviewValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ObjectPreviewValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ObjectPreviewValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ObjectPreviewValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ObjectPreviewValidationError) ErrorName() string { return "ObjectPreviewValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ObjectPreviewValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sObjectPreview.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ObjectPreviewValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ObjectPreviewValidationError{}
+
+// Validate checks the field values on PreviewResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PreviewResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PreviewResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PreviewResponseMultiError, or nil if none found.
+func (m *PreviewResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PreviewResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CommitId
+
+	// no validation rules for Strategy
+
+	for idx, item := range m.GetObjects() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PreviewResponseValidationError{
+						field:  fmt.Sprintf("Objects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PreviewResponseValidationError{
+						field:  fmt.Sprintf("Objects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PreviewResponseValidationError{
+					field:  fmt.Sprintf("Objects[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PreviewResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PreviewResponseMultiError is an error wrapping multiple validation errors
+// returned by PreviewResponse.ValidateAll() if the designated constraints
+// aren't met.
+type PreviewResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PreviewResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PreviewResponseMultiError) AllErrors() []error { return m }
+
+// PreviewResponseValidationError is the validation error returned by
+// PreviewResponse.Validate if the designated constraints aren't met.
+type PreviewResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PreviewResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PreviewResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PreviewResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PreviewResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PreviewResponseValidationError) ErrorName() string { return "PreviewResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PreviewResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPreviewResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PreviewResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PreviewResponseValidationError{}
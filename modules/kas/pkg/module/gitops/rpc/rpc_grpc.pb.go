@@ -0,0 +1,389 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.31.1
+// source: pkg/module/gitops/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Gitops_ReportSyncStatus_FullMethodName = "/plural.agent.gitops.rpc.Gitops/ReportSyncStatus"
+)
+
+// GitopsClient is the client API for Gitops service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Gitops is implemented by kas. agentk reports the sync status of its manifest projects to it.
+type GitopsClient interface {
+	// ReportSyncStatus replaces the sync status of the agent with the reported one.
+	ReportSyncStatus(ctx context.Context, in *ReportSyncStatusRequest, opts ...grpc.CallOption) (*ReportSyncStatusResponse, error)
+}
+
+type gitopsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGitopsClient(cc grpc.ClientConnInterface) GitopsClient {
+	return &gitopsClient{cc}
+}
+
+func (c *gitopsClient) ReportSyncStatus(ctx context.Context, in *ReportSyncStatusRequest, opts ...grpc.CallOption) (*ReportSyncStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportSyncStatusResponse)
+	err := c.cc.Invoke(ctx, Gitops_ReportSyncStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GitopsServer is the server API for Gitops service.
+// All implementations must embed UnimplementedGitopsServer
+// for forward compatibility.
+//
+// Gitops is implemented by kas. agentk reports the sync status of its manifest projects to it.
+type GitopsServer interface {
+	// ReportSyncStatus replaces the sync status of the agent with the reported one.
+	ReportSyncStatus(context.Context, *ReportSyncStatusRequest) (*ReportSyncStatusResponse, error)
+	mustEmbedUnimplementedGitopsServer()
+}
+
+// UnimplementedGitopsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGitopsServer struct{}
+
+func (UnimplementedGitopsServer) ReportSyncStatus(context.Context, *ReportSyncStatusRequest) (*ReportSyncStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportSyncStatus not implemented")
+}
+func (UnimplementedGitopsServer) mustEmbedUnimplementedGitopsServer() {}
+func (UnimplementedGitopsServer) testEmbeddedByValue()                {}
+
+// UnsafeGitopsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GitopsServer will
+// result in compilation errors.
+type UnsafeGitopsServer interface {
+	mustEmbedUnimplementedGitopsServer()
+}
+
+func RegisterGitopsServer(s grpc.ServiceRegistrar, srv GitopsServer) {
+	// If the following call panics, it indicates UnimplementedGitopsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Gitops_ServiceDesc, srv)
+}
+
+func _Gitops_ReportSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSyncStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitopsServer).ReportSyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gitops_ReportSyncStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitopsServer).ReportSyncStatus(ctx, req.(*ReportSyncStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Gitops_ServiceDesc is the grpc.ServiceDesc for Gitops service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Gitops_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.gitops.rpc.Gitops",
+	HandlerType: (*GitopsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportSyncStatus",
+			Handler:    _Gitops_ReportSyncStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/module/gitops/rpc/rpc.proto",
+}
+
+const (
+	GitopsApi_GetSyncStatus_FullMethodName = "/plural.agent.gitops.rpc.GitopsApi/GetSyncStatus"
+	GitopsApi_Preview_FullMethodName       = "/plural.agent.gitops.rpc.GitopsApi/Preview"
+)
+
+// GitopsApiClient is the client API for GitopsApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GitopsApi is implemented by kas. It returns the sync status agents have reported.
+type GitopsApiClient interface {
+	// GetSyncStatus returns the last sync status the agent has reported.
+	GetSyncStatus(ctx context.Context, in *GetSyncStatusRequest, opts ...grpc.CallOption) (*GetSyncStatusResponse, error)
+	// Preview returns what syncing a manifest project of the agent at a ref would change in the cluster.
+	// Nothing is changed in the cluster.
+	Preview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error)
+}
+
+type gitopsApiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGitopsApiClient(cc grpc.ClientConnInterface) GitopsApiClient {
+	return &gitopsApiClient{cc}
+}
+
+func (c *gitopsApiClient) GetSyncStatus(ctx context.Context, in *GetSyncStatusRequest, opts ...grpc.CallOption) (*GetSyncStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSyncStatusResponse)
+	err := c.cc.Invoke(ctx, GitopsApi_GetSyncStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitopsApiClient) Preview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewResponse)
+	err := c.cc.Invoke(ctx, GitopsApi_Preview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GitopsApiServer is the server API for GitopsApi service.
+// All implementations must embed UnimplementedGitopsApiServer
+// for forward compatibility.
+//
+// GitopsApi is implemented by kas. It returns the sync status agents have reported.
+type GitopsApiServer interface {
+	// GetSyncStatus returns the last sync status the agent has reported.
+	GetSyncStatus(context.Context, *GetSyncStatusRequest) (*GetSyncStatusResponse, error)
+	// Preview returns what syncing a manifest project of the agent at a ref would change in the cluster.
+	// Nothing is changed in the cluster.
+	Preview(context.Context, *PreviewRequest) (*PreviewResponse, error)
+	mustEmbedUnimplementedGitopsApiServer()
+}
+
+// UnimplementedGitopsApiServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGitopsApiServer struct{}
+
+func (UnimplementedGitopsApiServer) GetSyncStatus(context.Context, *GetSyncStatusRequest) (*GetSyncStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSyncStatus not implemented")
+}
+func (UnimplementedGitopsApiServer) Preview(context.Context, *PreviewRequest) (*PreviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Preview not implemented")
+}
+func (UnimplementedGitopsApiServer) mustEmbedUnimplementedGitopsApiServer() {}
+func (UnimplementedGitopsApiServer) testEmbeddedByValue()                   {}
+
+// UnsafeGitopsApiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GitopsApiServer will
+// result in compilation errors.
+type UnsafeGitopsApiServer interface {
+	mustEmbedUnimplementedGitopsApiServer()
+}
+
+func RegisterGitopsApiServer(s grpc.ServiceRegistrar, srv GitopsApiServer) {
+	// If the following call panics, it indicates UnimplementedGitopsApiServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GitopsApi_ServiceDesc, srv)
+}
+
+func _GitopsApi_GetSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitopsApiServer).GetSyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GitopsApi_GetSyncStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitopsApiServer).GetSyncStatus(ctx, req.(*GetSyncStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitopsApi_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitopsApiServer).Preview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GitopsApi_Preview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitopsApiServer).Preview(ctx, req.(*PreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GitopsApi_ServiceDesc is the grpc.ServiceDesc for GitopsApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GitopsApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.gitops.rpc.GitopsApi",
+	HandlerType: (*GitopsApiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSyncStatus",
+			Handler:    _GitopsApi_GetSyncStatus_Handler,
+		},
+		{
+			MethodName: "Preview",
+			Handler:    _GitopsApi_Preview_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/module/gitops/rpc/rpc.proto",
+}
+
+const (
+	GitopsPreview_Preview_FullMethodName = "/plural.agent.gitops.rpc.GitopsPreview/Preview"
+)
+
+// GitopsPreviewClient is the client API for GitopsPreview service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GitopsPreview is implemented by agentk. kas sends preview requests to it over the reverse tunnel.
+type GitopsPreviewClient interface {
+	// Preview returns what syncing a manifest project at a ref would change in the cluster.
+	// Nothing is changed in the cluster.
+	Preview(ctx context.Context, in *PreviewManifestsRequest, opts ...grpc.CallOption) (*PreviewResponse, error)
+}
+
+type gitopsPreviewClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGitopsPreviewClient(cc grpc.ClientConnInterface) GitopsPreviewClient {
+	return &gitopsPreviewClient{cc}
+}
+
+func (c *gitopsPreviewClient) Preview(ctx context.Context, in *PreviewManifestsRequest, opts ...grpc.CallOption) (*PreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewResponse)
+	err := c.cc.Invoke(ctx, GitopsPreview_Preview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GitopsPreviewServer is the server API for GitopsPreview service.
+// All implementations must embed UnimplementedGitopsPreviewServer
+// for forward compatibility.
+//
+// GitopsPreview is implemented by agentk. kas sends preview requests to it over the reverse tunnel.
+type GitopsPreviewServer interface {
+	// Preview returns what syncing a manifest project at a ref would change in the cluster.
+	// Nothing is changed in the cluster.
+	Preview(context.Context, *PreviewManifestsRequest) (*PreviewResponse, error)
+	mustEmbedUnimplementedGitopsPreviewServer()
+}
+
+// UnimplementedGitopsPreviewServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGitopsPreviewServer struct{}
+
+func (UnimplementedGitopsPreviewServer) Preview(context.Context, *PreviewManifestsRequest) (*PreviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Preview not implemented")
+}
+func (UnimplementedGitopsPreviewServer) mustEmbedUnimplementedGitopsPreviewServer() {}
+func (UnimplementedGitopsPreviewServer) testEmbeddedByValue()                       {}
+
+// UnsafeGitopsPreviewServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GitopsPreviewServer will
+// result in compilation errors.
+type UnsafeGitopsPreviewServer interface {
+	mustEmbedUnimplementedGitopsPreviewServer()
+}
+
+func RegisterGitopsPreviewServer(s grpc.ServiceRegistrar, srv GitopsPreviewServer) {
+	// If the following call panics, it indicates UnimplementedGitopsPreviewServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GitopsPreview_ServiceDesc, srv)
+}
+
+func _GitopsPreview_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewManifestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitopsPreviewServer).Preview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GitopsPreview_Preview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitopsPreviewServer).Preview(ctx, req.(*PreviewManifestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GitopsPreview_ServiceDesc is the grpc.ServiceDesc for GitopsPreview service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GitopsPreview_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.gitops.rpc.GitopsPreview",
+	HandlerType: (*GitopsPreviewServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Preview",
+			Handler:    _GitopsPreview_Preview_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/module/gitops/rpc/rpc.proto",
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
+)
+
+const (
+	// syncStatusTTL is how long a reported sync status is kept. agentk reports the status more often than that.
+	syncStatusTTL = time.Hour
+)
+
+type Factory struct {
+}
+
+func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
+	s := &server{
+		syncStatus: redistool.NewValueCacher(
+			config.Storage,
+			config.Log,
+			modshared.ApiToErrReporter(config.Api),
+			func(agentId int64) string {
+				return config.Config.Redis.KeyPrefix + ":gitops_sync_status:" + strconv.FormatInt(agentId, 10)
+			},
+		),
+		syncStatusTTL: syncStatusTTL,
+		previewClient: rpc.NewGitopsPreviewClient(config.AgentConn),
+	}
+	rpc.RegisterGitopsServer(config.AgentServer, s)
+	rpc.RegisterGitopsApiServer(config.ApiServer, s)
+	config.RegisterAgentApi(&rpc.GitopsPreview_ServiceDesc)
+	return &module{}, nil
+}
+
+func (f *Factory) Name() string {
+	return gitops.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	// Start after servers because the module uses agent connection (config.AgentConn), which works by accessing
+	// in-memory private API server.
+	return modshared.ModuleStartAfterServers
+}
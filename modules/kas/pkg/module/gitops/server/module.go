@@ -0,0 +1,17 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops"
+)
+
+type module struct{}
+
+func (m *module) Run(ctx context.Context) error {
+	return nil
+}
+
+func (m *module) Name() string {
+	return gitops.ModuleName
+}
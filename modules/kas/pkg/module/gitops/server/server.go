@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
+)
+
+type server struct {
+	rpc.UnimplementedGitopsServer
+	rpc.UnimplementedGitopsApiServer
+	// syncStatus keeps the last reported sync status by agent id.
+	syncStatus    redistool.ValueCacher[int64]
+	syncStatusTTL time.Duration
+	previewClient rpc.GitopsPreviewClient
+}
+
+func (s *server) ReportSyncStatus(ctx context.Context, req *rpc.ReportSyncStatusRequest) (*rpc.ReportSyncStatusResponse, error) {
+	rpcApi := modserver.AgentRpcApiFromContext(ctx)
+	log := rpcApi.Log()
+	agentInfo, err := rpcApi.AgentInfo(ctx, log)
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(&rpc.AgentSyncStatus{
+		AgentId:    agentInfo.Id,
+		Projects:   req.Projects,
+		ReportedAt: timestamppb.Now(),
+	})
+	if err != nil {
+		rpcApi.HandleProcessingError(log, agentInfo.Id, "Failed to marshal sync status", err)
+		return nil, status.Error(codes.Internal, "failed to marshal sync status")
+	}
+	s.syncStatus.CacheValue(ctx, agentInfo.Id, data, s.syncStatusTTL)
+	for _, p := range req.Projects {
+		if p.State == rpc.SyncState_failed {
+			log.Debug("Manifest project sync has failed", logz.AgentId(agentInfo.Id), logz.WorkerId(p.ProjectId),
+				logz.CommitId(p.CommitId), zap.String("message", p.Message))
+		}
+	}
+	return &rpc.ReportSyncStatusResponse{}, nil
+}
+
+func (s *server) GetSyncStatus(ctx context.Context, req *rpc.GetSyncStatusRequest) (*rpc.GetSyncStatusResponse, error) {
+	data := s.syncStatus.GetValue(ctx, req.AgentId)
+	if data == nil {
+		return &rpc.GetSyncStatusResponse{}, nil
+	}
+	syncStatus := &rpc.AgentSyncStatus{}
+	err := proto.Unmarshal(data, syncStatus)
+	if err != nil {
+		rpcApi := modserver.RpcApiFromContext(ctx)
+		rpcApi.HandleProcessingError(rpcApi.Log(), req.AgentId, "Failed to unmarshal sync status", err)
+		return nil, status.Error(codes.Internal, "failed to unmarshal sync status")
+	}
+	return &rpc.GetSyncStatusResponse{
+		Status: syncStatus,
+	}, nil
+}
+
+func (s *server) Preview(ctx context.Context, req *rpc.PreviewRequest) (*rpc.PreviewResponse, error) {
+	log := modserver.RpcApiFromContext(ctx).Log().With(logz.AgentId(req.AgentId), logz.WorkerId(req.Preview.ProjectId))
+	md := metadata.Pairs(modserver.RoutingAgentIdMetadataKey, strconv.FormatInt(req.AgentId, 10))
+	resp, err := s.previewClient.Preview(metadata.NewOutgoingContext(ctx, md), req.Preview)
+	if err != nil {
+		// Errors from agentk and from routing are gRPC status errors, pass them through.
+		log.Debug("Preview failed", logz.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/matcher"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_gitops"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/testhelpers"
+)
+
+var (
+	_ modserver.Module    = (*module)(nil)
+	_ modserver.Factory   = (*Factory)(nil)
+	_ rpc.GitopsServer    = (*server)(nil)
+	_ rpc.GitopsApiServer = (*server)(nil)
+)
+
+func TestReportSyncStatus_ThenGet(t *testing.T) {
+	s, ctrl := setupServer(t)
+	rpcApi := mock_modserver.NewMockAgentRpcApi(ctrl)
+	rpcApi.EXPECT().
+		AgentInfo(gomock.Any(), gomock.Any()).
+		Return(testhelpers.AgentInfoObj(), nil)
+	ctx := mock_modserver.IncomingAgentCtx(t, rpcApi)
+	project := &rpc.ProjectSyncStatus{
+		ProjectId:      "https://example.com/manifests.git",
+		CommitId:       "0123456789abcdef0123456789abcdef01234567",
+		State:          rpc.SyncState_failed,
+		Message:        "boom",
+		AppliedObjects: 3,
+	}
+	_, err := s.ReportSyncStatus(ctx, &rpc.ReportSyncStatusRequest{
+		Projects: []*rpc.ProjectSyncStatus{project},
+	})
+	require.NoError(t, err)
+
+	resp, err := s.GetSyncStatus(ctx, &rpc.GetSyncStatusRequest{AgentId: testhelpers.AgentId})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Status)
+	assert.EqualValues(t, testhelpers.AgentId, resp.Status.AgentId)
+	assert.Len(t, resp.Status.Projects, 1)
+	assert.Empty(t, cmp.Diff(project, resp.Status.Projects[0], protocmp.Transform()))
+	assert.NotNil(t, resp.Status.ReportedAt)
+}
+
+func TestGetSyncStatus_NotReported(t *testing.T) {
+	s, _ := setupServer(t)
+	resp, err := s.GetSyncStatus(context.Background(), &rpc.GetSyncStatusRequest{AgentId: testhelpers.AgentId})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Status)
+}
+
+func TestPreview_RoutesToAgent(t *testing.T) {
+	s, ctrl := setupServer(t)
+	client := mock_gitops.NewMockGitopsPreviewClient(ctrl)
+	s.previewClient = client
+	req := &rpc.PreviewRequest{
+		AgentId: testhelpers.AgentId,
+		Preview: &rpc.PreviewManifestsRequest{ProjectId: "https://example.com/manifests.git"},
+	}
+	resp := &rpc.PreviewResponse{CommitId: "0123456789abcdef0123456789abcdef01234567"}
+	client.EXPECT().
+		Preview(gomock.Any(), matcher.ProtoEq(t, req.Preview)).
+		DoAndReturn(func(ctx context.Context, in *rpc.PreviewManifestsRequest, opts ...grpc.CallOption) (*rpc.PreviewResponse, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Equal(t, []string{strconv.FormatInt(testhelpers.AgentId, 10)}, md.Get(modserver.RoutingAgentIdMetadataKey))
+			return resp, nil
+		})
+	actual, err := s.Preview(incomingCtx(t, ctrl), req)
+	require.NoError(t, err)
+	assert.Same(t, resp, actual)
+}
+
+func TestPreview_PassesThroughAgentError(t *testing.T) {
+	s, ctrl := setupServer(t)
+	client := mock_gitops.NewMockGitopsPreviewClient(ctrl)
+	s.previewClient = client
+	client.EXPECT().
+		Preview(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.NotFound, "manifest project is not in the agent configuration"))
+	_, err := s.Preview(incomingCtx(t, ctrl), &rpc.PreviewRequest{
+		AgentId: testhelpers.AgentId,
+		Preview: &rpc.PreviewManifestsRequest{ProjectId: "https://example.com/manifests.git"},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func incomingCtx(t *testing.T, ctrl *gomock.Controller) context.Context {
+	rpcApi := mock_modserver.NewMockRpcApi(ctrl)
+	rpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t)).
+		AnyTimes()
+	return modserver.InjectRpcApi(context.Background(), rpcApi)
+}
+
+func setupServer(t *testing.T) (*server, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	return &server{
+		syncStatus: redistool.NewValueCacher(
+			redistool.Backend{Store: redistool.NewMemoryStore()},
+			zaptest.NewLogger(t),
+			nil,
+			func(agentId int64) string {
+				return "gitops_sync_status"
+			},
+		),
+		syncStatusTTL: time.Minute,
+	}, ctrl
+}
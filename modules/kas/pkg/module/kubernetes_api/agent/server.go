@@ -160,10 +160,14 @@ func restImpersonationConfig(impConfig *rpc2.ImpersonationConfig, restConfig *re
 		// Impersonation is configured in the rest config
 	case !restImp && cfgImp && !reqImp:
 		// Impersonation is configured in the agent config
+		if err := impConfig.CheckUsername(); err != nil {
+			return nil, err
+		}
 		restConfig = rest.CopyConfig(restConfig) // copy to avoid mutating a potentially shared config object
 		restConfig.Impersonate.UserName = impConfig.Username
 		restConfig.Impersonate.UID = impConfig.Uid
-		restConfig.Impersonate.Groups = impConfig.Groups
+		restConfig.Impersonate.Groups = impConfig.ImpersonatedGroups()
+		restConfig.Impersonate.Extra = impConfig.ExtraMap()
 	case !restImp && !cfgImp && reqImp:
 		// Impersonation is configured in the HTTP request
 	default:
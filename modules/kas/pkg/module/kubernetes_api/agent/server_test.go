@@ -92,6 +92,58 @@ func TestClientImpersonation(t *testing.T) {
 			requestHeader: requestHeader,
 			expectedErr:   "nested impersonation is not supported - agent is already configured to impersonate an identity",
 		},
+		{
+			name: "impConfig",
+			impConfig: &rpc.ImpersonationConfig{
+				Username: "iuser1",
+				Groups:   []string{"ig1", "ig2"},
+				Roles:    []string{"admin", "viewer"},
+				Uid:      "iuid",
+			},
+			expectedRequestHeader: http.Header{
+				transport.ImpersonateUserHeader:  {"iuser1"},
+				transport.ImpersonateUIDHeader:   {"iuid"},
+				transport.ImpersonateGroupHeader: {"ig1", "ig2", "plural:role:admin", "plural:role:viewer"},
+			},
+		},
+		{
+			name: "impConfig with extra",
+			impConfig: &rpc.ImpersonationConfig{
+				Username: "iuser1",
+				Extra: []*rpc.ExtraKeyVal{
+					{
+						Key: "ix",
+						Val: []string{"ix1", "ix2"},
+					},
+				},
+			},
+			expectedRequestHeader: http.Header{
+				transport.ImpersonateUserHeader:                   {"iuser1"},
+				transport.ImpersonateUserExtraHeaderPrefix + "Ix": {"ix1", "ix2"},
+			},
+		},
+		{
+			name: "impConfig with roles only",
+			impConfig: &rpc.ImpersonationConfig{
+				Roles: []string{"admin"},
+			},
+			expectedErr: "impersonation config has no username",
+		},
+		{
+			name: "impConfig with groups only",
+			impConfig: &rpc.ImpersonationConfig{
+				Groups: []string{"ig1"},
+			},
+			expectedErr: "impersonation config has no username",
+		},
+		{
+			name: "impConfig with roles only and requestHeader",
+			impConfig: &rpc.ImpersonationConfig{
+				Roles: []string{"admin"},
+			},
+			requestHeader: requestHeader,
+			expectedErr:   "nested impersonation is not supported - agent is already configured to impersonate an identity",
+		},
 		{
 			name:          "requestHeader",
 			requestHeader: requestHeader,
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// ReviewAccess checks if the identity is allowed to perform the action described by attrs.
+// impConfig is the identity agentk impersonates for the request. If it's empty, the request is made as agentk itself
+// and the access is checked with a SelfSubjectAccessReview.
+// It returns the reason given by the authorizer if the access is not allowed.
+func ReviewAccess(ctx context.Context, client authorizationv1client.AuthorizationV1Interface, impConfig *ImpersonationConfig,
+	attrs *authorizationv1.ResourceAttributes) (bool, string, error) {
+	if impConfig.IsEmpty() {
+		review, err := client.SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: attrs,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		return review.Status.Allowed, review.Status.Reason, nil
+	}
+	var extra map[string]authorizationv1.ExtraValue
+	if len(impConfig.Extra) > 0 {
+		extra = make(map[string]authorizationv1.ExtraValue, len(impConfig.Extra))
+		for _, kv := range impConfig.Extra {
+			extra[kv.Key] = kv.Val
+		}
+	}
+	review, err := client.SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attrs,
+			User:               impConfig.Username,
+			Groups:             reviewedGroups(impConfig),
+			Extra:              extra,
+			UID:                impConfig.Uid,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	return review.Status.Allowed, review.Status.Reason, nil
+}
+
+// reviewedGroups returns the groups the API server would see for an impersonated request.
+// The API server adds system:authenticated to impersonated users, so it's added here too.
+func reviewedGroups(impConfig *ImpersonationConfig) []string {
+	groups := impConfig.ImpersonatedGroups()
+	if impConfig.Username == user.Anonymous {
+		return groups
+	}
+	for _, g := range groups {
+		if g == user.AllAuthenticated {
+			return groups
+		}
+	}
+	res := make([]string, 0, len(groups)+1)
+	res = append(res, groups...)
+	return append(res, user.AllAuthenticated)
+}
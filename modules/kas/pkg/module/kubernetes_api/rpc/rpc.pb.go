@@ -72,11 +72,13 @@ func (x *HeaderExtra) GetImpConfig() *ImpersonationConfig {
 // ImpersonationConfig is a representation of client-go rest.ImpersonationConfig.
 // See https://github.com/kubernetes/client-go/blob/release-1.22/rest/config.go#L201-L210
 type ImpersonationConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Groups        []string               `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
-	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
-	Uid           string                 `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Groups   []string               `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Plural bound roles of the user. agentk impersonates each of them as a "plural:role:<role name>" group.
+	Roles         []string       `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	Uid           string         `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	Extra         []*ExtraKeyVal `protobuf:"bytes,5,rep,name=extra,proto3" json:"extra,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -139,6 +141,13 @@ func (x *ImpersonationConfig) GetUid() string {
 	return ""
 }
 
+func (x *ImpersonationConfig) GetExtra() []*ExtraKeyVal {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
 type ExtraKeyVal struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -198,12 +207,13 @@ const file_pkg_module_kubernetes_api_rpc_rpc_proto_rawDesc = "" +
 	"'pkg/module/kubernetes_api/rpc/rpc.proto\x12\x1fplural.agent.kubernetes_api.rpc\x1a pkg/tool/grpctool/grpctool.proto\"b\n" +
 	"\vHeaderExtra\x12S\n" +
 	"\n" +
-	"imp_config\x18\x01 \x01(\v24.plural.agent.kubernetes_api.rpc.ImpersonationConfigR\timpConfig\"q\n" +
+	"imp_config\x18\x01 \x01(\v24.plural.agent.kubernetes_api.rpc.ImpersonationConfigR\timpConfig\"\xb5\x01\n" +
 	"\x13ImpersonationConfig\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x16\n" +
 	"\x06groups\x18\x02 \x03(\tR\x06groups\x12\x14\n" +
 	"\x05roles\x18\x03 \x03(\tR\x05roles\x12\x10\n" +
-	"\x03uid\x18\x04 \x01(\tR\x03uid\"1\n" +
+	"\x03uid\x18\x04 \x01(\tR\x03uid\x12B\n" +
+	"\x05extra\x18\x05 \x03(\v2,.plural.agent.kubernetes_api.rpc.ExtraKeyValR\x05extra\"1\n" +
 	"\vExtraKeyVal\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x10\n" +
 	"\x03val\x18\x02 \x03(\tR\x03val2m\n" +
@@ -232,13 +242,14 @@ var file_pkg_module_kubernetes_api_rpc_rpc_proto_goTypes = []any{
 }
 var file_pkg_module_kubernetes_api_rpc_rpc_proto_depIdxs = []int32{
 	1, // 0: plural.agent.kubernetes_api.rpc.HeaderExtra.imp_config:type_name -> plural.agent.kubernetes_api.rpc.ImpersonationConfig
-	3, // 1: plural.agent.kubernetes_api.rpc.KubernetesApi.MakeRequest:input_type -> plural.agent.grpctool.HttpRequest
-	4, // 2: plural.agent.kubernetes_api.rpc.KubernetesApi.MakeRequest:output_type -> plural.agent.grpctool.HttpResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 1: plural.agent.kubernetes_api.rpc.ImpersonationConfig.extra:type_name -> plural.agent.kubernetes_api.rpc.ExtraKeyVal
+	3, // 2: plural.agent.kubernetes_api.rpc.KubernetesApi.MakeRequest:input_type -> plural.agent.grpctool.HttpRequest
+	4, // 3: plural.agent.kubernetes_api.rpc.KubernetesApi.MakeRequest:output_type -> plural.agent.grpctool.HttpResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_pkg_module_kubernetes_api_rpc_rpc_proto_init() }
@@ -189,6 +189,40 @@ func (m *ImpersonationConfig) validate(all bool) error {
 
 	// no validation rules for Uid
 
+	for idx, item := range m.GetExtra() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ImpersonationConfigValidationError{
+						field:  fmt.Sprintf("Extra[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ImpersonationConfigValidationError{
+						field:  fmt.Sprintf("Extra[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ImpersonationConfigValidationError{
+					field:  fmt.Sprintf("Extra[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return ImpersonationConfigMultiError(errors)
 	}
@@ -1,8 +1,58 @@
 package rpc
 
+import (
+	"errors"
+)
+
+const (
+	// BoundRoleGroupPrefix is prepended to a Plural bound role name to get the name of the group agentk
+	// impersonates for that role. Bind "plural:role:<role name>" groups to grant permissions to a role.
+	BoundRoleGroupPrefix = "plural:role:"
+)
+
+// ErrNoUsername is returned for an identity to impersonate that has groups, roles, a UID or extra fields, but no username.
+var ErrNoUsername = errors.New("impersonation config has no username")
+
 func (x *ImpersonationConfig) IsEmpty() bool {
 	if x == nil {
 		return true
 	}
-	return x.Username == "" && len(x.Groups) == 0 && x.Uid == ""
+	return x.Username == "" && len(x.Groups) == 0 && len(x.Roles) == 0 && x.Uid == "" && len(x.Extra) == 0
+}
+
+// CheckUsername returns ErrNoUsername if the config is not empty but has no username.
+// The API server rejects requests that impersonate groups, a UID or extra fields without impersonating a user.
+func (x *ImpersonationConfig) CheckUsername() error {
+	if x.IsEmpty() || x.Username != "" {
+		return nil
+	}
+	return ErrNoUsername
+}
+
+// ExtraMap returns extra fields in the format client-go rest.ImpersonationConfig uses.
+// Returns nil if there are no extra fields.
+func (x *ImpersonationConfig) ExtraMap() map[string][]string {
+	if len(x.Extra) == 0 {
+		return nil
+	}
+	extra := make(map[string][]string, len(x.Extra))
+	for _, kv := range x.Extra {
+		extra[kv.Key] = kv.Val
+	}
+	return extra
+}
+
+// ImpersonatedGroups returns the groups to impersonate.
+// Plural bound roles are impersonated as groups prefixed with BoundRoleGroupPrefix
+// so that RoleBindings and ClusterRoleBindings in the cluster can grant permissions to them.
+func (x *ImpersonationConfig) ImpersonatedGroups() []string {
+	if len(x.Roles) == 0 {
+		return x.Groups
+	}
+	groups := make([]string, 0, len(x.Groups)+len(x.Roles))
+	groups = append(groups, x.Groups...)
+	for _, role := range x.Roles {
+		groups = append(groups, BoundRoleGroupPrefix+role)
+	}
+	return groups
 }
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+const (
+	tokenTypePlural = "plrl"
+	tokenTypeCi     = "ci"
+	tokenTypeOidc   = "oidc"
+	tokenTypeStatic = "static"
+	// authnTypeClientCertificate is not a token type. Client certificates are taken from the TLS connection.
+	authnTypeClientCertificate = "x509"
+
+	// anyCluster in a cluster allow list allows access to all clusters.
+	anyCluster = "*"
+)
+
+// credentials are the credentials extracted from a proxied request.
+type credentials struct {
+	// authnType is one of the tokenType* or authnType* constants.
+	authnType string
+	clusterId string
+	// token is set for bearer token credentials.
+	token string
+	// cert is set for client certificate credentials.
+	cert *x509.Certificate
+}
+
+// authenticator verifies credentials of a particular type.
+type authenticator interface {
+	// authenticate verifies the credentials and returns the user they belong to.
+	// It doesn't check if the user can access the cluster. creds.clusterId is empty and agentId is modshared.NoAgentId
+	// if the credentials are not for a particular cluster, i.e. for the kubeconfig endpoint.
+	authenticate(ctx context.Context, log *zap.Logger, agentId int64, r *http.Request, creds credentials) (authenticatedUser, *grpctool.ErrResp)
+}
+
+// authenticatedUser is the user of verified credentials.
+type authenticatedUser interface {
+	// name identifies the user among the users of the same authentication method.
+	name() string
+	// authorize checks that the user can access the cluster and returns what the user is allowed to do in it.
+	// A user that cannot access the cluster gets 401.
+	authorize(ctx context.Context, log *zap.Logger, agentId int64, clusterId string) (*authorization, *grpctool.ErrResp)
+}
+
+// authorization is what an authenticated user is allowed to do in a cluster.
+type authorization struct {
+	// impConfig is the identity to impersonate. nil means the request is made using agent's own identity.
+	impConfig *rpc.ImpersonationConfig
+	// defaultNamespace is the namespace of the user's kubeconfig context. Empty if there is none.
+	defaultNamespace string
+	// trackUsage updates usage metrics for a proxied request. nil if the authentication method has no metrics of its own.
+	trackUsage func(p *kubernetesApiProxy, agentId int64)
+}
+
+// identityUser is a user with the same identity in all clusters it can access.
+type identityUser struct {
+	identity *rpc.ImpersonationConfig
+	clusters clusterAllowList
+}
+
+func (u *identityUser) name() string {
+	return u.identity.Username
+}
+
+func (u *identityUser) authorize(ctx context.Context, log *zap.Logger, agentId int64, clusterId string) (*authorization, *grpctool.ErrResp) {
+	if eResp := u.clusters.check(log, clusterId); eResp != nil {
+		return nil, eResp
+	}
+	return &authorization{
+		impConfig: u.identity,
+	}, nil
+}
+
+// clusterAllowList is the set of clusters that users of an authentication method can access.
+type clusterAllowList struct {
+	any        bool
+	clusterIds map[string]struct{}
+}
+
+func newClusterAllowList(clusterIds []string) clusterAllowList {
+	l := clusterAllowList{
+		clusterIds: make(map[string]struct{}, len(clusterIds)),
+	}
+	for _, clusterId := range clusterIds {
+		if clusterId == anyCluster {
+			l.any = true
+		}
+		l.clusterIds[clusterId] = struct{}{}
+	}
+	return l
+}
+
+// check returns an error if the cluster is not in the allow list.
+// 401 is used rather than 403 so that the kubeconfig endpoint skips the cluster.
+func (l clusterAllowList) check(log *zap.Logger, clusterId string) *grpctool.ErrResp {
+	if l.any {
+		return nil
+	}
+	if _, ok := l.clusterIds[clusterId]; ok {
+		return nil
+	}
+	return unauthorizedErrResp(log, fmt.Errorf("not allowed to access cluster %s", clusterId))
+}
+
+// prefixedIdentity returns the identity to impersonate with the username and groups prefixed with the authentication
+// method. The prefix stops identities from an external source from clashing with Kubernetes users and groups,
+// e.g. an OIDC groups claim with system:masters.
+func prefixedIdentity(prefix, username, uid string, groups []string) *rpc.ImpersonationConfig {
+	var prefixedGroups []string
+	if len(groups) > 0 {
+		prefixedGroups = make([]string, 0, len(groups))
+		for _, group := range groups {
+			prefixedGroups = append(prefixedGroups, prefix+group)
+		}
+	}
+	return &rpc.ImpersonationConfig{
+		Username: prefix + username,
+		Uid:      uid,
+		Groups:   prefixedGroups,
+	}
+}
+
+// isCacheableConsoleError returns true for errors that Plural Console would return again for the same token.
+func isCacheableConsoleError(err error) bool {
+	return gitlab2.IsUnauthorized(err) || gitlab2.IsForbidden(err) || gitlab2.IsNotFound(err)
+}
+
+func unauthorizedErrResp(log *zap.Logger, err error) *grpctool.ErrResp {
+	msg := "Unauthorized"
+	log.Debug(msg, logz.Error(err))
+	return &grpctool.ErrResp{
+		StatusCode: http.StatusUnauthorized,
+		Msg:        msg,
+		Err:        err,
+	}
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+)
+
+// clientCertificateAuthenticator authenticates X.509 client certificates.
+// Certificates have already been verified during the TLS handshake, so only the identity needs to be extracted.
+// Like in Kubernetes, common name is the username and organizations are groups.
+type clientCertificateAuthenticator struct {
+	clusters clusterAllowList
+}
+
+func (a *clientCertificateAuthenticator) authenticate(ctx context.Context, log *zap.Logger, agentId int64, r *http.Request, creds credentials) (authenticatedUser, *grpctool.ErrResp) {
+	subject := creds.cert.Subject
+	if subject.CommonName == "" {
+		return nil, unauthorizedErrResp(log, errors.New("client certificate: empty common name"))
+	}
+	// The cluster id comes from a request header, so the allow list is what limits the clusters a certificate can access.
+	return &identityUser{
+		identity: prefixedIdentity(authnTypeClientCertificate+":", subject.CommonName, "", subject.Organization),
+		clusters: a.clusters,
+	}, nil
+}
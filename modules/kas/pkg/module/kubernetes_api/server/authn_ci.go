@@ -0,0 +1,265 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	pluralapi "github.com/pluralsh/kubernetes-agent/pkg/plural/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+const (
+	ciJobUsernamePrefix = "plural:ci_job:"
+	ciJobGroup          = "plural:ci_job"
+	ciJobExtraPrefix    = "agent.plural.sh/"
+)
+
+// ciJobAuthenticator authenticates CI job tokens. Plural Console validates the token and returns the agents
+// the job can access, along with the matching ci_access configuration of each agent.
+type ciJobAuthenticator struct {
+	api                modserver.Api
+	pluralUrl          string
+	allowedAgentsCache *cache.CacheWithErr[string, *pluralapi.AllowedAgentsForJob]
+}
+
+// ciJobUser is a CI job along with the agents it can access.
+type ciJobUser struct {
+	a             *ciJobAuthenticator
+	allowedForJob *pluralapi.AllowedAgentsForJob
+}
+
+func (a *ciJobAuthenticator) authenticate(ctx context.Context, log *zap.Logger, agentId int64, r *http.Request, creds credentials) (authenticatedUser, *grpctool.ErrResp) {
+	allowedForJob, eResp := a.getAllowedAgentsForJob(ctx, log, agentId, creds.token)
+	if eResp != nil {
+		return nil, eResp
+	}
+	return &ciJobUser{
+		a:             a,
+		allowedForJob: allowedForJob,
+	}, nil
+}
+
+func (u *ciJobUser) name() string {
+	return u.allowedForJob.GetUser().GetUsername()
+}
+
+func (u *ciJobUser) authorize(ctx context.Context, log *zap.Logger, agentId int64, clusterId string) (*authorization, *grpctool.ErrResp) {
+	allowedForJob := u.allowedForJob
+	allowedAgent := findAllowedAgent(agentId, allowedForJob)
+	if allowedAgent == nil {
+		return nil, unauthorizedErrResp(log, fmt.Errorf("CI job is not allowed to access cluster %s", clusterId))
+	}
+	config := allowedAgent.Configuration
+	if !matchesAnyEnvironment(config.GetEnvironments(), allowedForJob.Environment) {
+		return nil, unauthorizedErrResp(log, fmt.Errorf("CI job environment is not allowed to access cluster %s", clusterId))
+	}
+	impConfig, err := constructJobImpersonationConfig(allowedForJob, allowedAgent, clusterId)
+	if err != nil {
+		msg := "Failed to construct CI job impersonation config"
+		u.a.api.HandleProcessingError(ctx, log, agentId, msg, err)
+		return nil, &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+			Err:        err,
+		}
+	}
+	user := allowedForJob.User
+	return &authorization{
+		impConfig:        impConfig,
+		defaultNamespace: config.GetDefaultNamespace(),
+		trackUsage: func(p *kubernetesApiProxy, agentId int64) {
+			p.trackCiAccess(agentId, user)
+		},
+	}, nil
+}
+
+func (a *ciJobAuthenticator) getAllowedAgentsForJob(ctx context.Context, log *zap.Logger, agentId int64, jobToken string) (*pluralapi.AllowedAgentsForJob, *grpctool.ErrResp) {
+	allowedForJob, err := a.allowedAgentsCache.GetItem(ctx, jobToken, func() (*pluralapi.AllowedAgentsForJob, error) {
+		return pluralapi.GetAllowedAgentsForJob(ctx, jobToken, a.pluralUrl)
+	})
+	if err != nil {
+		eResp := &grpctool.ErrResp{
+			Err: err,
+		}
+		switch {
+		case gitlab2.IsUnauthorized(err):
+			eResp.StatusCode = http.StatusUnauthorized
+			eResp.Msg = "Unauthorized: CI job token"
+		case gitlab2.IsForbidden(err):
+			eResp.StatusCode = http.StatusForbidden
+			eResp.Msg = "Forbidden: CI job token"
+		case gitlab2.IsNotFound(err):
+			eResp.StatusCode = http.StatusNotFound
+			eResp.Msg = "Not found: agents for CI job token"
+		default:
+			eResp.StatusCode = http.StatusInternalServerError
+			eResp.Msg = "Failed to get allowed agents for CI job token"
+			a.api.HandleProcessingError(ctx, log, agentId, eResp.Msg, err)
+			return nil, eResp
+		}
+		log.Debug("Allowed agents for CI job error", logz.Error(err))
+		return nil, eResp
+	}
+	return allowedForJob, nil
+}
+
+func findAllowedAgent(agentId int64, allowedForJob *pluralapi.AllowedAgentsForJob) *pluralapi.AllowedAgent {
+	for _, aa := range allowedForJob.AllowedAgents {
+		if aa.Id == agentId {
+			return aa
+		}
+	}
+	return nil
+}
+
+// matchesAnyEnvironment returns true if there are no patterns or the job's environment matches one of them.
+func matchesAnyEnvironment(patterns []string, env *pluralapi.Environment) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	if env == nil {
+		return false
+	}
+	for _, pattern := range patterns {
+		if matchesEnvironment(pattern, env.Slug) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEnvironment returns true if the environment matches the pattern.
+// A * in the pattern matches any sequence of characters, including /.
+func matchesEnvironment(pattern, env string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == env
+	}
+	first, last := parts[0], parts[len(parts)-1]
+	if len(env) < len(first)+len(last) || !strings.HasPrefix(env, first) || !strings.HasSuffix(env, last) {
+		return false
+	}
+	env = env[len(first) : len(env)-len(last)]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(env, part)
+		if i == -1 {
+			return false
+		}
+		env = env[i+len(part):]
+	}
+	return true
+}
+
+func constructJobImpersonationConfig(allowedForJob *pluralapi.AllowedAgentsForJob, allowedAgent *pluralapi.AllowedAgent, clusterId string) (*rpc.ImpersonationConfig, error) {
+	as := allowedAgent.Configuration.GetAccessAs().GetAs()
+	switch imp := as.(type) {
+	case nil, *agentcfg.CiAccessAsCF_Agent: // the default if no access_as is specified
+		return nil, nil
+	case *agentcfg.CiAccessAsCF_Impersonate:
+		i := imp.Impersonate
+		return &rpc.ImpersonationConfig{
+			Username: i.Username,
+			Groups:   i.Groups,
+			Uid:      i.Uid,
+			Extra:    impersonationExtra(i.Extra),
+		}, nil
+	case *agentcfg.CiAccessAsCF_CiJob:
+		return &rpc.ImpersonationConfig{
+			Username: ciJobUsernamePrefix + strconv.FormatInt(allowedForJob.Job.Id, 10),
+			Groups:   ciJobGroups(allowedForJob),
+			Extra:    ciJobExtra(allowedForJob, allowedAgent, clusterId),
+		}, nil
+	default:
+		// Normally this should never happen
+		return nil, fmt.Errorf("unexpected job impersonation mode: %T", imp)
+	}
+}
+
+func impersonationExtra(in []*agentcfg.ExtraKeyValCF) []*rpc.ExtraKeyVal {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*rpc.ExtraKeyVal, 0, len(in))
+	for _, kv := range in {
+		out = append(out, &rpc.ExtraKeyVal{
+			Key: kv.Key,
+			Val: kv.Val,
+		})
+	}
+	return out
+}
+
+// ciJobGroups returns the groups of a CI job. Bind them to grant permissions to jobs of a project or group,
+// optionally only in an environment or an environment tier.
+func ciJobGroups(allowedForJob *pluralapi.AllowedAgentsForJob) []string {
+	projectId := strconv.FormatInt(allowedForJob.Project.Id, 10)
+	env := allowedForJob.Environment
+	groups := []string{ciJobGroup, "plural:project:" + projectId}
+	if env != nil {
+		groups = append(groups,
+			"plural:project_env:"+projectId+":"+env.Slug,
+			"plural:project_env_tier:"+projectId+":"+env.Tier,
+		)
+	}
+	for _, group := range allowedForJob.Project.Groups {
+		groupId := strconv.FormatInt(group.Id, 10)
+		groups = append(groups, "plural:group:"+groupId)
+		if env != nil {
+			groups = append(groups, "plural:group_env_tier:"+groupId+":"+env.Tier)
+		}
+	}
+	return groups
+}
+
+func ciJobExtra(allowedForJob *pluralapi.AllowedAgentsForJob, allowedAgent *pluralapi.AllowedAgent, clusterId string) []*rpc.ExtraKeyVal {
+	extra := []*rpc.ExtraKeyVal{
+		{
+			Key: ciJobExtraPrefix + "cluster_id",
+			Val: []string{clusterId},
+		},
+		{
+			Key: ciJobExtraPrefix + "config_project_id",
+			Val: []string{strconv.FormatInt(allowedAgent.ConfigProject.Id, 10)},
+		},
+		{
+			Key: ciJobExtraPrefix + "project_id",
+			Val: []string{strconv.FormatInt(allowedForJob.Project.Id, 10)},
+		},
+		{
+			Key: ciJobExtraPrefix + "ci_pipeline_id",
+			Val: []string{strconv.FormatInt(allowedForJob.Pipeline.Id, 10)},
+		},
+		{
+			Key: ciJobExtraPrefix + "ci_job_id",
+			Val: []string{strconv.FormatInt(allowedForJob.Job.Id, 10)},
+		},
+		{
+			Key: ciJobExtraPrefix + "username",
+			Val: []string{allowedForJob.User.Username},
+		},
+	}
+	if env := allowedForJob.Environment; env != nil {
+		extra = append(extra,
+			&rpc.ExtraKeyVal{
+				Key: ciJobExtraPrefix + "environment_slug",
+				Val: []string{env.Slug},
+			},
+			&rpc.ExtraKeyVal{
+				Key: ciJobExtraPrefix + "environment_tier",
+				Val: []string{env.Tier},
+			},
+		)
+	}
+	return extra
+}
@@ -0,0 +1,443 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/audit"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	pluralapi "github.com/pluralsh/kubernetes-agent/pkg/plural/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_usage_metrics"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/uuid"
+)
+
+const (
+	testJobToken = "job-token"
+	testUserId   = "7c0e6a9e-3f4b-4c1a-8f0e-2b6f1d5a9c3e"
+)
+
+func TestCiJobAuthenticator_AccessAs(t *testing.T) {
+	tests := []struct {
+		name              string
+		accessAs          *agentcfg.CiAccessAsCF
+		env               *pluralapi.Environment
+		expectedImpConfig *rpc.ImpersonationConfig
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "agent",
+			accessAs: &agentcfg.CiAccessAsCF{
+				As: &agentcfg.CiAccessAsCF_Agent{
+					Agent: &agentcfg.CiAccessAsAgentCF{},
+				},
+			},
+		},
+		{
+			name: "impersonate",
+			accessAs: &agentcfg.CiAccessAsCF{
+				As: &agentcfg.CiAccessAsCF_Impersonate{
+					Impersonate: &agentcfg.CiAccessAsImpersonateCF{
+						Username: "user1",
+						Groups:   []string{"g1", "g2"},
+						Uid:      "uid",
+						Extra: []*agentcfg.ExtraKeyValCF{
+							{
+								Key: "k1",
+								Val: []string{"v1", "v2"},
+							},
+						},
+					},
+				},
+			},
+			expectedImpConfig: &rpc.ImpersonationConfig{
+				Username: "user1",
+				Groups:   []string{"g1", "g2"},
+				Uid:      "uid",
+				Extra: []*rpc.ExtraKeyVal{
+					{
+						Key: "k1",
+						Val: []string{"v1", "v2"},
+					},
+				},
+			},
+		},
+		{
+			name: "ci job without environment",
+			accessAs: &agentcfg.CiAccessAsCF{
+				As: &agentcfg.CiAccessAsCF_CiJob{
+					CiJob: &agentcfg.CiAccessAsCiJobCF{},
+				},
+			},
+			expectedImpConfig: &rpc.ImpersonationConfig{
+				Username: "plural:ci_job:1",
+				Groups:   []string{"plural:ci_job", "plural:project:3", "plural:group:6"},
+				Extra:    testCiJobExtra(),
+			},
+		},
+		{
+			name: "ci job with environment",
+			accessAs: &agentcfg.CiAccessAsCF{
+				As: &agentcfg.CiAccessAsCF_CiJob{
+					CiJob: &agentcfg.CiAccessAsCiJobCF{},
+				},
+			},
+			env: &pluralapi.Environment{
+				Slug: "prod",
+				Tier: "production",
+			},
+			expectedImpConfig: &rpc.ImpersonationConfig{
+				Username: "plural:ci_job:1",
+				Groups: []string{
+					"plural:ci_job",
+					"plural:project:3",
+					"plural:project_env:3:prod",
+					"plural:project_env_tier:3:production",
+					"plural:group:6",
+					"plural:group_env_tier:6:production",
+				},
+				Extra: append(testCiJobExtra(),
+					&rpc.ExtraKeyVal{
+						Key: "agent.plural.sh/environment_slug",
+						Val: []string{"prod"},
+					},
+					&rpc.ExtraKeyVal{
+						Key: "agent.plural.sh/environment_tier",
+						Val: []string{"production"},
+					},
+				),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			aa := testAllowedAgentsForJob(t, &pluralapi.Configuration{
+				DefaultNamespace: "ns1",
+				AccessAs:         tc.accessAs,
+			})
+			aa.Environment = tc.env
+			a := newTestCiJobAuthenticator(t, fakeAllowedAgentsConsole(t, http.StatusOK, aa), nil)
+
+			auth, eResp := authorizeTestCiJob(t, a, testClusterAgentId(t))
+			require.Nil(t, eResp)
+			assert.Empty(t, cmp.Diff(tc.expectedImpConfig, auth.impConfig, protocmp.Transform()))
+			assert.Equal(t, "ns1", auth.defaultNamespace)
+			assert.NotNil(t, auth.trackUsage)
+		})
+	}
+}
+
+func TestCiJobAuthenticator_Environments(t *testing.T) {
+	tests := []struct {
+		name         string
+		environments []string
+		env          *pluralapi.Environment
+		allowed      bool
+	}{
+		{
+			name:    "no environments, no environment",
+			allowed: true,
+		},
+		{
+			name:    "no environments",
+			env:     &pluralapi.Environment{Slug: "prod", Tier: "production"},
+			allowed: true,
+		},
+		{
+			name:         "matching environment",
+			environments: []string{"staging", "prod"},
+			env:          &pluralapi.Environment{Slug: "prod", Tier: "production"},
+			allowed:      true,
+		},
+		{
+			name:         "matching wildcard",
+			environments: []string{"review/*"},
+			env:          &pluralapi.Environment{Slug: "review/feature/x", Tier: "development"},
+			allowed:      true,
+		},
+		{
+			name:         "other environment",
+			environments: []string{"prod"},
+			env:          &pluralapi.Environment{Slug: "staging", Tier: "staging"},
+		},
+		{
+			name:         "no environment",
+			environments: []string{"prod"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			aa := testAllowedAgentsForJob(t, &pluralapi.Configuration{
+				Environments: tc.environments,
+			})
+			aa.Environment = tc.env
+			a := newTestCiJobAuthenticator(t, fakeAllowedAgentsConsole(t, http.StatusOK, aa), nil)
+
+			_, eResp := authorizeTestCiJob(t, a, testClusterAgentId(t))
+			if tc.allowed {
+				assert.Nil(t, eResp)
+			} else {
+				require.NotNil(t, eResp)
+				assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCiJobAuthenticator_AgentNotAllowed(t *testing.T) {
+	aa := testAllowedAgentsForJob(t, nil)
+	a := newTestCiJobAuthenticator(t, fakeAllowedAgentsConsole(t, http.StatusOK, aa), nil)
+
+	_, eResp := authorizeTestCiJob(t, a, testClusterAgentId(t)+1)
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+}
+
+func TestCiJobAuthenticator_ConsoleErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		consoleStatus int
+		expectedCode  int32
+		expectedMsg   string
+		handleErr     bool
+	}{
+		{
+			name:          "invalid token",
+			consoleStatus: http.StatusUnauthorized,
+			expectedCode:  http.StatusUnauthorized,
+			expectedMsg:   "Unauthorized: CI job token",
+		},
+		{
+			name:          "forbidden token",
+			consoleStatus: http.StatusForbidden,
+			expectedCode:  http.StatusForbidden,
+			expectedMsg:   "Forbidden: CI job token",
+		},
+		{
+			name:          "unknown job",
+			consoleStatus: http.StatusOK,
+			expectedCode:  http.StatusNotFound,
+			expectedMsg:   "Not found: agents for CI job token",
+		},
+		{
+			name:          "server error",
+			consoleStatus: http.StatusBadGateway,
+			expectedCode:  http.StatusInternalServerError,
+			expectedMsg:   "Failed to get allowed agents for CI job token",
+			handleErr:     true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockApi := mock_modserver.NewMockApi(gomock.NewController(t))
+			if tc.handleErr {
+				mockApi.EXPECT().
+					HandleProcessingError(gomock.Any(), gomock.Any(), testClusterAgentId(t), tc.expectedMsg, gomock.Any())
+			}
+			a := newTestCiJobAuthenticator(t, fakeAllowedAgentsConsole(t, tc.consoleStatus, nil), mockApi)
+
+			_, eResp := a.authenticate(context.Background(), zaptest.NewLogger(t), testClusterAgentId(t), nil, testCiJobCredentials())
+			require.NotNil(t, eResp)
+			assert.Equal(t, tc.expectedCode, eResp.StatusCode)
+			assert.Equal(t, tc.expectedMsg, eResp.Msg)
+		})
+	}
+}
+
+func TestMatchesEnvironment(t *testing.T) {
+	tests := []struct {
+		pattern string
+		env     string
+		matches bool
+	}{
+		{pattern: "prod", env: "prod", matches: true},
+		{pattern: "prod", env: "production"},
+		{pattern: "*", env: "anything", matches: true},
+		{pattern: "review/*", env: "review/a/b", matches: true},
+		{pattern: "review/*", env: "review", matches: false},
+		{pattern: "*-prod", env: "eu-prod", matches: true},
+		{pattern: "*-prod", env: "eu-prod-2"},
+		{pattern: "a*b*c", env: "abc", matches: true},
+		{pattern: "a*b*c", env: "axbxc", matches: true},
+		{pattern: "a*b*c", env: "axc"},
+		{pattern: "ab*ba", env: "aba"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.env, func(t *testing.T) {
+			assert.Equal(t, tc.matches, matchesEnvironment(tc.pattern, tc.env))
+		})
+	}
+}
+
+func TestProxy_CiAccessUsageMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	requestCounter := mock_usage_metrics.NewMockCounter(ctrl)
+	usersCounter := mock_usage_metrics.NewMockUniqueCounter(ctrl)
+	agentsCounter := mock_usage_metrics.NewMockUniqueCounter(ctrl)
+	tunnelUsersCounter := mock_usage_metrics.NewMockUniqueCounter(ctrl)
+	userId, err := uuid.ToInt64(testUserId)
+	require.NoError(t, err)
+	agentId := testClusterAgentId(t)
+	gomock.InOrder(
+		requestCounter.EXPECT().Inc(),
+		agentsCounter.EXPECT().Add(agentId),
+		usersCounter.EXPECT().Add(userId),
+		tunnelUsersCounter.EXPECT().Add(userId),
+	)
+	aa := testAllowedAgentsForJob(t, nil)
+	p := &kubernetesApiProxy{
+		authenticators: map[string]authenticator{
+			tokenTypeCi: newTestCiJobAuthenticator(t, fakeAllowedAgentsConsole(t, http.StatusOK, aa), nil),
+		},
+		ciTunnelUsersCounter:   tunnelUsersCounter,
+		ciAccessRequestCounter: requestCounter,
+		ciAccessUsersCounter:   usersCounter,
+		ciAccessAgentsCounter:  agentsCounter,
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(httpz.AuthorizationHeader, "Bearer ci:"+testClusterId+":"+testJobToken)
+	ev := &audit.Event{}
+
+	_, actualAgentId, impConfig, eResp := p.authenticateAndImpersonateRequest(context.Background(), zaptest.NewLogger(t), r, ev)
+	require.Nil(t, eResp)
+	assert.Equal(t, agentId, actualAgentId)
+	assert.Nil(t, impConfig)
+	assert.Equal(t, tokenTypeCi, ev.AuthnType)
+	assert.Empty(t, ev.PluralToken)
+}
+
+func TestUsageUserId(t *testing.T) {
+	userId, err := uuid.ToInt64(testUserId)
+	require.NoError(t, err)
+	assert.Equal(t, userId, usageUserId(testUserId))
+
+	// Users with ids that are not UUIDs are counted too, consistently and separately from each other.
+	assert.Equal(t, usageUserId("user1"), usageUserId("user1"))
+	assert.NotEqual(t, usageUserId("user1"), usageUserId("user2"))
+}
+
+func newTestCiJobAuthenticator(t *testing.T, pluralUrl string, api *mock_modserver.MockApi) *ciJobAuthenticator {
+	if api == nil {
+		api = mock_modserver.NewMockApi(gomock.NewController(t))
+	}
+	return &ciJobAuthenticator{
+		api:       api,
+		pluralUrl: pluralUrl,
+		allowedAgentsCache: cache.NewWithError[string, *pluralapi.AllowedAgentsForJob](0, 0, nil,
+			trace.NewNoopTracerProvider().Tracer(""), isCacheableConsoleError),
+	}
+}
+
+// authorizeTestCiJob authenticates the test job token and authorizes the job to access the agent.
+func authorizeTestCiJob(t *testing.T, a *ciJobAuthenticator, agentId int64) (*authorization, *grpctool.ErrResp) {
+	log := zaptest.NewLogger(t)
+	user, eResp := a.authenticate(context.Background(), log, agentId, nil, testCiJobCredentials())
+	require.Nil(t, eResp)
+	return user.authorize(context.Background(), log, agentId, testClusterId)
+}
+
+func testClusterAgentId(t *testing.T) int64 {
+	agentId, err := uuid.ToInt64(testClusterId)
+	require.NoError(t, err)
+	return agentId
+}
+
+func testCiJobCredentials() credentials {
+	return credentials{
+		authnType: tokenTypeCi,
+		clusterId: testClusterId,
+		token:     testJobToken,
+	}
+}
+
+// testAllowedAgentsForJob returns a job of project 3 in group 6 that can access the test cluster.
+func testAllowedAgentsForJob(t *testing.T, config *pluralapi.Configuration) *pluralapi.AllowedAgentsForJob {
+	return &pluralapi.AllowedAgentsForJob{
+		AllowedAgents: []*pluralapi.AllowedAgent{
+			{
+				Id:            testClusterAgentId(t),
+				ConfigProject: &pluralapi.ConfigProject{Id: 5},
+				Configuration: config,
+			},
+		},
+		Job:      &pluralapi.Job{Id: 1},
+		Pipeline: &pluralapi.Pipeline{Id: 2},
+		Project: &pluralapi.Project{
+			Id:     3,
+			Groups: []*pluralapi.Group{{Id: 6}},
+		},
+		User: &pluralapi.User{
+			Id:       testUserId,
+			Username: "user1",
+			Email:    "user1@example.com",
+		},
+	}
+}
+
+func testCiJobExtra() []*rpc.ExtraKeyVal {
+	return []*rpc.ExtraKeyVal{
+		{
+			Key: "agent.plural.sh/cluster_id",
+			Val: []string{testClusterId},
+		},
+		{
+			Key: "agent.plural.sh/config_project_id",
+			Val: []string{"5"},
+		},
+		{
+			Key: "agent.plural.sh/project_id",
+			Val: []string{"3"},
+		},
+		{
+			Key: "agent.plural.sh/ci_pipeline_id",
+			Val: []string{"2"},
+		},
+		{
+			Key: "agent.plural.sh/ci_job_id",
+			Val: []string{"1"},
+		},
+		{
+			Key: "agent.plural.sh/username",
+			Val: []string{"user1"},
+		},
+	}
+}
+
+// fakeAllowedAgentsConsole starts a Plural Console that responds to the test job token with the given status and
+// allowed agents. A nil aa means Console does not know the job.
+func fakeAllowedAgentsConsole(t *testing.T, status int, aa *pluralapi.AllowedAgentsForJob) string {
+	result := []byte("null")
+	if aa != nil {
+		var err error
+		result, err = protojson.Marshal(aa)
+		require.NoError(t, err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "Token "+testJobToken, r.Header.Get(httpz.AuthorizationHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set(httpz.ContentTypeHeader, "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":{"allowedAgentsForJob":` + string(result) + `}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+)
+
+var (
+	oidcValidMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+)
+
+// oidcAuthenticator authenticates OIDC ID tokens using public keys from a JWKS file.
+type oidcAuthenticator struct {
+	issuer        string
+	audience      string
+	usernameClaim string
+	groupsClaim   string
+	// keys maps key id to the public key.
+	keys     map[string]any
+	clusters clusterAllowList
+}
+
+func newOidcAuthenticator(cfg *kascfg.KubernetesApiOidcAuthCF) (*oidcAuthenticator, error) {
+	data, err := os.ReadFile(cfg.JwksFile) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("JWKS file: %w", err)
+	}
+	keys, err := parseJwks(data)
+	if err != nil {
+		return nil, fmt.Errorf("JWKS file %s: %w", cfg.JwksFile, err)
+	}
+	return &oidcAuthenticator{
+		issuer:        cfg.Issuer,
+		audience:      cfg.Audience,
+		usernameClaim: cfg.UsernameClaim,
+		groupsClaim:   cfg.GroupsClaim,
+		keys:          keys,
+		clusters:      newClusterAllowList(cfg.ClusterIds),
+	}, nil
+}
+
+func (a *oidcAuthenticator) authenticate(ctx context.Context, log *zap.Logger, agentId int64, r *http.Request, creds credentials) (authenticatedUser, *grpctool.ErrResp) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(creds.token, claims, a.key,
+		jwt.WithIssuer(a.issuer),
+		jwt.WithAudience(a.audience),
+		jwt.WithValidMethods(oidcValidMethods),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, unauthorizedErrResp(log, fmt.Errorf("ID token: %w", err))
+	}
+	username, _ := claims[a.usernameClaim].(string)
+	if username == "" {
+		return nil, unauthorizedErrResp(log, fmt.Errorf("ID token: missing %q claim", a.usernameClaim))
+	}
+	groups, err := stringsClaim(claims[a.groupsClaim])
+	if err != nil {
+		return nil, unauthorizedErrResp(log, fmt.Errorf("ID token: %q claim: %w", a.groupsClaim, err))
+	}
+	return &identityUser{
+		identity: prefixedIdentity(tokenTypeOidc+":", username, "", groups),
+		clusters: a.clusters,
+	}, nil
+}
+
+func (a *oidcAuthenticator) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, nil
+		}
+	}
+	key, ok := a.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+// stringsClaim converts a claim that is either a string or a list of strings into a slice.
+func stringsClaim(claim any) ([]string, error) {
+	switch c := claim.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{c}, nil
+	case []any:
+		res := make([]string, 0, len(c))
+		for _, v := range c {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string, got %T", v)
+			}
+			res = append(res, s)
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("expected a string or a list of strings, got %T", claim)
+	}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJwks parses signature verification keys from a JSON Web Key Set.
+// See https://datatracker.ietf.org/doc/html/rfc7517 and https://datatracker.ietf.org/doc/html/rfc7518#section-6.
+func parseJwks(data []byte) (map[string]any, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err := json.Unmarshal(data, &jwks)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]any, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var key any
+		switch k.Kty {
+		case "RSA":
+			key, err = parseRsaJwk(k)
+		case "EC":
+			key, err = parseEcJwk(k)
+		default:
+			err = fmt.Errorf("unsupported key type %q", k.Kty)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signature verification keys")
+	}
+	return keys, nil
+}
+
+func parseRsaJwk(k jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("n: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("e: %w", err)
+	}
+	exp := new(big.Int).SetBytes(e)
+	if !exp.IsInt64() || exp.Int64() > 1<<31-1 || exp.Int64() < 2 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exp.Int64()),
+	}, nil
+}
+
+func parseEcJwk(k jsonWebKey) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("x: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("y: %w", err)
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) != size || len(y) != size {
+		return nil, errors.New("invalid coordinate length")
+	}
+	// Uncompressed point encoding: 0x04 || x || y.
+	point := make([]byte, 0, 1+2*size)
+	point = append(point, 4)
+	point = append(point, x...)
+	point = append(point, y...)
+	return ecdsa.ParseUncompressedPublicKey(curve, point)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	pluralapi "github.com/pluralsh/kubernetes-agent/pkg/plural/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+type proxyUserCacheKey struct {
+	agentId   int64
+	accessKey string
+	clusterId string
+}
+
+// pluralAuthenticator authenticates Plural Console tokens using the Console token exchange.
+type pluralAuthenticator struct {
+	api                     modserver.Api
+	pluralUrl               string
+	userCache               *cache.CacheWithErr[string, *pluralapi.User]
+	authorizeProxyUserCache *cache.CacheWithErr[proxyUserCacheKey, *pluralapi.AuthorizeProxyUserResponse]
+}
+
+// pluralUser is a user of a Plural Console token. The identity to impersonate depends on the cluster.
+type pluralUser struct {
+	a     *pluralAuthenticator
+	token string
+	user  *pluralapi.User
+}
+
+func (a *pluralAuthenticator) authenticate(ctx context.Context, log *zap.Logger, agentId int64, r *http.Request, creds credentials) (authenticatedUser, *grpctool.ErrResp) {
+	user, err := a.userCache.GetItem(ctx, creds.token, func() (*pluralapi.User, error) {
+		return pluralapi.GetUser(ctx, creds.token, a.pluralUrl)
+	})
+	if err != nil {
+		if isCacheableConsoleError(err) {
+			return nil, unauthorizedErrResp(log, err)
+		}
+		msg := "Failed to authenticate user"
+		a.api.HandleProcessingError(ctx, log, agentId, msg, err)
+		return nil, &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+		}
+	}
+	return &pluralUser{
+		a:     a,
+		token: creds.token,
+		user:  user,
+	}, nil
+}
+
+func (u *pluralUser) name() string {
+	return u.user.Username
+}
+
+func (u *pluralUser) authorize(ctx context.Context, log *zap.Logger, agentId int64, clusterId string) (*authorization, *grpctool.ErrResp) {
+	auth, eResp := u.a.authorizeProxyUser(ctx, log, agentId, u.token, clusterId)
+	if eResp != nil {
+		return nil, eResp
+	}
+	impConfig, err := constructUserImpersonationConfig(auth)
+	if err != nil {
+		msg := "Failed to construct user impersonation config"
+		u.a.api.HandleProcessingError(ctx, log, agentId, msg, err)
+		return nil, &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+			Err:        err,
+		}
+	}
+	return &authorization{
+		impConfig: impConfig,
+	}, nil
+}
+
+func (a *pluralAuthenticator) authorizeProxyUser(ctx context.Context, log *zap.Logger, agentId int64, accessKey, clusterId string) (*pluralapi.AuthorizeProxyUserResponse, *grpctool.ErrResp) {
+	key := proxyUserCacheKey{
+		agentId:   agentId,
+		clusterId: clusterId,
+		accessKey: accessKey,
+	}
+	auth, err := a.authorizeProxyUserCache.GetItem(ctx, key, func() (*pluralapi.AuthorizeProxyUserResponse, error) {
+		return pluralapi.AuthorizeProxyUser(ctx, accessKey, clusterId, a.pluralUrl)
+	})
+	if err != nil {
+		switch {
+		case gitlab2.IsUnauthorized(err), gitlab2.IsForbidden(err), gitlab2.IsNotFound(err):
+			log.Debug("Authorize proxy user error", logz.Error(err))
+			return nil, &grpctool.ErrResp{
+				StatusCode: http.StatusUnauthorized,
+				Msg:        "Unauthorized",
+			}
+		default:
+			msg := "Failed to authorize user session"
+			a.api.HandleProcessingError(ctx, log, agentId, msg, err)
+			return nil, &grpctool.ErrResp{
+				StatusCode: http.StatusInternalServerError,
+				Msg:        msg,
+			}
+		}
+	}
+	return auth, nil
+}
+
+func constructUserImpersonationConfig(auth *pluralapi.AuthorizeProxyUserResponse) (*rpc2.ImpersonationConfig, error) {
+	switch imp := auth.GetAccessAs().AccessAs.(type) {
+	case *pluralapi.AccessAsProxyAuthorization_Agent:
+		return nil, nil
+	case *pluralapi.AccessAsProxyAuthorization_User:
+		impConfig := &rpc2.ImpersonationConfig{
+			Username: auth.User.GetUsername(),
+			Groups:   auth.AccessAs.GetUser().Groups,
+			Roles:    auth.AccessAs.GetUser().Roles,
+		}
+		if err := impConfig.CheckUsername(); err != nil {
+			// Groups and roles cannot be impersonated without a user.
+			return nil, err
+		}
+		return impConfig, nil
+	default:
+		// Normally this should never happen
+		return nil, fmt.Errorf("unexpected user impersonation mode: %T", imp)
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+)
+
+// staticTokenAuthenticator authenticates bearer tokens listed in a static token file.
+type staticTokenAuthenticator struct {
+	// identities maps SHA-256 of a token to the identity it authenticates.
+	identities map[[sha256.Size]byte]*rpc.ImpersonationConfig
+	clusters   clusterAllowList
+}
+
+func newStaticTokenAuthenticator(cfg *kascfg.KubernetesApiStaticTokenAuthCF) (*staticTokenAuthenticator, error) {
+	f, err := os.Open(cfg.TokenFile) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("static token file: %w", err)
+	}
+	defer f.Close() // nolint: errcheck
+	identities, err := parseStaticTokens(f)
+	if err != nil {
+		return nil, fmt.Errorf("static token file %s: %w", cfg.TokenFile, err)
+	}
+	return &staticTokenAuthenticator{
+		identities: identities,
+		clusters:   newClusterAllowList(cfg.ClusterIds),
+	}, nil
+}
+
+func (a *staticTokenAuthenticator) authenticate(ctx context.Context, log *zap.Logger, agentId int64, r *http.Request, creds credentials) (authenticatedUser, *grpctool.ErrResp) {
+	identity, ok := a.identities[sha256.Sum256([]byte(creds.token))]
+	if !ok {
+		return nil, unauthorizedErrResp(log, errors.New("invalid static token"))
+	}
+	return &identityUser{
+		identity: prefixedIdentity(tokenTypeStatic+":", identity.Username, identity.Uid, identity.Groups),
+		clusters: a.clusters,
+	}, nil
+}
+
+// parseStaticTokens parses token records in the Kubernetes API server's --token-auth-file format:
+// token,username,uid,"group1,group2".
+func parseStaticTokens(r io.Reader) (map[[sha256.Size]byte]*rpc.ImpersonationConfig, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	identities := map[[sha256.Size]byte]*rpc.ImpersonationConfig{}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expecting at least 3 fields, got %d", line, len(record))
+		}
+		token, username := record[0], record[1]
+		if token == "" {
+			return nil, fmt.Errorf("line %d: empty token", line)
+		}
+		if username == "" {
+			return nil, fmt.Errorf("line %d: empty username", line)
+		}
+		identity := &rpc.ImpersonationConfig{
+			Username: username,
+			Uid:      record[2],
+		}
+		if len(record) > 3 && record[3] != "" {
+			for _, group := range strings.Split(record[3], ",") {
+				identity.Groups = append(identity.Groups, strings.TrimSpace(group))
+			}
+		}
+		key := sha256.Sum256([]byte(token))
+		if _, ok := identities[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate token", line)
+		}
+		identities[key] = identity
+	}
+	return identities, nil
+}
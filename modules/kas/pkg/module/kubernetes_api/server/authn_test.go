@@ -0,0 +1,445 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	pluralapi "github.com/pluralsh/kubernetes-agent/pkg/plural/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/uuid"
+)
+
+const (
+	testClusterId = "2d8e1f5c-5b8e-4b0e-9a55-5a3f3a4b1c2d"
+	testIssuer    = "https://issuer.example.com"
+	testAudience  = "kas"
+)
+
+var (
+	_ authenticator = (*pluralAuthenticator)(nil)
+	_ authenticator = (*ciJobAuthenticator)(nil)
+	_ authenticator = (*oidcAuthenticator)(nil)
+	_ authenticator = (*staticTokenAuthenticator)(nil)
+	_ authenticator = (*clientCertificateAuthenticator)(nil)
+
+	_ authenticatedUser = (*pluralUser)(nil)
+	_ authenticatedUser = (*ciJobUser)(nil)
+	_ authenticatedUser = (*identityUser)(nil)
+)
+
+func TestGetAuthorizationInfoFromRequest_TokenTypes(t *testing.T) {
+	expectedAgentId, err := uuid.ToInt64(testClusterId)
+	require.NoError(t, err)
+	for _, tokenType := range []string{tokenTypePlural, tokenTypeCi, tokenTypeOidc, tokenTypeStatic} {
+		t.Run(tokenType, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, "/", nil) // nolint: noctx
+			require.NoError(t, err)
+			r.Header.Set(httpz.AuthorizationHeader, "Bearer "+tokenType+":"+testClusterId+":tok")
+
+			agentId, creds, err := getAuthorizationInfoFromRequest(r)
+			require.NoError(t, err)
+			assert.Equal(t, expectedAgentId, agentId)
+			assert.Equal(t, credentials{
+				authnType: tokenType,
+				clusterId: testClusterId,
+				token:     "tok",
+			}, creds)
+		})
+	}
+}
+
+func TestGetAuthorizationInfoFromRequest_UnknownTokenType(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "/", nil) // nolint: noctx
+	require.NoError(t, err)
+	r.Header.Set(httpz.AuthorizationHeader, "Bearer bla:"+testClusterId+":tok")
+
+	_, _, err = getAuthorizationInfoFromRequest(r)
+	assert.EqualError(t, err, "Authorization header: unknown token type")
+}
+
+func TestGetAuthorizationInfoFromRequest_ClientCertificate(t *testing.T) {
+	cert := &x509.Certificate{}
+	r, err := http.NewRequest(http.MethodGet, "/", nil) // nolint: noctx
+	require.NoError(t, err)
+	r.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{cert}},
+	}
+
+	_, _, err = getAuthorizationInfoFromRequest(r)
+	assert.EqualError(t, err, "Gitlab-Agent-Id header: expecting a single header, got 0")
+
+	r.Header.Set(httpz.GitlabAgentIdHeader, testClusterId)
+	_, creds, err := getAuthorizationInfoFromRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, authnTypeClientCertificate, creds.authnType)
+	assert.Same(t, cert, creds.cert)
+}
+
+func TestOidcAuthenticator_RSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	a := newTestOidcAuthenticator(t, map[string]any{
+		"kty": "RSA",
+		"kid": "k1",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	})
+	token := signTestIdToken(t, jwt.SigningMethodRS256, "k1", key, jwt.MapClaims{
+		"iss":    testIssuer,
+		"aud":    testAudience,
+		"exp":    time.Now().Add(time.Minute).Unix(),
+		"sub":    "user1",
+		"groups": []string{"g1", "g2"},
+	})
+
+	log := zaptest.NewLogger(t)
+	user, eResp := a.authenticate(context.Background(), log, 1, nil, credentials{token: token})
+	require.Nil(t, eResp)
+	assert.Equal(t, "oidc:user1", user.name())
+	auth, eResp := user.authorize(context.Background(), log, 1, testClusterId)
+	require.Nil(t, eResp)
+	assert.Empty(t, cmp.Diff(&rpc.ImpersonationConfig{
+		Username: "oidc:user1",
+		Groups:   []string{"oidc:g1", "oidc:g2"},
+	}, auth.impConfig, protocmp.Transform()))
+
+	_, eResp = user.authorize(context.Background(), log, 1, "other")
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+}
+
+func TestOidcAuthenticator_EC(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub, err := key.PublicKey.Bytes() // uncompressed point: 0x04 || x || y
+	require.NoError(t, err)
+	a := newTestOidcAuthenticator(t, map[string]any{
+		"kty": "EC",
+		"kid": "k1",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(pub[1:33]),
+		"y":   base64.RawURLEncoding.EncodeToString(pub[33:]),
+	})
+	token := signTestIdToken(t, jwt.SigningMethodES256, "", key, jwt.MapClaims{
+		"iss":    testIssuer,
+		"aud":    []string{"other", testAudience},
+		"exp":    time.Now().Add(time.Minute).Unix(),
+		"sub":    "user1",
+		"groups": "g1",
+	})
+
+	impConfig := authenticateAndAuthorize(t, a, credentials{token: token})
+	assert.Equal(t, "oidc:user1", impConfig.Username)
+	assert.Equal(t, []string{"oidc:g1"}, impConfig.Groups)
+}
+
+func TestOidcAuthenticator_InvalidTokens(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	a := newTestOidcAuthenticator(t, map[string]any{
+		"kty": "RSA",
+		"kid": "k1",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	})
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": testIssuer,
+			"aud": testAudience,
+			"exp": time.Now().Add(time.Minute).Unix(),
+			"sub": "user1",
+		}
+	}
+	tests := []struct {
+		name   string
+		kid    string
+		key    *rsa.PrivateKey
+		modify func(jwt.MapClaims)
+	}{
+		{
+			name:   "wrong issuer",
+			kid:    "k1",
+			key:    key,
+			modify: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		},
+		{
+			name:   "wrong audience",
+			kid:    "k1",
+			key:    key,
+			modify: func(c jwt.MapClaims) { c["aud"] = "other" },
+		},
+		{
+			name:   "expired",
+			kid:    "k1",
+			key:    key,
+			modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		},
+		{
+			name:   "no expiration",
+			kid:    "k1",
+			key:    key,
+			modify: func(c jwt.MapClaims) { delete(c, "exp") },
+		},
+		{
+			name:   "no username",
+			kid:    "k1",
+			key:    key,
+			modify: func(c jwt.MapClaims) { delete(c, "sub") },
+		},
+		{
+			name:   "invalid groups",
+			kid:    "k1",
+			key:    key,
+			modify: func(c jwt.MapClaims) { c["groups"] = 42 },
+		},
+		{
+			name:   "unknown key id",
+			kid:    "k2",
+			key:    key,
+			modify: func(c jwt.MapClaims) {},
+		},
+		{
+			name:   "wrong signature",
+			kid:    "k1",
+			key:    otherKey,
+			modify: func(c jwt.MapClaims) {},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := validClaims()
+			tc.modify(claims)
+			token := signTestIdToken(t, jwt.SigningMethodRS256, tc.kid, tc.key, claims)
+
+			_, eResp := a.authenticate(context.Background(), zaptest.NewLogger(t), 1, nil, credentials{token: token})
+			require.NotNil(t, eResp)
+			assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+		})
+	}
+}
+
+func TestParseJwks_Errors(t *testing.T) {
+	_, err := parseJwks([]byte(`{"keys":[]}`))
+	assert.EqualError(t, err, "no signature verification keys")
+
+	_, err = parseJwks([]byte(`{"keys":[{"kty":"oct","kid":"k1"}]}`))
+	assert.EqualError(t, err, `key "k1": unsupported key type "oct"`)
+
+	_, err = parseJwks([]byte(`{"keys":[{"kty":"EC","kid":"k1","crv":"P-256","x":"AA","y":"AA"}]}`))
+	assert.EqualError(t, err, `key "k1": invalid coordinate length`)
+}
+
+func TestParseStaticTokens(t *testing.T) {
+	identities, err := parseStaticTokens(strings.NewReader(`# comment
+token1,user1,uid1
+token2,user2,uid2,"g1,g2"
+`))
+	require.NoError(t, err)
+	a := &staticTokenAuthenticator{
+		identities: identities,
+		clusters:   newClusterAllowList([]string{testClusterId}),
+	}
+	log := zaptest.NewLogger(t)
+
+	impConfig := authenticateAndAuthorize(t, a, credentials{token: "token1"})
+	assert.Empty(t, cmp.Diff(&rpc.ImpersonationConfig{
+		Username: "static:user1",
+		Uid:      "uid1",
+	}, impConfig, protocmp.Transform()))
+
+	impConfig = authenticateAndAuthorize(t, a, credentials{token: "token2"})
+	assert.Empty(t, cmp.Diff(&rpc.ImpersonationConfig{
+		Username: "static:user2",
+		Uid:      "uid2",
+		Groups:   []string{"static:g1", "static:g2"},
+	}, impConfig, protocmp.Transform()))
+
+	_, eResp := a.authenticate(context.Background(), log, 1, nil, credentials{token: "token3"})
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+
+	user, eResp := a.authenticate(context.Background(), log, 1, nil, credentials{token: "token1"})
+	require.Nil(t, eResp)
+	_, eResp = user.authorize(context.Background(), log, 1, "other")
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+}
+
+func TestParseStaticTokens_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name:        "too few fields",
+			data:        "token1,user1",
+			expectedErr: "line 1: expecting at least 3 fields, got 2",
+		},
+		{
+			name:        "empty token",
+			data:        ",user1,uid1",
+			expectedErr: "line 1: empty token",
+		},
+		{
+			name:        "empty username",
+			data:        "token1,,uid1",
+			expectedErr: "line 1: empty username",
+		},
+		{
+			name:        "duplicate token",
+			data:        "token1,user1,uid1\ntoken1,user2,uid2",
+			expectedErr: "line 2: duplicate token",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseStaticTokens(strings.NewReader(tc.data))
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestClientCertificateAuthenticator(t *testing.T) {
+	a := &clientCertificateAuthenticator{
+		clusters: newClusterAllowList([]string{testClusterId}),
+	}
+	log := zaptest.NewLogger(t)
+	cert := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   "user1",
+			Organization: []string{"system:masters", "g2"},
+		},
+	}
+	impConfig := authenticateAndAuthorize(t, a, credentials{cert: cert})
+	assert.Equal(t, "x509:user1", impConfig.Username)
+	assert.Equal(t, []string{"x509:system:masters", "x509:g2"}, impConfig.Groups)
+
+	user, eResp := a.authenticate(context.Background(), log, 1, nil, credentials{cert: cert})
+	require.Nil(t, eResp)
+	_, eResp = user.authorize(context.Background(), log, 1, "other")
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+
+	_, eResp = a.authenticate(context.Background(), log, 1, nil, credentials{
+		cert: &x509.Certificate{},
+	})
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+}
+
+func TestConstructUserImpersonationConfig(t *testing.T) {
+	auth := &pluralapi.AuthorizeProxyUserResponse{
+		User: &pluralapi.User{
+			Username: "user1",
+		},
+		AccessAs: &pluralapi.AccessAsProxyAuthorization{
+			AccessAs: &pluralapi.AccessAsProxyAuthorization_User{
+				User: &pluralapi.AccessAsUserAuthorization{
+					Roles:  []string{"admin"},
+					Groups: []string{"g1"},
+				},
+			},
+		},
+	}
+	impConfig, err := constructUserImpersonationConfig(auth)
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(&rpc.ImpersonationConfig{
+		Username: "user1",
+		Groups:   []string{"g1"},
+		Roles:    []string{"admin"},
+	}, impConfig, protocmp.Transform()))
+
+	// Roles and groups cannot be impersonated without a user.
+	auth.User.Username = ""
+	_, err = constructUserImpersonationConfig(auth)
+	assert.ErrorIs(t, err, rpc.ErrNoUsername)
+}
+
+func TestClusterAllowList(t *testing.T) {
+	log := zaptest.NewLogger(t)
+	l := newClusterAllowList([]string{"c1", "c2"})
+	assert.Nil(t, l.check(log, "c1"))
+	assert.Nil(t, l.check(log, "c2"))
+	assert.NotNil(t, l.check(log, "c3"))
+
+	l = newClusterAllowList([]string{anyCluster})
+	assert.Nil(t, l.check(log, "c3"))
+
+	l = newClusterAllowList(nil)
+	assert.NotNil(t, l.check(log, "c1"))
+}
+
+// authenticateAndAuthorize returns the identity the credentials get in the test cluster.
+func authenticateAndAuthorize(t *testing.T, a authenticator, creds credentials) *rpc.ImpersonationConfig {
+	log := zaptest.NewLogger(t)
+	user, eResp := a.authenticate(context.Background(), log, 1, nil, creds)
+	require.Nil(t, eResp)
+	auth, eResp := user.authorize(context.Background(), log, 1, testClusterId)
+	require.Nil(t, eResp)
+	return auth.impConfig
+}
+
+func newTestOidcAuthenticator(t *testing.T, jwk map[string]any) *oidcAuthenticator {
+	data, err := json.Marshal(map[string]any{
+		"keys": []any{jwk},
+	})
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, data, 0o600))
+	cfg := &kascfg.KubernetesApiOidcAuthCF{
+		Issuer:     testIssuer,
+		Audience:   testAudience,
+		JwksFile:   jwksFile,
+		ClusterIds: []string{testClusterId},
+	}
+	ApplyDefaults(&kascfg.ConfigurationFile{
+		Agent: &kascfg.AgentCF{
+			KubernetesApi: &kascfg.KubernetesApiCF{
+				Authentication: &kascfg.KubernetesApiAuthenticationCF{
+					Oidc: cfg,
+				},
+			},
+		},
+	})
+	a, err := newOidcAuthenticator(cfg)
+	require.NoError(t, err)
+	return a
+}
+
+func signTestIdToken(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
@@ -15,6 +15,14 @@ const (
 	defaultAllowedAgentInfoCacheTTL      = 1 * time.Minute
 	defaultAllowedAgentInfoCacheErrorTTL = 10 * time.Second
 	defaultShutdownGracePeriod           = 1 * time.Hour
+	defaultOidcUsernameClaim             = "sub"
+	defaultOidcGroupsClaim               = "groups"
+	defaultAuditQueueSize                = 10000
+	defaultAuditBatchSize                = 100
+	defaultAuditFlushInterval            = 1 * time.Second
+	defaultAuditMaxRetryBackoff          = 1 * time.Minute
+	defaultDiscoveryCacheTTL             = 10 * time.Minute
+	defaultDiscoveryCacheMaxSize         = 64 * 1024 * 1024
 )
 
 func ApplyDefaults(config *kascfg.ConfigurationFile) {
@@ -34,4 +42,20 @@ func ApplyDefaults(config *kascfg.ConfigurationFile) {
 	}
 	prototool.Duration(&o.AllowedAgentCacheTtl, defaultAllowedAgentInfoCacheTTL)
 	prototool.Duration(&o.AllowedAgentCacheErrorTtl, defaultAllowedAgentInfoCacheErrorTTL)
+	if oidc := o.Authentication.GetOidc(); oidc != nil {
+		prototool.String(&oidc.UsernameClaim, defaultOidcUsernameClaim)
+		prototool.String(&oidc.GroupsClaim, defaultOidcGroupsClaim)
+	}
+	prototool.NotNil(&o.Audit)
+	prototool.Uint32(&o.Audit.QueueSize, defaultAuditQueueSize)
+	prototool.Uint32(&o.Audit.BatchSize, defaultAuditBatchSize)
+	prototool.Duration(&o.Audit.FlushInterval, defaultAuditFlushInterval)
+	prototool.Duration(&o.Audit.MaxRetryBackoff, defaultAuditMaxRetryBackoff)
+	if exec := o.Kubeconfig.GetExec(); exec != nil && len(exec.TokenTypes) == 0 {
+		exec.TokenTypes = []string{tokenTypePlural}
+	}
+	if dc := o.DiscoveryCache; dc != nil {
+		prototool.Duration(&dc.Ttl, defaultDiscoveryCacheTTL)
+		prototool.Uint64(&dc.MaxSize, defaultDiscoveryCacheMaxSize)
+	}
 }
@@ -0,0 +1,324 @@
+package server
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	httpz2 "github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+)
+
+const (
+	discoveryCacheRequestsMetricName = "k8s_api_proxy_discovery_cache_requests_total"
+	discoveryCacheResultHit          = "hit"
+	discoveryCacheResultMiss         = "miss"
+
+	// discoveryCacheMaxEntryFraction limits the size of a single cached response to a fraction of the cache size.
+	discoveryCacheMaxEntryFraction = 4
+)
+
+var (
+	// discoveryCacheHeaders are the response headers that are cached together with the response body.
+	// Must be in canonical form.
+	discoveryCacheHeaders = []string{
+		httpz2.ContentTypeHeader,
+		httpz2.ContentEncodingHeader,
+		httpz2.CacheControlHeader,
+		httpz2.EtagHeader,
+		httpz2.LastModifiedHeader,
+	}
+)
+
+type discoveryCacheKey struct {
+	agentId int64
+	// identity is a hash of the impersonated identity. Discovery responses may be filtered by RBAC
+	// so a response must not be served to a different identity.
+	identity string
+	path     string
+	query    string
+	// Discovery and OpenAPI responses depend on the requested media type and encoding.
+	accept         string
+	acceptEncoding string
+}
+
+type discoveryCacheEntry struct {
+	key               discoveryCacheKey
+	kubernetesVersion string
+	expires           time.Time
+	header            http.Header
+	body              []byte
+	etag              string
+	elem              *list.Element
+}
+
+// discoveryCache is an in-memory LRU cache of successful discovery and OpenAPI responses.
+// Responses are cached per agent and impersonated identity.
+type discoveryCache struct {
+	ttl          time.Duration
+	maxSize      int64
+	maxEntrySize int64
+	requests     *prometheus.CounterVec
+
+	// mu protects fields below
+	mu   sync.Mutex
+	data map[discoveryCacheKey]*discoveryCacheEntry
+	lru  *list.List // of *discoveryCacheEntry, most recently used first
+	size int64
+}
+
+func newDiscoveryCache(ttl time.Duration, maxSize int64) *discoveryCache {
+	return &discoveryCache{
+		ttl:          ttl,
+		maxSize:      maxSize,
+		maxEntrySize: maxSize / discoveryCacheMaxEntryFraction,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: discoveryCacheRequestsMetricName,
+			Help: "The total number of cacheable discovery and OpenAPI requests, by result",
+		}, []string{"result"}),
+		data: map[discoveryCacheKey]*discoveryCacheEntry{},
+		lru:  list.New(),
+	}
+}
+
+// get returns a cached response or nil.
+// A response that was cached for a different Kubernetes version of the cluster is dropped.
+func (c *discoveryCache) get(key discoveryCacheKey, kubernetesVersion string) *discoveryCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.data[key]
+	if e == nil {
+		return nil
+	}
+	if e.kubernetesVersion != kubernetesVersion || time.Now().After(e.expires) {
+		c.removeLocked(e)
+		return nil
+	}
+	c.lru.MoveToFront(e.elem)
+	return e
+}
+
+func (c *discoveryCache) put(e *discoveryCacheEntry) {
+	size := int64(len(e.body))
+	if size > c.maxEntrySize {
+		return
+	}
+	e.expires = time.Now().Add(c.ttl)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old := c.data[e.key]; old != nil {
+		c.removeLocked(old)
+	}
+	e.elem = c.lru.PushFront(e)
+	c.data[e.key] = e
+	c.size += size
+	for c.size > c.maxSize {
+		c.removeLocked(c.lru.Back().Value.(*discoveryCacheEntry))
+	}
+}
+
+func (c *discoveryCache) removeLocked(e *discoveryCacheEntry) {
+	c.lru.Remove(e.elem)
+	delete(c.data, e.key)
+	c.size -= int64(len(e.body))
+}
+
+// isDiscoveryRequest returns true for requests that fetch API discovery documents, OpenAPI schemas or the version.
+// path is the request path without urlPathPrefix.
+func isDiscoveryRequest(r *http.Request, path string, info *request.RequestInfo) bool {
+	if r.Method != http.MethodGet || info.IsResourceRequest || len(r.Header[httpz2.UpgradeHeader]) > 0 {
+		return false
+	}
+	switch {
+	case path == "/api", path == "/apis", path == "/version":
+		return true
+	case strings.HasPrefix(path, "/api/"), strings.HasPrefix(path, "/apis/"), strings.HasPrefix(path, "/openapi/"):
+		return true
+	default:
+		return false
+	}
+}
+
+// serveFromDiscoveryCache writes a cached response if there is one.
+// Otherwise, it may return a writer that should be used to proxy the request and that caches a successful response.
+func (p *kubernetesApiProxy) serveFromDiscoveryCache(ctx context.Context, log *zap.Logger, agentId int64,
+	impConfig *rpc.ImpersonationConfig, w http.ResponseWriter, r *http.Request, path string) (*discoveryCacheWriter, bool /* served */) {
+	kubernetesVersion, err := p.getKubernetesVersion(ctx, agentId)
+	if err != nil {
+		p.api.HandleProcessingError(ctx, log, agentId, "Failed to get Kubernetes version of the cluster", err)
+		return nil, false
+	}
+	if kubernetesVersion == "" {
+		// Don't know which version the response would be for.
+		return nil, false
+	}
+	identity, err := discoveryCacheIdentity(impConfig)
+	if err != nil {
+		p.api.HandleProcessingError(ctx, log, agentId, "Failed to hash impersonation config", err)
+		return nil, false
+	}
+	key := discoveryCacheKey{
+		agentId:        agentId,
+		identity:       identity,
+		path:           path,
+		query:          r.URL.RawQuery,
+		accept:         r.Header.Get(httpz2.AcceptHeader),
+		acceptEncoding: r.Header.Get(httpz2.AcceptEncodingHeader),
+	}
+	e := p.discoveryCache.get(key, kubernetesVersion)
+	if e == nil {
+		p.discoveryCache.requests.WithLabelValues(discoveryCacheResultMiss).Inc()
+		return &discoveryCacheWriter{
+			ResponseWriter:    w,
+			cache:             p.discoveryCache,
+			key:               key,
+			kubernetesVersion: kubernetesVersion,
+		}, false
+	}
+	p.discoveryCache.requests.WithLabelValues(discoveryCacheResultHit).Inc()
+	header := w.Header()
+	p.mergeProxiedResponseHeaders(e.header.Clone(), header)
+	header[httpz2.EtagHeader] = []string{e.etag}
+	if etagMatches(r.Header.Get(httpz2.IfNoneMatchHeader), e.etag) {
+		delete(header, httpz2.ContentTypeHeader)
+		delete(header, httpz2.ContentEncodingHeader)
+		w.WriteHeader(http.StatusNotModified)
+		return nil, true
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(e.body) // I/O errors
+	return nil, true
+}
+
+// discoveryCacheIdentity returns a hash of the impersonated identity. impConfig can be nil, which means agentk's own identity.
+func discoveryCacheIdentity(impConfig *rpc.ImpersonationConfig) (string, error) {
+	if impConfig == nil {
+		return "", nil
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(impConfig)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getKubernetesVersion returns the Kubernetes version the agent reported or an empty string if it's not known.
+func (p *kubernetesApiProxy) getKubernetesVersion(ctx context.Context, agentId int64) (string, error) {
+	var kubernetesVersion string
+	err := p.agentQuerier.GetConnectionsByAgentId(ctx, agentId, func(info *agent_tracker.ConnectedAgentInfo) (bool, error) {
+		kubernetesVersion = info.AgentMeta.GetKubernetesVersion().GetGitVersion()
+		return kubernetesVersion != "", nil
+	})
+	return kubernetesVersion, err
+}
+
+// etagMatches implements the weak comparison of If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, v := range strings.Split(ifNoneMatch, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// discoveryCacheWriter captures a proxied response to cache it.
+type discoveryCacheWriter struct {
+	http.ResponseWriter
+	cache             *discoveryCache
+	key               discoveryCacheKey
+	kubernetesVersion string
+	status            int
+	header            http.Header
+	contentLength     int64 // -1 if not known
+	body              bytes.Buffer
+	tooLarge          bool
+}
+
+func (w *discoveryCacheWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+		header := w.ResponseWriter.Header()
+		w.header = make(http.Header, len(discoveryCacheHeaders))
+		for _, name := range discoveryCacheHeaders {
+			if v := header[name]; len(v) > 0 {
+				w.header[name] = v
+			}
+		}
+		w.contentLength = -1
+		if l, err := strconv.ParseInt(header.Get(httpz2.ContentLengthHeader), 10, 64); err == nil {
+			w.contentLength = l
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *discoveryCacheWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.tooLarge {
+		if int64(w.body.Len()+len(b)) > w.cache.maxEntrySize {
+			w.tooLarge = true
+			w.body = bytes.Buffer{}
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *discoveryCacheWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *discoveryCacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// store caches the captured response if it's cacheable.
+// It must only be called once the response has been proxied completely. An aborted response panics with
+// http.ErrAbortHandler so store is not reached. The body length is checked anyway in case the response was truncated
+// elsewhere.
+func (w *discoveryCacheWriter) store() {
+	if w.status != http.StatusOK || w.tooLarge {
+		return
+	}
+	body := w.body.Bytes()
+	if w.contentLength != -1 && w.contentLength != int64(len(body)) {
+		return
+	}
+	etag := w.header.Get(httpz2.EtagHeader)
+	if etag == "" {
+		sum := sha256.Sum256(body)
+		etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+	w.cache.put(&discoveryCacheEntry{
+		key:               w.key,
+		kubernetesVersion: w.kubernetesVersion,
+		header:            w.header,
+		body:              body,
+		etag:              etag,
+	})
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/entity"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_agent_tracker"
+)
+
+func TestDiscoveryCache_Version(t *testing.T) {
+	c := newDiscoveryCache(time.Minute, 100)
+	key := discoveryCacheKey{agentId: testAgentId, path: "/api"}
+	c.put(&discoveryCacheEntry{
+		key:               key,
+		kubernetesVersion: "v1.30.1",
+		body:              []byte("body"),
+	})
+
+	require.NotNil(t, c.get(key, "v1.30.1"))
+	assert.Nil(t, c.get(key, "v1.31.0"))
+	assert.Nil(t, c.get(key, "v1.30.1")) // dropped by the previous call
+	assert.Zero(t, c.size)
+}
+
+func TestDiscoveryCache_Expiry(t *testing.T) {
+	c := newDiscoveryCache(-time.Second, 100)
+	key := discoveryCacheKey{agentId: testAgentId, path: "/api"}
+	c.put(&discoveryCacheEntry{
+		key:               key,
+		kubernetesVersion: "v1.30.1",
+		body:              []byte("body"),
+	})
+
+	assert.Nil(t, c.get(key, "v1.30.1"))
+	assert.Empty(t, c.data)
+}
+
+func TestDiscoveryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newDiscoveryCache(time.Minute, 40) // up to 10 bytes per entry
+	keys := []discoveryCacheKey{
+		{agentId: testAgentId, path: "/api"},
+		{agentId: testAgentId, path: "/apis"},
+		{agentId: testAgentId, path: "/version"},
+		{agentId: testAgentId, path: "/openapi/v2"},
+		{agentId: testAgentId, path: "/openapi/v3"},
+	}
+	for _, key := range keys[:4] {
+		c.put(&discoveryCacheEntry{
+			key:               key,
+			kubernetesVersion: "v1",
+			body:              []byte("0123456789"),
+		})
+	}
+	require.NotNil(t, c.get(keys[0], "v1")) // keys[1] is now the least recently used entry
+	c.put(&discoveryCacheEntry{
+		key:               keys[4],
+		kubernetesVersion: "v1",
+		body:              []byte("0123456789"),
+	})
+
+	assert.EqualValues(t, 40, c.size)
+	assert.Nil(t, c.get(keys[1], "v1"))
+	for _, key := range []discoveryCacheKey{keys[0], keys[2], keys[3], keys[4]} {
+		assert.NotNil(t, c.get(key, "v1"), key.path)
+	}
+}
+
+func TestDiscoveryCache_EntryTooLarge(t *testing.T) {
+	c := newDiscoveryCache(time.Minute, 40)
+	key := discoveryCacheKey{agentId: testAgentId, path: "/openapi/v2"}
+	c.put(&discoveryCacheEntry{
+		key:               key,
+		kubernetesVersion: "v1",
+		body:              []byte("01234567890"),
+	})
+
+	assert.Nil(t, c.get(key, "v1"))
+	assert.Zero(t, c.size)
+}
+
+func TestIsDiscoveryRequest(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		upgrade  bool
+		expected bool
+	}{
+		{method: http.MethodGet, path: "/api", expected: true},
+		{method: http.MethodGet, path: "/api/v1", expected: true},
+		{method: http.MethodGet, path: "/apis", expected: true},
+		{method: http.MethodGet, path: "/apis/apps", expected: true},
+		{method: http.MethodGet, path: "/apis/apps/v1", expected: true},
+		{method: http.MethodGet, path: "/openapi/v2", expected: true},
+		{method: http.MethodGet, path: "/openapi/v3/apis/apps/v1", expected: true},
+		{method: http.MethodGet, path: "/version", expected: true},
+		{method: http.MethodGet, path: "/api/v1/pods"},
+		{method: http.MethodGet, path: "/apis/apps/v1/namespaces/ns1/deployments"},
+		{method: http.MethodGet, path: "/healthz"},
+		{method: http.MethodPost, path: "/api"},
+		{method: http.MethodGet, path: "/api", upgrade: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, "/prefix"+tc.path, nil)
+			if tc.upgrade {
+				r.Header.Set(httpz.UpgradeHeader, "websocket")
+			}
+			info, err := newRequestInfo(r, tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, isDiscoveryRequest(r, tc.path, info))
+		})
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	tests := []struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		{ifNoneMatch: ""},
+		{ifNoneMatch: `"a"`, expected: true},
+		{ifNoneMatch: `W/"a"`, expected: true},
+		{ifNoneMatch: `"b", "a"`, expected: true},
+		{ifNoneMatch: "*", expected: true},
+		{ifNoneMatch: `"b"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.ifNoneMatch, func(t *testing.T) {
+			assert.Equal(t, tc.expected, etagMatches(tc.ifNoneMatch, `"a"`))
+		})
+	}
+}
+
+func TestServeFromDiscoveryCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tracker := mock_agent_tracker.NewMockTracker(ctrl)
+	tracker.EXPECT().
+		GetConnectionsByAgentId(gomock.Any(), testAgentId, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, agentId int64, cb agent_tracker.ConnectedAgentInfoCallback) error {
+			_, err := cb(&agent_tracker.ConnectedAgentInfo{
+				AgentMeta: &entity.AgentMeta{
+					KubernetesVersion: &entity.KubernetesVersion{
+						GitVersion: "v1.30.1",
+					},
+				},
+			})
+			return err
+		}).
+		Times(4)
+	p := &kubernetesApiProxy{
+		agentQuerier:   tracker,
+		discoveryCache: newDiscoveryCache(time.Minute, 1024),
+		serverVia:      "gRPC/1.0 sv1",
+	}
+	log := zaptest.NewLogger(t)
+	newRequest := func(ifNoneMatch string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/prefix/apis", nil)
+		r.Header.Set(httpz.AcceptHeader, "application/json")
+		if ifNoneMatch != "" {
+			r.Header.Set(httpz.IfNoneMatchHeader, ifNoneMatch)
+		}
+		return r
+	}
+
+	// Miss. Response is captured while it's proxied.
+	w := httptest.NewRecorder()
+	dw, served := p.serveFromDiscoveryCache(context.Background(), log, testAgentId, nil, w, newRequest(""), "/apis")
+	require.False(t, served)
+	require.NotNil(t, dw)
+	dw.Header().Set(httpz.ContentTypeHeader, "application/json")
+	dw.Header().Set(httpz.AccessControlAllowOriginHeader, "kas.example.com")
+	_, err := dw.Write([]byte(`{"kind":"APIGroupList"}`))
+	require.NoError(t, err)
+	dw.store()
+
+	// Hit.
+	w = httptest.NewRecorder()
+	dw, served = p.serveFromDiscoveryCache(context.Background(), log, testAgentId, nil, w, newRequest(""), "/apis")
+	require.True(t, served)
+	assert.Nil(t, dw)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"kind":"APIGroupList"}`, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get(httpz.ContentTypeHeader))
+	assert.Empty(t, w.Header().Get(httpz.AccessControlAllowOriginHeader))
+	assert.Equal(t, []string{"gRPC/1.0 sv1"}, w.Header()[httpz.ViaHeader])
+	etag := w.Header().Get(httpz.EtagHeader)
+	assert.True(t, strings.HasPrefix(etag, `"`), etag)
+
+	// Not modified.
+	w = httptest.NewRecorder()
+	_, served = p.serveFromDiscoveryCache(context.Background(), log, testAgentId, nil, w, newRequest(etag), "/apis")
+	require.True(t, served)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get(httpz.EtagHeader))
+
+	// Miss for a different identity.
+	w = httptest.NewRecorder()
+	impConfig := &rpc.ImpersonationConfig{Username: "oidc:user1"}
+	dw, served = p.serveFromDiscoveryCache(context.Background(), log, testAgentId, impConfig, w, newRequest(""), "/apis")
+	require.False(t, served)
+	assert.NotNil(t, dw)
+
+	assert.EqualValues(t, 2, testutil.ToFloat64(p.discoveryCache.requests.WithLabelValues(discoveryCacheResultHit)))
+	assert.EqualValues(t, 2, testutil.ToFloat64(p.discoveryCache.requests.WithLabelValues(discoveryCacheResultMiss)))
+}
+
+func TestDiscoveryCacheWriter_DoesNotStoreErrors(t *testing.T) {
+	c := newDiscoveryCache(time.Minute, 1024)
+	key := discoveryCacheKey{agentId: testAgentId, path: "/apis"}
+	w := &discoveryCacheWriter{
+		ResponseWriter:    httptest.NewRecorder(),
+		cache:             c,
+		key:               key,
+		kubernetesVersion: "v1",
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, err := w.Write([]byte("unavailable"))
+	require.NoError(t, err)
+	w.store()
+
+	assert.Nil(t, c.get(key, "v1"))
+}
+
+func TestDiscoveryCacheWriter_DoesNotStoreTruncatedResponse(t *testing.T) {
+	c := newDiscoveryCache(time.Minute, 1024)
+	key := discoveryCacheKey{agentId: testAgentId, path: "/apis"}
+	rec := httptest.NewRecorder()
+	w := &discoveryCacheWriter{
+		ResponseWriter:    rec,
+		cache:             c,
+		key:               key,
+		kubernetesVersion: "v1",
+	}
+	rec.Header().Set(httpz.ContentLengthHeader, "100")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write([]byte(`{"kind":`))
+	require.NoError(t, err)
+	w.store()
+
+	assert.Nil(t, c.get(key, "v1"))
+}
+
+func TestDiscoveryCacheIdentity(t *testing.T) {
+	id, err := discoveryCacheIdentity(nil)
+	require.NoError(t, err)
+	assert.Empty(t, id)
+
+	id1, err := discoveryCacheIdentity(&rpc.ImpersonationConfig{Username: "user1", Groups: []string{"g1"}})
+	require.NoError(t, err)
+	id2, err := discoveryCacheIdentity(&rpc.ImpersonationConfig{Username: "user1", Groups: []string{"g2"}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, id1)
+	assert.NotEqual(t, id1, id2)
+}
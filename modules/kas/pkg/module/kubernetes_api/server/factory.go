@@ -4,15 +4,23 @@ import (
 	"crypto/sha256"
 	"crypto/tls"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
+	"strconv"
 
+	"github.com/pluralsh/kubernetes-agent/pkg/audit"
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	serviceproxyrpc "github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpc"
+	tcpforwardrpc "github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/plural/api"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/metric"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/prototool"
 	redistool2 "github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/tlstool"
@@ -39,6 +47,7 @@ const (
 )
 
 type Factory struct {
+	AgentQuerier agent_tracker.Querier
 }
 
 func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
@@ -55,6 +64,17 @@ func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
 	if err != nil {
 		return nil, err
 	}
+	if certAuthn := k8sApi.Authentication.GetClientCertificate(); certAuthn != nil {
+		if tlsConfig == nil {
+			return nil, errors.New("client certificate authentication requires TLS to be enabled on the Kubernetes API listener")
+		}
+		tlsConfig.ClientCAs, err = tlstool.LoadCertPool(certAuthn.CaCertificateFile)
+		if err != nil {
+			return nil, err
+		}
+		// Other authentication methods don't need a client certificate.
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
 	if tlsConfig != nil {
 		listener = func() (net.Listener, error) {
 			return tls.Listen(*listenCfg.Network, listenCfg.Address, tlsConfig)
@@ -69,46 +89,114 @@ func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
 	allowedAgentCacheTtl := k8sApi.AllowedAgentCacheTtl.AsDuration()
 	allowedAgentCacheErrorTtl := k8sApi.AllowedAgentCacheErrorTtl.AsDuration()
 	tracer := config.TraceProvider.Tracer(kubernetes_api.ModuleName)
-	m := &module{
-		log: config.Log,
-		proxy: kubernetesApiProxy{
-			log:                 config.Log,
-			api:                 config.Api,
-			kubernetesApiClient: rpc.NewKubernetesApiClient(config.AgentConn),
-			pluralUrl:           config.Config.PluralUrl,
-			allowedOriginUrls:   allowedOriginUrls,
-			allowedAgentsCache: cache.NewWithError[string, *api.AllowedAgentsForJob](
-				allowedAgentCacheTtl,
-				allowedAgentCacheErrorTtl,
-				&redistool2.ErrCacher[string]{
-					Log:          config.Log,
-					ErrRep:       modshared.ApiToErrReporter(config.Api),
-					Client:       config.RedisClient,
-					ErrMarshaler: prototool.ProtoErrMarshaler{},
-					KeyToRedisKey: func(jobToken string) string {
-						// Hash half of the token. Even if that hash leaks, it's not a big deal.
-						// We do the same in api.AgentToken2key().
-						n := len(jobToken) / 2
-						tokenHash := sha256.Sum256([]byte(jobToken[:n]))
-						return config.Config.Redis.KeyPrefix + ":allowed_agents_errs:" + string(tokenHash[:])
-					},
-				},
-				tracer,
-				nil,
+	authenticators, err := newAuthenticators(k8sApi.Authentication)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := newRequestPolicies(k8sApi.Policies)
+	if err != nil {
+		return nil, err
+	}
+	auditPipeline, err := newAuditPipeline(config, k8sApi.Audit)
+	if err != nil {
+		return nil, err
+	}
+	userRateLimiter, err := newRateLimiter(config, "k8s_api_proxy_user", k8sApi.Limits.GetRequestsPerUserPerMinute(),
+		userRateExceededMetricName, "The total number of times configured rate limit of proxied requests per user was exceeded",
+		userRateLimitKey)
+	if err != nil {
+		return nil, err
+	}
+	clusterRateLimiter, err := newRateLimiter(config, "k8s_api_proxy_cluster", k8sApi.Limits.GetRequestsPerClusterPerMinute(),
+		clusterRateExceededMetricName, "The total number of times configured rate limit of proxied requests per cluster was exceeded",
+		func(req *rateLimitRequest) []byte {
+			return strconv.AppendInt(nil, req.agentId, 10)
+		})
+	if err != nil {
+		return nil, err
+	}
+	var discoveryRespCache *discoveryCache
+	if dc := k8sApi.DiscoveryCache; dc != nil {
+		discoveryRespCache = newDiscoveryCache(dc.Ttl.AsDuration(), int64(dc.MaxSize))
+		err = metric.Register(config.Registerer, discoveryRespCache.requests)
+		if err != nil {
+			return nil, err
+		}
+	}
+	recorder, err := newSessionRecorder(k8sApi.SessionRecording)
+	if err != nil {
+		return nil, err
+	}
+	authenticators[tokenTypePlural] = &pluralAuthenticator{
+		api:       config.Api,
+		pluralUrl: config.Config.PluralUrl,
+		userCache: cache.NewWithError[string, *api.User](
+			allowedAgentCacheTtl,
+			allowedAgentCacheErrorTtl,
+			redistool2.NewErrCacher(
+				config.Storage,
+				config.Log,
+				modshared.ApiToErrReporter(config.Api),
+				prototool.ProtoErrMarshaler{},
+				getTokenCacheKey(config.Config.Redis.KeyPrefix+":plural_user_errs:"),
 			),
-			authorizeProxyUserCache: cache.NewWithError[proxyUserCacheKey, *api.AuthorizeProxyUserResponse](
+			tracer,
+			isCacheableConsoleError,
+		),
+		authorizeProxyUserCache: cache.NewWithError[proxyUserCacheKey, *api.AuthorizeProxyUserResponse](
+			allowedAgentCacheTtl,
+			allowedAgentCacheErrorTtl,
+			redistool2.NewErrCacher(
+				config.Storage,
+				config.Log,
+				modshared.ApiToErrReporter(config.Api),
+				prototool.ProtoErrMarshaler{},
+				getAuthorizedProxyUserCacheKey(config.Config.Redis.KeyPrefix),
+			),
+			tracer,
+			nil,
+		),
+	}
+	if k8sApi.Authentication.GetCiJob() != nil {
+		authenticators[tokenTypeCi] = &ciJobAuthenticator{
+			api:       config.Api,
+			pluralUrl: config.Config.PluralUrl,
+			allowedAgentsCache: cache.NewWithError[string, *api.AllowedAgentsForJob](
 				allowedAgentCacheTtl,
 				allowedAgentCacheErrorTtl,
-				&redistool2.ErrCacher[proxyUserCacheKey]{
-					Log:           config.Log,
-					ErrRep:        modshared.ApiToErrReporter(config.Api),
-					Client:        config.RedisClient,
-					ErrMarshaler:  prototool.ProtoErrMarshaler{},
-					KeyToRedisKey: getAuthorizedProxyUserCacheKey(config.Config.Redis.KeyPrefix),
-				},
+				redistool2.NewErrCacher(
+					config.Storage,
+					config.Log,
+					modshared.ApiToErrReporter(config.Api),
+					prototool.ProtoErrMarshaler{},
+					getTokenCacheKey(config.Config.Redis.KeyPrefix+":allowed_agents_errs:"),
+				),
 				tracer,
-				nil,
+				isCacheableConsoleError,
 			),
+		}
+	}
+	m := &module{
+		log: config.Log,
+		proxy: kubernetesApiProxy{
+			log:                      config.Log,
+			api:                      config.Api,
+			kubernetesApiClient:      rpc.NewKubernetesApiClient(config.AgentConn),
+			serviceProxyClient:       serviceproxyrpc.NewServiceProxyClient(config.AgentConn),
+			tcpForwardClient:         tcpforwardrpc.NewTcpForwardClient(config.AgentConn),
+			pluralUrl:                config.Config.PluralUrl,
+			allowedOriginUrls:        allowedOriginUrls,
+			authenticators:           authenticators,
+			policies:                 policies,
+			audit:                    auditPipeline,
+			userRateLimiter:          userRateLimiter,
+			clusterRateLimiter:       clusterRateLimiter,
+			maxRequestBodySize:       int64(k8sApi.Limits.GetMaxRequestBodySize()),
+			agentQuerier:             f.AgentQuerier,
+			kubeconfigServerUrl:      k8sApi.Kubeconfig.GetServerUrl(),
+			kubeconfigExec:           k8sApi.Kubeconfig.GetExec(),
+			discoveryCache:           discoveryRespCache,
+			sessionRecorder:          recorder,
 			requestCounter:           config.UsageTracker.RegisterCounter(k8sApiRequestCountKnownMetric),
 			ciTunnelUsersCounter:     config.UsageTracker.RegisterUniqueCounter(usersCiTunnelInteractionsCountMetric),
 			ciAccessRequestCounter:   config.UsageTracker.RegisterCounter(k8sApiProxyRequestsViaCiAccessMetricName),
@@ -131,8 +219,11 @@ func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
 			shutdownGracePeriod:      listenCfg.ShutdownGracePeriod.AsDuration(),
 		},
 		listener: listener,
+		audit:    auditPipeline,
 	}
 	config.RegisterAgentApi(&rpc.KubernetesApi_ServiceDesc)
+	config.RegisterAgentApi(&serviceproxyrpc.ServiceProxy_ServiceDesc)
+	config.RegisterAgentApi(&tcpforwardrpc.TcpForward_ServiceDesc)
 	return m, nil
 }
 
@@ -146,6 +237,65 @@ func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
 	return modshared.ModuleStartAfterServers
 }
 
+// newAuthenticators constructs authenticators for the optional authentication methods enabled in the configuration.
+func newAuthenticators(cfg *kascfg.KubernetesApiAuthenticationCF) (map[string]authenticator, error) {
+	authenticators := map[string]authenticator{}
+	if oidc := cfg.GetOidc(); oidc != nil {
+		a, err := newOidcAuthenticator(oidc)
+		if err != nil {
+			return nil, err
+		}
+		authenticators[tokenTypeOidc] = a
+	}
+	if static := cfg.GetStaticToken(); static != nil {
+		a, err := newStaticTokenAuthenticator(static)
+		if err != nil {
+			return nil, err
+		}
+		authenticators[tokenTypeStatic] = a
+	}
+	if cert := cfg.GetClientCertificate(); cert != nil {
+		authenticators[authnTypeClientCertificate] = &clientCertificateAuthenticator{
+			clusters: newClusterAllowList(cert.ClusterIds),
+		}
+	}
+	return authenticators, nil
+}
+
+func newAuditPipeline(config *modserver.Config, cfg *kascfg.KubernetesApiAuditCF) (*audit.Pipeline, error) {
+	sinks := []audit.Sink{
+		audit.NewConsoleSink(config.Config.PluralUrl),
+	}
+	if file := cfg.File; file != nil {
+		sink, err := audit.NewFileSink(file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("audit file: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	p := audit.NewPipeline(config.Log, audit.PipelineConfig{
+		QueueSize:       int(cfg.QueueSize),
+		BatchSize:       int(cfg.BatchSize),
+		FlushInterval:   cfg.FlushInterval.AsDuration(),
+		MaxRetryBackoff: cfg.MaxRetryBackoff.AsDuration(),
+	}, sinks...)
+	err := metric.Register(config.Registerer, p.Collectors()...)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func getTokenCacheKey(keyPrefix string) redistool2.KeyToRedisKey[string] {
+	return func(token string) string {
+		// Hash half of the token. Even if that hash leaks, it's not a big deal.
+		// We do the same in api.AgentToken2key().
+		n := len(token) / 2
+		tokenHash := sha256.Sum256([]byte(token[:n]))
+		return keyPrefix + string(tokenHash[:])
+	}
+}
+
 func getAuthorizedProxyUserCacheKey(redisKeyPrefix string) redistool2.KeyToRedisKey[proxyUserCacheKey] {
 	return func(key proxyUserCacheKey) string {
 		// Hash half of the token. Even if that hash leaks, it's not a big deal.
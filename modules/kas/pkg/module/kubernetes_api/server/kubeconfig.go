@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	httpz2 "github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+const (
+	// kubeconfigPath is relative to urlPathPrefix. Kubernetes API paths never start with "-".
+	kubeconfigPath = "-/kubeconfig"
+
+	kubeconfigClusterIdQueryParam = "cluster_id"
+	kubeconfigExecQueryParam      = "exec"
+	kubeconfigClusterIdEnv        = "PLURAL_CLUSTER_ID"
+	kubeconfigTokenTypeEnv        = "PLURAL_TOKEN_TYPE"
+	kubeconfigExecApiVersion      = "client.authentication.k8s.io/v1"
+	kubeconfigContentType         = "application/yaml"
+
+	// kubeconfigMaxClusters is the maximum number of connected clusters a kubeconfig request checks access to.
+	// Each check may be a round trip to Plural Console and counts towards the user's rate limit.
+	kubeconfigMaxClusters = 100
+)
+
+// kubeconfig responds with a kubeconfig that has a context for each connected cluster the caller can access.
+// The caller authenticates with a `Bearer <token type>:<token>` header, i.e. the usual proxy token without the cluster id.
+func (p *kubernetesApiProxy) kubeconfig(w http.ResponseWriter, r *http.Request) (*zap.Logger, *grpctool.ErrResp) {
+	ctx := r.Context()
+	log := p.log.With(logz.TraceIdFromContext(ctx))
+
+	if r.Method != http.MethodGet {
+		return log, &grpctool.ErrResp{
+			StatusCode: http.StatusMethodNotAllowed,
+			Msg:        "Method not allowed",
+		}
+	}
+	query := r.URL.Query()
+	useExec := query.Get(kubeconfigExecQueryParam) == "true"
+	if useExec && p.kubeconfigExec == nil {
+		return log, &grpctool.ErrResp{
+			StatusCode: http.StatusBadRequest,
+			Msg:        "Bad request: exec credential plugin is not configured",
+		}
+	}
+	if len(r.Header[httpz2.AuthorizationHeader]) == 0 && r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		// A kubeconfig can only carry a token. A certificate must be configured in the client by the user.
+		return log, &grpctool.ErrResp{
+			StatusCode: http.StatusBadRequest,
+			Msg:        "Bad request: client certificates cannot be used to get a kubeconfig",
+		}
+	}
+	creds, err := getKubeconfigCredentialsFromRequest(r)
+	if err != nil {
+		return log, unauthorizedErrResp(log, err)
+	}
+	if useExec && !slices.Contains(p.kubeconfigExec.TokenTypes, creds.authnType) {
+		return log, &grpctool.ErrResp{
+			StatusCode: http.StatusBadRequest,
+			Msg:        fmt.Sprintf("Bad request: exec credential plugin does not support %s tokens", creds.authnType),
+		}
+	}
+	authn, ok := p.authenticators[creds.authnType]
+	if !ok {
+		return log, unauthorizedErrResp(log, fmt.Errorf("%s authentication is not enabled", creds.authnType))
+	}
+	// Authenticate once rather than for every connected cluster, so that invalid credentials cost a single check.
+	user, eResp := authn.authenticate(ctx, log, modshared.NoAgentId, r, creds)
+	if eResp != nil {
+		return log, eResp
+	}
+	eResp = p.checkUserRateLimit(ctx, log, w, modshared.NoAgentId, creds.authnType, user.name())
+	if eResp != nil {
+		return log, eResp
+	}
+	clusters, eResp := p.accessibleClusters(ctx, log, w, creds.authnType, user, query.Get(kubeconfigClusterIdQueryParam))
+	if eResp != nil {
+		return log, eResp
+	}
+	data, err := clientcmd.Write(*p.buildKubeconfig(r, creds, clusters, useExec))
+	if err != nil {
+		msg := "Failed to encode kubeconfig"
+		p.api.HandleProcessingError(ctx, log, modshared.NoAgentId, msg, err)
+		return log, &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+			Err:        err,
+		}
+	}
+	header := w.Header()
+	header[httpz2.ContentTypeHeader] = []string{kubeconfigContentType}
+	header[httpz2.CacheControlHeader] = []string{"no-store"} // may contain the token
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data) // I/O errors
+	return log, nil
+}
+
+// kubeconfigCluster is a connected cluster that the credentials can access.
+type kubeconfigCluster struct {
+	id string
+	// namespace is the default namespace of the context. Empty if the credentials don't come with one.
+	namespace string
+}
+
+// accessibleClusters returns connected clusters that the user can access, sorted by id.
+// If onlyClusterId is not empty, only that cluster is considered.
+// Checking access to a cluster counts towards the user's rate limit, like a request to the cluster would.
+func (p *kubernetesApiProxy) accessibleClusters(ctx context.Context, log *zap.Logger, w http.ResponseWriter, authnType string,
+	user authenticatedUser, onlyClusterId string) ([]kubeconfigCluster, *grpctool.ErrResp) {
+	var (
+		clusters     []kubeconfigCluster
+		unauthorized *grpctool.ErrResp
+		eResp        *grpctool.ErrResp
+		checked      int
+	)
+	err := p.agentQuerier.GetConnectedAgents(ctx, func(agentId int64, clusterId string) (bool, error) {
+		if onlyClusterId != "" && clusterId != onlyClusterId {
+			return false, nil
+		}
+		if checked == kubeconfigMaxClusters {
+			eResp = &grpctool.ErrResp{
+				StatusCode: http.StatusBadRequest,
+				Msg: fmt.Sprintf("Bad request: more than %d connected clusters, use the %s query parameter to select one",
+					kubeconfigMaxClusters, kubeconfigClusterIdQueryParam),
+			}
+			return true, nil
+		}
+		checked++
+		agentLog := log.With(logz.AgentId(agentId))
+		eResp = p.checkUserRateLimit(ctx, agentLog, w, agentId, authnType, user.name())
+		if eResp != nil {
+			return true, nil
+		}
+		auth, authnErr := user.authorize(ctx, agentLog, agentId, clusterId)
+		switch {
+		case authnErr == nil:
+			clusters = append(clusters, kubeconfigCluster{
+				id:        clusterId,
+				namespace: auth.defaultNamespace,
+			})
+		case authnErr.StatusCode == http.StatusUnauthorized:
+			// No access to this cluster.
+			unauthorized = authnErr
+		default:
+			eResp = authnErr
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		msg := "Failed to list connected clusters"
+		p.api.HandleProcessingError(ctx, log, modshared.NoAgentId, msg, err)
+		return nil, &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+			Err:        err,
+		}
+	}
+	if eResp != nil {
+		return nil, eResp
+	}
+	if len(clusters) == 0 {
+		if unauthorized != nil {
+			// Credentials are not valid for any of the clusters.
+			return nil, unauthorized
+		}
+		if onlyClusterId != "" {
+			return nil, &grpctool.ErrResp{
+				StatusCode: http.StatusNotFound,
+				Msg:        "Not found: cluster is not connected",
+			}
+		}
+	}
+	slices.SortFunc(clusters, func(a, b kubeconfigCluster) int {
+		return strings.Compare(a.id, b.id)
+	})
+	return clusters, nil
+}
+
+func (p *kubernetesApiProxy) buildKubeconfig(r *http.Request, creds credentials, clusters []kubeconfigCluster, useExec bool) *clientcmdapi.Config {
+	server := p.kubeconfigServerUrl
+	if server == "" {
+		server = p.serverUrlFromRequest(r)
+	}
+	cfg := clientcmdapi.NewConfig()
+	for _, cluster := range clusters {
+		clusterId := cluster.id
+		authInfo := &clientcmdapi.AuthInfo{}
+		if useExec {
+			authInfo.Exec = &clientcmdapi.ExecConfig{
+				APIVersion: kubeconfigExecApiVersion,
+				Command:    p.kubeconfigExec.Command,
+				Args:       p.kubeconfigExec.Args,
+				Env: []clientcmdapi.ExecEnvVar{
+					{
+						Name:  kubeconfigClusterIdEnv,
+						Value: clusterId,
+					},
+					{
+						Name:  kubeconfigTokenTypeEnv,
+						Value: creds.authnType,
+					},
+				},
+				InstallHint:     p.kubeconfigExec.InstallHint,
+				InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+			}
+		} else {
+			authInfo.Token = strings.Join([]string{creds.authnType, clusterId, creds.token}, tokenSeparator)
+		}
+		cfg.Clusters[clusterId] = &clientcmdapi.Cluster{
+			Server: server,
+		}
+		cfg.AuthInfos[clusterId] = authInfo
+		cfg.Contexts[clusterId] = &clientcmdapi.Context{
+			Cluster:   clusterId,
+			AuthInfo:  clusterId,
+			Namespace: cluster.namespace,
+		}
+	}
+	if len(clusters) == 1 {
+		cfg.CurrentContext = clusters[0].id
+	}
+	return cfg
+}
+
+// serverUrlFromRequest returns the URL of the proxy as it was reached by the client.
+func (p *kubernetesApiProxy) serverUrlFromRequest(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	// urlPathPrefix is guaranteed to end with / by defaulting. Drop it, client-go adds request paths to the server URL.
+	return scheme + "://" + r.Host + strings.TrimSuffix(p.urlPathPrefix, "/")
+}
+
+func getKubeconfigCredentialsFromRequest(r *http.Request) (credentials, error) {
+	authzHeader := r.Header[httpz2.AuthorizationHeader]
+	if len(authzHeader) != 1 {
+		return credentials{}, fmt.Errorf("%s header: expecting a single header, got %d", httpz2.AuthorizationHeader, len(authzHeader))
+	}
+	tokenType, token, err := getTokenFromHeader(authzHeader[0])
+	if err != nil {
+		return credentials{}, err
+	}
+	if token == "" {
+		return credentials{}, fmt.Errorf("%s header: empty token", httpz2.AuthorizationHeader)
+	}
+	return credentials{
+		authnType: tokenType,
+		token:     token,
+	}, nil
+}
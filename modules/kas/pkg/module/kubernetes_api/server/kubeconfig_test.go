@@ -0,0 +1,338 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	pluralapi "github.com/pluralsh/kubernetes-agent/pkg/plural/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_agent_tracker"
+)
+
+func TestKubeconfig_Token(t *testing.T) {
+	p := setupKubeconfigProxy(t, map[string]int32{
+		"c2": 0,
+		"c1": 0,
+		"c3": http.StatusUnauthorized,
+	})
+	r := newKubeconfigRequest("/prefix/-/kubeconfig", "Bearer static:tok")
+
+	cfg := requireKubeconfig(t, p, r)
+
+	assert.Len(t, cfg.Contexts, 2)
+	assert.Empty(t, cfg.CurrentContext)
+	for _, clusterId := range []string{"c1", "c2"} {
+		assert.Equal(t, "http://kas.example.com/prefix", cfg.Clusters[clusterId].Server)
+		assert.Equal(t, "static:"+clusterId+":tok", cfg.AuthInfos[clusterId].Token)
+		assert.Equal(t, clusterId, cfg.Contexts[clusterId].Cluster)
+		assert.Equal(t, clusterId, cfg.Contexts[clusterId].AuthInfo)
+	}
+}
+
+func TestKubeconfig_Exec(t *testing.T) {
+	p := setupKubeconfigProxy(t, map[string]int32{
+		"c1": 0,
+		"c2": 0,
+	})
+	p.kubeconfigServerUrl = "https://kas.example.com/k8s/"
+	p.kubeconfigExec = &kascfg.KubernetesApiKubeconfigExecCF{
+		Command:     "cred",
+		Args:        []string{"a1"},
+		InstallHint: "install cred",
+		TokenTypes:  []string{tokenTypePlural, tokenTypeStatic},
+	}
+	r := newKubeconfigRequest("/prefix/-/kubeconfig?exec=true&cluster_id=c2", "Bearer static:tok")
+
+	cfg := requireKubeconfig(t, p, r)
+
+	assert.Len(t, cfg.Contexts, 1)
+	assert.Equal(t, "c2", cfg.CurrentContext)
+	assert.Equal(t, "https://kas.example.com/k8s/", cfg.Clusters["c2"].Server)
+	authInfo := cfg.AuthInfos["c2"]
+	assert.Empty(t, authInfo.Token)
+	require.NotNil(t, authInfo.Exec)
+	assert.Equal(t, "client.authentication.k8s.io/v1", authInfo.Exec.APIVersion)
+	assert.Equal(t, "cred", authInfo.Exec.Command)
+	assert.Equal(t, []string{"a1"}, authInfo.Exec.Args)
+	assert.Equal(t, []clientcmdapi.ExecEnvVar{
+		{Name: "PLURAL_CLUSTER_ID", Value: "c2"},
+		{Name: "PLURAL_TOKEN_TYPE", Value: "static"},
+	}, authInfo.Exec.Env)
+	assert.Equal(t, "install cred", authInfo.Exec.InstallHint)
+}
+
+func TestKubeconfig_CiJobDefaultNamespace(t *testing.T) {
+	p := setupKubeconfigProxy(t, map[string]int32{
+		testClusterId: 0, // agent id 1
+	})
+	aa := testAllowedAgentsForJob(t, &pluralapi.Configuration{
+		DefaultNamespace: "ns1",
+	})
+	aa.AllowedAgents[0].Id = 1
+	p.authenticators[tokenTypeCi] = newTestCiJobAuthenticator(t, fakeAllowedAgentsConsole(t, http.StatusOK, aa), nil)
+	r := newKubeconfigRequest("/prefix/-/kubeconfig", "Bearer ci:"+testJobToken)
+
+	cfg := requireKubeconfig(t, p, r)
+
+	assert.Equal(t, testClusterId, cfg.CurrentContext)
+	assert.Equal(t, "ns1", cfg.Contexts[testClusterId].Namespace)
+	assert.Equal(t, "ci:"+testClusterId+":"+testJobToken, cfg.AuthInfos[testClusterId].Token)
+}
+
+func TestKubeconfig_InvalidTokenIsAuthenticatedOnce(t *testing.T) {
+	p := setupKubeconfigProxy(t, map[string]int32{
+		"c1": 0,
+		"c2": 0,
+		"c3": 0,
+	})
+	w := httptest.NewRecorder()
+	_, eResp := p.kubeconfig(w, newKubeconfigRequest("/prefix/-/kubeconfig", "Bearer static:invalid"))
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusUnauthorized, eResp.StatusCode)
+	assert.Equal(t, 1, p.authenticators[tokenTypeStatic].(*testKubeconfigAuthenticator).authentications)
+}
+
+func TestKubeconfig_UserRateLimit(t *testing.T) {
+	p := setupKubeconfigProxy(t, map[string]int32{
+		"c1": 0,
+	})
+	p.userRateLimiter = windowLimiterFunc(func(ctx context.Context) bool {
+		req := ctx.Value(rateLimitRequestKey{}).(*rateLimitRequest)
+		assert.Equal(t, "static:user1", string(userRateLimitKey(req)))
+		return false
+	})
+	w := httptest.NewRecorder()
+	_, eResp := p.kubeconfig(w, newKubeconfigRequest("/prefix/-/kubeconfig", "Bearer static:tok"))
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusTooManyRequests, eResp.StatusCode)
+	assert.NotEmpty(t, w.Header().Get(httpz.RetryAfterHeader))
+}
+
+func TestKubeconfig_UserRateLimitIsChargedPerCluster(t *testing.T) {
+	p := setupKubeconfigProxy(t, map[string]int32{
+		"c1": 0,
+		"c2": 0,
+		"c3": 0,
+	})
+	allowed := 3 // the request itself and two clusters
+	p.userRateLimiter = windowLimiterFunc(func(ctx context.Context) bool {
+		allowed--
+		return allowed >= 0
+	})
+	w := httptest.NewRecorder()
+	_, eResp := p.kubeconfig(w, newKubeconfigRequest("/prefix/-/kubeconfig", "Bearer static:tok"))
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusTooManyRequests, eResp.StatusCode)
+	assert.Equal(t, 2, p.authenticators[tokenTypeStatic].(*testKubeconfigAuthenticator).authorizations)
+}
+
+func TestKubeconfig_TooManyClusters(t *testing.T) {
+	authnResults := make(map[string]int32, kubeconfigMaxClusters+1)
+	for i := range kubeconfigMaxClusters + 1 {
+		authnResults["c"+strconv.Itoa(i)] = 0
+	}
+	p := setupKubeconfigProxy(t, authnResults)
+	w := httptest.NewRecorder()
+	_, eResp := p.kubeconfig(w, newKubeconfigRequest("/prefix/-/kubeconfig", "Bearer static:tok"))
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusBadRequest, eResp.StatusCode)
+	assert.Equal(t, kubeconfigMaxClusters, p.authenticators[tokenTypeStatic].(*testKubeconfigAuthenticator).authorizations)
+
+	// A single cluster can still be requested.
+	cfg := requireKubeconfig(t, p, newKubeconfigRequest("/prefix/-/kubeconfig?cluster_id=c1", "Bearer static:tok"))
+	assert.Len(t, cfg.Contexts, 1)
+}
+
+func TestKubeconfig_ClientCertificate(t *testing.T) {
+	p := setupKubeconfigProxy(t, map[string]int32{
+		"c1": 0,
+	})
+	r := httptest.NewRequest(http.MethodGet, "https://kas.example.com/prefix/-/kubeconfig", nil)
+	r.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{}},
+	}
+	w := httptest.NewRecorder()
+	_, eResp := p.kubeconfig(w, r)
+	require.NotNil(t, eResp)
+	assert.EqualValues(t, http.StatusBadRequest, eResp.StatusCode)
+	assert.Equal(t, "Bad request: client certificates cannot be used to get a kubeconfig", eResp.Msg)
+}
+
+func TestKubeconfig_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		auth         string
+		authnResults map[string]int32
+		exec         *kascfg.KubernetesApiKubeconfigExecCF
+		expectedCode int32
+	}{
+		{
+			name:         "exec not configured",
+			url:          "/prefix/-/kubeconfig?exec=true",
+			auth:         "Bearer static:tok",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "exec does not support token type",
+			url:          "/prefix/-/kubeconfig?exec=true",
+			auth:         "Bearer static:tok",
+			exec:         &kascfg.KubernetesApiKubeconfigExecCF{Command: "cred", TokenTypes: []string{tokenTypePlural}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown token type",
+			url:          "/prefix/-/kubeconfig",
+			auth:         "Bearer bla:c1:tok",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "authentication not enabled",
+			url:          "/prefix/-/kubeconfig",
+			auth:         "Bearer oidc:tok",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "no access to any cluster",
+			url:  "/prefix/-/kubeconfig",
+			auth: "Bearer static:tok",
+			authnResults: map[string]int32{
+				"c1": http.StatusUnauthorized,
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "cluster not connected",
+			url:  "/prefix/-/kubeconfig?cluster_id=c2",
+			auth: "Bearer static:tok",
+			authnResults: map[string]int32{
+				"c1": 0,
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "authentication error",
+			url:  "/prefix/-/kubeconfig",
+			auth: "Bearer static:tok",
+			authnResults: map[string]int32{
+				"c1": http.StatusInternalServerError,
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := setupKubeconfigProxy(t, tc.authnResults)
+			p.kubeconfigExec = tc.exec
+			w := httptest.NewRecorder()
+			_, eResp := p.kubeconfig(w, newKubeconfigRequest(tc.url, tc.auth))
+			require.NotNil(t, eResp)
+			assert.Equal(t, tc.expectedCode, eResp.StatusCode)
+		})
+	}
+}
+
+// setupKubeconfigProxy returns a proxy with connected clusters that are authenticated with the given status codes.
+// Zero means the cluster can be accessed.
+func setupKubeconfigProxy(t *testing.T, authnResults map[string]int32) *kubernetesApiProxy {
+	ctrl := gomock.NewController(t)
+	tracker := mock_agent_tracker.NewMockTracker(ctrl)
+	tracker.EXPECT().
+		GetConnectedAgents(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, cb agent_tracker.ConnectedAgentCallback) error {
+			var agentId int64
+			for clusterId := range authnResults {
+				agentId++
+				done, err := cb(agentId, clusterId)
+				if err != nil || done {
+					return err
+				}
+			}
+			return nil
+		}).
+		AnyTimes()
+	return &kubernetesApiProxy{
+		log:          zaptest.NewLogger(t),
+		agentQuerier: tracker,
+		authenticators: map[string]authenticator{
+			tokenTypeStatic: &testKubeconfigAuthenticator{
+				t:            t,
+				authnResults: authnResults,
+			},
+		},
+		urlPathPrefix: "/prefix/",
+	}
+}
+
+func newKubeconfigRequest(url, auth string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "http://kas.example.com"+url, nil)
+	r.Header.Set(httpz.AuthorizationHeader, auth)
+	return r
+}
+
+func requireKubeconfig(t *testing.T, p *kubernetesApiProxy, r *http.Request) *clientcmdapi.Config {
+	w := httptest.NewRecorder()
+	_, eResp := p.kubeconfig(w, r)
+	require.Nil(t, eResp)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get(httpz.ContentTypeHeader))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	cfg, err := clientcmd.Load(w.Body.Bytes())
+	require.NoError(t, err)
+	return cfg
+}
+
+// testKubeconfigAuthenticator authenticates the "tok" token. The user can access clusters with a zero authnResults code.
+type testKubeconfigAuthenticator struct {
+	t               *testing.T
+	authnResults    map[string]int32
+	authentications int
+	authorizations  int
+}
+
+func (a *testKubeconfigAuthenticator) authenticate(ctx context.Context, log *zap.Logger, agentId int64, r *http.Request, creds credentials) (authenticatedUser, *grpctool.ErrResp) {
+	a.authentications++
+	assert.Empty(a.t, creds.clusterId)
+	if creds.token != "tok" {
+		return nil, &grpctool.ErrResp{
+			StatusCode: http.StatusUnauthorized,
+			Msg:        "error",
+		}
+	}
+	return a, nil
+}
+
+func (a *testKubeconfigAuthenticator) name() string {
+	return "user1"
+}
+
+func (a *testKubeconfigAuthenticator) authorize(ctx context.Context, log *zap.Logger, agentId int64, clusterId string) (*authorization, *grpctool.ErrResp) {
+	a.authorizations++
+	code := a.authnResults[clusterId]
+	if code == 0 {
+		return &authorization{
+			impConfig: &rpc.ImpersonationConfig{},
+		}, nil
+	}
+	return nil, &grpctool.ErrResp{
+		StatusCode: code,
+		Msg:        "error",
+	}
+}
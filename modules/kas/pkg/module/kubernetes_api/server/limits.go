@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api"
+	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/metric"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
+)
+
+const (
+	userRateExceededMetricName    = "k8s_api_proxy_user_rate_exceeded_total"
+	clusterRateExceededMetricName = "k8s_api_proxy_cluster_rate_exceeded_total"
+)
+
+var (
+	_ redistool.RpcApi = (*rateLimitApi)(nil)
+)
+
+// rateLimitRequestKey is the context key for *rateLimitRequest.
+type rateLimitRequestKey struct{}
+
+// rateLimitRequest is passed to rate limiters via the request context.
+type rateLimitRequest struct {
+	log     *zap.Logger
+	api     modserver.Api
+	agentId int64
+	// authnType is the authentication method of the user. Users of different methods can have the same username.
+	authnType string
+	username  string
+}
+
+// instrumentedRateLimiter is a redistool.WindowLimiter with instrumented Allow.
+type instrumentedRateLimiter struct {
+	metric.AllowLimiter
+	limiter redistool.WindowLimiter
+}
+
+func (l *instrumentedRateLimiter) ResetTime() time.Time {
+	return l.limiter.ResetTime()
+}
+
+type rateLimitApi struct {
+	ctx context.Context
+	req *rateLimitRequest
+	key []byte
+}
+
+func (a *rateLimitApi) Log() *zap.Logger {
+	return a.req.log
+}
+
+func (a *rateLimitApi) HandleProcessingError(msg string, err error) {
+	a.req.api.HandleProcessingError(a.ctx, a.req.log, a.req.agentId, msg, err)
+}
+
+func (a *rateLimitApi) RequestKey() []byte {
+	return a.key
+}
+
+// newRateLimiter returns nil if limitPerMinute is zero i.e. there is no limit.
+func newRateLimiter(config *modserver.Config, name string, limitPerMinute uint32, exceededMetricName, exceededMetricHelp string,
+	requestKey func(*rateLimitRequest) []byte) (redistool.WindowLimiter, error) {
+	if limitPerMinute == 0 {
+		return nil, nil
+	}
+	exceededCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: exceededMetricName,
+		Help: exceededMetricHelp,
+	})
+	err := metric.Register(config.Registerer, exceededCounter)
+	if err != nil {
+		return nil, err
+	}
+	limiter := redistool.NewLimiter(
+		config.Storage,
+		config.Config.Redis.KeyPrefix+":"+name,
+		uint64(limitPerMinute),
+		exceededCounter,
+		func(ctx context.Context) redistool.RpcApi {
+			req := ctx.Value(rateLimitRequestKey{}).(*rateLimitRequest)
+			return &rateLimitApi{
+				ctx: ctx,
+				req: req,
+				key: requestKey(req),
+			}
+		},
+	)
+	instrumented, err := metric.NewAllowLimiterInstrumentation(
+		name,
+		float64(limitPerMinute),
+		"{request/m}",
+		config.TraceProvider.Tracer(kubernetes_api.ModuleName),
+		config.MeterProvider.Meter(kubernetes_api.ModuleName),
+		limiter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedRateLimiter{
+		AllowLimiter: instrumented,
+		limiter:      limiter,
+	}, nil
+}
+
+// userRateLimitKey is the key of the per-user rate limit bucket.
+func userRateLimitKey(req *rateLimitRequest) []byte {
+	key := make([]byte, 0, len(req.authnType)+1+len(req.username))
+	key = append(key, req.authnType...)
+	key = append(key, tokenSeparator...)
+	key = append(key, req.username...)
+	return key
+}
+
+func (p *kubernetesApiProxy) checkRateLimits(ctx context.Context, log *zap.Logger, w http.ResponseWriter, agentId int64, authnType string, impConfig *rpc2.ImpersonationConfig) *grpctool.ErrResp {
+	// Requests made using agent's own identity don't have a user.
+	if impConfig != nil {
+		eResp := p.checkUserRateLimit(ctx, log, w, agentId, authnType, impConfig.Username)
+		if eResp != nil {
+			return eResp
+		}
+	}
+	if p.clusterRateLimiter != nil && !p.clusterRateLimiter.Allow(p.rateLimitContext(ctx, log, agentId, "", "")) {
+		return rateLimitExceededErrResp(log, w, "cluster", p.clusterRateLimiter.ResetTime())
+	}
+	return nil
+}
+
+// checkUserRateLimit counts a request of the user. agentId is modshared.NoAgentId if the request is not for a cluster.
+func (p *kubernetesApiProxy) checkUserRateLimit(ctx context.Context, log *zap.Logger, w http.ResponseWriter, agentId int64, authnType, username string) *grpctool.ErrResp {
+	if p.userRateLimiter != nil && !p.userRateLimiter.Allow(p.rateLimitContext(ctx, log, agentId, authnType, username)) {
+		return rateLimitExceededErrResp(log, w, "user", p.userRateLimiter.ResetTime())
+	}
+	return nil
+}
+
+func (p *kubernetesApiProxy) rateLimitContext(ctx context.Context, log *zap.Logger, agentId int64, authnType, username string) context.Context {
+	return context.WithValue(ctx, rateLimitRequestKey{}, &rateLimitRequest{
+		log:       log,
+		api:       p.api,
+		agentId:   agentId,
+		authnType: authnType,
+		username:  username,
+	})
+}
+
+func rateLimitExceededErrResp(log *zap.Logger, w http.ResponseWriter, limit string, resetTime time.Time) *grpctool.ErrResp {
+	// Round up so that the client doesn't retry before the limit is reset.
+	retryAfter := max(int((time.Until(resetTime)+time.Second-1)/time.Second), 1)
+	w.Header()[httpz.RetryAfterHeader] = []string{strconv.Itoa(retryAfter)}
+	msg := fmt.Sprintf("Too many requests: %s rate limit exceeded", limit)
+	log.Debug(msg)
+	return &grpctool.ErrResp{
+		StatusCode: http.StatusTooManyRequests,
+		Msg:        msg,
+	}
+}
+
+// limitRequestBody rejects requests with a body that is known to be too large
+// and caps the body of other requests at the configured size.
+func (p *kubernetesApiProxy) limitRequestBody(w http.ResponseWriter, r *http.Request) *grpctool.ErrResp {
+	if p.maxRequestBodySize == 0 {
+		return nil
+	}
+	if r.ContentLength > p.maxRequestBodySize {
+		return &grpctool.ErrResp{
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Msg:        fmt.Sprintf("Request entity too large: limit is %d bytes", p.maxRequestBodySize),
+		}
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, p.maxRequestBodySize)
+	return nil
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+)
+
+const (
+	testAgentId int64 = 123
+)
+
+func TestCheckRateLimits(t *testing.T) {
+	user := &rpc.ImpersonationConfig{
+		Username: "user1",
+	}
+	tests := []struct {
+		name               string
+		impConfig          *rpc.ImpersonationConfig
+		userAllowed        bool
+		clusterAllowed     bool
+		expectedMsg        string
+		expectedUserChecks int
+	}{
+		{
+			name:               "allowed",
+			impConfig:          user,
+			userAllowed:        true,
+			clusterAllowed:     true,
+			expectedUserChecks: 1,
+		},
+		{
+			name:               "user limit exceeded",
+			impConfig:          user,
+			clusterAllowed:     true,
+			expectedMsg:        "Too many requests: user rate limit exceeded",
+			expectedUserChecks: 1,
+		},
+		{
+			name:               "cluster limit exceeded",
+			impConfig:          user,
+			userAllowed:        true,
+			expectedMsg:        "Too many requests: cluster rate limit exceeded",
+			expectedUserChecks: 1,
+		},
+		{
+			name:           "agent identity is not limited per user",
+			clusterAllowed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var userChecks int
+			p := &kubernetesApiProxy{
+				userRateLimiter: windowLimiterFunc(func(ctx context.Context) bool {
+					userChecks++
+					req := ctx.Value(rateLimitRequestKey{}).(*rateLimitRequest)
+					assert.Equal(t, "static:user1", string(userRateLimitKey(req)))
+					return tc.userAllowed
+				}),
+				clusterRateLimiter: windowLimiterFunc(func(ctx context.Context) bool {
+					req := ctx.Value(rateLimitRequestKey{}).(*rateLimitRequest)
+					assert.EqualValues(t, testAgentId, req.agentId)
+					return tc.clusterAllowed
+				}),
+			}
+			w := httptest.NewRecorder()
+			eResp := p.checkRateLimits(context.Background(), zaptest.NewLogger(t), w, testAgentId, tokenTypeStatic, tc.impConfig)
+			assert.Equal(t, tc.expectedUserChecks, userChecks)
+			if tc.expectedMsg == "" {
+				assert.Nil(t, eResp)
+				assert.Empty(t, w.Header())
+				return
+			}
+			require.NotNil(t, eResp)
+			assert.EqualValues(t, http.StatusTooManyRequests, eResp.StatusCode)
+			assert.Equal(t, tc.expectedMsg, eResp.Msg)
+			assert.Equal(t, "30", w.Header().Get(httpz.RetryAfterHeader))
+		})
+	}
+}
+
+func TestUserRateLimitKey(t *testing.T) {
+	oidc := userRateLimitKey(&rateLimitRequest{authnType: tokenTypeOidc, username: "user1"})
+	static := userRateLimitKey(&rateLimitRequest{authnType: tokenTypeStatic, username: "user1"})
+	assert.NotEqual(t, oidc, static)
+}
+
+func TestRateLimitExceededErrResp_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name               string
+		resetIn            time.Duration
+		expectedRetryAfter string
+	}{
+		{
+			name:               "rounded up",
+			resetIn:            1500 * time.Millisecond,
+			expectedRetryAfter: "2",
+		},
+		{
+			name:               "already reset",
+			resetIn:            -time.Second,
+			expectedRetryAfter: "1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rateLimitExceededErrResp(zaptest.NewLogger(t), w, "user", time.Now().Add(tc.resetIn))
+			assert.Equal(t, tc.expectedRetryAfter, w.Header().Get(httpz.RetryAfterHeader))
+		})
+	}
+}
+
+func TestCheckRateLimits_NoLimits(t *testing.T) {
+	p := &kubernetesApiProxy{}
+	w := httptest.NewRecorder()
+	eResp := p.checkRateLimits(context.Background(), zaptest.NewLogger(t), w, testAgentId, tokenTypeStatic, &rpc.ImpersonationConfig{Username: "user1"})
+	assert.Nil(t, eResp)
+}
+
+func TestWriteErrorResponse_RetryAfter(t *testing.T) {
+	p := &kubernetesApiProxy{
+		responseSerializer: serializer.NewCodecFactory(runtime.NewScheme()),
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	r.Header.Set(httpz.AcceptHeader, "application/json")
+	eResp := rateLimitExceededErrResp(zaptest.NewLogger(t), w, "user", time.Now().Add(30*time.Second))
+
+	p.writeErrorResponse(zaptest.NewLogger(t), testAgentId)(w, r, eResp)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	var s metav1.Status
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &s))
+	assert.Equal(t, metav1.StatusReasonTooManyRequests, s.Reason)
+	require.NotNil(t, s.Details)
+	assert.Equal(t, w.Header().Get(httpz.RetryAfterHeader), strconv.Itoa(int(s.Details.RetryAfterSeconds)))
+}
+
+func TestLimitRequestBody(t *testing.T) {
+	p := &kubernetesApiProxy{
+		maxRequestBodySize: 5,
+	}
+
+	t.Run("content length too large", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/ns1/configmaps", strings.NewReader("123456"))
+		eResp := p.limitRequestBody(httptest.NewRecorder(), r)
+		require.NotNil(t, eResp)
+		assert.EqualValues(t, http.StatusRequestEntityTooLarge, eResp.StatusCode)
+		assert.Equal(t, "Request entity too large: limit is 5 bytes", eResp.Msg)
+	})
+	t.Run("unknown content length", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/ns1/configmaps", strings.NewReader("123456"))
+		r.ContentLength = -1
+		eResp := p.limitRequestBody(httptest.NewRecorder(), r)
+		require.Nil(t, eResp)
+		_, err := io.ReadAll(r.Body)
+		var maxBytesErr *http.MaxBytesError
+		assert.True(t, errors.As(err, &maxBytesErr))
+	})
+	t.Run("small body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/ns1/configmaps", strings.NewReader("12345"))
+		eResp := p.limitRequestBody(httptest.NewRecorder(), r)
+		require.Nil(t, eResp)
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "12345", string(data))
+	})
+}
+
+// windowLimiterFunc is a limiter whose window resets in 30 seconds.
+type windowLimiterFunc func(context.Context) bool
+
+func (f windowLimiterFunc) Allow(ctx context.Context) bool {
+	return f(ctx)
+}
+
+func (f windowLimiterFunc) ResetTime() time.Time {
+	return time.Now().Add(30 * time.Second)
+}
@@ -5,7 +5,9 @@ import (
 	"net"
 
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/wait"
 
+	"github.com/pluralsh/kubernetes-agent/pkg/audit"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
 )
@@ -14,6 +16,7 @@ type module struct {
 	log      *zap.Logger
 	proxy    kubernetesApiProxy
 	listener func() (net.Listener, error)
+	audit    *audit.Pipeline
 }
 
 func (m *module) Run(ctx context.Context) error {
@@ -25,6 +28,13 @@ func (m *module) Run(ctx context.Context) error {
 	// a second close always produces an error.
 	defer lis.Close() // nolint:errcheck,gosec
 
+	// Audit pipeline is stopped after the proxy so that events of in-flight requests are not lost.
+	auditCtx, auditCancel := context.WithCancel(context.Background())
+	var wg wait.Group
+	defer wg.Wait()
+	defer auditCancel()
+	wg.StartWithContext(auditCtx, m.audit.Run)
+
 	m.log.Info("Kubernetes API endpoint is up",
 		logz.NetNetworkFromAddr(lis.Addr()),
 		logz.NetAddressFromAddr(lis.Addr()),
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/uuid"
+)
+
+const (
+	policyEffectDeny = "deny"
+	policyMatchAll   = "*"
+)
+
+var (
+	requestInfoFactory = &request.RequestInfoFactory{
+		APIPrefixes:          sets.NewString("api", "apis"),
+		GrouplessAPIPrefixes: sets.NewString("api"),
+	}
+)
+
+// requestPolicy is a compiled kascfg.KubernetesApiPolicyCF.
+// A nil set matches anything.
+type requestPolicy struct {
+	name         string
+	deny         bool
+	agentIds     sets.Set[int64]
+	users        sets.Set[string]
+	groups       sets.Set[string]
+	verbs        sets.Set[string]
+	apiGroups    sets.Set[string]
+	resources    sets.Set[string]
+	subresources sets.Set[string]
+	namespaces   sets.Set[string]
+}
+
+// policyRequest is what policies are evaluated against.
+type policyRequest struct {
+	agentId   int64
+	impConfig *rpc.ImpersonationConfig // nil if the request is made using agent's own identity
+	info      *request.RequestInfo
+}
+
+func newRequestPolicies(cfgs []*kascfg.KubernetesApiPolicyCF) ([]requestPolicy, error) {
+	policies := make([]requestPolicy, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		var agentIds sets.Set[int64]
+		if clusterIds := matchSet(cfg.ClusterIds); clusterIds != nil {
+			agentIds = sets.New[int64]()
+			for clusterId := range clusterIds {
+				agentId, err := uuid.ToInt64(clusterId)
+				if err != nil {
+					return nil, fmt.Errorf("policy %s: cluster id %q: %w", cfg.Name, clusterId, err)
+				}
+				agentIds.Insert(agentId)
+			}
+		}
+		policies = append(policies, requestPolicy{
+			name:         cfg.Name,
+			deny:         cfg.Effect == policyEffectDeny,
+			agentIds:     agentIds,
+			users:        matchSet(cfg.Users),
+			groups:       matchSet(cfg.Groups),
+			verbs:        matchSet(cfg.Verbs),
+			apiGroups:    matchSet(cfg.ApiGroups),
+			resources:    matchSet(cfg.Resources),
+			subresources: matchSet(cfg.Subresources),
+			namespaces:   matchSet(cfg.Namespaces),
+		})
+	}
+	return policies, nil
+}
+
+// evaluatePolicies returns the first policy that matches the request.
+// It returns nil if no policy matches.
+func evaluatePolicies(policies []requestPolicy, req policyRequest) *requestPolicy {
+	for i := range policies {
+		if policies[i].matches(req) {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+func (p *requestPolicy) matches(req policyRequest) bool {
+	info := req.info
+	return matchesValue(p.agentIds, req.agentId) &&
+		p.matchesUser(req.impConfig) &&
+		p.matchesGroups(req.impConfig) &&
+		matchesValue(p.verbs, info.Verb) &&
+		matchesValue(p.apiGroups, info.APIGroup) &&
+		matchesValue(p.resources, info.Resource) &&
+		matchesValue(p.subresources, info.Subresource) &&
+		matchesValue(p.namespaces, info.Namespace)
+}
+
+func (p *requestPolicy) matchesUser(impConfig *rpc.ImpersonationConfig) bool {
+	if p.users == nil {
+		return true
+	}
+	return impConfig != nil && p.users.Has(impConfig.Username)
+}
+
+func (p *requestPolicy) matchesGroups(impConfig *rpc.ImpersonationConfig) bool {
+	if p.groups == nil {
+		return true
+	}
+	if impConfig == nil {
+		return false
+	}
+	if slices.ContainsFunc(impConfig.Groups, p.groups.Has) {
+		return true
+	}
+	for _, role := range impConfig.Roles {
+		if p.groups.Has(rpc.BoundRoleGroupPrefix + role) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesValue[T comparable](set sets.Set[T], v T) bool {
+	return set == nil || set.Has(v)
+}
+
+// matchSet returns nil if the condition matches anything.
+func matchSet(values []string) sets.Set[string] {
+	if len(values) == 0 || slices.Contains(values, policyMatchAll) {
+		return nil
+	}
+	return sets.New(values...)
+}
+
+// newRequestInfo parses Kubernetes API request attributes. urlPath is the request's URL path without the proxy prefix.
+func newRequestInfo(r *http.Request, urlPath string) (*request.RequestInfo, error) {
+	u := *r.URL
+	u.Path = urlPath
+	u.RawPath = ""
+	req := r.WithContext(r.Context()) // shallow copy to not mutate the original request
+	req.URL = &u
+	return requestInfoFactory.NewRequestInfo(req)
+}
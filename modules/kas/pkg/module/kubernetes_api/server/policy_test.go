@@ -0,0 +1,187 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/uuid"
+)
+
+const (
+	otherTestClusterId = "7c1f0f3e-1d2b-4f6a-8e5d-9b0a1c2d3e4f"
+)
+
+func TestPolicies(t *testing.T) {
+	agentId, err := uuid.ToInt64(testClusterId)
+	require.NoError(t, err)
+	otherAgentId, err := uuid.ToInt64(otherTestClusterId)
+	require.NoError(t, err)
+
+	policies, err := newRequestPolicies([]*kascfg.KubernetesApiPolicyCF{
+		{
+			Name:      "admins-allowed",
+			Effect:    "allow",
+			Groups:    []string{"plural:role:admin"},
+			ApiGroups: []string{"*"},
+		},
+		{
+			Name:         "no-exec",
+			Effect:       "deny",
+			Groups:       []string{"developers"},
+			Resources:    []string{"pods"},
+			Subresources: []string{"exec", "attach"},
+		},
+		{
+			Name:       "no-secret-reads",
+			Effect:     "deny",
+			ClusterIds: []string{testClusterId},
+			Verbs:      []string{"get", "list", "watch"},
+			ApiGroups:  []string{""},
+			Resources:  []string{"secrets"},
+		},
+		{
+			Name:       "no-kube-system-changes",
+			Effect:     "deny",
+			Users:      []string{"user1"},
+			Verbs:      []string{"create", "update", "patch", "delete"},
+			Namespaces: []string{"kube-system"},
+		},
+	})
+	require.NoError(t, err)
+
+	developer := &rpc.ImpersonationConfig{
+		Username: "user1",
+		Groups:   []string{"developers"},
+	}
+	admin := &rpc.ImpersonationConfig{
+		Username: "user1",
+		Groups:   []string{"developers"},
+		Roles:    []string{"admin"},
+	}
+
+	tests := []struct {
+		name           string
+		agentId        int64
+		impConfig      *rpc.ImpersonationConfig
+		method         string
+		path           string
+		expectedPolicy string
+	}{
+		{
+			name:           "exec denied",
+			agentId:        agentId,
+			impConfig:      developer,
+			method:         http.MethodPost,
+			path:           "/api/v1/namespaces/ns1/pods/pod1/exec",
+			expectedPolicy: "no-exec",
+		},
+		{
+			name:      "logs allowed",
+			agentId:   agentId,
+			impConfig: developer,
+			method:    http.MethodGet,
+			path:      "/api/v1/namespaces/ns1/pods/pod1/log",
+		},
+		{
+			name:      "exec allowed for admin role",
+			agentId:   agentId,
+			impConfig: admin,
+			method:    http.MethodPost,
+			path:      "/api/v1/namespaces/ns1/pods/pod1/exec",
+		},
+		{
+			name:           "secret read denied",
+			agentId:        agentId,
+			method:         http.MethodGet,
+			path:           "/api/v1/namespaces/ns1/secrets/s1",
+			expectedPolicy: "no-secret-reads",
+		},
+		{
+			name:           "secret watch denied",
+			agentId:        agentId,
+			method:         http.MethodGet,
+			path:           "/api/v1/secrets?watch=true",
+			expectedPolicy: "no-secret-reads",
+		},
+		{
+			name:    "secret read allowed in other cluster",
+			agentId: otherAgentId,
+			method:  http.MethodGet,
+			path:    "/api/v1/namespaces/ns1/secrets/s1",
+		},
+		{
+			name:    "secret create allowed",
+			agentId: agentId,
+			method:  http.MethodPost,
+			path:    "/api/v1/namespaces/ns1/secrets",
+		},
+		{
+			name:      "secrets in other API group allowed",
+			agentId:   agentId,
+			impConfig: developer,
+			method:    http.MethodGet,
+			path:      "/apis/example.com/v1/namespaces/ns1/secrets/s1",
+		},
+		{
+			name:           "kube-system change denied",
+			agentId:        otherAgentId,
+			impConfig:      developer,
+			method:         http.MethodDelete,
+			path:           "/apis/apps/v1/namespaces/kube-system/deployments/d1",
+			expectedPolicy: "no-kube-system-changes",
+		},
+		{
+			name:    "kube-system change allowed for agent identity",
+			agentId: otherAgentId,
+			method:  http.MethodDelete,
+			path:    "/apis/apps/v1/namespaces/kube-system/deployments/d1",
+		},
+		{
+			name:      "non-resource request allowed",
+			agentId:   agentId,
+			impConfig: developer,
+			method:    http.MethodGet,
+			path:      "/apis",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &kubernetesApiProxy{
+				policies: policies,
+			}
+			r, err := http.NewRequest(tc.method, "https://kas.example.com/prefix"+tc.path, nil) // nolint: noctx
+			require.NoError(t, err)
+			originalPath := r.URL.Path
+			info, err := newRequestInfo(r, strings.TrimPrefix(r.URL.Path, "/prefix"))
+			require.NoError(t, err)
+			assert.Equal(t, originalPath, r.URL.Path)
+
+			eResp := p.checkPolicies(zaptest.NewLogger(t), tc.agentId, tc.impConfig, info)
+			if tc.expectedPolicy == "" {
+				assert.Nil(t, eResp)
+				return
+			}
+			require.NotNil(t, eResp)
+			assert.EqualValues(t, http.StatusForbidden, eResp.StatusCode)
+			assert.Equal(t, "Forbidden: request denied by policy "+tc.expectedPolicy, eResp.Msg)
+		})
+	}
+}
+
+func TestPolicies_InvalidClusterId(t *testing.T) {
+	_, err := newRequestPolicies([]*kascfg.KubernetesApiPolicyCF{
+		{
+			Name:       "p1",
+			Effect:     "deny",
+			ClusterIds: []string{"bla"},
+		},
+	})
+	assert.EqualError(t, err, `policy p1: cluster id "bla": uuid: incorrect UUID length: bla`)
+}
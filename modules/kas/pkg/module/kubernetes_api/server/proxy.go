@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"net"
 	"net/http"
 	"strconv"
@@ -12,17 +13,22 @@ import (
 	"time"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/api"
-	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+	"github.com/pluralsh/kubernetes-agent/pkg/audit"
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/agent_tracker"
 	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	serviceproxyrpc "github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpc"
+	tcpforwardrpc "github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/usage_metrics"
 	pluralapi "github.com/pluralsh/kubernetes-agent/pkg/plural/api"
-	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
+	"github.com/pluralsh/kubernetes-agent/pkg/recording"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
 	httpz2 "github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/memz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/uuid"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -35,6 +41,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/request"
 )
 
 const (
@@ -43,7 +50,6 @@ const (
 
 	authorizationHeaderBearerPrefix = "Bearer " // must end with a space
 	tokenSeparator                  = ":"
-	tokenTypePlural                 = "plrl"
 )
 
 var (
@@ -67,22 +73,31 @@ var (
 		http.StatusServiceUnavailable:  metav1.StatusReasonServiceUnavailable,
 		http.StatusGatewayTimeout:      metav1.StatusReasonTimeout,
 	}
-)
 
-type proxyUserCacheKey struct {
-	agentId   int64
-	accessKey string
-	clusterId string
-}
+	// pluralCookiePrefixes are lowercase name prefixes of cookies set by kas and Plural. They may carry credentials
+	// so they are not forwarded. Other cookies, such as the ones set by a proxied service, are forwarded.
+	pluralCookiePrefixes = []string{"_gitlab_kas", "_plural", "plural"}
+)
 
 type kubernetesApiProxy struct {
 	log                      *zap.Logger
 	api                      modserver.Api
 	kubernetesApiClient      rpc2.KubernetesApiClient
+	serviceProxyClient       serviceproxyrpc.ServiceProxyClient
+	tcpForwardClient         tcpforwardrpc.TcpForwardClient
 	pluralUrl                string
 	allowedOriginUrls        []string
-	allowedAgentsCache       *cache.CacheWithErr[string, *pluralapi.AllowedAgentsForJob]
-	authorizeProxyUserCache  *cache.CacheWithErr[proxyUserCacheKey, *pluralapi.AuthorizeProxyUserResponse]
+	authenticators           map[string]authenticator // keyed by token type or authnTypeClientCertificate
+	policies                 []requestPolicy
+	audit                    *audit.Pipeline
+	userRateLimiter          redistool.WindowLimiter // nil if there is no limit
+	clusterRateLimiter       redistool.WindowLimiter // nil if there is no limit
+	maxRequestBodySize       int64                   // zero if there is no limit
+	agentQuerier             agent_tracker.Querier
+	kubeconfigServerUrl      string                                // empty to derive from the request
+	kubeconfigExec           *kascfg.KubernetesApiKubeconfigExecCF // nil if not configured
+	discoveryCache           *discoveryCache                       // nil if not enabled
+	sessionRecorder          *sessionRecorder                      // nil if not enabled
 	requestCounter           usage_metrics.Counter
 	ciTunnelUsersCounter     usage_metrics.UniqueCounter
 	ciAccessRequestCounter   usage_metrics.Counter
@@ -154,10 +169,29 @@ func (p *kubernetesApiProxy) proxy(w http.ResponseWriter, r *http.Request) {
 		header[httpz2.AccessControlAllowMethodsHeader] = []string{"GET, HEAD, POST, PUT, DELETE, CONNECT, OPTIONS, TRACE, PATCH"}
 		header[httpz2.AccessControlMaxAgeHeader] = []string{"86400"}
 		w.WriteHeader(http.StatusOK)
+	} else if r.URL.Path == p.urlPathPrefix+kubeconfigPath {
+		log, eResp := p.kubeconfig(w, r)
+		if eResp != nil {
+			p.writeErrorResponse(log, modshared.NoAgentId)(w, r, eResp)
+		}
 	} else {
-		log, agentId, eResp := p.proxyInternal(w, r)
+		start := time.Now()
+		sw := &statusRecordingWriter{ResponseWriter: w}
+		ev := &audit.Event{
+			Time:   start,
+			Method: r.Method,
+		}
+		log, agentId, eResp := p.proxyInternal(sw, r, ev)
 		if eResp != nil {
-			p.writeErrorResponse(log, agentId)(w, r, eResp)
+			p.writeErrorResponse(log, agentId)(sw, r, eResp)
+		}
+		if ev.ClusterId != "" { // only audit requests with credentials
+			ev.ResponseStatus = sw.status
+			ev.Latency = time.Since(start)
+			if traceId := trace.SpanContextFromContext(r.Context()).TraceID(); traceId.IsValid() {
+				ev.TraceId = traceId.String()
+			}
+			p.audit.Record(ev)
 		}
 	}
 }
@@ -171,7 +205,7 @@ func (p *kubernetesApiProxy) isOriginAllowed(origin string) bool {
 	return false
 }
 
-func (p *kubernetesApiProxy) proxyInternal(w http.ResponseWriter, r *http.Request) (*zap.Logger, int64 /* agentId */, *grpctool.ErrResp) {
+func (p *kubernetesApiProxy) proxyInternal(w http.ResponseWriter, r *http.Request, ev *audit.Event) (*zap.Logger, int64 /* agentId */, *grpctool.ErrResp) {
 	ctx := r.Context()
 	log := p.log.With(logz.TraceIdFromContext(ctx))
 
@@ -184,7 +218,10 @@ func (p *kubernetesApiProxy) proxyInternal(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	log, clusterId, impConfig, eResp := p.authenticateAndImpersonateRequest(ctx, log, r)
+	// urlPathPrefix is guaranteed to end with / by defaulting. Keep the / by -1 on length.
+	ev.Path = r.URL.Path[len(p.urlPathPrefix)-1:]
+
+	log, clusterId, impConfig, eResp := p.authenticateAndImpersonateRequest(ctx, log, r, ev)
 	if eResp != nil {
 		// If Plural doesn't authorize the proxy user to make the call,
 		// we send an extra header to indicate that, so that the client
@@ -196,8 +233,92 @@ func (p *kubernetesApiProxy) proxyInternal(w http.ResponseWriter, r *http.Reques
 		return log, clusterId, eResp
 	}
 
+	var (
+		svcTarget *serviceProxyTarget // nil if it's not a service proxy request
+		tcpTarget *tcpForwardTarget   // nil if it's not a TCP forwarding request
+		info      *request.RequestInfo
+		err       error
+		msg       string
+	)
+	switch {
+	case isServiceProxyPath(ev.Path):
+		msg = "Bad request: failed to parse service proxy request"
+		svcTarget, err = parseServiceProxyPath(ev.Path)
+		if err == nil {
+			info, err = newRequestInfo(r, svcTarget.requestInfoPath())
+		}
+	case isTcpForwardPath(ev.Path):
+		msg = "Bad request: failed to parse TCP forwarding request"
+		tcpTarget, err = parseTcpForwardPath(ev.Path)
+		if err == nil {
+			info = tcpTarget.requestInfo(ev.Path)
+		}
+	default:
+		msg = "Bad request: failed to parse Kubernetes API request"
+		info, err = newRequestInfo(r, ev.Path)
+	}
+	if err != nil {
+		log.Debug(msg, logz.Error(err))
+		return log, clusterId, &grpctool.ErrResp{
+			StatusCode: http.StatusBadRequest,
+			Msg:        msg,
+			Err:        err,
+		}
+	}
+	setAuditRequestInfo(ev, info)
+
+	eResp = p.checkPolicies(log, clusterId, impConfig, info)
+	if eResp != nil {
+		return log, clusterId, eResp
+	}
+
+	eResp = p.checkRateLimits(ctx, log, w, clusterId, ev.AuthnType, impConfig)
+	if eResp != nil {
+		return log, clusterId, eResp
+	}
+
+	eResp = p.limitRequestBody(w, r)
+	if eResp != nil {
+		return log, clusterId, eResp
+	}
+
 	p.requestCounter.Inc() // Count only authenticated and authorized requests
 
+	switch {
+	case svcTarget != nil:
+		return log, clusterId, p.proxyService(ctx, log, clusterId, impConfig, w, r, svcTarget)
+	case tcpTarget != nil:
+		return log, clusterId, p.forwardTcp(ctx, log, clusterId, impConfig, w, r, tcpTarget)
+	}
+
+	var discoveryWriter *discoveryCacheWriter
+	if p.discoveryCache != nil && isDiscoveryRequest(r, ev.Path, info) {
+		var served bool
+		discoveryWriter, served = p.serveFromDiscoveryCache(ctx, log, clusterId, impConfig, w, r, ev.Path)
+		if served {
+			return log, clusterId, nil
+		}
+		if discoveryWriter != nil {
+			w = discoveryWriter
+		}
+	}
+
+	var newUpgradeTap grpctool.NewUpgradeTapFunc
+	if p.sessionRecorder != nil && p.sessionRecorder.shouldRecord(r, policyRequest{agentId: clusterId, impConfig: impConfig, info: info}) {
+		var session *recording.Session
+		session, eResp = p.sessionRecorder.start(log, ev.ClusterId, impConfig, info, r)
+		if eResp != nil {
+			return log, clusterId, eResp
+		}
+		defer func() {
+			err := session.Close()
+			if err != nil {
+				p.api.HandleProcessingError(ctx, log, clusterId, "Failed to write session recording", err)
+			}
+		}()
+		newUpgradeTap = sessionUpgradeTap(session)
+	}
+
 	md := metadata.Pairs(modserver.RoutingAgentIdMetadataKey, strconv.FormatInt(clusterId, 10))
 	mkClient, err := p.kubernetesApiClient.MakeRequest(metadata.NewOutgoingContext(ctx, md))
 	if err != nil {
@@ -210,98 +331,128 @@ func (p *kubernetesApiProxy) proxyInternal(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	p.pipeStreams(log, clusterId, w, r, mkClient, impConfig) // nolint: contextcheck
+	var extra proto.Message // don't use a concrete type here or extra will be passed as a typed nil.
+	if impConfig != nil {
+		extra = &rpc2.HeaderExtra{
+			ImpConfig: impConfig,
+		}
+	}
+	// urlPathPrefix is guaranteed to end with / by defaulting. That means / will be removed here.
+	// Put it back by -1 on length.
+	p.pipeStreams(log, clusterId, w, r, mkClient, r.URL.Path[len(p.urlPathPrefix)-1:], extra, newUpgradeTap) // nolint: contextcheck
+	if discoveryWriter != nil {
+		discoveryWriter.store()
+	}
 	return log, clusterId, nil
 }
 
-func (p *kubernetesApiProxy) authenticateAndImpersonateRequest(ctx context.Context, log *zap.Logger, r *http.Request) (*zap.Logger, int64 /* agentId */, *rpc2.ImpersonationConfig, *grpctool.ErrResp) {
+func (p *kubernetesApiProxy) authenticateAndImpersonateRequest(ctx context.Context, log *zap.Logger, r *http.Request, ev *audit.Event) (*zap.Logger, int64 /* agentId */, *rpc2.ImpersonationConfig, *grpctool.ErrResp) {
 	agentId, creds, err := getAuthorizationInfoFromRequest(r)
 	if err != nil {
-		msg := "Unauthorized"
-		log.Debug(msg, logz.Error(err))
-		return log, modshared.NoAgentId, nil, &grpctool.ErrResp{
-			StatusCode: http.StatusUnauthorized,
-			Msg:        msg,
-			Err:        err,
-		}
+		return log, modshared.NoAgentId, nil, unauthorizedErrResp(log, err)
 	}
+	ev.ClusterId = creds.clusterId
+	ev.AuthnType = creds.authnType
 	log = log.With(logz.AgentId(agentId))
 	trace.SpanFromContext(ctx).SetAttributes(api.TraceAgentIdAttr.Int64(agentId))
 
-	var (
-		impConfig *rpc2.ImpersonationConfig // can be nil
-	)
-
-	switch c := creds.(type) {
-	case patAuthn:
-		pluralapi.CreateAuditLogInBackground(log, agentId, r, c.token, c.clusterId, p.pluralUrl)
-		auth, eResp := p.authorizeProxyUser(ctx, log, agentId, c.token, c.clusterId)
-		if eResp != nil {
-			return log, agentId, nil, eResp
-		}
-		impConfig, err = constructUserImpersonationConfig(auth)
-		if err != nil {
-			msg := "Failed to construct user impersonation config"
-			p.api.HandleProcessingError(ctx, log, agentId, msg, err)
-			return log, agentId, nil, &grpctool.ErrResp{
-				StatusCode: http.StatusInternalServerError,
-				Msg:        msg,
-				Err:        err,
-			}
-		}
+	authn, ok := p.authenticators[creds.authnType]
+	if !ok {
+		return log, agentId, nil, unauthorizedErrResp(log, fmt.Errorf("%s authentication is not enabled", creds.authnType))
+	}
+	user, eResp := authn.authenticate(ctx, log, agentId, r, creds)
+	if eResp != nil {
+		return log, agentId, nil, eResp
+	}
+	auth, eResp := user.authorize(ctx, log, agentId, creds.clusterId)
+	if eResp != nil {
+		return log, agentId, nil, eResp
+	}
+	if auth.trackUsage != nil {
+		auth.trackUsage(p, agentId)
+	}
+	impConfig := auth.impConfig // can be nil
+	if impConfig != nil {
+		ev.User = impConfig.Username
+		ev.Groups = impConfig.Groups
+		ev.Roles = impConfig.Roles
+	}
+	if creds.authnType == tokenTypePlural {
+		ev.PluralToken = creds.token
 		// update usage metrics for PAT requests using the CI tunnel
 		p.patAccessRequestCounter.Inc()
 		// p.patAccessUsersCounter.Add(userId)
 		p.patAccessAgentsCounter.Add(agentId)
-	default: // This should never happen
-		msg := "Invalid authorization type"
-		p.api.HandleProcessingError(ctx, log, agentId, msg, err)
-		return log, agentId, nil, &grpctool.ErrResp{
-			StatusCode: http.StatusInternalServerError,
-			Msg:        msg,
-		}
 	}
 	return log, agentId, impConfig, nil
 }
 
-func (p *kubernetesApiProxy) authorizeProxyUser(ctx context.Context, log *zap.Logger, agentId int64, accessKey, clusterId string) (*pluralapi.AuthorizeProxyUserResponse, *grpctool.ErrResp) {
-	key := proxyUserCacheKey{
-		agentId:   agentId,
-		clusterId: clusterId,
-		accessKey: accessKey,
+// trackCiAccess updates usage metrics for requests from CI jobs.
+func (p *kubernetesApiProxy) trackCiAccess(agentId int64, user *pluralapi.User) {
+	p.ciAccessRequestCounter.Inc()
+	p.ciAccessAgentsCounter.Add(agentId)
+	userId := usageUserId(user.GetId())
+	p.ciAccessUsersCounter.Add(userId)
+	p.ciTunnelUsersCounter.Add(userId)
+}
+
+// usageUserId returns the id to count a user by in unique user counters.
+// Plural user ids are UUIDs. Ids that are not are hashed so that their users are still counted.
+func usageUserId(id string) int64 {
+	if userId, err := uuid.ToInt64(id); err == nil {
+		return userId
 	}
-	auth, err := p.authorizeProxyUserCache.GetItem(ctx, key, func() (*pluralapi.AuthorizeProxyUserResponse, error) {
-		return pluralapi.AuthorizeProxyUser(ctx, accessKey, clusterId, p.pluralUrl)
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(id))
+	return int64(h.Sum64())
+}
+
+func (p *kubernetesApiProxy) checkPolicies(log *zap.Logger, agentId int64, impConfig *rpc2.ImpersonationConfig, info *request.RequestInfo) *grpctool.ErrResp {
+	policy := evaluatePolicies(p.policies, policyRequest{
+		agentId:   agentId,
+		impConfig: impConfig,
+		info:      info,
 	})
+	if policy == nil || !policy.deny {
+		return nil
+	}
+	msg := fmt.Sprintf("Forbidden: request denied by policy %s", policy.name)
+	log.Debug(msg)
+	return &grpctool.ErrResp{
+		StatusCode: http.StatusForbidden,
+		Msg:        msg,
+	}
+}
+
+// proxyService proxies the request to an in-cluster service via agentk.
+// agentk checks that the impersonated identity is allowed to proxy requests to the service. impConfig can be nil.
+func (p *kubernetesApiProxy) proxyService(ctx context.Context, log *zap.Logger, agentId int64, impConfig *rpc2.ImpersonationConfig,
+	w http.ResponseWriter, r *http.Request, target *serviceProxyTarget) *grpctool.ErrResp {
+	target.extra.ImpConfig = impConfig
+	md := metadata.Pairs(modserver.RoutingAgentIdMetadataKey, strconv.FormatInt(agentId, 10))
+	mkClient, err := p.serviceProxyClient.MakeRequest(metadata.NewOutgoingContext(ctx, md))
 	if err != nil {
-		switch {
-		case gitlab2.IsUnauthorized(err), gitlab2.IsForbidden(err), gitlab2.IsNotFound(err):
-			log.Debug("Authorize proxy user error", logz.Error(err))
-			return nil, &grpctool.ErrResp{
-				StatusCode: http.StatusUnauthorized,
-				Msg:        "Unauthorized",
-			}
-		default:
-			msg := "Failed to authorize user session"
-			p.api.HandleProcessingError(ctx, log, agentId, msg, err)
-			return nil, &grpctool.ErrResp{
-				StatusCode: http.StatusInternalServerError,
-				Msg:        msg,
-			}
+		msg := "Proxy failed to make outbound request"
+		p.api.HandleProcessingError(ctx, log, agentId, msg, err)
+		return &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+			Err:        err,
 		}
 	}
-	return auth, nil
+	p.pipeStreams(log, agentId, w, r, mkClient, target.urlPath, target.extra, nil) // nolint: contextcheck
+	return nil
 }
 
+// pipeStreams pipes the request to agentk. urlPath is the path of the outbound request.
+// extra and newUpgradeTap can be nil.
 func (p *kubernetesApiProxy) pipeStreams(log *zap.Logger, agentId int64, w http.ResponseWriter, r *http.Request,
-	client rpc2.KubernetesApi_MakeRequestClient, impConfig *rpc2.ImpersonationConfig) {
-	// urlPathPrefix is guaranteed to end with / by defaulting. That means / will be removed here.
-	// Put it back by -1 on length.
-	r.URL.Path = r.URL.Path[len(p.urlPathPrefix)-1:]
+	client grpctool.HttpRequestClient, urlPath string, extra proto.Message, newUpgradeTap grpctool.NewUpgradeTapFunc) {
+	r.URL.Path = urlPath
 
 	// remove Plural authorization headers (job token, session cookie etc)
 	delete(r.Header, httpz2.AuthorizationHeader)
-	delete(r.Header, httpz2.CookieHeader)
+	removePluralCookies(r)
 	delete(r.Header, httpz2.GitlabAgentIdHeader)
 	delete(r.Header, httpz2.CsrfTokenHeader)
 	// remove Plural authorization query parameters
@@ -319,14 +470,35 @@ func (p *kubernetesApiProxy) pipeStreams(log *zap.Logger, agentId int64, w http.
 		},
 		WriteErrorResponse: p.writeErrorResponse(log, agentId),
 		MergeHeaders:       p.mergeProxiedResponseHeaders,
+		NewUpgradeTap:      newUpgradeTap,
 	}
-	var extra proto.Message // don't use a concrete type here or extra will be passed as a typed nil.
-	if impConfig != nil {
-		extra = &rpc2.HeaderExtra{
-			ImpConfig: impConfig,
+	http2grpc.Pipe(client, w, r, extra)
+}
+
+// removePluralCookies removes cookies set by kas and Plural from the request and keeps the others.
+func removePluralCookies(r *http.Request) {
+	cookies := r.Cookies()
+	kept := make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		if !isPluralCookie(c.Name) {
+			kept = append(kept, c.String())
 		}
 	}
-	http2grpc.Pipe(client, w, r, extra)
+	if len(kept) == 0 {
+		delete(r.Header, httpz2.CookieHeader)
+		return
+	}
+	r.Header[httpz2.CookieHeader] = []string{strings.Join(kept, "; ")}
+}
+
+func isPluralCookie(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range pluralCookiePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *kubernetesApiProxy) mergeProxiedResponseHeaders(outbound, inbound http.Header) {
@@ -373,6 +545,11 @@ func (p *kubernetesApiProxy) writeErrorResponse(log *zap.Logger, agentId int64)
 			Reason:  code2reason[errResp.StatusCode], // if mapping is not present, then "" means metav1.StatusReasonUnknown
 			Code:    errResp.StatusCode,
 		}
+		if retryAfter, err := strconv.ParseInt(w.Header().Get(httpz2.RetryAfterHeader), 10, 32); err == nil {
+			s.Details = &metav1.StatusDetails{
+				RetryAfterSeconds: int32(retryAfter),
+			}
+		}
 		buf := memz.Get32k() // use a temporary buffer to segregate I/O errors and encoding errors
 		defer memz.Put32k(buf)
 		buf = buf[:0] // don't care what's in the buf, start writing from the start
@@ -406,46 +583,46 @@ func formatStatusMessage(ctx context.Context, msg string, err error) string {
 	return b.String()
 }
 
-type patAuthn struct {
-	token     string
-	clusterId string
-}
-
-func getAuthorizationInfoFromRequest(r *http.Request) (int64 /* agentId */, any, error) {
+func getAuthorizationInfoFromRequest(r *http.Request) (int64 /* agentId */, credentials, error) {
 	if authzHeader := r.Header[httpz2.AuthorizationHeader]; len(authzHeader) >= 1 {
 		if len(authzHeader) > 1 {
-			return 0, nil, fmt.Errorf("%s header: expecting a single header, got %d", httpz2.AuthorizationHeader, len(authzHeader))
+			return 0, credentials{}, fmt.Errorf("%s header: expecting a single header, got %d", httpz2.AuthorizationHeader, len(authzHeader))
 		}
 		agentId, tokenType, token, clusterId, err := getAgentIdAndTokenFromHeader(authzHeader[0])
 		if err != nil {
-			return 0, nil, err
+			return 0, credentials{}, err
 		}
-		if tokenType == tokenTypePlural {
-			return agentId, patAuthn{
-				token:     token,
-				clusterId: clusterId,
-			}, nil
+		return agentId, credentials{
+			authnType: tokenType,
+			clusterId: clusterId,
+			token:     token,
+		}, nil
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		// Client certificate has been verified during the TLS handshake. Cluster id is passed in a header.
+		clusterIdHeader := r.Header[httpz2.GitlabAgentIdHeader]
+		if len(clusterIdHeader) != 1 {
+			return 0, credentials{}, fmt.Errorf("%s header: expecting a single header, got %d", httpz2.GitlabAgentIdHeader, len(clusterIdHeader))
+		}
+		clusterId := clusterIdHeader[0]
+		agentId, err := uuid.ToInt64(clusterId)
+		if err != nil {
+			return 0, credentials{}, fmt.Errorf("%s header: failed to parse: %w", httpz2.GitlabAgentIdHeader, err)
 		}
+		return agentId, credentials{
+			authnType: authnTypeClientCertificate,
+			clusterId: clusterId,
+			cert:      r.TLS.VerifiedChains[0][0],
+		}, nil
 	}
-	return 0, nil, errors.New("no valid credentials provided")
+	return 0, credentials{}, errors.New("no valid credentials provided")
 }
 
 func getAgentIdAndTokenFromHeader(header string) (int64, string /* token type */, string /* token */, string /* clusterId */, error) {
-	if !strings.HasPrefix(header, authorizationHeaderBearerPrefix) {
-		// "missing" space in message - it's in the authorizationHeaderBearerPrefix constant already
-		return 0, "", "", "", fmt.Errorf("%s header: expecting %stoken", httpz2.AuthorizationHeader, authorizationHeaderBearerPrefix)
-	}
-	tokenValue := header[len(authorizationHeaderBearerPrefix):]
-	tokenType, tokenContents, found := strings.Cut(tokenValue, tokenSeparator)
-	if !found {
-		return 0, "", "", "", fmt.Errorf("%s header: invalid value", httpz2.AuthorizationHeader)
-	}
-	switch tokenType {
-	case tokenTypePlural:
-	default:
-		return 0, "", "", "", fmt.Errorf("%s header: unknown token type", httpz2.AuthorizationHeader)
+	tokenType, agentIdAndToken, err := getTokenFromHeader(header)
+	if err != nil {
+		return 0, "", "", "", err
 	}
-	agentIdAndToken := tokenContents
 	clusterIdStr, token, found := strings.Cut(agentIdAndToken, tokenSeparator)
 	if !found {
 		return 0, "", "", "", fmt.Errorf("%s header: invalid value", httpz2.AuthorizationHeader)
@@ -461,18 +638,21 @@ func getAgentIdAndTokenFromHeader(header string) (int64, string /* token type */
 	return agentId, tokenType, token, clusterIdStr, nil
 }
 
-func constructUserImpersonationConfig(auth *pluralapi.AuthorizeProxyUserResponse) (*rpc2.ImpersonationConfig, error) {
-	switch imp := auth.GetAccessAs().AccessAs.(type) {
-	case *pluralapi.AccessAsProxyAuthorization_Agent:
-		return nil, nil
-	case *pluralapi.AccessAsProxyAuthorization_User:
-		return &rpc2.ImpersonationConfig{
-			Username: auth.User.Username,
-			Groups:   auth.AccessAs.GetUser().Groups,
-			Roles:    auth.AccessAs.GetUser().Roles,
-		}, nil
+// getTokenFromHeader parses a `Bearer <token type>:<token contents>` header value.
+func getTokenFromHeader(header string) (string /* token type */, string /* token contents */, error) {
+	if !strings.HasPrefix(header, authorizationHeaderBearerPrefix) {
+		// "missing" space in message - it's in the authorizationHeaderBearerPrefix constant already
+		return "", "", fmt.Errorf("%s header: expecting %stoken", httpz2.AuthorizationHeader, authorizationHeaderBearerPrefix)
+	}
+	tokenValue := header[len(authorizationHeaderBearerPrefix):]
+	tokenType, tokenContents, found := strings.Cut(tokenValue, tokenSeparator)
+	if !found {
+		return "", "", fmt.Errorf("%s header: invalid value", httpz2.AuthorizationHeader)
+	}
+	switch tokenType {
+	case tokenTypePlural, tokenTypeCi, tokenTypeOidc, tokenTypeStatic:
 	default:
-		// Normally this should never happen
-		return nil, fmt.Errorf("unexpected user impersonation mode: %T", imp)
+		return "", "", fmt.Errorf("%s header: unknown token type", httpz2.AuthorizationHeader)
 	}
+	return tokenType, tokenContents, nil
 }
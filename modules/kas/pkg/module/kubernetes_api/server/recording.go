@@ -0,0 +1,124 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/recording"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	httpz2 "github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+const (
+	containerQueryParam = "container"
+	commandQueryParam   = "command"
+)
+
+var (
+	recordedResources    = []string{"pods"}
+	recordedSubresources = []string{"exec", "attach"}
+)
+
+// sessionRecorder records exec and attach sessions selected by kascfg.KubernetesApiSessionRecordingCF.
+type sessionRecorder struct {
+	sink recording.Sink
+	// policies select sessions to record. A session is recorded if any of them matches.
+	policies []requestPolicy
+}
+
+func newSessionRecorder(cfg *kascfg.KubernetesApiSessionRecordingCF) (*sessionRecorder, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var policyCfgs []*kascfg.KubernetesApiPolicyCF
+	if len(cfg.ClusterIds) > 0 {
+		policyCfgs = append(policyCfgs, &kascfg.KubernetesApiPolicyCF{
+			Name:         "session_recording.cluster_ids",
+			ClusterIds:   cfg.ClusterIds,
+			Resources:    recordedResources,
+			Subresources: recordedSubresources,
+		})
+	}
+	if len(cfg.Groups) > 0 {
+		policyCfgs = append(policyCfgs, &kascfg.KubernetesApiPolicyCF{
+			Name:         "session_recording.groups",
+			Groups:       cfg.Groups,
+			Resources:    recordedResources,
+			Subresources: recordedSubresources,
+		})
+	}
+	if len(policyCfgs) == 0 {
+		policyCfgs = append(policyCfgs, &kascfg.KubernetesApiPolicyCF{
+			Name:         "session_recording",
+			Resources:    recordedResources,
+			Subresources: recordedSubresources,
+		})
+	}
+	policies, err := newRequestPolicies(policyCfgs)
+	if err != nil {
+		return nil, err
+	}
+	sink, err := recording.NewFileSink(cfg.File.Dir)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionRecorder{
+		sink:     sink,
+		policies: policies,
+	}, nil
+}
+
+// shouldRecord returns true if the request starts a session that must be recorded.
+func (s *sessionRecorder) shouldRecord(r *http.Request, req policyRequest) bool {
+	if len(r.Header[httpz2.UpgradeHeader]) == 0 {
+		return false // not a streaming session
+	}
+	return evaluatePolicies(s.policies, req) != nil
+}
+
+// start creates a recording of the session. It fails the request if the recording cannot be created
+// so that sessions that must be recorded never go unrecorded.
+func (s *sessionRecorder) start(log *zap.Logger, clusterId string, impConfig *rpc.ImpersonationConfig,
+	info *request.RequestInfo, r *http.Request) (*recording.Session, *grpctool.ErrResp) {
+	query := r.URL.Query()
+	md := &recording.Metadata{
+		Time:      time.Now(),
+		ClusterId: clusterId,
+		Namespace: info.Namespace,
+		Pod:       info.Name,
+		Container: query.Get(containerQueryParam),
+		Command:   query[commandQueryParam],
+	}
+	if impConfig != nil {
+		md.User = impConfig.Username
+	}
+	session, err := recording.NewSession(s.sink, md)
+	if err != nil {
+		msg := "Failed to start session recording"
+		log.Error(msg, logz.RecordingSink(s.sink.Name()), logz.Error(err))
+		return nil, &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+			Err:        err,
+		}
+	}
+	return session, nil
+}
+
+// sessionUpgradeTap returns a function that starts decoding the session once the connection is upgraded.
+// The upgrade fails if the session cannot be recorded.
+func sessionUpgradeTap(session *recording.Session) grpctool.NewUpgradeTapFunc {
+	return func(responseHeader http.Header) (grpctool.UpgradeTap, error) {
+		err := session.Start(recording.ProtocolFromResponseHeader(responseHeader))
+		if err != nil {
+			return nil, err
+		}
+		return session, nil
+	}
+}
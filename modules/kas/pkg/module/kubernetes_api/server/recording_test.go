@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/uuid"
+)
+
+func TestSessionRecorder_ShouldRecord(t *testing.T) {
+	clusterId := "a1b2c3d4-0000-0000-0000-000000000000"
+	agentId, err := uuid.ToInt64(clusterId)
+	require.NoError(t, err)
+	execInfo := &request.RequestInfo{Resource: "pods", Subresource: "exec", Namespace: "ns1", Name: "pod1"}
+	tests := []struct {
+		name    string
+		cfg     *kascfg.KubernetesApiSessionRecordingCF
+		req     policyRequest
+		upgrade bool
+		record  bool
+	}{
+		{
+			name:    "everything",
+			cfg:     &kascfg.KubernetesApiSessionRecordingCF{},
+			req:     policyRequest{agentId: 1, info: execInfo},
+			upgrade: true,
+			record:  true,
+		},
+		{
+			name: "not upgraded",
+			cfg:  &kascfg.KubernetesApiSessionRecordingCF{},
+			req:  policyRequest{agentId: 1, info: execInfo},
+		},
+		{
+			name:    "logs",
+			cfg:     &kascfg.KubernetesApiSessionRecordingCF{},
+			req:     policyRequest{agentId: 1, info: &request.RequestInfo{Resource: "pods", Subresource: "log"}},
+			upgrade: true,
+		},
+		{
+			name:    "cluster matches",
+			cfg:     &kascfg.KubernetesApiSessionRecordingCF{ClusterIds: []string{clusterId}, Groups: []string{"g1"}},
+			req:     policyRequest{agentId: agentId, info: execInfo},
+			upgrade: true,
+			record:  true,
+		},
+		{
+			name:    "role matches",
+			cfg:     &kascfg.KubernetesApiSessionRecordingCF{ClusterIds: []string{clusterId}, Groups: []string{"plural:role:r1"}},
+			req:     policyRequest{agentId: 1, impConfig: &rpc.ImpersonationConfig{Roles: []string{"r1"}}, info: execInfo},
+			upgrade: true,
+			record:  true,
+		},
+		{
+			name:    "nothing matches",
+			cfg:     &kascfg.KubernetesApiSessionRecordingCF{ClusterIds: []string{clusterId}, Groups: []string{"g1"}},
+			req:     policyRequest{agentId: 1, impConfig: &rpc.ImpersonationConfig{Groups: []string{"g2"}}, info: execInfo},
+			upgrade: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.File = &kascfg.KubernetesApiSessionRecordingFileSinkCF{Dir: t.TempDir()}
+			s, err := newSessionRecorder(tc.cfg)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.upgrade {
+				r.Header.Set("Upgrade", "websocket")
+			}
+			assert.Equal(t, tc.record, s.shouldRecord(r, tc.req))
+		})
+	}
+}
+
+func TestNewSessionRecorder_NotConfigured(t *testing.T) {
+	s, err := newSessionRecorder(nil)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+}
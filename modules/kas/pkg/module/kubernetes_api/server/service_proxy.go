@@ -0,0 +1,79 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	serviceproxyrpc "github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpc"
+)
+
+const (
+	// servicesPath is relative to urlPathPrefix. Kubernetes API paths never start with "-".
+	servicesPath = "-/services/"
+
+	serviceSchemeHttp = "http"
+)
+
+// serviceProxyTarget is an in-cluster service a request is proxied to.
+type serviceProxyTarget struct {
+	extra *serviceproxyrpc.HeaderExtra
+	// urlPath is the path of the request to the service.
+	urlPath string
+}
+
+// isServiceProxyPath returns true if the request should be proxied to an in-cluster service rather than Kubernetes API.
+// path is the request path without urlPathPrefix.
+func isServiceProxyPath(path string) bool {
+	return strings.HasPrefix(path, "/"+servicesPath)
+}
+
+// parseServiceProxyPath parses a /-/services/<namespace>/<[scheme:]name:port>/<path> path.
+// The service is specified the same way as in the Kubernetes API server's service proxy.
+func parseServiceProxyPath(path string) (*serviceProxyTarget, error) {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"+servicesPath), "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expecting %s<namespace>/<[scheme:]name:port>/<path>", servicesPath)
+	}
+	extra := &serviceproxyrpc.HeaderExtra{
+		Namespace: parts[0],
+		Scheme:    serviceSchemeHttp,
+	}
+	var portStr string
+	spec := strings.Split(parts[1], ":")
+	switch len(spec) {
+	case 2:
+		extra.Name, portStr = spec[0], spec[1]
+	case 3:
+		extra.Scheme, extra.Name, portStr = spec[0], spec[1], spec[2]
+	default:
+		return nil, fmt.Errorf("invalid service %q: expecting [scheme:]name:port", parts[1])
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service %q: port: %w", parts[1], err)
+	}
+	extra.Port = uint32(port)
+	err = extra.ValidateAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid service %q: %w", parts[1], err)
+	}
+	urlPath := "/"
+	if len(parts) == 3 {
+		urlPath += parts[2]
+	}
+	return &serviceProxyTarget{
+		extra:   extra,
+		urlPath: urlPath,
+	}, nil
+}
+
+// requestInfoPath returns the Kubernetes API server's service proxy path for the target.
+// Policies and audit events see requests to services as requests to the services/proxy subresource.
+func (t *serviceProxyTarget) requestInfoPath() string {
+	name := t.extra.Name + ":" + strconv.FormatUint(uint64(t.extra.Port), 10)
+	if t.extra.Scheme != serviceSchemeHttp {
+		name = t.extra.Scheme + ":" + name
+	}
+	return "/api/v1/namespaces/" + t.extra.Namespace + "/services/" + name + "/proxy" + t.urlPath
+}
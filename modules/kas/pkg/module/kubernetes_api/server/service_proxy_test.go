@@ -0,0 +1,124 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	serviceproxyrpc "github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpc"
+)
+
+func TestParseServiceProxyPath(t *testing.T) {
+	tests := []struct {
+		path            string
+		expectedExtra   *serviceproxyrpc.HeaderExtra
+		expectedUrlPath string
+	}{
+		{
+			path: "/-/services/monitoring/grafana:80",
+			expectedExtra: &serviceproxyrpc.HeaderExtra{
+				Namespace: "monitoring",
+				Name:      "grafana",
+				Port:      80,
+				Scheme:    "http",
+			},
+			expectedUrlPath: "/",
+		},
+		{
+			path: "/-/services/argocd/https:argocd-server:443/api/v1/applications",
+			expectedExtra: &serviceproxyrpc.HeaderExtra{
+				Namespace: "argocd",
+				Name:      "argocd-server",
+				Port:      443,
+				Scheme:    "https",
+			},
+			expectedUrlPath: "/api/v1/applications",
+		},
+		{
+			path: "/-/services/monitoring/grafana:3000/",
+			expectedExtra: &serviceproxyrpc.HeaderExtra{
+				Namespace: "monitoring",
+				Name:      "grafana",
+				Port:      3000,
+				Scheme:    "http",
+			},
+			expectedUrlPath: "/",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := parseServiceProxyPath(tc.path)
+			require.NoError(t, err)
+			assert.Empty(t, cmp.Diff(tc.expectedExtra, target.extra, protocmp.Transform()))
+			assert.Equal(t, tc.expectedUrlPath, target.urlPath)
+		})
+	}
+}
+
+func TestParseServiceProxyPath_Errors(t *testing.T) {
+	paths := []string{
+		"/-/services/",
+		"/-/services/monitoring",
+		"/-/services/monitoring/",
+		"/-/services//grafana:80",
+		"/-/services/monitoring/grafana",
+		"/-/services/monitoring/grafana:http",
+		"/-/services/monitoring/grafana:0",
+		"/-/services/monitoring/grafana:65536",
+		"/-/services/monitoring/ftp:grafana:21",
+		"/-/services/monitoring/a:b:c:80",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			_, err := parseServiceProxyPath(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestServiceProxyTarget_RequestInfo(t *testing.T) {
+	target, err := parseServiceProxyPath("/-/services/argocd/https:argocd-server:443/api/v1/applications")
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/prefix/-/services/argocd/https:argocd-server:443/api/v1/applications", nil)
+
+	info, err := newRequestInfo(r, target.requestInfoPath())
+	require.NoError(t, err)
+
+	assert.True(t, info.IsResourceRequest)
+	assert.Equal(t, "create", info.Verb)
+	assert.Empty(t, info.APIGroup)
+	assert.Equal(t, "services", info.Resource)
+	assert.Equal(t, "proxy", info.Subresource)
+	assert.Equal(t, "argocd", info.Namespace)
+	assert.Equal(t, "https:argocd-server:443", info.Name)
+}
+
+func TestIsServiceProxyPath(t *testing.T) {
+	assert.True(t, isServiceProxyPath("/-/services/monitoring/grafana:80"))
+	assert.False(t, isServiceProxyPath("/api/v1/namespaces/monitoring/services/grafana:80/proxy"))
+	assert.False(t, isServiceProxyPath("/-/kubeconfig"))
+}
+
+func TestRemovePluralCookies(t *testing.T) {
+	tests := []struct {
+		cookie   string
+		expected []string
+	}{
+		{cookie: "_gitlab_kas=secret", expected: nil},
+		{cookie: "grafana_session=abc; _plural_session=secret", expected: []string{"grafana_session=abc"}},
+		{cookie: "a=1; PluralToken=secret; b=2", expected: []string{"a=1; b=2"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.cookie, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Cookie", tc.cookie)
+			removePluralCookies(r)
+			assert.Equal(t, tc.expected, r.Header["Cookie"])
+		})
+	}
+}
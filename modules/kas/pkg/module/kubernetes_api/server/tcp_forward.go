@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/coder/websocket"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	tcpforwardrpc "github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	httpz2 "github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/memz"
+)
+
+const (
+	// tcpForwardPath is relative to urlPathPrefix. Kubernetes API paths never start with "-".
+	tcpForwardPath         = "-/tcp/"
+	tcpForwardServicesPath = tcpForwardPath + "services/"
+	tcpForwardHostsPath    = tcpForwardPath + "hosts/"
+)
+
+// tcpForwardTarget is an in-cluster target a TCP connection is forwarded to.
+type tcpForwardTarget struct {
+	header *tcpforwardrpc.ForwardRequest_Header
+}
+
+// isTcpForwardPath returns true if the request should be forwarded to an in-cluster TCP target.
+// path is the request path without urlPathPrefix.
+func isTcpForwardPath(path string) bool {
+	return strings.HasPrefix(path, "/"+tcpForwardPath)
+}
+
+// parseTcpForwardPath parses a /-/tcp/services/<namespace>/<name>:<port> or a /-/tcp/hosts/<host>:<port> path.
+func parseTcpForwardPath(path string) (*tcpForwardTarget, error) {
+	header := &tcpforwardrpc.ForwardRequest_Header{}
+	switch {
+	case strings.HasPrefix(path, "/"+tcpForwardServicesPath):
+		namespace, nameAndPort, found := strings.Cut(strings.TrimPrefix(path, "/"+tcpForwardServicesPath), "/")
+		if !found {
+			return nil, fmt.Errorf("expecting %s<namespace>/<name>:<port>", tcpForwardServicesPath)
+		}
+		name, port, err := splitHostPort(nameAndPort)
+		if err != nil {
+			return nil, err
+		}
+		header.Target = &tcpforwardrpc.ForwardRequest_Header_Service{
+			Service: &tcpforwardrpc.Service{
+				Namespace: namespace,
+				Name:      name,
+			},
+		}
+		header.Port = port
+	case strings.HasPrefix(path, "/"+tcpForwardHostsPath):
+		host, port, err := splitHostPort(strings.TrimPrefix(path, "/"+tcpForwardHostsPath))
+		if err != nil {
+			return nil, err
+		}
+		header.Target = &tcpforwardrpc.ForwardRequest_Header_Host{
+			Host: host,
+		}
+		header.Port = port
+	default:
+		return nil, fmt.Errorf("expecting %s<namespace>/<name>:<port> or %s<host>:<port>", tcpForwardServicesPath, tcpForwardHostsPath)
+	}
+	err := header.ValidateAll()
+	if err != nil {
+		return nil, err
+	}
+	return &tcpForwardTarget{
+		header: header,
+	}, nil
+}
+
+func splitHostPort(hostPort string) (string, uint32, error) {
+	host, portStr, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("port: %w", err)
+	}
+	return host, uint32(port), nil
+}
+
+// requestInfo returns the request attributes that policies and audit events see.
+// They are the attributes the agent checks access with, so policies can be written against the same
+// resources as RBAC rules: services/proxy for services and pods/portforward for hosts.
+func (t *tcpForwardTarget) requestInfo(path string) *request.RequestInfo {
+	attrs := tcpforwardrpc.TargetAccess(t.header)
+	return &request.RequestInfo{
+		IsResourceRequest: true,
+		Path:              path,
+		Verb:              attrs.Verb,
+		APIPrefix:         "api",
+		APIVersion:        "v1", // services and pods are in the core API group
+		Namespace:         attrs.Namespace,
+		Resource:          attrs.Resource,
+		Subresource:       attrs.Subresource,
+		Name:              attrs.Name,
+	}
+}
+
+// forwardTcp upgrades the request to a WebSocket connection and pipes its binary messages to the target via agentk.
+// agentk checks that the impersonated identity is allowed to connect to the target. impConfig can be nil.
+func (p *kubernetesApiProxy) forwardTcp(ctx context.Context, log *zap.Logger, agentId int64, impConfig *rpc.ImpersonationConfig,
+	w http.ResponseWriter, r *http.Request, target *tcpForwardTarget) *grpctool.ErrResp {
+	if !strings.EqualFold(r.Header.Get(httpz2.UpgradeHeader), "websocket") {
+		return &grpctool.ErrResp{
+			StatusCode: http.StatusBadRequest,
+			Msg:        "Bad request: expecting a WebSocket upgrade request",
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	md := metadata.Pairs(modserver.RoutingAgentIdMetadataKey, strconv.FormatInt(agentId, 10))
+	client, err := p.tcpForwardClient.Forward(metadata.NewOutgoingContext(ctx, md))
+	if err != nil {
+		msg := "Proxy failed to make outbound request"
+		p.api.HandleProcessingError(ctx, log, agentId, msg, err)
+		return &grpctool.ErrResp{
+			StatusCode: http.StatusInternalServerError,
+			Msg:        msg,
+			Err:        err,
+		}
+	}
+	target.header.ImpConfig = impConfig
+	err = client.Send(&tcpforwardrpc.ForwardRequest{
+		Message: &tcpforwardrpc.ForwardRequest_Header_{
+			Header: target.header,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			_, err = client.Recv()
+		}
+		return tcpForwardErrResp(log, err)
+	}
+	// Wait for the connection to be established to be able to respond with an error status code.
+	resp, err := client.Recv()
+	if err != nil {
+		return tcpForwardErrResp(log, err)
+	}
+	if resp.GetHeader() == nil {
+		return tcpForwardErrResp(log, fmt.Errorf("expecting header, got %T", resp.Message))
+	}
+	ws, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		InsecureSkipVerify: true, // Origin has been checked already
+	})
+	if err != nil {
+		// Accept() has responded already.
+		log.Debug("TCP forward: failed to accept WebSocket connection", logz.Error(err))
+		return nil
+	}
+	conn := websocket.NetConn(ctx, ws, websocket.MessageBinary)
+	defer conn.Close() // nolint: errcheck
+
+	go func() {
+		err := pipeConnToForwardClient(conn, client)
+		if err != nil {
+			log.Debug("TCP forward: WebSocket -> gRPC", logz.Error(err))
+			cancel()
+		}
+	}()
+	for {
+		resp, err = client.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Debug("TCP forward: gRPC -> WebSocket", logz.Error(err))
+			}
+			return nil
+		}
+		data := resp.GetData()
+		if data == nil {
+			log.Debug("TCP forward: gRPC -> WebSocket", logz.Error(fmt.Errorf("expecting data, got %T", resp.Message)))
+			return nil
+		}
+		_, err = conn.Write(data.Data)
+		if err != nil {
+			log.Debug("TCP forward: gRPC -> WebSocket", logz.Error(err))
+			return nil
+		}
+	}
+}
+
+func pipeConnToForwardClient(conn net.Conn, client tcpforwardrpc.TcpForward_ForwardClient) error {
+	buf := memz.Get32k()
+	defer memz.Put32k(buf)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			sendErr := client.Send(&tcpforwardrpc.ForwardRequest{
+				Message: &tcpforwardrpc.ForwardRequest_Data_{
+					Data: &tcpforwardrpc.ForwardRequest_Data{
+						Data: buf[:n],
+					},
+				},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// The client is done sending data.
+				return client.CloseSend()
+			}
+			return err
+		}
+	}
+}
+
+// tcpForwardErrResp converts an error from agentk into an error response.
+func tcpForwardErrResp(log *zap.Logger, err error) *grpctool.ErrResp {
+	msg := "TCP forward: failed to connect to target"
+	log.Debug(msg, logz.Error(err))
+	code := http.StatusBadGateway
+	switch status.Code(err) { // nolint: exhaustive
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	}
+	return &grpctool.ErrResp{
+		StatusCode: int32(code),
+		Msg:        msg,
+		Err:        err,
+	}
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	tcpforwardrpc "github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpc"
+)
+
+func TestParseTcpForwardPath(t *testing.T) {
+	tests := []struct {
+		path           string
+		expectedHeader *tcpforwardrpc.ForwardRequest_Header
+	}{
+		{
+			path: "/-/tcp/services/cache/redis:6379",
+			expectedHeader: &tcpforwardrpc.ForwardRequest_Header{
+				Target: &tcpforwardrpc.ForwardRequest_Header_Service{
+					Service: &tcpforwardrpc.Service{
+						Namespace: "cache",
+						Name:      "redis",
+					},
+				},
+				Port: 6379,
+			},
+		},
+		{
+			path: "/-/tcp/hosts/db.example.internal:5432",
+			expectedHeader: &tcpforwardrpc.ForwardRequest_Header{
+				Target: &tcpforwardrpc.ForwardRequest_Header_Host{
+					Host: "db.example.internal",
+				},
+				Port: 5432,
+			},
+		},
+		{
+			path: "/-/tcp/hosts/[fd00::1]:5432",
+			expectedHeader: &tcpforwardrpc.ForwardRequest_Header{
+				Target: &tcpforwardrpc.ForwardRequest_Header_Host{
+					Host: "fd00::1",
+				},
+				Port: 5432,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := parseTcpForwardPath(tc.path)
+			require.NoError(t, err)
+			assert.Empty(t, cmp.Diff(tc.expectedHeader, target.header, protocmp.Transform()))
+		})
+	}
+}
+
+func TestParseTcpForwardPath_Errors(t *testing.T) {
+	paths := []string{
+		"/-/tcp/",
+		"/-/tcp/pods/ns/pod:80",
+		"/-/tcp/services/cache",
+		"/-/tcp/services/cache/redis",
+		"/-/tcp/services//redis:6379",
+		"/-/tcp/services/cache/:6379",
+		"/-/tcp/services/cache/redis:0",
+		"/-/tcp/hosts/db:65536",
+		"/-/tcp/hosts/db:postgres",
+		"/-/tcp/hosts/:5432",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			_, err := parseTcpForwardPath(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTcpForwardTarget_RequestInfo(t *testing.T) {
+	target, err := parseTcpForwardPath("/-/tcp/services/cache/redis:6379")
+	require.NoError(t, err)
+	info := target.requestInfo("/-/tcp/services/cache/redis:6379")
+	assert.True(t, info.IsResourceRequest)
+	assert.Equal(t, "create", info.Verb)
+	assert.Equal(t, "services", info.Resource)
+	assert.Equal(t, "proxy", info.Subresource)
+	assert.Equal(t, "cache", info.Namespace)
+	assert.Equal(t, "redis", info.Name)
+
+	target, err = parseTcpForwardPath("/-/tcp/hosts/db.example.internal:5432")
+	require.NoError(t, err)
+	info = target.requestInfo("/-/tcp/hosts/db.example.internal:5432")
+	assert.Equal(t, "create", info.Verb)
+	assert.Equal(t, "pods", info.Resource)
+	assert.Equal(t, "portforward", info.Subresource)
+	assert.Empty(t, info.Namespace)
+	assert.Empty(t, info.Name)
+}
+
+func TestForwardTcp_Echo(t *testing.T) {
+	p := setupTcpForwardProxy(t, &echoTcpForwardServer{user: "user1"})
+	srv := httptest.NewServer(tcpForwardHandler(t, p, "/-/tcp/services/cache/redis:6379"))
+	defer srv.Close()
+
+	ws, _, err := websocket.Dial(context.Background(), srv.URL, nil) // nolint: bodyclose
+	require.NoError(t, err)
+	conn := websocket.NetConn(context.Background(), ws, websocket.MessageBinary)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("PING\r\n"))
+	require.NoError(t, err)
+	buf := make([]byte, 6)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "PING\r\n", string(buf))
+}
+
+func TestForwardTcp_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		upgrade      bool
+		expectedCode int32
+	}{
+		{
+			name:         "not a WebSocket request",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "not allowed",
+			err:          status.Error(codes.PermissionDenied, "not in the allowlist"),
+			upgrade:      true,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "connection failed",
+			err:          status.Error(codes.Unavailable, "connection refused"),
+			upgrade:      true,
+			expectedCode: http.StatusBadGateway,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := setupTcpForwardProxy(t, &echoTcpForwardServer{err: tc.err})
+			target, err := parseTcpForwardPath("/-/tcp/services/cache/redis:6379")
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodGet, "/prefix/-/tcp/services/cache/redis:6379", nil)
+			if tc.upgrade {
+				r.Header.Set("Upgrade", "websocket")
+			}
+			eResp := p.forwardTcp(context.Background(), zaptest.NewLogger(t), testAgentId, nil, httptest.NewRecorder(), r, target)
+			require.NotNil(t, eResp)
+			assert.Equal(t, tc.expectedCode, eResp.StatusCode)
+		})
+	}
+}
+
+func tcpForwardHandler(t *testing.T, p *kubernetesApiProxy, path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target, err := parseTcpForwardPath(path)
+		if !assert.NoError(t, err) {
+			return
+		}
+		impConfig := &rpc.ImpersonationConfig{
+			Username: "user1",
+		}
+		eResp := p.forwardTcp(r.Context(), zaptest.NewLogger(t), testAgentId, impConfig, w, r, target)
+		assert.Nil(t, eResp)
+	}
+}
+
+// setupTcpForwardProxy returns a proxy that uses the given server as agentk.
+func setupTcpForwardProxy(t *testing.T, agentServer tcpforwardrpc.TcpForwardServer) *kubernetesApiProxy {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	tcpforwardrpc.RegisterTcpForwardServer(s, agentServer)
+	go func() {
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return &kubernetesApiProxy{
+		log:              zaptest.NewLogger(t),
+		tcpForwardClient: tcpforwardrpc.NewTcpForwardClient(conn),
+	}
+}
+
+// echoTcpForwardServer sends received data back or fails with err.
+type echoTcpForwardServer struct {
+	tcpforwardrpc.UnimplementedTcpForwardServer
+	err error
+	// user is the expected impersonated user. Empty means no impersonation.
+	user string
+}
+
+func (s *echoTcpForwardServer) Forward(server tcpforwardrpc.TcpForward_ForwardServer) error {
+	req, err := server.Recv()
+	if err != nil {
+		return err
+	}
+	if req.GetHeader() == nil {
+		return errors.New("expecting header")
+	}
+	if user := req.GetHeader().GetImpConfig().GetUsername(); user != s.user {
+		return status.Errorf(codes.PermissionDenied, "unexpected user %q", user)
+	}
+	if s.err != nil {
+		return s.err
+	}
+	err = server.Send(&tcpforwardrpc.ForwardResponse{
+		Message: &tcpforwardrpc.ForwardResponse_Header_{
+			Header: &tcpforwardrpc.ForwardResponse_Header{},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		req, err = server.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		err = server.Send(&tcpforwardrpc.ForwardResponse{
+			Message: &tcpforwardrpc.ForwardResponse_Data_{
+				Data: &tcpforwardrpc.ForwardResponse_Data{
+					Data: req.GetData().Data,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
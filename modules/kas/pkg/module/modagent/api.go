@@ -13,6 +13,7 @@ import (
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/kubectl/pkg/cmd/util"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
@@ -36,6 +37,8 @@ type Config struct {
 	Api       Api
 	// K8sUtilFactory provides means to interact with the Kubernetes cluster agentk is running in.
 	K8sUtilFactory util.Factory
+	// EventRecorder records Kubernetes events on behalf of agentk.
+	EventRecorder record.EventRecorder
 	// KasConn is the gRPC connection to gitlab-kas.
 	KasConn grpc.ClientConnInterface
 	// Server is a gRPC server that can be used to expose API endpoints to gitlab-kas and/or GitLab.
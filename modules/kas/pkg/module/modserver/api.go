@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/redis/rueidis"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
@@ -16,6 +15,7 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/observability"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/usage_metrics"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
 )
 
@@ -67,7 +67,9 @@ type Config struct {
 	TraceProvider   trace.TracerProvider
 	TracePropagator propagation.TextMapPropagator
 	MeterProvider   metric.MeterProvider
-	RedisClient     rueidis.Client
+	// Storage is where data, that kas replicas share, is kept.
+	// Use constructors in the redistool package to work with it.
+	Storage redistool.Backend
 	// KasName is a string "gitlab-kas". Can be used as a user agent, server name, service name, etc.
 	KasName string
 	// Version is gitlab-kas version.
@@ -117,6 +117,8 @@ type MetricServer struct {
 	Gatherer              prometheus.Gatherer
 	Registerer            prometheus.Registerer
 	ProbeRegistry         *ProbeRegistry
+	// Handlers are additional handlers to serve, keyed by URL path.
+	Handlers map[string]http.Handler
 }
 
 func (s *MetricServer) Run(ctx context.Context) error {
@@ -134,6 +136,9 @@ func (s *MetricServer) ConstructHandler() http.Handler {
 	s.probesHandler(mux) // nolint: contextcheck
 	s.pprofHandler(mux)
 	s.prometheusHandler(mux)
+	for urlPath, handler := range s.Handlers {
+		mux.Handle(urlPath, s.setHeader(handler))
+	}
 	return mux
 }
 
@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -15,6 +16,8 @@ import (
 
 type Factory struct {
 	Gatherer prometheus.Gatherer
+	// Handlers are additional handlers to serve on the observability endpoint, keyed by URL path.
+	Handlers map[string]http.Handler
 }
 
 func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
@@ -43,6 +46,7 @@ func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
 		registerer:    config.Registerer,
 		serverName:    fmt.Sprintf("%s/%s/%s", config.KasName, config.Version, config.CommitId),
 		probeRegistry: config.ProbeRegistry,
+		handlers:      f.Handlers,
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"net"
+	"net/http"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/kascfg"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
@@ -22,6 +23,7 @@ type module struct {
 	registerer    prometheus.Registerer
 	serverName    string
 	probeRegistry *observability2.ProbeRegistry
+	handlers      map[string]http.Handler
 }
 
 func (m *module) Run(ctx context.Context) (retErr error) {
@@ -49,6 +51,7 @@ func (m *module) Run(ctx context.Context) (retErr error) {
 		Gatherer:              m.gatherer,
 		Registerer:            m.registerer,
 		ProbeRegistry:         m.probeRegistry,
+		Handlers:              m.handlers,
 	}
 	return metricSrv.Run(ctx)
 }
@@ -0,0 +1,117 @@
+package agent
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// adaptiveSampleInterval is how often the request rate is sampled and the pool is resized.
+	adaptiveSampleInterval = 10 * time.Second
+	// adaptiveHeadroom is how many times more idle connections to keep than the estimated number of
+	// requests that arrive while a new connection is being set up.
+	adaptiveHeadroom = 2
+	// defaultSetupTime is used until the setup time of a connection has been observed.
+	defaultSetupTime = time.Second
+	// maxSetupTime caps observed setup times. Setting up a connection takes longer while kas is unreachable
+	// and that must not be mistaken for load.
+	maxSetupTime = 10 * time.Second
+)
+
+type adaptivePoolConfig struct {
+	window             time.Duration
+	minIdleConnections int32
+	maxIdleConnections int32
+}
+
+type adaptiveSample struct {
+	rate       float64 // activations per second
+	setupTotal time.Duration
+	setups     int64
+}
+
+// adaptiveSizer estimates the minimum number of idle connections.
+// A request that arrives when there are no idle connections has to wait for a new connection to be set up.
+// To avoid that, the pool needs enough idle connections to serve requests that arrive during setup of new ones.
+// That is the peak request rate of the window multiplied by the setup time.
+type adaptiveSizer struct {
+	cfg adaptivePoolConfig
+	// samples is a ring buffer of samples of the window.
+	samples    []adaptiveSample
+	next       int
+	lastSample time.Time
+	setupTime  time.Duration // estimate from the last sample
+
+	// Counters since the last sample.
+	activations int64
+	setupTotal  time.Duration
+	setups      int64
+}
+
+func newAdaptiveSizer(cfg adaptivePoolConfig, now time.Time) *adaptiveSizer {
+	s := &adaptiveSizer{
+		lastSample: now,
+		setupTime:  defaultSetupTime,
+	}
+	s.setConfig(cfg)
+	return s
+}
+
+// setConfig applies new configuration. Samples are discarded if the window changes.
+func (s *adaptiveSizer) setConfig(cfg adaptivePoolConfig) {
+	if cfg.window != s.cfg.window {
+		n := int((cfg.window + adaptiveSampleInterval - 1) / adaptiveSampleInterval)
+		s.samples = make([]adaptiveSample, max(n, 1))
+		s.next = 0
+	}
+	s.cfg = cfg
+}
+
+// activated records that a connection started carrying a request.
+func (s *adaptiveSizer) activated() {
+	s.activations++
+}
+
+// connected records how long it took to set up a connection.
+func (s *adaptiveSizer) connected(setupTime time.Duration) {
+	s.setupTotal += min(setupTime, maxSetupTime)
+	s.setups++
+}
+
+// sample records activity since the previous sample and returns the new minimum number of idle connections.
+func (s *adaptiveSizer) sample(now time.Time) int32 {
+	elapsed := now.Sub(s.lastSample)
+	if elapsed <= 0 {
+		elapsed = adaptiveSampleInterval
+	}
+	s.lastSample = now
+	s.samples[s.next] = adaptiveSample{
+		rate:       float64(s.activations) / elapsed.Seconds(),
+		setupTotal: s.setupTotal,
+		setups:     s.setups,
+	}
+	s.next = (s.next + 1) % len(s.samples)
+	s.activations = 0
+	s.setupTotal = 0
+	s.setups = 0
+
+	var (
+		peakRate   float64
+		setupTotal time.Duration
+		setups     int64
+	)
+	for _, smp := range s.samples {
+		peakRate = max(peakRate, smp.rate)
+		setupTotal += smp.setupTotal
+		setups += smp.setups
+	}
+	if setups > 0 { // otherwise keep the previous estimate
+		s.setupTime = setupTotal / time.Duration(setups)
+	}
+	needed := math.Ceil(peakRate * s.setupTime.Seconds() * adaptiveHeadroom)
+	return s.clamp(int32(min(needed, math.MaxInt32)))
+}
+
+func (s *adaptiveSizer) clamp(n int32) int32 {
+	return min(max(n, s.cfg.minIdleConnections), s.cfg.maxIdleConnections)
+}
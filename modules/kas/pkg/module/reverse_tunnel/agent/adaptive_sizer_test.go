@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveSizer_IdleClusterUsesLowerBound(t *testing.T) {
+	now := time.Now()
+	s := newAdaptiveSizer(adaptivePoolConfig{window: time.Minute, minIdleConnections: 1, maxIdleConnections: 50}, now)
+	assert.EqualValues(t, 1, s.sample(now.Add(adaptiveSampleInterval)))
+}
+
+func TestAdaptiveSizer_ScalesWithPeakRateAndSetupTime(t *testing.T) {
+	now := time.Now()
+	s := newAdaptiveSizer(adaptivePoolConfig{window: time.Minute, minIdleConnections: 1, maxIdleConnections: 50}, now)
+	// 100 requests in 10s = 10 rps, 500ms to set up a connection. 10 * 0.5 * 2 = 10.
+	for i := 0; i < 100; i++ {
+		s.activated()
+	}
+	s.connected(400 * time.Millisecond)
+	s.connected(600 * time.Millisecond)
+	now = now.Add(adaptiveSampleInterval)
+	assert.EqualValues(t, 10, s.sample(now))
+	// The peak is remembered for the rest of the window. Setup time estimate is kept.
+	for i := 0; i < 5; i++ {
+		now = now.Add(adaptiveSampleInterval)
+		assert.EqualValues(t, 10, s.sample(now))
+	}
+	// The peak leaves the window.
+	now = now.Add(adaptiveSampleInterval)
+	assert.EqualValues(t, 1, s.sample(now))
+}
+
+func TestAdaptiveSizer_ClampsToUpperBound(t *testing.T) {
+	now := time.Now()
+	s := newAdaptiveSizer(adaptivePoolConfig{window: time.Minute, minIdleConnections: 1, maxIdleConnections: 5}, now)
+	for i := 0; i < 1000; i++ {
+		s.activated()
+	}
+	s.connected(time.Hour) // capped at maxSetupTime
+	assert.EqualValues(t, 5, s.sample(now.Add(adaptiveSampleInterval)))
+	assert.Equal(t, maxSetupTime, s.setupTime)
+}
+
+func TestAdaptiveSizer_SetConfigResetsSamplesOnWindowChange(t *testing.T) {
+	now := time.Now()
+	s := newAdaptiveSizer(adaptivePoolConfig{window: time.Minute, minIdleConnections: 1, maxIdleConnections: 50}, now)
+	for i := 0; i < 100; i++ {
+		s.activated()
+	}
+	now = now.Add(adaptiveSampleInterval)
+	assert.EqualValues(t, 20, s.sample(now)) // default setup time of 1s
+	s.setConfig(adaptivePoolConfig{window: time.Minute, minIdleConnections: 1, maxIdleConnections: 10})
+	assert.EqualValues(t, 10, s.sample(now.Add(adaptiveSampleInterval)))
+	s.setConfig(adaptivePoolConfig{window: 15 * time.Second, minIdleConnections: 1, maxIdleConnections: 10})
+	assert.Len(t, s.samples, 2)
+	assert.EqualValues(t, 1, s.sample(now.Add(2*adaptiveSampleInterval)))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -23,6 +24,7 @@ const (
 	requestInfoNumber protoreflect.FieldNumber = 1
 	messageNumber     protoreflect.FieldNumber = 2
 	closeSendNumber   protoreflect.FieldNumber = 3
+	muxNumber         protoreflect.FieldNumber = 4
 )
 
 var (
@@ -33,6 +35,9 @@ var (
 )
 
 type connectionInterface interface {
+	// Run handles connections until pollCtx is done.
+	// attemptCtx aborts the current connection unless it has started handling a stream already.
+	// A multiplexed connection is retired instead: kas stops opening streams on it and closes it once they are done.
 	Run(attemptCtx, pollCtx context.Context)
 }
 
@@ -45,6 +50,12 @@ type connection struct {
 	pollConfig         retry.PollConfigFactory
 	onActive           func(connectionInterface)
 	onIdle             func(connectionInterface)
+	// onGoAway is called when kas asks to replace the multiplexed tunnel.
+	onGoAway func(connectionInterface)
+	// multiplexing is advertised to kas. kas decides whether to multiplex streams over the tunnel or not.
+	multiplexing *rpc2.Multiplexing
+	// onConnected is called with the time it took to set up the tunnel.
+	onConnected func(connectionInterface, time.Duration)
 }
 
 func (c *connection) Run(attemptCtx, pollCtx context.Context) {
@@ -64,11 +75,12 @@ func (c *connection) Run(attemptCtx, pollCtx context.Context) {
 	c.log.Debug("Connection done")
 }
 
-func (c *connection) attempt(ctx context.Context) (retErr error) {
+func (c *connection) attempt(attemptCtx context.Context) (retErr error) {
 	defer c.onIdle(c)
-	ctx, cancel, stopPropagation := propagateUntil(ctx)
+	ctx, cancel, stopPropagation := propagateUntil(attemptCtx)
 	defer cancel()
 
+	start := time.Now()
 	tunnel, err := c.client.Connect(ctx, grpc.WaitForReady(true))
 	if err != nil {
 		return fmt.Errorf("Connect(): %w", err) // wrap
@@ -76,7 +88,9 @@ func (c *connection) attempt(ctx context.Context) (retErr error) {
 	err = tunnel.Send(&rpc2.ConnectRequest{
 		Msg: &rpc2.ConnectRequest_Descriptor_{
 			Descriptor_: &rpc2.Descriptor{
-				AgentDescriptor: c.descriptor,
+				AgentDescriptor:       c.descriptor,
+				SupportedCompressions: rpc2.SupportedCompressions,
+				Multiplexing:          c.multiplexing,
 			},
 		},
 	})
@@ -86,14 +100,18 @@ func (c *connection) attempt(ctx context.Context) (retErr error) {
 		}
 		return fmt.Errorf("Send(descriptor): %w", err) // wrap
 	}
+	c.onConnected(c, time.Since(start))
 	var (
 		clientStream grpc.ClientStream
+		compression  rpc2.Compression
 		g            errgroup.Group
+		muxConn      *muxConnection
 	)
 	// pipe tunnel -> internal client
 	err1 := c.streamVisitor.Visit(tunnel,
 		grpctool2.WithCallback(requestInfoNumber, func(reqInfo *rpc2.RequestInfo) error {
 			c.onActive(c)
+			compression = reqInfo.Compression
 			outgoingCtx := metadata.NewOutgoingContext(ctx, reqInfo.Metadata())
 			clientStream, err = c.internalServerConn.NewStream(outgoingCtx, &proxyStreamDesc, reqInfo.MethodName)
 			if err != nil {
@@ -105,13 +123,17 @@ func (c *connection) attempt(ctx context.Context) (retErr error) {
 			stopPropagation()
 			g.Go(func() error {
 				// pipe internal client -> tunnel
-				return pipeInternalClientIntoTunnel(tunnel, clientStream)
+				return pipeInternalClientIntoTunnel(tunnel, clientStream, compression)
 			})
 			return nil
 		}),
 		grpctool2.WithCallback(messageNumber, func(message *rpc2.Message) error {
+			data, err := message.Decompress(compression)
+			if err != nil {
+				return fmt.Errorf("decompress message: %w", err)
+			}
 			err = clientStream.SendMsg(&grpctool2.RawFrame{
-				Data: message.Data,
+				Data: data,
 			})
 			if err != nil {
 				if err == io.EOF { // nolint:errorlint
@@ -128,7 +150,35 @@ func (c *connection) attempt(ctx context.Context) (retErr error) {
 			}
 			return nil
 		}),
+		grpctool2.WithCallback(muxNumber, func(resp *rpc2.MuxResponse) error {
+			if muxConn == nil {
+				// kas has switched the tunnel into multiplexed mode. The tunnel can take more streams, so it's not
+				// accounted as active and stays idle until kas closes it.
+				// Same as above, don't interrupt running requests.
+				stopPropagation()
+				muxConn = &muxConnection{
+					log:                c.log,
+					tunnel:             tunnel,
+					internalServerConn: c.internalServerConn,
+					ctx:                ctx,
+					maxStreams:         int(c.multiplexing.GetMaxStreams()),
+					windowSize:         c.multiplexing.GetInitialWindowSize(),
+					streams:            make(map[uint64]*muxStream),
+					onGoAway: func() {
+						c.onGoAway(c)
+					},
+				}
+				// attemptCtx no longer aborts the tunnel, retire it instead.
+				muxConn.goAwayWhenDone(attemptCtx.Done())
+			}
+			return muxConn.handle(resp)
+		}),
 	)
+	if muxConn != nil {
+		// kas has closed the tunnel or there was an error. Either way, abort the remaining streams.
+		cancel()
+		muxConn.wait()
+	}
 	if err1 != nil {
 		cancel()
 	}
@@ -139,7 +189,7 @@ func (c *connection) attempt(ctx context.Context) (retErr error) {
 	return err1
 }
 
-func pipeInternalClientIntoTunnel(tunnel rpc2.ReverseTunnel_ConnectClient, clientStream grpc.ClientStream) error {
+func pipeInternalClientIntoTunnel(tunnel rpc2.ReverseTunnel_ConnectClient, clientStream grpc.ClientStream, compression rpc2.Compression) error {
 	header, err := clientStream.Header()
 	if err != nil {
 		return sendErrorToTunnel(err, tunnel)
@@ -183,11 +233,13 @@ func pipeInternalClientIntoTunnel(tunnel rpc2.ReverseTunnel_ConnectClient, clien
 			}
 			return sendErrorToTunnel(recvErr, tunnel)
 		}
+		msg, err := rpc2.NewMessage(compression, frame.Data)
+		if err != nil {
+			return sendErrorToTunnel(err, tunnel)
+		}
 		err = tunnel.Send(&rpc2.ConnectRequest{
 			Msg: &rpc2.ConnectRequest_Message{
-				Message: &rpc2.Message{
-					Data: frame.Data,
-				},
+				Message: msg,
 			},
 		})
 		if err != nil {
@@ -230,16 +282,9 @@ func sendErrorToTunnel(errToSend error, tunnel rpc2.ReverseTunnel_ConnectClient)
 // To cancel the returned context use the returned context.CancelFunc.
 func propagateUntil(ctx context.Context) (context.Context, context.CancelFunc, func() /* stop */) {
 	ctxInternal, cancel := context.WithCancel(context.Background())
-	stopPropagation := make(chan struct{})
-	go func() {
-		select {
-		case <-ctx.Done():
-			cancel()
-		case <-ctxInternal.Done():
-		case <-stopPropagation:
-		}
-	}()
+	// stop() is synchronous so that a multiplexed tunnel is retired rather than canceled when ctx is done right after.
+	stop := context.AfterFunc(ctx, cancel)
 	return ctxInternal, cancel, func() {
-		close(stopPropagation)
+		stop()
 	}
 }
@@ -9,7 +9,9 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/wait"
 
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/info"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
 )
 
 type state int8
@@ -19,18 +21,32 @@ const (
 	_ state = iota
 	idle
 	active
+	// draining connections are finishing their streams. A replacement connection has been started for each of them.
+	draining
 	stopped
 )
 
 type connectionInfo struct {
-	pollCancel context.CancelFunc
-	lastActive time.Time
-	state      state
+	// attemptCancel aborts the current connection attempt unless it has started handling a stream already.
+	// A multiplexed connection is retired instead, kas closes it once its streams are done.
+	attemptCancel context.CancelFunc
+	pollCancel    context.CancelFunc
+	lastActive    time.Time
+	state         state
+}
+
+// poolConfig is the configuration of the pool of connections.
+type poolConfig struct {
+	minIdleConnections int32
+	maxConnections     int32
+	scaleUpStep        int32
+	maxIdleTime        time.Duration
+	adaptive           *adaptivePoolConfig // nil if not enabled
 }
 
 // connectionManager manages a pool of connections and their lifecycles.
 type connectionManager struct {
-	mu          sync.Mutex // protects connections,idleConnections,activeConnections
+	mu          sync.Mutex // protects connections,idleConnections,activeConnections and the pool configuration
 	connections map[connectionInterface]connectionInfo
 	// Counters to track connections in those states. There may be timedOut connections in the map too.
 	idleConnections   int32
@@ -45,17 +61,81 @@ type connectionManager struct {
 	// scaleUpStep is the number of new connections to start when below minIdleConnections.
 	scaleUpStep int32
 	// maxIdleTime is the maximum duration of time a connection can stay in an idle state.
-	maxIdleTime       time.Duration
+	maxIdleTime time.Duration
+	// adaptive sizes minIdleConnections from the observed load. nil if not enabled.
+	adaptive          *adaptiveSizer
 	connectionFactory connectionFactory
 	agentDescriptor   *info.AgentDescriptor
+	// reconnect is dispatched to to replace all connections. May be nil.
+	reconnect *syncz.Subscriptions[struct{}]
 }
 
-func (m *connectionManager) Run(ctx context.Context) {
+// Run starts connections and applies configuration changes from cfg until ctx is done.
+func (m *connectionManager) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) {
 	defer m.wg.Wait() // blocks here until ctx is done and all connections exit
+	m.mu.Lock()
+	m.ensureMinIdleLocked(ctx)
+	m.mu.Unlock()
+	if m.reconnect != nil {
+		m.wg.StartWithContext(ctx, func(ctx context.Context) {
+			m.reconnect.On(ctx, func(ctx context.Context, _ struct{}) {
+				m.reconnectAll(ctx)
+			})
+		})
+	}
+	ticker := time.NewTicker(adaptiveSampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case config, ok := <-cfg:
+			if !ok {
+				cfg = nil // keep running until ctx is done
+				continue
+			}
+			m.setConfig(ctx, newPoolConfig(config.ReverseTunnel))
+		case <-ticker.C:
+			m.resize(ctx)
+		}
+	}
+}
+
+func (m *connectionManager) setConfig(ctx context.Context, cfg poolConfig) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	for m.idleConnections < m.minIdleConnections {
-		m.startConnectionLocked(ctx)
+	m.maxConnections = cfg.maxConnections
+	m.scaleUpStep = cfg.scaleUpStep
+	m.maxIdleTime = cfg.maxIdleTime
+	switch {
+	case cfg.adaptive == nil:
+		m.adaptive = nil
+		m.minIdleConnections = cfg.minIdleConnections
+	case m.adaptive == nil:
+		m.adaptive = newAdaptiveSizer(*cfg.adaptive, time.Now())
+		m.minIdleConnections = m.adaptive.clamp(cfg.minIdleConnections)
+	default: // keep the current estimate
+		m.adaptive.setConfig(*cfg.adaptive)
+		m.minIdleConnections = m.adaptive.clamp(m.minIdleConnections)
+	}
+	m.ensureMinIdleLocked(ctx)
+}
+
+// resize updates the minimum number of idle connections if adaptive sizing is enabled.
+func (m *connectionManager) resize(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.adaptive == nil {
+		return
+	}
+	m.minIdleConnections = m.adaptive.sample(time.Now())
+	m.ensureMinIdleLocked(ctx)
+}
+
+// ensureMinIdleLocked starts connections until there are minIdleConnections idle ones or maxConnections in total.
+func (m *connectionManager) ensureMinIdleLocked(rootCtx context.Context) {
+	for m.idleConnections < m.minIdleConnections && m.idleConnections+m.activeConnections < m.maxConnections {
+		m.startConnectionLocked(rootCtx)
 	}
 }
 
@@ -65,18 +145,50 @@ func (m *connectionManager) startConnectionLocked(rootCtx context.Context) {
 		func(c connectionInterface) {
 			m.onActive(rootCtx, c)
 		},
-		m.onIdle)
+		m.onIdle,
+		func(c connectionInterface) {
+			m.onGoAway(rootCtx, c)
+		},
+		m.onConnected)
+	attemptCtx, attemptCancel := context.WithCancel(rootCtx)
 	pollCtx, pollCancel := context.WithCancel(rootCtx)
 	m.connections[c] = connectionInfo{
-		pollCancel: pollCancel,
-		state:      idle,
+		attemptCancel: attemptCancel,
+		pollCancel:    pollCancel,
+		state:         idle,
 	}
-	m.wg.StartWithContext(rootCtx, func(rootCtx context.Context) {
+	m.wg.Start(func() {
 		defer m.onStop(c)
-		c.Run(rootCtx, pollCtx)
+		c.Run(attemptCtx, pollCtx)
 	})
 }
 
+// reconnectAll replaces all connections with new ones.
+// Idle connections are stopped right away, active ones are stopped once they are done with their stream.
+func (m *connectionManager) reconnectAll(rootCtx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for c, i := range m.connections {
+		switch i.state { // nolint: exhaustive
+		case idle:
+			// Close the tunnel too. Otherwise kas may route a request to it before it's closed.
+			// Multiplexed tunnels stay idle while they have streams. kas stops routing to them and closes them once
+			// the streams are done.
+			i.attemptCancel()
+			i.pollCancel()
+			m.idleConnections--
+			i.state = stopped
+		case active: // same as onGoAway(), but the replacement is started below
+			m.activeConnections--
+			i.state = draining
+		default:
+			continue
+		}
+		m.connections[c] = i
+	}
+	m.ensureMinIdleLocked(rootCtx)
+}
+
 func (m *connectionManager) onActive(rootCtx context.Context, c connectionInterface) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -87,6 +199,9 @@ func (m *connectionManager) onActive(rootCtx context.Context, c connectionInterf
 		m.connections[c] = i
 		m.idleConnections--
 		m.activeConnections++
+		if m.adaptive != nil {
+			m.adaptive.activated()
+		}
 		if m.idleConnections < m.minIdleConnections {
 			// Not enough idle connections. Must scale up the number of connections.
 			// Ensure we don't go above the limit.
@@ -102,8 +217,36 @@ func (m *connectionManager) onActive(rootCtx context.Context, c connectionInterf
 		}
 	case active:
 		panic(errors.New("connection is already active"))
+	case draining:
+		panic(errors.New("invalid state: draining"))
 	case stopped:
-		panic(errors.New("invalid state: stopped"))
+		// reconnectAll() has stopped the connection, but kas has routed a request to it before it was closed.
+		// The connection handles the request and exits once it's done.
+	default:
+		panic(fmt.Errorf("unknown state: %d", i.state))
+	}
+}
+
+// onGoAway starts a replacement for a connection that kas is going to close once its streams are done.
+// This way there is no gap in the number of available connections, and the replacement most likely goes to
+// another kas instance since the one that sent GoAway is shutting down.
+func (m *connectionManager) onGoAway(rootCtx context.Context, c connectionInterface) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.connections[c]
+	switch i.state { // nolint: exhaustive
+	case idle: // idle -> draining transition. Multiplexed connections stay idle while they have streams.
+		i.state = draining
+		m.connections[c] = i
+		m.idleConnections--
+		m.startConnectionLocked(rootCtx)
+	case active: // active -> draining transition
+		i.state = draining
+		m.connections[c] = i
+		m.activeConnections--
+		m.startConnectionLocked(rootCtx)
+	case draining, stopped:
+		// Already replaced.
 	default:
 		panic(fmt.Errorf("unknown state: %d", i.state))
 	}
@@ -132,17 +275,32 @@ func (m *connectionManager) onIdle(c connectionInterface) {
 		m.connections[c] = i
 		m.idleConnections++
 		m.activeConnections--
+	case draining: // draining -> stopped transition
+		// kas has closed the tunnel. The connection has been replaced already so stop it.
+		i.pollCancel()
+		i.state = stopped
+		m.connections[c] = i
 	case stopped:
-		panic(errors.New("invalid state: stopped"))
+		// reconnectAll() has stopped the connection. Nothing to do.
 	default:
 		panic(fmt.Errorf("unknown state: %d", i.state))
 	}
 }
 
+func (m *connectionManager) onConnected(c connectionInterface, setupTime time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.adaptive != nil {
+		m.adaptive.connected(setupTime)
+	}
+}
+
 func (m *connectionManager) onStop(c connectionInterface) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	i := m.connections[c]
+	i.attemptCancel()
+	i.pollCancel()
 	delete(m.connections, c)
 	if i.state != stopped {
 		// onIdle() decrements this field if maxIdleTime has been reached.
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"io"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -10,8 +11,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/info"
+	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/testhelpers"
 )
 
 var (
@@ -24,7 +34,7 @@ func TestConnManager_StartsMinIdleConnectionsOnRun(t *testing.T) {
 	cm, conns, mu := setupConnManager(t)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	go cm.Run(ctx)
+	go cm.Run(ctx, nil)
 	require.Eventually(t, func() bool {
 		cm.mu.Lock()
 		defer cm.mu.Unlock()
@@ -43,7 +53,7 @@ func TestConnManager_ScalesIdleConnectionsToMaxAndThenToMin(t *testing.T) {
 	var activated int
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	go cm.Run(ctx)
+	go cm.Run(ctx, nil)
 	// Scale to max
 	require.Eventually(t, func() bool {
 		mu.Lock()
@@ -84,6 +94,260 @@ func TestConnManager_ScalesIdleConnectionsToMaxAndThenToMin(t *testing.T) {
 	require.Len(t, *conns, int(cm.maxConnections))
 }
 
+func TestConnManager_ReplacesConnectionOnGoAway(t *testing.T) {
+	cm, conns, mu := setupConnManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cm.Run(ctx, nil)
+	var c *mockConnection
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(*conns) == 0 {
+			return false
+		}
+		c = (*conns)[0]
+		return true
+	}, time.Minute, 10*time.Millisecond)
+	// A multiplexed connection stays idle. kas is draining, a replacement is started right away.
+	c.onGoAway(c)
+	c.onGoAway(c) // no-op
+	cm.mu.Lock()
+	assert.Equal(t, draining, cm.connections[c].state)
+	assert.Zero(t, cm.activeConnections)
+	assert.EqualValues(t, 1, cm.idleConnections)
+	cm.mu.Unlock()
+	mu.Lock()
+	assert.Len(t, *conns, 2)
+	mu.Unlock()
+	// kas has closed the tunnel, the connection stops.
+	c.onIdle(c)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&c.stopped) == 1
+	}, time.Minute, 10*time.Millisecond)
+	cm.mu.Lock()
+	_, ok := cm.connections[c] // NotContains would compare connections field by field, racing with them
+	assert.False(t, ok)
+	assert.EqualValues(t, 1, cm.idleConnections)
+	cm.mu.Unlock()
+	cancel()
+	cm.wg.Wait()
+}
+
+func TestConnManager_ReconnectReplacesAllConnections(t *testing.T) {
+	cm, conns, mu := setupConnManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cm.Run(ctx, nil)
+	var c *mockConnection
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(*conns) == 0 {
+			return false
+		}
+		c = (*conns)[0]
+		return true
+	}, time.Minute, 10*time.Millisecond)
+	c.onActive(c) // scales up to keep an idle connection
+	mu.Lock()
+	oldConns := append([]*mockConnection(nil), *conns...)
+	mu.Unlock()
+	require.Len(t, oldConns, 1+int(cm.scaleUpStep))
+
+	cm.reconnectAll(ctx)
+	cm.mu.Lock()
+	assert.Equal(t, draining, cm.connections[c].state)
+	cm.mu.Unlock()
+	// Idle connections are stopped right away.
+	for _, oc := range oldConns[1:] {
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&oc.stopped) == 1
+		}, time.Minute, 10*time.Millisecond)
+	}
+	cm.mu.Lock()
+	assert.Zero(t, cm.activeConnections)
+	assert.EqualValues(t, cm.minIdleConnections, cm.idleConnections)
+	cm.mu.Unlock()
+	mu.Lock()
+	assert.Len(t, *conns, len(oldConns)+int(cm.minIdleConnections))
+	mu.Unlock()
+	// The active connection stops once its stream is done.
+	c.onIdle(c)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&c.stopped) == 1
+	}, time.Minute, 10*time.Millisecond)
+	cancel()
+	cm.wg.Wait()
+}
+
+func TestConnManager_ReconnectAbortsIdleConnection(t *testing.T) {
+	client, _, _, c := setupConnection(t)
+	c.pollConfig = retry.NewPollConfigFactory(0, retry.NewExponentialBackoffFactory(time.Millisecond, time.Millisecond, time.Minute, 2, 0))
+	connecting := make(chan struct{})
+	client.EXPECT().
+		Connect(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, opts ...grpc.CallOption) (rpc2.ReverseTunnel_ConnectClient, error) {
+			close(connecting)
+			<-ctx.Done() // blocks until the attempt is aborted
+			return nil, ctx.Err()
+		})
+	var replacements []*mockConnection
+	started := false
+	cm := &connectionManager{
+		connections:        make(map[connectionInterface]connectionInfo),
+		minIdleConnections: 1,
+		maxConnections:     1,
+		scaleUpStep:        1,
+		maxIdleTime:        time.Minute,
+		connectionFactory: func(agentDescriptor *info.AgentDescriptor, onActive, onIdle, onGoAway func(connectionInterface),
+			onConnected func(connectionInterface, time.Duration)) connectionInterface {
+			if started {
+				r := &mockConnection{
+					onActive: onActive,
+					onIdle:   onIdle,
+					onGoAway: onGoAway,
+				}
+				replacements = append(replacements, r)
+				return r
+			}
+			started = true
+			c.onActive = onActive
+			c.onIdle = onIdle
+			c.onGoAway = onGoAway
+			c.onConnected = onConnected
+			return c
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cm.Run(ctx, nil)
+	<-connecting
+	cm.reconnectAll(ctx)
+	// The connection stops without waiting for kas to close the tunnel.
+	require.Eventually(t, func() bool {
+		cm.mu.Lock()
+		defer cm.mu.Unlock()
+		_, ok := cm.connections[c]
+		return !ok
+	}, time.Minute, 10*time.Millisecond)
+	cm.mu.Lock()
+	assert.EqualValues(t, 1, cm.idleConnections)
+	assert.Len(t, cm.connections, 1)
+	cm.mu.Unlock()
+	cancel()
+	cm.wg.Wait()
+	require.Len(t, replacements, 1)
+	assert.EqualValues(t, 1, replacements[0].stopped)
+}
+
+func TestConnManager_ReconnectRetiresMultiplexedConnection(t *testing.T) {
+	cm, c, pong, goAway, replacements := setupMuxConnManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cm.Run(ctx, nil)
+	<-pong // the tunnel is multiplexed now
+	cm.reconnectAll(ctx)
+	// The connection asks kas to retire the tunnel and stops once kas closes it.
+	<-goAway
+	require.Eventually(t, func() bool {
+		cm.mu.Lock()
+		defer cm.mu.Unlock()
+		_, ok := cm.connections[c]
+		return !ok
+	}, time.Minute, 10*time.Millisecond)
+	cm.mu.Lock()
+	assert.EqualValues(t, 1, cm.idleConnections)
+	assert.Len(t, cm.connections, 1)
+	cm.mu.Unlock()
+	cancel()
+	cm.wg.Wait()
+	require.Len(t, *replacements, 1)
+	assert.EqualValues(t, 1, (*replacements)[0].stopped)
+}
+
+func TestConnManager_TokenRotationRetiresMultiplexedConnection(t *testing.T) {
+	cm, c, pong, goAway, replacements := setupMuxConnManager(t)
+	cm.reconnect = &syncz.Subscriptions[struct{}]{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cm.Run(ctx, nil)
+	<-pong // the tunnel is multiplexed now
+	// The token file watcher dispatches a reconnect after a rotation. Dispatch until the manager has subscribed.
+	require.Eventually(t, func() bool {
+		cm.reconnect.Dispatch(ctx, struct{}{})
+		select {
+		case <-goAway:
+			return true
+		default:
+			return false
+		}
+	}, time.Minute, 10*time.Millisecond)
+	// The tunnel that has been authenticated with the old token is closed once kas has retired it.
+	// Replacements of replacements stop too if the reconnect has been dispatched more than once.
+	require.Eventually(t, func() bool {
+		cm.mu.Lock()
+		defer cm.mu.Unlock()
+		_, ok := cm.connections[c]
+		return !ok && cm.idleConnections == 1 && len(cm.connections) == 1
+	}, time.Minute, 10*time.Millisecond)
+	cancel()
+	cm.wg.Wait()
+	assert.NotEmpty(t, *replacements)
+}
+
+func TestConnManager_AppliesConfiguration(t *testing.T) {
+	cm, conns, mu := setupConnManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := make(chan *agentcfg.AgentConfiguration)
+	go cm.Run(ctx, cfg)
+	cfg <- &agentcfg.AgentConfiguration{
+		ReverseTunnel: &agentcfg.ReverseTunnelCF{
+			MinIdleConnections: 5,
+			MaxConnections:     4,
+			ScaleUpStep:        3,
+			MaxIdleTime:        durationpb.New(time.Second),
+		},
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(*conns) == 4 // capped by MaxConnections
+	}, time.Minute, 10*time.Millisecond)
+	cm.mu.Lock()
+	assert.EqualValues(t, 5, cm.minIdleConnections)
+	assert.EqualValues(t, 3, cm.scaleUpStep)
+	assert.Equal(t, time.Second, cm.maxIdleTime)
+	assert.Nil(t, cm.adaptive)
+	cm.mu.Unlock()
+
+	cfg <- &agentcfg.AgentConfiguration{
+		ReverseTunnel: &agentcfg.ReverseTunnelCF{
+			MinIdleConnections: 8,
+			MaxConnections:     10,
+			ScaleUpStep:        3,
+			MaxIdleTime:        durationpb.New(time.Second),
+			Adaptive: &agentcfg.ReverseTunnelAdaptiveCF{
+				Window:             durationpb.New(time.Minute),
+				MinIdleConnections: 1,
+				MaxIdleConnections: 6,
+			},
+		},
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(*conns) == 6 // starting value clamped to the adaptive upper bound
+	}, time.Minute, 10*time.Millisecond)
+	cm.mu.Lock()
+	assert.EqualValues(t, 6, cm.minIdleConnections)
+	assert.NotNil(t, cm.adaptive)
+	cm.mu.Unlock()
+	cancel()
+	cm.wg.Wait()
+}
+
 func setupConnManager(t *testing.T) (*connectionManager, *[]*mockConnection, *sync.Mutex) {
 	t.Parallel()
 	var conns []*mockConnection
@@ -94,10 +358,12 @@ func setupConnManager(t *testing.T) (*connectionManager, *[]*mockConnection, *sy
 		maxConnections:     maxConnections,
 		scaleUpStep:        2,
 		maxIdleTime:        time.Minute,
-		connectionFactory: func(agentDescriptor *info.AgentDescriptor, onActive, onIdle func(connectionInterface)) connectionInterface {
+		connectionFactory: func(agentDescriptor *info.AgentDescriptor, onActive, onIdle, onGoAway func(connectionInterface),
+			onConnected func(connectionInterface, time.Duration)) connectionInterface {
 			c := &mockConnection{
 				onActive: onActive,
 				onIdle:   onIdle,
+				onGoAway: onGoAway,
 			}
 			mu.Lock()
 			defer mu.Unlock()
@@ -118,9 +384,87 @@ func setupConnManager(t *testing.T) (*connectionManager, *[]*mockConnection, *sy
 	return cm, &conns, &mu
 }
 
+// setupMuxConnManager sets up a connection manager with a single connection that kas switches into multiplexed mode.
+// pong is closed once the connection is multiplexed, goAway is closed when it asks kas to retire the tunnel.
+// kas closes the tunnel right after that, like it does for a tunnel without streams.
+func setupMuxConnManager(t *testing.T) (*connectionManager, *connection, <-chan struct{}, <-chan struct{}, *[]*mockConnection) {
+	client, _, tunnel, c := setupConnection(t)
+	c.pollConfig = retry.NewPollConfigFactory(0, retry.NewExponentialBackoffFactory(time.Millisecond, time.Millisecond, time.Minute, 2, 0))
+	c.multiplexing = &rpc2.Multiplexing{
+		MaxStreams:        2,
+		InitialWindowSize: 1024,
+	}
+	pong := make(chan struct{})
+	goAway := make(chan struct{})
+	client.EXPECT().
+		Connect(gomock.Any(), gomock.Any()).
+		Return(tunnel, nil)
+	tunnel.EXPECT().
+		Send(gomock.Any()). // descriptor, pong and GoAway
+		DoAndReturn(func(req *rpc2.ConnectRequest) error {
+			switch {
+			case req.GetMux().GetPong() != nil:
+				close(pong)
+			case req.GetMux().GetGoAway() != nil:
+				close(goAway)
+			}
+			return nil
+		}).
+		AnyTimes()
+	gomock.InOrder(
+		tunnel.EXPECT().
+			RecvMsg(gomock.Any()).
+			Do(testhelpers.RecvMsg(&rpc2.ConnectResponse{
+				Msg: &rpc2.ConnectResponse_Mux{
+					Mux: &rpc2.MuxResponse{
+						Msg: &rpc2.MuxResponse_Ping{
+							Ping: &rpc2.Ping{
+								Id: 1,
+							},
+						},
+					},
+				},
+			})),
+		tunnel.EXPECT().
+			RecvMsg(gomock.Any()).
+			DoAndReturn(func(m any) error {
+				<-goAway
+				return io.EOF
+			}),
+	)
+	var replacements []*mockConnection
+	started := false
+	cm := &connectionManager{
+		connections:        make(map[connectionInterface]connectionInfo),
+		minIdleConnections: 1,
+		maxConnections:     1,
+		scaleUpStep:        1,
+		maxIdleTime:        time.Minute,
+		connectionFactory: func(agentDescriptor *info.AgentDescriptor, onActive, onIdle, onGoAway func(connectionInterface),
+			onConnected func(connectionInterface, time.Duration)) connectionInterface {
+			if started {
+				r := &mockConnection{
+					onActive: onActive,
+					onIdle:   onIdle,
+					onGoAway: onGoAway,
+				}
+				replacements = append(replacements, r)
+				return r
+			}
+			started = true
+			c.onActive = onActive
+			c.onIdle = onIdle
+			c.onGoAway = onGoAway
+			c.onConnected = onConnected
+			return c
+		},
+	}
+	return cm, c, pong, goAway, &replacements
+}
+
 type mockConnection struct {
-	runCalled, stopped int32
-	onActive, onIdle   func(connectionInterface)
+	runCalled, stopped         int32
+	onActive, onIdle, onGoAway func(connectionInterface)
 }
 
 func (m *mockConnection) Run(attemptCtx, pollCtx context.Context) {
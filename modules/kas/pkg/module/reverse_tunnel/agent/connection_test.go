@@ -446,7 +446,8 @@ func TestAgentDescriptorIsSent(t *testing.T) {
 			Send(matcher.ProtoEq(t, &rpc2.ConnectRequest{
 				Msg: &rpc2.ConnectRequest_Descriptor_{
 					Descriptor_: &rpc2.Descriptor{
-						AgentDescriptor: descriptor(),
+						AgentDescriptor:       descriptor(),
+						SupportedCompressions: rpc2.SupportedCompressions,
 					},
 				},
 			})).
@@ -544,6 +545,53 @@ func TestAttemptIsUnblockedOnTunnelHeaderSendError(t *testing.T) {
 	require.EqualError(t, err, "expected recv error")
 }
 
+// A multiplexed tunnel is pinged by kas as soon as it's registered. That must not make the connection active.
+func TestMuxPingDoesNotActivateConnection(t *testing.T) {
+	client, _, tunnel, c := setupConnection(t)
+	c.multiplexing = &rpc2.Multiplexing{
+		MaxStreams:        10,
+		InitialWindowSize: 1024,
+	}
+	c.onActive = func(c connectionInterface) {
+		t.Error("unexpected onActive()")
+	}
+
+	gomock.InOrder(
+		client.EXPECT().
+			Connect(gomock.Any(), gomock.Any()).
+			Return(tunnel, nil),
+		tunnel.EXPECT().
+			Send(gomock.Any()),
+		tunnel.EXPECT().
+			RecvMsg(gomock.Any()).
+			Do(testhelpers.RecvMsg(&rpc2.ConnectResponse{
+				Msg: &rpc2.ConnectResponse_Mux{
+					Mux: &rpc2.MuxResponse{
+						Msg: &rpc2.MuxResponse_Ping{
+							Ping: &rpc2.Ping{Id: 1},
+						},
+					},
+				},
+			})),
+		tunnel.EXPECT().
+			Send(matcher.ProtoEq(nil, &rpc2.ConnectRequest{
+				Msg: &rpc2.ConnectRequest_Mux{
+					Mux: &rpc2.MuxRequest{
+						Msg: &rpc2.MuxRequest_Pong{
+							Pong: &rpc2.Pong{Id: 1},
+						},
+					},
+				},
+			})),
+		tunnel.EXPECT().
+			RecvMsg(gomock.Any()).
+			Return(io.EOF),
+	)
+
+	err := c.attempt(context.Background())
+	require.NoError(t, err)
+}
+
 func setupConnection(t *testing.T) (*mock_reverse_tunnel_rpc.MockReverseTunnelClient, *mock_rpc.MockClientConnInterface, *mock_reverse_tunnel_rpc.MockReverseTunnel_ConnectClient[rpc2.ConnectRequest, rpc2.ConnectResponse], *connection) {
 	ctrl := gomock.NewController(t)
 	client := mock_reverse_tunnel_rpc.NewMockReverseTunnelClient(ctrl)
@@ -559,6 +607,8 @@ func setupConnection(t *testing.T) (*mock_reverse_tunnel_rpc.MockReverseTunnelCl
 		streamVisitor:      sv,
 		onIdle:             func(c connectionInterface) {},
 		onActive:           func(c connectionInterface) {},
+		onGoAway:           func(c connectionInterface) {},
+		onConnected:        func(c connectionInterface, setupTime time.Duration) {},
 	}
 	return client, conn, tunnel, c
 }
@@ -12,6 +12,7 @@ import (
 	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/rpc"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/retry"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
 )
 
 const (
@@ -21,6 +22,15 @@ const (
 	// scaleUpStep defines how many new connections are started when there is not enough idle connections.
 	scaleUpStep = 10
 
+	// muxMaxStreams is the maximum number of streams kas may multiplex over one tunnel.
+	muxMaxStreams = 100
+	// muxInitialWindowSize is the flow control window of each multiplexed stream.
+	muxInitialWindowSize = 256 * 1024
+
+	defaultAdaptiveWindow             = 5 * time.Minute
+	defaultAdaptiveMinIdleConnections = 1
+	defaultAdaptiveMaxIdleConnections = 50
+
 	connectionInitBackoff   = 1 * time.Second
 	connectionMaxBackoff    = 20 * time.Second
 	connectionResetDuration = 25 * time.Second
@@ -30,6 +40,8 @@ const (
 
 type Factory struct {
 	InternalServerConn grpc.ClientConnInterface
+	// Reconnect is dispatched to to replace all tunnels with new ones. May be nil.
+	Reconnect *syncz.Subscriptions[struct{}]
 }
 
 func (f *Factory) IsProducingLeaderModules() bool {
@@ -55,7 +67,9 @@ func (f *Factory) New(config *modagent.Config) (modagent.Module, error) {
 		maxConnections:     maxConnections,
 		scaleUpStep:        scaleUpStep,
 		maxIdleTime:        maxIdleTime,
-		connectionFactory: func(descriptor *info.AgentDescriptor, onActive, onIdle func(c connectionInterface)) connectionInterface {
+		reconnect:          f.Reconnect,
+		connectionFactory: func(descriptor *info.AgentDescriptor, onActive, onIdle, onGoAway func(c connectionInterface),
+			onConnected func(connectionInterface, time.Duration)) connectionInterface {
 			return &connection{
 				log:                config.Log,
 				descriptor:         descriptor,
@@ -65,6 +79,12 @@ func (f *Factory) New(config *modagent.Config) (modagent.Module, error) {
 				pollConfig:         pollConfig,
 				onActive:           onActive,
 				onIdle:             onIdle,
+				onGoAway:           onGoAway,
+				onConnected:        onConnected,
+				multiplexing: &rpc2.Multiplexing{
+					MaxStreams:        muxMaxStreams,
+					InitialWindowSize: muxInitialWindowSize,
+				},
 			}
 		},
 	}, nil
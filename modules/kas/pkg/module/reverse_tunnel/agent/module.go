@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"google.golang.org/grpc"
@@ -9,10 +10,13 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/info"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/prototool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/syncz"
 )
 
 // connectionFactory helps to inject fake connections for testing.
-type connectionFactory func(agentDescriptor *info.AgentDescriptor, onActive, onIdle func(connectionInterface)) connectionInterface
+type connectionFactory func(agentDescriptor *info.AgentDescriptor, onActive, onIdle, onGoAway func(connectionInterface),
+	onConnected func(connectionInterface, time.Duration)) connectionInterface
 
 type module struct {
 	server *grpc.Server
@@ -24,6 +28,7 @@ type module struct {
 	// maxIdleTime is the maximum duration of time a connection can stay in an idle state.
 	maxIdleTime       time.Duration
 	connectionFactory connectionFactory
+	reconnect         *syncz.Subscriptions[struct{}]
 }
 
 func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
@@ -35,15 +40,53 @@ func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguratio
 		maxIdleTime:        m.maxIdleTime,
 		connectionFactory:  m.connectionFactory,
 		agentDescriptor:    m.agentDescriptor(),
+		reconnect:          m.reconnect,
 	}
-	cm.Run(ctx)
+	cm.Run(ctx, cfg)
 	return nil
 }
 
 func (m *module) DefaultAndValidateConfiguration(config *agentcfg.AgentConfiguration) error {
+	prototool.NotNil(&config.ReverseTunnel)
+	rt := config.ReverseTunnel
+	prototool.Uint32(&rt.MinIdleConnections, uint32(m.minIdleConnections))
+	prototool.Uint32(&rt.MaxConnections, uint32(m.maxConnections))
+	prototool.Uint32(&rt.ScaleUpStep, uint32(m.scaleUpStep))
+	prototool.Duration(&rt.MaxIdleTime, m.maxIdleTime)
+	if rt.MinIdleConnections > rt.MaxConnections {
+		return fmt.Errorf("reverse_tunnel: min_idle_connections (%d) must not exceed max_connections (%d)",
+			rt.MinIdleConnections, rt.MaxConnections)
+	}
+	if a := rt.Adaptive; a != nil {
+		prototool.Duration(&a.Window, defaultAdaptiveWindow)
+		prototool.Uint32(&a.MinIdleConnections, defaultAdaptiveMinIdleConnections)
+		prototool.Uint32(&a.MaxIdleConnections, min(defaultAdaptiveMaxIdleConnections, rt.MaxConnections))
+		if a.MinIdleConnections > a.MaxIdleConnections || a.MaxIdleConnections > rt.MaxConnections {
+			return fmt.Errorf("reverse_tunnel.adaptive: min_idle_connections (%d) <= max_idle_connections (%d) <= max_connections (%d) must hold",
+				a.MinIdleConnections, a.MaxIdleConnections, rt.MaxConnections)
+		}
+	}
 	return nil
 }
 
+// newPoolConfig converts defaulted configuration.
+func newPoolConfig(rt *agentcfg.ReverseTunnelCF) poolConfig {
+	cfg := poolConfig{
+		minIdleConnections: int32(rt.MinIdleConnections),
+		maxConnections:     int32(rt.MaxConnections),
+		scaleUpStep:        int32(rt.ScaleUpStep),
+		maxIdleTime:        rt.MaxIdleTime.AsDuration(),
+	}
+	if a := rt.Adaptive; a != nil {
+		cfg.adaptive = &adaptivePoolConfig{
+			window:             a.Window.AsDuration(),
+			minIdleConnections: int32(a.MinIdleConnections),
+			maxIdleConnections: int32(a.MaxIdleConnections),
+		}
+	}
+	return cfg
+}
+
 func (m *module) Name() string {
 	return reverse_tunnel.ModuleName
 }
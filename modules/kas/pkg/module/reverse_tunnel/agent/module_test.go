@@ -1,9 +1,82 @@
 package agent
 
 import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
 	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
 )
 
 var (
 	_ modagent.Module = &module{}
 )
+
+func TestDefaultAndValidateConfiguration(t *testing.T) {
+	m := &module{
+		minIdleConnections: minIdleConnections,
+		maxConnections:     maxConnections,
+		scaleUpStep:        scaleUpStep,
+		maxIdleTime:        maxIdleTime,
+	}
+	cfg := &agentcfg.AgentConfiguration{
+		ReverseTunnel: &agentcfg.ReverseTunnelCF{
+			MaxConnections: 20,
+			Adaptive:       &agentcfg.ReverseTunnelAdaptiveCF{},
+		},
+	}
+	require.NoError(t, m.DefaultAndValidateConfiguration(cfg))
+	assert.Empty(t, cmp.Diff(&agentcfg.ReverseTunnelCF{
+		MinIdleConnections: minIdleConnections,
+		MaxConnections:     20,
+		ScaleUpStep:        scaleUpStep,
+		MaxIdleTime:        durationpb.New(maxIdleTime),
+		Adaptive: &agentcfg.ReverseTunnelAdaptiveCF{
+			Window:             durationpb.New(defaultAdaptiveWindow),
+			MinIdleConnections: defaultAdaptiveMinIdleConnections,
+			MaxIdleConnections: 20,
+		},
+	}, cfg.ReverseTunnel, protocmp.Transform()))
+	assert.Equal(t, poolConfig{
+		minIdleConnections: minIdleConnections,
+		maxConnections:     20,
+		scaleUpStep:        scaleUpStep,
+		maxIdleTime:        maxIdleTime,
+		adaptive: &adaptivePoolConfig{
+			window:             5 * time.Minute,
+			minIdleConnections: 1,
+			maxIdleConnections: 20,
+		},
+	}, newPoolConfig(cfg.ReverseTunnel))
+}
+
+func TestDefaultAndValidateConfiguration_Invalid(t *testing.T) {
+	m := &module{
+		minIdleConnections: minIdleConnections,
+		maxConnections:     maxConnections,
+		scaleUpStep:        scaleUpStep,
+		maxIdleTime:        maxIdleTime,
+	}
+	err := m.DefaultAndValidateConfiguration(&agentcfg.AgentConfiguration{
+		ReverseTunnel: &agentcfg.ReverseTunnelCF{
+			MinIdleConnections: 10,
+			MaxConnections:     5,
+		},
+	})
+	assert.EqualError(t, err, "reverse_tunnel: min_idle_connections (10) must not exceed max_connections (5)")
+	err = m.DefaultAndValidateConfiguration(&agentcfg.AgentConfiguration{
+		ReverseTunnel: &agentcfg.ReverseTunnelCF{
+			Adaptive: &agentcfg.ReverseTunnelAdaptiveCF{
+				MinIdleConnections: 10,
+				MaxIdleConnections: 5,
+			},
+		},
+	})
+	assert.Error(t, err)
+}
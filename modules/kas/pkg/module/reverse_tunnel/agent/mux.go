@@ -0,0 +1,332 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/mux"
+	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/rpc"
+	grpctool2 "github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+// muxConnection handles streams of a tunnel that kas has switched into multiplexed mode.
+type muxConnection struct {
+	log                *zap.Logger
+	tunnel             rpc2.ReverseTunnel_ConnectClient
+	internalServerConn grpc.ClientConnInterface
+	// ctx is the parent context for all streams.
+	ctx        context.Context
+	maxStreams int
+	windowSize uint32
+	// onGoAway is called when kas is not going to open new streams on the tunnel.
+	onGoAway func()
+	wg       sync.WaitGroup
+
+	sendMu sync.Mutex // serializes Send() calls
+
+	mu      sync.Mutex // protects streams
+	streams map[uint64]*muxStream
+}
+
+func (m *muxConnection) handle(resp *rpc2.MuxResponse) error {
+	switch msg := resp.Msg.(type) {
+	case *rpc2.MuxResponse_RequestInfo:
+		return m.open(resp.StreamId, msg.RequestInfo)
+	case *rpc2.MuxResponse_Ping:
+		err := m.send(&rpc2.MuxRequest{
+			Msg: &rpc2.MuxRequest_Pong{
+				Pong: &rpc2.Pong{
+					Id: msg.Ping.Id,
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Send(pong): %w", err) // wrap
+		}
+		return nil
+	case *rpc2.MuxResponse_GoAway:
+		m.onGoAway()
+		return nil
+	}
+	m.mu.Lock()
+	s := m.streams[resp.StreamId]
+	m.mu.Unlock()
+	if s == nil {
+		// Stream is done already, drop the frame.
+		return nil
+	}
+	switch msg := resp.Msg.(type) {
+	case *rpc2.MuxResponse_Message:
+		if err := s.recvWindow.Received(len(msg.Message.Data)); err != nil {
+			return fmt.Errorf("stream %d: %w", resp.StreamId, err)
+		}
+		s.recv.Push(resp)
+	case *rpc2.MuxResponse_CloseSend:
+		s.recv.Push(resp)
+	case *rpc2.MuxResponse_WindowUpdate:
+		s.sendWindow.Add(msg.WindowUpdate.Increment)
+	case *rpc2.MuxResponse_Cancel:
+		s.cancel()
+	default:
+		return fmt.Errorf("unexpected frame type: %T", resp.Msg)
+	}
+	return nil
+}
+
+func (m *muxConnection) open(streamId uint64, reqInfo *rpc2.RequestInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.streams[streamId]; ok {
+		return fmt.Errorf("stream %d is open already", streamId)
+	}
+	if len(m.streams) >= m.maxStreams {
+		return fmt.Errorf("stream %d exceeds the maximum number of streams %d", streamId, m.maxStreams)
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	s := &muxStream{
+		conn:       m,
+		id:         streamId,
+		reqInfo:    reqInfo,
+		recv:       mux.NewQueue[*rpc2.MuxResponse](),
+		sendWindow: mux.NewSendWindow(m.windowSize),
+		recvWindow: mux.NewRecvWindow(m.windowSize),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	m.streams[streamId] = s
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer m.remove(s)
+		s.run()
+	}()
+	return nil
+}
+
+func (m *muxConnection) remove(s *muxStream) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.streams, s.id)
+}
+
+func (m *muxConnection) send(req *rpc2.MuxRequest) error {
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+	return m.tunnel.Send(&rpc2.ConnectRequest{
+		Msg: &rpc2.ConnectRequest_Mux{
+			Mux: req,
+		},
+	})
+}
+
+// goAwayWhenDone tells kas not to open new streams on the tunnel once done is closed.
+// kas closes the tunnel when the open streams are done. This is how agentk retires a multiplexed tunnel.
+func (m *muxConnection) goAwayWhenDone(done <-chan struct{}) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		select {
+		case <-done:
+		case <-m.ctx.Done():
+			return // the tunnel is closed already
+		}
+		err := m.send(&rpc2.MuxRequest{
+			Msg: &rpc2.MuxRequest_GoAway{
+				GoAway: &rpc2.GoAway{},
+			},
+		})
+		if err != nil {
+			m.log.Debug("Failed to send GoAway to multiplexed tunnel", logz.Error(err))
+		}
+	}()
+}
+
+// wait waits for all streams to finish.
+func (m *muxConnection) wait() {
+	m.wg.Wait()
+}
+
+type muxStream struct {
+	conn       *muxConnection
+	id         uint64
+	reqInfo    *rpc2.RequestInfo
+	recv       *mux.Queue[*rpc2.MuxResponse]
+	sendWindow *mux.SendWindow
+	recvWindow *mux.RecvWindow
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (s *muxStream) run() {
+	defer s.cancel()
+	log := s.conn.log.With(logz.GrpcMethod(s.reqInfo.MethodName))
+	outgoingCtx := metadata.NewOutgoingContext(s.ctx, s.reqInfo.Metadata())
+	clientStream, err := s.conn.internalServerConn.NewStream(outgoingCtx, &proxyStreamDesc, s.reqInfo.MethodName)
+	if err != nil {
+		err = s.send(&rpc2.MuxRequest{
+			Msg: &rpc2.MuxRequest_Error{
+				Error: &rpc2.Error{
+					Status: status.Convert(err).Proto(),
+				},
+			},
+		})
+		if err != nil {
+			log.Debug("Failed to send error to multiplexed tunnel", logz.Error(err))
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// pipe tunnel -> internal client
+		err := s.pipeTunnelIntoInternalClient(clientStream)
+		if err != nil {
+			log.Debug("Error piping multiplexed tunnel into internal client", logz.Error(err))
+			s.cancel()
+		}
+	}()
+	// pipe internal client -> tunnel
+	err = s.pipeInternalClientIntoTunnel(clientStream)
+	if err != nil {
+		log.Debug("Error piping internal client into multiplexed tunnel", logz.Error(err))
+	}
+	// The call has finished, unblock the other goroutine.
+	s.cancel()
+	wg.Wait()
+}
+
+func (s *muxStream) pipeTunnelIntoInternalClient(clientStream grpc.ClientStream) error {
+	for {
+		resp, err := s.recv.Pop(s.ctx)
+		if err != nil {
+			return nil // stream is done
+		}
+		switch msg := resp.Msg.(type) {
+		case *rpc2.MuxResponse_Message:
+			data, err := msg.Message.Decompress(s.reqInfo.Compression)
+			if err != nil {
+				return fmt.Errorf("decompress message: %w", err)
+			}
+			err = clientStream.SendMsg(&grpctool2.RawFrame{
+				Data: data,
+			})
+			if err != nil {
+				if err == io.EOF { // nolint:errorlint
+					return nil // the other goroutine will receive the error in RecvMsg()
+				}
+				return fmt.Errorf("SendMsg(): %w", err)
+			}
+			inc := s.recvWindow.Consumed(len(msg.Message.Data))
+			if inc == 0 {
+				continue
+			}
+			err = s.send(&rpc2.MuxRequest{
+				Msg: &rpc2.MuxRequest_WindowUpdate{
+					WindowUpdate: &rpc2.WindowUpdate{
+						Increment: inc,
+					},
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("Send(window update): %w", err)
+			}
+		case *rpc2.MuxResponse_CloseSend:
+			err = clientStream.CloseSend()
+			if err != nil {
+				return fmt.Errorf("CloseSend(): %w", err)
+			}
+			return nil
+		}
+	}
+}
+
+func (s *muxStream) pipeInternalClientIntoTunnel(clientStream grpc.ClientStream) error {
+	header, err := clientStream.Header()
+	if err != nil {
+		return s.sendError(err)
+	}
+	err = s.send(&rpc2.MuxRequest{
+		Msg: &rpc2.MuxRequest_Header{
+			Header: &rpc2.Header{
+				Meta: grpctool2.MetaToValuesMap(header),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Send(header): %w", err) // wrap
+	}
+	var frame grpctool2.RawFrame
+	for {
+		recvErr := clientStream.RecvMsg(&frame)
+		if recvErr != nil {
+			// Trailer becomes available after RecvMsg() returns an error
+			err = s.send(&rpc2.MuxRequest{
+				Msg: &rpc2.MuxRequest_Trailer{
+					Trailer: &rpc2.Trailer{
+						Meta: grpctool2.MetaToValuesMap(clientStream.Trailer()),
+					},
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("Send(trailer): %w", err) // wrap
+			}
+			if recvErr == io.EOF { // nolint:errorlint
+				break
+			}
+			return s.sendError(recvErr)
+		}
+		msg, err := rpc2.NewMessage(s.reqInfo.Compression, frame.Data)
+		if err != nil {
+			return s.sendError(err)
+		}
+		err = s.sendWindow.Acquire(s.ctx, len(msg.Data))
+		if err != nil {
+			return err
+		}
+		err = s.send(&rpc2.MuxRequest{
+			Msg: &rpc2.MuxRequest_Message{
+				Message: msg,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Send(message): %w", err) // wrap
+		}
+	}
+	err = s.send(&rpc2.MuxRequest{
+		Msg: &rpc2.MuxRequest_CloseSend{
+			CloseSend: &rpc2.CloseSend{},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Send(close send): %w", err) // wrap
+	}
+	return nil
+}
+
+func (s *muxStream) sendError(errToSend error) error {
+	err := s.send(&rpc2.MuxRequest{
+		Msg: &rpc2.MuxRequest_Error{
+			Error: &rpc2.Error{
+				Status: status.Convert(errToSend).Proto(),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Send(error): %w", err) // wrap
+	}
+	return nil
+}
+
+func (s *muxStream) send(req *rpc2.MuxRequest) error {
+	req.StreamId = s.id
+	return s.conn.send(req)
+}
@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/matcher"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_reverse_tunnel_rpc"
+)
+
+func TestMuxPingIsAnsweredWithPong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tunnel := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectClient[rpc2.ConnectRequest, rpc2.ConnectResponse](ctrl)
+	tunnel.EXPECT().
+		Send(matcher.ProtoEq(t, &rpc2.ConnectRequest{
+			Msg: &rpc2.ConnectRequest_Mux{
+				Mux: &rpc2.MuxRequest{
+					Msg: &rpc2.MuxRequest_Pong{
+						Pong: &rpc2.Pong{
+							Id: 42,
+						},
+					},
+				},
+			},
+		}))
+	m := &muxConnection{
+		log:     zaptest.NewLogger(t),
+		tunnel:  tunnel,
+		streams: map[uint64]*muxStream{},
+	}
+	err := m.handle(&rpc2.MuxResponse{
+		Msg: &rpc2.MuxResponse_Ping{
+			Ping: &rpc2.Ping{
+				Id: 42,
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestMuxGoAwayCallsOnGoAway(t *testing.T) {
+	goAway := 0
+	m := &muxConnection{
+		log:     zaptest.NewLogger(t),
+		streams: map[uint64]*muxStream{},
+		onGoAway: func() {
+			goAway++
+		},
+	}
+	err := m.handle(&rpc2.MuxResponse{
+		Msg: &rpc2.MuxResponse_GoAway{
+			GoAway: &rpc2.GoAway{},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, goAway)
+}
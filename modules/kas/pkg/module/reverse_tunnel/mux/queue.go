@@ -0,0 +1,78 @@
+package mux
+
+import (
+	"context"
+	"sync"
+)
+
+// Queue is an unbounded FIFO queue of frames of a stream.
+// It is filled by the goroutine that reads the tunnel and drained by the goroutine that handles the stream so that
+// a slow stream doesn't block the other streams of the tunnel. Flow control bounds how much data it holds.
+type Queue[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	err     error
+	changed chan struct{}
+}
+
+func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Push appends an item to the queue. Items pushed after Close are dropped.
+func (q *Queue[T]) Push(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err != nil {
+		return
+	}
+	q.items = append(q.items, item)
+	q.notifyLocked()
+}
+
+// Close makes Pop return err once the queued items have been consumed. Only the first call has an effect.
+func (q *Queue[T]) Close(err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err != nil {
+		return
+	}
+	q.err = err
+	q.notifyLocked()
+}
+
+// Pop blocks until there is an item in the queue, the queue is closed or ctx is done.
+func (q *Queue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			item := q.items[0]
+			var zero T
+			q.items[0] = zero // don't hold a reference
+			q.items = q.items[1:]
+			q.mu.Unlock()
+			return item, nil
+		}
+		err := q.err
+		q.mu.Unlock()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-q.changed:
+		}
+	}
+}
+
+func (q *Queue[T]) notifyLocked() {
+	select {
+	case q.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,46 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_PopReturnsItemsThenError(t *testing.T) {
+	q := NewQueue[int]()
+	q.Push(1)
+	q.Push(2)
+	expectedErr := errors.New("closed")
+	q.Close(expectedErr)
+	q.Push(3) // dropped
+	for _, expected := range []int{1, 2} {
+		item, err := q.Pop(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expected, item)
+	}
+	_, err := q.Pop(context.Background())
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestQueue_PopBlocksUntilPush(t *testing.T) {
+	q := NewQueue[int]()
+	done := make(chan int)
+	go func() {
+		item, _ := q.Pop(context.Background())
+		done <- item
+	}()
+	q.Push(42)
+	assert.Equal(t, 42, <-done)
+}
+
+func TestQueue_PopRespectsContext(t *testing.T) {
+	q := NewQueue[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := q.Pop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,111 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// SendWindow tracks how much Message data may be sent on a stream.
+// Sending is allowed while the window is positive. A message may be larger than the remaining window, in which
+// case the window becomes negative and sending blocks until the peer grants enough to make it positive again.
+// This bounds the amount of buffered data on the receiving side by the window size plus the size of one message.
+type SendWindow struct {
+	mu      sync.Mutex
+	size    int64
+	changed chan struct{}
+	err     error
+}
+
+func NewSendWindow(size uint32) *SendWindow {
+	return &SendWindow{
+		size:    int64(size),
+		changed: make(chan struct{}),
+	}
+}
+
+// Acquire blocks until the window is positive and then takes n bytes from it.
+func (w *SendWindow) Acquire(ctx context.Context, n int) error {
+	for {
+		w.mu.Lock()
+		if w.err != nil {
+			w.mu.Unlock()
+			return w.err
+		}
+		if w.size > 0 {
+			w.size -= int64(n)
+			w.mu.Unlock()
+			return nil
+		}
+		changed := w.changed
+		w.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Add grows the window by n bytes, as granted by the peer.
+func (w *SendWindow) Add(n uint32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.size += int64(n)
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+// Close unblocks pending and future Acquire calls with err.
+func (w *SendWindow) Close(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return
+	}
+	w.err = err
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+// RecvWindow tracks how much Message data the peer may send on a stream and when to grant it more.
+type RecvWindow struct {
+	mu          sync.Mutex
+	size        int64
+	available   int64 // may become negative, see SendWindow.
+	unannounced int64 // consumed bytes that have not been granted back to the peer yet.
+}
+
+func NewRecvWindow(size uint32) *RecvWindow {
+	return &RecvWindow{
+		size:      int64(size),
+		available: int64(size),
+	}
+}
+
+// Received takes n bytes from the window. It returns an error if the peer has sent data while the window was not positive.
+func (w *RecvWindow) Received(n int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.available <= 0 {
+		return errors.New("flow control window exceeded")
+	}
+	w.available -= int64(n)
+	return nil
+}
+
+// Consumed records that n bytes have been processed and returns how many bytes to grant to the peer.
+// Zero means nothing needs to be granted yet. Grants are batched to half the window size to reduce the number of
+// WindowUpdate frames.
+func (w *RecvWindow) Consumed(n int) uint32 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.unannounced += int64(n)
+	if w.unannounced < w.size/2 && w.available > 0 {
+		return 0
+	}
+	inc := w.unannounced
+	w.unannounced = 0
+	w.available += inc
+	return uint32(inc)
+}
@@ -0,0 +1,63 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendWindow_AllowsOverdraftOnce(t *testing.T) {
+	w := NewSendWindow(10)
+	require.NoError(t, w.Acquire(context.Background(), 25)) // window is positive, so a large message is allowed
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Acquire(ctx, 1), context.DeadlineExceeded)
+}
+
+func TestSendWindow_AddUnblocksAcquire(t *testing.T) {
+	w := NewSendWindow(1)
+	require.NoError(t, w.Acquire(context.Background(), 1))
+	done := make(chan error)
+	go func() {
+		done <- w.Acquire(context.Background(), 1)
+	}()
+	w.Add(1)
+	assert.NoError(t, <-done)
+}
+
+func TestSendWindow_CloseUnblocksAcquire(t *testing.T) {
+	w := NewSendWindow(1)
+	require.NoError(t, w.Acquire(context.Background(), 1))
+	done := make(chan error)
+	go func() {
+		done <- w.Acquire(context.Background(), 1)
+	}()
+	expectedErr := errors.New("closed")
+	w.Close(expectedErr)
+	w.Close(errors.New("ignored"))
+	assert.Equal(t, expectedErr, <-done)
+	w.Add(10)
+	assert.Equal(t, expectedErr, w.Acquire(context.Background(), 1))
+}
+
+func TestRecvWindow_BatchesGrants(t *testing.T) {
+	w := NewRecvWindow(100)
+	require.NoError(t, w.Received(30))
+	assert.Zero(t, w.Consumed(30))
+	require.NoError(t, w.Received(30))
+	assert.EqualValues(t, 60, w.Consumed(30)) // half of the window has been consumed
+}
+
+func TestRecvWindow_GrantsWhenExhausted(t *testing.T) {
+	w := NewRecvWindow(100)
+	require.NoError(t, w.Received(120)) // overdraft
+	assert.Error(t, w.Received(1))      // window is not positive, peer must not send
+	assert.EqualValues(t, 10, w.Consumed(10))
+	assert.Error(t, w.Received(1)) // still not positive
+	assert.EqualValues(t, 30, w.Consumed(30))
+	assert.NoError(t, w.Received(1))
+}
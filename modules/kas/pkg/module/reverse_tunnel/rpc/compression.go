@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionMinSize is the minimum size of Message data to compress. Smaller data is sent as is.
+	CompressionMinSize = 1024
+	// MaxDecompressedSize is the maximum size of decompressed Message data.
+	MaxDecompressedSize = 64 * 1024 * 1024
+)
+
+var (
+	// SupportedCompressions are the compression algorithms this package can compress and decompress with,
+	// in order of preference.
+	SupportedCompressions = []Compression{Compression_zstd, Compression_gzip}
+
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(MaxDecompressedSize))
+	gzipWriters    = sync.Pool{
+		New: func() any {
+			w, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+			return w
+		},
+	}
+)
+
+// NegotiateCompression returns the preferred compression algorithm if the peer supports it and none otherwise.
+func NegotiateCompression(preferred Compression, supported []Compression) Compression {
+	if slices.Contains(supported, preferred) {
+		return preferred
+	}
+	return Compression_none
+}
+
+// NewMessage constructs a Message with data compressed using c.
+// Data is not compressed if it's small or if compression doesn't make it smaller.
+func NewMessage(c Compression, data []byte) (*Message, error) {
+	if c == Compression_none || len(data) < CompressionMinSize {
+		return &Message{Data: data}, nil
+	}
+	compressed, err := compress(c, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed) >= len(data) {
+		return &Message{Data: data}, nil
+	}
+	return &Message{
+		Data:        compressed,
+		Compression: c,
+	}, nil
+}
+
+// Decompress returns decompressed data of the message.
+// allowed is the compression algorithm the peer was allowed to use, in addition to none.
+func (x *Message) Decompress(allowed Compression) ([]byte, error) {
+	switch x.Compression {
+	case Compression_none:
+		return x.Data, nil
+	case allowed:
+		return decompress(x.Compression, x.Data)
+	default:
+		return nil, fmt.Errorf("unexpected compression algorithm: %s", x.Compression)
+	}
+}
+
+func compress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case Compression_zstd:
+		return zstdEncoder.EncodeAll(data, nil), nil
+	case Compression_gzip:
+		var buf bytes.Buffer
+		w := gzipWriters.Get().(*gzip.Writer)
+		defer gzipWriters.Put(w)
+		w.Reset(&buf)
+		_, err := w.Write(data)
+		if err != nil {
+			return nil, err
+		}
+		err = w.Close()
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %s", c)
+	}
+}
+
+func decompress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case Compression_zstd:
+		return zstdDecoder.DecodeAll(data, nil)
+	case Compression_gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		res, err := io.ReadAll(io.LimitReader(r, MaxDecompressedSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(res) > MaxDecompressedSize {
+			return nil, fmt.Errorf("decompressed data exceeds %d bytes", MaxDecompressedSize)
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %s", c)
+	}
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessage_RoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible "), 1000)
+	for _, c := range SupportedCompressions {
+		t.Run(c.String(), func(t *testing.T) {
+			msg, err := NewMessage(c, data)
+			require.NoError(t, err)
+			assert.Equal(t, c, msg.Compression)
+			assert.Less(t, len(msg.Data), len(data))
+			decompressed, err := msg.Decompress(c)
+			require.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+		})
+	}
+}
+
+func TestNewMessage_SmallDataIsNotCompressed(t *testing.T) {
+	data := []byte("small")
+	msg, err := NewMessage(Compression_zstd, data)
+	require.NoError(t, err)
+	assert.Equal(t, Compression_none, msg.Compression)
+	assert.Equal(t, data, msg.Data)
+}
+
+func TestNewMessage_IncompressibleDataIsNotCompressed(t *testing.T) {
+	data := make([]byte, 2*CompressionMinSize)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	msg, err := NewMessage(Compression_gzip, data)
+	require.NoError(t, err)
+	assert.Equal(t, Compression_none, msg.Compression)
+	assert.Equal(t, data, msg.Data)
+}
+
+func TestDecompress_RejectsUnexpectedCompression(t *testing.T) {
+	msg, err := NewMessage(Compression_zstd, bytes.Repeat([]byte{1}, 2*CompressionMinSize))
+	require.NoError(t, err)
+	_, err = msg.Decompress(Compression_gzip)
+	assert.EqualError(t, err, "unexpected compression algorithm: zstd")
+	_, err = msg.Decompress(Compression_none)
+	assert.Error(t, err)
+}
+
+func TestDecompress_LimitsSize(t *testing.T) {
+	for _, c := range SupportedCompressions {
+		t.Run(c.String(), func(t *testing.T) {
+			msg, err := NewMessage(c, make([]byte, MaxDecompressedSize+1))
+			require.NoError(t, err)
+			_, err = msg.Decompress(c)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	assert.Equal(t, Compression_zstd, NegotiateCompression(Compression_zstd, SupportedCompressions))
+	assert.Equal(t, Compression_none, NegotiateCompression(Compression_zstd, []Compression{Compression_gzip}))
+	assert.Equal(t, Compression_none, NegotiateCompression(Compression_gzip, nil))
+	assert.Equal(t, Compression_none, NegotiateCompression(Compression_none, SupportedCompressions))
+}
@@ -28,11 +28,65 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Compression is a compression algorithm for Message data.
+type Compression int32
+
+const (
+	Compression_none Compression = 0
+	Compression_gzip Compression = 1
+	Compression_zstd Compression = 2
+)
+
+// Enum value maps for Compression.
+var (
+	Compression_name = map[int32]string{
+		0: "none",
+		1: "gzip",
+		2: "zstd",
+	}
+	Compression_value = map[string]int32{
+		"none": 0,
+		"gzip": 1,
+		"zstd": 2,
+	}
+)
+
+func (x Compression) Enum() *Compression {
+	p := new(Compression)
+	*p = x
+	return p
+}
+
+func (x Compression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Compression) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_enumTypes[0].Descriptor()
+}
+
+func (Compression) Type() protoreflect.EnumType {
+	return &file_pkg_module_reverse_tunnel_rpc_rpc_proto_enumTypes[0]
+}
+
+func (x Compression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Compression.Descriptor instead.
+func (Compression) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{0}
+}
+
 type Descriptor struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	AgentDescriptor *info.AgentDescriptor  `protobuf:"bytes,1,opt,name=agent_descriptor,json=agentDescriptor,proto3" json:"agent_descriptor,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Compression algorithms agentk supports for Message data.
+	SupportedCompressions []Compression `protobuf:"varint,2,rep,packed,name=supported_compressions,json=supportedCompressions,proto3,enum=plural.agent.reverse_tunnel.rpc.Compression" json:"supported_compressions,omitempty"`
+	// Set if agentk supports multiplexing several streams over the tunnel.
+	Multiplexing  *Multiplexing `protobuf:"bytes,3,opt,name=multiplexing,proto3" json:"multiplexing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Descriptor) Reset() {
@@ -72,6 +126,76 @@ func (x *Descriptor) GetAgentDescriptor() *info.AgentDescriptor {
 	return nil
 }
 
+func (x *Descriptor) GetSupportedCompressions() []Compression {
+	if x != nil {
+		return x.SupportedCompressions
+	}
+	return nil
+}
+
+func (x *Descriptor) GetMultiplexing() *Multiplexing {
+	if x != nil {
+		return x.Multiplexing
+	}
+	return nil
+}
+
+// Multiplexing describes agentk's multiplexing limits.
+type Multiplexing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of concurrent streams over one tunnel.
+	MaxStreams uint32 `protobuf:"varint,1,opt,name=max_streams,json=maxStreams,proto3" json:"max_streams,omitempty"`
+	// Number of bytes of Message data that can be sent on a stream in each direction
+	// before the receiver grants more with a WindowUpdate.
+	InitialWindowSize uint32 `protobuf:"varint,2,opt,name=initial_window_size,json=initialWindowSize,proto3" json:"initial_window_size,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Multiplexing) Reset() {
+	*x = Multiplexing{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Multiplexing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Multiplexing) ProtoMessage() {}
+
+func (x *Multiplexing) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Multiplexing.ProtoReflect.Descriptor instead.
+func (*Multiplexing) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Multiplexing) GetMaxStreams() uint32 {
+	if x != nil {
+		return x.MaxStreams
+	}
+	return 0
+}
+
+func (x *Multiplexing) GetInitialWindowSize() uint32 {
+	if x != nil {
+		return x.InitialWindowSize
+	}
+	return 0
+}
+
 // Header is a gRPC metadata.
 type Header struct {
 	state         protoimpl.MessageState       `protogen:"open.v1"`
@@ -82,7 +206,7 @@ type Header struct {
 
 func (x *Header) Reset() {
 	*x = Header{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[1]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -94,7 +218,7 @@ func (x *Header) String() string {
 func (*Header) ProtoMessage() {}
 
 func (x *Header) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[1]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,7 +231,7 @@ func (x *Header) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Header.ProtoReflect.Descriptor instead.
 func (*Header) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{1}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Header) GetMeta() map[string]*prototool.Values {
@@ -119,15 +243,17 @@ func (x *Header) GetMeta() map[string]*prototool.Values {
 
 // Message is a gRPC message data.
 type Message struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Compression algorithm data is compressed with.
+	Compression   Compression `protobuf:"varint,2,opt,name=compression,proto3,enum=plural.agent.reverse_tunnel.rpc.Compression" json:"compression,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[2]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -139,7 +265,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[2]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -152,7 +278,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{2}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Message) GetData() []byte {
@@ -162,6 +288,13 @@ func (x *Message) GetData() []byte {
 	return nil
 }
 
+func (x *Message) GetCompression() Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return Compression_none
+}
+
 // Trailer is a gRPC trailer metadata.
 type Trailer struct {
 	state         protoimpl.MessageState       `protogen:"open.v1"`
@@ -172,7 +305,7 @@ type Trailer struct {
 
 func (x *Trailer) Reset() {
 	*x = Trailer{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[3]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -184,7 +317,7 @@ func (x *Trailer) String() string {
 func (*Trailer) ProtoMessage() {}
 
 func (x *Trailer) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[3]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,7 +330,7 @@ func (x *Trailer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Trailer.ProtoReflect.Descriptor instead.
 func (*Trailer) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{3}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Trailer) GetMeta() map[string]*prototool.Values {
@@ -219,7 +352,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[4]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -231,7 +364,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[4]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -244,7 +377,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{4}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Error) GetStatus() *status.Status {
@@ -263,6 +396,7 @@ type ConnectRequest struct {
 	//	*ConnectRequest_Message
 	//	*ConnectRequest_Trailer
 	//	*ConnectRequest_Error
+	//	*ConnectRequest_Mux
 	Msg           isConnectRequest_Msg `protobuf_oneof:"msg"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -270,7 +404,7 @@ type ConnectRequest struct {
 
 func (x *ConnectRequest) Reset() {
 	*x = ConnectRequest{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[5]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -282,7 +416,7 @@ func (x *ConnectRequest) String() string {
 func (*ConnectRequest) ProtoMessage() {}
 
 func (x *ConnectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[5]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +429,7 @@ func (x *ConnectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectRequest.ProtoReflect.Descriptor instead.
 func (*ConnectRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{5}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ConnectRequest) GetMsg() isConnectRequest_Msg {
@@ -350,6 +484,15 @@ func (x *ConnectRequest) GetError() *Error {
 	return nil
 }
 
+func (x *ConnectRequest) GetMux() *MuxRequest {
+	if x != nil {
+		if x, ok := x.Msg.(*ConnectRequest_Mux); ok {
+			return x.Mux
+		}
+	}
+	return nil
+}
+
 type isConnectRequest_Msg interface {
 	isConnectRequest_Msg()
 }
@@ -374,6 +517,10 @@ type ConnectRequest_Error struct {
 	Error *Error `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
 }
 
+type ConnectRequest_Mux struct {
+	Mux *MuxRequest `protobuf:"bytes,6,opt,name=mux,proto3,oneof"`
+}
+
 func (*ConnectRequest_Descriptor_) isConnectRequest_Msg() {}
 
 func (*ConnectRequest_Header) isConnectRequest_Msg() {}
@@ -384,17 +531,22 @@ func (*ConnectRequest_Trailer) isConnectRequest_Msg() {}
 
 func (*ConnectRequest_Error) isConnectRequest_Msg() {}
 
+func (*ConnectRequest_Mux) isConnectRequest_Msg() {}
+
 type RequestInfo struct {
-	state         protoimpl.MessageState       `protogen:"open.v1"`
-	MethodName    string                       `protobuf:"bytes,1,opt,name=method_name,json=methodName,proto3" json:"method_name,omitempty"`
-	Meta          map[string]*prototool.Values `protobuf:"bytes,2,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state      protoimpl.MessageState       `protogen:"open.v1"`
+	MethodName string                       `protobuf:"bytes,1,opt,name=method_name,json=methodName,proto3" json:"method_name,omitempty"`
+	Meta       map[string]*prototool.Values `protobuf:"bytes,2,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Compression algorithm agentk should use for Message data of this stream.
+	// Always one of the algorithms from Descriptor.supported_compressions or none.
+	Compression   Compression `protobuf:"varint,3,opt,name=compression,proto3,enum=plural.agent.reverse_tunnel.rpc.Compression" json:"compression,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RequestInfo) Reset() {
 	*x = RequestInfo{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[6]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -406,7 +558,7 @@ func (x *RequestInfo) String() string {
 func (*RequestInfo) ProtoMessage() {}
 
 func (x *RequestInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[6]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,7 +571,7 @@ func (x *RequestInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestInfo.ProtoReflect.Descriptor instead.
 func (*RequestInfo) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{6}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RequestInfo) GetMethodName() string {
@@ -436,6 +588,13 @@ func (x *RequestInfo) GetMeta() map[string]*prototool.Values {
 	return nil
 }
 
+func (x *RequestInfo) GetCompression() Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return Compression_none
+}
+
 type CloseSend struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -444,7 +603,7 @@ type CloseSend struct {
 
 func (x *CloseSend) Reset() {
 	*x = CloseSend{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[7]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -456,7 +615,7 @@ func (x *CloseSend) String() string {
 func (*CloseSend) ProtoMessage() {}
 
 func (x *CloseSend) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[7]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,9 +628,585 @@ func (x *CloseSend) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseSend.ProtoReflect.Descriptor instead.
 func (*CloseSend) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{7}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+// WindowUpdate grants the peer permission to send more Message data on a multiplexed stream.
+type WindowUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Increment     uint32                 `protobuf:"varint,1,opt,name=increment,proto3" json:"increment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WindowUpdate) Reset() {
+	*x = WindowUpdate{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowUpdate) ProtoMessage() {}
+
+func (x *WindowUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowUpdate.ProtoReflect.Descriptor instead.
+func (*WindowUpdate) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WindowUpdate) GetIncrement() uint32 {
+	if x != nil {
+		return x.Increment
+	}
+	return 0
+}
+
+// Cancel aborts a multiplexed stream.
+type Cancel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cancel) Reset() {
+	*x = Cancel{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cancel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cancel) ProtoMessage() {}
+
+func (x *Cancel) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cancel.ProtoReflect.Descriptor instead.
+func (*Cancel) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+// Ping asks the peer to reply with a Pong with the same id. Used to measure round-trip time of a multiplexed tunnel.
+type Ping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ping) Reset() {
+	*x = Ping{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ping) ProtoMessage() {}
+
+func (x *Ping) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ping.ProtoReflect.Descriptor instead.
+func (*Ping) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Ping) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Pong is a reply to a Ping.
+type Pong struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pong) Reset() {
+	*x = Pong{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pong) ProtoMessage() {}
+
+func (x *Pong) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pong.ProtoReflect.Descriptor instead.
+func (*Pong) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Pong) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GoAway tells agentk that kas will not open new streams on the multiplexed tunnel.
+// kas closes the tunnel once the open streams are done. agentk should open a replacement tunnel right away.
+// agentk sends it to kas to retire the tunnel, e.g. to reconnect with a rotated token. kas stops opening new streams
+// on the tunnel and closes it once the open streams are done.
+type GoAway struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoAway) Reset() {
+	*x = GoAway{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoAway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoAway) ProtoMessage() {}
+
+func (x *GoAway) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoAway.ProtoReflect.Descriptor instead.
+func (*GoAway) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{13}
+}
+
+// MuxRequest is a frame of a multiplexed stream, sent by agentk.
+// A stream is a Header, zero or more Message frames, a Trailer and then an Error or a CloseSend.
+// An Error may also be sent instead of the Header.
+// Pong and GoAway frames are not part of any stream and have stream_id set to 0.
+type MuxRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	StreamId uint64                 `protobuf:"varint,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*MuxRequest_Header
+	//	*MuxRequest_Message
+	//	*MuxRequest_Trailer
+	//	*MuxRequest_Error
+	//	*MuxRequest_CloseSend
+	//	*MuxRequest_WindowUpdate
+	//	*MuxRequest_Pong
+	//	*MuxRequest_GoAway
+	Msg           isMuxRequest_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuxRequest) Reset() {
+	*x = MuxRequest{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuxRequest) ProtoMessage() {}
+
+func (x *MuxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuxRequest.ProtoReflect.Descriptor instead.
+func (*MuxRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MuxRequest) GetStreamId() uint64 {
+	if x != nil {
+		return x.StreamId
+	}
+	return 0
+}
+
+func (x *MuxRequest) GetMsg() isMuxRequest_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetHeader() *Header {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetMessage() *Message {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetTrailer() *Trailer {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_Trailer); ok {
+			return x.Trailer
+		}
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetCloseSend() *CloseSend {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_CloseSend); ok {
+			return x.CloseSend
+		}
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetWindowUpdate() *WindowUpdate {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_WindowUpdate); ok {
+			return x.WindowUpdate
+		}
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetPong() *Pong {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_Pong); ok {
+			return x.Pong
+		}
+	}
+	return nil
+}
+
+func (x *MuxRequest) GetGoAway() *GoAway {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxRequest_GoAway); ok {
+			return x.GoAway
+		}
+	}
+	return nil
+}
+
+type isMuxRequest_Msg interface {
+	isMuxRequest_Msg()
+}
+
+type MuxRequest_Header struct {
+	Header *Header `protobuf:"bytes,2,opt,name=header,proto3,oneof"`
+}
+
+type MuxRequest_Message struct {
+	Message *Message `protobuf:"bytes,3,opt,name=message,proto3,oneof"`
+}
+
+type MuxRequest_Trailer struct {
+	Trailer *Trailer `protobuf:"bytes,4,opt,name=trailer,proto3,oneof"`
 }
 
+type MuxRequest_Error struct {
+	Error *Error `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+type MuxRequest_CloseSend struct {
+	CloseSend *CloseSend `protobuf:"bytes,6,opt,name=close_send,json=closeSend,proto3,oneof"`
+}
+
+type MuxRequest_WindowUpdate struct {
+	WindowUpdate *WindowUpdate `protobuf:"bytes,7,opt,name=window_update,json=windowUpdate,proto3,oneof"`
+}
+
+type MuxRequest_Pong struct {
+	Pong *Pong `protobuf:"bytes,8,opt,name=pong,proto3,oneof"`
+}
+
+type MuxRequest_GoAway struct {
+	GoAway *GoAway `protobuf:"bytes,9,opt,name=go_away,json=goAway,proto3,oneof"`
+}
+
+func (*MuxRequest_Header) isMuxRequest_Msg() {}
+
+func (*MuxRequest_Message) isMuxRequest_Msg() {}
+
+func (*MuxRequest_Trailer) isMuxRequest_Msg() {}
+
+func (*MuxRequest_Error) isMuxRequest_Msg() {}
+
+func (*MuxRequest_CloseSend) isMuxRequest_Msg() {}
+
+func (*MuxRequest_WindowUpdate) isMuxRequest_Msg() {}
+
+func (*MuxRequest_Pong) isMuxRequest_Msg() {}
+
+func (*MuxRequest_GoAway) isMuxRequest_Msg() {}
+
+// MuxResponse is a frame of a multiplexed stream, sent by kas.
+// A stream is a RequestInfo, zero or more Message frames and then a CloseSend.
+// A Cancel may be sent at any point to abort the stream.
+// Ping and GoAway frames are not part of any stream and have stream_id set to 0.
+type MuxResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	StreamId uint64                 `protobuf:"varint,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*MuxResponse_RequestInfo
+	//	*MuxResponse_Message
+	//	*MuxResponse_CloseSend
+	//	*MuxResponse_WindowUpdate
+	//	*MuxResponse_Cancel
+	//	*MuxResponse_Ping
+	//	*MuxResponse_GoAway
+	Msg           isMuxResponse_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuxResponse) Reset() {
+	*x = MuxResponse{}
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuxResponse) ProtoMessage() {}
+
+func (x *MuxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuxResponse.ProtoReflect.Descriptor instead.
+func (*MuxResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MuxResponse) GetStreamId() uint64 {
+	if x != nil {
+		return x.StreamId
+	}
+	return 0
+}
+
+func (x *MuxResponse) GetMsg() isMuxResponse_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *MuxResponse) GetRequestInfo() *RequestInfo {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxResponse_RequestInfo); ok {
+			return x.RequestInfo
+		}
+	}
+	return nil
+}
+
+func (x *MuxResponse) GetMessage() *Message {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxResponse_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *MuxResponse) GetCloseSend() *CloseSend {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxResponse_CloseSend); ok {
+			return x.CloseSend
+		}
+	}
+	return nil
+}
+
+func (x *MuxResponse) GetWindowUpdate() *WindowUpdate {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxResponse_WindowUpdate); ok {
+			return x.WindowUpdate
+		}
+	}
+	return nil
+}
+
+func (x *MuxResponse) GetCancel() *Cancel {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxResponse_Cancel); ok {
+			return x.Cancel
+		}
+	}
+	return nil
+}
+
+func (x *MuxResponse) GetPing() *Ping {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxResponse_Ping); ok {
+			return x.Ping
+		}
+	}
+	return nil
+}
+
+func (x *MuxResponse) GetGoAway() *GoAway {
+	if x != nil {
+		if x, ok := x.Msg.(*MuxResponse_GoAway); ok {
+			return x.GoAway
+		}
+	}
+	return nil
+}
+
+type isMuxResponse_Msg interface {
+	isMuxResponse_Msg()
+}
+
+type MuxResponse_RequestInfo struct {
+	RequestInfo *RequestInfo `protobuf:"bytes,2,opt,name=request_info,json=requestInfo,proto3,oneof"`
+}
+
+type MuxResponse_Message struct {
+	Message *Message `protobuf:"bytes,3,opt,name=message,proto3,oneof"`
+}
+
+type MuxResponse_CloseSend struct {
+	CloseSend *CloseSend `protobuf:"bytes,4,opt,name=close_send,json=closeSend,proto3,oneof"`
+}
+
+type MuxResponse_WindowUpdate struct {
+	WindowUpdate *WindowUpdate `protobuf:"bytes,5,opt,name=window_update,json=windowUpdate,proto3,oneof"`
+}
+
+type MuxResponse_Cancel struct {
+	Cancel *Cancel `protobuf:"bytes,6,opt,name=cancel,proto3,oneof"`
+}
+
+type MuxResponse_Ping struct {
+	Ping *Ping `protobuf:"bytes,7,opt,name=ping,proto3,oneof"`
+}
+
+type MuxResponse_GoAway struct {
+	GoAway *GoAway `protobuf:"bytes,8,opt,name=go_away,json=goAway,proto3,oneof"`
+}
+
+func (*MuxResponse_RequestInfo) isMuxResponse_Msg() {}
+
+func (*MuxResponse_Message) isMuxResponse_Msg() {}
+
+func (*MuxResponse_CloseSend) isMuxResponse_Msg() {}
+
+func (*MuxResponse_WindowUpdate) isMuxResponse_Msg() {}
+
+func (*MuxResponse_Cancel) isMuxResponse_Msg() {}
+
+func (*MuxResponse_Ping) isMuxResponse_Msg() {}
+
+func (*MuxResponse_GoAway) isMuxResponse_Msg() {}
+
 type ConnectResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Msg:
@@ -479,6 +1214,7 @@ type ConnectResponse struct {
 	//	*ConnectResponse_RequestInfo
 	//	*ConnectResponse_Message
 	//	*ConnectResponse_CloseSend
+	//	*ConnectResponse_Mux
 	Msg           isConnectResponse_Msg `protobuf_oneof:"msg"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -486,7 +1222,7 @@ type ConnectResponse struct {
 
 func (x *ConnectResponse) Reset() {
 	*x = ConnectResponse{}
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[8]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -498,7 +1234,7 @@ func (x *ConnectResponse) String() string {
 func (*ConnectResponse) ProtoMessage() {}
 
 func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[8]
+	mi := &file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +1247,7 @@ func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse.ProtoReflect.Descriptor instead.
 func (*ConnectResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{8}
+	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ConnectResponse) GetMsg() isConnectResponse_Msg {
@@ -548,6 +1284,15 @@ func (x *ConnectResponse) GetCloseSend() *CloseSend {
 	return nil
 }
 
+func (x *ConnectResponse) GetMux() *MuxResponse {
+	if x != nil {
+		if x, ok := x.Msg.(*ConnectResponse_Mux); ok {
+			return x.Mux
+		}
+	}
+	return nil
+}
+
 type isConnectResponse_Msg interface {
 	isConnectResponse_Msg()
 }
@@ -564,59 +1309,114 @@ type ConnectResponse_CloseSend struct {
 	CloseSend *CloseSend `protobuf:"bytes,3,opt,name=close_send,json=closeSend,proto3,oneof"`
 }
 
+type ConnectResponse_Mux struct {
+	Mux *MuxResponse `protobuf:"bytes,4,opt,name=mux,proto3,oneof"`
+}
+
 func (*ConnectResponse_RequestInfo) isConnectResponse_Msg() {}
 
 func (*ConnectResponse_Message) isConnectResponse_Msg() {}
 
 func (*ConnectResponse_CloseSend) isConnectResponse_Msg() {}
 
+func (*ConnectResponse_Mux) isConnectResponse_Msg() {}
+
 var File_pkg_module_reverse_tunnel_rpc_rpc_proto protoreflect.FileDescriptor
 
 const file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDesc = "" +
 	"\n" +
-	"'pkg/module/reverse_tunnel/rpc/rpc.proto\x12\x1fplural.agent.reverse_tunnel.rpc\x1a)pkg/tool/grpctool/automata/automata.proto\x1a\"pkg/tool/prototool/prototool.proto\x1a)pkg/module/reverse_tunnel/info/info.proto\x1a\x17validate/validate.proto\x1a\x17google/rpc/status.proto\"t\n" +
+	"'pkg/module/reverse_tunnel/rpc/rpc.proto\x12\x1fplural.agent.reverse_tunnel.rpc\x1a)pkg/tool/grpctool/automata/automata.proto\x1a\"pkg/tool/prototool/prototool.proto\x1a)pkg/module/reverse_tunnel/info/info.proto\x1a\x17validate/validate.proto\x1a\x17google/rpc/status.proto\"\xbb\x02\n" +
 	"\n" +
 	"Descriptor\x12f\n" +
-	"\x10agent_descriptor\x18\x01 \x01(\v21.plural.agent.reverse_tunnel.info.AgentDescriptorB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x0fagentDescriptor\"\xa8\x01\n" +
+	"\x10agent_descriptor\x18\x01 \x01(\v21.plural.agent.reverse_tunnel.info.AgentDescriptorB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x0fagentDescriptor\x12r\n" +
+	"\x16supported_compressions\x18\x02 \x03(\x0e2,.plural.agent.reverse_tunnel.rpc.CompressionB\r\xfaB\n" +
+	"\x92\x01\a\"\x05\x82\x01\x02\x10\x01R\x15supportedCompressions\x12Q\n" +
+	"\fmultiplexing\x18\x03 \x01(\v2-.plural.agent.reverse_tunnel.rpc.MultiplexingR\fmultiplexing\"q\n" +
+	"\fMultiplexing\x12(\n" +
+	"\vmax_streams\x18\x01 \x01(\rB\a\xfaB\x04*\x02 \x00R\n" +
+	"maxStreams\x127\n" +
+	"\x13initial_window_size\x18\x02 \x01(\rB\a\xfaB\x04*\x02 \x00R\x11initialWindowSize\"\xa8\x01\n" +
 	"\x06Header\x12E\n" +
 	"\x04meta\x18\x01 \x03(\v21.plural.agent.reverse_tunnel.rpc.Header.MetaEntryR\x04meta\x1aW\n" +
 	"\tMetaEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x124\n" +
-	"\x05value\x18\x02 \x01(\v2\x1e.plural.agent.prototool.ValuesR\x05value:\x028\x01\"\x1d\n" +
+	"\x05value\x18\x02 \x01(\v2\x1e.plural.agent.prototool.ValuesR\x05value:\x028\x01\"w\n" +
 	"\aMessage\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"\xaa\x01\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12X\n" +
+	"\vcompression\x18\x02 \x01(\x0e2,.plural.agent.reverse_tunnel.rpc.CompressionB\b\xfaB\x05\x82\x01\x02\x10\x01R\vcompression\"\xaa\x01\n" +
 	"\aTrailer\x12F\n" +
 	"\x04meta\x18\x01 \x03(\v22.plural.agent.reverse_tunnel.rpc.Trailer.MetaEntryR\x04meta\x1aW\n" +
 	"\tMetaEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x124\n" +
 	"\x05value\x18\x02 \x01(\v2\x1e.plural.agent.prototool.ValuesR\x05value:\x028\x01\"=\n" +
 	"\x05Error\x124\n" +
-	"\x06status\x18\x01 \x01(\v2\x12.google.rpc.StatusB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x06status\"\xe0\x03\n" +
-	"\x0eConnectRequest\x12]\n" +
+	"\x06status\x18\x01 \x01(\v2\x12.google.rpc.StatusB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x06status\"\xbb\x04\n" +
+	"\x0eConnectRequest\x12^\n" +
 	"\n" +
-	"descriptor\x18\x01 \x01(\v2+.plural.agent.reverse_tunnel.rpc.DescriptorB\x0e\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\x02\x02\x05H\x00R\n" +
+	"descriptor\x18\x01 \x01(\v2+.plural.agent.reverse_tunnel.rpc.DescriptorB\x0f\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\x03\x02\x05\x06H\x00R\n" +
 	"descriptor\x12Q\n" +
 	"\x06header\x18\x02 \x01(\v2'.plural.agent.reverse_tunnel.rpc.HeaderB\x0e\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\x02\x03\x04H\x00R\x06header\x12T\n" +
 	"\amessage\x18\x03 \x01(\v2(.plural.agent.reverse_tunnel.rpc.MessageB\x0e\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\x02\x03\x04H\x00R\amessage\x12]\n" +
 	"\atrailer\x18\x04 \x01(\v2(.plural.agent.reverse_tunnel.rpc.TrailerB\x17\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\v\x05\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\atrailer\x12V\n" +
 	"\x05error\x18\x05 \x01(\v2&.plural.agent.reverse_tunnel.rpc.ErrorB\x16\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\n" +
-	"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x05errorB\x0f\n" +
-	"\x03msg\x12\b\xf8B\x01\x8a\xf6,\x01\x01\"\xd3\x01\n" +
+	"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x05error\x12X\n" +
+	"\x03mux\x18\x06 \x01(\v2+.plural.agent.reverse_tunnel.rpc.MuxRequestB\x17\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\v\x06\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x03muxB\x0f\n" +
+	"\x03msg\x12\b\xf8B\x01\x8a\xf6,\x01\x01\"\xad\x02\n" +
 	"\vRequestInfo\x12\x1f\n" +
 	"\vmethod_name\x18\x01 \x01(\tR\n" +
 	"methodName\x12J\n" +
-	"\x04meta\x18\x02 \x03(\v26.plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntryR\x04meta\x1aW\n" +
+	"\x04meta\x18\x02 \x03(\v26.plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntryR\x04meta\x12X\n" +
+	"\vcompression\x18\x03 \x01(\x0e2,.plural.agent.reverse_tunnel.rpc.CompressionB\b\xfaB\x05\x82\x01\x02\x10\x01R\vcompression\x1aW\n" +
 	"\tMetaEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x124\n" +
 	"\x05value\x18\x02 \x01(\v2\x1e.plural.agent.prototool.ValuesR\x05value:\x028\x01\"\v\n" +
-	"\tCloseSend\"\xde\x02\n" +
+	"\tCloseSend\"5\n" +
+	"\fWindowUpdate\x12%\n" +
+	"\tincrement\x18\x01 \x01(\rB\a\xfaB\x04*\x02 \x00R\tincrement\"\b\n" +
+	"\x06Cancel\"\x16\n" +
+	"\x04Ping\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x16\n" +
+	"\x04Pong\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\b\n" +
+	"\x06GoAway\"\xb8\x05\n" +
+	"\n" +
+	"MuxRequest\x12\x1b\n" +
+	"\tstream_id\x18\x01 \x01(\x04R\bstreamId\x12K\n" +
+	"\x06header\x18\x02 \x01(\v2'.plural.agent.reverse_tunnel.rpc.HeaderB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x06header\x12N\n" +
+	"\amessage\x18\x03 \x01(\v2(.plural.agent.reverse_tunnel.rpc.MessageB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\amessage\x12N\n" +
+	"\atrailer\x18\x04 \x01(\v2(.plural.agent.reverse_tunnel.rpc.TrailerB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\atrailer\x12H\n" +
+	"\x05error\x18\x05 \x01(\v2&.plural.agent.reverse_tunnel.rpc.ErrorB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x05error\x12U\n" +
+	"\n" +
+	"close_send\x18\x06 \x01(\v2*.plural.agent.reverse_tunnel.rpc.CloseSendB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\tcloseSend\x12^\n" +
+	"\rwindow_update\x18\a \x01(\v2-.plural.agent.reverse_tunnel.rpc.WindowUpdateB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\fwindowUpdate\x12E\n" +
+	"\x04pong\x18\b \x01(\v2%.plural.agent.reverse_tunnel.rpc.PongB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x04pong\x12L\n" +
+	"\ago_away\x18\t \x01(\v2'.plural.agent.reverse_tunnel.rpc.GoAwayB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x06goAwayB\n" +
+	"\n" +
+	"\x03msg\x12\x03\xf8B\x01\"\xfc\x04\n" +
+	"\vMuxResponse\x12\x1b\n" +
+	"\tstream_id\x18\x01 \x01(\x04R\bstreamId\x12[\n" +
+	"\frequest_info\x18\x02 \x01(\v2,.plural.agent.reverse_tunnel.rpc.RequestInfoB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\vrequestInfo\x12N\n" +
+	"\amessage\x18\x03 \x01(\v2(.plural.agent.reverse_tunnel.rpc.MessageB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\amessage\x12U\n" +
+	"\n" +
+	"close_send\x18\x04 \x01(\v2*.plural.agent.reverse_tunnel.rpc.CloseSendB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\tcloseSend\x12^\n" +
+	"\rwindow_update\x18\x05 \x01(\v2-.plural.agent.reverse_tunnel.rpc.WindowUpdateB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\fwindowUpdate\x12K\n" +
+	"\x06cancel\x18\x06 \x01(\v2'.plural.agent.reverse_tunnel.rpc.CancelB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x06cancel\x12E\n" +
+	"\x04ping\x18\a \x01(\v2%.plural.agent.reverse_tunnel.rpc.PingB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x04ping\x12L\n" +
+	"\ago_away\x18\b \x01(\v2'.plural.agent.reverse_tunnel.rpc.GoAwayB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\x06goAwayB\n" +
+	"\n" +
+	"\x03msg\x12\x03\xf8B\x01\"\xba\x03\n" +
 	"\x0fConnectResponse\x12k\n" +
 	"\frequest_info\x18\x01 \x01(\v2,.plural.agent.reverse_tunnel.rpc.RequestInfoB\x18\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\f\x02\x03\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\vrequestInfo\x12^\n" +
 	"\amessage\x18\x02 \x01(\v2(.plural.agent.reverse_tunnel.rpc.MessageB\x18\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\f\x02\x03\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\amessage\x12c\n" +
 	"\n" +
 	"close_send\x18\x03 \x01(\v2*.plural.agent.reverse_tunnel.rpc.CloseSendB\x16\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\n" +
-	"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\tcloseSendB\x19\n" +
-	"\x03msg\x12\x12\xf8B\x01\x8a\xf6,\v\x01\xff\xff\xff\xff\xff\xff\xff\xff\xff\x012\x83\x01\n" +
+	"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\tcloseSend\x12Y\n" +
+	"\x03mux\x18\x04 \x01(\v2,.plural.agent.reverse_tunnel.rpc.MuxResponseB\x17\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\v\x04\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x03muxB\x1a\n" +
+	"\x03msg\x12\x13\xf8B\x01\x8a\xf6,\f\x01\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\x04*+\n" +
+	"\vCompression\x12\b\n" +
+	"\x04none\x10\x00\x12\b\n" +
+	"\x04gzip\x10\x01\x12\b\n" +
+	"\x04zstd\x10\x022\x83\x01\n" +
 	"\rReverseTunnel\x12r\n" +
 	"\aConnect\x12/.plural.agent.reverse_tunnel.rpc.ConnectRequest\x1a0.plural.agent.reverse_tunnel.rpc.ConnectResponse\"\x00(\x010\x01BDZBgithub.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/rpcb\x06proto3"
 
@@ -632,48 +1432,79 @@ func file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescGZIP() []byte {
 	return file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDescData
 }
 
-var file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_pkg_module_reverse_tunnel_rpc_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_pkg_module_reverse_tunnel_rpc_rpc_proto_goTypes = []any{
-	(*Descriptor)(nil),           // 0: plural.agent.reverse_tunnel.rpc.Descriptor
-	(*Header)(nil),               // 1: plural.agent.reverse_tunnel.rpc.Header
-	(*Message)(nil),              // 2: plural.agent.reverse_tunnel.rpc.Message
-	(*Trailer)(nil),              // 3: plural.agent.reverse_tunnel.rpc.Trailer
-	(*Error)(nil),                // 4: plural.agent.reverse_tunnel.rpc.Error
-	(*ConnectRequest)(nil),       // 5: plural.agent.reverse_tunnel.rpc.ConnectRequest
-	(*RequestInfo)(nil),          // 6: plural.agent.reverse_tunnel.rpc.RequestInfo
-	(*CloseSend)(nil),            // 7: plural.agent.reverse_tunnel.rpc.CloseSend
-	(*ConnectResponse)(nil),      // 8: plural.agent.reverse_tunnel.rpc.ConnectResponse
-	nil,                          // 9: plural.agent.reverse_tunnel.rpc.Header.MetaEntry
-	nil,                          // 10: plural.agent.reverse_tunnel.rpc.Trailer.MetaEntry
-	nil,                          // 11: plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntry
-	(*info.AgentDescriptor)(nil), // 12: plural.agent.reverse_tunnel.info.AgentDescriptor
-	(*status.Status)(nil),        // 13: google.rpc.Status
-	(*prototool.Values)(nil),     // 14: plural.agent.prototool.Values
+	(Compression)(0),             // 0: plural.agent.reverse_tunnel.rpc.Compression
+	(*Descriptor)(nil),           // 1: plural.agent.reverse_tunnel.rpc.Descriptor
+	(*Multiplexing)(nil),         // 2: plural.agent.reverse_tunnel.rpc.Multiplexing
+	(*Header)(nil),               // 3: plural.agent.reverse_tunnel.rpc.Header
+	(*Message)(nil),              // 4: plural.agent.reverse_tunnel.rpc.Message
+	(*Trailer)(nil),              // 5: plural.agent.reverse_tunnel.rpc.Trailer
+	(*Error)(nil),                // 6: plural.agent.reverse_tunnel.rpc.Error
+	(*ConnectRequest)(nil),       // 7: plural.agent.reverse_tunnel.rpc.ConnectRequest
+	(*RequestInfo)(nil),          // 8: plural.agent.reverse_tunnel.rpc.RequestInfo
+	(*CloseSend)(nil),            // 9: plural.agent.reverse_tunnel.rpc.CloseSend
+	(*WindowUpdate)(nil),         // 10: plural.agent.reverse_tunnel.rpc.WindowUpdate
+	(*Cancel)(nil),               // 11: plural.agent.reverse_tunnel.rpc.Cancel
+	(*Ping)(nil),                 // 12: plural.agent.reverse_tunnel.rpc.Ping
+	(*Pong)(nil),                 // 13: plural.agent.reverse_tunnel.rpc.Pong
+	(*GoAway)(nil),               // 14: plural.agent.reverse_tunnel.rpc.GoAway
+	(*MuxRequest)(nil),           // 15: plural.agent.reverse_tunnel.rpc.MuxRequest
+	(*MuxResponse)(nil),          // 16: plural.agent.reverse_tunnel.rpc.MuxResponse
+	(*ConnectResponse)(nil),      // 17: plural.agent.reverse_tunnel.rpc.ConnectResponse
+	nil,                          // 18: plural.agent.reverse_tunnel.rpc.Header.MetaEntry
+	nil,                          // 19: plural.agent.reverse_tunnel.rpc.Trailer.MetaEntry
+	nil,                          // 20: plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntry
+	(*info.AgentDescriptor)(nil), // 21: plural.agent.reverse_tunnel.info.AgentDescriptor
+	(*status.Status)(nil),        // 22: google.rpc.Status
+	(*prototool.Values)(nil),     // 23: plural.agent.prototool.Values
 }
 var file_pkg_module_reverse_tunnel_rpc_rpc_proto_depIdxs = []int32{
-	12, // 0: plural.agent.reverse_tunnel.rpc.Descriptor.agent_descriptor:type_name -> plural.agent.reverse_tunnel.info.AgentDescriptor
-	9,  // 1: plural.agent.reverse_tunnel.rpc.Header.meta:type_name -> plural.agent.reverse_tunnel.rpc.Header.MetaEntry
-	10, // 2: plural.agent.reverse_tunnel.rpc.Trailer.meta:type_name -> plural.agent.reverse_tunnel.rpc.Trailer.MetaEntry
-	13, // 3: plural.agent.reverse_tunnel.rpc.Error.status:type_name -> google.rpc.Status
-	0,  // 4: plural.agent.reverse_tunnel.rpc.ConnectRequest.descriptor:type_name -> plural.agent.reverse_tunnel.rpc.Descriptor
-	1,  // 5: plural.agent.reverse_tunnel.rpc.ConnectRequest.header:type_name -> plural.agent.reverse_tunnel.rpc.Header
-	2,  // 6: plural.agent.reverse_tunnel.rpc.ConnectRequest.message:type_name -> plural.agent.reverse_tunnel.rpc.Message
-	3,  // 7: plural.agent.reverse_tunnel.rpc.ConnectRequest.trailer:type_name -> plural.agent.reverse_tunnel.rpc.Trailer
-	4,  // 8: plural.agent.reverse_tunnel.rpc.ConnectRequest.error:type_name -> plural.agent.reverse_tunnel.rpc.Error
-	11, // 9: plural.agent.reverse_tunnel.rpc.RequestInfo.meta:type_name -> plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntry
-	6,  // 10: plural.agent.reverse_tunnel.rpc.ConnectResponse.request_info:type_name -> plural.agent.reverse_tunnel.rpc.RequestInfo
-	2,  // 11: plural.agent.reverse_tunnel.rpc.ConnectResponse.message:type_name -> plural.agent.reverse_tunnel.rpc.Message
-	7,  // 12: plural.agent.reverse_tunnel.rpc.ConnectResponse.close_send:type_name -> plural.agent.reverse_tunnel.rpc.CloseSend
-	14, // 13: plural.agent.reverse_tunnel.rpc.Header.MetaEntry.value:type_name -> plural.agent.prototool.Values
-	14, // 14: plural.agent.reverse_tunnel.rpc.Trailer.MetaEntry.value:type_name -> plural.agent.prototool.Values
-	14, // 15: plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntry.value:type_name -> plural.agent.prototool.Values
-	5,  // 16: plural.agent.reverse_tunnel.rpc.ReverseTunnel.Connect:input_type -> plural.agent.reverse_tunnel.rpc.ConnectRequest
-	8,  // 17: plural.agent.reverse_tunnel.rpc.ReverseTunnel.Connect:output_type -> plural.agent.reverse_tunnel.rpc.ConnectResponse
-	17, // [17:18] is the sub-list for method output_type
-	16, // [16:17] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	21, // 0: plural.agent.reverse_tunnel.rpc.Descriptor.agent_descriptor:type_name -> plural.agent.reverse_tunnel.info.AgentDescriptor
+	0,  // 1: plural.agent.reverse_tunnel.rpc.Descriptor.supported_compressions:type_name -> plural.agent.reverse_tunnel.rpc.Compression
+	2,  // 2: plural.agent.reverse_tunnel.rpc.Descriptor.multiplexing:type_name -> plural.agent.reverse_tunnel.rpc.Multiplexing
+	18, // 3: plural.agent.reverse_tunnel.rpc.Header.meta:type_name -> plural.agent.reverse_tunnel.rpc.Header.MetaEntry
+	0,  // 4: plural.agent.reverse_tunnel.rpc.Message.compression:type_name -> plural.agent.reverse_tunnel.rpc.Compression
+	19, // 5: plural.agent.reverse_tunnel.rpc.Trailer.meta:type_name -> plural.agent.reverse_tunnel.rpc.Trailer.MetaEntry
+	22, // 6: plural.agent.reverse_tunnel.rpc.Error.status:type_name -> google.rpc.Status
+	1,  // 7: plural.agent.reverse_tunnel.rpc.ConnectRequest.descriptor:type_name -> plural.agent.reverse_tunnel.rpc.Descriptor
+	3,  // 8: plural.agent.reverse_tunnel.rpc.ConnectRequest.header:type_name -> plural.agent.reverse_tunnel.rpc.Header
+	4,  // 9: plural.agent.reverse_tunnel.rpc.ConnectRequest.message:type_name -> plural.agent.reverse_tunnel.rpc.Message
+	5,  // 10: plural.agent.reverse_tunnel.rpc.ConnectRequest.trailer:type_name -> plural.agent.reverse_tunnel.rpc.Trailer
+	6,  // 11: plural.agent.reverse_tunnel.rpc.ConnectRequest.error:type_name -> plural.agent.reverse_tunnel.rpc.Error
+	15, // 12: plural.agent.reverse_tunnel.rpc.ConnectRequest.mux:type_name -> plural.agent.reverse_tunnel.rpc.MuxRequest
+	20, // 13: plural.agent.reverse_tunnel.rpc.RequestInfo.meta:type_name -> plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntry
+	0,  // 14: plural.agent.reverse_tunnel.rpc.RequestInfo.compression:type_name -> plural.agent.reverse_tunnel.rpc.Compression
+	3,  // 15: plural.agent.reverse_tunnel.rpc.MuxRequest.header:type_name -> plural.agent.reverse_tunnel.rpc.Header
+	4,  // 16: plural.agent.reverse_tunnel.rpc.MuxRequest.message:type_name -> plural.agent.reverse_tunnel.rpc.Message
+	5,  // 17: plural.agent.reverse_tunnel.rpc.MuxRequest.trailer:type_name -> plural.agent.reverse_tunnel.rpc.Trailer
+	6,  // 18: plural.agent.reverse_tunnel.rpc.MuxRequest.error:type_name -> plural.agent.reverse_tunnel.rpc.Error
+	9,  // 19: plural.agent.reverse_tunnel.rpc.MuxRequest.close_send:type_name -> plural.agent.reverse_tunnel.rpc.CloseSend
+	10, // 20: plural.agent.reverse_tunnel.rpc.MuxRequest.window_update:type_name -> plural.agent.reverse_tunnel.rpc.WindowUpdate
+	13, // 21: plural.agent.reverse_tunnel.rpc.MuxRequest.pong:type_name -> plural.agent.reverse_tunnel.rpc.Pong
+	14, // 22: plural.agent.reverse_tunnel.rpc.MuxRequest.go_away:type_name -> plural.agent.reverse_tunnel.rpc.GoAway
+	8,  // 23: plural.agent.reverse_tunnel.rpc.MuxResponse.request_info:type_name -> plural.agent.reverse_tunnel.rpc.RequestInfo
+	4,  // 24: plural.agent.reverse_tunnel.rpc.MuxResponse.message:type_name -> plural.agent.reverse_tunnel.rpc.Message
+	9,  // 25: plural.agent.reverse_tunnel.rpc.MuxResponse.close_send:type_name -> plural.agent.reverse_tunnel.rpc.CloseSend
+	10, // 26: plural.agent.reverse_tunnel.rpc.MuxResponse.window_update:type_name -> plural.agent.reverse_tunnel.rpc.WindowUpdate
+	11, // 27: plural.agent.reverse_tunnel.rpc.MuxResponse.cancel:type_name -> plural.agent.reverse_tunnel.rpc.Cancel
+	12, // 28: plural.agent.reverse_tunnel.rpc.MuxResponse.ping:type_name -> plural.agent.reverse_tunnel.rpc.Ping
+	14, // 29: plural.agent.reverse_tunnel.rpc.MuxResponse.go_away:type_name -> plural.agent.reverse_tunnel.rpc.GoAway
+	8,  // 30: plural.agent.reverse_tunnel.rpc.ConnectResponse.request_info:type_name -> plural.agent.reverse_tunnel.rpc.RequestInfo
+	4,  // 31: plural.agent.reverse_tunnel.rpc.ConnectResponse.message:type_name -> plural.agent.reverse_tunnel.rpc.Message
+	9,  // 32: plural.agent.reverse_tunnel.rpc.ConnectResponse.close_send:type_name -> plural.agent.reverse_tunnel.rpc.CloseSend
+	16, // 33: plural.agent.reverse_tunnel.rpc.ConnectResponse.mux:type_name -> plural.agent.reverse_tunnel.rpc.MuxResponse
+	23, // 34: plural.agent.reverse_tunnel.rpc.Header.MetaEntry.value:type_name -> plural.agent.prototool.Values
+	23, // 35: plural.agent.reverse_tunnel.rpc.Trailer.MetaEntry.value:type_name -> plural.agent.prototool.Values
+	23, // 36: plural.agent.reverse_tunnel.rpc.RequestInfo.MetaEntry.value:type_name -> plural.agent.prototool.Values
+	7,  // 37: plural.agent.reverse_tunnel.rpc.ReverseTunnel.Connect:input_type -> plural.agent.reverse_tunnel.rpc.ConnectRequest
+	17, // 38: plural.agent.reverse_tunnel.rpc.ReverseTunnel.Connect:output_type -> plural.agent.reverse_tunnel.rpc.ConnectResponse
+	38, // [38:39] is the sub-list for method output_type
+	37, // [37:38] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_pkg_module_reverse_tunnel_rpc_rpc_proto_init() }
@@ -681,30 +1512,52 @@ func file_pkg_module_reverse_tunnel_rpc_rpc_proto_init() {
 	if File_pkg_module_reverse_tunnel_rpc_rpc_proto != nil {
 		return
 	}
-	file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[5].OneofWrappers = []any{
+	file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[6].OneofWrappers = []any{
 		(*ConnectRequest_Descriptor_)(nil),
 		(*ConnectRequest_Header)(nil),
 		(*ConnectRequest_Message)(nil),
 		(*ConnectRequest_Trailer)(nil),
 		(*ConnectRequest_Error)(nil),
+		(*ConnectRequest_Mux)(nil),
+	}
+	file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[14].OneofWrappers = []any{
+		(*MuxRequest_Header)(nil),
+		(*MuxRequest_Message)(nil),
+		(*MuxRequest_Trailer)(nil),
+		(*MuxRequest_Error)(nil),
+		(*MuxRequest_CloseSend)(nil),
+		(*MuxRequest_WindowUpdate)(nil),
+		(*MuxRequest_Pong)(nil),
+		(*MuxRequest_GoAway)(nil),
+	}
+	file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[15].OneofWrappers = []any{
+		(*MuxResponse_RequestInfo)(nil),
+		(*MuxResponse_Message)(nil),
+		(*MuxResponse_CloseSend)(nil),
+		(*MuxResponse_WindowUpdate)(nil),
+		(*MuxResponse_Cancel)(nil),
+		(*MuxResponse_Ping)(nil),
+		(*MuxResponse_GoAway)(nil),
 	}
-	file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[8].OneofWrappers = []any{
+	file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes[16].OneofWrappers = []any{
 		(*ConnectResponse_RequestInfo)(nil),
 		(*ConnectResponse_Message)(nil),
 		(*ConnectResponse_CloseSend)(nil),
+		(*ConnectResponse_Mux)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDesc), len(file_pkg_module_reverse_tunnel_rpc_rpc_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_pkg_module_reverse_tunnel_rpc_rpc_proto_goTypes,
 		DependencyIndexes: file_pkg_module_reverse_tunnel_rpc_rpc_proto_depIdxs,
+		EnumInfos:         file_pkg_module_reverse_tunnel_rpc_rpc_proto_enumTypes,
 		MessageInfos:      file_pkg_module_reverse_tunnel_rpc_rpc_proto_msgTypes,
 	}.Build()
 	File_pkg_module_reverse_tunnel_rpc_rpc_proto = out.File
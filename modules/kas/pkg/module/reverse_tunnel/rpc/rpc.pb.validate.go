@@ -97,6 +97,51 @@ func (m *Descriptor) validate(all bool) error {
 		}
 	}
 
+	for idx, item := range m.GetSupportedCompressions() {
+		_, _ = idx, item
+
+		if _, ok := Compression_name[int32(item)]; !ok {
+			err := DescriptorValidationError{
+				field:  fmt.Sprintf("SupportedCompressions[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetMultiplexing()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DescriptorValidationError{
+					field:  "Multiplexing",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DescriptorValidationError{
+					field:  "Multiplexing",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMultiplexing()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DescriptorValidationError{
+				field:  "Multiplexing",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return DescriptorMultiError(errors)
 	}
@@ -174,6 +219,127 @@ var _ interface {
 	ErrorName() string
 } = DescriptorValidationError{}
 
+// Validate checks the field values on Multiplexing with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Multiplexing) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Multiplexing with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in MultiplexingMultiError, or
+// nil if none found.
+func (m *Multiplexing) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Multiplexing) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMaxStreams() <= 0 {
+		err := MultiplexingValidationError{
+			field:  "MaxStreams",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetInitialWindowSize() <= 0 {
+		err := MultiplexingValidationError{
+			field:  "InitialWindowSize",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return MultiplexingMultiError(errors)
+	}
+
+	return nil
+}
+
+// MultiplexingMultiError is an error wrapping multiple validation errors
+// returned by Multiplexing.ValidateAll() if the designated constraints aren't met.
+type MultiplexingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MultiplexingMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MultiplexingMultiError) AllErrors() []error { return m }
+
+// MultiplexingValidationError is the validation error returned by
+// Multiplexing.Validate if the designated constraints aren't met.
+type MultiplexingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MultiplexingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MultiplexingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MultiplexingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MultiplexingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MultiplexingValidationError) ErrorName() string { return "MultiplexingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MultiplexingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMultiplexing.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MultiplexingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MultiplexingValidationError{}
+
 // Validate checks the field values on Header with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -341,6 +507,17 @@ func (m *Message) validate(all bool) error {
 
 	// no validation rules for Data
 
+	if _, ok := Compression_name[int32(m.GetCompression())]; !ok {
+		err := MessageValidationError{
+			field:  "Compression",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return MessageMultiError(errors)
 	}
@@ -989,6 +1166,59 @@ func (m *ConnectRequest) validate(all bool) error {
 			}
 		}
 
+	case *ConnectRequest_Mux:
+		if v == nil {
+			err := ConnectRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetMux() == nil {
+			err := ConnectRequestValidationError{
+				field:  "Mux",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetMux()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConnectRequestValidationError{
+						field:  "Mux",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConnectRequestValidationError{
+						field:  "Mux",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetMux()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConnectRequestValidationError{
+					field:  "Mux",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
@@ -1151,6 +1381,17 @@ func (m *RequestInfo) validate(all bool) error {
 		}
 	}
 
+	if _, ok := Compression_name[int32(m.GetCompression())]; !ok {
+		err := RequestInfoValidationError{
+			field:  "Compression",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return RequestInfoMultiError(errors)
 	}
@@ -1327,86 +1568,1621 @@ var _ interface {
 	ErrorName() string
 } = CloseSendValidationError{}
 
-// Validate checks the field values on ConnectResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *ConnectResponse) Validate() error {
+// Validate checks the field values on WindowUpdate with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WindowUpdate) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ConnectResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// ConnectResponseMultiError, or nil if none found.
-func (m *ConnectResponse) ValidateAll() error {
+// ValidateAll checks the field values on WindowUpdate with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WindowUpdateMultiError, or
+// nil if none found.
+func (m *WindowUpdate) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ConnectResponse) validate(all bool) error {
+func (m *WindowUpdate) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	oneofMsgPresent := false
-	switch v := m.Msg.(type) {
-	case *ConnectResponse_RequestInfo:
-		if v == nil {
-			err := ConnectResponseValidationError{
-				field:  "Msg",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
+	if m.GetIncrement() <= 0 {
+		err := WindowUpdateValidationError{
+			field:  "Increment",
+			reason: "value must be greater than 0",
 		}
-		oneofMsgPresent = true
-
-		if m.GetRequestInfo() == nil {
-			err := ConnectResponseValidationError{
-				field:  "RequestInfo",
-				reason: "value is required",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
+	}
 
-		if all {
-			switch v := interface{}(m.GetRequestInfo()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ConnectResponseValidationError{
-						field:  "RequestInfo",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ConnectResponseValidationError{
-						field:  "RequestInfo",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetRequestInfo()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ConnectResponseValidationError{
-					field:  "RequestInfo",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	if len(errors) > 0 {
+		return WindowUpdateMultiError(errors)
+	}
 
-	case *ConnectResponse_Message:
-		if v == nil {
-			err := ConnectResponseValidationError{
+	return nil
+}
+
+// WindowUpdateMultiError is an error wrapping multiple validation errors
+// returned by WindowUpdate.ValidateAll() if the designated constraints aren't met.
+type WindowUpdateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WindowUpdateMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WindowUpdateMultiError) AllErrors() []error { return m }
+
+// WindowUpdateValidationError is the validation error returned by
+// WindowUpdate.Validate if the designated constraints aren't met.
+type WindowUpdateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WindowUpdateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WindowUpdateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WindowUpdateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WindowUpdateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WindowUpdateValidationError) ErrorName() string { return "WindowUpdateValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WindowUpdateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWindowUpdate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WindowUpdateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WindowUpdateValidationError{}
+
+// Validate checks the field values on Cancel with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Cancel) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Cancel with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in CancelMultiError, or nil if none found.
+func (m *Cancel) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Cancel) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return CancelMultiError(errors)
+	}
+
+	return nil
+}
+
+// CancelMultiError is an error wrapping multiple validation errors returned by
+// Cancel.ValidateAll() if the designated constraints aren't met.
+type CancelMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CancelMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CancelMultiError) AllErrors() []error { return m }
+
+// CancelValidationError is the validation error returned by Cancel.Validate if
+// the designated constraints aren't met.
+type CancelValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CancelValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CancelValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CancelValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CancelValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CancelValidationError) ErrorName() string { return "CancelValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CancelValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCancel.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CancelValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CancelValidationError{}
+
+// Validate checks the field values on Ping with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Ping) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Ping with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in PingMultiError, or nil if none found.
+func (m *Ping) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Ping) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return PingMultiError(errors)
+	}
+
+	return nil
+}
+
+// PingMultiError is an error wrapping multiple validation errors returned by
+// Ping.ValidateAll() if the designated constraints aren't met.
+type PingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PingMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PingMultiError) AllErrors() []error { return m }
+
+// PingValidationError is the validation error returned by Ping.Validate if the
+// designated constraints aren't met.
+type PingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PingValidationError) ErrorName() string { return "PingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPing.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PingValidationError{}
+
+// Validate checks the field values on Pong with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Pong) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Pong with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in PongMultiError, or nil if none found.
+func (m *Pong) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Pong) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return PongMultiError(errors)
+	}
+
+	return nil
+}
+
+// PongMultiError is an error wrapping multiple validation errors returned by
+// Pong.ValidateAll() if the designated constraints aren't met.
+type PongMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PongMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PongMultiError) AllErrors() []error { return m }
+
+// PongValidationError is the validation error returned by Pong.Validate if the
+// designated constraints aren't met.
+type PongValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PongValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PongValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PongValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PongValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PongValidationError) ErrorName() string { return "PongValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PongValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPong.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PongValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PongValidationError{}
+
+// Validate checks the field values on GoAway with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GoAway) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GoAway with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in GoAwayMultiError, or nil if none found.
+func (m *GoAway) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GoAway) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GoAwayMultiError(errors)
+	}
+
+	return nil
+}
+
+// GoAwayMultiError is an error wrapping multiple validation errors returned by
+// GoAway.ValidateAll() if the designated constraints aren't met.
+type GoAwayMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GoAwayMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GoAwayMultiError) AllErrors() []error { return m }
+
+// GoAwayValidationError is the validation error returned by GoAway.Validate if
+// the designated constraints aren't met.
+type GoAwayValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GoAwayValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GoAwayValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GoAwayValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GoAwayValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GoAwayValidationError) ErrorName() string { return "GoAwayValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GoAwayValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGoAway.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GoAwayValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GoAwayValidationError{}
+
+// Validate checks the field values on MuxRequest with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *MuxRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MuxRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in MuxRequestMultiError, or
+// nil if none found.
+func (m *MuxRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MuxRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for StreamId
+
+	oneofMsgPresent := false
+	switch v := m.Msg.(type) {
+	case *MuxRequest_Header:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetHeader() == nil {
+			err := MuxRequestValidationError{
+				field:  "Header",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetHeader()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Header",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Header",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetHeader()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "Header",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxRequest_Message:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetMessage() == nil {
+			err := MuxRequestValidationError{
+				field:  "Message",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetMessage()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Message",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Message",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetMessage()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "Message",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxRequest_Trailer:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetTrailer() == nil {
+			err := MuxRequestValidationError{
+				field:  "Trailer",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetTrailer()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Trailer",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Trailer",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetTrailer()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "Trailer",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxRequest_Error:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetError() == nil {
+			err := MuxRequestValidationError{
+				field:  "Error",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetError()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Error",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Error",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetError()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "Error",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxRequest_CloseSend:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetCloseSend() == nil {
+			err := MuxRequestValidationError{
+				field:  "CloseSend",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCloseSend()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "CloseSend",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "CloseSend",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCloseSend()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "CloseSend",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxRequest_WindowUpdate:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetWindowUpdate() == nil {
+			err := MuxRequestValidationError{
+				field:  "WindowUpdate",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetWindowUpdate()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "WindowUpdate",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "WindowUpdate",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetWindowUpdate()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "WindowUpdate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxRequest_Pong:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetPong() == nil {
+			err := MuxRequestValidationError{
+				field:  "Pong",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetPong()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Pong",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "Pong",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetPong()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "Pong",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxRequest_GoAway:
+		if v == nil {
+			err := MuxRequestValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetGoAway() == nil {
+			err := MuxRequestValidationError{
+				field:  "GoAway",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetGoAway()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "GoAway",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxRequestValidationError{
+						field:  "GoAway",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetGoAway()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxRequestValidationError{
+					field:  "GoAway",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofMsgPresent {
+		err := MuxRequestValidationError{
+			field:  "Msg",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return MuxRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// MuxRequestMultiError is an error wrapping multiple validation errors
+// returned by MuxRequest.ValidateAll() if the designated constraints aren't met.
+type MuxRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MuxRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MuxRequestMultiError) AllErrors() []error { return m }
+
+// MuxRequestValidationError is the validation error returned by
+// MuxRequest.Validate if the designated constraints aren't met.
+type MuxRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MuxRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MuxRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MuxRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MuxRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MuxRequestValidationError) ErrorName() string { return "MuxRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MuxRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMuxRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MuxRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MuxRequestValidationError{}
+
+// Validate checks the field values on MuxResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *MuxResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MuxResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in MuxResponseMultiError, or
+// nil if none found.
+func (m *MuxResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MuxResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for StreamId
+
+	oneofMsgPresent := false
+	switch v := m.Msg.(type) {
+	case *MuxResponse_RequestInfo:
+		if v == nil {
+			err := MuxResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetRequestInfo() == nil {
+			err := MuxResponseValidationError{
+				field:  "RequestInfo",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetRequestInfo()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "RequestInfo",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "RequestInfo",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRequestInfo()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxResponseValidationError{
+					field:  "RequestInfo",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxResponse_Message:
+		if v == nil {
+			err := MuxResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetMessage() == nil {
+			err := MuxResponseValidationError{
+				field:  "Message",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetMessage()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "Message",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "Message",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetMessage()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxResponseValidationError{
+					field:  "Message",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxResponse_CloseSend:
+		if v == nil {
+			err := MuxResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetCloseSend() == nil {
+			err := MuxResponseValidationError{
+				field:  "CloseSend",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCloseSend()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "CloseSend",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "CloseSend",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCloseSend()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxResponseValidationError{
+					field:  "CloseSend",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxResponse_WindowUpdate:
+		if v == nil {
+			err := MuxResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetWindowUpdate() == nil {
+			err := MuxResponseValidationError{
+				field:  "WindowUpdate",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetWindowUpdate()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "WindowUpdate",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "WindowUpdate",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetWindowUpdate()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxResponseValidationError{
+					field:  "WindowUpdate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxResponse_Cancel:
+		if v == nil {
+			err := MuxResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetCancel() == nil {
+			err := MuxResponseValidationError{
+				field:  "Cancel",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCancel()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "Cancel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "Cancel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCancel()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxResponseValidationError{
+					field:  "Cancel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxResponse_Ping:
+		if v == nil {
+			err := MuxResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetPing() == nil {
+			err := MuxResponseValidationError{
+				field:  "Ping",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetPing()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "Ping",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "Ping",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetPing()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxResponseValidationError{
+					field:  "Ping",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MuxResponse_GoAway:
+		if v == nil {
+			err := MuxResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetGoAway() == nil {
+			err := MuxResponseValidationError{
+				field:  "GoAway",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetGoAway()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "GoAway",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MuxResponseValidationError{
+						field:  "GoAway",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetGoAway()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MuxResponseValidationError{
+					field:  "GoAway",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofMsgPresent {
+		err := MuxResponseValidationError{
+			field:  "Msg",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return MuxResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// MuxResponseMultiError is an error wrapping multiple validation errors
+// returned by MuxResponse.ValidateAll() if the designated constraints aren't met.
+type MuxResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MuxResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MuxResponseMultiError) AllErrors() []error { return m }
+
+// MuxResponseValidationError is the validation error returned by
+// MuxResponse.Validate if the designated constraints aren't met.
+type MuxResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MuxResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MuxResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MuxResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MuxResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MuxResponseValidationError) ErrorName() string { return "MuxResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MuxResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMuxResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MuxResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MuxResponseValidationError{}
+
+// Validate checks the field values on ConnectResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ConnectResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConnectResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConnectResponseMultiError, or nil if none found.
+func (m *ConnectResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConnectResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofMsgPresent := false
+	switch v := m.Msg.(type) {
+	case *ConnectResponse_RequestInfo:
+		if v == nil {
+			err := ConnectResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetRequestInfo() == nil {
+			err := ConnectResponseValidationError{
+				field:  "RequestInfo",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetRequestInfo()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConnectResponseValidationError{
+						field:  "RequestInfo",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConnectResponseValidationError{
+						field:  "RequestInfo",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRequestInfo()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConnectResponseValidationError{
+					field:  "RequestInfo",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ConnectResponse_Message:
+		if v == nil {
+			err := ConnectResponseValidationError{
 				field:  "Msg",
 				reason: "oneof value cannot be a typed-nil",
 			}
@@ -1510,6 +3286,59 @@ func (m *ConnectResponse) validate(all bool) error {
 			}
 		}
 
+	case *ConnectResponse_Mux:
+		if v == nil {
+			err := ConnectResponseValidationError{
+				field:  "Msg",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMsgPresent = true
+
+		if m.GetMux() == nil {
+			err := ConnectResponseValidationError{
+				field:  "Mux",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetMux()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConnectResponseValidationError{
+						field:  "Mux",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConnectResponseValidationError{
+						field:  "Mux",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetMux()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConnectResponseValidationError{
+					field:  "Mux",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
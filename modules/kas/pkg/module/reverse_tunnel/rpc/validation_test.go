@@ -16,6 +16,17 @@ func TestValidation_Valid(t *testing.T) {
 				Status: &status.Status{},
 			},
 		},
+		{
+			Name: "multiplexed message",
+			Valid: &MuxRequest{
+				StreamId: 1,
+				Msg: &MuxRequest_Message{
+					Message: &Message{
+						Compression: Compression_zstd,
+					},
+				},
+			},
+		},
 	}
 	testhelpers.AssertValid(t, tests)
 }
@@ -26,6 +37,26 @@ func TestValidation_Invalid(t *testing.T) {
 			ErrString: "invalid Error.Status: value is required",
 			Invalid:   &Error{},
 		},
+		{
+			ErrString: "invalid Multiplexing.MaxStreams: value must be greater than 0",
+			Invalid: &Multiplexing{
+				InitialWindowSize: 1,
+			},
+		},
+		{
+			ErrString: "invalid WindowUpdate.Increment: value must be greater than 0",
+			Invalid:   &WindowUpdate{},
+		},
+		{
+			ErrString: "invalid MuxResponse.Msg: value is required",
+			Invalid:   &MuxResponse{},
+		},
+		{
+			ErrString: "invalid Message.Compression: value must be one of the defined enum values",
+			Invalid: &Message{
+				Compression: 10,
+			},
+		},
 	}
 	testhelpers.AssertInvalid(t, tests)
 }
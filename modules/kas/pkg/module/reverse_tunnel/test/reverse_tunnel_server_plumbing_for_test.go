@@ -32,7 +32,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
-func serverConstructComponents(ctx context.Context, t *testing.T) (func(context.Context) error, *grpc.ClientConn, *grpc.ClientConn, *mock_modserver2.MockAgentRpcApi, *mock_reverse_tunnel_tunnel.MockTracker) {
+func serverConstructComponents(ctx context.Context, t *testing.T, mode tunnelMode) (func(context.Context) error, *grpc.ClientConn, *grpc.ClientConn, *mock_modserver2.MockAgentRpcApi, *mock_reverse_tunnel_tunnel.MockTracker) {
 	log := zaptest.NewLogger(t)
 	ctrl := gomock.NewController(t)
 	mockApi := mock_modserver2.NewMockApi(ctrl)
@@ -58,7 +58,7 @@ func serverConstructComponents(ctx context.Context, t *testing.T) (func(context.
 
 	internalListener := grpctool2.NewDialListener()
 	tr := trace.NewNoopTracerProvider().Tracer("test")
-	tunnelRegistry, err := tunnel.NewRegistry(log, mockApi, tr, time.Minute, time.Minute, tunnelTracker)
+	tunnelRegistry, err := tunnel.NewRegistry(log, mockApi, tr, time.Minute, time.Minute, tunnelTracker, mode.compression, mode.multiplexing)
 	require.NoError(t, err)
 
 	internalServer := serverConstructInternalServer(ctx, log)
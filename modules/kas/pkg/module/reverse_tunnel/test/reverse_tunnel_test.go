@@ -2,7 +2,10 @@ package test
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -164,6 +167,69 @@ func testStreamHappyPath(ctx context.Context, t *testing.T, client test2.Testing
 	assert.Equal(t, meta.Get(metaKey), header.Get(metaKey))
 }
 
+func TestStreamLargeMessagesConcurrently(t *testing.T) {
+	const (
+		messages = 10
+		streams  = 5
+	)
+	ats := &test2.GrpcTestingServer{
+		StreamingFunc: func(server test2.Testing_StreamingRequestResponseServer) error {
+			recv, err := server.Recv()
+			if err != nil {
+				return status.Error(codes.Unavailable, "unavailable")
+			}
+			for i := 0; i < messages; i++ {
+				err = server.Send(&test2.Response{
+					Message: &test2.Response_Data_{
+						Data: &test2.Response_Data{
+							Data: []byte(strings.Repeat(recv.S1, 10*1024)),
+						},
+					},
+				})
+				if err != nil {
+					return status.Error(codes.Unavailable, "unavailable")
+				}
+			}
+			return nil
+		},
+	}
+	runTest(t, ats, func(ctx context.Context, t *testing.T, client test2.TestingClient) {
+		var g errgroup.Group
+		for i := 0; i < streams; i++ {
+			s1 := strings.Repeat(strconv.Itoa(i), 10) // larger than the window of multiplexed streams in total
+			g.Go(func() error {
+				stream, err := client.StreamingRequestResponse(ctx)
+				if err != nil {
+					return err
+				}
+				err = stream.Send(&test2.Request{S1: s1})
+				if err != nil {
+					return err
+				}
+				err = stream.CloseSend()
+				if err != nil {
+					return err
+				}
+				for j := 0; j < messages; j++ {
+					resp, err := stream.Recv()
+					if err != nil {
+						return err
+					}
+					if string(resp.Message.(*test2.Response_Data_).Data.Data) != strings.Repeat(s1, 10*1024) {
+						return fmt.Errorf("unexpected data in message %d", j)
+					}
+				}
+				_, err = stream.Recv()
+				if err != io.EOF { // nolint:errorlint
+					return fmt.Errorf("expected io.EOF, got %w", err)
+				}
+				return nil
+			})
+		}
+		require.NoError(t, g.Wait())
+	})
+}
+
 func TestUnaryHappyPath(t *testing.T) {
 	ats := &test2.GrpcTestingServer{
 		UnaryFunc: func(ctx context.Context, request *test2.Request) (*test2.Response, error) {
@@ -260,13 +326,34 @@ func TestUnaryError(t *testing.T) {
 	})
 }
 
+type tunnelMode struct {
+	name         string
+	compression  rpc.Compression
+	multiplexing bool
+}
+
+var tunnelModes = []tunnelMode{
+	{name: "plain", compression: rpc.Compression_none},
+	{name: "zstd", compression: rpc.Compression_zstd},
+	{name: "multiplexed", compression: rpc.Compression_none, multiplexing: true},
+	{name: "multiplexed gzip", compression: rpc.Compression_gzip, multiplexing: true},
+}
+
 func runTest(t *testing.T, ats test2.TestingServer, f func(context.Context, *testing.T, test2.TestingClient)) {
+	for _, mode := range tunnelModes {
+		t.Run(mode.name, func(t *testing.T) {
+			runTestWithMode(t, mode, ats, f)
+		})
+	}
+}
+
+func runTestWithMode(t *testing.T, mode tunnelMode, ats test2.TestingServer, f func(context.Context, *testing.T, test2.TestingClient)) {
 	// Start/stop
 	g, ctx := errgroup.WithContext(context.Background())
 	ctx, cancel := context.WithCancel(ctx)
 
 	// Construct server and agent components
-	runServer, kasConn, serverInternalServerConn, serverRpcApi, tunnelRegisterer := serverConstructComponents(ctx, t)
+	runServer, kasConn, serverInternalServerConn, serverRpcApi, tunnelRegisterer := serverConstructComponents(ctx, t, mode)
 	defer func() {
 		assert.NoError(t, kasConn.Close())
 		assert.NoError(t, serverInternalServerConn.Close())
@@ -69,6 +69,7 @@ type tunnelImpl struct {
 	tunnelRetErr        chan<- error
 	agentId             int64
 	agentDescriptor     *info.AgentDescriptor
+	compression         rpc2.Compression
 	state               stateType
 
 	onForward func(*tunnelImpl) error
@@ -109,8 +110,9 @@ func (t *tunnelImpl) forwardStream(log *zap.Logger, rpcApi RpcApi, incomingStrea
 		err := t.tunnel.Send(&rpc2.ConnectResponse{
 			Msg: &rpc2.ConnectResponse_RequestInfo{
 				RequestInfo: &rpc2.RequestInfo{
-					MethodName: grpc.ServerTransportStreamFromContext(incomingCtx).Method(),
-					Meta:       grpctool2.MetaToValuesMap(md),
+					MethodName:  grpc.ServerTransportStreamFromContext(incomingCtx).Method(),
+					Meta:        grpctool2.MetaToValuesMap(md),
+					Compression: t.compression,
 				},
 			},
 		})
@@ -128,11 +130,14 @@ func (t *tunnelImpl) forwardStream(log *zap.Logger, rpcApi RpcApi, incomingStrea
 				}
 				return status.Error(codes.Canceled, "read from incoming stream"), err
 			}
+			msg, err := rpc2.NewMessage(t.compression, frame.Data)
+			if err != nil {
+				err = status.Errorf(codes.Internal, "compress message: %v", err)
+				return err, err
+			}
 			err = t.tunnel.Send(&rpc2.ConnectResponse{
 				Msg: &rpc2.ConnectResponse_Message{
-					Message: &rpc2.Message{
-						Data: frame.Data,
-					},
+					Message: msg,
 				},
 			})
 			if err != nil {
@@ -156,11 +161,16 @@ func (t *tunnelImpl) forwardStream(log *zap.Logger, rpcApi RpcApi, incomingStrea
 		var forTunnel, forIncomingStream error
 		fromVisitor := t.tunnelStreamVisitor.Visit(t.tunnel,
 			grpctool2.WithStartState(agentDescriptorNumber),
+			grpctool2.WithNotExpectingToGet(codes.InvalidArgument, muxNumber),
 			grpctool2.WithCallback(headerNumber, func(header *rpc2.Header) error {
 				return cb.Header(header.Meta)
 			}),
 			grpctool2.WithCallback(messageNumber, func(message *rpc2.Message) error {
-				return cb.Message(message.Data)
+				data, err := message.Decompress(t.compression)
+				if err != nil {
+					return status.Errorf(codes.InvalidArgument, "decompress message: %v", err)
+				}
+				return cb.Message(data)
 			}),
 			grpctool2.WithCallback(trailerNumber, func(trailer *rpc2.Trailer) error {
 				return cb.Trailer(trailer.Meta)
@@ -0,0 +1,158 @@
+package tunnel
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Introspector provides information about tunnels connected to this kas instance.
+type Introspector interface {
+	Querier
+	// AgentTunnels returns information about tunnels and pending find requests of an agent.
+	// Safe for concurrent use.
+	AgentTunnels(agentId int64) AgentTunnelsInfo
+	// AllAgentTunnels returns information about tunnels and pending find requests of all agents that have them,
+	// sorted by agent id.
+	// Safe for concurrent use.
+	AllAgentTunnels() []AgentTunnelsInfo
+}
+
+// AgentTunnelsInfo contains information about tunnels of an agent.
+type AgentTunnelsInfo struct {
+	AgentId int64
+	// IdleTunnels is the number of non-multiplexed tunnels that are waiting to be used.
+	IdleTunnels int
+	// ActiveTunnels is the number of non-multiplexed tunnels that are being used to forward a request.
+	ActiveTunnels int
+	MuxTunnels    []MuxTunnelInfo
+	// PendingFindRequests is the number of FindTunnel() requests that are waiting for a tunnel.
+	PendingFindRequests int
+	// OldestPendingFindRequestAge is for how long the oldest pending request has been waiting.
+	OldestPendingFindRequestAge time.Duration
+}
+
+// MuxTunnelInfo contains information about a multiplexed tunnel.
+type MuxTunnelInfo struct {
+	ActiveStreams int
+	MaxStreams    int
+	// Draining is set when the tunnel is waiting for the active streams to finish before closing.
+	Draining bool
+	// RoundTripTime is the smoothed round-trip time. Zero if no ping has been answered yet.
+	RoundTripTime     time.Duration
+	LastRoundTripTime time.Duration
+	LastPongAt        time.Time
+}
+
+// liveTunnels holds tunnels of an agent that are being handled by HandleTunnel(), regardless of their state.
+type liveTunnels struct {
+	tuns    map[*tunnelImpl]struct{}
+	muxTuns map[*muxTunnel]struct{}
+}
+
+func (l *liveTunnels) isEmpty() bool {
+	return len(l.tuns) == 0 && len(l.muxTuns) == 0
+}
+
+func (r *Registry) AgentTunnels(agentId int64) AgentTunnelsInfo {
+	// Use GetPointer() to avoid copying the embedded mutex.
+	return r.stripes.GetPointer(agentId).AgentTunnels(agentId, time.Now())
+}
+
+func (r *Registry) AllAgentTunnels() []AgentTunnelsInfo {
+	now := time.Now()
+	var infos []AgentTunnelsInfo
+	for s := range r.stripes.Stripes { // use index var to avoid copying embedded mutex
+		infos = append(infos, r.stripes.Stripes[s].AllAgentTunnels(now)...)
+	}
+	slices.SortFunc(infos, func(a, b AgentTunnelsInfo) int {
+		return cmp.Compare(a.AgentId, b.AgentId)
+	})
+	return infos
+}
+
+func (r *registryStripe) AgentTunnels(agentId int64, now time.Time) AgentTunnelsInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.agentTunnelsLocked(agentId, now)
+}
+
+func (r *registryStripe) AllAgentTunnels(now time.Time) []AgentTunnelsInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	infos := make([]AgentTunnelsInfo, 0, len(r.liveTunsByAgentId))
+	for agentId := range r.liveTunsByAgentId {
+		infos = append(infos, r.agentTunnelsLocked(agentId, now))
+	}
+	for agentId := range r.findRequestsByAgentId {
+		if _, ok := r.liveTunsByAgentId[agentId]; ok {
+			continue // added above
+		}
+		infos = append(infos, r.agentTunnelsLocked(agentId, now))
+	}
+	return infos
+}
+
+func (r *registryStripe) agentTunnelsLocked(agentId int64, now time.Time) AgentTunnelsInfo {
+	info := AgentTunnelsInfo{
+		AgentId: agentId,
+	}
+	if live := r.liveTunsByAgentId[agentId]; live != nil {
+		for tun := range live.tuns {
+			switch tun.state { // nolint: exhaustive
+			case stateReady:
+				info.IdleTunnels++
+			case stateFound, stateForwarding:
+				info.ActiveTunnels++
+			}
+		}
+		for mt := range live.muxTuns {
+			info.MuxTunnels = append(info.MuxTunnels, mt.infoLocked())
+		}
+	}
+	for ftr := range r.findRequestsByAgentId[agentId] {
+		info.PendingFindRequests++
+		info.OldestPendingFindRequestAge = max(info.OldestPendingFindRequestAge, now.Sub(ftr.createdAt))
+	}
+	return info
+}
+
+func (r *registryStripe) trackTunnelLocked(agentId int64, add func(*liveTunnels)) {
+	live := r.liveTunsByAgentId[agentId]
+	if live == nil {
+		live = &liveTunnels{
+			tuns:    make(map[*tunnelImpl]struct{}),
+			muxTuns: make(map[*muxTunnel]struct{}),
+		}
+		r.liveTunsByAgentId[agentId] = live
+	}
+	add(live)
+}
+
+// untrackTunnel removes a tunnel from the live tunnels when HandleTunnel() is about to return.
+func (r *registryStripe) untrackTunnel(agentId int64, remove func(*liveTunnels)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	live := r.liveTunsByAgentId[agentId]
+	if live == nil {
+		return // Stop() has been called.
+	}
+	remove(live)
+	if live.isEmpty() {
+		delete(r.liveTunsByAgentId, agentId)
+	}
+}
+
+// infoLocked must be called with registryStripe.mu held.
+func (t *muxTunnel) infoLocked() MuxTunnelInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return MuxTunnelInfo{
+		ActiveStreams:     t.reserved,
+		MaxStreams:        t.maxStreams,
+		Draining:          t.closed,
+		RoundTripTime:     t.rtt,
+		LastRoundTripTime: t.lastRtt,
+		LastPongAt:        t.lastPongAt,
+	}
+}
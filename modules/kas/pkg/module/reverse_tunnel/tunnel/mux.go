@@ -0,0 +1,461 @@
+package tunnel
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/info"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/mux"
+	rpc2 "github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/rpc"
+	grpctool2 "github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+)
+
+const (
+	muxNumber protoreflect.FieldNumber = 6
+
+	// pingPeriod is how often round-trip time of a multiplexed tunnel is measured.
+	pingPeriod = 15 * time.Second
+	// pongTimeout is how long to wait for a Pong before the Ping is considered lost and a new one may be sent.
+	pongTimeout = 10 * time.Second
+)
+
+// muxTunnel is a tunnel that carries several streams concurrently.
+// Unlike tunnelImpl, it stays in the registry while it's used and is only removed when it's closed.
+type muxTunnel struct {
+	tunnel          rpc2.ReverseTunnel_ConnectServer
+	agentId         int64
+	agentDescriptor *info.AgentDescriptor
+	compression     rpc2.Compression
+	maxStreams      int
+	windowSize      uint32
+	// retErr is used to make HandleTunnel() return.
+	retErr chan error
+	// goAway is signaled when agentk retires the tunnel.
+	goAway chan struct{}
+
+	onStreamDone func(context.Context, *muxStream)
+
+	// Fields below are protected by registryStripe.mu.
+
+	// reserved is the number of streams that have been handed out and are not done yet.
+	reserved int
+	// closed is set when the tunnel must not be used for new streams anymore.
+	closed bool
+	// idle is closed when the tunnel is closed and reserved drops to zero.
+	idle chan struct{}
+
+	sendMu sync.Mutex // serializes Send() calls
+
+	mu           sync.Mutex // protects fields below
+	streams      map[uint64]*muxStream
+	nextStreamId uint64
+	err          error
+	pingId       uint64
+	// pingSentAt is when the unanswered ping was sent. Zero if there is no unanswered ping.
+	pingSentAt time.Time
+	// rtt is the smoothed round-trip time.
+	rtt        time.Duration
+	lastRtt    time.Duration
+	lastPongAt time.Time
+}
+
+func (t *muxTunnel) canOpenLocked(service, method string) bool {
+	return !t.closed && t.reserved < t.maxStreams && t.agentDescriptor.SupportsServiceAndMethod(service, method)
+}
+
+func (t *muxTunnel) openStreamLocked() *muxStream {
+	t.reserved++
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextStreamId++
+	s := &muxStream{
+		tunnel:     t,
+		id:         t.nextStreamId,
+		recv:       mux.NewQueue[*rpc2.MuxRequest](),
+		sendWindow: mux.NewSendWindow(t.windowSize),
+		recvWindow: mux.NewRecvWindow(t.windowSize),
+	}
+	if t.err != nil {
+		s.recv.Close(t.err)
+		s.sendWindow.Close(t.err)
+	} else {
+		t.streams[s.id] = s
+	}
+	return s
+}
+
+func (t *muxTunnel) closeLocked() {
+	t.closed = true
+	if t.reserved > 0 {
+		t.idle = make(chan struct{})
+	}
+}
+
+func (t *muxTunnel) onStreamDoneLocked(s *muxStream) {
+	t.reserved--
+	if t.idle != nil && t.reserved == 0 {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// read reads frames from agentk and dispatches them to streams until the tunnel is closed.
+func (t *muxTunnel) read(visitor *grpctool2.StreamVisitor) error {
+	return visitor.Visit(t.tunnel,
+		grpctool2.WithStartState(agentDescriptorNumber),
+		grpctool2.WithNotExpectingToGet(codes.InvalidArgument, headerNumber, messageNumber, trailerNumber, errorNumber),
+		grpctool2.WithCallback(muxNumber, t.dispatch),
+	)
+}
+
+func (t *muxTunnel) dispatch(req *rpc2.MuxRequest) error {
+	switch msg := req.Msg.(type) {
+	case *rpc2.MuxRequest_Pong:
+		t.onPong(msg.Pong.Id)
+		return nil
+	case *rpc2.MuxRequest_GoAway:
+		select {
+		case t.goAway <- struct{}{}:
+		default: // Already retiring.
+		}
+		return nil
+	}
+	t.mu.Lock()
+	s := t.streams[req.StreamId]
+	t.mu.Unlock()
+	if s == nil {
+		// Stream is done already, drop the frame.
+		return nil
+	}
+	switch msg := req.Msg.(type) {
+	case *rpc2.MuxRequest_WindowUpdate:
+		s.sendWindow.Add(msg.WindowUpdate.Increment)
+	case *rpc2.MuxRequest_Message:
+		if err := s.recvWindow.Received(len(msg.Message.Data)); err != nil {
+			return status.Errorf(codes.InvalidArgument, "stream %d: %v", req.StreamId, err)
+		}
+		s.recv.Push(req)
+	default:
+		s.recv.Push(req)
+	}
+	return nil
+}
+
+// ping sends a Ping frame right away and then every period until ctx is done.
+// A new Ping is not sent while the previous one is unanswered, unless it has been unanswered for longer than timeout.
+func (t *muxTunnel) ping(ctx context.Context, period, timeout time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		err := t.sendPing(timeout)
+		if err != nil {
+			return // The tunnel is broken, read() will get an error too.
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *muxTunnel) sendPing(timeout time.Duration) error {
+	now := time.Now()
+	t.mu.Lock()
+	if !t.pingSentAt.IsZero() && now.Sub(t.pingSentAt) < timeout {
+		t.mu.Unlock()
+		return nil
+	}
+	// No unanswered Ping or it has been lost. A late Pong for a lost Ping has a stale id and is ignored.
+	t.pingId++
+	id := t.pingId
+	t.pingSentAt = now
+	t.mu.Unlock()
+	return t.send(&rpc2.MuxResponse{
+		Msg: &rpc2.MuxResponse_Ping{
+			Ping: &rpc2.Ping{
+				Id: id,
+			},
+		},
+	})
+}
+
+func (t *muxTunnel) onPong(id uint64) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pingSentAt.IsZero() || id != t.pingId {
+		return // Not a reply to the unanswered ping, ignore.
+	}
+	rtt := now.Sub(t.pingSentAt)
+	t.pingSentAt = time.Time{}
+	t.lastRtt = rtt
+	t.lastPongAt = now
+	if t.rtt == 0 {
+		t.rtt = rtt
+	} else {
+		// Same smoothing as for TCP's SRTT in RFC 6298.
+		t.rtt += (rtt - t.rtt) / 8
+	}
+}
+
+// sendGoAway tells agentk that no new streams will be opened on the tunnel.
+func (t *muxTunnel) sendGoAway() error {
+	return t.send(&rpc2.MuxResponse{
+		Msg: &rpc2.MuxResponse_GoAway{
+			GoAway: &rpc2.GoAway{},
+		},
+	})
+}
+
+// fail aborts all streams with err.
+func (t *muxTunnel) fail(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return
+	}
+	t.err = err
+	for id, s := range t.streams {
+		s.recv.Close(err)
+		s.sendWindow.Close(err)
+		delete(t.streams, id)
+	}
+}
+
+func (t *muxTunnel) send(resp *rpc2.MuxResponse) error {
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+	return t.tunnel.Send(&rpc2.ConnectResponse{
+		Msg: &rpc2.ConnectResponse_Mux{
+			Mux: resp,
+		},
+	})
+}
+
+// abort makes HandleTunnel() return err, closing the tunnel.
+func (t *muxTunnel) abort(err error) {
+	select {
+	case t.retErr <- err:
+	default: // Already aborting.
+	}
+}
+
+func (t *muxTunnel) removeStream(s *muxStream) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.streams, s.id)
+}
+
+// muxStream is a Tunnel that is a stream of a muxTunnel.
+type muxStream struct {
+	tunnel     *muxTunnel
+	id         uint64
+	recv       *mux.Queue[*rpc2.MuxRequest]
+	sendWindow *mux.SendWindow
+	recvWindow *mux.RecvWindow
+
+	mu        sync.Mutex
+	forwarded bool
+	done      bool
+}
+
+func (s *muxStream) ForwardStream(log *zap.Logger, rpcApi RpcApi, incomingStream grpc.ServerStream, cb DataCallback) error {
+	s.mu.Lock()
+	switch {
+	case s.done:
+		s.mu.Unlock()
+		return status.Error(codes.Internal, "ForwardStream() called after Done()")
+	case s.forwarded:
+		s.mu.Unlock()
+		return status.Error(codes.Internal, "ForwardStream() called more than once")
+	}
+	s.forwarded = true
+	s.mu.Unlock()
+
+	pair := s.forwardStream(log, rpcApi, incomingStream, cb)
+	if pair.forTunnel != nil {
+		s.tunnel.abort(pair.forTunnel)
+	}
+	return pair.forIncomingStream
+}
+
+func (s *muxStream) forwardStream(log *zap.Logger, rpcApi RpcApi, incomingStream grpc.ServerStream, cb DataCallback) errPair {
+	// See tunnelImpl.forwardStream() for the explanation of the approach.
+	// The difference is that only I/O errors on the tunnel abort it. Other errors abort just this stream.
+	res := make(chan errPair, 1)
+	incomingCtx := incomingStream.Context()
+	compression := s.tunnel.compression
+	// Pipe incoming stream (i.e. data a client is sending us) into the tunnel stream
+	goErrPair(res, func() (error /* forTunnel */, error /* forIncomingStream */) {
+		md, _ := metadata.FromIncomingContext(incomingCtx)
+		err := s.send(&rpc2.MuxResponse{
+			Msg: &rpc2.MuxResponse_RequestInfo{
+				RequestInfo: &rpc2.RequestInfo{
+					MethodName:  grpc.ServerTransportStreamFromContext(incomingCtx).Method(),
+					Meta:        grpctool2.MetaToValuesMap(md),
+					Compression: compression,
+				},
+			},
+		})
+		if err != nil {
+			err = rpcApi.HandleIoError(log, "Send(MuxResponse_RequestInfo)", err)
+			return err, err
+		}
+		// Outside the loop to allocate once vs on each message
+		var frame grpctool2.RawFrame
+		for {
+			err = incomingStream.RecvMsg(&frame)
+			if err != nil {
+				if err == io.EOF { // nolint:errorlint
+					break
+				}
+				s.cancel()
+				return nil, err
+			}
+			msg, err := rpc2.NewMessage(compression, frame.Data)
+			if err != nil {
+				s.cancel()
+				return nil, status.Errorf(codes.Internal, "compress message: %v", err)
+			}
+			err = s.sendWindow.Acquire(incomingCtx, len(msg.Data))
+			if err != nil {
+				s.cancel()
+				return nil, streamError(incomingCtx, err)
+			}
+			err = s.send(&rpc2.MuxResponse{
+				Msg: &rpc2.MuxResponse_Message{
+					Message: msg,
+				},
+			})
+			if err != nil {
+				err = rpcApi.HandleIoError(log, "Send(MuxResponse_Message)", err)
+				return err, err
+			}
+		}
+		err = s.send(&rpc2.MuxResponse{
+			Msg: &rpc2.MuxResponse_CloseSend{
+				CloseSend: &rpc2.CloseSend{},
+			},
+		})
+		if err != nil {
+			err = rpcApi.HandleIoError(log, "Send(MuxResponse_CloseSend)", err)
+			return err, err
+		}
+		return nil, nil
+	})
+	// Pipe tunnel stream (i.e. data agentk is sending us) into the incoming stream
+	goErrPair(res, func() (error /* forTunnel */, error /* forIncomingStream */) {
+		for {
+			req, err := s.recv.Pop(incomingCtx)
+			if err != nil {
+				s.cancel()
+				return nil, streamError(incomingCtx, err)
+			}
+			switch msg := req.Msg.(type) {
+			case *rpc2.MuxRequest_Header:
+				err = cb.Header(msg.Header.Meta)
+			case *rpc2.MuxRequest_Message:
+				var data []byte
+				data, err = msg.Message.Decompress(compression)
+				if err != nil {
+					s.cancel()
+					return nil, status.Errorf(codes.InvalidArgument, "decompress message: %v", err)
+				}
+				err = cb.Message(data)
+				if err == nil {
+					err = s.consumed(len(msg.Message.Data))
+					if err != nil {
+						err = rpcApi.HandleIoError(log, "Send(MuxResponse_WindowUpdate)", err)
+						return err, err
+					}
+				}
+			case *rpc2.MuxRequest_Trailer:
+				err = cb.Trailer(msg.Trailer.Meta)
+			case *rpc2.MuxRequest_Error:
+				return nil, cb.Error(msg.Error)
+			case *rpc2.MuxRequest_CloseSend:
+				return nil, nil
+			default:
+				err = status.Errorf(codes.InvalidArgument, "unexpected frame type: %T", req.Msg)
+			}
+			if err != nil {
+				s.cancel()
+				return nil, err
+			}
+		}
+	})
+	pair := <-res
+	if !pair.isNil() {
+		return pair
+	}
+	select {
+	case <-incomingCtx.Done():
+		// incoming stream finished sending all data (i.e. io.EOF was read from it) but
+		// now it signals that it's closing. Abort the stream in agentk.
+		s.cancel()
+		pair = errPair{
+			forIncomingStream: grpctool2.StatusErrorFromContext(incomingCtx, "Incoming stream closed"),
+		}
+	case pair = <-res:
+	}
+	return pair
+}
+
+func (s *muxStream) consumed(n int) error {
+	inc := s.recvWindow.Consumed(n)
+	if inc == 0 {
+		return nil
+	}
+	return s.send(&rpc2.MuxResponse{
+		Msg: &rpc2.MuxResponse_WindowUpdate{
+			WindowUpdate: &rpc2.WindowUpdate{
+				Increment: inc,
+			},
+		},
+	})
+}
+
+// cancel asks agentk to abort the stream. Errors are ignored as there is nothing more to do with the stream.
+func (s *muxStream) cancel() {
+	_ = s.send(&rpc2.MuxResponse{
+		Msg: &rpc2.MuxResponse_Cancel{
+			Cancel: &rpc2.Cancel{},
+		},
+	})
+}
+
+func (s *muxStream) send(resp *rpc2.MuxResponse) error {
+	resp.StreamId = s.id
+	return s.tunnel.send(resp)
+}
+
+func (s *muxStream) Done(ctx context.Context) {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		panic("Done() called more than once")
+	}
+	s.done = true
+	s.mu.Unlock()
+	s.tunnel.removeStream(s)
+	s.tunnel.onStreamDone(ctx, s)
+}
+
+// streamError converts an error from waiting on a stream into a gRPC status error.
+// err is either the tunnel error, which is a status error already, or a context error.
+func streamError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return grpctool2.StatusErrorFromContext(ctx, "Incoming stream closed")
+	}
+	return err
+}
@@ -25,6 +25,7 @@ const (
 	traceTunnelFoundAttr    attribute.Key = "found"
 	traceStoppedTunnelsAttr attribute.Key = "stoppedTunnels"
 	traceAbortedFTRAttr     attribute.Key = "abortedFTR"
+	traceDrainedAgentsAttr  attribute.Key = "drainedAgents"
 )
 
 type Handler interface {
@@ -32,6 +33,7 @@ type Handler interface {
 	// It registers the tunnel and blocks, waiting for a request to proxy through the tunnel.
 	// The method returns the error value to return to gRPC framework.
 	// ageCtx can be used to unblock the method if the tunnel is not being used already.
+	// The tunnel is rejected if the registry is draining.
 	HandleTunnel(ageCtx context.Context, agentInfo *api.AgentInfo, server rpc2.ReverseTunnel_ConnectServer) error
 }
 
@@ -52,6 +54,7 @@ type Finder interface {
 	// - supports handling provided gRPC service and method.
 	// Tunnel found boolean indicates whether a suitable tunnel is immediately available from the
 	// returned FindHandle object.
+	// No tunnel is found if the registry is draining, Get() of the returned FindHandle fails right away.
 	FindTunnel(ctx context.Context, agentId int64, service, method string) (bool, FindHandle)
 }
 
@@ -63,8 +66,11 @@ type Registry struct {
 	stripes       syncz.StripedValue[registryStripe]
 }
 
+// NewRegistry constructs a new Registry.
+// compression is the preferred compression algorithm for data sent over tunnels.
+// multiplexing enables multiplexing of streams over tunnels from agents that support it.
 func NewRegistry(log *zap.Logger, api modshared.Api, tracer trace.Tracer, refreshPeriod, ttl time.Duration,
-	tunnelTracker Tracker) (*Registry, error) {
+	tunnelTracker Tracker, compression rpc2.Compression, multiplexing bool) (*Registry, error) {
 	tunnelStreamVisitor, err := grpctool.NewStreamVisitor(&rpc2.ConnectRequest{})
 	if err != nil {
 		return nil, err
@@ -82,8 +88,11 @@ func NewRegistry(log *zap.Logger, api modshared.Api, tracer trace.Tracer, refres
 				tunnelStreamVisitor:   tunnelStreamVisitor,
 				tunnelTracker:         tunnelTracker,
 				ttl:                   ttl,
+				compression:           compression,
+				multiplexing:          multiplexing,
 				tunsByAgentId:         make(map[int64]agentId2tunInfo),
 				findRequestsByAgentId: make(map[int64]map[*findTunnelRequest]struct{}),
+				liveTunsByAgentId:     make(map[int64]*liveTunnels),
 			}
 		}),
 	}, nil
@@ -122,6 +131,31 @@ func (r *Registry) Run(ctx context.Context) error {
 	}
 }
 
+// Drain prepares the registry for shutdown, handing agents over to other kas replicas:
+// - tunnels are not used for new requests anymore and new tunnels are rejected.
+// - agents are unregistered from the tracker so that other kas replicas stop routing requests to this one.
+// In-flight requests are not affected. Tunnels are closed when their ageCtx is done: idle tunnels right away
+// and multiplexed tunnels once their streams are done.
+func (r *Registry) Drain(ctx context.Context) {
+	ctx = contextWithoutCancel(ctx)
+	ctx, cancel := context.WithTimeout(ctx, stopTimeout)
+	defer cancel()
+	ctx, span := r.tracer.Start(ctx, "Registry.Drain", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var wg wait.Group
+	var drainedAgents atomic.Int32
+
+	for s := range r.stripes.Stripes { // use index var to avoid copying embedded mutex
+		wg.Start(func() {
+			drainedAgents.Add(int32(r.stripes.Stripes[s].Drain(ctx)))
+		})
+	}
+	wg.Wait()
+
+	span.SetAttributes(traceDrainedAgentsAttr.Int(int(drainedAgents.Load())))
+}
+
 // stopInternal aborts any open tunnels.
 // It should not be necessary to abort tunnels when registry is used correctly i.e. this method is called after
 // all tunnels have terminated gracefully.
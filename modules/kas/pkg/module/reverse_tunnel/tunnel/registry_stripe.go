@@ -25,15 +25,26 @@ const (
 	unregistrationDelay = 5 * time.Second
 )
 
+var (
+	errDraining     = status.Error(codes.Unavailable, "kas is draining")
+	errShuttingDown = status.Error(codes.Unavailable, "kas is shutting down")
+)
+
 type findTunnelRequest struct {
 	agentId         int64
 	service, method string
-	retTun          chan<- *tunnelImpl
+	retTun          chan<- Tunnel
+	createdAt       time.Time
+	// abortErr is set before nil is sent to retTun to abort the request.
+	abortErr error
 }
 
 type findHandle struct {
-	tracer    trace.Tracer
-	retTun    <-chan *tunnelImpl
+	tracer trace.Tracer
+	retTun <-chan Tunnel
+	ftr    *findTunnelRequest
+	// err is returned by Get right away if set.
+	err       error
 	done      func(context.Context)
 	gotTunnel bool
 }
@@ -42,6 +53,10 @@ func (h *findHandle) Get(ctx context.Context) (Tunnel, error) {
 	ctx, span := h.tracer.Start(ctx, "findHandle.Get", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
 
+	if h.err != nil {
+		span.SetStatus(otelcodes.Error, h.err.Error())
+		return nil, h.err
+	}
 	select {
 	case <-ctx.Done():
 		span.SetStatus(otelcodes.Error, "FindTunnel request aborted")
@@ -50,8 +65,9 @@ func (h *findHandle) Get(ctx context.Context) (Tunnel, error) {
 	case tun := <-h.retTun:
 		h.gotTunnel = true
 		if tun == nil {
-			span.SetStatus(otelcodes.Error, "kas is shutting down")
-			return nil, status.Error(codes.Unavailable, "kas is shutting down")
+			err := h.ftr.abortErr // receiving from retTun makes it safe to read
+			span.SetStatus(otelcodes.Error, err.Error())
+			return nil, err
 		}
 		span.SetStatus(otelcodes.Ok, "")
 		return tun, nil
@@ -62,8 +78,8 @@ func (h *findHandle) Done(ctx context.Context) {
 	ctx, span := h.tracer.Start(ctx, "findHandle.Done", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
 
-	if h.gotTunnel {
-		// No cleanup needed if Get returned a tunnel.
+	if h.gotTunnel || h.err != nil {
+		// No cleanup needed if Get returned a tunnel or the request has not been queued.
 		return
 	}
 	h.done(ctx)
@@ -71,12 +87,17 @@ func (h *findHandle) Done(ctx context.Context) {
 
 type agentId2tunInfo struct {
 	tuns      map[*tunnelImpl]struct{}
+	muxTuns   map[*muxTunnel]struct{}
 	waitForIO chan struct{}
 	// stopIO can be called to stop a pending (un)registration if it hasn't started yet
 	stopIO        func() bool
 	missedRefresh bool
 }
 
+func (i *agentId2tunInfo) isEmpty() bool {
+	return len(i.tuns) == 0 && len(i.muxTuns) == 0
+}
+
 type registryStripe struct {
 	log                 *zap.Logger
 	api                 modshared.Api
@@ -84,10 +105,15 @@ type registryStripe struct {
 	tunnelStreamVisitor *grpctool2.StreamVisitor
 	tunnelTracker       Tracker
 	ttl                 time.Duration
+	compression         rpc2.Compression
+	multiplexing        bool
 
 	mu                    sync.Mutex
 	tunsByAgentId         map[int64]agentId2tunInfo
 	findRequestsByAgentId map[int64]map[*findTunnelRequest]struct{}
+	liveTunsByAgentId     map[int64]*liveTunnels
+	// draining is set when tunnels must not be used for new requests and agents have been unregistered.
+	draining bool
 }
 
 func (r *registryStripe) Refresh(ctx context.Context) error {
@@ -97,9 +123,12 @@ func (r *registryStripe) Refresh(ctx context.Context) error {
 	func() {
 		r.mu.Lock()
 		defer r.mu.Unlock()
+		if r.draining {
+			return // agents have been unregistered, don't register them again
+		}
 		refresh = make([]int64, 0, len(r.tunsByAgentId))
 		for agentId, info := range r.tunsByAgentId {
-			if len(info.tuns) == 0 {
+			if info.isEmpty() {
 				select {
 				case <-info.waitForIO:
 					// Unregistration IO has completed already.
@@ -127,20 +156,34 @@ func (r *registryStripe) FindTunnel(ctx context.Context, agentId int64, service,
 	defer span.End()
 
 	// Buffer 1 to not block on send when a tunnel is found before find request is registered.
-	retTun := make(chan *tunnelImpl, 1) // can receive nil from it if Stop() is called
+	retTun := make(chan Tunnel, 1) // can receive nil from it if Drain() or Stop() is called
 	ftr := &findTunnelRequest{
-		agentId: agentId,
-		service: service,
-		method:  method,
-		retTun:  retTun,
+		agentId:   agentId,
+		service:   service,
+		method:    method,
+		retTun:    retTun,
+		createdAt: time.Now(),
 	}
 	found := false
+	draining := false
 	func() {
 		r.mu.Lock()
 		defer r.mu.Unlock()
 
-		// 1. Check if we have a suitable tunnel
-		for tun := range r.tunsByAgentId[agentId].tuns {
+		if r.draining {
+			// Don't use tunnels for new requests and don't queue them either.
+			// The routing kas retries the request on another kas replica.
+			draining = true
+			return
+		}
+		// 1. Check if we have a suitable tunnel. Prefer multiplexed tunnels to keep the others available.
+		info := r.tunsByAgentId[agentId]
+		if mt := busiestMuxTunnelLocked(info.muxTuns, service, method); mt != nil {
+			retTun <- mt.openStreamLocked() // must not block because the reception is below
+			found = true
+			return
+		}
+		for tun := range info.tuns {
 			if !tun.agentDescriptor.SupportsServiceAndMethod(service, method) {
 				continue
 			}
@@ -160,19 +203,33 @@ func (r *registryStripe) FindTunnel(ctx context.Context, agentId int64, service,
 		findRequestsForAgentId[ftr] = struct{}{}
 	}()
 	span.SetAttributes(traceTunnelFoundAttr.Bool(found))
+	if draining {
+		return false, &findHandle{
+			tracer: r.tracer,
+			err:    errDraining,
+		}
+	}
 	return found, &findHandle{
 		tracer: r.tracer,
 		retTun: retTun,
+		ftr:    ftr,
 		done: func(ctx context.Context) {
 			r.mu.Lock()
 			defer r.mu.Unlock()
 			close(retTun)
-			tun := <-retTun // will get nil if there was nothing in the channel or if registry is shutting down.
-			if tun != nil {
-				// Got the tunnel, but it's too late so return it to the registry.
-				r.onTunnelDoneLocked(ctx, tun)
-			} else {
+			tun := <-retTun // will get nil if there was nothing in the channel or if the request has been aborted.
+			switch t := tun.(type) {
+			case nil:
 				r.deleteFindRequestLocked(ftr)
+			case *tunnelImpl:
+				// Got the tunnel, but it's too late so return it to the registry.
+				r.onTunnelDoneLocked(ctx, t)
+			case *muxStream:
+				// Got a stream, but it's too late so give it back.
+				t.tunnel.removeStream(t)
+				r.onMuxStreamDoneLocked(ctx, t)
+			default:
+				panic(fmt.Errorf("unexpected tunnel type: %T", tun))
 			}
 		},
 	}
@@ -193,18 +250,29 @@ func (r *registryStripe) HandleTunnel(ageCtx context.Context, agentInfo *api.Age
 	}
 	retErr := make(chan error, 1)
 	agentId := agentInfo.Id
+	compression := rpc2.NegotiateCompression(r.compression, descriptor.Descriptor_.SupportedCompressions)
+	if r.multiplexing && descriptor.Descriptor_.Multiplexing != nil {
+		return r.handleMuxTunnel(ageCtx, ctx, agentId, descriptor.Descriptor_, compression, server)
+	}
 	tun := &tunnelImpl{
 		tunnel:              server,
 		tunnelStreamVisitor: r.tunnelStreamVisitor,
 		tunnelRetErr:        retErr,
 		agentId:             agentId,
 		agentDescriptor:     descriptor.Descriptor_.AgentDescriptor,
+		compression:         compression,
 		state:               stateReady,
 		onForward:           r.onTunnelForward,
 		onDone:              r.onTunnelDone,
 	}
 	// Register
-	r.registerTunnel(ctx, tun) // nolint: contextcheck
+	err = r.registerTunnel(ctx, tun) // nolint: contextcheck
+	if err != nil {
+		return err
+	}
+	defer r.untrackTunnel(agentId, func(live *liveTunnels) {
+		delete(live.tuns, tun)
+	})
 	// Wait for return error or for cancellation
 	select {
 	case <-ageCtx.Done():
@@ -244,13 +312,26 @@ func (r *registryStripe) HandleTunnel(ageCtx context.Context, agentInfo *api.Age
 	}
 }
 
-func (r *registryStripe) registerTunnel(ctx context.Context, toReg *tunnelImpl) {
+func (r *registryStripe) registerTunnel(ctx context.Context, toReg *tunnelImpl) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.draining {
+		return errDraining
+	}
+	r.trackTunnelLocked(toReg.agentId, func(live *liveTunnels) {
+		live.tuns[toReg] = struct{}{}
+	})
 	r.registerTunnelLocked(ctx, toReg)
+	return nil
 }
 
 func (r *registryStripe) registerTunnelLocked(ctx context.Context, toReg *tunnelImpl) {
+	if r.draining {
+		// A found tunnel has not been used. Close it instead of putting it back.
+		toReg.state = stateDone
+		toReg.tunnelRetErr <- nil // nil so that HandleTunnel() returns cleanly and agent immediately reconnects
+		return
+	}
 	agentId := toReg.agentId
 	// 1. Before registering the tunnel see if there is a find tunnel request waiting for it
 	findRequestsForAgentId := r.findRequestsByAgentId[agentId]
@@ -266,11 +347,18 @@ func (r *registryStripe) registerTunnelLocked(ctx context.Context, toReg *tunnel
 	}
 
 	// 2. Register the tunnel
-	shouldRegister := false
 	toReg.state = stateReady
+	r.addLocked(ctx, agentId, func(info agentId2tunInfo) {
+		info.tuns[toReg] = struct{}{}
+	})
+}
+
+// addLocked adds a tunnel to the registry using add and registers the agent with the tracker if it's the first tunnel.
+func (r *registryStripe) addLocked(ctx context.Context, agentId int64, add func(agentId2tunInfo)) {
+	shouldRegister := false
 	info, ok := r.tunsByAgentId[agentId]
 	if ok {
-		if len(info.tuns) == 0 { // no tunnels
+		if info.isEmpty() { // no tunnels
 			if info.stopIO() { // Try to stop unregistration
 				// Succeeded, close the channel to signal any waiters that I/O "has been done".
 				close(info.waitForIO)
@@ -285,10 +373,11 @@ func (r *registryStripe) registerTunnelLocked(ctx context.Context, toReg *tunnel
 	} else {
 		shouldRegister = true
 		info = agentId2tunInfo{
-			tuns: make(map[*tunnelImpl]struct{}),
+			tuns:    make(map[*tunnelImpl]struct{}),
+			muxTuns: make(map[*muxTunnel]struct{}),
 		}
 	}
-	info.tuns[toReg] = struct{}{}
+	add(info)
 	if shouldRegister {
 		// First tunnel for this agentId. Register it asynchronously.
 		register, waitForIO := r.registerTunnelIO()
@@ -316,7 +405,12 @@ func (r *registryStripe) unregisterTunnelLocked(ctx context.Context, toUnreg *tu
 	agentId := toUnreg.agentId
 	info := r.tunsByAgentId[agentId]
 	delete(info.tuns, toUnreg)
-	if len(info.tuns) == 0 {
+	r.maybeUnregisterLocked(ctx, agentId, info)
+}
+
+// maybeUnregisterLocked schedules unregistration of the agent with the tracker if it has no tunnels left.
+func (r *registryStripe) maybeUnregisterLocked(ctx context.Context, agentId int64, info agentId2tunInfo) {
+	if info.isEmpty() && !r.draining { // all agents have been unregistered already if draining
 		// Last tunnel for this agentId had been used. However, don't unregister it immediately. Agentk will
 		// almost certainly establish more connections to compensate for the lack of available ones. If we
 		// unregister it now, we'll have to re-register it in a moment again, causing useless I/O and delays.
@@ -392,6 +486,157 @@ func (r *registryStripe) onTunnelDoneLocked(ctx context.Context, tun *tunnelImpl
 	}
 }
 
+func (r *registryStripe) handleMuxTunnel(ageCtx, ctx context.Context, agentId int64, descriptor *rpc2.Descriptor,
+	compression rpc2.Compression, server rpc2.ReverseTunnel_ConnectServer) error {
+	mt := &muxTunnel{
+		tunnel:          server,
+		agentId:         agentId,
+		agentDescriptor: descriptor.AgentDescriptor,
+		compression:     compression,
+		maxStreams:      int(descriptor.Multiplexing.MaxStreams),
+		windowSize:      descriptor.Multiplexing.InitialWindowSize,
+		retErr:          make(chan error, 1),
+		goAway:          make(chan struct{}, 1),
+		onStreamDone:    r.onMuxStreamDone,
+		streams:         make(map[uint64]*muxStream),
+	}
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- mt.read(r.tunnelStreamVisitor)
+	}()
+	pingCtx, pingCancel := context.WithCancel(ctx)
+	defer pingCancel()
+	go mt.ping(pingCtx, pingPeriod, pongTimeout)
+	// Register
+	err := r.registerMuxTunnel(ctx, mt) // nolint: contextcheck
+	if err != nil {
+		return err
+	}
+	defer r.untrackTunnel(agentId, func(live *liveTunnels) {
+		delete(live.muxTuns, mt)
+	})
+	defer mt.fail(status.Error(codes.Unavailable, "tunnel closed"))
+	// Wait for return error or for cancellation
+	select {
+	case <-ageCtx.Done():
+		// Context canceled. Let agentk open a replacement tunnel while the streams are finishing.
+		return r.retireMuxTunnel(ctx, mt, true, readErr) // nolint: contextcheck
+	case <-mt.goAway:
+		// agentk is replacing the tunnel, it doesn't need to be told.
+		return r.retireMuxTunnel(ctx, mt, false, readErr) // nolint: contextcheck
+	case err := <-readErr:
+		// agentk closed the tunnel or an error happened.
+		r.unregisterMuxTunnel(ctx, mt) // nolint: contextcheck
+		return err
+	case err := <-mt.retErr:
+		r.unregisterMuxTunnel(ctx, mt) // nolint: contextcheck
+		return err
+	}
+}
+
+// retireMuxTunnel stops using the tunnel for new streams and waits for the existing ones to finish.
+func (r *registryStripe) retireMuxTunnel(ctx context.Context, mt *muxTunnel, sendGoAway bool, readErr <-chan error) error {
+	r.mu.Lock()
+	r.unregisterMuxTunnelLocked(ctx, mt)
+	idle := mt.idle
+	r.mu.Unlock()
+	if idle == nil {
+		return nil
+	}
+	if sendGoAway {
+		_ = mt.sendGoAway() // ignore error, read() will get it too
+	}
+	select {
+	case <-idle:
+		return nil
+	case err := <-readErr:
+		return err
+	case err := <-mt.retErr:
+		return err
+	}
+}
+
+func (r *registryStripe) registerMuxTunnel(ctx context.Context, toReg *muxTunnel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.draining {
+		return errDraining
+	}
+	r.trackTunnelLocked(toReg.agentId, func(live *liveTunnels) {
+		live.muxTuns[toReg] = struct{}{}
+	})
+	// 1. Before registering the tunnel see if there are find tunnel requests waiting for it
+	r.serveFindRequestsLocked(toReg)
+	// 2. Register the tunnel, it stays registered while it's used
+	r.addLocked(ctx, toReg.agentId, func(info agentId2tunInfo) {
+		info.muxTuns[toReg] = struct{}{}
+	})
+	return nil
+}
+
+func (r *registryStripe) unregisterMuxTunnel(ctx context.Context, toUnreg *muxTunnel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unregisterMuxTunnelLocked(ctx, toUnreg)
+}
+
+func (r *registryStripe) unregisterMuxTunnelLocked(ctx context.Context, toUnreg *muxTunnel) {
+	if toUnreg.closed {
+		// Unregistered already or registry has been stopped.
+		return
+	}
+	toUnreg.closeLocked()
+	agentId := toUnreg.agentId
+	info := r.tunsByAgentId[agentId]
+	delete(info.muxTuns, toUnreg)
+	r.maybeUnregisterLocked(ctx, agentId, info)
+}
+
+// serveFindRequestsLocked opens streams on mt for the waiting find tunnel requests, as long as it has capacity.
+func (r *registryStripe) serveFindRequestsLocked(mt *muxTunnel) {
+	if r.draining {
+		return
+	}
+	for ftr := range r.findRequestsByAgentId[mt.agentId] {
+		if !mt.canOpenLocked(ftr.service, ftr.method) {
+			continue
+		}
+		ftr.retTun <- mt.openStreamLocked() // Satisfy the waiting request ASAP
+		r.deleteFindRequestLocked(ftr)      // Remove it from the queue
+	}
+}
+
+// busiestMuxTunnelLocked returns the tunnel with the most streams that can open a stream for the method or nil.
+// Packing streams onto as few tunnels as possible lets the tunnels that aren't needed stay unused.
+func busiestMuxTunnelLocked(muxTuns map[*muxTunnel]struct{}, service, method string) *muxTunnel {
+	var busiest *muxTunnel
+	for mt := range muxTuns {
+		if !mt.canOpenLocked(service, method) {
+			continue
+		}
+		if busiest == nil || mt.reserved > busiest.reserved {
+			busiest = mt
+		}
+	}
+	return busiest
+}
+
+func (r *registryStripe) onMuxStreamDone(ctx context.Context, s *muxStream) {
+	ctx, span := r.tracer.Start(ctx, "registryStripe.onMuxStreamDone", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onMuxStreamDoneLocked(ctx, s)
+}
+
+func (r *registryStripe) onMuxStreamDoneLocked(ctx context.Context, s *muxStream) {
+	mt := s.tunnel
+	mt.onStreamDoneLocked(s)
+	// The tunnel has capacity for one more stream now.
+	r.serveFindRequestsLocked(mt)
+}
+
 func (r *registryStripe) deleteFindRequestLocked(ftr *findTunnelRequest) {
 	findRequestsForAgentId := r.findRequestsByAgentId[ftr.agentId]
 	delete(findRequestsForAgentId, ftr)
@@ -400,54 +645,124 @@ func (r *registryStripe) deleteFindRequestLocked(ftr *findTunnelRequest) {
 	}
 }
 
-// Stop aborts any open tunnels.
-// It should not be necessary to abort tunnels when registry is used correctly i.e. this method is called after
-// all tunnels have terminated gracefully.
-func (r *registryStripe) Stop(ctx context.Context) (int /*stoppedTun*/, int /*abortedFtr*/) {
-	stoppedTun := 0
-	abortedFtr := 0
-
+// Drain makes the stripe stop using tunnels for new requests and reject new tunnels.
+// Waiting find tunnel requests fail, like new ones, so that they are retried on another kas replica.
+// Agents are unregistered from the tracker so that other kas replicas stop routing requests to this one.
+// Open tunnels are left to HandleTunnel() to close when ageCtx is done.
+func (r *registryStripe) Drain(ctx context.Context) int /*unregisteredAgents*/ {
+	var wg wait.Group
+	defer wg.Wait() // runs after the lock is released so that I/O doesn't block requests that need the lock
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.draining {
+		return 0
+	}
+	r.draining = true
+	r.abortFindRequestsLocked(errDraining)
 
-	// 1. Abort all waiting new stream requests
+	for agentId, info := range r.tunsByAgentId {
+		if info.isEmpty() && info.stopIO() { // Try to stop delayed unregistration to unregister ASAP instead
+			// Succeeded, close the channel to signal any waiters that I/O "has been done".
+			close(info.waitForIO)
+		}
+		waitForIO := info.waitForIO
+		unregister, unregistered := r.unregisterTunnelIO(ctx, agentId)
+		wg.Start(func() {
+			<-waitForIO // wait for the current (un)registration I/O to finish to not race with it
+			unregister()
+		})
+		info.waitForIO = unregistered
+		info.stopIO = unstoppableIO
+		r.tunsByAgentId[agentId] = info
+	}
+	return len(r.tunsByAgentId)
+}
+
+// abortFindRequestsLocked makes all waiting find tunnel requests fail with err.
+func (r *registryStripe) abortFindRequestsLocked(err error) int /*abortedFtr*/ {
+	abortedFtr := 0
 	for _, findRequestsForAgentId := range r.findRequestsByAgentId {
 		for ftr := range findRequestsForAgentId {
 			abortedFtr++
+			ftr.abortErr = err
 			ftr.retTun <- nil
 		}
 	}
-	r.findRequestsByAgentId = map[int64]map[*findTunnelRequest]struct{}{} // TODO use clear() in Go 1.21
+	clear(r.findRequestsByAgentId)
+	return abortedFtr
+}
 
-	// 2. Abort all tunnels
-	var wg wait.Group
-	defer wg.Wait()
-	var waitForIOs []<-chan struct{} // nolint: prealloc
-	for agentId, info := range r.tunsByAgentId {
-		if len(info.tuns) == 0 {
-			if info.stopIO() { // Try to stop delayed unregistration to unregister ASAP instead
-				// Succeeded, close the channel to signal any waiters that I/O "has been done".
-				close(info.waitForIO)
+// Stop aborts any open tunnels. Multiplexed tunnels with in-flight streams are asked to go away and are closed
+// once their streams are done or ctx is done.
+// It should not be necessary to abort tunnels when registry is used correctly i.e. this method is called after
+// all tunnels have terminated gracefully.
+func (r *registryStripe) Stop(ctx context.Context) (int /*stoppedTun*/, int /*abortedFtr*/) {
+	stoppedTun := 0
+	abortedFtr := 0
+	var draining []*muxTunnel
+	var idles []<-chan struct{}
+
+	func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		// 1. Abort all waiting new stream requests
+		abortedFtr = r.abortFindRequestsLocked(errShuttingDown)
+
+		// 2. Abort all tunnels
+		var wg wait.Group
+		defer wg.Wait()
+		var waitForIOs []<-chan struct{} // nolint: prealloc
+		for agentId, info := range r.tunsByAgentId {
+			if info.isEmpty() {
+				if info.stopIO() { // Try to stop delayed unregistration to unregister ASAP instead
+					// Succeeded, close the channel to signal any waiters that I/O "has been done".
+					close(info.waitForIO)
+				} else {
+					waitForIOs = append(waitForIOs, info.waitForIO) // wait for the current unregistration I/O to finish.
+					continue                                        // unregistered this one, so go to the next tunnel
+				}
 			} else {
-				waitForIOs = append(waitForIOs, info.waitForIO) // wait for the current unregistration I/O to finish.
-				continue                                        // unregistered this one, so go to the next tunnel
-			}
-		} else {
-			for tun := range info.tuns {
-				stoppedTun++
-				tun.state = stateDone
-				tun.tunnelRetErr <- nil // nil so that HandleTunnel() returns cleanly and agent immediately retries
+				for tun := range info.tuns {
+					stoppedTun++
+					tun.state = stateDone
+					tun.tunnelRetErr <- nil // nil so that HandleTunnel() returns cleanly and agent immediately retries
+				}
+				for mt := range info.muxTuns {
+					stoppedTun++
+					mt.closeLocked()
+					if mt.idle == nil {
+						mt.abort(nil) // nil so that HandleTunnel() returns cleanly and agent immediately retries
+					} else {
+						// Let in-flight streams finish.
+						draining = append(draining, mt)
+						idles = append(idles, mt.idle)
+					}
+				}
+				waitForIOs = append(waitForIOs, info.waitForIO)
 			}
-			waitForIOs = append(waitForIOs, info.waitForIO)
+			unregister, waitForIO := r.unregisterTunnelIO(ctx, agentId)
+			wg.Start(unregister) // do I/O concurrently
+			waitForIOs = append(waitForIOs, waitForIO)
 		}
-		unregister, waitForIO := r.unregisterTunnelIO(ctx, agentId)
-		wg.Start(unregister) // do I/O concurrently
-		waitForIOs = append(waitForIOs, waitForIO)
-	}
-	r.tunsByAgentId = make(map[int64]agentId2tunInfo) // TODO use clear() in Go 1.21
+		r.tunsByAgentId = make(map[int64]agentId2tunInfo) // TODO use clear() in Go 1.21
+		r.liveTunsByAgentId = make(map[int64]*liveTunnels)
+
+		for _, w := range waitForIOs {
+			<-w // wait for the current (un)registration I/O to finish
+		}
+	}()
 
-	for _, w := range waitForIOs {
-		<-w // wait for the current (un)registration I/O to finish
+	// 3. Wait for streams of multiplexed tunnels. Must not hold the mutex as streams need it to finish.
+	for _, mt := range draining {
+		_ = mt.sendGoAway() // ignore error, the tunnel is closed below anyway
+	}
+	for i, mt := range draining {
+		select {
+		case <-idles[i]:
+		case <-ctx.Done():
+		}
+		mt.abort(nil)
 	}
 
 	if stoppedTun > 0 || abortedFtr > 0 {
@@ -3,6 +3,7 @@ package tunnel
 import (
 	"context"
 	"io"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -67,7 +68,7 @@ func TestStopUnregistersAllConnections(t *testing.T) {
 		mockApi.EXPECT().
 			HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 	)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	var wg wait.Group
 	defer wg.Wait()
@@ -121,7 +122,7 @@ func TestTunnelDoneRegistersUnusedTunnel(t *testing.T) {
 			HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 	)
 	agentInfo := testhelpers.AgentInfoObj()
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	var wg wait.Group
 	defer wg.Wait()
@@ -176,7 +177,7 @@ func TestTunnelDoneDonePanics(t *testing.T) {
 	mockApi.EXPECT().
 		HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	agentInfo := testhelpers.AgentInfoObj()
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	var wg wait.Group
 	defer wg.Wait()
@@ -225,7 +226,7 @@ func TestHandleTunnelIsUnblockedByContext(t *testing.T) {
 		tunnelTracker.EXPECT().
 			UnregisterTunnel(gomock.Any(), gomock.Any()),
 	)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	err = r.HandleTunnel(ctxConn, testhelpers.AgentInfoObj(), connectServer)
 	assert.NoError(t, err)
@@ -273,7 +274,7 @@ func TestHandleTunnelIsUnblockedByContext_WithTwoTunnels(t *testing.T) {
 		tunnelTracker.EXPECT().
 			UnregisterTunnel(gomock.Any(), gomock.Any()),
 	)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	defer r.stopInternal(context.Background())
 	var wg wait.Group
@@ -330,7 +331,7 @@ func TestHandleTunnelReturnErrOnRecvErr(t *testing.T) {
 		Recv().
 		Return(nil, status.Error(codes.DataLoss, "expected err"))
 	tunnelTracker := NewMockTracker(ctrl)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	err = r.HandleTunnel(context.Background(), testhelpers.AgentInfoObj(), connectServer)
 	assert.EqualError(t, err, "rpc error: code = DataLoss desc = expected err")
@@ -352,7 +353,7 @@ func TestHandleTunnelReturnErrOnInvalidMsg(t *testing.T) {
 			},
 		}, nil)
 	tunnelTracker := NewMockTracker(ctrl)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	err = r.HandleTunnel(context.Background(), testhelpers.AgentInfoObj(), connectServer)
 	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = Invalid oneof value type: *rpc.ConnectRequest_Header")
@@ -408,7 +409,7 @@ func TestHandleTunnelIsNotMatchedToIncomingConnectionForMissingMethod(t *testing
 		mockApi.EXPECT().
 			HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 	)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	agentInfo := testhelpers.AgentInfoObj()
 	var wg wait.Group
@@ -479,7 +480,7 @@ func TestForwardStreamIsNotMatchedToHandleTunnelForMissingMethod(t *testing.T) {
 		mockApi.EXPECT().
 			HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 	)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	agentInfo := testhelpers.AgentInfoObj()
 	var wg wait.Group
@@ -507,7 +508,7 @@ func TestFindTunnelIsUnblockedByContext(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockApi := mock_modserver2.NewMockApi(ctrl)
 	tunnelTracker := NewMockTracker(ctrl)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	found, th := r.FindTunnel(context.Background(), testhelpers.AgentId, serviceName, methodName)
 	defer th.Done(context.Background())
@@ -521,7 +522,7 @@ func TestRefreshRegistrations(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockApi := mock_modserver2.NewMockApi(ctrl)
 	tunnelTracker := NewMockTracker(ctrl)
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	tunnelTracker.EXPECT().
 		Refresh(gomock.Any(), gomock.Any()).
@@ -656,11 +657,668 @@ func setupStreams(t *testing.T, expectRegisterTunnel bool) (*mock_rpc.MockServer
 			Return(io.EOF),
 	)
 
-	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker)
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
 	require.NoError(t, err)
 	return incomingStream, rpcApi, cb, connectServer, r
 }
 
+func TestMuxTunnelIsSharedAndDrainsOnContextDone(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockApi := mock_modserver2.NewMockApi(ctrl)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	tunnelTracker := NewMockTracker(ctrl)
+	connectServer.EXPECT().
+		Context().
+		Return(context.Background()).
+		MinTimes(1)
+	closeTunnel := make(chan struct{})
+	reg := make(chan struct{})
+	desc := descriptor()
+	desc.Multiplexing = &rpc.Multiplexing{
+		MaxStreams:        2,
+		InitialWindowSize: 1024,
+	}
+	connectServer.EXPECT().
+		Recv().
+		Return(&rpc.ConnectRequest{
+			Msg: &rpc.ConnectRequest_Descriptor_{
+				Descriptor_: desc,
+			},
+		}, nil)
+	connectServer.EXPECT().
+		RecvMsg(gomock.Any()).
+		DoAndReturn(func(msg any) error {
+			<-closeTunnel
+			return io.EOF
+		})
+	var goAwaySent atomic.Bool
+	connectServer.EXPECT().
+		Send(gomock.Any()). // pings and GoAway
+		DoAndReturn(func(resp *rpc.ConnectResponse) error {
+			if resp.GetMux().GetGoAway() != nil {
+				goAwaySent.Store(true)
+			}
+			return nil
+		}).
+		AnyTimes()
+	gomock.InOrder(
+		tunnelTracker.EXPECT().
+			RegisterTunnel(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, ttl time.Duration, agentId int64) error {
+				close(reg)
+				return nil
+			}),
+		tunnelTracker.EXPECT().
+			UnregisterTunnel(gomock.Any(), gomock.Any()),
+	)
+	agentInfo := testhelpers.AgentInfoObj()
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_zstd, true)
+	require.NoError(t, err)
+	ageCtx, ageCancel := context.WithCancel(context.Background())
+	defer ageCancel()
+	handleDone := make(chan struct{})
+	go func() {
+		defer close(handleDone)
+		assert.NoError(t, r.HandleTunnel(ageCtx, agentInfo, connectServer))
+	}()
+	<-reg
+	// Two streams share the tunnel.
+	var tuns []Tunnel
+	for i := 0; i < 2; i++ {
+		found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+		assert.True(t, found)
+		tun, err := th.Get(context.Background())
+		require.NoError(t, err)
+		th.Done(context.Background())
+		tuns = append(tuns, tun)
+	}
+	assert.Equal(t, tuns[0].(*muxStream).tunnel, tuns[1].(*muxStream).tunnel)
+	// The tunnel is at capacity.
+	found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+	assert.False(t, found)
+	// Capacity is freed when a stream is done.
+	tuns[0].Done(context.Background())
+	tun, err := th.Get(context.Background())
+	require.NoError(t, err)
+	th.Done(context.Background())
+	tuns[0] = tun
+	// Tunnel is not used for new streams after context is done but waits for the existing ones.
+	ageCancel()
+	time.Sleep(50 * time.Millisecond)
+	found, th = r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+	assert.False(t, found)
+	th.Done(context.Background())
+	assert.True(t, goAwaySent.Load())
+	select {
+	case <-handleDone:
+		t.Fatal("HandleTunnel() returned before streams are done")
+	default:
+	}
+	for _, tun := range tuns {
+		tun.Done(context.Background())
+	}
+	<-handleDone
+	close(closeTunnel)
+	tl, fl := r.stopInternal(context.Background())
+	assert.Zero(t, tl)
+	assert.Zero(t, fl)
+}
+
+func TestMuxTunnelIsRetiredByAgent(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockApi := mock_modserver2.NewMockApi(ctrl)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	tunnelTracker := NewMockTracker(ctrl)
+	connectServer.EXPECT().
+		Context().
+		Return(context.Background()).
+		MinTimes(1)
+	sendGoAway := make(chan struct{})
+	closeTunnel := make(chan struct{})
+	reg := make(chan struct{})
+	desc := descriptor()
+	desc.Multiplexing = &rpc.Multiplexing{
+		MaxStreams:        2,
+		InitialWindowSize: 1024,
+	}
+	connectServer.EXPECT().
+		Recv().
+		Return(&rpc.ConnectRequest{
+			Msg: &rpc.ConnectRequest_Descriptor_{
+				Descriptor_: desc,
+			},
+		}, nil)
+	gomock.InOrder(
+		connectServer.EXPECT().
+			RecvMsg(gomock.Any()).
+			DoAndReturn(func(msg any) error {
+				<-sendGoAway
+				return testhelpers.RecvMsg(&rpc.ConnectRequest{
+					Msg: &rpc.ConnectRequest_Mux{
+						Mux: &rpc.MuxRequest{
+							Msg: &rpc.MuxRequest_GoAway{
+								GoAway: &rpc.GoAway{},
+							},
+						},
+					},
+				})(msg)
+			}),
+		connectServer.EXPECT().
+			RecvMsg(gomock.Any()).
+			DoAndReturn(func(msg any) error {
+				<-closeTunnel
+				return io.EOF
+			}),
+	)
+	var goAwaySent atomic.Bool
+	connectServer.EXPECT().
+		Send(gomock.Any()). // pings
+		DoAndReturn(func(resp *rpc.ConnectResponse) error {
+			if resp.GetMux().GetGoAway() != nil {
+				goAwaySent.Store(true)
+			}
+			return nil
+		}).
+		AnyTimes()
+	gomock.InOrder(
+		tunnelTracker.EXPECT().
+			RegisterTunnel(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, ttl time.Duration, agentId int64) error {
+				close(reg)
+				return nil
+			}),
+		tunnelTracker.EXPECT().
+			UnregisterTunnel(gomock.Any(), gomock.Any()),
+	)
+	agentInfo := testhelpers.AgentInfoObj()
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_zstd, true)
+	require.NoError(t, err)
+	handleDone := make(chan struct{})
+	go func() {
+		defer close(handleDone)
+		assert.NoError(t, r.HandleTunnel(context.Background(), agentInfo, connectServer))
+	}()
+	<-reg
+	found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+	assert.True(t, found)
+	tun, err := th.Get(context.Background())
+	require.NoError(t, err)
+	th.Done(context.Background())
+	// Tunnel is not used for new streams after agentk retires it but waits for the existing ones.
+	close(sendGoAway)
+	require.Eventually(t, func() bool {
+		found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+		th.Done(context.Background())
+		return !found
+	}, time.Minute, 10*time.Millisecond)
+	select {
+	case <-handleDone:
+		t.Fatal("HandleTunnel() returned before streams are done")
+	default:
+	}
+	tun.Done(context.Background())
+	<-handleDone
+	assert.False(t, goAwaySent.Load()) // agentk knows already
+	close(closeTunnel)
+	tl, fl := r.stopInternal(context.Background())
+	assert.Zero(t, tl)
+	assert.Zero(t, fl)
+}
+
+func TestBusiestMuxTunnelIsPicked(t *testing.T) {
+	newMuxTunnel := func(reserved int) *muxTunnel {
+		return &muxTunnel{
+			agentDescriptor: descriptor().AgentDescriptor,
+			maxStreams:      3,
+			reserved:        reserved,
+		}
+	}
+	empty := newMuxTunnel(0)
+	busy := newMuxTunnel(2)
+	full := newMuxTunnel(3)
+	closed := newMuxTunnel(2)
+	closed.closed = true
+	muxTuns := map[*muxTunnel]struct{}{
+		empty:  {},
+		busy:   {},
+		full:   {},
+		closed: {},
+	}
+	assert.Same(t, busy, busiestMuxTunnelLocked(muxTuns, serviceName, methodName))
+	assert.Nil(t, busiestMuxTunnelLocked(muxTuns, serviceName, "unknown"))
+	busy.reserved = 3
+	assert.Same(t, empty, busiestMuxTunnelLocked(muxTuns, serviceName, methodName))
+}
+
+func TestStopDrainsMuxTunnel(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockApi := mock_modserver2.NewMockApi(ctrl)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	tunnelTracker := NewMockTracker(ctrl)
+	connectServer.EXPECT().
+		Context().
+		Return(context.Background()).
+		MinTimes(1)
+	closeTunnel := make(chan struct{})
+	defer close(closeTunnel)
+	reg := make(chan struct{})
+	desc := descriptor()
+	desc.Multiplexing = &rpc.Multiplexing{
+		MaxStreams:        2,
+		InitialWindowSize: 1024,
+	}
+	connectServer.EXPECT().
+		Recv().
+		Return(&rpc.ConnectRequest{
+			Msg: &rpc.ConnectRequest_Descriptor_{
+				Descriptor_: desc,
+			},
+		}, nil)
+	connectServer.EXPECT().
+		RecvMsg(gomock.Any()).
+		DoAndReturn(func(msg any) error {
+			<-closeTunnel
+			return io.EOF
+		})
+	goAwaySent := make(chan struct{})
+	connectServer.EXPECT().
+		Send(gomock.Any()). // pings and GoAway
+		DoAndReturn(func(resp *rpc.ConnectResponse) error {
+			if resp.GetMux().GetGoAway() != nil {
+				close(goAwaySent)
+			}
+			return nil
+		}).
+		AnyTimes()
+	gomock.InOrder(
+		tunnelTracker.EXPECT().
+			RegisterTunnel(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, ttl time.Duration, agentId int64) error {
+				close(reg)
+				return nil
+			}),
+		tunnelTracker.EXPECT().
+			UnregisterTunnel(gomock.Any(), gomock.Any()),
+	)
+	mockApi.EXPECT().
+		HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	agentInfo := testhelpers.AgentInfoObj()
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, true)
+	require.NoError(t, err)
+	handleDone := make(chan struct{})
+	go func() {
+		defer close(handleDone)
+		assert.NoError(t, r.HandleTunnel(context.Background(), agentInfo, connectServer))
+	}()
+	<-reg
+	found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+	require.True(t, found)
+	tun, err := th.Get(context.Background())
+	require.NoError(t, err)
+	th.Done(context.Background())
+
+	stopDone := make(chan struct{})
+	go func() {
+		defer close(stopDone)
+		tl, fl := r.stopInternal(context.Background())
+		assert.Equal(t, 1, tl)
+		assert.Zero(t, fl)
+	}()
+	<-goAwaySent
+	select {
+	case <-handleDone:
+		t.Fatal("HandleTunnel() returned before the stream is done")
+	case <-stopDone:
+		t.Fatal("Stop() returned before the stream is done")
+	case <-time.After(50 * time.Millisecond):
+	}
+	tun.Done(context.Background())
+	<-stopDone
+	<-handleDone
+}
+
+func TestDrainUnregistersAgentsAndStopsUsingTunnels(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockApi := mock_modserver2.NewMockApi(ctrl)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	tunnelTracker := NewMockTracker(ctrl)
+	connectServer.EXPECT().
+		Context().
+		Return(context.Background()).
+		MinTimes(1)
+	connectServer.EXPECT().
+		Recv().
+		Return(&rpc.ConnectRequest{
+			Msg: &rpc.ConnectRequest_Descriptor_{
+				Descriptor_: descriptor(),
+			},
+		}, nil).
+		Times(2)
+	reg := make(chan struct{})
+	gomock.InOrder(
+		tunnelTracker.EXPECT().
+			RegisterTunnel(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, ttl time.Duration, agentId int64) error {
+				close(reg)
+				return nil
+			}),
+		tunnelTracker.EXPECT().
+			UnregisterTunnel(gomock.Any(), testhelpers.AgentId),
+	)
+	agentInfo := testhelpers.AgentInfoObj()
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
+	require.NoError(t, err)
+	ageCtx, ageCancel := context.WithCancel(context.Background())
+	defer ageCancel()
+	var wg wait.Group
+	defer wg.Wait()
+	wg.Start(func() {
+		assert.NoError(t, r.HandleTunnel(ageCtx, agentInfo, connectServer))
+	})
+	<-reg
+	r.Drain(context.Background())
+	// The idle tunnel is not used for new requests.
+	found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+	assert.False(t, found)
+	// The request fails right away instead of waiting for a tunnel, so that it's retried on another kas.
+	_, err = th.Get(context.Background())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	th.Done(context.Background())
+	stripe := r.stripes.GetPointer(agentInfo.Id)
+	stripe.mu.Lock()
+	assert.Empty(t, stripe.findRequestsByAgentId[agentInfo.Id]) // not queued
+	stripe.mu.Unlock()
+	// New tunnels are rejected.
+	err = r.HandleTunnel(context.Background(), agentInfo, connectServer)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	// Agents are not registered again.
+	tunnelTracker.EXPECT().
+		Refresh(gomock.Any(), gomock.Any()).
+		Times(len(r.stripes.Stripes))
+	r.refreshRegistrations(context.Background())
+	// The idle tunnel is closed when its context is done.
+	ageCancel()
+	wg.Wait()
+	tl, fl := r.stopInternal(context.Background())
+	assert.Zero(t, tl)
+	assert.Zero(t, fl)
+}
+
+func TestDrainFailsWaitingRequestsAndUnregistersWithoutLock(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockApi := mock_modserver2.NewMockApi(ctrl)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	tunnelTracker := NewMockTracker(ctrl)
+	connectServer.EXPECT().
+		Context().
+		Return(context.Background()).
+		MinTimes(1)
+	connectServer.EXPECT().
+		Recv().
+		Return(&rpc.ConnectRequest{
+			Msg: &rpc.ConnectRequest_Descriptor_{
+				Descriptor_: descriptor(),
+			},
+		}, nil)
+	reg := make(chan struct{})
+	unregStarted := make(chan struct{})
+	unblockUnreg := make(chan struct{})
+	gomock.InOrder(
+		tunnelTracker.EXPECT().
+			RegisterTunnel(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, ttl time.Duration, agentId int64) error {
+				close(reg)
+				return nil
+			}),
+		tunnelTracker.EXPECT().
+			UnregisterTunnel(gomock.Any(), testhelpers.AgentId).
+			DoAndReturn(func(ctx context.Context, agentId int64) error {
+				close(unregStarted)
+				<-unblockUnreg
+				return nil
+			}),
+	)
+	agentInfo := testhelpers.AgentInfoObj()
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
+	require.NoError(t, err)
+	ageCtx, ageCancel := context.WithCancel(context.Background())
+	defer ageCancel()
+	var wg wait.Group
+	defer wg.Wait()
+	wg.Start(func() {
+		assert.NoError(t, r.HandleTunnel(ageCtx, agentInfo, connectServer))
+	})
+	<-reg
+	// The tunnel doesn't support the method so the request waits for another tunnel.
+	found, waiting := r.FindTunnel(context.Background(), agentInfo.Id, "other.Service", methodName)
+	assert.False(t, found)
+
+	drained := make(chan struct{})
+	wg.Start(func() {
+		defer close(drained)
+		r.Drain(context.Background())
+	})
+	<-unregStarted
+	// The waiting request fails like a new one.
+	_, err = waiting.Get(context.Background())
+	assert.Equal(t, errDraining, err)
+	waiting.Done(context.Background())
+	// The stripe is not locked while agents are unregistered.
+	found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+	assert.False(t, found)
+	_, err = th.Get(context.Background())
+	assert.Equal(t, errDraining, err)
+	th.Done(context.Background())
+	select {
+	case <-drained:
+		t.Fatal("Drain() returned before unregistration finished")
+	default:
+	}
+	close(unblockUnreg)
+	<-drained
+
+	ageCancel()
+	wg.Wait()
+	tl, fl := r.stopInternal(context.Background())
+	assert.Zero(t, tl)
+	assert.Zero(t, fl)
+}
+
+func TestAgentTunnelsReportsTunnelsAndFindRequests(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockApi := mock_modserver2.NewMockApi(ctrl)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	tunnelTracker := NewMockTracker(ctrl)
+	connectServer.EXPECT().
+		Context().
+		Return(context.Background()).
+		MinTimes(1)
+	reg := make(chan struct{})
+	gomock.InOrder(
+		connectServer.EXPECT().
+			Recv().
+			Return(&rpc.ConnectRequest{
+				Msg: &rpc.ConnectRequest_Descriptor_{
+					Descriptor_: descriptor(),
+				},
+			}, nil),
+		tunnelTracker.EXPECT().
+			RegisterTunnel(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, ttl time.Duration, agentId int64) error {
+				close(reg)
+				return nil
+			}),
+		tunnelTracker.EXPECT().
+			UnregisterTunnel(gomock.Any(), gomock.Any()),
+		mockApi.EXPECT().
+			HandleProcessingError(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+	)
+	agentInfo := testhelpers.AgentInfoObj()
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, false)
+	require.NoError(t, err)
+	assert.Empty(t, r.AllAgentTunnels())
+	// A find request is waiting for a tunnel.
+	found, th := r.FindTunnel(context.Background(), agentInfo.Id, serviceName, methodName)
+	assert.False(t, found)
+	time.Sleep(10 * time.Millisecond)
+	info := r.AgentTunnels(agentInfo.Id)
+	assert.EqualValues(t, 1, info.PendingFindRequests)
+	assert.GreaterOrEqual(t, info.OldestPendingFindRequestAge, 10*time.Millisecond)
+	all := r.AllAgentTunnels()
+	require.Len(t, all, 1)
+	assert.Equal(t, agentInfo.Id, all[0].AgentId)
+	assert.EqualValues(t, 1, all[0].PendingFindRequests)
+	var wg wait.Group
+	defer wg.Wait()
+	wg.Start(func() {
+		assert.NoError(t, r.HandleTunnel(context.Background(), agentInfo, connectServer))
+	})
+	// The tunnel is used for the waiting request.
+	tun, err := th.Get(context.Background())
+	require.NoError(t, err)
+	th.Done(context.Background())
+	assert.Equal(t, AgentTunnelsInfo{
+		AgentId:       agentInfo.Id,
+		ActiveTunnels: 1,
+	}, r.AgentTunnels(agentInfo.Id))
+	// The unused tunnel goes back to the registry.
+	tun.Done(context.Background())
+	<-reg
+	assert.Equal(t, []AgentTunnelsInfo{
+		{
+			AgentId:     agentInfo.Id,
+			IdleTunnels: 1,
+		},
+	}, r.AllAgentTunnels())
+	tl, fl := r.stopInternal(context.Background())
+	assert.EqualValues(t, 1, tl)
+	assert.Zero(t, fl)
+	wg.Wait()
+	assert.Empty(t, r.AllAgentTunnels())
+}
+
+func TestMuxTunnelMeasuresRoundTripTime(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockApi := mock_modserver2.NewMockApi(ctrl)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	tunnelTracker := NewMockTracker(ctrl)
+	connectServer.EXPECT().
+		Context().
+		Return(context.Background()).
+		MinTimes(1)
+	closeTunnel := make(chan struct{})
+	pinged := make(chan uint64, 1)
+	desc := descriptor()
+	desc.Multiplexing = &rpc.Multiplexing{
+		MaxStreams:        2,
+		InitialWindowSize: 1024,
+	}
+	connectServer.EXPECT().
+		Recv().
+		Return(&rpc.ConnectRequest{
+			Msg: &rpc.ConnectRequest_Descriptor_{
+				Descriptor_: desc,
+			},
+		}, nil)
+	connectServer.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(resp *rpc.ConnectResponse) error {
+			pinged <- resp.Msg.(*rpc.ConnectResponse_Mux).Mux.Msg.(*rpc.MuxResponse_Ping).Ping.Id
+			return nil
+		})
+	gomock.InOrder(
+		connectServer.EXPECT().
+			RecvMsg(gomock.Any()).
+			DoAndReturn(func(msg any) error {
+				id := <-pinged
+				time.Sleep(10 * time.Millisecond)
+				return testhelpers.RecvMsg(&rpc.ConnectRequest{
+					Msg: &rpc.ConnectRequest_Mux{
+						Mux: &rpc.MuxRequest{
+							Msg: &rpc.MuxRequest_Pong{
+								Pong: &rpc.Pong{
+									Id: id,
+								},
+							},
+						},
+					},
+				})(msg)
+			}),
+		connectServer.EXPECT().
+			RecvMsg(gomock.Any()).
+			DoAndReturn(func(msg any) error {
+				<-closeTunnel
+				return io.EOF
+			}),
+	)
+	gomock.InOrder(
+		tunnelTracker.EXPECT().
+			RegisterTunnel(gomock.Any(), gomock.Any(), gomock.Any()),
+		tunnelTracker.EXPECT().
+			UnregisterTunnel(gomock.Any(), gomock.Any()),
+	)
+	agentInfo := testhelpers.AgentInfoObj()
+	r, err := NewRegistry(zaptest.NewLogger(t), mockApi, nt(), time.Minute, time.Minute, tunnelTracker, rpc.Compression_none, true)
+	require.NoError(t, err)
+	ageCtx, ageCancel := context.WithCancel(context.Background())
+	defer ageCancel()
+	handleDone := make(chan struct{})
+	go func() {
+		defer close(handleDone)
+		assert.NoError(t, r.HandleTunnel(ageCtx, agentInfo, connectServer))
+	}()
+	var info AgentTunnelsInfo
+	require.Eventually(t, func() bool {
+		info = r.AgentTunnels(agentInfo.Id)
+		return len(info.MuxTunnels) == 1 && !info.MuxTunnels[0].LastPongAt.IsZero()
+	}, time.Second, 10*time.Millisecond)
+	mt := info.MuxTunnels[0]
+	assert.Zero(t, mt.ActiveStreams)
+	assert.EqualValues(t, 2, mt.MaxStreams)
+	assert.False(t, mt.Draining)
+	assert.GreaterOrEqual(t, mt.LastRoundTripTime, 10*time.Millisecond)
+	assert.Equal(t, mt.LastRoundTripTime, mt.RoundTripTime)
+	ageCancel()
+	<-handleDone
+	close(closeTunnel)
+	tl, fl := r.stopInternal(context.Background())
+	assert.Zero(t, tl)
+	assert.Zero(t, fl)
+	assert.Empty(t, r.AllAgentTunnels())
+}
+
+func TestMuxTunnelResendsLostPing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	connectServer := mock_reverse_tunnel_rpc.NewMockReverseTunnel_ConnectServer[rpc.ConnectRequest, rpc.ConnectResponse](ctrl)
+	var pings []uint64
+	connectServer.EXPECT().
+		Send(gomock.Any()).
+		DoAndReturn(func(resp *rpc.ConnectResponse) error {
+			pings = append(pings, resp.GetMux().GetPing().GetId())
+			return nil
+		}).
+		Times(2)
+	mt := &muxTunnel{
+		tunnel: connectServer,
+	}
+	require.NoError(t, mt.sendPing(time.Hour))
+	require.NoError(t, mt.sendPing(time.Hour)) // waits for the Pong
+	require.NoError(t, mt.sendPing(0))         // the Pong is lost, pings again
+	assert.Equal(t, []uint64{1, 2}, pings)
+	mt.onPong(1) // late Pong for the lost Ping
+	assert.True(t, mt.lastPongAt.IsZero())
+	mt.onPong(2)
+	assert.False(t, mt.lastPongAt.IsZero())
+	assert.True(t, mt.pingSentAt.IsZero())
+}
+
 func descriptor() *rpc.Descriptor {
 	return &rpc.Descriptor{
 		AgentDescriptor: &info.AgentDescriptor{
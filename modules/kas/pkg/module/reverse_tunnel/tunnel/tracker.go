@@ -5,7 +5,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/redis/rueidis"
 	"k8s.io/utils/clock"
 
 	redistool2 "github.com/pluralsh/kubernetes-agent/pkg/tool/redistool"
@@ -35,35 +34,32 @@ type Tracker interface {
 	Querier
 }
 
-type RedisTracker struct {
+// StorageTracker keeps track of tunnels in the storage backend that kas replicas share.
+type StorageTracker struct {
 	ownPrivateApiUrl string
 	clock            clock.PassiveClock
 	tunnelsByAgentId redistool2.ExpiringHashApi[int64, string] // agentId -> kas URL -> nil
 }
 
-func NewRedisTracker(client rueidis.Client, agentKeyPrefix string, ownPrivateApiUrl string) *RedisTracker {
-	return &RedisTracker{
+func NewStorageTracker(backend redistool2.Backend, agentKeyPrefix string, ownPrivateApiUrl string) *StorageTracker {
+	return &StorageTracker{
 		ownPrivateApiUrl: ownPrivateApiUrl,
 		clock:            clock.RealClock{},
-		tunnelsByAgentId: &redistool2.RedisExpiringHashApi[int64, string]{
-			Client:         client,
-			Key1ToRedisKey: tunnelsByAgentIdHashKey(agentKeyPrefix),
-			Key2ToRedisKey: strToStr,
-		},
+		tunnelsByAgentId: redistool2.NewExpiringHashApi[int64, string](backend, tunnelsByAgentIdHashKey(agentKeyPrefix), strToStr),
 	}
 }
 
-func (t *RedisTracker) RegisterTunnel(ctx context.Context, ttl time.Duration, agentId int64) error {
+func (t *StorageTracker) RegisterTunnel(ctx context.Context, ttl time.Duration, agentId int64) error {
 	b := t.tunnelsByAgentId.SetBuilder()
 	b.Set(agentId, ttl, t.kv(t.clock.Now().Add(ttl)))
 	return b.Do(ctx)
 }
 
-func (t *RedisTracker) UnregisterTunnel(ctx context.Context, agentId int64) error {
+func (t *StorageTracker) UnregisterTunnel(ctx context.Context, agentId int64) error {
 	return t.tunnelsByAgentId.Unset(ctx, agentId, t.ownPrivateApiUrl)
 }
 
-func (t *RedisTracker) KasUrlsByAgentId(ctx context.Context, agentId int64) ([]string, error) {
+func (t *StorageTracker) KasUrlsByAgentId(ctx context.Context, agentId int64) ([]string, error) {
 	var urls []string
 	var errs []error
 	_, err := t.tunnelsByAgentId.Scan(ctx, agentId, func(rawHashKey string, value []byte, err error) (bool, error) {
@@ -80,7 +76,7 @@ func (t *RedisTracker) KasUrlsByAgentId(ctx context.Context, agentId int64) ([]s
 	return urls, errors.Join(errs...)
 }
 
-func (t *RedisTracker) Refresh(ctx context.Context, ttl time.Duration, agentIds ...int64) error {
+func (t *StorageTracker) Refresh(ctx context.Context, ttl time.Duration, agentIds ...int64) error {
 	b := t.tunnelsByAgentId.SetBuilder()
 	// allocate once. Slice is passed as-is to variadic funcs vs individual args allocate a new one on each call
 	kvs := []redistool2.BuilderKV[string]{t.kv(t.clock.Now().Add(ttl))}
@@ -90,7 +86,7 @@ func (t *RedisTracker) Refresh(ctx context.Context, ttl time.Duration, agentIds
 	return b.Do(ctx)
 }
 
-func (t *RedisTracker) kv(expiresAt time.Time) redistool2.BuilderKV[string] {
+func (t *StorageTracker) kv(expiresAt time.Time) redistool2.BuilderKV[string] {
 	return redistool2.BuilderKV[string]{
 		HashKey: t.ownPrivateApiUrl,
 		Value: &redistool2.ExpiringValue{
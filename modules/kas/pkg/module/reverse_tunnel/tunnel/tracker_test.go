@@ -17,9 +17,9 @@ import (
 )
 
 var (
-	_ Registerer = &RedisTracker{}
-	_ Tracker    = &RedisTracker{}
-	_ Querier    = &RedisTracker{}
+	_ Registerer = &StorageTracker{}
+	_ Tracker    = &StorageTracker{}
+	_ Querier    = &StorageTracker{}
 )
 
 const (
@@ -32,7 +32,7 @@ func TestRegisterConnection(t *testing.T) {
 	hash := mock_redis2.NewMockExpiringHashApi[int64, string](ctrl)
 	b := mock_redis2.NewMockSetBuilder[int64, string](ctrl)
 	tm := time.Now()
-	r := &RedisTracker{
+	r := &StorageTracker{
 		ownPrivateApiUrl: selfUrl,
 		clock:            clocktesting.NewFakePassiveClock(tm),
 		tunnelsByAgentId: hash,
@@ -58,7 +58,7 @@ func TestUnregisterConnection(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	hash := mock_redis2.NewMockExpiringHashApi[int64, string](ctrl)
 	b := mock_redis2.NewMockSetBuilder[int64, string](ctrl)
-	r := &RedisTracker{
+	r := &StorageTracker{
 		ownPrivateApiUrl: selfUrl,
 		clock:            clocktesting.NewFakePassiveClock(time.Now()),
 		tunnelsByAgentId: hash,
@@ -84,7 +84,7 @@ func TestUnregisterConnection_TwoConnections(t *testing.T) {
 	hash := mock_redis2.NewMockExpiringHashApi[int64, string](ctrl)
 	b1 := mock_redis2.NewMockSetBuilder[int64, string](ctrl)
 	b2 := mock_redis2.NewMockSetBuilder[int64, string](ctrl)
-	r := &RedisTracker{
+	r := &StorageTracker{
 		ownPrivateApiUrl: selfUrl,
 		clock:            clocktesting.NewFakePassiveClock(time.Now()),
 		tunnelsByAgentId: hash,
@@ -152,7 +152,7 @@ func TestRefresh_NoAgents(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	hash := mock_redis2.NewMockExpiringHashApi[int64, string](ctrl)
 	b := mock_redis2.NewMockSetBuilder[int64, string](ctrl)
-	r := &RedisTracker{
+	r := &StorageTracker{
 		ownPrivateApiUrl: selfUrl,
 		clock:            clocktesting.NewFakePassiveClock(time.Now()),
 		tunnelsByAgentId: hash,
@@ -172,7 +172,7 @@ func TestRefresh_OneAgent(t *testing.T) {
 	hash := mock_redis2.NewMockExpiringHashApi[int64, string](ctrl)
 	b := mock_redis2.NewMockSetBuilder[int64, string](ctrl)
 	tm := time.Now()
-	r := &RedisTracker{
+	r := &StorageTracker{
 		ownPrivateApiUrl: selfUrl,
 		clock:            clocktesting.NewFakePassiveClock(tm),
 		tunnelsByAgentId: hash,
@@ -199,7 +199,7 @@ func TestRefresh_TwoAgents(t *testing.T) {
 	hash := mock_redis2.NewMockExpiringHashApi[int64, string](ctrl)
 	b := mock_redis2.NewMockSetBuilder[int64, string](ctrl)
 	tm := time.Now()
-	r := &RedisTracker{
+	r := &StorageTracker{
 		ownPrivateApiUrl: selfUrl,
 		clock:            clocktesting.NewFakePassiveClock(tm),
 		tunnelsByAgentId: hash,
@@ -228,10 +228,10 @@ func TestRefresh_TwoAgents(t *testing.T) {
 	assert.NoError(t, r.Refresh(context.Background(), ttl, testhelpers.AgentId, testhelpers.AgentId+1))
 }
 
-func setupTracker(t *testing.T) (*RedisTracker, *mock_redis2.MockExpiringHashApi[int64, string]) {
+func setupTracker(t *testing.T) (*StorageTracker, *mock_redis2.MockExpiringHashApi[int64, string]) {
 	ctrl := gomock.NewController(t)
 	hash := mock_redis2.NewMockExpiringHashApi[int64, string](ctrl)
-	return &RedisTracker{
+	return &StorageTracker{
 		ownPrivateApiUrl: selfUrl,
 		clock:            clocktesting.NewFakePassiveClock(time.Now()),
 		tunnelsByAgentId: hash,
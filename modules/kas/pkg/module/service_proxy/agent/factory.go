@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpc"
+)
+
+type Factory struct {
+}
+
+func (f *Factory) IsProducingLeaderModules() bool {
+	return false
+}
+
+func (f *Factory) New(config *modagent.Config) (modagent.Module, error) {
+	userAgent := fmt.Sprintf("%s/%s/%s", config.AgentName, config.AgentMeta.Version, config.AgentMeta.CommitId)
+	kubeClientset, err := config.K8sUtilFactory.KubernetesClientSet()
+	if err != nil {
+		return nil, fmt.Errorf("could not create kubernetes clientset: %w", err)
+	}
+	s := newServer(userAgent, kubeClientset.AuthorizationV1())
+	rpc.RegisterServiceProxyServer(config.Server, s)
+	return &module{
+		server: s,
+	}, nil
+}
+
+func (f *Factory) Name() string {
+	return service_proxy.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	// This module exposes an API endpoint on the internal server, but it does not make requests to it.
+	return modshared.ModuleStartBeforeServers
+}
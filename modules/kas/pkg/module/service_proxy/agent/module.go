@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy"
+)
+
+type module struct {
+	server *server
+}
+
+func (m *module) Run(ctx context.Context, cfg <-chan *agentcfg.AgentConfiguration) error {
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			return nil
+		case config, ok := <-cfg:
+			if !ok {
+				return nil
+			}
+			m.server.setServices(config.ServiceProxy.GetServices())
+		}
+	}
+}
+
+func (m *module) DefaultAndValidateConfiguration(config *agentcfg.AgentConfiguration) error {
+	return nil
+}
+
+func (m *module) Name() string {
+	return service_proxy.ModuleName
+}
@@ -0,0 +1,270 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	kubernetesapirpc "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	httpz2 "github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/prototool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/tlstool"
+)
+
+const (
+	schemeHttps = "https"
+
+	// servicesResource and proxySubresource identify the services/proxy subresource in access reviews.
+	servicesResource = "services"
+	proxySubresource = "proxy"
+
+	dialTimeout = 30 * time.Second
+)
+
+type serviceKey struct {
+	namespace string
+	name      string
+}
+
+type allowedService struct {
+	ports                 sets.Set[uint32] // nil if all ports are allowed
+	insecureSkipTlsVerify bool
+}
+
+type server struct {
+	rpc.UnimplementedServiceProxyServer
+	userAgent string
+	via       string
+	authz     authorizationv1client.AuthorizationV1Interface
+	transport http.RoundTripper
+	// insecureTransport doesn't verify server certificates.
+	insecureTransport http.RoundTripper
+	services          atomic.Pointer[map[serviceKey]allowedService]
+}
+
+func newServer(userAgent string, authz authorizationv1client.AuthorizationV1Interface) *server {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil // services are in the cluster, don't send requests to them via a proxy from the environment
+	t.TLSClientConfig = tlstool.DefaultClientTLSConfig()
+	it := t.Clone()
+	it.TLSClientConfig.InsecureSkipVerify = true // nolint: gosec
+	s := &server{
+		userAgent:         userAgent,
+		via:               "gRPC/1.0 " + userAgent,
+		authz:             authz,
+		transport:         t,
+		insecureTransport: it,
+	}
+	s.setServices(nil)
+	return s
+}
+
+// setServices replaces the allowlist of services.
+func (s *server) setServices(cfgs []*agentcfg.ServiceProxyServiceCF) {
+	services := make(map[serviceKey]allowedService, len(cfgs))
+	for _, cfg := range cfgs {
+		var ports sets.Set[uint32]
+		if len(cfg.Ports) > 0 {
+			ports = sets.New(cfg.Ports...)
+		}
+		services[serviceKey{namespace: cfg.Namespace, name: cfg.Name}] = allowedService{
+			ports:                 ports,
+			insecureSkipTlsVerify: cfg.InsecureSkipTlsVerify,
+		}
+	}
+	s.services.Store(&services)
+}
+
+// allowedService returns the allowlist entry for the service and port or false if access is not allowed.
+func (s *server) allowedService(extra *rpc.HeaderExtra) (allowedService, bool) {
+	svc, ok := (*s.services.Load())[serviceKey{namespace: extra.Namespace, name: extra.Name}]
+	if !ok {
+		return allowedService{}, false
+	}
+	if svc.ports != nil && !svc.ports.Has(extra.Port) {
+		return allowedService{}, false
+	}
+	return svc, true
+}
+
+func (s *server) MakeRequest(server rpc.ServiceProxy_MakeRequestServer) error {
+	rpcApi := modagent.RpcApiFromContext(server.Context())
+	log := rpcApi.Log()
+	grpc2http := grpctool.InboundGrpcToOutboundHttp{
+		Log: log,
+		HandleProcessingError: func(msg string, err error) {
+			rpcApi.HandleProcessingError(log, modshared.NoAgentId, msg, err)
+		},
+		HandleIoError: func(msg string, err error) error {
+			return rpcApi.HandleIoError(log, msg, err)
+		},
+		HttpDo: s.httpDo,
+	}
+	return grpc2http.Pipe(server)
+}
+
+func (s *server) httpDo(ctx context.Context, h *grpctool.HttpRequest_Header, body io.Reader) (grpctool.DoResponse, error) {
+	// 1. Check the target service is allowed
+	if h.Extra == nil {
+		return grpctool.DoResponse{}, errors.New("missing target service")
+	}
+	var extra rpc.HeaderExtra
+	err := h.Extra.UnmarshalTo(&extra)
+	if err != nil {
+		return grpctool.DoResponse{}, err
+	}
+	err = extra.ValidateAll()
+	if err != nil {
+		return grpctool.DoResponse{}, err
+	}
+	svc, ok := s.allowedService(&extra)
+	if !ok {
+		return grpctool.DoResponse{
+			Resp: s.forbiddenResponse(fmt.Sprintf("service %s/%s port %d is not in the allowlist", extra.Namespace, extra.Name, extra.Port)),
+		}, nil
+	}
+	// 2. Check the caller is allowed to proxy requests to the service
+	allowed, reason, err := kubernetesapirpc.ReviewAccess(ctx, s.authz, extra.ImpConfig, &authorizationv1.ResourceAttributes{
+		Namespace:   extra.Namespace,
+		Verb:        proxyVerb(h.Request.Method),
+		Resource:    servicesResource,
+		Subresource: proxySubresource,
+		Name:        extra.Name,
+	})
+	if err != nil {
+		return grpctool.DoResponse{}, fmt.Errorf("access review: %w", err)
+	}
+	if !allowed {
+		msg := fmt.Sprintf("not allowed to proxy requests to service %s/%s", extra.Namespace, extra.Name)
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return grpctool.DoResponse{
+			Resp: s.forbiddenResponse(msg),
+		}, nil
+	}
+	// 3. Construct request
+	req, err := s.newRequest(ctx, &extra, h.Request, body)
+	if err != nil {
+		return grpctool.DoResponse{}, err
+	}
+	// 4. Construct round tripper
+	var (
+		rt        http.RoundTripper
+		upgradeRT *httpz2.UpgradeRoundTripper
+	)
+	isUpgrade := h.Request.IsUpgrade()
+	switch {
+	case isUpgrade:
+		tlsConfig := tlstool.DefaultClientTLSConfig()
+		tlsConfig.NextProtos = []string{httpz2.TLSNextProtoH1}   // HTTP Upgrade doesn't work over HTTP/2, so enforce HTTP/1.1
+		tlsConfig.InsecureSkipVerify = svc.insecureSkipTlsVerify // nolint: gosec
+		dialer := &net.Dialer{
+			Timeout: dialTimeout,
+		}
+		upgradeRT = &httpz2.UpgradeRoundTripper{
+			Dialer: dialer,
+			TlsDialer: &tls.Dialer{
+				NetDialer: dialer,
+				Config:    tlsConfig,
+			},
+		}
+		rt = upgradeRT
+	case svc.insecureSkipTlsVerify:
+		rt = s.insecureTransport
+	default:
+		rt = s.transport
+	}
+	// 5. Make a request
+	resp, err := rt.RoundTrip(req) // nolint: bodyclose
+	if err != nil {
+		ctxErr := ctx.Err()
+		if ctxErr != nil {
+			err = ctxErr // assume request errored out because of context
+		}
+		return grpctool.DoResponse{}, err
+	}
+	resp.Header[httpz2.ViaHeader] = append(resp.Header[httpz2.ViaHeader], fmt.Sprintf("%d.%d %s", resp.ProtoMajor, resp.ProtoMinor, s.userAgent))
+	if isUpgrade {
+		return grpctool.DoResponse{
+			Resp:        resp,
+			UpgradeConn: upgradeRT.Conn,
+			ConnReader:  upgradeRT.ConnReader,
+		}, nil
+	}
+	return grpctool.DoResponse{
+		Resp: resp,
+	}, nil
+}
+
+func (s *server) newRequest(ctx context.Context, extra *rpc.HeaderExtra, requestInfo *prototool.HttpRequest, body io.Reader) (*http.Request, error) {
+	u := url.URL{
+		Scheme:   extra.Scheme,
+		Host:     net.JoinHostPort(serviceHost(extra), strconv.FormatUint(uint64(extra.Port), 10)),
+		Path:     requestInfo.UrlPath,
+		RawQuery: requestInfo.UrlQuery().Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, requestInfo.Method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = requestInfo.HttpHeader()
+	req.Header[httpz2.ViaHeader] = append(req.Header[httpz2.ViaHeader], s.via)
+	return req, nil
+}
+
+// proxyVerb returns the verb the Kubernetes API server uses to authorize a proxy request with the HTTP method.
+func proxyVerb(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return "get"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return strings.ToLower(method)
+	}
+}
+
+// serviceHost returns the host name of the service. It's resolved using the search domains of the agentk Pod.
+func serviceHost(extra *rpc.HeaderExtra) string {
+	return extra.Name + "." + extra.Namespace + ".svc"
+}
+
+func (s *server) forbiddenResponse(msg string) *http.Response {
+	return &http.Response{
+		Status:     strconv.Itoa(http.StatusForbidden) + " " + http.StatusText(http.StatusForbidden),
+		StatusCode: http.StatusForbidden,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			httpz2.ContentTypeHeader: []string{"text/plain; charset=utf-8"},
+			httpz2.ViaHeader:         []string{"1.1 " + s.userAgent},
+		},
+		Body: io.NopCloser(strings.NewReader("Forbidden: " + msg)),
+	}
+}
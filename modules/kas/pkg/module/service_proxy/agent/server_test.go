@@ -0,0 +1,215 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	fakeauthorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	kubernetesapirpc "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/prototool"
+)
+
+var (
+	_ modagent.Module  = (*module)(nil)
+	_ modagent.Factory = (*Factory)(nil)
+)
+
+func TestAllowedService(t *testing.T) {
+	s := newTestServer(t, true)
+	s.setServices([]*agentcfg.ServiceProxyServiceCF{
+		{
+			Namespace: "monitoring",
+			Name:      "grafana",
+			Ports:     []uint32{80},
+		},
+		{
+			Namespace:             "argocd",
+			Name:                  "argocd-server",
+			InsecureSkipTlsVerify: true,
+		},
+	})
+	tests := []struct {
+		namespace string
+		name      string
+		port      uint32
+		allowed   bool
+		insecure  bool
+	}{
+		{namespace: "monitoring", name: "grafana", port: 80, allowed: true},
+		{namespace: "monitoring", name: "grafana", port: 3000},
+		{namespace: "argocd", name: "argocd-server", port: 443, allowed: true, insecure: true},
+		{namespace: "default", name: "grafana", port: 80},
+	}
+	for _, tc := range tests {
+		t.Run(tc.namespace+"/"+tc.name, func(t *testing.T) {
+			svc, ok := s.allowedService(&rpc.HeaderExtra{
+				Namespace: tc.namespace,
+				Name:      tc.name,
+				Port:      tc.port,
+			})
+			assert.Equal(t, tc.allowed, ok)
+			assert.Equal(t, tc.insecure, svc.insecureSkipTlsVerify)
+		})
+	}
+
+	s.setServices(nil)
+	_, ok := s.allowedService(&rpc.HeaderExtra{Namespace: "monitoring", Name: "grafana", Port: 80})
+	assert.False(t, ok)
+}
+
+func TestHttpDo_Forbidden(t *testing.T) {
+	s := newTestServer(t, true)
+	extra, err := anypb.New(&rpc.HeaderExtra{
+		Namespace: "monitoring",
+		Name:      "grafana",
+		Port:      80,
+		Scheme:    "http",
+	})
+	require.NoError(t, err)
+
+	resp, err := s.httpDo(context.Background(), &grpctool.HttpRequest_Header{
+		Request: &prototool.HttpRequest{
+			Method:  http.MethodGet,
+			UrlPath: "/",
+		},
+		Extra: extra,
+	}, http.NoBody)
+	require.NoError(t, err)
+	defer resp.Resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.Resp.StatusCode)
+	body, err := io.ReadAll(resp.Resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Forbidden: service monitoring/grafana port 80 is not in the allowlist", string(body))
+}
+
+func TestHttpDo_AccessReviewDenied(t *testing.T) {
+	s := newTestServer(t, false)
+	s.setServices([]*agentcfg.ServiceProxyServiceCF{
+		{
+			Namespace: "monitoring",
+			Name:      "grafana",
+		},
+	})
+	var review *authorizationv1.SubjectAccessReview
+	s.authz.(*fakeauthorizationv1.FakeAuthorizationV1).PrependReactor("create", "subjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review = action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			review.Status.Reason = "no RBAC policy matched"
+			return true, review, nil
+		})
+	extra, err := anypb.New(&rpc.HeaderExtra{
+		Namespace: "monitoring",
+		Name:      "grafana",
+		Port:      80,
+		Scheme:    "http",
+		ImpConfig: &kubernetesapirpc.ImpersonationConfig{
+			Username: "oidc:user1",
+			Groups:   []string{"oidc:g1"},
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := s.httpDo(context.Background(), &grpctool.HttpRequest_Header{
+		Request: &prototool.HttpRequest{
+			Method:  http.MethodPost,
+			UrlPath: "/",
+		},
+		Extra: extra,
+	}, http.NoBody)
+	require.NoError(t, err)
+	defer resp.Resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.Resp.StatusCode)
+	body, err := io.ReadAll(resp.Resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Forbidden: not allowed to proxy requests to service monitoring/grafana: no RBAC policy matched", string(body))
+	require.NotNil(t, review)
+	assert.Equal(t, "oidc:user1", review.Spec.User)
+	assert.Equal(t, []string{"oidc:g1", "system:authenticated"}, review.Spec.Groups)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Namespace:   "monitoring",
+		Verb:        "create",
+		Resource:    "services",
+		Subresource: "proxy",
+		Name:        "grafana",
+	}, review.Spec.ResourceAttributes)
+}
+
+func TestHttpDo_InvalidExtra(t *testing.T) {
+	s := newTestServer(t, true)
+	extra, err := anypb.New(&rpc.HeaderExtra{
+		Namespace: "monitoring",
+		Name:      "grafana",
+		Port:      80,
+		Scheme:    "ftp",
+	})
+	require.NoError(t, err)
+
+	_, err = s.httpDo(context.Background(), &grpctool.HttpRequest_Header{
+		Request: &prototool.HttpRequest{
+			Method:  http.MethodGet,
+			UrlPath: "/",
+		},
+		Extra: extra,
+	}, http.NoBody)
+	assert.Error(t, err)
+}
+
+func TestNewRequest(t *testing.T) {
+	s := newTestServer(t, true)
+	req, err := s.newRequest(context.Background(), &rpc.HeaderExtra{
+		Namespace: "monitoring",
+		Name:      "grafana",
+		Port:      3000,
+		Scheme:    "https",
+	}, &prototool.HttpRequest{
+		Method:  http.MethodGet,
+		UrlPath: "/api/health",
+		Query: map[string]*prototool.Values{
+			"a": {Value: []string{"b"}},
+		},
+	}, http.NoBody)
+	require.NoError(t, err)
+	assert.Equal(t, "https://grafana.monitoring.svc:3000/api/health?a=b", req.URL.String())
+	assert.Equal(t, []string{"gRPC/1.0 agentk"}, req.Header["Via"])
+}
+
+func TestNewServer_NoProxy(t *testing.T) {
+	s := newTestServer(t, true)
+	assert.Nil(t, s.transport.(*http.Transport).Proxy)
+	assert.Nil(t, s.insecureTransport.(*http.Transport).Proxy)
+}
+
+// newTestServer returns a server whose access reviews return the given result.
+func newTestServer(t *testing.T, allowed bool) *server {
+	client := fake.NewClientset()
+	allow := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch obj := action.(k8stesting.CreateAction).GetObject().(type) {
+		case *authorizationv1.SubjectAccessReview:
+			obj.Status.Allowed = allowed
+			return true, obj, nil
+		case *authorizationv1.SelfSubjectAccessReview:
+			obj.Status.Allowed = allowed
+			return true, obj, nil
+		default:
+			t.Errorf("unexpected object: %T", obj)
+			return true, nil, nil
+		}
+	}
+	client.PrependReactor("create", "subjectaccessreviews", allow)
+	client.PrependReactor("create", "selfsubjectaccessreviews", allow)
+	return newServer("agentk", client.AuthorizationV1())
+}
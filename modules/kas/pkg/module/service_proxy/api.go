@@ -0,0 +1,5 @@
+package service_proxy
+
+const (
+	ModuleName = "service_proxy"
+)
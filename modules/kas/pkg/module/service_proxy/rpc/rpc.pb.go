@@ -0,0 +1,176 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: pkg/module/service_proxy/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	rpc "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	grpctool "github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HeaderExtra is passed in grpctool.HttpRequest.extra. It identifies the service to make the request to.
+type HeaderExtra struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Port      uint32                 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	// http or https.
+	Scheme string `protobuf:"bytes,4,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Identity of the caller. agentk checks that it's allowed to use the services/proxy subresource of the service.
+	// Not set if the caller uses the identity of agentk.
+	ImpConfig     *rpc.ImpersonationConfig `protobuf:"bytes,5,opt,name=imp_config,json=impConfig,proto3" json:"imp_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeaderExtra) Reset() {
+	*x = HeaderExtra{}
+	mi := &file_pkg_module_service_proxy_rpc_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeaderExtra) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderExtra) ProtoMessage() {}
+
+func (x *HeaderExtra) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_service_proxy_rpc_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderExtra.ProtoReflect.Descriptor instead.
+func (*HeaderExtra) Descriptor() ([]byte, []int) {
+	return file_pkg_module_service_proxy_rpc_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HeaderExtra) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *HeaderExtra) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HeaderExtra) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *HeaderExtra) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *HeaderExtra) GetImpConfig() *rpc.ImpersonationConfig {
+	if x != nil {
+		return x.ImpConfig
+	}
+	return nil
+}
+
+var File_pkg_module_service_proxy_rpc_rpc_proto protoreflect.FileDescriptor
+
+const file_pkg_module_service_proxy_rpc_rpc_proto_rawDesc = "" +
+	"\n" +
+	"&pkg/module/service_proxy/rpc/rpc.proto\x12\x1eplural.agent.service_proxy.rpc\x1a'pkg/module/kubernetes_api/rpc/rpc.proto\x1a pkg/tool/grpctool/grpctool.proto\x1a\x17validate/validate.proto\"\xf3\x01\n" +
+	"\vHeaderExtra\x12%\n" +
+	"\tnamespace\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\tnamespace\x12\x1b\n" +
+	"\x04name\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04name\x12\x1f\n" +
+	"\x04port\x18\x03 \x01(\rB\v\xfaB\b*\x06\x18\xff\xff\x03 \x00R\x04port\x12*\n" +
+	"\x06scheme\x18\x04 \x01(\tB\x12\xfaB\x0fr\rR\x04httpR\x05httpsR\x06scheme\x12S\n" +
+	"\n" +
+	"imp_config\x18\x05 \x01(\v24.plural.agent.kubernetes_api.rpc.ImpersonationConfigR\timpConfig2l\n" +
+	"\fServiceProxy\x12\\\n" +
+	"\vMakeRequest\x12\".plural.agent.grpctool.HttpRequest\x1a#.plural.agent.grpctool.HttpResponse\"\x00(\x010\x01BCZAgithub.com/pluralsh/kubernetes-agent/pkg/module/service_proxy/rpcb\x06proto3"
+
+var (
+	file_pkg_module_service_proxy_rpc_rpc_proto_rawDescOnce sync.Once
+	file_pkg_module_service_proxy_rpc_rpc_proto_rawDescData []byte
+)
+
+func file_pkg_module_service_proxy_rpc_rpc_proto_rawDescGZIP() []byte {
+	file_pkg_module_service_proxy_rpc_rpc_proto_rawDescOnce.Do(func() {
+		file_pkg_module_service_proxy_rpc_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_module_service_proxy_rpc_rpc_proto_rawDesc), len(file_pkg_module_service_proxy_rpc_rpc_proto_rawDesc)))
+	})
+	return file_pkg_module_service_proxy_rpc_rpc_proto_rawDescData
+}
+
+var file_pkg_module_service_proxy_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pkg_module_service_proxy_rpc_rpc_proto_goTypes = []any{
+	(*HeaderExtra)(nil),             // 0: plural.agent.service_proxy.rpc.HeaderExtra
+	(*rpc.ImpersonationConfig)(nil), // 1: plural.agent.kubernetes_api.rpc.ImpersonationConfig
+	(*grpctool.HttpRequest)(nil),    // 2: plural.agent.grpctool.HttpRequest
+	(*grpctool.HttpResponse)(nil),   // 3: plural.agent.grpctool.HttpResponse
+}
+var file_pkg_module_service_proxy_rpc_rpc_proto_depIdxs = []int32{
+	1, // 0: plural.agent.service_proxy.rpc.HeaderExtra.imp_config:type_name -> plural.agent.kubernetes_api.rpc.ImpersonationConfig
+	2, // 1: plural.agent.service_proxy.rpc.ServiceProxy.MakeRequest:input_type -> plural.agent.grpctool.HttpRequest
+	3, // 2: plural.agent.service_proxy.rpc.ServiceProxy.MakeRequest:output_type -> plural.agent.grpctool.HttpResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_module_service_proxy_rpc_rpc_proto_init() }
+func file_pkg_module_service_proxy_rpc_rpc_proto_init() {
+	if File_pkg_module_service_proxy_rpc_rpc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_service_proxy_rpc_rpc_proto_rawDesc), len(file_pkg_module_service_proxy_rpc_rpc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_module_service_proxy_rpc_rpc_proto_goTypes,
+		DependencyIndexes: file_pkg_module_service_proxy_rpc_rpc_proto_depIdxs,
+		MessageInfos:      file_pkg_module_service_proxy_rpc_rpc_proto_msgTypes,
+	}.Build()
+	File_pkg_module_service_proxy_rpc_rpc_proto = out.File
+	file_pkg_module_service_proxy_rpc_rpc_proto_goTypes = nil
+	file_pkg_module_service_proxy_rpc_rpc_proto_depIdxs = nil
+}
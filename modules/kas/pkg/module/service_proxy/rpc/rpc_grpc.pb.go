@@ -0,0 +1,120 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.31.1
+// source: pkg/module/service_proxy/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	context "context"
+	grpctool "github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ServiceProxy_MakeRequest_FullMethodName = "/plural.agent.service_proxy.rpc.ServiceProxy/MakeRequest"
+)
+
+// ServiceProxyClient is the client API for ServiceProxy service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ServiceProxyClient interface {
+	// MakeRequest allows to make a HTTP request to an in-cluster service.
+	MakeRequest(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[grpctool.HttpRequest, grpctool.HttpResponse], error)
+}
+
+type serviceProxyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewServiceProxyClient(cc grpc.ClientConnInterface) ServiceProxyClient {
+	return &serviceProxyClient{cc}
+}
+
+func (c *serviceProxyClient) MakeRequest(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[grpctool.HttpRequest, grpctool.HttpResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ServiceProxy_ServiceDesc.Streams[0], ServiceProxy_MakeRequest_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[grpctool.HttpRequest, grpctool.HttpResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServiceProxy_MakeRequestClient = grpc.BidiStreamingClient[grpctool.HttpRequest, grpctool.HttpResponse]
+
+// ServiceProxyServer is the server API for ServiceProxy service.
+// All implementations must embed UnimplementedServiceProxyServer
+// for forward compatibility.
+type ServiceProxyServer interface {
+	// MakeRequest allows to make a HTTP request to an in-cluster service.
+	MakeRequest(grpc.BidiStreamingServer[grpctool.HttpRequest, grpctool.HttpResponse]) error
+	mustEmbedUnimplementedServiceProxyServer()
+}
+
+// UnimplementedServiceProxyServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedServiceProxyServer struct{}
+
+func (UnimplementedServiceProxyServer) MakeRequest(grpc.BidiStreamingServer[grpctool.HttpRequest, grpctool.HttpResponse]) error {
+	return status.Error(codes.Unimplemented, "method MakeRequest not implemented")
+}
+func (UnimplementedServiceProxyServer) mustEmbedUnimplementedServiceProxyServer() {}
+func (UnimplementedServiceProxyServer) testEmbeddedByValue()                      {}
+
+// UnsafeServiceProxyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ServiceProxyServer will
+// result in compilation errors.
+type UnsafeServiceProxyServer interface {
+	mustEmbedUnimplementedServiceProxyServer()
+}
+
+func RegisterServiceProxyServer(s grpc.ServiceRegistrar, srv ServiceProxyServer) {
+	// If the following call panics, it indicates UnimplementedServiceProxyServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ServiceProxy_ServiceDesc, srv)
+}
+
+func _ServiceProxy_MakeRequest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceProxyServer).MakeRequest(&grpc.GenericServerStream[grpctool.HttpRequest, grpctool.HttpResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServiceProxy_MakeRequestServer = grpc.BidiStreamingServer[grpctool.HttpRequest, grpctool.HttpResponse]
+
+// ServiceProxy_ServiceDesc is the grpc.ServiceDesc for ServiceProxy service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ServiceProxy_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.service_proxy.rpc.ServiceProxy",
+	HandlerType: (*ServiceProxyServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MakeRequest",
+			Handler:       _ServiceProxy_MakeRequest_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/module/service_proxy/rpc/rpc.proto",
+}
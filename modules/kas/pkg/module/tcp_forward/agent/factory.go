@@ -0,0 +1,38 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpc"
+)
+
+type Factory struct {
+}
+
+func (f *Factory) IsProducingLeaderModules() bool {
+	return false
+}
+
+func (f *Factory) New(config *modagent.Config) (modagent.Module, error) {
+	kubeClientset, err := config.K8sUtilFactory.KubernetesClientSet()
+	if err != nil {
+		return nil, fmt.Errorf("could not create kubernetes clientset: %w", err)
+	}
+	s := newServer(kubeClientset.AuthorizationV1())
+	rpc.RegisterTcpForwardServer(config.Server, s)
+	return &module{
+		server: s,
+	}, nil
+}
+
+func (f *Factory) Name() string {
+	return tcp_forward.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	// This module exposes an API endpoint on the internal server, but it does not make requests to it.
+	return modshared.ModuleStartBeforeServers
+}
@@ -0,0 +1,230 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/sets"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	kubernetesapirpc "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/memz"
+)
+
+const (
+	dialTimeout = 30 * time.Second
+)
+
+type server struct {
+	rpc.UnimplementedTcpForwardServer
+	dialer *net.Dialer
+	authz  authorizationv1client.AuthorizationV1Interface
+	// allowed maps host:port of allowed targets to an empty struct.
+	allowed atomic.Pointer[sets.Set[string]]
+}
+
+func newServer(authz authorizationv1client.AuthorizationV1Interface) *server {
+	s := &server{
+		dialer: &net.Dialer{
+			Timeout: dialTimeout,
+		},
+		authz: authz,
+	}
+	s.setTargets(nil)
+	return s
+}
+
+// setTargets replaces the allowlist of targets.
+func (s *server) setTargets(cfgs []*agentcfg.TcpForwardTargetCF) {
+	allowed := sets.New[string]()
+	for _, cfg := range cfgs {
+		var host string
+		switch t := cfg.Target.(type) {
+		case *agentcfg.TcpForwardTargetCF_Service:
+			host = serviceHost(t.Service.Namespace, t.Service.Name)
+		case *agentcfg.TcpForwardTargetCF_Host:
+			host = t.Host
+		default:
+			continue // validated so cannot happen
+		}
+		for _, port := range cfg.Ports {
+			allowed.Insert(hostPort(host, port))
+		}
+	}
+	s.allowed.Store(&allowed)
+}
+
+func (s *server) Forward(server rpc.TcpForward_ForwardServer) error {
+	ctx := server.Context()
+	rpcApi := modagent.RpcApiFromContext(ctx)
+	log := rpcApi.Log()
+
+	connC := make(chan net.Conn)
+	// Channel of size 1 to ensure that if we return early, the other goroutine has space for the value.
+	res := make(chan error, 1)
+	go func() {
+		res <- s.pipeStreamToConn(ctx, server, connC)
+	}()
+	var conn net.Conn
+	select {
+	case <-ctx.Done():
+		return toStatusError(rpcApi, log, ctx.Err())
+	case c, ok := <-connC:
+		if !ok {
+			// Something went wrong before the connection was established.
+			return toStatusError(rpcApi, log, <-res)
+		}
+		conn = c
+	}
+	cc := httpz.NewContextConn(conn)
+	go cc.CloseOnDone(ctx)
+	defer cc.Close() // nolint: errcheck
+	err := server.Send(&rpc.ForwardResponse{
+		Message: &rpc.ForwardResponse_Header_{
+			Header: &rpc.ForwardResponse_Header{},
+		},
+	})
+	if err != nil {
+		return toStatusError(rpcApi, log, err)
+	}
+	// Unlike an HTTP proxy, the connection is done when the target closes it. Returning from the handler
+	// aborts reads from the stream in the other goroutine.
+	return toStatusError(rpcApi, log, pipeConnToStream(cc, server))
+}
+
+// pipeStreamToConn dials the target from the first message and then writes received data to the connection.
+// The connection is sent to connC once it's established. connC is closed when this method returns.
+func (s *server) pipeStreamToConn(ctx context.Context, server rpc.TcpForward_ForwardServer, connC chan<- net.Conn) error {
+	// unblock the caller if we exited before sending the connection due to an error.
+	defer close(connC)
+	var conn net.Conn
+	err := rpc.ForwardRequestStreamVisitor.Get().Visit(server,
+		grpctool.WithCallback(rpc.ForwardRequestHeaderFieldNumber, func(header *rpc.ForwardRequest_Header) error {
+			var err error
+			conn, err = s.dial(ctx, header)
+			if err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case connC <- conn:
+				return nil
+			}
+		}),
+		grpctool.WithCallback(rpc.ForwardRequestDataFieldNumber, func(data *rpc.ForwardRequest_Data) error {
+			_, err := conn.Write(data.Data)
+			return err
+		}),
+		grpctool.WithEOFCallback(func() error {
+			// The client is done sending data.
+			if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+				return cw.CloseWrite()
+			}
+			return nil
+		}),
+	)
+	if err != nil && conn != nil {
+		_ = conn.Close() // unblock reads from the connection
+	}
+	return err
+}
+
+// dial connects to the target if it's allowed. The caller needs the access rpc.TargetAccess returns.
+func (s *server) dial(ctx context.Context, header *rpc.ForwardRequest_Header) (net.Conn, error) {
+	var host string
+	switch t := header.Target.(type) {
+	case *rpc.ForwardRequest_Header_Service:
+		host = serviceHost(t.Service.Namespace, t.Service.Name)
+	case *rpc.ForwardRequest_Header_Host:
+		host = t.Host
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown target type: %T", header.Target)
+	}
+	addr := hostPort(host, header.Port)
+	if !s.allowed.Load().Has(addr) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not in the allowlist", addr)
+	}
+	allowed, reason, err := kubernetesapirpc.ReviewAccess(ctx, s.authz, header.ImpConfig, rpc.TargetAccess(header))
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "access review for %s: %v", addr, err)
+	}
+	if !allowed {
+		msg := fmt.Sprintf("not allowed to connect to %s", addr)
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return nil, status.Error(codes.PermissionDenied, msg)
+	}
+	conn, err := s.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect to %s: %v", addr, err)
+	}
+	return conn, nil
+}
+
+func pipeConnToStream(conn net.Conn, server rpc.TcpForward_ForwardServer) error {
+	buf := memz.Get32k()
+	defer memz.Put32k(buf)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			sendErr := server.Send(&rpc.ForwardResponse{
+				Message: &rpc.ForwardResponse_Data_{
+					Data: &rpc.ForwardResponse_Data{
+						Data: buf[:n],
+					},
+				},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// The target closed the connection.
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func toStatusError(rpcApi modagent.RpcApi, log *zap.Logger, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case grpctool.IsStatusError(err):
+		// A gRPC status already
+		return err
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return rpcApi.HandleIoError(log, "TCP forward", err)
+	}
+}
+
+// serviceHost returns the host name of the service. It's resolved using the search domains of the agentk Pod.
+func serviceHost(namespace, name string) string {
+	return name + "." + namespace + ".svc"
+}
+
+func hostPort(host string, port uint32) string {
+	return net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+}
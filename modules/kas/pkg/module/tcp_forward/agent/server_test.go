@@ -0,0 +1,225 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	kubernetesapirpc "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modagent"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpc"
+)
+
+var (
+	_ modagent.Module  = (*module)(nil)
+	_ modagent.Factory = (*Factory)(nil)
+)
+
+func TestDial_Allowlist(t *testing.T) {
+	s := newTestServer(t)
+	s.setTargets([]*agentcfg.TcpForwardTargetCF{
+		{
+			Target: &agentcfg.TcpForwardTargetCF_Service{
+				Service: &agentcfg.TcpForwardServiceCF{
+					Namespace: "cache",
+					Name:      "redis",
+				},
+			},
+			Ports: []uint32{6379},
+		},
+		{
+			Target: &agentcfg.TcpForwardTargetCF_Host{
+				Host: "db.example.internal",
+			},
+			Ports: []uint32{5432, 5433},
+		},
+	})
+	assert.Equal(t, []string{
+		"db.example.internal:5432",
+		"db.example.internal:5433",
+		"redis.cache.svc:6379",
+	}, sortedAllowed(s))
+
+	tests := []struct {
+		name   string
+		header *rpc.ForwardRequest_Header
+	}{
+		{
+			name: "service port",
+			header: &rpc.ForwardRequest_Header{
+				Target: &rpc.ForwardRequest_Header_Service{
+					Service: &rpc.Service{Namespace: "cache", Name: "redis"},
+				},
+				Port: 6380,
+			},
+		},
+		{
+			name: "service namespace",
+			header: &rpc.ForwardRequest_Header{
+				Target: &rpc.ForwardRequest_Header_Service{
+					Service: &rpc.Service{Namespace: "default", Name: "redis"},
+				},
+				Port: 6379,
+			},
+		},
+		{
+			name: "host",
+			header: &rpc.ForwardRequest_Header{
+				Target: &rpc.ForwardRequest_Header_Host{
+					Host: "db2.example.internal",
+				},
+				Port: 5432,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.dial(context.Background(), tc.header)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+	}
+}
+
+func TestDial_Connects(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+
+	s := newTestServer(t)
+	s.setTargets([]*agentcfg.TcpForwardTargetCF{
+		{
+			Target: &agentcfg.TcpForwardTargetCF_Host{
+				Host: "127.0.0.1",
+			},
+			Ports: []uint32{port},
+		},
+	})
+	conn, err := s.dial(context.Background(), &rpc.ForwardRequest_Header{
+		Target: &rpc.ForwardRequest_Header_Host{
+			Host: "127.0.0.1",
+		},
+		Port: port,
+	})
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+
+	require.NoError(t, l.Close())
+	_, err = s.dial(context.Background(), &rpc.ForwardRequest_Header{
+		Target: &rpc.ForwardRequest_Header_Host{
+			Host: "127.0.0.1",
+		},
+		Port: port,
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestDial_AccessReview(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+
+	tests := []struct {
+		name     string
+		header   *rpc.ForwardRequest_Header
+		expected *authorizationv1.ResourceAttributes
+	}{
+		{
+			name: "service",
+			header: &rpc.ForwardRequest_Header{
+				Target: &rpc.ForwardRequest_Header_Service{
+					Service: &rpc.Service{Namespace: "cache", Name: "redis"},
+				},
+				Port: 6379,
+			},
+			expected: &authorizationv1.ResourceAttributes{
+				Namespace:   "cache",
+				Verb:        "create",
+				Resource:    "services",
+				Subresource: "proxy",
+				Name:        "redis",
+			},
+		},
+		{
+			name: "host",
+			header: &rpc.ForwardRequest_Header{
+				Target: &rpc.ForwardRequest_Header_Host{
+					Host: "127.0.0.1",
+				},
+				Port: port,
+			},
+			expected: &authorizationv1.ResourceAttributes{
+				Verb:        "create",
+				Resource:    "pods",
+				Subresource: "portforward",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var review *authorizationv1.SubjectAccessReview
+			client := fake.NewClientset()
+			client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review = action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				return true, review, nil
+			})
+			s := newServer(client.AuthorizationV1())
+			s.setTargets([]*agentcfg.TcpForwardTargetCF{
+				{
+					Target: &agentcfg.TcpForwardTargetCF_Service{
+						Service: &agentcfg.TcpForwardServiceCF{
+							Namespace: "cache",
+							Name:      "redis",
+						},
+					},
+					Ports: []uint32{6379},
+				},
+				{
+					Target: &agentcfg.TcpForwardTargetCF_Host{
+						Host: "127.0.0.1",
+					},
+					Ports: []uint32{port},
+				},
+			})
+			tc.header.ImpConfig = &kubernetesapirpc.ImpersonationConfig{
+				Username: "oidc:user1",
+			}
+			_, err := s.dial(context.Background(), tc.header)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			require.NotNil(t, review)
+			assert.Equal(t, "oidc:user1", review.Spec.User)
+			assert.Equal(t, tc.expected, review.Spec.ResourceAttributes)
+		})
+	}
+}
+
+// newTestServer returns a server that allows access to agentk's own identity.
+func newTestServer(t *testing.T) *server {
+	client := fake.NewClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		t.Error("unexpected SubjectAccessReview")
+		return true, nil, nil
+	})
+	return newServer(client.AuthorizationV1())
+}
+
+func sortedAllowed(s *server) []string {
+	return sets.List(*s.allowed.Load())
+}
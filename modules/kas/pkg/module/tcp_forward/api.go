@@ -0,0 +1,5 @@
+package tcp_forward
+
+const (
+	ModuleName = "tcp_forward"
+)
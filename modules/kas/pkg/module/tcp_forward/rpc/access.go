@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// TargetAccess returns the access a caller needs to connect to the target of the header. nil if the target is unknown.
+// kas policies and the access review in the agent both use it so that a rule written for one matches the other.
+// A service requires create on its services/proxy subresource. A host may be any Pod in the cluster,
+// so it requires create on pods/portforward in all namespaces.
+func TargetAccess(header *ForwardRequest_Header) *authorizationv1.ResourceAttributes {
+	switch t := header.Target.(type) {
+	case *ForwardRequest_Header_Service:
+		return &authorizationv1.ResourceAttributes{
+			Namespace:   t.Service.Namespace,
+			Verb:        "create",
+			Resource:    "services",
+			Subresource: "proxy",
+			Name:        t.Service.Name,
+		}
+	case *ForwardRequest_Header_Host:
+		return &authorizationv1.ResourceAttributes{
+			Verb:        "create",
+			Resource:    "pods",
+			Subresource: "portforward",
+		}
+	default:
+		return nil
+	}
+}
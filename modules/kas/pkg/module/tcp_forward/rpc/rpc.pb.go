@@ -0,0 +1,580 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: pkg/module/tcp_forward/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	rpc "github.com/pluralsh/kubernetes-agent/pkg/module/kubernetes_api/rpc"
+	_ "github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool/automata"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Service is a Kubernetes Service.
+type Service struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Service) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ForwardRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*ForwardRequest_Header_
+	//	*ForwardRequest_Data_
+	Message       isForwardRequest_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardRequest) Reset() {
+	*x = ForwardRequest{}
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardRequest) ProtoMessage() {}
+
+func (x *ForwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardRequest.ProtoReflect.Descriptor instead.
+func (*ForwardRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ForwardRequest) GetMessage() isForwardRequest_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ForwardRequest) GetHeader() *ForwardRequest_Header {
+	if x != nil {
+		if x, ok := x.Message.(*ForwardRequest_Header_); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *ForwardRequest) GetData() *ForwardRequest_Data {
+	if x != nil {
+		if x, ok := x.Message.(*ForwardRequest_Data_); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isForwardRequest_Message interface {
+	isForwardRequest_Message()
+}
+
+type ForwardRequest_Header_ struct {
+	Header *ForwardRequest_Header `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type ForwardRequest_Data_ struct {
+	Data *ForwardRequest_Data `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ForwardRequest_Header_) isForwardRequest_Message() {}
+
+func (*ForwardRequest_Data_) isForwardRequest_Message() {}
+
+type ForwardResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*ForwardResponse_Header_
+	//	*ForwardResponse_Data_
+	Message       isForwardResponse_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardResponse) Reset() {
+	*x = ForwardResponse{}
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardResponse) ProtoMessage() {}
+
+func (x *ForwardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardResponse.ProtoReflect.Descriptor instead.
+func (*ForwardResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ForwardResponse) GetMessage() isForwardResponse_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ForwardResponse) GetHeader() *ForwardResponse_Header {
+	if x != nil {
+		if x, ok := x.Message.(*ForwardResponse_Header_); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *ForwardResponse) GetData() *ForwardResponse_Data {
+	if x != nil {
+		if x, ok := x.Message.(*ForwardResponse_Data_); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isForwardResponse_Message interface {
+	isForwardResponse_Message()
+}
+
+type ForwardResponse_Header_ struct {
+	Header *ForwardResponse_Header `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type ForwardResponse_Data_ struct {
+	Data *ForwardResponse_Data `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ForwardResponse_Header_) isForwardResponse_Message() {}
+
+func (*ForwardResponse_Data_) isForwardResponse_Message() {}
+
+// First message of the stream.
+type ForwardRequest_Header struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*ForwardRequest_Header_Service
+	//	*ForwardRequest_Header_Host
+	Target isForwardRequest_Header_Target `protobuf_oneof:"target"`
+	Port   uint32                         `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	// Identity of the caller. agentk checks that it's allowed to connect to the target.
+	// Not set if the caller uses the identity of agentk.
+	ImpConfig     *rpc.ImpersonationConfig `protobuf:"bytes,4,opt,name=imp_config,json=impConfig,proto3" json:"imp_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardRequest_Header) Reset() {
+	*x = ForwardRequest_Header{}
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardRequest_Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardRequest_Header) ProtoMessage() {}
+
+func (x *ForwardRequest_Header) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardRequest_Header.ProtoReflect.Descriptor instead.
+func (*ForwardRequest_Header) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ForwardRequest_Header) GetTarget() isForwardRequest_Header_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *ForwardRequest_Header) GetService() *Service {
+	if x != nil {
+		if x, ok := x.Target.(*ForwardRequest_Header_Service); ok {
+			return x.Service
+		}
+	}
+	return nil
+}
+
+func (x *ForwardRequest_Header) GetHost() string {
+	if x != nil {
+		if x, ok := x.Target.(*ForwardRequest_Header_Host); ok {
+			return x.Host
+		}
+	}
+	return ""
+}
+
+func (x *ForwardRequest_Header) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ForwardRequest_Header) GetImpConfig() *rpc.ImpersonationConfig {
+	if x != nil {
+		return x.ImpConfig
+	}
+	return nil
+}
+
+type isForwardRequest_Header_Target interface {
+	isForwardRequest_Header_Target()
+}
+
+type ForwardRequest_Header_Service struct {
+	Service *Service `protobuf:"bytes,1,opt,name=service,proto3,oneof"`
+}
+
+type ForwardRequest_Header_Host struct {
+	// DNS name or IP address.
+	Host string `protobuf:"bytes,2,opt,name=host,proto3,oneof"`
+}
+
+func (*ForwardRequest_Header_Service) isForwardRequest_Header_Target() {}
+
+func (*ForwardRequest_Header_Host) isForwardRequest_Header_Target() {}
+
+// Subsequent messages of the stream.
+type ForwardRequest_Data struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardRequest_Data) Reset() {
+	*x = ForwardRequest_Data{}
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardRequest_Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardRequest_Data) ProtoMessage() {}
+
+func (x *ForwardRequest_Data) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardRequest_Data.ProtoReflect.Descriptor instead.
+func (*ForwardRequest_Data) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *ForwardRequest_Data) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// First message of the stream. Sent once the connection to the target has been established.
+type ForwardResponse_Header struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardResponse_Header) Reset() {
+	*x = ForwardResponse_Header{}
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardResponse_Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardResponse_Header) ProtoMessage() {}
+
+func (x *ForwardResponse_Header) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardResponse_Header.ProtoReflect.Descriptor instead.
+func (*ForwardResponse_Header) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP(), []int{2, 0}
+}
+
+// Subsequent messages of the stream.
+type ForwardResponse_Data struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardResponse_Data) Reset() {
+	*x = ForwardResponse_Data{}
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardResponse_Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardResponse_Data) ProtoMessage() {}
+
+func (x *ForwardResponse_Data) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardResponse_Data.ProtoReflect.Descriptor instead.
+func (*ForwardResponse_Data) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *ForwardResponse_Data) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_pkg_module_tcp_forward_rpc_rpc_proto protoreflect.FileDescriptor
+
+const file_pkg_module_tcp_forward_rpc_rpc_proto_rawDesc = "" +
+	"\n" +
+	"$pkg/module/tcp_forward/rpc/rpc.proto\x12\x1cplural.agent.tcp_forward.rpc\x1a'pkg/module/kubernetes_api/rpc/rpc.proto\x1a)pkg/tool/grpctool/automata/automata.proto\x1a\x17validate/validate.proto\"M\n" +
+	"\aService\x12%\n" +
+	"\tnamespace\x18\x01 \x01(\tB\a\xfaB\x04r\x02 \x01R\tnamespace\x12\x1b\n" +
+	"\x04name\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01R\x04name\"\x87\x04\n" +
+	"\x0eForwardRequest\x12f\n" +
+	"\x06header\x18\x01 \x01(\v23.plural.agent.tcp_forward.rpc.ForwardRequest.HeaderB\x17\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\v\x02\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x06header\x12`\n" +
+	"\x04data\x18\x02 \x01(\v21.plural.agent.tcp_forward.rpc.ForwardRequest.DataB\x17\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\v\x02\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x04data\x1a\xf9\x01\n" +
+	"\x06Header\x12K\n" +
+	"\aservice\x18\x01 \x01(\v2%.plural.agent.tcp_forward.rpc.ServiceB\b\xfaB\x05\x8a\x01\x02\x10\x01H\x00R\aservice\x12\x1d\n" +
+	"\x04host\x18\x02 \x01(\tB\a\xfaB\x04r\x02 \x01H\x00R\x04host\x12\x1f\n" +
+	"\x04port\x18\x03 \x01(\rB\v\xfaB\b*\x06\x18\xff\xff\x03 \x00R\x04port\x12S\n" +
+	"\n" +
+	"imp_config\x18\x04 \x01(\v24.plural.agent.kubernetes_api.rpc.ImpersonationConfigR\timpConfigB\r\n" +
+	"\x06target\x12\x03\xf8B\x01\x1a\x1a\n" +
+	"\x04Data\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04dataB\x13\n" +
+	"\amessage\x12\b\xf8B\x01\x8a\xf6,\x01\x01\"\x98\x02\n" +
+	"\x0fForwardResponse\x12g\n" +
+	"\x06header\x18\x01 \x01(\v24.plural.agent.tcp_forward.rpc.ForwardResponse.HeaderB\x17\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\v\x02\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x06header\x12a\n" +
+	"\x04data\x18\x02 \x01(\v22.plural.agent.tcp_forward.rpc.ForwardResponse.DataB\x17\xfaB\x05\x8a\x01\x02\x10\x01\x82\xf6,\v\x02\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01H\x00R\x04data\x1a\b\n" +
+	"\x06Header\x1a\x1a\n" +
+	"\x04Data\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04dataB\x13\n" +
+	"\amessage\x12\b\xf8B\x01\x8a\xf6,\x01\x012z\n" +
+	"\n" +
+	"TcpForward\x12l\n" +
+	"\aForward\x12,.plural.agent.tcp_forward.rpc.ForwardRequest\x1a-.plural.agent.tcp_forward.rpc.ForwardResponse\"\x00(\x010\x01BAZ?github.com/pluralsh/kubernetes-agent/pkg/module/tcp_forward/rpcb\x06proto3"
+
+var (
+	file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescOnce sync.Once
+	file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescData []byte
+)
+
+func file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescGZIP() []byte {
+	file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescOnce.Do(func() {
+		file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_module_tcp_forward_rpc_rpc_proto_rawDesc), len(file_pkg_module_tcp_forward_rpc_rpc_proto_rawDesc)))
+	})
+	return file_pkg_module_tcp_forward_rpc_rpc_proto_rawDescData
+}
+
+var file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_pkg_module_tcp_forward_rpc_rpc_proto_goTypes = []any{
+	(*Service)(nil),                 // 0: plural.agent.tcp_forward.rpc.Service
+	(*ForwardRequest)(nil),          // 1: plural.agent.tcp_forward.rpc.ForwardRequest
+	(*ForwardResponse)(nil),         // 2: plural.agent.tcp_forward.rpc.ForwardResponse
+	(*ForwardRequest_Header)(nil),   // 3: plural.agent.tcp_forward.rpc.ForwardRequest.Header
+	(*ForwardRequest_Data)(nil),     // 4: plural.agent.tcp_forward.rpc.ForwardRequest.Data
+	(*ForwardResponse_Header)(nil),  // 5: plural.agent.tcp_forward.rpc.ForwardResponse.Header
+	(*ForwardResponse_Data)(nil),    // 6: plural.agent.tcp_forward.rpc.ForwardResponse.Data
+	(*rpc.ImpersonationConfig)(nil), // 7: plural.agent.kubernetes_api.rpc.ImpersonationConfig
+}
+var file_pkg_module_tcp_forward_rpc_rpc_proto_depIdxs = []int32{
+	3, // 0: plural.agent.tcp_forward.rpc.ForwardRequest.header:type_name -> plural.agent.tcp_forward.rpc.ForwardRequest.Header
+	4, // 1: plural.agent.tcp_forward.rpc.ForwardRequest.data:type_name -> plural.agent.tcp_forward.rpc.ForwardRequest.Data
+	5, // 2: plural.agent.tcp_forward.rpc.ForwardResponse.header:type_name -> plural.agent.tcp_forward.rpc.ForwardResponse.Header
+	6, // 3: plural.agent.tcp_forward.rpc.ForwardResponse.data:type_name -> plural.agent.tcp_forward.rpc.ForwardResponse.Data
+	0, // 4: plural.agent.tcp_forward.rpc.ForwardRequest.Header.service:type_name -> plural.agent.tcp_forward.rpc.Service
+	7, // 5: plural.agent.tcp_forward.rpc.ForwardRequest.Header.imp_config:type_name -> plural.agent.kubernetes_api.rpc.ImpersonationConfig
+	1, // 6: plural.agent.tcp_forward.rpc.TcpForward.Forward:input_type -> plural.agent.tcp_forward.rpc.ForwardRequest
+	2, // 7: plural.agent.tcp_forward.rpc.TcpForward.Forward:output_type -> plural.agent.tcp_forward.rpc.ForwardResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pkg_module_tcp_forward_rpc_rpc_proto_init() }
+func file_pkg_module_tcp_forward_rpc_rpc_proto_init() {
+	if File_pkg_module_tcp_forward_rpc_rpc_proto != nil {
+		return
+	}
+	file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[1].OneofWrappers = []any{
+		(*ForwardRequest_Header_)(nil),
+		(*ForwardRequest_Data_)(nil),
+	}
+	file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[2].OneofWrappers = []any{
+		(*ForwardResponse_Header_)(nil),
+		(*ForwardResponse_Data_)(nil),
+	}
+	file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes[3].OneofWrappers = []any{
+		(*ForwardRequest_Header_Service)(nil),
+		(*ForwardRequest_Header_Host)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_tcp_forward_rpc_rpc_proto_rawDesc), len(file_pkg_module_tcp_forward_rpc_rpc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_module_tcp_forward_rpc_rpc_proto_goTypes,
+		DependencyIndexes: file_pkg_module_tcp_forward_rpc_rpc_proto_depIdxs,
+		MessageInfos:      file_pkg_module_tcp_forward_rpc_rpc_proto_msgTypes,
+	}.Build()
+	File_pkg_module_tcp_forward_rpc_rpc_proto = out.File
+	file_pkg_module_tcp_forward_rpc_rpc_proto_goTypes = nil
+	file_pkg_module_tcp_forward_rpc_rpc_proto_depIdxs = nil
+}
@@ -0,0 +1,1145 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: pkg/module/tcp_forward/rpc/rpc.proto
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Service with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Service) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Service with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in ServiceMultiError, or nil if none found.
+func (m *Service) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Service) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetNamespace()) < 1 {
+		err := ServiceValidationError{
+			field:  "Namespace",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetName()) < 1 {
+		err := ServiceValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ServiceMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServiceMultiError is an error wrapping multiple validation errors returned
+// by Service.ValidateAll() if the designated constraints aren't met.
+type ServiceMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServiceMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServiceMultiError) AllErrors() []error { return m }
+
+// ServiceValidationError is the validation error returned by Service.Validate
+// if the designated constraints aren't met.
+type ServiceValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServiceValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServiceValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServiceValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServiceValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServiceValidationError) ErrorName() string { return "ServiceValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ServiceValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sService.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServiceValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServiceValidationError{}
+
+// Validate checks the field values on ForwardRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ForwardRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ForwardRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ForwardRequestMultiError,
+// or nil if none found.
+func (m *ForwardRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ForwardRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofMessagePresent := false
+	switch v := m.Message.(type) {
+	case *ForwardRequest_Header_:
+		if v == nil {
+			err := ForwardRequestValidationError{
+				field:  "Message",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMessagePresent = true
+
+		if m.GetHeader() == nil {
+			err := ForwardRequestValidationError{
+				field:  "Header",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetHeader()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ForwardRequestValidationError{
+						field:  "Header",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ForwardRequestValidationError{
+						field:  "Header",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetHeader()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ForwardRequestValidationError{
+					field:  "Header",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ForwardRequest_Data_:
+		if v == nil {
+			err := ForwardRequestValidationError{
+				field:  "Message",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMessagePresent = true
+
+		if m.GetData() == nil {
+			err := ForwardRequestValidationError{
+				field:  "Data",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetData()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ForwardRequestValidationError{
+						field:  "Data",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ForwardRequestValidationError{
+						field:  "Data",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ForwardRequestValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofMessagePresent {
+		err := ForwardRequestValidationError{
+			field:  "Message",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ForwardRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ForwardRequestMultiError is an error wrapping multiple validation errors
+// returned by ForwardRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ForwardRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ForwardRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ForwardRequestMultiError) AllErrors() []error { return m }
+
+// ForwardRequestValidationError is the validation error returned by
+// ForwardRequest.Validate if the designated constraints aren't met.
+type ForwardRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ForwardRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ForwardRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ForwardRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ForwardRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ForwardRequestValidationError) ErrorName() string { return "ForwardRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ForwardRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sForwardRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ForwardRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ForwardRequestValidationError{}
+
+// Validate checks the field values on ForwardResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ForwardResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ForwardResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ForwardResponseMultiError, or nil if none found.
+func (m *ForwardResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ForwardResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofMessagePresent := false
+	switch v := m.Message.(type) {
+	case *ForwardResponse_Header_:
+		if v == nil {
+			err := ForwardResponseValidationError{
+				field:  "Message",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMessagePresent = true
+
+		if m.GetHeader() == nil {
+			err := ForwardResponseValidationError{
+				field:  "Header",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetHeader()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ForwardResponseValidationError{
+						field:  "Header",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ForwardResponseValidationError{
+						field:  "Header",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetHeader()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ForwardResponseValidationError{
+					field:  "Header",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ForwardResponse_Data_:
+		if v == nil {
+			err := ForwardResponseValidationError{
+				field:  "Message",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofMessagePresent = true
+
+		if m.GetData() == nil {
+			err := ForwardResponseValidationError{
+				field:  "Data",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetData()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ForwardResponseValidationError{
+						field:  "Data",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ForwardResponseValidationError{
+						field:  "Data",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ForwardResponseValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofMessagePresent {
+		err := ForwardResponseValidationError{
+			field:  "Message",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ForwardResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ForwardResponseMultiError is an error wrapping multiple validation errors
+// returned by ForwardResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ForwardResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ForwardResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ForwardResponseMultiError) AllErrors() []error { return m }
+
+// ForwardResponseValidationError is the validation error returned by
+// ForwardResponse.Validate if the designated constraints aren't met.
+type ForwardResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ForwardResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ForwardResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ForwardResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ForwardResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ForwardResponseValidationError) ErrorName() string { return "ForwardResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ForwardResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sForwardResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ForwardResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ForwardResponseValidationError{}
+
+// Validate checks the field values on ForwardRequest_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ForwardRequest_Header) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ForwardRequest_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ForwardRequest_HeaderMultiError, or nil if none found.
+func (m *ForwardRequest_Header) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ForwardRequest_Header) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetPort(); val <= 0 || val > 65535 {
+		err := ForwardRequest_HeaderValidationError{
+			field:  "Port",
+			reason: "value must be inside range (0, 65535]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetImpConfig()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ForwardRequest_HeaderValidationError{
+					field:  "ImpConfig",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ForwardRequest_HeaderValidationError{
+					field:  "ImpConfig",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetImpConfig()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ForwardRequest_HeaderValidationError{
+				field:  "ImpConfig",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	oneofTargetPresent := false
+	switch v := m.Target.(type) {
+	case *ForwardRequest_Header_Service:
+		if v == nil {
+			err := ForwardRequest_HeaderValidationError{
+				field:  "Target",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofTargetPresent = true
+
+		if m.GetService() == nil {
+			err := ForwardRequest_HeaderValidationError{
+				field:  "Service",
+				reason: "value is required",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetService()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ForwardRequest_HeaderValidationError{
+						field:  "Service",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ForwardRequest_HeaderValidationError{
+						field:  "Service",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetService()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ForwardRequest_HeaderValidationError{
+					field:  "Service",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ForwardRequest_Header_Host:
+		if v == nil {
+			err := ForwardRequest_HeaderValidationError{
+				field:  "Target",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofTargetPresent = true
+
+		if len(m.GetHost()) < 1 {
+			err := ForwardRequest_HeaderValidationError{
+				field:  "Host",
+				reason: "value length must be at least 1 bytes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofTargetPresent {
+		err := ForwardRequest_HeaderValidationError{
+			field:  "Target",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ForwardRequest_HeaderMultiError(errors)
+	}
+
+	return nil
+}
+
+// ForwardRequest_HeaderMultiError is an error wrapping multiple validation
+// errors returned by ForwardRequest_Header.ValidateAll() if the designated
+// constraints aren't met.
+type ForwardRequest_HeaderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ForwardRequest_HeaderMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ForwardRequest_HeaderMultiError) AllErrors() []error { return m }
+
+// ForwardRequest_HeaderValidationError is the validation error returned by
+// ForwardRequest_Header.Validate if the designated constraints aren't met.
+type ForwardRequest_HeaderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ForwardRequest_HeaderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ForwardRequest_HeaderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ForwardRequest_HeaderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ForwardRequest_HeaderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ForwardRequest_HeaderValidationError) ErrorName() string {
+	return "ForwardRequest_HeaderValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ForwardRequest_HeaderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sForwardRequest_Header.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ForwardRequest_HeaderValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ForwardRequest_HeaderValidationError{}
+
+// Validate checks the field values on ForwardRequest_Data with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ForwardRequest_Data) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ForwardRequest_Data with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ForwardRequest_DataMultiError, or nil if none found.
+func (m *ForwardRequest_Data) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ForwardRequest_Data) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return ForwardRequest_DataMultiError(errors)
+	}
+
+	return nil
+}
+
+// ForwardRequest_DataMultiError is an error wrapping multiple validation
+// errors returned by ForwardRequest_Data.ValidateAll() if the designated
+// constraints aren't met.
+type ForwardRequest_DataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ForwardRequest_DataMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ForwardRequest_DataMultiError) AllErrors() []error { return m }
+
+// ForwardRequest_DataValidationError is the validation error returned by
+// ForwardRequest_Data.Validate if the designated constraints aren't met.
+type ForwardRequest_DataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ForwardRequest_DataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ForwardRequest_DataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ForwardRequest_DataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ForwardRequest_DataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ForwardRequest_DataValidationError) ErrorName() string {
+	return "ForwardRequest_DataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ForwardRequest_DataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sForwardRequest_Data.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ForwardRequest_DataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ForwardRequest_DataValidationError{}
+
+// Validate checks the field values on ForwardResponse_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ForwardResponse_Header) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ForwardResponse_Header with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ForwardResponse_HeaderMultiError, or nil if none found.
+func (m *ForwardResponse_Header) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ForwardResponse_Header) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ForwardResponse_HeaderMultiError(errors)
+	}
+
+	return nil
+}
+
+// ForwardResponse_HeaderMultiError is an error wrapping multiple validation
+// errors returned by ForwardResponse_Header.ValidateAll() if the designated
+// constraints aren't met.
+type ForwardResponse_HeaderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ForwardResponse_HeaderMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ForwardResponse_HeaderMultiError) AllErrors() []error { return m }
+
+// ForwardResponse_HeaderValidationError is the validation error returned by
+// ForwardResponse_Header.Validate if the designated constraints aren't met.
+type ForwardResponse_HeaderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ForwardResponse_HeaderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ForwardResponse_HeaderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ForwardResponse_HeaderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ForwardResponse_HeaderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ForwardResponse_HeaderValidationError) ErrorName() string {
+	return "ForwardResponse_HeaderValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ForwardResponse_HeaderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sForwardResponse_Header.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ForwardResponse_HeaderValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ForwardResponse_HeaderValidationError{}
+
+// Validate checks the field values on ForwardResponse_Data with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ForwardResponse_Data) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ForwardResponse_Data with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ForwardResponse_DataMultiError, or nil if none found.
+func (m *ForwardResponse_Data) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ForwardResponse_Data) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return ForwardResponse_DataMultiError(errors)
+	}
+
+	return nil
+}
+
+// ForwardResponse_DataMultiError is an error wrapping multiple validation
+// errors returned by ForwardResponse_Data.ValidateAll() if the designated
+// constraints aren't met.
+type ForwardResponse_DataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ForwardResponse_DataMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ForwardResponse_DataMultiError) AllErrors() []error { return m }
+
+// ForwardResponse_DataValidationError is the validation error returned by
+// ForwardResponse_Data.Validate if the designated constraints aren't met.
+type ForwardResponse_DataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ForwardResponse_DataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ForwardResponse_DataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ForwardResponse_DataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ForwardResponse_DataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ForwardResponse_DataValidationError) ErrorName() string {
+	return "ForwardResponse_DataValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ForwardResponse_DataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sForwardResponse_Data.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ForwardResponse_DataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ForwardResponse_DataValidationError{}
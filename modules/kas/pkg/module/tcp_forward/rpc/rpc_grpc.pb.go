@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.31.1
+// source: pkg/module/tcp_forward/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TcpForward_Forward_FullMethodName = "/plural.agent.tcp_forward.rpc.TcpForward/Forward"
+)
+
+// TcpForwardClient is the client API for TcpForward service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TcpForwardClient interface {
+	// Forward opens a TCP connection to a target in the cluster and pipes data in both directions.
+	// The client closes its side of the stream to close the connection for writing.
+	Forward(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ForwardRequest, ForwardResponse], error)
+}
+
+type tcpForwardClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTcpForwardClient(cc grpc.ClientConnInterface) TcpForwardClient {
+	return &tcpForwardClient{cc}
+}
+
+func (c *tcpForwardClient) Forward(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ForwardRequest, ForwardResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TcpForward_ServiceDesc.Streams[0], TcpForward_Forward_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ForwardRequest, ForwardResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TcpForward_ForwardClient = grpc.BidiStreamingClient[ForwardRequest, ForwardResponse]
+
+// TcpForwardServer is the server API for TcpForward service.
+// All implementations must embed UnimplementedTcpForwardServer
+// for forward compatibility.
+type TcpForwardServer interface {
+	// Forward opens a TCP connection to a target in the cluster and pipes data in both directions.
+	// The client closes its side of the stream to close the connection for writing.
+	Forward(grpc.BidiStreamingServer[ForwardRequest, ForwardResponse]) error
+	mustEmbedUnimplementedTcpForwardServer()
+}
+
+// UnimplementedTcpForwardServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTcpForwardServer struct{}
+
+func (UnimplementedTcpForwardServer) Forward(grpc.BidiStreamingServer[ForwardRequest, ForwardResponse]) error {
+	return status.Error(codes.Unimplemented, "method Forward not implemented")
+}
+func (UnimplementedTcpForwardServer) mustEmbedUnimplementedTcpForwardServer() {}
+func (UnimplementedTcpForwardServer) testEmbeddedByValue()                    {}
+
+// UnsafeTcpForwardServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TcpForwardServer will
+// result in compilation errors.
+type UnsafeTcpForwardServer interface {
+	mustEmbedUnimplementedTcpForwardServer()
+}
+
+func RegisterTcpForwardServer(s grpc.ServiceRegistrar, srv TcpForwardServer) {
+	// If the following call panics, it indicates UnimplementedTcpForwardServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TcpForward_ServiceDesc, srv)
+}
+
+func _TcpForward_Forward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TcpForwardServer).Forward(&grpc.GenericServerStream[ForwardRequest, ForwardResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TcpForward_ForwardServer = grpc.BidiStreamingServer[ForwardRequest, ForwardResponse]
+
+// TcpForward_ServiceDesc is the grpc.ServiceDesc for TcpForward service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TcpForward_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.tcp_forward.rpc.TcpForward",
+	HandlerType: (*TcpForwardServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Forward",
+			Handler:       _TcpForward_Forward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/module/tcp_forward/rpc/rpc.proto",
+}
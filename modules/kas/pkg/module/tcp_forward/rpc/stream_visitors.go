@@ -0,0 +1,20 @@
+package rpc
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/grpctool"
+)
+
+const (
+	ForwardRequestHeaderFieldNumber protoreflect.FieldNumber = 1
+	ForwardRequestDataFieldNumber   protoreflect.FieldNumber = 2
+
+	ForwardResponseHeaderFieldNumber protoreflect.FieldNumber = 1
+	ForwardResponseDataFieldNumber   protoreflect.FieldNumber = 2
+)
+
+var (
+	ForwardRequestStreamVisitor  = grpctool.NewLazyStreamVisitor(&ForwardRequest{})
+	ForwardResponseStreamVisitor = grpctool.NewLazyStreamVisitor(&ForwardResponse{})
+)
@@ -0,0 +1,5 @@
+package tunnel_introspection
+
+const (
+	ModuleName = "tunnel_introspection"
+)
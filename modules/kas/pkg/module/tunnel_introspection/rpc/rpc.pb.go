@@ -0,0 +1,505 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: pkg/module/tunnel_introspection/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// MuxTunnel contains information about a multiplexed tunnel.
+type MuxTunnel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of streams that are currently open on the tunnel.
+	ActiveStreams uint32 `protobuf:"varint,1,opt,name=active_streams,proto3" json:"active_streams,omitempty"`
+	// Maximum number of concurrent streams agentk accepts on the tunnel.
+	MaxStreams uint32 `protobuf:"varint,2,opt,name=max_streams,proto3" json:"max_streams,omitempty"`
+	// Whether the tunnel is waiting for the open streams to finish before closing.
+	Draining bool `protobuf:"varint,3,opt,name=draining,proto3" json:"draining,omitempty"`
+	// Smoothed round-trip time, measured with pings. Not set if no ping has been answered yet.
+	RoundTripTime *durationpb.Duration `protobuf:"bytes,4,opt,name=round_trip_time,proto3" json:"round_trip_time,omitempty"`
+	// Round-trip time of the most recently answered ping.
+	LastRoundTripTime *durationpb.Duration `protobuf:"bytes,5,opt,name=last_round_trip_time,proto3" json:"last_round_trip_time,omitempty"`
+	// When the most recent ping was answered.
+	LastPongAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_pong_at,proto3" json:"last_pong_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuxTunnel) Reset() {
+	*x = MuxTunnel{}
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuxTunnel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuxTunnel) ProtoMessage() {}
+
+func (x *MuxTunnel) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuxTunnel.ProtoReflect.Descriptor instead.
+func (*MuxTunnel) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MuxTunnel) GetActiveStreams() uint32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *MuxTunnel) GetMaxStreams() uint32 {
+	if x != nil {
+		return x.MaxStreams
+	}
+	return 0
+}
+
+func (x *MuxTunnel) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *MuxTunnel) GetRoundTripTime() *durationpb.Duration {
+	if x != nil {
+		return x.RoundTripTime
+	}
+	return nil
+}
+
+func (x *MuxTunnel) GetLastRoundTripTime() *durationpb.Duration {
+	if x != nil {
+		return x.LastRoundTripTime
+	}
+	return nil
+}
+
+func (x *MuxTunnel) GetLastPongAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPongAt
+	}
+	return nil
+}
+
+// AgentTunnels contains information about tunnels of an agent, connected to a kas instance.
+type AgentTunnels struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique id of the agent.
+	AgentId int64 `protobuf:"varint,1,opt,name=agent_id,proto3" json:"agent_id,omitempty"`
+	// Number of non-multiplexed tunnels that are waiting to be used.
+	IdleTunnels uint32 `protobuf:"varint,2,opt,name=idle_tunnels,proto3" json:"idle_tunnels,omitempty"`
+	// Number of non-multiplexed tunnels that are being used to forward a request.
+	ActiveTunnels uint32 `protobuf:"varint,3,opt,name=active_tunnels,proto3" json:"active_tunnels,omitempty"`
+	// Multiplexed tunnels.
+	MuxTunnels []*MuxTunnel `protobuf:"bytes,4,rep,name=mux_tunnels,proto3" json:"mux_tunnels,omitempty"`
+	// Number of requests that are waiting for a tunnel to become available.
+	PendingFindRequests uint32 `protobuf:"varint,5,opt,name=pending_find_requests,proto3" json:"pending_find_requests,omitempty"`
+	// For how long the oldest pending request has been waiting. Not set if there are no pending requests.
+	OldestPendingFindRequestAge *durationpb.Duration `protobuf:"bytes,6,opt,name=oldest_pending_find_request_age,proto3" json:"oldest_pending_find_request_age,omitempty"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *AgentTunnels) Reset() {
+	*x = AgentTunnels{}
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentTunnels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentTunnels) ProtoMessage() {}
+
+func (x *AgentTunnels) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentTunnels.ProtoReflect.Descriptor instead.
+func (*AgentTunnels) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AgentTunnels) GetAgentId() int64 {
+	if x != nil {
+		return x.AgentId
+	}
+	return 0
+}
+
+func (x *AgentTunnels) GetIdleTunnels() uint32 {
+	if x != nil {
+		return x.IdleTunnels
+	}
+	return 0
+}
+
+func (x *AgentTunnels) GetActiveTunnels() uint32 {
+	if x != nil {
+		return x.ActiveTunnels
+	}
+	return 0
+}
+
+func (x *AgentTunnels) GetMuxTunnels() []*MuxTunnel {
+	if x != nil {
+		return x.MuxTunnels
+	}
+	return nil
+}
+
+func (x *AgentTunnels) GetPendingFindRequests() uint32 {
+	if x != nil {
+		return x.PendingFindRequests
+	}
+	return 0
+}
+
+func (x *AgentTunnels) GetOldestPendingFindRequestAge() *durationpb.Duration {
+	if x != nil {
+		return x.OldestPendingFindRequestAge
+	}
+	return nil
+}
+
+type GetAgentTunnelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       int64                  `protobuf:"varint,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentTunnelsRequest) Reset() {
+	*x = GetAgentTunnelsRequest{}
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentTunnelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentTunnelsRequest) ProtoMessage() {}
+
+func (x *GetAgentTunnelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentTunnelsRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentTunnelsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAgentTunnelsRequest) GetAgentId() int64 {
+	if x != nil {
+		return x.AgentId
+	}
+	return 0
+}
+
+type GetAgentTunnelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// URL of the kas instance that handled the request.
+	KasUrl string `protobuf:"bytes,1,opt,name=kas_url,proto3" json:"kas_url,omitempty"`
+	// Tunnels of the agent, connected to the kas instance that handled the request.
+	Agent *AgentTunnels `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	// URLs of all kas instances that have tunnels from the agent.
+	AgentKasUrls  []string `protobuf:"bytes,3,rep,name=agent_kas_urls,proto3" json:"agent_kas_urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentTunnelsResponse) Reset() {
+	*x = GetAgentTunnelsResponse{}
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentTunnelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentTunnelsResponse) ProtoMessage() {}
+
+func (x *GetAgentTunnelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentTunnelsResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentTunnelsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAgentTunnelsResponse) GetKasUrl() string {
+	if x != nil {
+		return x.KasUrl
+	}
+	return ""
+}
+
+func (x *GetAgentTunnelsResponse) GetAgent() *AgentTunnels {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *GetAgentTunnelsResponse) GetAgentKasUrls() []string {
+	if x != nil {
+		return x.AgentKasUrls
+	}
+	return nil
+}
+
+type ListAgentTunnelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentTunnelsRequest) Reset() {
+	*x = ListAgentTunnelsRequest{}
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentTunnelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentTunnelsRequest) ProtoMessage() {}
+
+func (x *ListAgentTunnelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentTunnelsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentTunnelsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescGZIP(), []int{4}
+}
+
+type ListAgentTunnelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// URL of the kas instance that handled the request.
+	KasUrl string `protobuf:"bytes,1,opt,name=kas_url,proto3" json:"kas_url,omitempty"`
+	// Agents that have tunnels or pending requests on the kas instance that handled the request.
+	Agents        []*AgentTunnels `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentTunnelsResponse) Reset() {
+	*x = ListAgentTunnelsResponse{}
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentTunnelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentTunnelsResponse) ProtoMessage() {}
+
+func (x *ListAgentTunnelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentTunnelsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentTunnelsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAgentTunnelsResponse) GetKasUrl() string {
+	if x != nil {
+		return x.KasUrl
+	}
+	return ""
+}
+
+func (x *ListAgentTunnelsResponse) GetAgents() []*AgentTunnels {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+var File_pkg_module_tunnel_introspection_rpc_rpc_proto protoreflect.FileDescriptor
+
+const file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDesc = "" +
+	"\n" +
+	"-pkg/module/tunnel_introspection/rpc/rpc.proto\x12%plural.agent.tunnel_introspection.rpc\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\xc5\x02\n" +
+	"\tMuxTunnel\x12&\n" +
+	"\x0eactive_streams\x18\x01 \x01(\rR\x0eactive_streams\x12 \n" +
+	"\vmax_streams\x18\x02 \x01(\rR\vmax_streams\x12\x1a\n" +
+	"\bdraining\x18\x03 \x01(\bR\bdraining\x12C\n" +
+	"\x0fround_trip_time\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0fround_trip_time\x12M\n" +
+	"\x14last_round_trip_time\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x14last_round_trip_time\x12>\n" +
+	"\flast_pong_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\flast_pong_at\"\xe5\x02\n" +
+	"\fAgentTunnels\x12\x1a\n" +
+	"\bagent_id\x18\x01 \x01(\x03R\bagent_id\x12\"\n" +
+	"\fidle_tunnels\x18\x02 \x01(\rR\fidle_tunnels\x12&\n" +
+	"\x0eactive_tunnels\x18\x03 \x01(\rR\x0eactive_tunnels\x12R\n" +
+	"\vmux_tunnels\x18\x04 \x03(\v20.plural.agent.tunnel_introspection.rpc.MuxTunnelR\vmux_tunnels\x124\n" +
+	"\x15pending_find_requests\x18\x05 \x01(\rR\x15pending_find_requests\x12c\n" +
+	"\x1foldest_pending_find_request_age\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x1foldest_pending_find_request_age\"<\n" +
+	"\x16GetAgentTunnelsRequest\x12\"\n" +
+	"\bagent_id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\aagentId\"\xa6\x01\n" +
+	"\x17GetAgentTunnelsResponse\x12\x18\n" +
+	"\akas_url\x18\x01 \x01(\tR\akas_url\x12I\n" +
+	"\x05agent\x18\x02 \x01(\v23.plural.agent.tunnel_introspection.rpc.AgentTunnelsR\x05agent\x12&\n" +
+	"\x0eagent_kas_urls\x18\x03 \x03(\tR\x0eagent_kas_urls\"\x19\n" +
+	"\x17ListAgentTunnelsRequest\"\x81\x01\n" +
+	"\x18ListAgentTunnelsResponse\x12\x18\n" +
+	"\akas_url\x18\x01 \x01(\tR\akas_url\x12K\n" +
+	"\x06agents\x18\x02 \x03(\v23.plural.agent.tunnel_introspection.rpc.AgentTunnelsR\x06agents2\xc2\x02\n" +
+	"\x13TunnelIntrospection\x12\x92\x01\n" +
+	"\x0fGetAgentTunnels\x12=.plural.agent.tunnel_introspection.rpc.GetAgentTunnelsRequest\x1a>.plural.agent.tunnel_introspection.rpc.GetAgentTunnelsResponse\"\x00\x12\x95\x01\n" +
+	"\x10ListAgentTunnels\x12>.plural.agent.tunnel_introspection.rpc.ListAgentTunnelsRequest\x1a?.plural.agent.tunnel_introspection.rpc.ListAgentTunnelsResponse\"\x00BJZHgithub.com/pluralsh/kubernetes-agent/pkg/module/tunnel_introspection/rpcb\x06proto3"
+
+var (
+	file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescOnce sync.Once
+	file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescData []byte
+)
+
+func file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescGZIP() []byte {
+	file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescOnce.Do(func() {
+		file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDesc), len(file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDesc)))
+	})
+	return file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDescData
+}
+
+var file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_pkg_module_tunnel_introspection_rpc_rpc_proto_goTypes = []any{
+	(*MuxTunnel)(nil),                // 0: plural.agent.tunnel_introspection.rpc.MuxTunnel
+	(*AgentTunnels)(nil),             // 1: plural.agent.tunnel_introspection.rpc.AgentTunnels
+	(*GetAgentTunnelsRequest)(nil),   // 2: plural.agent.tunnel_introspection.rpc.GetAgentTunnelsRequest
+	(*GetAgentTunnelsResponse)(nil),  // 3: plural.agent.tunnel_introspection.rpc.GetAgentTunnelsResponse
+	(*ListAgentTunnelsRequest)(nil),  // 4: plural.agent.tunnel_introspection.rpc.ListAgentTunnelsRequest
+	(*ListAgentTunnelsResponse)(nil), // 5: plural.agent.tunnel_introspection.rpc.ListAgentTunnelsResponse
+	(*durationpb.Duration)(nil),      // 6: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),    // 7: google.protobuf.Timestamp
+}
+var file_pkg_module_tunnel_introspection_rpc_rpc_proto_depIdxs = []int32{
+	6, // 0: plural.agent.tunnel_introspection.rpc.MuxTunnel.round_trip_time:type_name -> google.protobuf.Duration
+	6, // 1: plural.agent.tunnel_introspection.rpc.MuxTunnel.last_round_trip_time:type_name -> google.protobuf.Duration
+	7, // 2: plural.agent.tunnel_introspection.rpc.MuxTunnel.last_pong_at:type_name -> google.protobuf.Timestamp
+	0, // 3: plural.agent.tunnel_introspection.rpc.AgentTunnels.mux_tunnels:type_name -> plural.agent.tunnel_introspection.rpc.MuxTunnel
+	6, // 4: plural.agent.tunnel_introspection.rpc.AgentTunnels.oldest_pending_find_request_age:type_name -> google.protobuf.Duration
+	1, // 5: plural.agent.tunnel_introspection.rpc.GetAgentTunnelsResponse.agent:type_name -> plural.agent.tunnel_introspection.rpc.AgentTunnels
+	1, // 6: plural.agent.tunnel_introspection.rpc.ListAgentTunnelsResponse.agents:type_name -> plural.agent.tunnel_introspection.rpc.AgentTunnels
+	2, // 7: plural.agent.tunnel_introspection.rpc.TunnelIntrospection.GetAgentTunnels:input_type -> plural.agent.tunnel_introspection.rpc.GetAgentTunnelsRequest
+	4, // 8: plural.agent.tunnel_introspection.rpc.TunnelIntrospection.ListAgentTunnels:input_type -> plural.agent.tunnel_introspection.rpc.ListAgentTunnelsRequest
+	3, // 9: plural.agent.tunnel_introspection.rpc.TunnelIntrospection.GetAgentTunnels:output_type -> plural.agent.tunnel_introspection.rpc.GetAgentTunnelsResponse
+	5, // 10: plural.agent.tunnel_introspection.rpc.TunnelIntrospection.ListAgentTunnels:output_type -> plural.agent.tunnel_introspection.rpc.ListAgentTunnelsResponse
+	9, // [9:11] is the sub-list for method output_type
+	7, // [7:9] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_pkg_module_tunnel_introspection_rpc_rpc_proto_init() }
+func file_pkg_module_tunnel_introspection_rpc_rpc_proto_init() {
+	if File_pkg_module_tunnel_introspection_rpc_rpc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDesc), len(file_pkg_module_tunnel_introspection_rpc_rpc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_module_tunnel_introspection_rpc_rpc_proto_goTypes,
+		DependencyIndexes: file_pkg_module_tunnel_introspection_rpc_rpc_proto_depIdxs,
+		MessageInfos:      file_pkg_module_tunnel_introspection_rpc_rpc_proto_msgTypes,
+	}.Build()
+	File_pkg_module_tunnel_introspection_rpc_rpc_proto = out.File
+	file_pkg_module_tunnel_introspection_rpc_rpc_proto_goTypes = nil
+	file_pkg_module_tunnel_introspection_rpc_rpc_proto_depIdxs = nil
+}
@@ -0,0 +1,884 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: pkg/module/tunnel_introspection/rpc/rpc.proto
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on MuxTunnel with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *MuxTunnel) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MuxTunnel with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in MuxTunnelMultiError, or nil
+// if none found.
+func (m *MuxTunnel) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MuxTunnel) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ActiveStreams
+
+	// no validation rules for MaxStreams
+
+	// no validation rules for Draining
+
+	if all {
+		switch v := interface{}(m.GetRoundTripTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MuxTunnelValidationError{
+					field:  "RoundTripTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MuxTunnelValidationError{
+					field:  "RoundTripTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRoundTripTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MuxTunnelValidationError{
+				field:  "RoundTripTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastRoundTripTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MuxTunnelValidationError{
+					field:  "LastRoundTripTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MuxTunnelValidationError{
+					field:  "LastRoundTripTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastRoundTripTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MuxTunnelValidationError{
+				field:  "LastRoundTripTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastPongAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MuxTunnelValidationError{
+					field:  "LastPongAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MuxTunnelValidationError{
+					field:  "LastPongAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastPongAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MuxTunnelValidationError{
+				field:  "LastPongAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return MuxTunnelMultiError(errors)
+	}
+
+	return nil
+}
+
+// MuxTunnelMultiError is an error wrapping multiple validation errors returned
+// by MuxTunnel.ValidateAll() if the designated constraints aren't met.
+type MuxTunnelMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MuxTunnelMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MuxTunnelMultiError) AllErrors() []error { return m }
+
+// MuxTunnelValidationError is the validation error returned by
+// MuxTunnel.Validate if the designated constraints aren't met.
+type MuxTunnelValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MuxTunnelValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MuxTunnelValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MuxTunnelValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MuxTunnelValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MuxTunnelValidationError) ErrorName() string { return "MuxTunnelValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MuxTunnelValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMuxTunnel.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MuxTunnelValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MuxTunnelValidationError{}
+
+// Validate checks the field values on AgentTunnels with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AgentTunnels) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AgentTunnels with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AgentTunnelsMultiError, or
+// nil if none found.
+func (m *AgentTunnels) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AgentTunnels) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for AgentId
+
+	// no validation rules for IdleTunnels
+
+	// no validation rules for ActiveTunnels
+
+	for idx, item := range m.GetMuxTunnels() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AgentTunnelsValidationError{
+						field:  fmt.Sprintf("MuxTunnels[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AgentTunnelsValidationError{
+						field:  fmt.Sprintf("MuxTunnels[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AgentTunnelsValidationError{
+					field:  fmt.Sprintf("MuxTunnels[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for PendingFindRequests
+
+	if all {
+		switch v := interface{}(m.GetOldestPendingFindRequestAge()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AgentTunnelsValidationError{
+					field:  "OldestPendingFindRequestAge",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AgentTunnelsValidationError{
+					field:  "OldestPendingFindRequestAge",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOldestPendingFindRequestAge()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AgentTunnelsValidationError{
+				field:  "OldestPendingFindRequestAge",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AgentTunnelsMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentTunnelsMultiError is an error wrapping multiple validation errors
+// returned by AgentTunnels.ValidateAll() if the designated constraints aren't met.
+type AgentTunnelsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentTunnelsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentTunnelsMultiError) AllErrors() []error { return m }
+
+// AgentTunnelsValidationError is the validation error returned by
+// AgentTunnels.Validate if the designated constraints aren't met.
+type AgentTunnelsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentTunnelsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentTunnelsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentTunnelsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentTunnelsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentTunnelsValidationError) ErrorName() string { return "AgentTunnelsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AgentTunnelsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAgentTunnels.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AgentTunnelsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AgentTunnelsValidationError{}
+
+// Validate checks the field values on GetAgentTunnelsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetAgentTunnelsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetAgentTunnelsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAgentTunnelsRequestMultiError, or nil if none found.
+func (m *GetAgentTunnelsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetAgentTunnelsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAgentId() <= 0 {
+		err := GetAgentTunnelsRequestValidationError{
+			field:  "AgentId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetAgentTunnelsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetAgentTunnelsRequestMultiError is an error wrapping multiple validation
+// errors returned by GetAgentTunnelsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetAgentTunnelsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetAgentTunnelsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetAgentTunnelsRequestMultiError) AllErrors() []error { return m }
+
+// GetAgentTunnelsRequestValidationError is the validation error returned by
+// GetAgentTunnelsRequest.Validate if the designated constraints aren't met.
+type GetAgentTunnelsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetAgentTunnelsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetAgentTunnelsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetAgentTunnelsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetAgentTunnelsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetAgentTunnelsRequestValidationError) ErrorName() string {
+	return "GetAgentTunnelsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetAgentTunnelsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetAgentTunnelsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetAgentTunnelsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetAgentTunnelsRequestValidationError{}
+
+// Validate checks the field values on GetAgentTunnelsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetAgentTunnelsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetAgentTunnelsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAgentTunnelsResponseMultiError, or nil if none found.
+func (m *GetAgentTunnelsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetAgentTunnelsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for KasUrl
+
+	if all {
+		switch v := interface{}(m.GetAgent()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetAgentTunnelsResponseValidationError{
+					field:  "Agent",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetAgentTunnelsResponseValidationError{
+					field:  "Agent",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAgent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetAgentTunnelsResponseValidationError{
+				field:  "Agent",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetAgentTunnelsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetAgentTunnelsResponseMultiError is an error wrapping multiple validation
+// errors returned by GetAgentTunnelsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetAgentTunnelsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetAgentTunnelsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetAgentTunnelsResponseMultiError) AllErrors() []error { return m }
+
+// GetAgentTunnelsResponseValidationError is the validation error returned by
+// GetAgentTunnelsResponse.Validate if the designated constraints aren't met.
+type GetAgentTunnelsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetAgentTunnelsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetAgentTunnelsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetAgentTunnelsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetAgentTunnelsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetAgentTunnelsResponseValidationError) ErrorName() string {
+	return "GetAgentTunnelsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetAgentTunnelsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetAgentTunnelsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetAgentTunnelsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetAgentTunnelsResponseValidationError{}
+
+// Validate checks the field values on ListAgentTunnelsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAgentTunnelsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAgentTunnelsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAgentTunnelsRequestMultiError, or nil if none found.
+func (m *ListAgentTunnelsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAgentTunnelsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListAgentTunnelsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAgentTunnelsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListAgentTunnelsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListAgentTunnelsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAgentTunnelsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAgentTunnelsRequestMultiError) AllErrors() []error { return m }
+
+// ListAgentTunnelsRequestValidationError is the validation error returned by
+// ListAgentTunnelsRequest.Validate if the designated constraints aren't met.
+type ListAgentTunnelsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAgentTunnelsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAgentTunnelsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAgentTunnelsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAgentTunnelsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAgentTunnelsRequestValidationError) ErrorName() string {
+	return "ListAgentTunnelsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAgentTunnelsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAgentTunnelsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAgentTunnelsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAgentTunnelsRequestValidationError{}
+
+// Validate checks the field values on ListAgentTunnelsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAgentTunnelsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAgentTunnelsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAgentTunnelsResponseMultiError, or nil if none found.
+func (m *ListAgentTunnelsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAgentTunnelsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for KasUrl
+
+	for idx, item := range m.GetAgents() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAgentTunnelsResponseValidationError{
+						field:  fmt.Sprintf("Agents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAgentTunnelsResponseValidationError{
+						field:  fmt.Sprintf("Agents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAgentTunnelsResponseValidationError{
+					field:  fmt.Sprintf("Agents[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListAgentTunnelsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAgentTunnelsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListAgentTunnelsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListAgentTunnelsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAgentTunnelsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAgentTunnelsResponseMultiError) AllErrors() []error { return m }
+
+// ListAgentTunnelsResponseValidationError is the validation error returned by
+// ListAgentTunnelsResponse.Validate if the designated constraints aren't met.
+type ListAgentTunnelsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAgentTunnelsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAgentTunnelsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAgentTunnelsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAgentTunnelsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAgentTunnelsResponseValidationError) ErrorName() string {
+	return "ListAgentTunnelsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAgentTunnelsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAgentTunnelsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAgentTunnelsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAgentTunnelsResponseValidationError{}
@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.31.1
+// source: pkg/module/tunnel_introspection/rpc/rpc.proto
+
+// If you make any changes make sure you run: make regenerate-proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TunnelIntrospection_GetAgentTunnels_FullMethodName  = "/plural.agent.tunnel_introspection.rpc.TunnelIntrospection/GetAgentTunnels"
+	TunnelIntrospection_ListAgentTunnels_FullMethodName = "/plural.agent.tunnel_introspection.rpc.TunnelIntrospection/ListAgentTunnels"
+)
+
+// TunnelIntrospectionClient is the client API for TunnelIntrospection service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TunnelIntrospectionClient interface {
+	// Get tunnels of an agent, connected to this kas instance, and the kas instances the agent is connected to.
+	GetAgentTunnels(ctx context.Context, in *GetAgentTunnelsRequest, opts ...grpc.CallOption) (*GetAgentTunnelsResponse, error)
+	// List tunnels of all agents, connected to this kas instance.
+	ListAgentTunnels(ctx context.Context, in *ListAgentTunnelsRequest, opts ...grpc.CallOption) (*ListAgentTunnelsResponse, error)
+}
+
+type tunnelIntrospectionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTunnelIntrospectionClient(cc grpc.ClientConnInterface) TunnelIntrospectionClient {
+	return &tunnelIntrospectionClient{cc}
+}
+
+func (c *tunnelIntrospectionClient) GetAgentTunnels(ctx context.Context, in *GetAgentTunnelsRequest, opts ...grpc.CallOption) (*GetAgentTunnelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentTunnelsResponse)
+	err := c.cc.Invoke(ctx, TunnelIntrospection_GetAgentTunnels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunnelIntrospectionClient) ListAgentTunnels(ctx context.Context, in *ListAgentTunnelsRequest, opts ...grpc.CallOption) (*ListAgentTunnelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentTunnelsResponse)
+	err := c.cc.Invoke(ctx, TunnelIntrospection_ListAgentTunnels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TunnelIntrospectionServer is the server API for TunnelIntrospection service.
+// All implementations must embed UnimplementedTunnelIntrospectionServer
+// for forward compatibility.
+type TunnelIntrospectionServer interface {
+	// Get tunnels of an agent, connected to this kas instance, and the kas instances the agent is connected to.
+	GetAgentTunnels(context.Context, *GetAgentTunnelsRequest) (*GetAgentTunnelsResponse, error)
+	// List tunnels of all agents, connected to this kas instance.
+	ListAgentTunnels(context.Context, *ListAgentTunnelsRequest) (*ListAgentTunnelsResponse, error)
+	mustEmbedUnimplementedTunnelIntrospectionServer()
+}
+
+// UnimplementedTunnelIntrospectionServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTunnelIntrospectionServer struct{}
+
+func (UnimplementedTunnelIntrospectionServer) GetAgentTunnels(context.Context, *GetAgentTunnelsRequest) (*GetAgentTunnelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAgentTunnels not implemented")
+}
+func (UnimplementedTunnelIntrospectionServer) ListAgentTunnels(context.Context, *ListAgentTunnelsRequest) (*ListAgentTunnelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAgentTunnels not implemented")
+}
+func (UnimplementedTunnelIntrospectionServer) mustEmbedUnimplementedTunnelIntrospectionServer() {}
+func (UnimplementedTunnelIntrospectionServer) testEmbeddedByValue()                             {}
+
+// UnsafeTunnelIntrospectionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TunnelIntrospectionServer will
+// result in compilation errors.
+type UnsafeTunnelIntrospectionServer interface {
+	mustEmbedUnimplementedTunnelIntrospectionServer()
+}
+
+func RegisterTunnelIntrospectionServer(s grpc.ServiceRegistrar, srv TunnelIntrospectionServer) {
+	// If the following call panics, it indicates UnimplementedTunnelIntrospectionServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TunnelIntrospection_ServiceDesc, srv)
+}
+
+func _TunnelIntrospection_GetAgentTunnels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentTunnelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunnelIntrospectionServer).GetAgentTunnels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TunnelIntrospection_GetAgentTunnels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunnelIntrospectionServer).GetAgentTunnels(ctx, req.(*GetAgentTunnelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TunnelIntrospection_ListAgentTunnels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentTunnelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunnelIntrospectionServer).ListAgentTunnels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TunnelIntrospection_ListAgentTunnels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunnelIntrospectionServer).ListAgentTunnels(ctx, req.(*ListAgentTunnelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TunnelIntrospection_ServiceDesc is the grpc.ServiceDesc for TunnelIntrospection service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TunnelIntrospection_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "plural.agent.tunnel_introspection.rpc.TunnelIntrospection",
+	HandlerType: (*TunnelIntrospectionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAgentTunnels",
+			Handler:    _TunnelIntrospection_GetAgentTunnels_Handler,
+		},
+		{
+			MethodName: "ListAgentTunnels",
+			Handler:    _TunnelIntrospection_ListAgentTunnels_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/module/tunnel_introspection/rpc/rpc.proto",
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tunnel_introspection"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tunnel_introspection/rpc"
+)
+
+type Factory struct {
+	Introspector tunnel.Introspector
+	// OwnUrl is the URL of this kas instance, as other kas instances see it.
+	OwnUrl string
+}
+
+func (f *Factory) New(config *modserver.Config) (modserver.Module, error) {
+	rpc.RegisterTunnelIntrospectionServer(config.ApiServer, &server{
+		introspector: f.Introspector,
+		ownUrl:       f.OwnUrl,
+	})
+	return &module{}, nil
+}
+
+func (f *Factory) Name() string {
+	return tunnel_introspection.ModuleName
+}
+
+func (f *Factory) StartStopPhase() modshared.ModuleStartStopPhase {
+	return modshared.ModuleStartBeforeServers
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modshared"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/httpz"
+)
+
+const (
+	// HttpUrlPath is the URL path of the JSON view on the observability endpoint.
+	HttpUrlPath = "/debug/tunnels"
+
+	agentIdQueryParam = "agent_id"
+)
+
+// NewHttpHandler returns a JSON view of the TunnelIntrospection API.
+// It responds with ListAgentTunnelsResponse or, if the agent_id query parameter is set, with GetAgentTunnelsResponse.
+func NewHttpHandler(log *zap.Logger, api modshared.Api, introspector tunnel.Introspector, ownUrl string) http.Handler {
+	return &httpHandler{
+		log: log,
+		api: api,
+		server: &server{
+			introspector: introspector,
+			ownUrl:       ownUrl,
+		},
+	}
+}
+
+type httpHandler struct {
+	log    *zap.Logger
+	api    modshared.Api
+	server *server
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	var resp proto.Message
+	agentIdStr := r.URL.Query().Get(agentIdQueryParam)
+	if agentIdStr == "" {
+		resp = h.server.listAgentTunnels()
+	} else {
+		agentId, err := strconv.ParseInt(agentIdStr, 10, 64)
+		if err != nil || agentId <= 0 {
+			http.Error(w, "invalid agent_id query parameter", http.StatusBadRequest)
+			return
+		}
+		resp, err = h.server.getAgentTunnels(r.Context(), agentId)
+		if err != nil {
+			h.api.HandleProcessingError(r.Context(), h.log, agentId, "KasUrlsByAgentId() failed", err)
+			http.Error(w, "KasUrlsByAgentId() failed", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+	if err != nil {
+		h.api.HandleProcessingError(r.Context(), h.log, modshared.NoAgentId, "Failed to marshal response", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header()[httpz.ContentTypeHeader] = []string{"application/json"}
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tunnel_introspection/rpc"
+)
+
+type server struct {
+	rpc.UnimplementedTunnelIntrospectionServer
+	introspector tunnel.Introspector
+	ownUrl       string
+}
+
+func (s *server) GetAgentTunnels(ctx context.Context, req *rpc.GetAgentTunnelsRequest) (*rpc.GetAgentTunnelsResponse, error) {
+	rpcApi := modserver.RpcApiFromContext(ctx)
+	resp, err := s.getAgentTunnels(ctx, req.AgentId)
+	if err != nil {
+		rpcApi.HandleProcessingError(rpcApi.Log(), req.AgentId, "KasUrlsByAgentId() failed", err)
+		return nil, status.Error(codes.Unavailable, "KasUrlsByAgentId() failed")
+	}
+	return resp, nil
+}
+
+func (s *server) ListAgentTunnels(ctx context.Context, req *rpc.ListAgentTunnelsRequest) (*rpc.ListAgentTunnelsResponse, error) {
+	return s.listAgentTunnels(), nil
+}
+
+func (s *server) getAgentTunnels(ctx context.Context, agentId int64) (*rpc.GetAgentTunnelsResponse, error) {
+	kasUrls, err := s.introspector.KasUrlsByAgentId(ctx, agentId)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.GetAgentTunnelsResponse{
+		KasUrl:       s.ownUrl,
+		Agent:        toAgentTunnels(s.introspector.AgentTunnels(agentId)),
+		AgentKasUrls: kasUrls,
+	}, nil
+}
+
+func (s *server) listAgentTunnels() *rpc.ListAgentTunnelsResponse {
+	infos := s.introspector.AllAgentTunnels()
+	agents := make([]*rpc.AgentTunnels, 0, len(infos))
+	for _, info := range infos {
+		agents = append(agents, toAgentTunnels(info))
+	}
+	return &rpc.ListAgentTunnelsResponse{
+		KasUrl: s.ownUrl,
+		Agents: agents,
+	}
+}
+
+func toAgentTunnels(info tunnel.AgentTunnelsInfo) *rpc.AgentTunnels {
+	res := &rpc.AgentTunnels{
+		AgentId:             info.AgentId,
+		IdleTunnels:         uint32(info.IdleTunnels),
+		ActiveTunnels:       uint32(info.ActiveTunnels),
+		MuxTunnels:          make([]*rpc.MuxTunnel, 0, len(info.MuxTunnels)),
+		PendingFindRequests: uint32(info.PendingFindRequests),
+	}
+	if info.PendingFindRequests > 0 {
+		res.OldestPendingFindRequestAge = durationpb.New(info.OldestPendingFindRequestAge)
+	}
+	for _, mt := range info.MuxTunnels {
+		m := &rpc.MuxTunnel{
+			ActiveStreams: uint32(mt.ActiveStreams),
+			MaxStreams:    uint32(mt.MaxStreams),
+			Draining:      mt.Draining,
+		}
+		if !mt.LastPongAt.IsZero() {
+			m.RoundTripTime = durationpb.New(mt.RoundTripTime)
+			m.LastRoundTripTime = durationpb.New(mt.LastRoundTripTime)
+			m.LastPongAt = timestamppb.New(mt.LastPongAt)
+		}
+		res.MuxTunnels = append(res.MuxTunnels, m)
+	}
+	return res
+}
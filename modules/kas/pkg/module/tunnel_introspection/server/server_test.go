@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/module/modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel"
+	"github.com/pluralsh/kubernetes-agent/pkg/module/tunnel_introspection/rpc"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_modserver"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_reverse_tunnel_tunnel"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/testhelpers"
+)
+
+const (
+	ownUrl = "grpc://127.0.0.1:8155"
+)
+
+var (
+	_ rpc.TunnelIntrospectionServer = &server{}
+	_ http.Handler                  = &httpHandler{}
+
+	pongAt = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+)
+
+func TestGetAgentTunnels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := mock_reverse_tunnel_tunnel.NewMockIntrospector(ctrl)
+	introspector.EXPECT().
+		KasUrlsByAgentId(gomock.Any(), testhelpers.AgentId).
+		Return([]string{ownUrl, "grpc://127.0.0.2:8155"}, nil)
+	introspector.EXPECT().
+		AgentTunnels(testhelpers.AgentId).
+		Return(agentTunnelsInfo())
+	s := &server{
+		introspector: introspector,
+		ownUrl:       ownUrl,
+	}
+	ctx := modserver.InjectRpcApi(context.Background(), mock_modserver.NewMockRpcApi(ctrl))
+	resp, err := s.GetAgentTunnels(ctx, &rpc.GetAgentTunnelsRequest{
+		AgentId: testhelpers.AgentId,
+	})
+	require.NoError(t, err)
+	expected := &rpc.GetAgentTunnelsResponse{
+		KasUrl:       ownUrl,
+		Agent:        agentTunnels(),
+		AgentKasUrls: []string{ownUrl, "grpc://127.0.0.2:8155"},
+	}
+	assert.Empty(t, cmp.Diff(expected, resp, protocmp.Transform()))
+}
+
+func TestGetAgentTunnels_KasUrlsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := mock_reverse_tunnel_tunnel.NewMockIntrospector(ctrl)
+	rpcApi := mock_modserver.NewMockRpcApi(ctrl)
+	err := errors.New("boom")
+	introspector.EXPECT().
+		KasUrlsByAgentId(gomock.Any(), testhelpers.AgentId).
+		Return(nil, err)
+	rpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t))
+	rpcApi.EXPECT().
+		HandleProcessingError(gomock.Any(), testhelpers.AgentId, "KasUrlsByAgentId() failed", err)
+	s := &server{
+		introspector: introspector,
+		ownUrl:       ownUrl,
+	}
+	ctx := modserver.InjectRpcApi(context.Background(), rpcApi)
+	_, err = s.GetAgentTunnels(ctx, &rpc.GetAgentTunnelsRequest{
+		AgentId: testhelpers.AgentId,
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestListAgentTunnels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := mock_reverse_tunnel_tunnel.NewMockIntrospector(ctrl)
+	introspector.EXPECT().
+		AllAgentTunnels().
+		Return([]tunnel.AgentTunnelsInfo{
+			agentTunnelsInfo(),
+			{
+				AgentId:     testhelpers.AgentId + 1,
+				IdleTunnels: 1,
+			},
+		})
+	s := &server{
+		introspector: introspector,
+		ownUrl:       ownUrl,
+	}
+	resp, err := s.ListAgentTunnels(context.Background(), &rpc.ListAgentTunnelsRequest{})
+	require.NoError(t, err)
+	expected := &rpc.ListAgentTunnelsResponse{
+		KasUrl: ownUrl,
+		Agents: []*rpc.AgentTunnels{
+			agentTunnels(),
+			{
+				AgentId:     testhelpers.AgentId + 1,
+				IdleTunnels: 1,
+				MuxTunnels:  []*rpc.MuxTunnel{},
+			},
+		},
+	}
+	assert.Empty(t, cmp.Diff(expected, resp, protocmp.Transform()))
+}
+
+func TestHttpHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := mock_reverse_tunnel_tunnel.NewMockIntrospector(ctrl)
+	introspector.EXPECT().
+		AllAgentTunnels().
+		Return([]tunnel.AgentTunnelsInfo{agentTunnelsInfo()})
+	h := NewHttpHandler(zaptest.NewLogger(t), mock_modserver.NewMockApi(ctrl), introspector, ownUrl)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, HttpUrlPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var resp rpc.ListAgentTunnelsResponse
+	require.NoError(t, protojson.Unmarshal(w.Body.Bytes(), &resp))
+	expected := &rpc.ListAgentTunnelsResponse{
+		KasUrl: ownUrl,
+		Agents: []*rpc.AgentTunnels{agentTunnels()},
+	}
+	assert.Empty(t, cmp.Diff(expected, &resp, protocmp.Transform()))
+}
+
+func TestHttpHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := mock_reverse_tunnel_tunnel.NewMockIntrospector(ctrl)
+	introspector.EXPECT().
+		KasUrlsByAgentId(gomock.Any(), testhelpers.AgentId).
+		Return([]string{ownUrl}, nil)
+	introspector.EXPECT().
+		AgentTunnels(testhelpers.AgentId).
+		Return(agentTunnelsInfo())
+	h := NewHttpHandler(zaptest.NewLogger(t), mock_modserver.NewMockApi(ctrl), introspector, ownUrl)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, HttpUrlPath+"?agent_id=123", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp rpc.GetAgentTunnelsResponse
+	require.NoError(t, protojson.Unmarshal(w.Body.Bytes(), &resp))
+	expected := &rpc.GetAgentTunnelsResponse{
+		KasUrl:       ownUrl,
+		Agent:        agentTunnels(),
+		AgentKasUrls: []string{ownUrl},
+	}
+	assert.Empty(t, cmp.Diff(expected, &resp, protocmp.Transform()))
+}
+
+func TestHttpHandler_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		code   int
+	}{
+		{
+			name:   "invalid agent id",
+			method: http.MethodGet,
+			target: HttpUrlPath + "?agent_id=abc",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "negative agent id",
+			method: http.MethodGet,
+			target: HttpUrlPath + "?agent_id=-1",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "wrong method",
+			method: http.MethodPost,
+			target: HttpUrlPath,
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			h := NewHttpHandler(zaptest.NewLogger(t), mock_modserver.NewMockApi(ctrl), mock_reverse_tunnel_tunnel.NewMockIntrospector(ctrl), ownUrl)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tc.method, tc.target, nil))
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func agentTunnelsInfo() tunnel.AgentTunnelsInfo {
+	return tunnel.AgentTunnelsInfo{
+		AgentId:       testhelpers.AgentId,
+		IdleTunnels:   2,
+		ActiveTunnels: 3,
+		MuxTunnels: []tunnel.MuxTunnelInfo{
+			{
+				ActiveStreams:     5,
+				MaxStreams:        100,
+				RoundTripTime:     20 * time.Millisecond,
+				LastRoundTripTime: 30 * time.Millisecond,
+				LastPongAt:        pongAt,
+			},
+			{
+				// No pong yet
+				MaxStreams: 100,
+				Draining:   true,
+			},
+		},
+		PendingFindRequests:         1,
+		OldestPendingFindRequestAge: time.Second,
+	}
+}
+
+func agentTunnels() *rpc.AgentTunnels {
+	return &rpc.AgentTunnels{
+		AgentId:       testhelpers.AgentId,
+		IdleTunnels:   2,
+		ActiveTunnels: 3,
+		MuxTunnels: []*rpc.MuxTunnel{
+			{
+				ActiveStreams:     5,
+				MaxStreams:        100,
+				RoundTripTime:     durationpb.New(20 * time.Millisecond),
+				LastRoundTripTime: durationpb.New(30 * time.Millisecond),
+				LastPongAt:        timestamppb.New(pongAt),
+			},
+			{
+				MaxStreams: 100,
+				Draining:   true,
+			},
+		},
+		PendingFindRequests:         1,
+		OldestPendingFindRequestAge: durationpb.New(time.Second),
+	}
+}
@@ -32,8 +32,10 @@ type Configuration struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	DefaultNamespace string                 `protobuf:"bytes,1,opt,name=default_namespace,proto3" json:"default_namespace,omitempty"`
 	AccessAs         *agentcfg.CiAccessAsCF `protobuf:"bytes,2,opt,name=access_as,proto3" json:"access_as,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Environments the configuration is limited to. Empty means the configuration applies to jobs in any environment.
+	Environments  []string `protobuf:"bytes,3,rep,name=environments,proto3" json:"environments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Configuration) Reset() {
@@ -80,6 +82,13 @@ func (x *Configuration) GetAccessAs() *agentcfg.CiAccessAsCF {
 	return nil
 }
 
+func (x *Configuration) GetEnvironments() []string {
+	if x != nil {
+		return x.Environments
+	}
+	return nil
+}
+
 type AllowedAgent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1090,10 +1099,11 @@ var File_pkg_plural_api_api_proto protoreflect.FileDescriptor
 
 const file_pkg_plural_api_api_proto_rawDesc = "" +
 	"\n" +
-	"\x18pkg/plural/api/api.proto\x12\x17plural.agent.plural.api\x1a\x1bpkg/agentcfg/agentcfg.proto\x1a\x17validate/validate.proto\"\x80\x01\n" +
+	"\x18pkg/plural/api/api.proto\x12\x17plural.agent.plural.api\x1a\x1bpkg/agentcfg/agentcfg.proto\x1a\x17validate/validate.proto\"\xa4\x01\n" +
 	"\rConfiguration\x12,\n" +
 	"\x11default_namespace\x18\x01 \x01(\tR\x11default_namespace\x12A\n" +
-	"\taccess_as\x18\x02 \x01(\v2#.plural.agent.agentcfg.CiAccessAsCFR\taccess_as\"\xc6\x01\n" +
+	"\taccess_as\x18\x02 \x01(\v2#.plural.agent.agentcfg.CiAccessAsCFR\taccess_as\x12\"\n" +
+	"\fenvironments\x18\x03 \x03(\tR\fenvironments\"\xc6\x01\n" +
 	"\fAllowedAgent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12X\n" +
 	"\x0econfig_project\x18\x02 \x01(\v2&.plural.agent.plural.api.ConfigProjectB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x0econfig_project\x12L\n" +
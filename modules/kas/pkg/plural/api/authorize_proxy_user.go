@@ -3,14 +3,11 @@ package api
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	console "github.com/pluralsh/console/go/client"
 	"github.com/pluralsh/polly/algorithms"
-	"go.uber.org/zap"
 
 	"github.com/pluralsh/kubernetes-agent/pkg/plural"
-	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
 )
 
 func AuthorizeProxyUser(ctx context.Context, token, clusterId, pluralURL string) (*AuthorizeProxyUserResponse, error) {
@@ -40,20 +37,3 @@ func AuthorizeProxyUser(ctx context.Context, token, clusterId, pluralURL string)
 		},
 	}, nil
 }
-
-func CreateAuditLogInBackground(log *zap.Logger, agentId int64, r *http.Request, token, clusterId, pluralURL string) {
-	go func() {
-		log = log.With(logz.AgentId(agentId))
-
-		client := plural.New(pluralURL, token)
-		_, err := client.Console.AddClusterAuditLog(context.Background(), console.ClusterAuditAttributes{
-			ClusterID: clusterId,
-			Method:    r.Method,
-			Path:      r.URL.Path,
-		})
-
-		if err != nil {
-			log.Error("failed to create audit log", logz.Error(err))
-		}
-	}()
-}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+	"github.com/pluralsh/kubernetes-agent/pkg/plural"
+)
+
+const (
+	AllowedAgentsForJobOperation = "AllowedAgentsForJob"
+
+	allowedAgentsForJobDocument = `query AllowedAgentsForJob {
+	allowedAgentsForJob
+}`
+)
+
+type allowedAgentsForJobResponse struct {
+	// AllowedAgentsForJob is an object in the AllowedAgentsForJob JSON format.
+	AllowedAgentsForJob json.RawMessage `json:"allowedAgentsForJob"`
+}
+
+// GetAllowedAgentsForJob returns the agents the CI job can access and how, as configured in the ci_access section
+// of the agents' configuration. The job token is used to authenticate with Plural Console.
+// The allowedAgentsForJob query is not part of the Console schema the generated client is built from, so it is only
+// available with a Plural Console that serves it. Other Consoles fail the query with a GraphQL error.
+// Requests that Plural Console has rejected fail with a *gitlab.ClientError.
+func GetAllowedAgentsForJob(ctx context.Context, jobToken, pluralURL string) (*AllowedAgentsForJob, error) {
+	client := plural.New(pluralURL, jobToken)
+	var res allowedAgentsForJobResponse
+	// The generated client has no query for allowed agents.
+	err := client.Query(ctx, AllowedAgentsForJobOperation, allowedAgentsForJobDocument, &res, nil)
+	if err != nil {
+		return nil, toClientError(err, AllowedAgentsForJobOperation)
+	}
+	if len(res.AllowedAgentsForJob) == 0 || string(res.AllowedAgentsForJob) == "null" {
+		// Plural Console does not know the job.
+		return nil, &gitlab2.ClientError{
+			StatusCode: http.StatusNotFound,
+			Path:       AllowedAgentsForJobOperation,
+		}
+	}
+	aa := &AllowedAgentsForJob{}
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(res.AllowedAgentsForJob, aa)
+	if err != nil {
+		return nil, fmt.Errorf("allowed agents for job: %w", err)
+	}
+	err = aa.ValidateAll()
+	if err != nil {
+		return nil, fmt.Errorf("allowed agents for job: %w", err)
+	}
+	return aa, nil
+}
+
+// toClientError converts HTTP 4xx responses of Plural Console into a *gitlab.ClientError.
+func toClientError(err error, operation string) error {
+	var e *clientv2.ErrorResponse
+	if errors.As(err, &e) && e.NetworkError != nil && e.NetworkError.Code >= 400 && e.NetworkError.Code < 500 {
+		return &gitlab2.ClientError{
+			StatusCode: int32(e.NetworkError.Code), // nolint: gosec
+			Path:       operation,
+		}
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/agentcfg"
+	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+)
+
+const (
+	testJobToken = "job-token"
+
+	testAllowedAgentsForJob = `{
+		"allowed_agents": [{
+			"id": 123,
+			"config_project": {"id": 5},
+			"configuration": {
+				"default_namespace": "ns1",
+				"access_as": {"ci_job": {}},
+				"environments": ["prod"]
+			}
+		}],
+		"job": {"id": 1},
+		"pipeline": {"id": 2},
+		"project": {"id": 3, "groups": [{"id": 6}]},
+		"user": {"id": "u1", "username": "user1", "email": "user1@example.com"},
+		"environment": {"slug": "prod", "tier": "production"},
+		"unknown": "is ignored"
+	}`
+)
+
+func TestGetAllowedAgentsForJob(t *testing.T) {
+	url := fakeAllowedAgentsConsole(t, http.StatusOK, testAllowedAgentsForJob)
+
+	aa, err := GetAllowedAgentsForJob(context.Background(), testJobToken, url)
+	require.NoError(t, err)
+	require.Len(t, aa.AllowedAgents, 1)
+	agent := aa.AllowedAgents[0]
+	assert.EqualValues(t, 123, agent.Id)
+	assert.EqualValues(t, 5, agent.ConfigProject.Id)
+	assert.Equal(t, "ns1", agent.Configuration.DefaultNamespace)
+	assert.IsType(t, &agentcfg.CiAccessAsCF_CiJob{}, agent.Configuration.AccessAs.As)
+	assert.Equal(t, []string{"prod"}, agent.Configuration.Environments)
+	assert.EqualValues(t, 1, aa.Job.Id)
+	assert.Equal(t, "user1", aa.User.Username)
+	assert.Equal(t, "production", aa.Environment.Tier)
+}
+
+func TestGetAllowedAgentsForJob_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		result string
+		check  func(error) bool
+	}{
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			result: `null`,
+			check:  gitlab2.IsUnauthorized,
+		},
+		{
+			name:   "forbidden",
+			status: http.StatusForbidden,
+			result: `null`,
+			check:  gitlab2.IsForbidden,
+		},
+		{
+			name:   "unknown job",
+			status: http.StatusOK,
+			result: `null`,
+			check:  gitlab2.IsNotFound,
+		},
+		{
+			name:   "server error",
+			status: http.StatusBadGateway,
+			result: `null`,
+			check: func(err error) bool {
+				var e *gitlab2.ClientError
+				return !errors.As(err, &e)
+			},
+		},
+		{
+			name:   "invalid response",
+			status: http.StatusOK,
+			result: `{"allowed_agents": []}`, // job is required
+			check: func(err error) bool {
+				var e *gitlab2.ClientError
+				return !errors.As(err, &e)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			url := fakeAllowedAgentsConsole(t, tc.status, tc.result)
+			_, err := GetAllowedAgentsForJob(context.Background(), testJobToken, url)
+			require.Error(t, err)
+			assert.True(t, tc.check(err), err)
+		})
+	}
+}
+
+// fakeAllowedAgentsConsole starts a Plural Console that responds to the job token with the given status and result.
+func fakeAllowedAgentsConsole(t *testing.T, status int, result string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "Token "+testJobToken, r.Header.Get("Authorization")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":{"allowedAgentsForJob":` + result + `}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
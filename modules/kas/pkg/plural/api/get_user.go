@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+	"github.com/pluralsh/kubernetes-agent/pkg/plural"
+)
+
+const (
+	GetUserOperation = "Me"
+)
+
+// GetUser returns the user the access token belongs to.
+// Tokens that Plural Console has rejected fail with a *gitlab.ClientError.
+func GetUser(ctx context.Context, token, pluralURL string) (*User, error) {
+	client := plural.New(pluralURL, token)
+	resp, err := client.Console.Me(ctx)
+	if err != nil {
+		return nil, toClientError(err, GetUserOperation)
+	}
+	if resp.Me == nil {
+		return nil, &gitlab2.ClientError{
+			StatusCode: http.StatusUnauthorized,
+			Path:       GetUserOperation,
+		}
+	}
+	return &User{
+		Id:       resp.Me.ID,
+		Username: resp.Me.Email,
+		Email:    resp.Me.Email,
+	}, nil
+}
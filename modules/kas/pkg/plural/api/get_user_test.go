@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gitlab2 "github.com/pluralsh/kubernetes-agent/pkg/gitlab"
+)
+
+const (
+	testAccessToken = "access-token"
+)
+
+func TestGetUser(t *testing.T) {
+	url := fakeMeConsole(t, http.StatusOK, `{"id": "u1", "email": "user1@example.com", "name": "User 1"}`)
+
+	user, err := GetUser(context.Background(), testAccessToken, url)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", user.Id)
+	assert.Equal(t, "user1@example.com", user.Username)
+	assert.Equal(t, "user1@example.com", user.Email)
+}
+
+func TestGetUser_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		result string
+	}{
+		{
+			name:   "invalid token",
+			status: http.StatusUnauthorized,
+			result: `null`,
+		},
+		{
+			name:   "no user",
+			status: http.StatusOK,
+			result: `null`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			url := fakeMeConsole(t, tc.status, tc.result)
+			_, err := GetUser(context.Background(), testAccessToken, url)
+			require.Error(t, err)
+			assert.True(t, gitlab2.IsUnauthorized(err), err)
+		})
+	}
+}
+
+// fakeMeConsole starts a Plural Console that responds to the access token with the given status and user.
+func fakeMeConsole(t *testing.T, status int, result string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "Token "+testAccessToken, r.Header.Get("Authorization")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":{"me":` + result + `}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
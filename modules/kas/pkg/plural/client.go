@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/Yamashou/gqlgenc/clientv2"
 	console "github.com/pluralsh/console/go/client"
 )
 
@@ -13,13 +14,28 @@ type authedTransport struct {
 }
 
 func (t *authedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", "Token "+t.token)
+	setToken(req, t.token)
 	return t.wrapped.RoundTrip(req)
 }
 
+// WithToken authenticates a single request of a client made with NewUnauthorized.
+// It allows using one client for requests on behalf of many users.
+func WithToken(token string) clientv2.RequestInterceptor {
+	return func(ctx context.Context, req *http.Request, gqlInfo *clientv2.GQLRequestInfo, res any, next clientv2.RequestInterceptorFunc) error {
+		setToken(req, token)
+		return next(ctx, req, gqlInfo, res)
+	}
+}
+
+func setToken(req *http.Request, token string) {
+	req.Header.Set("Authorization", "Token "+token)
+}
+
 type Client struct {
 	ctx     context.Context
 	Console console.ConsoleClient
+	// graphql is the GraphQL client of Console. Used for queries that Console has no generated method for.
+	graphql *clientv2.Client
 }
 
 func New(url, token string) *Client {
@@ -30,15 +46,23 @@ func New(url, token string) *Client {
 		},
 	}
 
-	return &Client{
-		Console: console.NewClient(&httpClient, url, nil),
-		ctx:     context.Background(),
-	}
+	return newClient(&httpClient, url)
 }
 
 func NewUnauthorized(url string) *Client {
+	return newClient(http.DefaultClient, url)
+}
+
+func newClient(httpClient clientv2.HttpClient, url string) *Client {
+	graphql := clientv2.NewClient(httpClient, url, nil)
 	return &Client{
-		Console: console.NewClient(http.DefaultClient, url, nil),
+		Console: &console.Client{Client: graphql},
+		graphql: graphql,
 		ctx:     context.Background(),
 	}
 }
+
+// Query sends a query that the generated Console client has no method for and unmarshals its data into respData.
+func (c *Client) Query(ctx context.Context, operationName, query string, respData any, vars map[string]any, interceptors ...clientv2.RequestInterceptor) error {
+	return c.graphql.Post(ctx, operationName, query, respData, vars, interceptors...)
+}
@@ -0,0 +1,52 @@
+package plural
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/api"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+)
+
+// AgentConfigurationMetadataKey is the key of the agent configuration in the metadata of a cluster in Plural Console.
+// The value is either a YAML document or an object.
+const AgentConfigurationMetadataKey = "agent_config"
+
+const getAgentConfigurationDocument = `query AgentConfiguration {
+	myCluster {
+		id
+		metadata
+	}
+}`
+
+type getAgentConfigurationResponse struct {
+	MyCluster *struct {
+		ID       string         `json:"id"`
+		Metadata map[string]any `json:"metadata"`
+	} `json:"myCluster"`
+}
+
+// GetAgentConfiguration returns the agent configuration file that is kept in the metadata of the agent's cluster.
+// Returns nil if there is no configuration.
+func GetAgentConfiguration(ctx context.Context, agentToken api.AgentToken, pluralURL string) ([]byte, error) {
+	client := New(pluralURL, string(agentToken))
+	var res getAgentConfigurationResponse
+	// The generated client has no query for cluster metadata.
+	err := client.Query(ctx, "AgentConfiguration", getAgentConfigurationDocument, &res, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.MyCluster == nil {
+		return nil, nil
+	}
+	switch config := res.MyCluster.Metadata[AgentConfigurationMetadataKey].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(config), nil
+	case map[string]any:
+		return json.Marshal(config)
+	default:
+		return nil, errz.NewUserErrorf("cluster metadata: %s must be a string or an object, got %T", AgentConfigurationMetadataKey, config)
+	}
+}
@@ -0,0 +1,78 @@
+package plural
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+)
+
+func TestGetAgentConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		expected string
+	}{
+		{
+			name:     "no metadata",
+			metadata: `null`,
+		},
+		{
+			name:     "no configuration",
+			metadata: `{"other": "value"}`,
+		},
+		{
+			name:     "YAML",
+			metadata: `{"agent_config": "observability:\n  logging:\n    level: debug\n"}`,
+			expected: "observability:\n  logging:\n    level: debug\n",
+		},
+		{
+			name:     "object",
+			metadata: `{"agent_config": {"observability": {"logging": {"level": "debug"}}}}`,
+			expected: `{"observability":{"logging":{"level":"debug"}}}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			url := fakeConsole(t, tc.metadata)
+			data, err := GetAgentConfiguration(context.Background(), "token", url)
+			require.NoError(t, err)
+			if tc.expected == "" {
+				assert.Nil(t, data)
+			} else {
+				assert.Equal(t, tc.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestGetAgentConfiguration_InvalidType(t *testing.T) {
+	url := fakeConsole(t, `{"agent_config": 42}`)
+	_, err := GetAgentConfiguration(context.Background(), "token", url)
+	assert.ErrorAs(t, err, &errz.UserError{})
+}
+
+func TestGetAgentConfiguration_Unauthorized(t *testing.T) {
+	url := fakeConsole(t, `null`)
+	_, err := GetAgentConfiguration(context.Background(), "invalid", url)
+	assert.Error(t, err)
+}
+
+// fakeConsole starts a Plural Console that accepts the "token" token and returns a cluster with the given metadata.
+func fakeConsole(t *testing.T, metadata string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"myCluster":{"id":"abc","metadata":` + metadata + `}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
@@ -0,0 +1,127 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	castVersion       = 2
+	castDefaultWidth  = 80
+	castDefaultHeight = 24
+
+	eventOutput = "o"
+	eventInput  = "i"
+	eventResize = "r"
+)
+
+// castHeader is the header line of an asciinema v2 recording.
+// See https://docs.asciinema.org/manual/asciicast/v2/
+type castHeader struct {
+	Version   int    `json:"version"`
+	Width     uint16 `json:"width"`
+	Height    uint16 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Command   string `json:"command,omitempty"`
+	Title     string `json:"title"`
+	// Metadata is not part of the format. Players ignore it.
+	Metadata *Metadata `json:"metadata"`
+}
+
+// castWriter writes an asciinema v2 recording.
+// The header is written with the first event so that the initial terminal size can be taken from
+// a resize that is sent before any output.
+type castWriter struct {
+	mu            sync.Mutex
+	w             *bufio.Writer
+	start         time.Time
+	header        castHeader
+	headerWritten bool
+	err           error
+}
+
+func newCastWriter(w io.Writer, md *Metadata) *castWriter {
+	return &castWriter{
+		w:     bufio.NewWriter(w),
+		start: md.Time,
+		header: castHeader{
+			Version:   castVersion,
+			Width:     castDefaultWidth,
+			Height:    castDefaultHeight,
+			Timestamp: md.Time.Unix(),
+			Command:   strings.Join(md.Command, " "),
+			Title:     fmt.Sprintf("%s/%s", md.Namespace, md.Pod),
+			Metadata:  md,
+		},
+	}
+}
+
+func (c *castWriter) event(t time.Time, code string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeHeader()
+	// Invalid UTF-8 is replaced with U+FFFD by the encoder.
+	c.writeLine([]any{c.offset(t), code, string(data)})
+}
+
+func (c *castWriter) resize(t time.Time, width, height uint16) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.headerWritten {
+		c.header.Width = width
+		c.header.Height = height
+		c.writeHeader()
+		return
+	}
+	c.writeLine([]any{c.offset(t), eventResize, fmt.Sprintf("%dx%d", width, height)})
+}
+
+// close writes the header if nothing has been recorded and flushes buffered data.
+// It returns the first error that happened while writing.
+func (c *castWriter) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeHeader()
+	if c.err == nil {
+		c.err = c.w.Flush()
+	}
+	return c.err
+}
+
+// error returns the first error that happened while writing.
+func (c *castWriter) error() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// offset returns seconds since the start of the session, with microsecond precision.
+func (c *castWriter) offset(t time.Time) float64 {
+	return float64(t.Sub(c.start).Microseconds()) / 1e6
+}
+
+func (c *castWriter) writeHeader() {
+	if c.headerWritten {
+		return
+	}
+	c.headerWritten = true
+	c.writeLine(&c.header)
+}
+
+func (c *castWriter) writeLine(v any) {
+	if c.err != nil {
+		return
+	}
+	line, err := json.Marshal(v)
+	if err != nil {
+		c.err = err
+		return
+	}
+	line = append(line, '\n')
+	_, c.err = c.w.Write(line)
+}
@@ -0,0 +1,48 @@
+package recording
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileSink writes each recording to a new .cast file in a directory.
+type FileSink struct {
+	dir string
+}
+
+// NewFileSink creates the directory if needed.
+func NewFileSink(dir string) (*FileSink, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{
+		dir: dir,
+	}, nil
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Create(md *Metadata) (io.WriteCloser, error) {
+	name := fmt.Sprintf("%s_%s_%s_%s.cast",
+		md.Time.UTC().Format("20060102T150405.000000000Z"),
+		fileNameSafe(md.ClusterId), fileNameSafe(md.Namespace), fileNameSafe(md.Pod))
+	return os.OpenFile(filepath.Join(s.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) // nolint: gosec
+}
+
+// fileNameSafe replaces characters that are not safe to use in a file name.
+func fileNameSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
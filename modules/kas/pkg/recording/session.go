@@ -0,0 +1,251 @@
+package recording
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Channels of the Kubernetes remote command streaming protocols.
+// See https://github.com/kubernetes/kubernetes/blob/master/staging/src/k8s.io/apimachinery/pkg/util/remotecommand/constants.go
+const (
+	channelStdin  byte = 0
+	channelStdout byte = 1
+	channelStderr byte = 2
+	channelError  byte = 3
+	channelResize byte = 4
+)
+
+// Protocol is the streaming protocol of an upgraded exec or attach connection.
+type Protocol int
+
+const (
+	ProtocolUnknown Protocol = iota
+	// ProtocolSpdy is SPDY/3.1 with a stream per channel.
+	ProtocolSpdy
+	// ProtocolWebSocket is WebSocket with binary messages prefixed with a channel byte, e.g. v5.channel.k8s.io.
+	ProtocolWebSocket
+	// ProtocolWebSocketBase64 is WebSocket with base64-encoded text messages prefixed with an ASCII channel digit,
+	// e.g. v4.base64.channel.k8s.io.
+	ProtocolWebSocketBase64
+)
+
+// ProtocolFromResponseHeader determines the protocol from the header of the response that accepted a connection upgrade.
+func ProtocolFromResponseHeader(header http.Header) Protocol {
+	upgrade := strings.ToLower(header.Get("Upgrade"))
+	switch {
+	case strings.HasPrefix(upgrade, "spdy/"):
+		return ProtocolSpdy
+	case upgrade == "websocket":
+		if strings.Contains(header.Get("Sec-WebSocket-Protocol"), "base64.") {
+			return ProtocolWebSocketBase64
+		}
+		return ProtocolWebSocket
+	default:
+		return ProtocolUnknown
+	}
+}
+
+// Metadata describes a recorded session.
+type Metadata struct {
+	// Time is when the session started.
+	Time time.Time `json:"-"`
+	// ClusterId is the Plural cluster id the session is in.
+	ClusterId string `json:"cluster_id"`
+	// User is the impersonated user. Empty if the session uses agent's own identity.
+	User      string `json:"user,omitempty"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container,omitempty"`
+	// Command is the executed command. Empty for attach sessions.
+	Command []string `json:"command,omitempty"`
+}
+
+// Sink stores recordings.
+type Sink interface {
+	// Name returns sink's name. It is used in logs.
+	Name() string
+	// Create starts a new recording. The asciinema v2 stream of the session is written to the returned writer.
+	Create(md *Metadata) (io.WriteCloser, error)
+}
+
+const (
+	// sessionQueueSize is the number of chunks of connection data that may wait to be recorded.
+	sessionQueueSize = 256
+)
+
+var (
+	ErrUnknownProtocol = errors.New("unknown protocol of upgraded connection")
+	errFallingBehind   = errors.New("session recording is falling behind")
+	errNotStarted      = errors.New("session recording has not been started")
+)
+
+// Session records an exec or attach session.
+// It observes raw data of the upgraded connection and writes stdin, stdout, stderr and terminal resizes
+// to the recording. Data is decoded and written to the sink on a separate goroutine so that a slow sink
+// doesn't slow down the connection. If recording can't keep up or fails, observing data returns an error
+// and the connection must be closed so that no activity goes unrecorded.
+type Session struct {
+	out  io.WriteCloser
+	cast *castWriter
+	now  func() time.Time
+
+	mu       sync.Mutex // protects fields below
+	queue    chan chunk
+	done     chan struct{}
+	err      error
+	inbound  decoder
+	outbound decoder
+}
+
+// chunk is data of one direction of the connection.
+type chunk struct {
+	inbound bool
+	data    []byte
+}
+
+// decoder decodes a stream of one direction of a connection. It calls emit for each received channel message.
+type decoder interface {
+	// write decodes data. It returns an error if data cannot be decoded.
+	write(data []byte) error
+	// close releases resources. It's called after the last write.
+	close()
+}
+
+// NewSession creates a recording in the sink.
+func NewSession(sink Sink, md *Metadata) (*Session, error) {
+	out, err := sink.Create(md)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		out:  out,
+		cast: newCastWriter(out, md),
+		now:  time.Now,
+	}, nil
+}
+
+// Start starts decoding data of the upgraded connection that uses protocol p.
+// It returns ErrUnknownProtocol if the protocol cannot be recorded.
+func (s *Session) Start(p Protocol) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done != nil {
+		return nil
+	}
+	switch p {
+	case ProtocolSpdy:
+		s.inbound, s.outbound = newSpdyDecoders(s.handle)
+	case ProtocolWebSocket, ProtocolWebSocketBase64:
+		b64 := p == ProtocolWebSocketBase64
+		s.inbound = newWebSocketDecoder(b64, s.handle)
+		s.outbound = newWebSocketDecoder(b64, s.handle)
+	default:
+		return ErrUnknownProtocol
+	}
+	s.queue = make(chan chunk, sessionQueueSize)
+	s.done = make(chan struct{})
+	go s.run(s.queue, s.done, s.inbound, s.outbound)
+	return nil
+}
+
+// InboundData queues data sent by the client for recording.
+func (s *Session) InboundData(data []byte) error {
+	return s.enqueue(true, data)
+}
+
+// OutboundData queues data sent by the server for recording.
+func (s *Session) OutboundData(data []byte) error {
+	return s.enqueue(false, data)
+}
+
+// Close finishes the recording. It must be called after the connection is closed.
+// It returns an error if some data has not been recorded.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	queue, done := s.queue, s.done
+	s.queue = nil
+	s.mu.Unlock()
+	if queue != nil {
+		close(queue)
+		<-done
+		s.inbound.close()
+		s.outbound.close()
+	}
+	s.mu.Lock()
+	err := s.err
+	s.mu.Unlock()
+	return errors.Join(err, s.cast.close(), s.out.Close())
+}
+
+func (s *Session) enqueue(inbound bool, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.cast.error(); err != nil {
+		s.err = err
+		return err
+	}
+	if s.queue == nil {
+		return errNotStarted
+	}
+	select {
+	case s.queue <- chunk{inbound: inbound, data: append([]byte(nil), data...)}:
+		return nil
+	default:
+		s.err = errFallingBehind
+		return s.err
+	}
+}
+
+// run decodes queued data until the queue is closed.
+func (s *Session) run(queue <-chan chunk, done chan<- struct{}, inbound, outbound decoder) {
+	defer close(done)
+	failed := false
+	for c := range queue {
+		if failed {
+			continue // drain the queue
+		}
+		d := outbound
+		if c.inbound {
+			d = inbound
+		}
+		if err := d.write(c.data); err != nil {
+			s.setError(err)
+			failed = true
+		}
+	}
+}
+
+func (s *Session) setError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// handle is called concurrently by inbound and outbound decoders.
+func (s *Session) handle(channel byte, data []byte) {
+	switch channel {
+	case channelStdin:
+		s.cast.event(s.now(), eventInput, data)
+	case channelStdout, channelStderr:
+		s.cast.event(s.now(), eventOutput, data)
+	case channelResize:
+		var size struct { // k8s.io/client-go/tools/remotecommand.TerminalSize
+			Width  uint16
+			Height uint16
+		}
+		if json.Unmarshal(data, &size) != nil {
+			return
+		}
+		s.cast.resize(s.now(), size.Width, size.Height)
+	}
+}
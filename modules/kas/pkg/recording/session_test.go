@@ -0,0 +1,185 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolFromResponseHeader(t *testing.T) {
+	tests := []struct {
+		header   http.Header
+		protocol Protocol
+	}{
+		{
+			header:   http.Header{"Upgrade": []string{"SPDY/3.1"}, "X-Stream-Protocol-Version": []string{"v4.channel.k8s.io"}},
+			protocol: ProtocolSpdy,
+		},
+		{
+			header:   http.Header{"Upgrade": []string{"websocket"}, "Sec-Websocket-Protocol": []string{"v5.channel.k8s.io"}},
+			protocol: ProtocolWebSocket,
+		},
+		{
+			header:   http.Header{"Upgrade": []string{"websocket"}, "Sec-Websocket-Protocol": []string{"v4.base64.channel.k8s.io"}},
+			protocol: ProtocolWebSocketBase64,
+		},
+		{
+			header:   http.Header{"Upgrade": []string{"h2c"}},
+			protocol: ProtocolUnknown,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.header.Get("Upgrade"), func(t *testing.T) {
+			assert.Equal(t, tc.protocol, ProtocolFromResponseHeader(tc.header))
+		})
+	}
+}
+
+func TestSession_WebSocket(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewFileSink(filepath.Join(dir, "recordings"))
+	require.NoError(t, err)
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	md := &Metadata{
+		Time:      start,
+		ClusterId: "c1/../x",
+		User:      "user1",
+		Namespace: "ns1",
+		Pod:       "pod1",
+		Container: "app",
+		Command:   []string{"sh", "-i"},
+	}
+	s, err := NewSession(sink, md)
+	require.NoError(t, err)
+	offset := time.Duration(0)
+	s.now = func() time.Time {
+		offset += 500 * time.Millisecond
+		return start.Add(offset)
+	}
+
+	assert.ErrorIs(t, s.InboundData(wsFrame(true, wsOpBinary, true, []byte{channelStdin, 'x'})), errNotStarted)
+	require.NoError(t, s.Start(ProtocolWebSocket))
+	require.NoError(t, s.InboundData(wsFrame(true, wsOpBinary, true, append([]byte{channelResize}, `{"Width":100,"Height":30}`...))))
+	require.NoError(t, s.InboundData(wsFrame(true, wsOpBinary, true, []byte{channelStdin, 'l', 's', '\n'})))
+	require.NoError(t, s.OutboundData(wsFrame(true, wsOpBinary, false, []byte{channelStdout, 'o', 'k'})))
+	require.NoError(t, s.OutboundData(wsFrame(true, wsOpBinary, false, []byte{channelError, '{', '}'})))
+	require.NoError(t, s.InboundData(wsFrame(true, wsOpBinary, true, append([]byte{channelResize}, `{"Width":80,"Height":20}`...))))
+	require.NoError(t, s.Close())
+
+	files, err := os.ReadDir(filepath.Join(dir, "recordings"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "20240102T030405.000000000Z_c1_.._x_ns1_pod1.cast", files[0].Name())
+	data, err := os.ReadFile(filepath.Join(dir, "recordings", files[0].Name()))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 4)
+	var header map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, map[string]any{
+		"version":   2.0,
+		"width":     100.0,
+		"height":    30.0,
+		"timestamp": float64(start.Unix()),
+		"command":   "sh -i",
+		"title":     "ns1/pod1",
+		"metadata": map[string]any{
+			"cluster_id": "c1/../x",
+			"user":       "user1",
+			"namespace":  "ns1",
+			"pod":        "pod1",
+			"container":  "app",
+			"command":    []any{"sh", "-i"},
+		},
+	}, header)
+	assert.Equal(t, `[1,"i","ls\n"]`, lines[1])
+	assert.Equal(t, `[1.5,"o","ok"]`, lines[2])
+	assert.Equal(t, `[2,"r","80x20"]`, lines[3])
+}
+
+func TestSession_NothingRecorded(t *testing.T) {
+	sink, err := NewFileSink(t.TempDir())
+	require.NoError(t, err)
+	s, err := NewSession(sink, &Metadata{Time: time.Unix(1, 0), Namespace: "ns1", Pod: "pod1"})
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	files, err := os.ReadDir(sink.dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(filepath.Join(sink.dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":2,"width":80,"height":24,"timestamp":1,"title":"ns1/pod1","metadata":{"cluster_id":"","namespace":"ns1","pod":"pod1"}}`+"\n", string(data))
+}
+
+func TestSession_UnknownProtocol(t *testing.T) {
+	sink, err := NewFileSink(t.TempDir())
+	require.NoError(t, err)
+	s, err := NewSession(sink, &Metadata{Time: time.Unix(1, 0), Namespace: "ns1", Pod: "pod1"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Start(ProtocolUnknown), ErrUnknownProtocol)
+	require.NoError(t, s.Close())
+}
+
+func TestSession_FallingBehind(t *testing.T) {
+	out := &blockingWriteCloser{unblock: make(chan struct{})}
+	s, err := NewSession(sinkFunc(func(md *Metadata) (io.WriteCloser, error) {
+		return out, nil
+	}), &Metadata{Time: time.Unix(1, 0), Namespace: "ns1", Pod: "pod1"})
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ProtocolWebSocket))
+	// Big enough to not fit into the buffer of the cast writer so that the recording goroutine blocks on the sink.
+	frame := wsFrame(true, wsOpBinary, false, append([]byte{channelStdout}, bytes.Repeat([]byte{'x'}, 10*1024)...))
+	for range sessionQueueSize + 2 {
+		if err = s.OutboundData(frame); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, errFallingBehind)
+	assert.ErrorIs(t, s.OutboundData(frame), errFallingBehind) // stays failed
+	close(out.unblock)
+	assert.ErrorIs(t, s.Close(), errFallingBehind)
+}
+
+func TestSession_InvalidData(t *testing.T) {
+	sink, err := NewFileSink(t.TempDir())
+	require.NoError(t, err)
+	s, err := NewSession(sink, &Metadata{Time: time.Unix(1, 0), Namespace: "ns1", Pod: "pod1"})
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ProtocolWebSocket))
+	require.NoError(t, s.InboundData([]byte{0x80 | wsOpBinary, 127, 0xff, 0, 0, 0, 0, 0, 0, 0}))
+	assert.ErrorIs(t, s.Close(), errWebSocketInvalidFrame)
+}
+
+type sinkFunc func(md *Metadata) (io.WriteCloser, error)
+
+func (f sinkFunc) Name() string {
+	return "func"
+}
+
+func (f sinkFunc) Create(md *Metadata) (io.WriteCloser, error) {
+	return f(md)
+}
+
+// blockingWriteCloser blocks writes until unblock is closed.
+type blockingWriteCloser struct {
+	unblock chan struct{}
+}
+
+func (w *blockingWriteCloser) Write(p []byte) (int, error) {
+	<-w.unblock
+	return len(p), nil
+}
+
+func (w *blockingWriteCloser) Close() error {
+	return nil
+}
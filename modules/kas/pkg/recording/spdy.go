@@ -0,0 +1,106 @@
+package recording
+
+import (
+	"io"
+	"sync"
+
+	"github.com/moby/spdystream/spdy"
+)
+
+const (
+	spdyStreamTypeHeader = "streamType"
+)
+
+var (
+	// spdyStreamTypes maps stream types of the Kubernetes SPDY protocol to channels.
+	spdyStreamTypes = map[string]byte{
+		"stdin":  channelStdin,
+		"stdout": channelStdout,
+		"stderr": channelStderr,
+		"error":  channelError,
+		"resize": channelResize,
+	}
+)
+
+// spdyStreams maps stream ids to channels. Streams are created by the client and data frames
+// of both directions refer to them.
+type spdyStreams struct {
+	mu       sync.Mutex
+	channels map[spdy.StreamId]byte
+}
+
+func (s *spdyStreams) set(id spdy.StreamId, channel byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channels[id] = channel
+}
+
+func (s *spdyStreams) get(id spdy.StreamId) (byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, ok := s.channels[id]
+	return channel, ok
+}
+
+// spdyDecoder decodes SPDY/3.1 frames. spdy.Framer reads from an io.Reader, so data is passed to it via a pipe.
+// Each direction of a connection has its own header compression context and needs its own decoder.
+type spdyDecoder struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+}
+
+func newSpdyDecoders(emit func(channel byte, data []byte)) (*spdyDecoder, *spdyDecoder) {
+	streams := &spdyStreams{
+		channels: map[spdy.StreamId]byte{},
+	}
+	return newSpdyDecoder(streams, emit), newSpdyDecoder(streams, emit)
+}
+
+func newSpdyDecoder(streams *spdyStreams, emit func(channel byte, data []byte)) *spdyDecoder {
+	pr, pw := io.Pipe()
+	d := &spdyDecoder{
+		pw:   pw,
+		done: make(chan struct{}),
+	}
+	go d.run(pr, streams, emit)
+	return d
+}
+
+func (d *spdyDecoder) run(pr *io.PipeReader, streams *spdyStreams, emit func(channel byte, data []byte)) {
+	defer close(d.done)
+	framer, err := spdy.NewFramer(io.Discard, pr)
+	if err != nil {
+		_ = pr.CloseWithError(err)
+		return
+	}
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			// Unblocks and fails pending and future writes.
+			_ = pr.CloseWithError(err)
+			return
+		}
+		switch f := frame.(type) {
+		case *spdy.SynStreamFrame:
+			if channel, ok := spdyStreamTypes[f.Headers.Get(spdyStreamTypeHeader)]; ok {
+				streams.set(f.StreamId, channel)
+			}
+		case *spdy.DataFrame:
+			if channel, ok := streams.get(f.StreamId); ok && len(f.Data) > 0 {
+				emit(channel, f.Data)
+			}
+		}
+	}
+}
+
+// write blocks until the framer has consumed data. It returns the error of the framer if it failed to decode
+// previously written data.
+func (d *spdyDecoder) write(data []byte) error {
+	_, err := d.pw.Write(data)
+	return err
+}
+
+func (d *spdyDecoder) close() {
+	_ = d.pw.Close()
+	<-d.done
+}
@@ -0,0 +1,66 @@
+package recording
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/moby/spdystream/spdy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpdyDecoders(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		msgs []channelMessage
+	)
+	emit := func(channel byte, data []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		msgs = append(msgs, channelMessage{channel: channel, data: string(data)})
+	}
+	in, out := newSpdyDecoders(emit)
+
+	var clientBuf, serverBuf bytes.Buffer
+	client, err := spdy.NewFramer(&clientBuf, nil)
+	require.NoError(t, err)
+	server, err := spdy.NewFramer(&serverBuf, nil)
+	require.NoError(t, err)
+	for id, streamType := range map[spdy.StreamId]string{1: "error", 3: "stdin", 5: "stdout", 7: "resize"} {
+		require.NoError(t, client.WriteFrame(&spdy.SynStreamFrame{
+			StreamId: id,
+			Headers:  http.Header{"streamtype": []string{streamType}},
+		}))
+		require.NoError(t, server.WriteFrame(&spdy.SynReplyFrame{
+			StreamId: id,
+			Headers:  http.Header{},
+		}))
+	}
+	require.NoError(t, client.WriteFrame(&spdy.DataFrame{StreamId: 7, Data: []byte(`{"Width":120,"Height":40}`)}))
+	require.NoError(t, client.WriteFrame(&spdy.DataFrame{StreamId: 3, Data: []byte("ls\n")}))
+	require.NoError(t, in.write(clientBuf.Bytes()))
+	in.close() // waits for the frames to be decoded
+	require.NoError(t, server.WriteFrame(&spdy.DataFrame{StreamId: 5, Data: []byte("file\n")}))
+	require.NoError(t, server.WriteFrame(&spdy.DataFrame{StreamId: 9, Data: []byte("unknown stream")}))
+	require.NoError(t, out.write(serverBuf.Bytes()))
+	out.close()
+
+	assert.Equal(t, []channelMessage{
+		{channel: channelResize, data: `{"Width":120,"Height":40}`},
+		{channel: channelStdin, data: "ls\n"},
+		{channel: channelStdout, data: "file\n"},
+	}, msgs)
+}
+
+func TestSpdyDecoder_InvalidData(t *testing.T) {
+	in, out := newSpdyDecoders(func(channel byte, data []byte) {
+		t.Fail()
+	})
+	// A control frame of an unknown type.
+	_ = in.write([]byte{0x80, 0x03, 0x00, 0xff, 0x00, 0x00, 0x00, 0x00})
+	assert.Error(t, in.write([]byte("more data")))
+	in.close()
+	out.close()
+}
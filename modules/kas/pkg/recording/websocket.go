@@ -0,0 +1,214 @@
+package recording
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8 // control frames have opcodes from 0x8
+
+	// wsMaxHeaderSize is the size of a frame header with a 64-bit payload length and a masking key.
+	wsMaxHeaderSize = 2 + 8 + 4
+
+	// wsMaxBufferedSize is the maximum size of a message that is emitted as a whole.
+	// Clients and the API server send much smaller messages. Larger messages are emitted in parts as they arrive.
+	wsMaxBufferedSize = 1024 * 1024
+)
+
+var (
+	errWebSocketInvalidFrame = errors.New("invalid WebSocket frame")
+)
+
+// webSocketDecoder decodes WebSocket frames of the Kubernetes channel protocols.
+// Each message is prefixed with the channel it belongs to.
+// See https://github.com/kubernetes/kubernetes/blob/master/staging/src/k8s.io/apiserver/pkg/util/wsstream/conn.go
+type webSocketDecoder struct {
+	base64 bool
+	emit   func(channel byte, data []byte)
+	header []byte // received part of the header of the next frame
+	err    error
+
+	// Current frame.
+	inFrame   bool
+	fin       bool
+	opcode    byte
+	masked    bool
+	key       [4]byte
+	keyPos    int
+	remaining uint64 // payload bytes of the current frame that have not been received yet
+
+	// Current, possibly fragmented, message.
+	inMessage bool
+	channel   int    // -1 until the channel prefix of the message has been received
+	msg       []byte // payload of the current message that has not been emitted yet
+}
+
+func newWebSocketDecoder(base64 bool, emit func(channel byte, data []byte)) *webSocketDecoder {
+	return &webSocketDecoder{
+		base64: base64,
+		emit:   emit,
+	}
+}
+
+// write decodes data. It unmasks data in place.
+func (d *webSocketDecoder) write(data []byte) error {
+	if d.err != nil {
+		return d.err
+	}
+	for len(data) > 0 {
+		if !d.inFrame {
+			n, err := d.readHeader(data)
+			if err != nil {
+				// Can't find the next frame anymore. Stop decoding.
+				d.err = err
+				d.header = nil
+				d.msg = nil
+				return err
+			}
+			data = data[n:]
+			if d.inFrame && d.remaining == 0 {
+				d.endFrame()
+			}
+			continue
+		}
+		n := int(min(uint64(len(data)), d.remaining))
+		d.payload(data[:n])
+		data = data[n:]
+		d.remaining -= uint64(n)
+		if d.remaining == 0 {
+			d.endFrame()
+		}
+	}
+	return nil
+}
+
+func (d *webSocketDecoder) close() {
+}
+
+// readHeader consumes the header of the next frame from data. It returns the number of consumed bytes.
+// d.inFrame is set once the header is complete.
+func (d *webSocketDecoder) readHeader(data []byte) (int, error) {
+	prev := len(d.header)
+	d.header = append(d.header, data[:min(len(data), wsMaxHeaderSize-prev)]...)
+	size, err := d.parseHeader(d.header)
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 {
+		return len(d.header) - prev, nil
+	}
+	d.header = d.header[:0]
+	return size - prev, nil
+}
+
+// parseHeader parses a frame header at the start of b. It returns the size of the header or zero if b doesn't hold
+// a complete header.
+// See https://datatracker.ietf.org/doc/html/rfc6455#section-5.2
+func (d *webSocketDecoder) parseHeader(b []byte) (int, error) {
+	if len(b) < 2 {
+		return 0, nil
+	}
+	fin := b[0]&0x80 != 0
+	opcode := b[0] & 0x0f
+	masked := b[1]&0x80 != 0
+	size := uint64(b[1] & 0x7f)
+	off := 2
+	switch size {
+	case 126:
+		if len(b) < off+2 {
+			return 0, nil
+		}
+		size = uint64(binary.BigEndian.Uint16(b[off:]))
+		off += 2
+	case 127:
+		if len(b) < off+8 {
+			return 0, nil
+		}
+		size = binary.BigEndian.Uint64(b[off:])
+		if size>>63 != 0 { // the most significant bit must be 0
+			return 0, errWebSocketInvalidFrame
+		}
+		off += 8
+	}
+	if masked { // frames sent by clients are masked
+		if len(b) < off+4 {
+			return 0, nil
+		}
+		copy(d.key[:], b[off:off+4])
+		off += 4
+	}
+	d.inFrame = true
+	d.fin = fin
+	d.opcode = opcode
+	d.masked = masked
+	d.keyPos = 0
+	d.remaining = size
+	switch opcode {
+	case wsOpText, wsOpBinary:
+		d.inMessage = true
+		d.channel = -1
+		d.msg = d.msg[:0]
+	}
+	return off, nil
+}
+
+func (d *webSocketDecoder) payload(p []byte) {
+	if d.masked {
+		for i := range p {
+			p[i] ^= d.key[d.keyPos%4]
+			d.keyPos++
+		}
+	}
+	if d.opcode >= wsOpClose || !d.inMessage {
+		return // control frame or a continuation of a message that started before decoding
+	}
+	d.msg = append(d.msg, p...)
+	if len(d.msg) > wsMaxBufferedSize {
+		d.flush(false)
+	}
+}
+
+func (d *webSocketDecoder) endFrame() {
+	d.inFrame = false
+	if d.opcode >= wsOpClose || !d.inMessage || !d.fin {
+		return
+	}
+	d.inMessage = false
+	d.flush(true)
+}
+
+// flush emits the part of the current message that has been received. final is true if the message is complete.
+func (d *webSocketDecoder) flush(final bool) {
+	msg := d.msg
+	if d.channel == -1 {
+		if len(msg) == 0 {
+			return
+		}
+		channel := msg[0]
+		if d.base64 {
+			channel -= '0'
+		}
+		d.channel = int(channel)
+		msg = msg[1:]
+	}
+	n := len(msg)
+	if d.base64 {
+		if !final {
+			n -= n % 4 // only decode complete base64 quanta
+		}
+		if n > 0 {
+			data, err := base64.StdEncoding.DecodeString(string(msg[:n]))
+			if err == nil {
+				d.emit(byte(d.channel), data)
+			}
+		}
+	} else if n > 0 {
+		d.emit(byte(d.channel), msg)
+	}
+	d.msg = d.msg[:copy(d.msg, msg[n:])]
+}
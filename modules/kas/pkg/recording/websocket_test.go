@@ -0,0 +1,133 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type channelMessage struct {
+	channel byte
+	data    string
+}
+
+func TestWebSocketDecoder_Binary(t *testing.T) {
+	var msgs []channelMessage
+	d := newWebSocketDecoder(false, collect(&msgs))
+	stream := wsFrame(true, wsOpBinary, true, append([]byte{channelStdin}, "ls\n"...))
+	stream = append(stream, wsFrame(true, 0x9, true, []byte("ping"))...)
+	stream = append(stream, wsFrame(false, wsOpBinary, false, append([]byte{channelStdout}, "hello "...))...)
+	stream = append(stream, wsFrame(true, wsOpContinuation, false, []byte("world"))...)
+	stream = append(stream, wsFrame(true, wsOpBinary, false, append([]byte{channelStderr}, make([]byte, 300)...))...)
+	// Feed byte by byte to exercise partial frames.
+	for i := range stream {
+		require.NoError(t, d.write(stream[i:i+1]))
+	}
+	assert.Equal(t, []channelMessage{
+		{channel: channelStdin, data: "ls\n"},
+		{channel: channelStdout, data: "hello world"},
+		{channel: channelStderr, data: string(make([]byte, 300))},
+	}, msgs)
+	assert.Empty(t, d.header)
+	assert.Empty(t, d.msg)
+}
+
+func TestWebSocketDecoder_Base64(t *testing.T) {
+	var msgs []channelMessage
+	d := newWebSocketDecoder(true, collect(&msgs))
+	payload := "1" + base64.StdEncoding.EncodeToString([]byte("hello"))
+	require.NoError(t, d.write(wsFrame(true, wsOpText, false, []byte(payload))))
+	assert.Equal(t, []channelMessage{{channel: channelStdout, data: "hello"}}, msgs)
+}
+
+func TestWebSocketDecoder_Large(t *testing.T) {
+	var msgs []channelMessage
+	d := newWebSocketDecoder(false, collect(&msgs))
+	data := bytes.Repeat([]byte("0123456789"), wsMaxBufferedSize/5)
+	header := []byte{0x80 | wsOpBinary, 127, 0, 0, 0, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint64(header[2:], uint64(len(data)+1))
+	require.NoError(t, d.write(header))
+	require.NoError(t, d.write([]byte{channelStdout}))
+	for chunk := range slices.Chunk(data, 64*1024) {
+		require.NoError(t, d.write(chunk))
+	}
+	require.NoError(t, d.write(wsFrame(true, wsOpBinary, false, []byte{channelStderr, 'x'})))
+	require.Greater(t, len(msgs), 2) // emitted in parts
+	var stdout strings.Builder
+	for _, msg := range msgs[:len(msgs)-1] {
+		assert.Equal(t, channelStdout, msg.channel)
+		stdout.WriteString(msg.data)
+	}
+	assert.Equal(t, string(data), stdout.String())
+	assert.Equal(t, channelMessage{channel: channelStderr, data: "x"}, msgs[len(msgs)-1])
+	assert.LessOrEqual(t, cap(d.msg), 2*wsMaxBufferedSize)
+}
+
+func TestWebSocketDecoder_LargeBase64(t *testing.T) {
+	var msgs []channelMessage
+	d := newWebSocketDecoder(true, collect(&msgs))
+	data := bytes.Repeat([]byte("abcdefg"), wsMaxBufferedSize/5) // not a multiple of 3 bytes
+	payload := "1" + base64.StdEncoding.EncodeToString(data)
+	header := []byte{0x80 | wsOpText, 127, 0, 0, 0, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint64(header[2:], uint64(len(payload)))
+	require.NoError(t, d.write(header))
+	for chunk := range slices.Chunk([]byte(payload), 64*1024+1) {
+		require.NoError(t, d.write(chunk))
+	}
+	require.Greater(t, len(msgs), 1)
+	var stdout strings.Builder
+	for _, msg := range msgs {
+		assert.Equal(t, channelStdout, msg.channel)
+		stdout.WriteString(msg.data)
+	}
+	assert.Equal(t, string(data), stdout.String())
+}
+
+func TestWebSocketDecoder_InvalidFrame(t *testing.T) {
+	var msgs []channelMessage
+	d := newWebSocketDecoder(false, collect(&msgs))
+	header := []byte{0x80 | wsOpBinary, 127, 0xff, 0, 0, 0, 0, 0, 0, 0}
+	assert.ErrorIs(t, d.write(header), errWebSocketInvalidFrame)
+	assert.ErrorIs(t, d.write(wsFrame(true, wsOpBinary, false, []byte{channelStdout, 'x'})), errWebSocketInvalidFrame)
+	assert.Empty(t, msgs)
+}
+
+func collect(msgs *[]channelMessage) func(byte, []byte) {
+	return func(channel byte, data []byte) {
+		*msgs = append(*msgs, channelMessage{channel: channel, data: string(data)})
+	}
+}
+
+func wsFrame(fin bool, opcode byte, masked bool, payload []byte) []byte {
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+	var b1 byte
+	if masked {
+		b1 = 0x80
+	}
+	frame := []byte{b0}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, b1|byte(len(payload)))
+	default:
+		frame = append(frame, b1|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	}
+	if !masked {
+		return append(frame, payload...)
+	}
+	key := []byte{1, 2, 3, 4}
+	frame = append(frame, key...)
+	for i, c := range payload {
+		frame = append(frame, c^key[i%4])
+	}
+	return frame
+}
@@ -50,6 +50,7 @@ type HttpRequestClient interface {
 }
 
 type MergeHeadersFunc func(outboundResponse, inboundResponse http.Header)
+type NewUpgradeTapFunc func(responseHeader http.Header) (UpgradeTap, error)
 type WriteErrorResponse func(w http.ResponseWriter, r *http.Request, eResp *ErrResp)
 
 type ErrResp struct {
@@ -64,6 +65,21 @@ type InboundHttpToOutboundGrpc struct {
 	HandleProcessingError HandleProcessingErrorFunc
 	WriteErrorResponse    WriteErrorResponse
 	MergeHeaders          MergeHeadersFunc
+	// NewUpgradeTap is called with the response header once the remote has accepted a connection upgrade,
+	// before the response is written to the client.
+	// It's optional and can return nil to not observe the upgraded connection.
+	// If it returns an error, the upgrade fails with an internal server error.
+	NewUpgradeTap NewUpgradeTapFunc
+}
+
+// UpgradeTap observes data flowing through an upgraded connection.
+// InboundData and OutboundData are called concurrently and must not retain the passed slice.
+// If either of them returns an error, the data is not forwarded and the connection is closed.
+type UpgradeTap interface {
+	// InboundData is called with data read from the client, before it's sent to the remote.
+	InboundData(data []byte) error
+	// OutboundData is called with data received from the remote, before it's written to the client.
+	OutboundData(data []byte) error
 }
 
 func (x *InboundHttpToOutboundGrpc) Pipe(outboundClient HttpRequestClient, w http.ResponseWriter, r *http.Request, headerExtra proto.Message) {
@@ -113,7 +129,7 @@ func (x *InboundHttpToOutboundGrpc) pipe(outboundClient HttpRequestClient, w htt
 		}
 	}
 	// 2. Pipe remote -> client
-	headerWritten, responseStatusCode, eResp := x.pipeOutboundToInbound(outboundClient, w, isUpgrade)
+	headerWritten, responseStatusCode, tap, eResp := x.pipeOutboundToInbound(outboundClient, w, isUpgrade)
 	if eResp != nil {
 		return headerWritten, eResp
 	}
@@ -125,19 +141,29 @@ func (x *InboundHttpToOutboundGrpc) pipe(outboundClient HttpRequestClient, w htt
 		// Remote doesn't want to upgrade the connection
 		return true, x.sendCloseSend(outboundClient)
 	}
-	return true, x.pipeUpgradedConnection(outboundClient, hijacker)
+	return true, x.pipeUpgradedConnection(outboundClient, hijacker, tap)
 }
 
-func (x *InboundHttpToOutboundGrpc) pipeOutboundToInbound(outboundClient HttpRequestClient, w http.ResponseWriter, isUpgrade bool) (bool, int32, *ErrResp) {
+func (x *InboundHttpToOutboundGrpc) pipeOutboundToInbound(outboundClient HttpRequestClient, w http.ResponseWriter, isUpgrade bool) (bool, int32, UpgradeTap, *ErrResp) {
 	writeFailed := false
 	headerWritten := false
-	var responseStatusCode int32
+	var (
+		responseStatusCode int32
+		tap                UpgradeTap
+		tapErr             error
+	)
 	flush := x.flush(w)
 	err := HttpResponseStreamVisitor.Get().Visit(outboundClient,
 		WithCallback(HttpResponseHeaderFieldNumber, func(header *HttpResponse_Header) error {
 			responseStatusCode = header.Response.StatusCode
 			outboundResponse := header.Response.HttpHeader()
 			cleanHeader(outboundResponse)
+			if isUpgrade && responseStatusCode == http.StatusSwitchingProtocols && x.NewUpgradeTap != nil {
+				tap, tapErr = x.NewUpgradeTap(outboundResponse)
+				if tapErr != nil {
+					return tapErr
+				}
+			}
 			x.MergeHeaders(outboundResponse, w.Header())
 			w.WriteHeader(int(header.Response.StatusCode))
 			// NOTE: the HTTP standard library doesn't no-op for a flush when WriteHeader() was already called with a 1xx status code
@@ -171,13 +197,17 @@ func (x *InboundHttpToOutboundGrpc) pipeOutboundToInbound(outboundClient HttpReq
 		WithNotExpectingToGet(codes.Internal, HttpResponseUpgradeDataFieldNumber),
 	)
 	if err != nil && err != errEarlyExit { // nolint: errorlint
-		if writeFailed {
+		switch {
+		case tapErr != nil:
+			return headerWritten, responseStatusCode, nil, x.handleInternalError("unable to observe upgraded connection", err)
+		case writeFailed:
 			// there is likely a connection problem so the client will likely not receive this
-			return headerWritten, responseStatusCode, x.handleIoError("failed to write HTTP response", err)
+			return headerWritten, responseStatusCode, nil, x.handleIoError("failed to write HTTP response", err)
+		default:
+			return headerWritten, responseStatusCode, nil, x.handleIoError("failed to read gRPC response", err)
 		}
-		return headerWritten, responseStatusCode, x.handleIoError("failed to read gRPC response", err)
 	}
-	return headerWritten, responseStatusCode, nil
+	return headerWritten, responseStatusCode, tap, nil
 }
 
 func (x *InboundHttpToOutboundGrpc) flush(w http.ResponseWriter) func() {
@@ -251,6 +281,16 @@ func (x *InboundHttpToOutboundGrpc) sendRequestBody(outboundClient HttpRequestCl
 			if readErr == io.EOF { // nolint:errorlint
 				break
 			}
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(readErr, &maxBytesErr) {
+				msg := "HTTP->gRPC: request body too large"
+				x.Log.Debug(msg, logz.Error(readErr))
+				return &ErrResp{
+					StatusCode: http.StatusRequestEntityTooLarge,
+					Msg:        msg,
+					Err:        readErr,
+				}
+			}
 			// There is likely a connection problem so the client will likely not receive this
 			return x.handleIoError("failed to read request body", readErr)
 		}
@@ -299,7 +339,7 @@ func (x *InboundHttpToOutboundGrpc) handleInternalError(msg string, err error) *
 	}
 }
 
-func (x *InboundHttpToOutboundGrpc) pipeUpgradedConnection(outboundClient HttpRequestClient, hijacker http.Hijacker) (errRet *ErrResp) {
+func (x *InboundHttpToOutboundGrpc) pipeUpgradedConnection(outboundClient HttpRequestClient, hijacker http.Hijacker, tap UpgradeTap) (errRet *ErrResp) {
 	conn, bufrw, err := hijacker.Hijack()
 	if err != nil {
 		return x.handleInternalError("unable to upgrade connection: error hijacking response", err)
@@ -328,10 +368,10 @@ func (x *InboundHttpToOutboundGrpc) pipeUpgradedConnection(outboundClient HttpRe
 	}
 	p := InboundStreamToOutboundStream{
 		PipeInboundToOutbound: func() error {
-			return x.pipeInboundToOutboundUpgraded(outboundClient, r)
+			return x.pipeInboundToOutboundUpgraded(outboundClient, r, tap)
 		},
 		PipeOutboundToInbound: func() error {
-			return x.pipeOutboundToInboundUpgraded(outboundClient, conn)
+			return x.pipeOutboundToInboundUpgraded(outboundClient, conn, tap)
 		},
 	}
 	err = p.Pipe()
@@ -341,12 +381,18 @@ func (x *InboundHttpToOutboundGrpc) pipeUpgradedConnection(outboundClient HttpRe
 	return nil
 }
 
-func (x *InboundHttpToOutboundGrpc) pipeInboundToOutboundUpgraded(outboundClient HttpRequestClient, inboundStream io.Reader) error {
+func (x *InboundHttpToOutboundGrpc) pipeInboundToOutboundUpgraded(outboundClient HttpRequestClient, inboundStream io.Reader, tap UpgradeTap) error {
 	buffer := memz.Get32k()
 	defer memz.Put32k(buffer)
 	for {
 		n, readErr := inboundStream.Read(buffer)
 		if n > 0 { // handle n>0 before readErr != nil to ensure any consumed data gets forwarded
+			if tap != nil {
+				err := tap.InboundData(buffer[:n])
+				if err != nil {
+					return fmt.Errorf("upgrade tap: %w", err)
+				}
+			}
 			sendErr := outboundClient.Send(&HttpRequest{
 				Message: &HttpRequest_UpgradeData_{
 					UpgradeData: &HttpRequest_UpgradeData{
@@ -376,11 +422,17 @@ func (x *InboundHttpToOutboundGrpc) pipeInboundToOutboundUpgraded(outboundClient
 	return nil
 }
 
-func (x *InboundHttpToOutboundGrpc) pipeOutboundToInboundUpgraded(outboundClient HttpRequestClient, inboundStream io.Writer) error {
+func (x *InboundHttpToOutboundGrpc) pipeOutboundToInboundUpgraded(outboundClient HttpRequestClient, inboundStream io.Writer, tap UpgradeTap) error {
 	var writeFailed bool
 	err := HttpResponseStreamVisitor.Get().Visit(outboundClient,
 		WithStartState(HttpResponseTrailerFieldNumber),
 		WithCallback(HttpResponseUpgradeDataFieldNumber, func(data *HttpResponse_UpgradeData) error {
+			if tap != nil {
+				err := tap.OutboundData(data.Data)
+				if err != nil {
+					return fmt.Errorf("upgrade tap: %w", err)
+				}
+			}
 			_, err := inboundStream.Write(data.Data)
 			if err != nil {
 				writeFailed = true
@@ -212,7 +212,57 @@ func TestHttp2Grpc_UpgradeHappyPath(t *testing.T) {
 		mrClient.EXPECT().CloseSend(),
 		connCloseCall,
 	)
+	tap := &testUpgradeTap{}
+	x.NewUpgradeTap = func(responseHeader http.Header) (grpctool2.UpgradeTap, error) {
+		assert.Equal(t, []string{"http/x"}, responseHeader[httpz.UpgradeHeader])
+		return tap, nil
+	}
+	x.Pipe(mrClient, w, r, headerExtra)
+	assert.Equal(t, requestUpgradeBodyData, string(tap.inbound))
+	assert.Equal(t, responseUpgradeBodyData, string(tap.outbound))
+}
+
+func TestHttp2Grpc_UpgradeTapError(t *testing.T) {
+	mrClient, w, r, x := setupHttp2grpc(t, true)
+	headerExtra := &test.Request{}
+	send := mockSendHappy(t, mrClient, headerExtra, true)
+	recv := []any{
+		mrClient.EXPECT().
+			RecvMsg(gomock.Any()).
+			Do(testhelpers.RecvMsg(&grpctool2.HttpResponse{
+				Message: &grpctool2.HttpResponse_Header_{
+					Header: &grpctool2.HttpResponse_Header{
+						Response: &prototool.HttpResponse{
+							StatusCode: http.StatusSwitchingProtocols,
+							Status:     http.StatusText(http.StatusSwitchingProtocols),
+							Header: map[string]*prototool.Values{
+								httpz.UpgradeHeader: {
+									Value: []string{"http/x"},
+								},
+								httpz.ConnectionHeader: {
+									Value: []string{"upgrade"},
+								},
+							},
+						},
+					},
+				},
+			})),
+		w.EXPECT().
+			WriteHeader(http.StatusInternalServerError),
+	}
+	calls := send
+	calls = append(calls, recv...)
+	gomock.InOrder(calls...)
+	tapErr := errors.New("unknown protocol")
+	var processingErr error
+	x.HandleProcessingError = func(msg string, err error) {
+		processingErr = err
+	}
+	x.NewUpgradeTap = func(responseHeader http.Header) (grpctool2.UpgradeTap, error) {
+		return nil, tapErr
+	}
 	x.Pipe(mrClient, w, r, headerExtra)
+	assert.ErrorIs(t, processingErr, tapErr)
 }
 
 func TestHttp2Grpc_ServerRefusesToUpgrade(t *testing.T) {
@@ -442,6 +492,27 @@ func TestHttp2Grpc_ErrorAfterBodyWritten(t *testing.T) {
 	})
 }
 
+func TestHttp2Grpc_RequestBodyTooLarge(t *testing.T) {
+	mrClient, w, r, x := setupHttp2grpc(t, false)
+	r.Body = http.MaxBytesReader(w, r.Body, 3)
+	gomock.InOrder(
+		mrClient.EXPECT().
+			Send(gomock.Any()), // header
+		mrClient.EXPECT().
+			Send(matcher.ProtoEq(t, &grpctool2.HttpRequest{
+				Message: &grpctool2.HttpRequest_Data_{
+					Data: &grpctool2.HttpRequest_Data{
+						Data: []byte(requestBodyData[:3]),
+					},
+				},
+			})),
+		w.EXPECT().
+			WriteHeader(http.StatusRequestEntityTooLarge),
+	)
+
+	x.Pipe(mrClient, w, r, &test.Request{})
+}
+
 func setupHttp2grpc(t *testing.T, isUpgrade bool) (*mock_kubernetes_api.MockKubernetesApi_MakeRequestClient[grpctool2.HttpRequest, grpctool2.HttpResponse], *mock_stdlib2.MockResponseWriterFlusher, *http.Request, grpctool2.InboundHttpToOutboundGrpc) {
 	ctrl := gomock.NewController(t)
 	mrClient := mock_kubernetes_api.NewMockKubernetesApi_MakeRequestClient[grpctool2.HttpRequest, grpctool2.HttpResponse](ctrl)
@@ -545,3 +616,18 @@ func mockSendHttp2grpcStream(t *testing.T, client *mock_kubernetes_api.MockKuber
 	}
 	return res
 }
+
+type testUpgradeTap struct {
+	inbound  []byte
+	outbound []byte
+}
+
+func (t *testUpgradeTap) InboundData(data []byte) error {
+	t.inbound = append(t.inbound, data...)
+	return nil
+}
+
+func (t *testUpgradeTap) OutboundData(data []byte) error {
+	t.outbound = append(t.outbound, data...)
+	return nil
+}
@@ -33,3 +33,27 @@ func (t *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string
 func (t *tokenCredentials) RequireTransportSecurity() bool {
 	return !t.insecure
 }
+
+// NewTokenSourceCredentials returns credentials that get the token from source for each request.
+// This allows to replace the token without recreating the connection.
+func NewTokenSourceCredentials(source func() api.AgentToken, insecure bool) credentials.PerRPCCredentials {
+	return &tokenSourceCredentials{
+		source:   source,
+		insecure: insecure,
+	}
+}
+
+type tokenSourceCredentials struct {
+	source   func() api.AgentToken
+	insecure bool
+}
+
+func (t *tokenSourceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		MetadataAuthorization: "Bearer " + string(t.source()),
+	}, nil
+}
+
+func (t *tokenSourceCredentials) RequireTransportSecurity() bool {
+	return !t.insecure
+}
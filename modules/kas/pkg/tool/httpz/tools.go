@@ -20,12 +20,20 @@ const (
 	TransferEncodingHeader              = "Transfer-Encoding"
 	UpgradeHeader                       = "Upgrade" // https://datatracker.ietf.org/doc/html/rfc9110#section-7.8
 	UserAgentHeader                     = "User-Agent"
-	AuthorizationHeader                 = "Authorization" // https://datatracker.ietf.org/doc/html/rfc9110#section-11.6.2
-	CookieHeader                        = "Cookie"        // https://datatracker.ietf.org/doc/html/rfc6265#section-5.4
-	ContentTypeHeader                   = "Content-Type"  // https://datatracker.ietf.org/doc/html/rfc9110#section-8.3
-	AcceptHeader                        = "Accept"        // https://datatracker.ietf.org/doc/html/rfc9110#section-12.5.1
-	ServerHeader                        = "Server"        // https://datatracker.ietf.org/doc/html/rfc9110#section-10.2.4
-	ViaHeader                           = "Via"           // https://datatracker.ietf.org/doc/html/rfc9110#section-7.6.3
+	AuthorizationHeader                 = "Authorization"    // https://datatracker.ietf.org/doc/html/rfc9110#section-11.6.2
+	CookieHeader                        = "Cookie"           // https://datatracker.ietf.org/doc/html/rfc6265#section-5.4
+	ContentTypeHeader                   = "Content-Type"     // https://datatracker.ietf.org/doc/html/rfc9110#section-8.3
+	AcceptHeader                        = "Accept"           // https://datatracker.ietf.org/doc/html/rfc9110#section-12.5.1
+	ServerHeader                        = "Server"           // https://datatracker.ietf.org/doc/html/rfc9110#section-10.2.4
+	ViaHeader                           = "Via"              // https://datatracker.ietf.org/doc/html/rfc9110#section-7.6.3
+	RetryAfterHeader                    = "Retry-After"      // https://datatracker.ietf.org/doc/html/rfc9110#section-10.2.3
+	CacheControlHeader                  = "Cache-Control"    // https://datatracker.ietf.org/doc/html/rfc9111#section-5.2
+	AcceptEncodingHeader                = "Accept-Encoding"  // https://datatracker.ietf.org/doc/html/rfc9110#section-12.5.3
+	ContentEncodingHeader               = "Content-Encoding" // https://datatracker.ietf.org/doc/html/rfc9110#section-8.4
+	ContentLengthHeader                 = "Content-Length"   // https://datatracker.ietf.org/doc/html/rfc9110#section-8.6
+	EtagHeader                          = "Etag"             // canonicalized version of "ETag"
+	IfNoneMatchHeader                   = "If-None-Match"    // https://datatracker.ietf.org/doc/html/rfc9110#section-13.1.2
+	LastModifiedHeader                  = "Last-Modified"    // https://datatracker.ietf.org/doc/html/rfc9110#section-8.8.2
 	GitlabAgentIdHeader                 = "Gitlab-Agent-Id"
 	GitlabAgentIdQueryParam             = "gitlab-agent-id"
 	GitlabUnauthorizedHeader            = "Gitlab-Unauthorized"
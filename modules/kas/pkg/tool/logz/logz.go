@@ -219,3 +219,11 @@ func InventoryName(name string) zap.Field {
 func InventoryNamespace(namespace string) zap.Field {
 	return zap.String("inventory_namespace", namespace)
 }
+
+func AuditSink(name string) zap.Field {
+	return zap.String("audit_sink", name)
+}
+
+func RecordingSink(name string) zap.Field {
+	return zap.String("recording_sink", name)
+}
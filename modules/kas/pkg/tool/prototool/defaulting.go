@@ -54,6 +54,12 @@ func Uint32(d *uint32, defaultValue uint32) {
 	}
 }
 
+func Uint64(d *uint64, defaultValue uint64) {
+	if *d == 0 {
+		*d = defaultValue
+	}
+}
+
 func Uint32Ptr(d **uint32, defaultValue uint32) {
 	if *d == nil {
 		*d = &defaultValue
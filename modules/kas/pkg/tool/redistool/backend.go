@@ -0,0 +1,101 @@
+package redistool
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/rueidis"
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/cache"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+)
+
+// Backend is where data, that kas replicas share, is kept.
+// Exactly one of Client and Store must be set.
+type Backend struct {
+	// Client is used to keep data in Redis.
+	Client rueidis.Client
+	// Store is used to keep data in memory. Only a single kas replica can be run with it, unless Kubernetes is set.
+	Store *MemoryStore
+	// Kubernetes is used to keep hashes in Lease objects, if set. Store must be set too.
+	// Other data is kept in Store i.e. each kas replica has its own rate limits and cached errors.
+	Kubernetes *KubernetesStore
+}
+
+// hashStore returns the HashStore to keep hashes in or nil if they are kept in Redis.
+func (b Backend) hashStore() HashStore {
+	switch {
+	case b.Kubernetes != nil:
+		return b.Kubernetes
+	case b.Store != nil:
+		return b.Store
+	default:
+		return nil
+	}
+}
+
+func NewExpiringHash[K1 comparable, K2 comparable](b Backend, key1ToRedisKey KeyToRedisKey[K1],
+	key2ToRedisKey KeyToRedisKey[K2], ttl time.Duration) ExpiringHash[K1, K2] {
+	if store := b.hashStore(); store != nil {
+		return NewStoreExpiringHash[K1, K2](store, key1ToRedisKey, key2ToRedisKey, ttl)
+	}
+	return NewRedisExpiringHash(b.Client, key1ToRedisKey, key2ToRedisKey, ttl)
+}
+
+func NewExpiringHashApi[K1 any, K2 any](b Backend, key1ToRedisKey KeyToRedisKey[K1],
+	key2ToRedisKey KeyToRedisKey[K2]) ExpiringHashApi[K1, K2] {
+	if store := b.hashStore(); store != nil {
+		return &StoreExpiringHashApi[K1, K2]{
+			Store:          store,
+			Key1ToRedisKey: key1ToRedisKey,
+			Key2ToRedisKey: key2ToRedisKey,
+		}
+	}
+	return &RedisExpiringHashApi[K1, K2]{
+		Client:         b.Client,
+		Key1ToRedisKey: key1ToRedisKey,
+		Key2ToRedisKey: key2ToRedisKey,
+	}
+}
+
+func NewErrCacher[K any](b Backend, log *zap.Logger, errRep errz.ErrReporter, errMarshaler ErrMarshaler,
+	keyToRedisKey KeyToRedisKey[K]) cache.ErrCacher[K] {
+	if b.Store != nil {
+		return &MemoryErrCacher[K]{
+			Store:         b.Store,
+			KeyToRedisKey: keyToRedisKey,
+		}
+	}
+	return &ErrCacher[K]{
+		Log:           log,
+		ErrRep:        errRep,
+		Client:        b.Client,
+		ErrMarshaler:  errMarshaler,
+		KeyToRedisKey: keyToRedisKey,
+	}
+}
+
+func NewLimiter(b Backend, keyPrefix string, limitPerMinute uint64, limitExceeded prometheus.Counter,
+	getApi func(context.Context) RpcApi) WindowLimiter {
+	if b.Store != nil {
+		return NewMemoryTokenLimiter(b.Store, keyPrefix, limitPerMinute, limitExceeded, getApi)
+	}
+	return NewTokenLimiter(b.Client, keyPrefix, limitPerMinute, limitExceeded, getApi)
+}
+
+func NewValueCacher[K any](b Backend, log *zap.Logger, errRep errz.ErrReporter, keyToRedisKey KeyToRedisKey[K]) ValueCacher[K] {
+	if b.Store != nil {
+		return &MemoryValueCacher[K]{
+			Store:         b.Store,
+			KeyToRedisKey: keyToRedisKey,
+		}
+	}
+	return &RedisValueCacher[K]{
+		Log:           log,
+		ErrRep:        errRep,
+		Client:        b.Client,
+		KeyToRedisKey: keyToRedisKey,
+	}
+}
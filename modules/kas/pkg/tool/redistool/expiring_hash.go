@@ -42,8 +42,10 @@ type ExpiringHash[K1 any, K2 any] interface {
 	GC() func(context.Context) (int /* keysDeleted */, error)
 	// Clear clears all data in this hash and deletes it from the backing store.
 	Clear(context.Context) (int, error)
-	// Refresh refreshes data in the backing store to prevent it from expiring.
-	Refresh(ctx context.Context, nextRefresh time.Time) error
+	// Refresh returns a function that refreshes data in the backing store to prevent it from expiring.
+	// Data to refresh is captured when Refresh is called so the returned function can be called concurrently
+	// with the hash's operation, e.g. without holding a lock that guards the hash.
+	Refresh(nextRefresh time.Time) func(context.Context) error
 }
 
 type RedisExpiringHash[K1 comparable, K2 comparable] struct {
@@ -76,7 +78,7 @@ func (h *RedisExpiringHash[K1, K2]) Set(ctx context.Context, key K1, hashKey K2,
 		ExpiresAt: time.Now().Add(h.ttl).Unix(),
 		Value:     value,
 	}
-	h.setData(key, hashKey, ev)
+	setData(h.data, key, hashKey, ev)
 
 	b := h.api.SetBuilder()
 	b.Set(key, h.ttl, BuilderKV[K2]{
@@ -87,12 +89,12 @@ func (h *RedisExpiringHash[K1, K2]) Set(ctx context.Context, key K1, hashKey K2,
 }
 
 func (h *RedisExpiringHash[K1, K2]) Unset(ctx context.Context, key K1, hashKey K2) error {
-	h.unsetData(key, hashKey)
+	unsetData(h.data, key, hashKey)
 	return h.api.Unset(ctx, key, hashKey)
 }
 
 func (h *RedisExpiringHash[K1, K2]) Forget(key K1, hashKey K2) {
-	h.unsetData(key, hashKey)
+	unsetData(h.data, key, hashKey)
 }
 
 func (h *RedisExpiringHash[K1, K2]) Len(ctx context.Context, key K1) (size int64, retErr error) {
@@ -220,12 +222,18 @@ func (h *RedisExpiringHash[K1, K2]) Clear(ctx context.Context) (int, error) {
 	return keysDeleted, errors.Join(errs...)
 }
 
-func (h *RedisExpiringHash[K1, K2]) Refresh(ctx context.Context, nextRefresh time.Time) error {
-	expiresAt := time.Now().Add(h.ttl).Unix()
-	nextRefreshUnix := nextRefresh.Unix()
+func (h *RedisExpiringHash[K1, K2]) Refresh(nextRefresh time.Time) func(context.Context) error {
 	b := h.api.SetBuilder()
+	refreshData(h.data, b, h.ttl, nextRefresh)
+	return b.Do
+}
+
+// refreshData enqueues sets for the values in data that expire before nextRefresh, updating their expiration time.
+func refreshData[K1 comparable, K2 comparable](data map[K1]map[K2]*ExpiringValue, b SetBuilder[K1, K2], ttl time.Duration, nextRefresh time.Time) {
+	expiresAt := time.Now().Add(ttl).Unix()
+	nextRefreshUnix := nextRefresh.Unix()
 	var kvs []BuilderKV[K2]
-	for key, hashData := range h.data {
+	for key, hashData := range data {
 		kvs = kvs[:0] // reuse backing array, but reset length
 		for hashKey, value := range hashData {
 			if value.ExpiresAt > nextRefreshUnix {
@@ -238,25 +246,24 @@ func (h *RedisExpiringHash[K1, K2]) Refresh(ctx context.Context, nextRefresh tim
 				Value:   value,
 			})
 		}
-		b.Set(key, h.ttl, kvs...)
+		b.Set(key, ttl, kvs...)
 	}
-	return b.Do(ctx)
 }
 
-func (h *RedisExpiringHash[K1, K2]) setData(key K1, hashKey K2, value *ExpiringValue) {
-	nm := h.data[key]
+func setData[K1 comparable, K2 comparable](data map[K1]map[K2]*ExpiringValue, key K1, hashKey K2, value *ExpiringValue) {
+	nm := data[key]
 	if nm == nil {
 		nm = make(map[K2]*ExpiringValue, 1)
-		h.data[key] = nm
+		data[key] = nm
 	}
 	nm[hashKey] = value
 }
 
-func (h *RedisExpiringHash[K1, K2]) unsetData(key K1, hashKey K2) {
-	nm := h.data[key]
+func unsetData[K1 comparable, K2 comparable](data map[K1]map[K2]*ExpiringValue, key K1, hashKey K2) {
+	nm := data[key]
 	delete(nm, hashKey)
 	if len(nm) == 0 {
-		delete(h.data, key)
+		delete(data, key)
 	}
 }
 
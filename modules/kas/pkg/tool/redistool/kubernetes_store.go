@@ -0,0 +1,531 @@
+package redistool
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	typedcoordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+const (
+	kubernetesFieldManager          = "kas"
+	kubernetesManagedByLabel        = "app.kubernetes.io/managed-by"
+	kubernetesManagedByValue        = "kas"
+	kubernetesHashLabel             = "kas.plural.sh/hash"
+	kubernetesEntryAnnotationPrefix = "entry.kas.plural.sh/"
+	kubernetesLeaseNamePrefix       = "kas-"
+	// kubernetesHashLen is the number of bytes of a SHA-256 hash that are used in names and labels.
+	// Label values and annotation names must be no longer than 63 characters.
+	kubernetesHashLen = 20
+	// kubernetesBucketsPerKey is the number of Lease objects a store spreads the hash keys of a key over.
+	// Annotations of an object must be no larger than 256KiB in total so a key with many hash keys cannot be
+	// kept in a single object.
+	kubernetesBucketsPerKey = 64
+	// kubernetesMaxConcurrentRequests is the maximum number of concurrent requests to the API server
+	// an operation makes.
+	kubernetesMaxConcurrentRequests = 8
+)
+
+// KubernetesStore keeps data in Lease objects in a Kubernetes namespace instead of Redis.
+// Each hash key is an annotation on a Lease object, labeled with the hash it belongs to.
+// Each store writes hash keys into its own Lease objects, a few per hash, so that kas replicas update their
+// data without conflicting with each other. Hash keys that several replicas set are merged when read.
+// Unlike with Redis, sets of multiple hash keys are applied atomically per Lease object only.
+// Safe for concurrent use.
+type KubernetesStore struct {
+	log    *zap.Logger
+	leases typedcoordinationv1.LeaseInterface
+	// owner identifies the Lease objects of this store.
+	owner string
+}
+
+func NewKubernetesStore(log *zap.Logger, client kubernetes.Interface, namespace string) *KubernetesStore {
+	return &KubernetesStore{
+		log:    log,
+		leases: client.CoordinationV1().Leases(namespace),
+		owner:  rand.Text(),
+	}
+}
+
+// Run periodically deletes expired values until ctx is done.
+// This is what key expiration does in Redis for hashes of kas replicas that have not shut down cleanly.
+func (s *KubernetesStore) Run(ctx context.Context, gcPeriod time.Duration) {
+	t := time.NewTicker(gcPeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			keysDeleted, err := s.gc(ctx, metav1.ListOptions{
+				LabelSelector: kubernetesManagedByLabel + "=" + kubernetesManagedByValue,
+			})
+			if err != nil {
+				s.log.Error("Failed to delete expired values from Lease objects", logz.Error(err))
+			}
+			if keysDeleted > 0 {
+				s.log.Info("Deleted expired values from Lease objects", logz.RemovedHashKeys(keysDeleted))
+			}
+		}
+	}
+}
+
+// Check checks that Lease objects can be listed.
+func (s *KubernetesStore) Check(ctx context.Context) error {
+	_, err := s.leases.List(ctx, metav1.ListOptions{
+		LabelSelector: kubernetesManagedByLabel + "=" + kubernetesManagedByValue,
+		Limit:         1,
+	})
+	return err
+}
+
+// kubernetesLeaseSet is a set of hash keys of a key that are kept in the same Lease object.
+type kubernetesLeaseSet struct {
+	key         string
+	ttl         time.Duration
+	annotations map[string]string
+}
+
+func (s *KubernetesStore) hashSet(ctx context.Context, sets []storeHashSet) error {
+	leaseSets := map[string]*kubernetesLeaseSet{} // Lease name -> set
+	var errs []error
+	for _, set := range sets {
+		for _, kv := range set.kvs {
+			entry, err := kubernetesEncodeEntry(kv.field, kv.value)
+			if err != nil {
+				// This should never happen
+				errs = append(errs, err)
+				continue
+			}
+			name := kubernetesLeaseName(set.key, s.owner, kubernetesBucket(kv.field))
+			ls := leaseSets[name]
+			if ls == nil {
+				ls = &kubernetesLeaseSet{
+					key:         set.key,
+					annotations: map[string]string{},
+				}
+				leaseSets[name] = ls
+			}
+			ls.ttl = max(ls.ttl, set.ttl)
+			ls.annotations[kubernetesEntryAnnotation(kv.field)] = entry
+		}
+	}
+	err := kubernetesForEach(leaseSets, func(name string, ls *kubernetesLeaseSet) error {
+		return s.setLease(ctx, name, ls)
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// setLease adds or replaces hash keys in a Lease object, creating it if it doesn't exist.
+func (s *KubernetesStore) setLease(ctx context.Context, name string, ls *kubernetesLeaseSet) error {
+	labels := map[string]string{
+		kubernetesManagedByLabel: kubernetesManagedByValue,
+		kubernetesHashLabel:      kubernetesHash(ls.key),
+	}
+	// Lease duration is informational, values are expired using ExpiringValue.ExpiresAt.
+	leaseDuration := int32(min(math.Ceil(ls.ttl.Seconds()), math.MaxInt32))
+	renewTime := metav1.NewMicroTime(time.Now())
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels":      labels,
+			"annotations": ls.annotations,
+		},
+		"spec": map[string]any{
+			"renewTime":            renewTime,
+			"leaseDurationSeconds": leaseDuration,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	opts := metav1.PatchOptions{
+		FieldManager: kubernetesFieldManager,
+	}
+	_, err = s.leases.Patch(ctx, name, types.MergePatchType, patch, opts)
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+	_, err = s.leases.Create(ctx, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: ls.annotations,
+		},
+		Spec: coordinationv1.LeaseSpec{
+			RenewTime:            &renewTime,
+			LeaseDurationSeconds: &leaseDuration,
+		},
+	}, metav1.CreateOptions{
+		FieldManager: kubernetesFieldManager,
+	})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	// Created concurrently
+	_, err = s.leases.Patch(ctx, name, types.MergePatchType, patch, opts)
+	return err
+}
+
+// hashDel deletes the hash keys from all Lease objects of the key, including the ones of other stores.
+func (s *KubernetesStore) hashDel(ctx context.Context, key string, fields []string) error {
+	list, err := s.leases.List(ctx, hashListOptions(key))
+	if err != nil {
+		return err
+	}
+	toDel := make(map[string]*coordinationv1.Lease)
+	for i := range list.Items {
+		lease := &list.Items[i]
+		for _, field := range fields {
+			if _, ok := lease.Annotations[kubernetesEntryAnnotation(field)]; ok {
+				toDel[lease.Name] = lease
+				break
+			}
+		}
+	}
+	return kubernetesForEach(toDel, func(name string, lease *coordinationv1.Lease) error {
+		annotations := make(map[string]any, len(fields))
+		for _, field := range fields {
+			annotations[kubernetesEntryAnnotation(field)] = nil // null removes the annotation
+		}
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"annotations": annotations,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		lease, err = s.leases.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{
+			FieldManager: kubernetesFieldManager,
+		})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if len(kubernetesEntries(lease)) > 0 {
+			return nil
+		}
+		_, err = s.deleteUnchanged(ctx, lease)
+		return err
+	})
+}
+
+// kubernetesScanEntry is a hash key that has been read from a Lease object.
+type kubernetesScanEntry struct {
+	lease      *coordinationv1.Lease
+	annotation string
+	entry      *KubernetesHashEntry
+}
+
+func (s *KubernetesStore) hashScan(ctx context.Context, key string, cb ScanCallback) (int /* keysDeleted */, error) {
+	now := time.Now().Unix()
+	list, err := s.leases.List(ctx, hashListOptions(key))
+	if err != nil {
+		return 0, err
+	}
+	values := map[string]*ExpiringValue{} // hash key -> latest value. Several stores may have set the same hash key.
+	var fields []string                   // hash keys in the order they have been found
+	var expired []kubernetesScanEntry
+	var cbErr error
+	for i := range list.Items {
+		lease := &list.Items[i]
+		for annotation, entry := range kubernetesEntries(lease) {
+			e, err := kubernetesDecodeEntry(entry)
+			if err != nil {
+				var done bool
+				done, cbErr = cb("", nil, fmt.Errorf("failed to unmarshal hash value from Lease %s: %w", lease.Name, err))
+				if cbErr != nil || done {
+					return 0, cbErr
+				}
+				continue
+			}
+			if e.Value.GetExpiresAt() < now {
+				expired = append(expired, kubernetesScanEntry{
+					lease:      lease,
+					annotation: annotation,
+					entry:      e,
+				})
+				continue
+			}
+			v, ok := values[e.Field]
+			if !ok {
+				fields = append(fields, e.Field)
+			}
+			if !ok || v.ExpiresAt < e.Value.ExpiresAt {
+				values[e.Field] = e.Value
+			}
+		}
+	}
+	for _, field := range fields {
+		var done bool
+		done, cbErr = cb(field, values[field].Value, nil)
+		if cbErr != nil || done {
+			break
+		}
+	}
+	keysDeleted, err := s.deleteExpired(ctx, expired)
+	if cbErr != nil {
+		return keysDeleted, cbErr
+	}
+	return keysDeleted, err
+}
+
+func (s *KubernetesStore) hashLen(ctx context.Context, key string) (int64, error) {
+	list, err := s.leases.List(ctx, hashListOptions(key))
+	if err != nil {
+		return 0, err
+	}
+	// Annotation names identify hash keys, no need to decode the values.
+	annotations := map[string]struct{}{}
+	for i := range list.Items {
+		for annotation := range kubernetesEntries(&list.Items[i]) {
+			annotations[annotation] = struct{}{}
+		}
+	}
+	return int64(len(annotations)), nil
+}
+
+func (s *KubernetesStore) hashGC(ctx context.Context, key string) (int /* keysDeleted */, error) {
+	return s.gc(ctx, hashListOptions(key))
+}
+
+// gc deletes expired values and Lease objects without values.
+func (s *KubernetesStore) gc(ctx context.Context, opts metav1.ListOptions) (int /* keysDeleted */, error) {
+	now := time.Now().Unix()
+	list, err := s.leases.List(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	var expired []kubernetesScanEntry
+	var empty []*coordinationv1.Lease
+	for i := range list.Items {
+		lease := &list.Items[i]
+		entries := kubernetesEntries(lease)
+		if len(entries) == 0 {
+			empty = append(empty, lease)
+			continue
+		}
+		for annotation, entry := range entries {
+			e, err := kubernetesDecodeEntry(entry)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Lease %s: %w", lease.Name, err))
+				continue
+			}
+			if e.Value.GetExpiresAt() < now {
+				expired = append(expired, kubernetesScanEntry{
+					lease:      lease,
+					annotation: annotation,
+					entry:      e,
+				})
+			}
+		}
+	}
+	keysDeleted, err := s.deleteExpired(ctx, expired)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	_, err = s.deleteUnchanged(ctx, empty...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return keysDeleted, errors.Join(errs...)
+}
+
+// deleteExpired deletes expired hash keys unless they have been updated since they were read.
+// Lease objects are deleted if all of their hash keys have expired.
+// This is what transactions are used for with Redis.
+func (s *KubernetesStore) deleteExpired(ctx context.Context, expired []kubernetesScanEntry) (int, error) {
+	byLease := map[string][]kubernetesScanEntry{}
+	for _, e := range expired {
+		byLease[e.lease.Name] = append(byLease[e.lease.Name], e)
+	}
+	var mu sync.Mutex
+	deleted := 0
+	err := kubernetesForEach(byLease, func(name string, entries []kubernetesScanEntry) error {
+		lease := entries[0].lease
+		var n int
+		var err error
+		if len(entries) == len(kubernetesEntries(lease)) {
+			n, err = s.deleteUnchanged(ctx, lease)
+			n *= len(entries)
+		} else {
+			n, err = s.removeUnchanged(ctx, name, entries)
+		}
+		mu.Lock()
+		deleted += n
+		mu.Unlock()
+		return err
+	})
+	return deleted, err
+}
+
+// removeUnchanged removes hash keys from a Lease object unless they have been updated since they were read.
+func (s *KubernetesStore) removeUnchanged(ctx context.Context, name string, entries []kubernetesScanEntry) (int, error) {
+	ops := make([]map[string]any, 0, 2*len(entries))
+	for _, e := range entries {
+		path := "/metadata/annotations/" + strings.ReplaceAll(e.annotation, "/", "~1")
+		ops = append(ops,
+			map[string]any{"op": "test", "path": path, "value": e.lease.Annotations[e.annotation]},
+			map[string]any{"op": "remove", "path": path},
+		)
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return 0, err
+	}
+	_, err = s.leases.Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{
+		FieldManager: kubernetesFieldManager,
+	})
+	switch {
+	case err == nil:
+		return len(entries), nil
+	case apierrors.IsNotFound(err), apierrors.IsConflict(err), apierrors.IsInvalid(err):
+		// Deleted or updated concurrently. A failed test operation is a conflict.
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// deleteUnchanged deletes Lease objects unless they have been updated since they were read.
+func (s *KubernetesStore) deleteUnchanged(ctx context.Context, leases ...*coordinationv1.Lease) (int, error) {
+	var errs []error
+	deleted := 0
+	for _, lease := range leases {
+		err := s.leases.Delete(ctx, lease.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				UID:             &lease.UID,
+				ResourceVersion: &lease.ResourceVersion,
+			},
+		})
+		switch {
+		case err == nil:
+			deleted++
+		case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+			// Deleted or updated concurrently
+		default:
+			errs = append(errs, err)
+		}
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// kubernetesForEach calls f for each element of m concurrently, making at most kubernetesMaxConcurrentRequests
+// calls at a time. It returns the errors of all calls.
+func kubernetesForEach[V any](m map[string]V, f func(string, V) error) error {
+	var g errgroup.Group
+	g.SetLimit(kubernetesMaxConcurrentRequests)
+	var mu sync.Mutex
+	var errs []error
+	for name, v := range m {
+		g.Go(func() error {
+			err := f(name, v)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait() // always nil
+	return errors.Join(errs...)
+}
+
+// kubernetesEntries returns the annotations of the Lease object that hold hash keys.
+func kubernetesEntries(lease *coordinationv1.Lease) map[string]string {
+	entries := make(map[string]string, len(lease.Annotations))
+	for annotation, entry := range lease.Annotations {
+		if strings.HasPrefix(annotation, kubernetesEntryAnnotationPrefix) {
+			entries[annotation] = entry
+		}
+	}
+	return entries
+}
+
+func kubernetesEncodeEntry(field string, value *ExpiringValue) (string, error) {
+	data, err := proto.Marshal(&KubernetesHashEntry{
+		Field: field,
+		Value: value,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal KubernetesHashEntry: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func kubernetesDecodeEntry(entry string) (*KubernetesHashEntry, error) {
+	data, err := base64.StdEncoding.DecodeString(entry)
+	if err != nil {
+		return nil, err
+	}
+	e := &KubernetesHashEntry{}
+	err = proto.Unmarshal(data, e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func hashListOptions(key string) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: kubernetesHashLabel + "=" + kubernetesHash(key),
+	}
+}
+
+// kubernetesLeaseName returns a valid object name for a bucket of hash keys of a key that an owner sets.
+// Keys may contain any bytes so they are hashed.
+func kubernetesLeaseName(key, owner string, bucket int) string {
+	h := sha256.New()
+	h.Write([]byte(key))
+	h.Write([]byte{0}) // separator so that different key and owner combinations don't produce the same input
+	h.Write([]byte(owner))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(bucket)))
+	return kubernetesLeaseNamePrefix + hex.EncodeToString(h.Sum(nil)[:kubernetesHashLen])
+}
+
+// kubernetesEntryAnnotation returns a valid annotation name for a hash key.
+// Hash keys may contain any bytes so they are hashed.
+func kubernetesEntryAnnotation(field string) string {
+	return kubernetesEntryAnnotationPrefix + kubernetesHash(field)
+}
+
+// kubernetesBucket returns the bucket of a hash key.
+func kubernetesBucket(field string) int {
+	sum := sha256.Sum256([]byte(field))
+	return int(binary.BigEndian.Uint32(sum[:]) % kubernetesBucketsPerKey)
+}
+
+func kubernetesHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:kubernetesHashLen])
+}
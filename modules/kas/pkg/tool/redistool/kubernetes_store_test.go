@@ -0,0 +1,240 @@
+package redistool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testNamespace = "kas"
+)
+
+var (
+	_ HashStore = &KubernetesStore{}
+	_ HashStore = &MemoryStore{}
+)
+
+func TestKubernetesStore_SetScan(t *testing.T) {
+	_, hash := setupKubernetesHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash.Set(context.Background(), "k", 124, []byte("v2")))
+	require.NoError(t, hash.Set(context.Background(), "other", 125, []byte("v3")))
+	assert.Equal(t, map[string]string{"123": "v1", "124": "v2"}, scanHash(t, hash, "k"))
+	size, err := hash.Len(context.Background(), "k")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, size)
+}
+
+func TestKubernetesStore_SetUpdatesLease(t *testing.T) {
+	client, hash := setupKubernetesHash(t)
+	store := hash.store.(*KubernetesStore)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v2")))
+	assert.Equal(t, map[string]string{"123": "v2"}, scanHash(t, hash, "k"))
+	leases, err := client.CoordinationV1().Leases(testNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, leases.Items, 1)
+	lease := leases.Items[0]
+	assert.Equal(t, kubernetesLeaseName("k", store.owner, kubernetesBucket("123")), lease.Name)
+	assert.Equal(t, kubernetesManagedByValue, lease.Labels[kubernetesManagedByLabel])
+	assert.Equal(t, kubernetesHash("k"), lease.Labels[kubernetesHashLabel])
+	e, err := kubernetesDecodeEntry(lease.Annotations[kubernetesEntryAnnotation("123")])
+	require.NoError(t, err)
+	assert.Equal(t, "123", e.Field)
+	assert.Equal(t, []byte("v2"), e.Value.Value)
+	assert.EqualValues(t, ttl.Seconds(), *lease.Spec.LeaseDurationSeconds)
+}
+
+func TestKubernetesStore_SetBatchesHashKeys(t *testing.T) {
+	client, hash := setupKubernetesHash(t)
+
+	const n = 100
+	b := hash.api.SetBuilder()
+	expected := make(map[string]string, n)
+	for i := range int64(n) {
+		b.Set("k", ttl, BuilderKV[int64]{
+			HashKey: i,
+			Value: &ExpiringValue{
+				ExpiresAt: time.Now().Add(ttl).Unix(),
+				Value:     []byte("v"),
+			},
+		})
+		expected[int64ToStr(i)] = "v"
+	}
+	require.NoError(t, b.Do(context.Background()))
+	assert.Equal(t, expected, scanHash(t, hash, "k"))
+	leases, err := client.CoordinationV1().Leases(testNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(leases.Items), kubernetesBucketsPerKey)
+}
+
+func TestKubernetesStore_SeveralStores(t *testing.T) {
+	client, hash1 := setupKubernetesHash(t)
+	hash2 := NewStoreExpiringHash[string, int64](NewKubernetesStore(zaptest.NewLogger(t), client, testNamespace), s2s, int64ToStr, ttl)
+
+	require.NoError(t, hash1.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash2.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash2.Set(context.Background(), "k", 124, []byte("v2")))
+	leases, err := client.CoordinationV1().Leases(testNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, leases.Items, 3) // hash keys of different stores are never in the same Lease object
+	assert.Equal(t, map[string]string{"123": "v1", "124": "v2"}, scanHash(t, hash1, "k"))
+	size, err := hash1.Len(context.Background(), "k")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, size)
+
+	// Unset deletes the hash key set by any store
+	require.NoError(t, hash1.Unset(context.Background(), "k", 123))
+	assert.Equal(t, map[string]string{"124": "v2"}, scanHash(t, hash1, "k"))
+}
+
+func TestKubernetesStore_Unset(t *testing.T) {
+	_, hash := setupKubernetesHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash.Unset(context.Background(), "k", 123))
+	require.NoError(t, hash.Unset(context.Background(), "k", 123)) // not found is not an error
+	assert.Empty(t, scanHash(t, hash, "k"))
+}
+
+func TestKubernetesStore_ScanDeletesExpiredValues(t *testing.T) {
+	client, hash := setupKubernetesHash(t)
+
+	setExpiredValue(t, hash, "k", 123)
+	keysDeleted, err := hash.Scan(context.Background(), "k", func(rawHashKey string, value []byte, err error) (bool, error) {
+		assert.FailNow(t, "unexpected callback invocation")
+		return false, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, keysDeleted)
+	assertNoLeases(t, client)
+}
+
+func TestKubernetesStore_ScanDeletesExpiredValuesFromLease(t *testing.T) {
+	client, hash := setupKubernetesHash(t)
+
+	expiredKey := int64(124)
+	for kubernetesBucket(int64ToStr(expiredKey)) != kubernetesBucket("123") { // find a hash key in the same Lease
+		expiredKey++
+	}
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	setExpiredValue(t, hash, "k", expiredKey)
+	keysDeleted, err := hash.Scan(context.Background(), "k", func(rawHashKey string, value []byte, err error) (bool, error) {
+		assert.Equal(t, "123", rawHashKey)
+		return false, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, keysDeleted)
+	leases, err := client.CoordinationV1().Leases(testNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, leases.Items, 1)
+	assert.Len(t, kubernetesEntries(&leases.Items[0]), 1)
+}
+
+func TestKubernetesStore_GC(t *testing.T) {
+	_, hash := setupKubernetesHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	setExpiredValue(t, hash, "k", 124)
+	keysDeleted, err := hash.GC()(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, keysDeleted)
+	assert.Equal(t, map[string]string{"123": "v1"}, scanHash(t, hash, "k"))
+}
+
+func TestKubernetesStore_GCDeletesEmptyLeases(t *testing.T) {
+	client, hash := setupKubernetesHash(t)
+	store := hash.store.(*KubernetesStore)
+
+	_, err := client.CoordinationV1().Leases(testNamespace).Create(context.Background(), &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kubernetesLeaseName("k", store.owner, 0),
+			Labels: map[string]string{
+				kubernetesManagedByLabel: kubernetesManagedByValue,
+				kubernetesHashLabel:      kubernetesHash("k"),
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	keysDeleted, err := store.hashGC(context.Background(), "k")
+	require.NoError(t, err)
+	assert.Zero(t, keysDeleted)
+	assertNoLeases(t, client)
+}
+
+func TestKubernetesStore_GCAll(t *testing.T) {
+	client, hash := setupKubernetesHash(t)
+	store := hash.store.(*KubernetesStore)
+
+	// Left behind by another kas replica
+	setExpiredValue(t, NewStoreExpiringHash[string, int64](store, s2s, int64ToStr, ttl), "other", 124)
+	keysDeleted, err := store.gc(context.Background(), metav1.ListOptions{
+		LabelSelector: kubernetesManagedByLabel + "=" + kubernetesManagedByValue,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, keysDeleted)
+	assertNoLeases(t, client)
+}
+
+func TestKubernetesStore_Clear(t *testing.T) {
+	client, hash := setupKubernetesHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k1", 123, []byte("v1")))
+	require.NoError(t, hash.Set(context.Background(), "k2", 124, []byte("v2")))
+	keysDeleted, err := hash.Clear(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, keysDeleted)
+	assertNoLeases(t, client)
+}
+
+func TestKubernetesStore_Refresh(t *testing.T) {
+	_, hash := setupKubernetesHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	hash.data["k"][123].ExpiresAt = time.Now().Add(-time.Minute).Unix() // expired, but not deleted yet
+	require.NoError(t, hash.Refresh(time.Now().Add(ttl))(context.Background()))
+	assert.Equal(t, map[string]string{"123": "v1"}, scanHash(t, hash, "k"))
+}
+
+func TestKubernetesLeaseName(t *testing.T) {
+	name := kubernetesLeaseName(PrefixedInt64Key("prefix:", 1), "owner", kubernetesBucketsPerKey-1)
+	assert.Len(t, name, len(kubernetesLeaseNamePrefix)+2*kubernetesHashLen)
+	assert.NotEqual(t, kubernetesLeaseName("ab", "c", 1), kubernetesLeaseName("a", "bc", 1))
+	assert.NotEqual(t, kubernetesLeaseName("a", "b", 1), kubernetesLeaseName("a", "b", 2))
+	assert.LessOrEqual(t, len(kubernetesHash("key")), 63)
+	assert.LessOrEqual(t, len(kubernetesEntryAnnotation("grpc://127.0.0.1:8155"))-len(kubernetesEntryAnnotationPrefix), 63)
+}
+
+func setupKubernetesHash(t *testing.T) (*fake.Clientset, *StoreExpiringHash[string, int64]) {
+	client := fake.NewClientset()
+	store := NewKubernetesStore(zaptest.NewLogger(t), client, testNamespace)
+	return client, NewStoreExpiringHash[string, int64](store, s2s, int64ToStr, ttl)
+}
+
+func setExpiredValue(t *testing.T, hash *StoreExpiringHash[string, int64], key string, hashKey int64) {
+	b := hash.api.SetBuilder()
+	b.Set(key, ttl, BuilderKV[int64]{
+		HashKey: hashKey,
+		Value: &ExpiringValue{
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+			Value:     []byte("expired"),
+		},
+	})
+	require.NoError(t, b.Do(context.Background()))
+}
+
+func assertNoLeases(t *testing.T, client *fake.Clientset) {
+	leases, err := client.CoordinationV1().Leases(testNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, leases.Items)
+}
@@ -0,0 +1,25 @@
+package redistool
+
+import (
+	"context"
+	"time"
+)
+
+// MemoryErrCacher is an ErrCacher that keeps errors in a MemoryStore.
+// Errors are kept as is, there is no need to marshal them.
+type MemoryErrCacher[K any] struct {
+	Store         *MemoryStore
+	KeyToRedisKey KeyToRedisKey[K]
+}
+
+func (c *MemoryErrCacher[K]) GetError(ctx context.Context, key K) error {
+	v, ok := c.Store.get(c.KeyToRedisKey(key))
+	if !ok {
+		return nil
+	}
+	return v.(error)
+}
+
+func (c *MemoryErrCacher[K]) CacheError(ctx context.Context, key K, err error, errTtl time.Duration) {
+	c.Store.set(c.KeyToRedisKey(key), err, errTtl)
+}
@@ -0,0 +1,28 @@
+package redistool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func TestMemoryErrCacher_CachesErrorUntilItExpires(t *testing.T) {
+	store := NewMemoryStore()
+	clock := clock_testing.NewFakePassiveClock(time.Now())
+	store.clock = clock
+	c := &MemoryErrCacher[string]{
+		Store:         store,
+		KeyToRedisKey: s2s,
+	}
+	err := errors.New("boom")
+
+	assert.NoError(t, c.GetError(context.Background(), "k"))
+	c.CacheError(context.Background(), "k", err, time.Minute)
+	assert.Equal(t, err, c.GetError(context.Background(), "k"))
+	clock.SetTime(clock.Now().Add(time.Minute))
+	assert.NoError(t, c.GetError(context.Background(), "k"))
+}
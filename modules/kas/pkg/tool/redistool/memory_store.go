@@ -0,0 +1,251 @@
+package redistool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+const (
+	memoryStoreGCPeriod = time.Minute
+)
+
+// MemoryStore keeps data in the memory of the process instead of Redis.
+// Keys and expiration work the same way as in Redis, so types that use it behave like their Redis counterparts.
+// Data is not shared between processes so only a single kas replica can use it.
+// Safe for concurrent use.
+type MemoryStore struct {
+	clock  clock.PassiveClock
+	mu     sync.Mutex
+	hashes map[string]*memoryHash
+	values map[string]memoryValue
+}
+
+type memoryHash struct {
+	fields    map[string]*ExpiringValue
+	expiresAt time.Time
+}
+
+type memoryValue struct {
+	value     any
+	expiresAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		clock:  clock.RealClock{},
+		hashes: make(map[string]*memoryHash),
+		values: make(map[string]memoryValue),
+	}
+}
+
+// Run periodically deletes expired keys until ctx is done.
+// Like in Redis, expired keys are never returned, but they only free memory when deleted.
+func (s *MemoryStore) Run(ctx context.Context) {
+	t := time.NewTicker(memoryStoreGCPeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.gc()
+		}
+	}
+}
+
+func (s *MemoryStore) gc() {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, h := range s.hashes {
+		if !now.Before(h.expiresAt) {
+			delete(s.hashes, key)
+		}
+	}
+	for key, v := range s.values {
+		if !now.Before(v.expiresAt) {
+			delete(s.values, key)
+		}
+	}
+}
+
+func (s *MemoryStore) hashSet(ctx context.Context, sets []storeHashSet) error {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, set := range sets {
+		h := s.hashLocked(set.key, now)
+		if h == nil {
+			h = &memoryHash{
+				fields: make(map[string]*ExpiringValue, len(set.kvs)),
+			}
+			s.hashes[set.key] = h
+		}
+		for _, kv := range set.kvs {
+			h.fields[kv.field] = kv.value
+		}
+		h.expiresAt = now.Add(set.ttl)
+	}
+	return nil
+}
+
+func (s *MemoryStore) hashDel(ctx context.Context, key string, fields []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.hashLocked(key, s.clock.Now())
+	if h == nil {
+		return nil
+	}
+	for _, field := range fields {
+		delete(h.fields, field)
+	}
+	if len(h.fields) == 0 {
+		delete(s.hashes, key)
+	}
+	return nil
+}
+
+func (s *MemoryStore) hashScan(ctx context.Context, key string, cb ScanCallback) (int /* keysDeleted */, error) {
+	now := time.Now().Unix()
+	// Take a snapshot to not hold the lock while calling cb.
+	var kvs []storeHashKV
+	s.mu.Lock()
+	if h := s.hashLocked(key, s.clock.Now()); h != nil {
+		kvs = make([]storeHashKV, 0, len(h.fields))
+		for field, value := range h.fields {
+			kvs = append(kvs, storeHashKV{
+				field: field,
+				value: value,
+			})
+		}
+	}
+	s.mu.Unlock()
+
+	var keysToDelete []string
+	var cbErr error
+	for _, kv := range kvs {
+		if kv.value.ExpiresAt < now {
+			keysToDelete = append(keysToDelete, kv.field)
+			continue
+		}
+		var done bool
+		done, cbErr = cb(kv.field, kv.value.Value, nil)
+		if cbErr != nil || done {
+			break
+		}
+	}
+	if len(keysToDelete) == 0 {
+		return 0, cbErr
+	}
+	return s.hashDelExpired(key, now, keysToDelete), cbErr
+}
+
+func (s *MemoryStore) hashLen(ctx context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.hashLocked(key, s.clock.Now())
+	if h == nil {
+		return 0, nil
+	}
+	return int64(len(h.fields)), nil
+}
+
+func (s *MemoryStore) hashGC(ctx context.Context, key string) (int /* keysDeleted */, error) {
+	return s.hashDelExpired(key, time.Now().Unix(), nil), nil
+}
+
+// hashDelExpired deletes values of the hash that expired before now, unless they have been set concurrently.
+// Only the given fields are inspected, or all of them if fields is nil.
+func (s *MemoryStore) hashDelExpired(key string, now int64, fields []string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.hashLocked(key, s.clock.Now())
+	if h == nil {
+		return 0
+	}
+	deleted := 0
+	del := func(field string) {
+		v, ok := h.fields[field]
+		if ok && v.ExpiresAt < now {
+			delete(h.fields, field)
+			deleted++
+		}
+	}
+	if fields == nil {
+		for field := range h.fields {
+			del(field)
+		}
+	} else {
+		for _, field := range fields {
+			del(field)
+		}
+	}
+	if len(h.fields) == 0 {
+		delete(s.hashes, key)
+	}
+	return deleted
+}
+
+func (s *MemoryStore) hashLocked(key string, now time.Time) *memoryHash {
+	h := s.hashes[key]
+	if h == nil {
+		return nil
+	}
+	if !now.Before(h.expiresAt) {
+		delete(s.hashes, key)
+		return nil
+	}
+	return h
+}
+
+// get is GET.
+func (s *MemoryStore) get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.valueLocked(key, s.clock.Now())
+	return v.value, ok
+}
+
+// set is SET with PX.
+func (s *MemoryStore) set(key string, value any, ttl time.Duration) {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = memoryValue{
+		value:     value,
+		expiresAt: now.Add(ttl),
+	}
+}
+
+// incrBelow increments the counter and sets its TTL unless the counter has already reached limit.
+// Returns the counter value before the increment and whether it was incremented.
+func (s *MemoryStore) incrBelow(key string, limit uint64, ttl time.Duration) (uint64, bool) {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, _ := s.valueLocked(key, now)
+	count, _ := v.value.(uint64)
+	if count >= limit {
+		return count, false
+	}
+	s.values[key] = memoryValue{
+		value:     count + 1,
+		expiresAt: now.Add(ttl),
+	}
+	return count, true
+}
+
+func (s *MemoryStore) valueLocked(key string, now time.Time) (memoryValue, bool) {
+	v, ok := s.values[key]
+	if !ok {
+		return memoryValue{}, false
+	}
+	if !now.Before(v.expiresAt) {
+		delete(s.values, key)
+		return memoryValue{}, false
+	}
+	return v, true
+}
@@ -0,0 +1,49 @@
+package redistool
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
+)
+
+// MemoryTokenLimiter is a TokenLimiter that keeps minute buckets in a MemoryStore.
+type MemoryTokenLimiter struct {
+	store          *MemoryStore
+	keyPrefix      string
+	limitPerMinute uint64
+	limitExceeded  prometheus.Counter
+	getApi         func(context.Context) RpcApi
+}
+
+// NewMemoryTokenLimiter returns a new MemoryTokenLimiter
+func NewMemoryTokenLimiter(store *MemoryStore, keyPrefix string,
+	limitPerMinute uint64, limitExceeded prometheus.Counter, getApi func(context.Context) RpcApi) *MemoryTokenLimiter {
+	return &MemoryTokenLimiter{
+		store:          store,
+		keyPrefix:      keyPrefix,
+		limitPerMinute: limitPerMinute,
+		limitExceeded:  limitExceeded,
+		getApi:         getApi,
+	}
+}
+
+// Allow consumes one limitable event from the token in the context
+func (l *MemoryTokenLimiter) Allow(ctx context.Context) bool {
+	api := l.getApi(ctx)
+	key := buildTokenLimiterKey(l.keyPrefix, api.RequestKey(), byte(l.store.clock.Now().UTC().Minute()))
+	count, ok := l.store.incrBelow(key, l.limitPerMinute, tokenLimiterBucketTtl)
+	if !ok {
+		l.limitExceeded.Inc()
+		api.Log().Debug("redistool.MemoryTokenLimiter: rate limit exceeded",
+			logz.RedisKey([]byte(key)), logz.U64Count(count), logz.TokenLimit(l.limitPerMinute))
+		return false
+	}
+	return true
+}
+
+func (l *MemoryTokenLimiter) ResetTime() time.Time {
+	return windowResetTime(l.store.clock.Now())
+}
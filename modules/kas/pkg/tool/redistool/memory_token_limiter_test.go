@@ -0,0 +1,60 @@
+package redistool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func TestMemoryTokenLimiter_Limit(t *testing.T) {
+	ctx, _, limiter := setupMemoryLimiter(t)
+
+	assert.True(t, limiter.Allow(ctx))
+	assert.True(t, limiter.Allow(ctx))
+	assert.False(t, limiter.Allow(ctx), "Do not allow when all tokens have been consumed")
+}
+
+func TestMemoryTokenLimiter_NextMinute(t *testing.T) {
+	ctx, store, limiter := setupMemoryLimiter(t)
+	clock := store.clock.(*clock_testing.FakePassiveClock)
+
+	assert.True(t, limiter.Allow(ctx))
+	assert.True(t, limiter.Allow(ctx))
+	clock.SetTime(clock.Now().Add(time.Minute))
+	assert.True(t, limiter.Allow(ctx), "Allow in the next minute")
+	store.gc()
+	assert.Len(t, store.values, 1, "Previous minute bucket has expired")
+}
+
+func TestMemoryTokenLimiter_ResetTime(t *testing.T) {
+	_, _, limiter := setupMemoryLimiter(t)
+
+	assert.Equal(t, time.Date(2026, 10, 18, 12, 1, 0, 0, time.UTC), limiter.ResetTime())
+}
+
+func setupMemoryLimiter(t *testing.T) (context.Context, *MemoryStore, *MemoryTokenLimiter) {
+	ctrl := gomock.NewController(t)
+	rpcApi := NewMockRpcApi(ctrl)
+	rpcApi.EXPECT().
+		Log().
+		Return(zaptest.NewLogger(t)).
+		AnyTimes()
+	rpcApi.EXPECT().
+		RequestKey().
+		Return([]byte{1, 2, 3}).
+		AnyTimes()
+	store := NewMemoryStore()
+	store.clock = clock_testing.NewFakePassiveClock(time.Date(2026, 10, 18, 12, 0, 30, 0, time.UTC))
+	limiter := NewMemoryTokenLimiter(store, "key_prefix", 2, prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "test",
+	}), func(ctx context.Context) RpcApi {
+		return rpcApi
+	})
+	return context.Background(), store, limiter
+}
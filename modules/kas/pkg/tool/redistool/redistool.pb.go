@@ -122,6 +122,59 @@ func (x *ExpiringValueTimestamp) GetExpiresAt() int64 {
 	return 0
 }
 
+// KubernetesHashEntry is a hash key and its value, as stored in an annotation of a Lease object.
+type KubernetesHashEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Value         *ExpiringValue         `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KubernetesHashEntry) Reset() {
+	*x = KubernetesHashEntry{}
+	mi := &file_pkg_tool_redistool_redistool_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubernetesHashEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubernetesHashEntry) ProtoMessage() {}
+
+func (x *KubernetesHashEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tool_redistool_redistool_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubernetesHashEntry.ProtoReflect.Descriptor instead.
+func (*KubernetesHashEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_tool_redistool_redistool_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KubernetesHashEntry) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *KubernetesHashEntry) GetValue() *ExpiringValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 var File_pkg_tool_redistool_redistool_proto protoreflect.FileDescriptor
 
 const file_pkg_tool_redistool_redistool_proto_rawDesc = "" +
@@ -133,7 +186,10 @@ const file_pkg_tool_redistool_redistool_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\fR\x05value\"7\n" +
 	"\x16ExpiringValueTimestamp\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\x01 \x01(\x03R\texpiresAtB9Z7github.com/pluralsh/kubernetes-agent/pkg/tool/redistoolb\x06proto3"
+	"expires_at\x18\x01 \x01(\x03R\texpiresAt\"h\n" +
+	"\x13KubernetesHashEntry\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12;\n" +
+	"\x05value\x18\x02 \x01(\v2%.plural.agent.redistool.ExpiringValueR\x05valueB9Z7github.com/pluralsh/kubernetes-agent/pkg/tool/redistoolb\x06proto3"
 
 var (
 	file_pkg_tool_redistool_redistool_proto_rawDescOnce sync.Once
@@ -147,17 +203,19 @@ func file_pkg_tool_redistool_redistool_proto_rawDescGZIP() []byte {
 	return file_pkg_tool_redistool_redistool_proto_rawDescData
 }
 
-var file_pkg_tool_redistool_redistool_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_tool_redistool_redistool_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_pkg_tool_redistool_redistool_proto_goTypes = []any{
 	(*ExpiringValue)(nil),          // 0: plural.agent.redistool.ExpiringValue
 	(*ExpiringValueTimestamp)(nil), // 1: plural.agent.redistool.ExpiringValueTimestamp
+	(*KubernetesHashEntry)(nil),    // 2: plural.agent.redistool.KubernetesHashEntry
 }
 var file_pkg_tool_redistool_redistool_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: plural.agent.redistool.KubernetesHashEntry.value:type_name -> plural.agent.redistool.ExpiringValue
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_pkg_tool_redistool_redistool_proto_init() }
@@ -171,7 +229,7 @@ func file_pkg_tool_redistool_redistool_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_tool_redistool_redistool_proto_rawDesc), len(file_pkg_tool_redistool_redistool_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
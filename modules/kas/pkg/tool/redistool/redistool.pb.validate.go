@@ -242,3 +242,136 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ExpiringValueTimestampValidationError{}
+
+// Validate checks the field values on KubernetesHashEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *KubernetesHashEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KubernetesHashEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// KubernetesHashEntryMultiError, or nil if none found.
+func (m *KubernetesHashEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KubernetesHashEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Field
+
+	if all {
+		switch v := interface{}(m.GetValue()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, KubernetesHashEntryValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, KubernetesHashEntryValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return KubernetesHashEntryValidationError{
+				field:  "Value",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return KubernetesHashEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// KubernetesHashEntryMultiError is an error wrapping multiple validation
+// errors returned by KubernetesHashEntry.ValidateAll() if the designated
+// constraints aren't met.
+type KubernetesHashEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KubernetesHashEntryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KubernetesHashEntryMultiError) AllErrors() []error { return m }
+
+// KubernetesHashEntryValidationError is the validation error returned by
+// KubernetesHashEntry.Validate if the designated constraints aren't met.
+type KubernetesHashEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KubernetesHashEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KubernetesHashEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KubernetesHashEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KubernetesHashEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KubernetesHashEntryValidationError) ErrorName() string {
+	return "KubernetesHashEntryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e KubernetesHashEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKubernetesHashEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KubernetesHashEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KubernetesHashEntryValidationError{}
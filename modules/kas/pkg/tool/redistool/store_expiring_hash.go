@@ -0,0 +1,109 @@
+package redistool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// StoreExpiringHash is an ExpiringHash that keeps data in a HashStore.
+type StoreExpiringHash[K1 comparable, K2 comparable] struct {
+	store          HashStore
+	key1ToRedisKey KeyToRedisKey[K1]
+	key2ToRedisKey KeyToRedisKey[K2]
+	ttl            time.Duration
+	api            StoreExpiringHashApi[K1, K2]
+	data           map[K1]map[K2]*ExpiringValue // key -> hash key -> value
+}
+
+func NewStoreExpiringHash[K1 comparable, K2 comparable](store HashStore, key1ToRedisKey KeyToRedisKey[K1],
+	key2ToRedisKey KeyToRedisKey[K2], ttl time.Duration) *StoreExpiringHash[K1, K2] {
+	return &StoreExpiringHash[K1, K2]{
+		store:          store,
+		key1ToRedisKey: key1ToRedisKey,
+		key2ToRedisKey: key2ToRedisKey,
+		ttl:            ttl,
+		api: StoreExpiringHashApi[K1, K2]{
+			Store:          store,
+			Key1ToRedisKey: key1ToRedisKey,
+			Key2ToRedisKey: key2ToRedisKey,
+		},
+		data: make(map[K1]map[K2]*ExpiringValue),
+	}
+}
+
+func (h *StoreExpiringHash[K1, K2]) Set(ctx context.Context, key K1, hashKey K2, value []byte) error {
+	ev := &ExpiringValue{
+		ExpiresAt: time.Now().Add(h.ttl).Unix(),
+		Value:     value,
+	}
+	setData(h.data, key, hashKey, ev)
+
+	b := h.api.SetBuilder()
+	b.Set(key, h.ttl, BuilderKV[K2]{
+		HashKey: hashKey,
+		Value:   ev,
+	})
+	return b.Do(ctx)
+}
+
+func (h *StoreExpiringHash[K1, K2]) Unset(ctx context.Context, key K1, hashKey K2) error {
+	unsetData(h.data, key, hashKey)
+	return h.api.Unset(ctx, key, hashKey)
+}
+
+func (h *StoreExpiringHash[K1, K2]) Forget(key K1, hashKey K2) {
+	unsetData(h.data, key, hashKey)
+}
+
+func (h *StoreExpiringHash[K1, K2]) Scan(ctx context.Context, key K1, cb ScanCallback) (int /* keysDeleted */, error) {
+	return h.api.Scan(ctx, key, cb)
+}
+
+func (h *StoreExpiringHash[K1, K2]) Len(ctx context.Context, key K1) (int64, error) {
+	return h.store.hashLen(ctx, h.key1ToRedisKey(key))
+}
+
+func (h *StoreExpiringHash[K1, K2]) GC() func(context.Context) (int /* keysDeleted */, error) {
+	// Copy keys for safe concurrent access.
+	keys := make([]string, 0, len(h.data))
+	for key := range h.data {
+		keys = append(keys, h.key1ToRedisKey(key))
+	}
+	return func(ctx context.Context) (int, error) {
+		var deletedKeys int
+		var errs []error
+		for _, key := range keys {
+			deleted, err := h.store.hashGC(ctx, key)
+			deletedKeys += deleted
+			if err != nil {
+				errs = append(errs, err) // Try to GC next key
+			}
+		}
+		return deletedKeys, errors.Join(errs...)
+	}
+}
+
+func (h *StoreExpiringHash[K1, K2]) Clear(ctx context.Context) (int, error) {
+	var errs []error
+	keysDeleted := 0
+	for k1, m := range h.data {
+		toDel := make([]string, 0, len(m))
+		for k2 := range m {
+			toDel = append(toDel, h.key2ToRedisKey(k2))
+		}
+		err := h.store.hashDel(ctx, h.key1ToRedisKey(k1), toDel)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		delete(h.data, k1)
+		keysDeleted += len(toDel)
+	}
+	return keysDeleted, errors.Join(errs...)
+}
+
+func (h *StoreExpiringHash[K1, K2]) Refresh(nextRefresh time.Time) func(context.Context) error {
+	b := h.api.SetBuilder()
+	refreshData(h.data, b, h.ttl, nextRefresh)
+	return b.Do
+}
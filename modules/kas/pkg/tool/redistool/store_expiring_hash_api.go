@@ -0,0 +1,92 @@
+package redistool
+
+import (
+	"context"
+	"time"
+)
+
+// HashStore keeps two-level hashes of ExpiringValue: key -> hash key -> value. It is an alternative to Redis.
+// Keys and hash keys are strings converted with KeyToRedisKey, like in Redis.
+type HashStore interface {
+	// hashSet is HSET followed by PEXPIRE for each of the sets.
+	// The store takes ownership of the values.
+	hashSet(ctx context.Context, sets []storeHashSet) error
+	// hashDel is HDEL.
+	hashDel(ctx context.Context, key string, fields []string) error
+	// hashScan calls cb for each value of the hash that has not expired. It deletes values that have expired.
+	hashScan(ctx context.Context, key string, cb ScanCallback) (int /* keysDeleted */, error)
+	// hashLen is HLEN.
+	hashLen(ctx context.Context, key string) (int64, error)
+	// hashGC deletes values of the hash that have expired.
+	hashGC(ctx context.Context, key string) (int /* keysDeleted */, error)
+}
+
+type storeHashKV struct {
+	field string
+	value *ExpiringValue
+}
+
+type storeHashSet struct {
+	key string
+	ttl time.Duration
+	kvs []storeHashKV
+}
+
+// StoreExpiringHashApi is an ExpiringHashApi that keeps data in a HashStore.
+type StoreExpiringHashApi[K1 any, K2 any] struct {
+	Store          HashStore
+	Key1ToRedisKey KeyToRedisKey[K1]
+	Key2ToRedisKey KeyToRedisKey[K2]
+}
+
+func (h *StoreExpiringHashApi[K1, K2]) SetBuilder() SetBuilder[K1, K2] {
+	return &StoreSetBuilder[K1, K2]{
+		store:          h.Store,
+		key1ToRedisKey: h.Key1ToRedisKey,
+		key2ToRedisKey: h.Key2ToRedisKey,
+	}
+}
+
+func (h *StoreExpiringHashApi[K1, K2]) Unset(ctx context.Context, key K1, hashKey K2) error {
+	return h.Store.hashDel(ctx, h.Key1ToRedisKey(key), []string{h.Key2ToRedisKey(hashKey)})
+}
+
+func (h *StoreExpiringHashApi[K1, K2]) Scan(ctx context.Context, key K1, cb ScanCallback) (int /* keysDeleted */, error) {
+	return h.Store.hashScan(ctx, h.Key1ToRedisKey(key), cb)
+}
+
+type StoreSetBuilder[K1 any, K2 any] struct {
+	store          HashStore
+	key1ToRedisKey KeyToRedisKey[K1]
+	key2ToRedisKey KeyToRedisKey[K2]
+	sets           []storeHashSet
+}
+
+func (b *StoreSetBuilder[K1, K2]) Set(key K1, ttl time.Duration, kvs ...BuilderKV[K2]) {
+	if len(kvs) == 0 {
+		return
+	}
+	set := storeHashSet{
+		key: b.key1ToRedisKey(key),
+		ttl: ttl,
+		kvs: make([]storeHashKV, 0, len(kvs)),
+	}
+	for _, kv := range kvs {
+		set.kvs = append(set.kvs, storeHashKV{
+			field: b.key2ToRedisKey(kv.HashKey),
+			// Copy the value as the caller may mutate it later, like RedisSetBuilder does by marshaling it.
+			value: &ExpiringValue{
+				ExpiresAt: kv.Value.ExpiresAt,
+				Value:     kv.Value.Value,
+			},
+		})
+	}
+	b.sets = append(b.sets, set)
+}
+
+func (b *StoreSetBuilder[K1, K2]) Do(ctx context.Context) error {
+	if len(b.sets) == 0 {
+		return nil
+	}
+	return b.store.hashSet(ctx, b.sets)
+}
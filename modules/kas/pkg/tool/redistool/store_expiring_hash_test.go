@@ -0,0 +1,153 @@
+package redistool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+var (
+	_ ExpiringHash[int, int]    = &StoreExpiringHash[int, int]{}
+	_ ExpiringHashApi[int, int] = &StoreExpiringHashApi[int, int]{}
+	_ SetBuilder[int, int]      = &StoreSetBuilder[int, int]{}
+)
+
+func TestStoreExpiringHash_SetScan(t *testing.T) {
+	_, hash := setupMemoryHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash.Set(context.Background(), "k", 124, []byte("v2")))
+	assert.Equal(t, map[string]string{"123": "v1", "124": "v2"}, scanHash(t, hash, "k"))
+	size, err := hash.Len(context.Background(), "k")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, size)
+}
+
+func TestStoreExpiringHash_Unset(t *testing.T) {
+	_, hash := setupMemoryHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash.Unset(context.Background(), "k", 123))
+	assert.Empty(t, scanHash(t, hash, "k"))
+	assert.Empty(t, hash.data)
+}
+
+func TestStoreExpiringHash_ForgetKeepsStoredData(t *testing.T) {
+	_, hash := setupMemoryHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	hash.Forget("k", 123)
+	assert.Empty(t, hash.data)
+	assert.Equal(t, map[string]string{"123": "v1"}, scanHash(t, hash, "k"))
+}
+
+func TestStoreExpiringHash_ScanDeletesExpiredValues(t *testing.T) {
+	store, hash := setupMemoryHash(t)
+
+	b := hash.api.SetBuilder()
+	b.Set("k", time.Minute, BuilderKV[int64]{
+		HashKey: 123,
+		Value: &ExpiringValue{
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+			Value:     []byte("v1"),
+		},
+	})
+	require.NoError(t, b.Do(context.Background()))
+	keysDeleted, err := hash.Scan(context.Background(), "k", func(rawHashKey string, value []byte, err error) (bool, error) {
+		assert.FailNow(t, "unexpected callback invocation")
+		return false, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, keysDeleted)
+	assert.Empty(t, store.hashes)
+}
+
+func TestStoreExpiringHash_HashExpires(t *testing.T) {
+	store, hash := setupMemoryHash(t)
+	clock := store.clock.(*clock_testing.FakePassiveClock)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	clock.SetTime(clock.Now().Add(ttl))
+	assert.Empty(t, scanHash(t, hash, "k"))
+	store.gc()
+	assert.Empty(t, store.hashes)
+}
+
+func TestStoreExpiringHash_GC(t *testing.T) {
+	store, hash := setupMemoryHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	b := hash.api.SetBuilder()
+	b.Set("k", time.Minute, BuilderKV[int64]{
+		HashKey: 124,
+		Value: &ExpiringValue{
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+			Value:     []byte("v2"),
+		},
+	})
+	require.NoError(t, b.Do(context.Background()))
+	assert.Len(t, store.hashes["k"].fields, 2)
+	keysDeleted, err := hash.GC()(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, keysDeleted)
+	assert.Equal(t, map[string]string{"123": "v1"}, scanHash(t, hash, "k"))
+}
+
+func TestStoreExpiringHash_Refresh(t *testing.T) {
+	store, hash := setupMemoryHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k", 123, []byte("v1")))
+	ev := hash.data["k"][123]
+	ev.ExpiresAt = time.Now().Unix() // expires before next refresh
+	require.NoError(t, hash.Refresh(time.Now().Add(ttl))(context.Background()))
+	assert.Greater(t, ev.ExpiresAt, time.Now().Unix())
+	stored := store.hashes["k"].fields["123"]
+	assert.Equal(t, ev.ExpiresAt, stored.ExpiresAt)
+	assert.NotSame(t, ev, stored)
+}
+
+func TestStoreExpiringHash_Clear(t *testing.T) {
+	store, hash := setupMemoryHash(t)
+
+	require.NoError(t, hash.Set(context.Background(), "k1", 123, []byte("v1")))
+	require.NoError(t, hash.Set(context.Background(), "k2", 124, []byte("v2")))
+	keysDeleted, err := hash.Clear(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, keysDeleted)
+	assert.Empty(t, hash.data)
+	assert.Empty(t, store.hashes)
+}
+
+func TestStoreExpiringHash_SharedStore(t *testing.T) {
+	store, hash1 := setupMemoryHash(t)
+	hash2 := NewStoreExpiringHash[string, int64](store, s2s, int64ToStr, ttl)
+
+	require.NoError(t, hash1.Set(context.Background(), "k", 123, []byte("v1")))
+	require.NoError(t, hash2.Set(context.Background(), "k", 124, []byte("v2")))
+	assert.Equal(t, map[string]string{"123": "v1", "124": "v2"}, scanHash(t, hash1, "k"))
+	hash1.Forget("k", 123) // e.g. hash1 is gone
+	_, err := hash2.Clear(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"123": "v1"}, scanHash(t, hash2, "k"))
+}
+
+func setupMemoryHash(t *testing.T) (*MemoryStore, *StoreExpiringHash[string, int64]) {
+	store := NewMemoryStore()
+	store.clock = clock_testing.NewFakePassiveClock(time.Now())
+	return store, NewStoreExpiringHash[string, int64](store, s2s, int64ToStr, ttl)
+}
+
+func scanHash(t *testing.T, hash *StoreExpiringHash[string, int64], key string) map[string]string {
+	res := map[string]string{}
+	_, err := hash.Scan(context.Background(), key, func(rawHashKey string, value []byte, err error) (bool, error) {
+		require.NoError(t, err)
+		res[rawHashKey] = string(value)
+		return false, nil
+	})
+	require.NoError(t, err)
+	return res
+}
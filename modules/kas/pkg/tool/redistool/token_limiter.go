@@ -3,6 +3,7 @@ package redistool
 import (
 	"context"
 	"errors"
+	"time"
 	"unsafe"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,12 +14,24 @@ import (
 	"github.com/pluralsh/kubernetes-agent/pkg/tool/logz"
 )
 
+const (
+	// tokenLimiterBucketTtl is how long a minute bucket is kept. It must expire before the same minute of the next hour.
+	tokenLimiterBucketTtl = 59 * time.Second
+)
+
 type RpcApi interface {
 	Log() *zap.Logger
 	HandleProcessingError(msg string, err error)
 	RequestKey() []byte
 }
 
+// WindowLimiter is a limiter that counts events in fixed one minute windows.
+type WindowLimiter interface {
+	Allow(context.Context) bool
+	// ResetTime returns when the current window ends and the limit is reset.
+	ResetTime() time.Time
+}
+
 // TokenLimiter is a redis-based rate limiter implementing the algorithm in https://redislabs.com/redis-best-practices/basic-rate-limiting/
 type TokenLimiter struct {
 	redisClient    rueidis.Client
@@ -66,7 +79,7 @@ func (l *TokenLimiter) Allow(ctx context.Context) bool {
 	resp := l.redisClient.DoMulti(ctx,
 		l.redisClient.B().Multi().Build(),
 		l.redisClient.B().Incr().Key(key).Build(),
-		l.redisClient.B().Expire().Key(key).Seconds(59).Build(),
+		l.redisClient.B().Expire().Key(key).Seconds(int64(tokenLimiterBucketTtl.Seconds())).Build(),
 		l.redisClient.B().Exec().Build(),
 	)
 	err = errors.Join(MultiErrors(resp)...)
@@ -78,6 +91,15 @@ func (l *TokenLimiter) Allow(ctx context.Context) bool {
 	return true
 }
 
+func (l *TokenLimiter) ResetTime() time.Time {
+	return windowResetTime(l.clock.Now())
+}
+
+// windowResetTime returns the start of the minute after now. Minute buckets are keyed by the UTC minute.
+func windowResetTime(now time.Time) time.Time {
+	return now.UTC().Truncate(time.Minute).Add(time.Minute)
+}
+
 func buildTokenLimiterKey(keyPrefix string, requestKey []byte, currentMinute byte) string {
 	result := make([]byte, 0, len(keyPrefix)+1+len(requestKey)+1+1)
 	result = append(result, keyPrefix...)
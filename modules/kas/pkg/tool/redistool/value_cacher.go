@@ -0,0 +1,68 @@
+package redistool
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/rueidis"
+	"go.uber.org/zap"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/errz"
+)
+
+// ValueCacher keeps values for a limited amount of time.
+// Failures are reported and not returned since a missing value must be handled anyway.
+type ValueCacher[K any] interface {
+	// GetValue returns the cached value or nil if there is none.
+	GetValue(ctx context.Context, key K) []byte
+	// CacheValue keeps the value for ttl.
+	CacheValue(ctx context.Context, key K, value []byte, ttl time.Duration)
+}
+
+type RedisValueCacher[K any] struct {
+	Log           *zap.Logger
+	ErrRep        errz.ErrReporter
+	Client        rueidis.Client
+	KeyToRedisKey KeyToRedisKey[K]
+}
+
+func (c *RedisValueCacher[K]) GetValue(ctx context.Context, key K) []byte {
+	getCmd := c.Client.B().Get().Key(c.KeyToRedisKey(key)).Build()
+	result, err := c.Client.Do(ctx, getCmd).AsBytes()
+	if err != nil {
+		if err != rueidis.Nil { // nolint:errorlint
+			c.ErrRep.HandleProcessingError(ctx, c.Log, "Failed to get cached value from Redis", err)
+		}
+		return nil
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (c *RedisValueCacher[K]) CacheValue(ctx context.Context, key K, value []byte, ttl time.Duration) {
+	setCmd := c.Client.B().Set().Key(c.KeyToRedisKey(key)).Value(rueidis.BinaryString(value)).Px(ttl).Build()
+	err := c.Client.Do(ctx, setCmd).Error()
+	if err != nil {
+		c.ErrRep.HandleProcessingError(ctx, c.Log, "Failed to cache value in Redis", err)
+	}
+}
+
+// MemoryValueCacher is a ValueCacher that keeps values in a MemoryStore.
+type MemoryValueCacher[K any] struct {
+	Store         *MemoryStore
+	KeyToRedisKey KeyToRedisKey[K]
+}
+
+func (c *MemoryValueCacher[K]) GetValue(ctx context.Context, key K) []byte {
+	v, ok := c.Store.get(c.KeyToRedisKey(key))
+	if !ok {
+		return nil
+	}
+	return v.([]byte)
+}
+
+func (c *MemoryValueCacher[K]) CacheValue(ctx context.Context, key K, value []byte, ttl time.Duration) {
+	c.Store.set(c.KeyToRedisKey(key), value, ttl)
+}
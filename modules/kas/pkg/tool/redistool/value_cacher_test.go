@@ -0,0 +1,98 @@
+package redistool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	rmock "github.com/redis/rueidis/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+	clock_testing "k8s.io/utils/clock/testing"
+
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/matcher"
+	"github.com/pluralsh/kubernetes-agent/pkg/tool/testing/mock_tool"
+)
+
+const (
+	valueKey = "test1"
+)
+
+var (
+	_ ValueCacher[string] = (*RedisValueCacher[string])(nil)
+	_ ValueCacher[string] = (*MemoryValueCacher[string])(nil)
+)
+
+func TestRedisValueCacher_GetValue_ReturnsNilOnClientError(t *testing.T) {
+	vc, client, rep := setupValueCacher(t)
+	client.EXPECT().
+		Do(gomock.Any(), rmock.Match("GET", valueKey)).
+		Return(rmock.ErrorResult(errors.New("boom")))
+	rep.EXPECT().
+		HandleProcessingError(gomock.Any(), gomock.Any(), "Failed to get cached value from Redis", matcher.ErrorEq("boom"))
+	assert.Nil(t, vc.GetValue(context.Background(), valueKey))
+}
+
+func TestRedisValueCacher_GetValue_ReturnsNilOnClientNil(t *testing.T) {
+	vc, client, _ := setupValueCacher(t)
+	client.EXPECT().
+		Do(gomock.Any(), rmock.Match("GET", valueKey)).
+		Return(rmock.Result(rmock.RedisNil()))
+	assert.Nil(t, vc.GetValue(context.Background(), valueKey))
+}
+
+func TestRedisValueCacher_GetValue_ReturnsCachedValue(t *testing.T) {
+	vc, client, _ := setupValueCacher(t)
+	client.EXPECT().
+		Do(gomock.Any(), rmock.Match("GET", valueKey)).
+		Return(rmock.Result(rmock.RedisString("value")))
+	assert.Equal(t, []byte("value"), vc.GetValue(context.Background(), valueKey))
+}
+
+func TestRedisValueCacher_CacheValue_HappyPath(t *testing.T) {
+	vc, client, _ := setupValueCacher(t)
+	client.EXPECT().
+		Do(gomock.Any(), rmock.Match("SET", valueKey, "value", "PX", "60000"))
+	vc.CacheValue(context.Background(), valueKey, []byte("value"), time.Minute)
+}
+
+func TestRedisValueCacher_CacheValue_ClientError(t *testing.T) {
+	vc, client, rep := setupValueCacher(t)
+	client.EXPECT().
+		Do(gomock.Any(), rmock.Match("SET", valueKey, "value", "PX", "60000")).
+		Return(rmock.ErrorResult(errors.New("boom")))
+	rep.EXPECT().
+		HandleProcessingError(gomock.Any(), gomock.Any(), "Failed to cache value in Redis", matcher.ErrorEq("boom"))
+	vc.CacheValue(context.Background(), valueKey, []byte("value"), time.Minute)
+}
+
+func TestMemoryValueCacher_CachesValueUntilItExpires(t *testing.T) {
+	store := NewMemoryStore()
+	clock := clock_testing.NewFakePassiveClock(time.Now())
+	store.clock = clock
+	c := &MemoryValueCacher[string]{
+		Store:         store,
+		KeyToRedisKey: s2s,
+	}
+
+	assert.Nil(t, c.GetValue(context.Background(), "k"))
+	c.CacheValue(context.Background(), "k", []byte("value"), time.Minute)
+	assert.Equal(t, []byte("value"), c.GetValue(context.Background(), "k"))
+	clock.SetTime(clock.Now().Add(time.Minute))
+	assert.Nil(t, c.GetValue(context.Background(), "k"))
+}
+
+func setupValueCacher(t *testing.T) (*RedisValueCacher[string], *rmock.Client, *mock_tool.MockErrReporter) {
+	ctrl := gomock.NewController(t)
+	client := rmock.NewClient(ctrl)
+	rep := mock_tool.NewMockErrReporter(ctrl)
+	vc := &RedisValueCacher[string]{
+		Log:           zaptest.NewLogger(t),
+		ErrRep:        rep,
+		Client:        client,
+		KeyToRedisKey: s2s,
+	}
+	return vc, client, rep
+}
@@ -0,0 +1,3 @@
+package mock_agent_control
+
+//go:generate mockgen.sh -destination "rpc.go" -package "mock_agent_control" "github.com/pluralsh/kubernetes-agent/pkg/module/agent_control/rpc" "AgentControlClient,AgentControl_ExecuteClient,AgentControl_ExecuteServer,AgentControlApi_ExecuteServer"
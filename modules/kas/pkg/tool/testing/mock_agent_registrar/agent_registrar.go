@@ -85,3 +85,47 @@ func (c *MockAgentRegistrarClientRegisterCall) DoAndReturn(f func(context.Contex
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Unregister mocks base method.
+func (m *MockAgentRegistrarClient) Unregister(ctx context.Context, in *rpc.UnregisterRequest, opts ...grpc.CallOption) (*rpc.UnregisterResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Unregister", varargs...)
+	ret0, _ := ret[0].(*rpc.UnregisterResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unregister indicates an expected call of Unregister.
+func (mr *MockAgentRegistrarClientMockRecorder) Unregister(ctx, in any, opts ...any) *MockAgentRegistrarClientUnregisterCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unregister", reflect.TypeOf((*MockAgentRegistrarClient)(nil).Unregister), varargs...)
+	return &MockAgentRegistrarClientUnregisterCall{Call: call}
+}
+
+// MockAgentRegistrarClientUnregisterCall wrap *gomock.Call
+type MockAgentRegistrarClientUnregisterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAgentRegistrarClientUnregisterCall) Return(arg0 *rpc.UnregisterResponse, arg1 error) *MockAgentRegistrarClientUnregisterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAgentRegistrarClientUnregisterCall) Do(f func(context.Context, *rpc.UnregisterRequest, ...grpc.CallOption) (*rpc.UnregisterResponse, error)) *MockAgentRegistrarClientUnregisterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAgentRegistrarClientUnregisterCall) DoAndReturn(f func(context.Context, *rpc.UnregisterRequest, ...grpc.CallOption) (*rpc.UnregisterResponse, error)) *MockAgentRegistrarClientUnregisterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -41,6 +41,44 @@ func (m *MockTracker) EXPECT() *MockTrackerMockRecorder {
 	return m.recorder
 }
 
+// GetConnectedAgents mocks base method.
+func (m *MockTracker) GetConnectedAgents(ctx context.Context, cb agent_tracker.ConnectedAgentCallback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectedAgents", ctx, cb)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetConnectedAgents indicates an expected call of GetConnectedAgents.
+func (mr *MockTrackerMockRecorder) GetConnectedAgents(ctx, cb any) *MockTrackerGetConnectedAgentsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectedAgents", reflect.TypeOf((*MockTracker)(nil).GetConnectedAgents), ctx, cb)
+	return &MockTrackerGetConnectedAgentsCall{Call: call}
+}
+
+// MockTrackerGetConnectedAgentsCall wrap *gomock.Call
+type MockTrackerGetConnectedAgentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTrackerGetConnectedAgentsCall) Return(arg0 error) *MockTrackerGetConnectedAgentsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTrackerGetConnectedAgentsCall) Do(f func(context.Context, agent_tracker.ConnectedAgentCallback) error) *MockTrackerGetConnectedAgentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTrackerGetConnectedAgentsCall) DoAndReturn(f func(context.Context, agent_tracker.ConnectedAgentCallback) error) *MockTrackerGetConnectedAgentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetConnectedAgentsCount mocks base method.
 func (m *MockTracker) GetConnectedAgentsCount(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +118,82 @@ func (c *MockTrackerGetConnectedAgentsCountCall) DoAndReturn(f func(context.Cont
 	return c
 }
 
+// GetConnectionHistory mocks base method.
+func (m *MockTracker) GetConnectionHistory(ctx context.Context, agentId int64, cb agent_tracker.ConnectionHistoryCallback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectionHistory", ctx, agentId, cb)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetConnectionHistory indicates an expected call of GetConnectionHistory.
+func (mr *MockTrackerMockRecorder) GetConnectionHistory(ctx, agentId, cb any) *MockTrackerGetConnectionHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionHistory", reflect.TypeOf((*MockTracker)(nil).GetConnectionHistory), ctx, agentId, cb)
+	return &MockTrackerGetConnectionHistoryCall{Call: call}
+}
+
+// MockTrackerGetConnectionHistoryCall wrap *gomock.Call
+type MockTrackerGetConnectionHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTrackerGetConnectionHistoryCall) Return(arg0 error) *MockTrackerGetConnectionHistoryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTrackerGetConnectionHistoryCall) Do(f func(context.Context, int64, agent_tracker.ConnectionHistoryCallback) error) *MockTrackerGetConnectionHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTrackerGetConnectionHistoryCall) DoAndReturn(f func(context.Context, int64, agent_tracker.ConnectionHistoryCallback) error) *MockTrackerGetConnectionHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetConnections mocks base method.
+func (m *MockTracker) GetConnections(ctx context.Context, cb agent_tracker.ConnectedAgentInfoCallback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnections", ctx, cb)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetConnections indicates an expected call of GetConnections.
+func (mr *MockTrackerMockRecorder) GetConnections(ctx, cb any) *MockTrackerGetConnectionsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnections", reflect.TypeOf((*MockTracker)(nil).GetConnections), ctx, cb)
+	return &MockTrackerGetConnectionsCall{Call: call}
+}
+
+// MockTrackerGetConnectionsCall wrap *gomock.Call
+type MockTrackerGetConnectionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTrackerGetConnectionsCall) Return(arg0 error) *MockTrackerGetConnectionsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTrackerGetConnectionsCall) Do(f func(context.Context, agent_tracker.ConnectedAgentInfoCallback) error) *MockTrackerGetConnectionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTrackerGetConnectionsCall) DoAndReturn(f func(context.Context, agent_tracker.ConnectedAgentInfoCallback) error) *MockTrackerGetConnectionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetConnectionsByAgentId mocks base method.
 func (m *MockTracker) GetConnectionsByAgentId(ctx context.Context, agentId int64, cb agent_tracker.ConnectedAgentInfoCallback) error {
 	m.ctrl.T.Helper()
@@ -195,17 +309,17 @@ func (c *MockTrackerRunCall) DoAndReturn(f func(context.Context) error) *MockTra
 }
 
 // UnregisterConnection mocks base method.
-func (m *MockTracker) UnregisterConnection(ctx context.Context, info *agent_tracker.ConnectedAgentInfo) error {
+func (m *MockTracker) UnregisterConnection(ctx context.Context, info *agent_tracker.ConnectedAgentInfo, reason agent_tracker.DisconnectReason) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnregisterConnection", ctx, info)
+	ret := m.ctrl.Call(m, "UnregisterConnection", ctx, info, reason)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UnregisterConnection indicates an expected call of UnregisterConnection.
-func (mr *MockTrackerMockRecorder) UnregisterConnection(ctx, info any) *MockTrackerUnregisterConnectionCall {
+func (mr *MockTrackerMockRecorder) UnregisterConnection(ctx, info, reason any) *MockTrackerUnregisterConnectionCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterConnection", reflect.TypeOf((*MockTracker)(nil).UnregisterConnection), ctx, info)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterConnection", reflect.TypeOf((*MockTracker)(nil).UnregisterConnection), ctx, info, reason)
 	return &MockTrackerUnregisterConnectionCall{Call: call}
 }
 
@@ -221,13 +335,13 @@ func (c *MockTrackerUnregisterConnectionCall) Return(arg0 error) *MockTrackerUnr
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockTrackerUnregisterConnectionCall) Do(f func(context.Context, *agent_tracker.ConnectedAgentInfo) error) *MockTrackerUnregisterConnectionCall {
+func (c *MockTrackerUnregisterConnectionCall) Do(f func(context.Context, *agent_tracker.ConnectedAgentInfo, agent_tracker.DisconnectReason) error) *MockTrackerUnregisterConnectionCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockTrackerUnregisterConnectionCall) DoAndReturn(f func(context.Context, *agent_tracker.ConnectedAgentInfo) error) *MockTrackerUnregisterConnectionCall {
+func (c *MockTrackerUnregisterConnectionCall) DoAndReturn(f func(context.Context, *agent_tracker.ConnectedAgentInfo, agent_tracker.DisconnectReason) error) *MockTrackerUnregisterConnectionCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
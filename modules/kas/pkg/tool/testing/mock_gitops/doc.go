@@ -0,0 +1,3 @@
+package mock_gitops
+
+//go:generate mockgen.sh -destination "rpc.go" -package "mock_gitops" "github.com/pluralsh/kubernetes-agent/pkg/module/gitops/rpc" "GitopsClient,GitopsPreviewClient"
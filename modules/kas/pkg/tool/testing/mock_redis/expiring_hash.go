@@ -195,17 +195,17 @@ func (c *MockExpiringHashLenCall[K1, K2]) DoAndReturn(f func(context.Context, K1
 }
 
 // Refresh mocks base method.
-func (m *MockExpiringHash[K1, K2]) Refresh(ctx context.Context, nextRefresh time.Time) error {
+func (m *MockExpiringHash[K1, K2]) Refresh(nextRefresh time.Time) func(context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Refresh", ctx, nextRefresh)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "Refresh", nextRefresh)
+	ret0, _ := ret[0].(func(context.Context) error)
 	return ret0
 }
 
 // Refresh indicates an expected call of Refresh.
-func (mr *MockExpiringHashMockRecorder[K1, K2]) Refresh(ctx, nextRefresh any) *MockExpiringHashRefreshCall[K1, K2] {
+func (mr *MockExpiringHashMockRecorder[K1, K2]) Refresh(nextRefresh any) *MockExpiringHashRefreshCall[K1, K2] {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockExpiringHash[K1, K2])(nil).Refresh), ctx, nextRefresh)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockExpiringHash[K1, K2])(nil).Refresh), nextRefresh)
 	return &MockExpiringHashRefreshCall[K1, K2]{Call: call}
 }
 
@@ -215,19 +215,19 @@ type MockExpiringHashRefreshCall[K1 any, K2 any] struct {
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockExpiringHashRefreshCall[K1, K2]) Return(arg0 error) *MockExpiringHashRefreshCall[K1, K2] {
+func (c *MockExpiringHashRefreshCall[K1, K2]) Return(arg0 func(context.Context) error) *MockExpiringHashRefreshCall[K1, K2] {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockExpiringHashRefreshCall[K1, K2]) Do(f func(context.Context, time.Time) error) *MockExpiringHashRefreshCall[K1, K2] {
+func (c *MockExpiringHashRefreshCall[K1, K2]) Do(f func(time.Time) func(context.Context) error) *MockExpiringHashRefreshCall[K1, K2] {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockExpiringHashRefreshCall[K1, K2]) DoAndReturn(f func(context.Context, time.Time) error) *MockExpiringHashRefreshCall[K1, K2] {
+func (c *MockExpiringHashRefreshCall[K1, K2]) DoAndReturn(f func(time.Time) func(context.Context) error) *MockExpiringHashRefreshCall[K1, K2] {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel (interfaces: Tracker,Handler,FindHandle,Tunnel,PollingQuerier,Finder)
+// Source: github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel (interfaces: Tracker,Handler,FindHandle,Tunnel,PollingQuerier,Finder,Introspector)
 //
 // Generated by this command:
 //
-//	mockgen -typed -destination tunnel.go -package mock_reverse_tunnel_tunnel github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel Tracker,Handler,FindHandle,Tunnel,PollingQuerier,Finder
+//	mockgen -typed -destination tunnel.go -package mock_reverse_tunnel_tunnel github.com/pluralsh/kubernetes-agent/pkg/module/reverse_tunnel/tunnel Tracker,Handler,FindHandle,Tunnel,PollingQuerier,Finder,Introspector
 //
 
 // Package mock_reverse_tunnel_tunnel is a generated GoMock package.
@@ -623,3 +623,142 @@ func (c *MockFinderFindTunnelCall) DoAndReturn(f func(context.Context, int64, st
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockIntrospector is a mock of Introspector interface.
+type MockIntrospector struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntrospectorMockRecorder
+	isgomock struct{}
+}
+
+// MockIntrospectorMockRecorder is the mock recorder for MockIntrospector.
+type MockIntrospectorMockRecorder struct {
+	mock *MockIntrospector
+}
+
+// NewMockIntrospector creates a new mock instance.
+func NewMockIntrospector(ctrl *gomock.Controller) *MockIntrospector {
+	mock := &MockIntrospector{ctrl: ctrl}
+	mock.recorder = &MockIntrospectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIntrospector) EXPECT() *MockIntrospectorMockRecorder {
+	return m.recorder
+}
+
+// AgentTunnels mocks base method.
+func (m *MockIntrospector) AgentTunnels(agentId int64) tunnel.AgentTunnelsInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AgentTunnels", agentId)
+	ret0, _ := ret[0].(tunnel.AgentTunnelsInfo)
+	return ret0
+}
+
+// AgentTunnels indicates an expected call of AgentTunnels.
+func (mr *MockIntrospectorMockRecorder) AgentTunnels(agentId any) *MockIntrospectorAgentTunnelsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgentTunnels", reflect.TypeOf((*MockIntrospector)(nil).AgentTunnels), agentId)
+	return &MockIntrospectorAgentTunnelsCall{Call: call}
+}
+
+// MockIntrospectorAgentTunnelsCall wrap *gomock.Call
+type MockIntrospectorAgentTunnelsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIntrospectorAgentTunnelsCall) Return(arg0 tunnel.AgentTunnelsInfo) *MockIntrospectorAgentTunnelsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIntrospectorAgentTunnelsCall) Do(f func(int64) tunnel.AgentTunnelsInfo) *MockIntrospectorAgentTunnelsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIntrospectorAgentTunnelsCall) DoAndReturn(f func(int64) tunnel.AgentTunnelsInfo) *MockIntrospectorAgentTunnelsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AllAgentTunnels mocks base method.
+func (m *MockIntrospector) AllAgentTunnels() []tunnel.AgentTunnelsInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllAgentTunnels")
+	ret0, _ := ret[0].([]tunnel.AgentTunnelsInfo)
+	return ret0
+}
+
+// AllAgentTunnels indicates an expected call of AllAgentTunnels.
+func (mr *MockIntrospectorMockRecorder) AllAgentTunnels() *MockIntrospectorAllAgentTunnelsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllAgentTunnels", reflect.TypeOf((*MockIntrospector)(nil).AllAgentTunnels))
+	return &MockIntrospectorAllAgentTunnelsCall{Call: call}
+}
+
+// MockIntrospectorAllAgentTunnelsCall wrap *gomock.Call
+type MockIntrospectorAllAgentTunnelsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIntrospectorAllAgentTunnelsCall) Return(arg0 []tunnel.AgentTunnelsInfo) *MockIntrospectorAllAgentTunnelsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIntrospectorAllAgentTunnelsCall) Do(f func() []tunnel.AgentTunnelsInfo) *MockIntrospectorAllAgentTunnelsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIntrospectorAllAgentTunnelsCall) DoAndReturn(f func() []tunnel.AgentTunnelsInfo) *MockIntrospectorAllAgentTunnelsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// KasUrlsByAgentId mocks base method.
+func (m *MockIntrospector) KasUrlsByAgentId(ctx context.Context, agentId int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KasUrlsByAgentId", ctx, agentId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KasUrlsByAgentId indicates an expected call of KasUrlsByAgentId.
+func (mr *MockIntrospectorMockRecorder) KasUrlsByAgentId(ctx, agentId any) *MockIntrospectorKasUrlsByAgentIdCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KasUrlsByAgentId", reflect.TypeOf((*MockIntrospector)(nil).KasUrlsByAgentId), ctx, agentId)
+	return &MockIntrospectorKasUrlsByAgentIdCall{Call: call}
+}
+
+// MockIntrospectorKasUrlsByAgentIdCall wrap *gomock.Call
+type MockIntrospectorKasUrlsByAgentIdCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIntrospectorKasUrlsByAgentIdCall) Return(arg0 []string, arg1 error) *MockIntrospectorKasUrlsByAgentIdCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIntrospectorKasUrlsByAgentIdCall) Do(f func(context.Context, int64) ([]string, error)) *MockIntrospectorKasUrlsByAgentIdCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIntrospectorKasUrlsByAgentIdCall) DoAndReturn(f func(context.Context, int64) ([]string, error)) *MockIntrospectorKasUrlsByAgentIdCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -23,6 +23,20 @@ func LoadCACert(caCertFile string) (*x509.CertPool, error) {
 	return certPool, nil
 }
 
+// LoadCertPool loads certificates from a PEM file into a new pool that doesn't contain system certificates.
+func LoadCertPool(certFile string) (*x509.CertPool, error) {
+	cert, err := os.ReadFile(certFile) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("certificate file: %w", err)
+	}
+	certPool := x509.NewCertPool()
+	ok := certPool.AppendCertsFromPEM(cert)
+	if !ok {
+		return nil, fmt.Errorf("AppendCertsFromPEM(%s) failed", certFile)
+	}
+	return certPool, nil
+}
+
 func DefaultClientTLSConfig() *tls.Config {
 	return &tls.Config{
 		CipherSuites: secureCipherSuites(),